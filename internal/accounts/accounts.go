@@ -21,12 +21,17 @@ package accounts
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
@@ -44,12 +49,21 @@ func init() {
 	CreateCommand.AddToParent(Cmd)
 	StakingCommand.AddToParent(Cmd)
 	GetCommand.AddToParent(Cmd)
+	AddKeyCommand.AddToParent(Cmd)
+	RevokeKeyCommand.AddToParent(Cmd)
+	FundCommand.AddToParent(Cmd)
+	ContractsCommand.AddToParent(Cmd)
+	StorageCommand.AddToParent(Cmd)
+	CreateDefaultsCommand.AddToParent(Cmd)
+	ExportCommand.AddToParent(Cmd)
+	ImportCommand.AddToParent(Cmd)
 }
 
 // AccountResult represent result from all account commands.
 type AccountResult struct {
 	*flow.Account
 	include []string
+	storage *services.AccountStorage // only fetched if the storage is included
 }
 
 func (r *AccountResult) JSON() interface{} {
@@ -57,9 +71,17 @@ func (r *AccountResult) JSON() interface{} {
 	result["address"] = r.Address
 	result["balance"] = cadence.UFix64(r.Balance).String()
 
-	keys := make([]string, 0)
+	keys := make([]map[string]interface{}, 0, len(r.Keys))
 	for _, key := range r.Keys {
-		keys = append(keys, fmt.Sprintf("%x", key.PublicKey.Encode()))
+		keys = append(keys, map[string]interface{}{
+			"index":              key.Index,
+			"publicKey":          fmt.Sprintf("%x", key.PublicKey.Encode()),
+			"weight":             key.Weight,
+			"sequenceNumber":     key.SequenceNumber,
+			"revoked":            key.Revoked,
+			"signatureAlgorithm": key.SigAlgo.String(),
+			"hashAlgorithm":      key.HashAlgo.String(),
+		})
 	}
 
 	result["keys"] = keys
@@ -79,6 +101,14 @@ func (r *AccountResult) JSON() interface{} {
 		result["code"] = c
 	}
 
+	if r.storage != nil {
+		result["storage"] = map[string]interface{}{
+			"used":             r.storage.Used,
+			"capacity":         r.storage.Capacity,
+			"availableBalance": cadence.UFix64(r.storage.AvailableBalance).String(),
+		}
+	}
+
 	return result
 }
 
@@ -89,6 +119,12 @@ func (r *AccountResult) String() string {
 	_, _ = fmt.Fprintf(writer, "Address\t 0x%s\n", r.Address)
 	_, _ = fmt.Fprintf(writer, "Balance\t %s\n", cadence.UFix64(r.Balance))
 
+	if r.storage != nil {
+		_, _ = fmt.Fprintf(writer, "Available Balance\t %s\n", cadence.UFix64(r.storage.AvailableBalance))
+		_, _ = fmt.Fprintf(writer, "Storage Used\t %s\n", formatBytes(r.storage.Used))
+		_, _ = fmt.Fprintf(writer, "Storage Capacity\t %s\n", formatBytes(r.storage.Capacity))
+	}
+
 	_, _ = fmt.Fprintf(writer, "Keys\t %d\n", len(r.Keys))
 
 	for i, key := range r.Keys {
@@ -133,5 +169,92 @@ func (r *AccountResult) Oneliner() string {
 		keys = append(keys, key.PublicKey.String())
 	}
 
+	if r.storage != nil {
+		return fmt.Sprintf(
+			"Address: 0x%s, Balance: %s, Available Balance: %s, Storage Used: %s, Storage Capacity: %s, Public Keys: %s",
+			r.Address,
+			cadence.UFix64(r.Balance),
+			cadence.UFix64(r.storage.AvailableBalance),
+			formatBytes(r.storage.Used),
+			formatBytes(r.storage.Capacity),
+			keys,
+		)
+	}
+
 	return fmt.Sprintf("Address: 0x%s, Balance: %s, Public Keys: %s", r.Address, cadence.UFix64(r.Balance), keys)
 }
+
+// formatBytes formats the storage size with the largest decimal unit smaller than the size.
+func formatBytes(size uint64) string {
+	const unit = 1000
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.2f %cB", float64(size)/float64(div), "kMGTPE"[exp])
+}
+
+// BatchResult represent the accounts created by the create command with the count flag.
+type BatchResult struct {
+	accounts    []*flowkit.Account
+	privateKeys []crypto.PrivateKey // private keys of the accounts
+	count       int                 // number of accounts requested
+	err         error               // error creating some of the accounts
+}
+
+func (r *BatchResult) JSON() interface{} {
+	accounts := make([]map[string]interface{}, 0, len(r.accounts))
+	for i, account := range r.accounts {
+		accounts = append(accounts, map[string]interface{}{
+			"name":       account.Name(),
+			"address":    account.Address().String(),
+			"privateKey": r.privateKeys[i].String(),
+		})
+	}
+
+	result := map[string]interface{}{
+		"accounts": accounts,
+	}
+	if r.err != nil {
+		result["error"] = r.err.Error()
+	}
+
+	return result
+}
+
+func (r *BatchResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Name\tAddress\tPrivate Key\n")
+	for i, account := range r.accounts {
+		_, _ = fmt.Fprintf(writer, "%s\t0x%s\t%s\n", account.Name(), account.Address(), r.privateKeys[i])
+	}
+
+	if r.err != nil {
+		_, _ = fmt.Fprintf(writer, "\n%s Created %d of %d accounts, %s\n", output.ErrorEmoji(), len(r.accounts), r.count, r.err)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *BatchResult) Oneliner() string {
+	names := make([]string, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		names = append(names, fmt.Sprintf("%s:0x%s", account.Name(), account.Address()))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Failed reports if some of the accounts couldn't be created, so the command exits with an error.
+func (r *BatchResult) Failed() bool {
+	return r.err != nil
+}
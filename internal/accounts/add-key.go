@@ -0,0 +1,191 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsAddKey struct {
+	PublicKey string   `default:"" flag:"public-key" info:"Public key to add to the account, a new key is generated and saved to the configuration if not provided"`
+	Weight    int      `default:"1000" flag:"weight" info:"Weight of the added key"`
+	SigAlgo   string   `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the added key"`
+	HashAlgo  string   `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm paired with the added key"`
+	Rotate    bool     `default:"false" flag:"rotate" info:"Generate a new key, add it to the account and revoke the key currently used by the account"`
+	Include   []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+}
+
+var addKeyFlags = flagsAddKey{}
+
+var AddKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "add-key <account>",
+		Short: "Add a key to an account",
+		Example: `flow accounts add-key alice --public-key d651f1931a2...8745 --weight 500
+flow accounts add-key alice --rotate`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &addKeyFlags,
+	RunS:  addKey,
+}
+
+func addKey(
+	args []string,
+	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(addKeyFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", addKeyFlags.SigAlgo)
+	}
+	hashAlgo := crypto.StringToHashAlgorithm(addKeyFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", addKeyFlags.HashAlgo)
+	}
+
+	if addKeyFlags.Rotate {
+		if addKeyFlags.PublicKey != "" {
+			return nil, fmt.Errorf("the key is generated when rotating, the public key flag can't be used")
+		}
+		if addKeyFlags.Weight != flow.AccountKeyWeightThreshold {
+			return nil, fmt.Errorf("the key must have the full weight of %d to replace the revoked key", flow.AccountKeyWeightThreshold)
+		}
+	}
+
+	var privateKey crypto.PrivateKey
+	var publicKey crypto.PublicKey
+	if addKeyFlags.PublicKey != "" {
+		publicKey, err = crypto.DecodePublicKeyHex(sigAlgo, strings.TrimPrefix(addKeyFlags.PublicKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding public key: %w", err)
+		}
+	} else {
+		// a missing weight in the configuration means the full weight, so zero weight keys can't be recorded
+		if addKeyFlags.Weight == 0 {
+			return nil, fmt.Errorf("generated keys with zero weight can't be saved to the configuration")
+		}
+
+		privateKey, err = services.Keys.Generate("", sigAlgo)
+		if err != nil {
+			return nil, err
+		}
+		publicKey = privateKey.PublicKey()
+	}
+
+	added, err := services.Accounts.AddKey(account, &flow.AccountKey{
+		PublicKey: publicKey,
+		SigAlgo:   sigAlgo,
+		HashAlgo:  hashAlgo,
+		Weight:    addKeyFlags.Weight,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// only keys generated by the CLI are saved, the private keys of the provided public keys aren't known
+	if privateKey != nil {
+		key, err := flowkit.NewAccountKey(config.AccountKey{
+			Type:       config.KeyTypeHex,
+			Index:      added.Index,
+			SigAlgo:    sigAlgo,
+			HashAlgo:   hashAlgo,
+			Weight:     added.Weight,
+			PrivateKey: privateKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		account.AddKey(key)
+
+		if addKeyFlags.Rotate {
+			err = rotateKey(account, added.Index, globalFlags, services, state)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		err = state.SaveDefault()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	flowAccount, err := services.Accounts.Get(account.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountResult{
+		Account: flowAccount,
+		include: addKeyFlags.Include,
+	}, nil
+}
+
+// rotateKey revokes the key used by the account after confirmation and makes the new key the signing key.
+func rotateKey(
+	account *flowkit.Account,
+	newIndex int,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) error {
+	oldIndex := account.Key().Index()
+
+	rotated, err := account.ForKeyIndex(newIndex)
+	if err != nil {
+		return err
+	}
+
+	if !globalFlags.Yes && !output.RevokeKeyPrompt(oldIndex, account.Address().String()) {
+		// the new key is still saved, so the rotation can be finished with the revoke-key command
+		state.Accounts().AddOrUpdate(rotated)
+		return nil
+	}
+
+	_, err = services.Accounts.RevokeKey(rotated, oldIndex)
+	if err != nil {
+		return err
+	}
+
+	err = rotated.RevokeKey(oldIndex)
+	if err != nil {
+		return err
+	}
+
+	state.Accounts().AddOrUpdate(rotated)
+	return nil
+}
@@ -33,6 +33,7 @@ type flagsUpdateContract struct {
 	ArgsJSON string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
 	Signer   string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
 	Include  []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	Force    bool     `default:"false" flag:"force" info:"Update the contract even if the update is not compatible with the deployed contract"`
 }
 
 var updateContractFlags = flagsUpdateContract{}
@@ -82,11 +83,11 @@ func updateContract(
 		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
 	}
 
-	_, _, err = srv.Accounts.AddContract(
+	_, err = srv.Accounts.UpdateContract(
 		to,
 		flowkit.NewScript(code, contractArgs, filename),
 		globalFlags.Network,
-		true,
+		updateContractFlags.Force,
 	)
 	if err != nil {
 		return nil, err
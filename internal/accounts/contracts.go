@@ -0,0 +1,224 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsContracts struct {
+	SaveDir  string `default:"" flag:"save-dir" info:"Directory to save the contracts to, each contract is saved to <name>.cdc"`
+	Contract string `default:"" flag:"contract" info:"Name of a single contract to output the code of"`
+}
+
+var contractsFlags = flagsContracts{}
+
+var ContractsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "contracts <address>",
+		Short: "List the contracts deployed on an account and save their code",
+		Example: `flow accounts contracts f8d6e0586b0a20c7
+flow accounts contracts f8d6e0586b0a20c7 --save-dir ./vendored
+flow accounts contracts f8d6e0586b0a20c7 --contract FungibleToken`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &contractsFlags,
+	Run:   contracts,
+}
+
+func contracts(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	address := flow.HexToAddress(args[0])
+
+	account, err := services.Accounts.Get(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if contractsFlags.Contract != "" {
+		code, ok := account.Contracts[contractsFlags.Contract]
+		if !ok {
+			return nil, fmt.Errorf(
+				"contract %s is not deployed on account %s, deployed contracts: %s",
+				contractsFlags.Contract,
+				address,
+				strings.Join(sortedContractNames(account), ", "),
+			)
+		}
+
+		return &ContractCodeResult{name: contractsFlags.Contract, code: code}, nil
+	}
+
+	result := &ContractsResult{address: address}
+	for _, name := range sortedContractNames(account) {
+		result.contracts = append(result.contracts, contractFile{
+			name: name,
+			code: account.Contracts[name],
+		})
+	}
+
+	if contractsFlags.SaveDir != "" {
+		err = saveContracts(readerWriter, contractsFlags.SaveDir, result.contracts)
+		if err != nil {
+			return nil, err
+		}
+		result.saved = true
+	}
+
+	return result, nil
+}
+
+func sortedContractNames(account *flow.Account) []string {
+	names := maps.Keys(account.Contracts)
+	slices.Sort(names)
+	return names
+}
+
+// saveContracts writes the code of each contract to <dir>/<name>.cdc.
+func saveContracts(readerWriter flowkit.ReaderWriter, dir string, contracts []contractFile) error {
+	if mkdir, ok := readerWriter.(interface {
+		MkdirAll(path string, perm os.FileMode) error
+	}); ok {
+		err := mkdir.MkdirAll(dir, 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	for i, contract := range contracts {
+		location := filepath.Join(dir, fmt.Sprintf("%s.cdc", contract.name))
+		err := readerWriter.WriteFile(location, contract.code, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to save contract %s: %w", contract.name, err)
+		}
+		contracts[i].location = location
+	}
+
+	return nil
+}
+
+type contractFile struct {
+	name     string
+	code     []byte
+	location string // only set if the contract was saved
+}
+
+// ContractsResult lists the contracts deployed on an account.
+type ContractsResult struct {
+	address   flow.Address
+	contracts []contractFile
+	saved     bool
+}
+
+func (r *ContractsResult) JSON() interface{} {
+	result := make([]map[string]interface{}, 0, len(r.contracts))
+	for _, contract := range r.contracts {
+		c := map[string]interface{}{
+			"name": contract.name,
+			"size": len(contract.code),
+			"hash": flowkit.ContractHash(contract.code),
+		}
+		if r.saved {
+			c["location"] = contract.location
+		}
+		result = append(result, c)
+	}
+
+	return result
+}
+
+func (r *ContractsResult) String() string {
+	if len(r.contracts) == 0 {
+		return fmt.Sprintf("No contracts deployed on account 0x%s", r.address)
+	}
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if r.saved {
+		_, _ = fmt.Fprintf(writer, "Name\tSize\tHash\tLocation\n")
+		for _, contract := range r.contracts {
+			_, _ = fmt.Fprintf(
+				writer,
+				"%s\t%s\t%s\t%s\n",
+				contract.name,
+				formatBytes(uint64(len(contract.code))),
+				flowkit.ContractHash(contract.code),
+				contract.location,
+			)
+		}
+	} else {
+		_, _ = fmt.Fprintf(writer, "Name\tSize\n")
+		for _, contract := range r.contracts {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\n", contract.name, formatBytes(uint64(len(contract.code))))
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *ContractsResult) Oneliner() string {
+	names := make([]string, 0, len(r.contracts))
+	for _, contract := range r.contracts {
+		names = append(names, contract.name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// ContractCodeResult is the code of a single contract deployed on an account.
+type ContractCodeResult struct {
+	name string
+	code []byte
+}
+
+func (r *ContractCodeResult) JSON() interface{} {
+	return map[string]interface{}{
+		"name": r.name,
+		"code": string(r.code),
+		"hash": flowkit.ContractHash(r.code),
+	}
+}
+
+func (r *ContractCodeResult) String() string {
+	return string(r.code)
+}
+
+func (r *ContractCodeResult) Oneliner() string {
+	return string(r.code)
+}
@@ -0,0 +1,110 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func setup() (afero.Afero, *flowkit.State, *services.Services, *tests.TestGateway) {
+	readerWriter, _ := tests.ReaderWriter()
+	state, err := flowkit.Init(readerWriter, crypto.ECDSA_P256, crypto.SHA3_256)
+	if err != nil {
+		panic(err)
+	}
+
+	gw := tests.DefaultMockGateway()
+	srv := services.NewServices(gw.Mock, state, output.NewStdoutLogger(output.NoneLog))
+
+	return readerWriter, state, srv, gw
+}
+
+func Test_Contracts(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	deployed := func(gw *tests.TestGateway) {
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(address.String())
+			account.Contracts = map[string][]byte{
+				tests.ContractHelloString.Name: tests.ContractHelloString.Source,
+				tests.ContractA.Name:           tests.ContractA.Source,
+			}
+			gw.GetAccount.Return(account, nil)
+		})
+	}
+
+	t.Run("Save Dir", func(t *testing.T) {
+		readerWriter, _, srv, gw := setup()
+		deployed(gw)
+
+		contractsFlags = flagsContracts{SaveDir: "vendored"}
+		defer func() { contractsFlags = flagsContracts{} }()
+
+		result, err := contracts([]string{address.String()}, readerWriter, command.GlobalFlags{}, srv)
+		require.NoError(t, err)
+
+		for _, contract := range []tests.Resource{tests.ContractA, tests.ContractHelloString} {
+			code, err := readerWriter.ReadFile(filepath.Join("vendored", contract.Name+".cdc"))
+			require.NoError(t, err)
+			assert.Equal(t, contract.Source, code)
+		}
+
+		saved := result.JSON().([]map[string]interface{})
+		require.Len(t, saved, 2)
+		assert.Equal(t, tests.ContractA.Name, saved[0]["name"])
+		assert.Equal(t, filepath.Join("vendored", tests.ContractA.Name+".cdc"), saved[0]["location"])
+	})
+
+	t.Run("Single Contract", func(t *testing.T) {
+		readerWriter, _, srv, gw := setup()
+		deployed(gw)
+
+		contractsFlags = flagsContracts{Contract: tests.ContractA.Name}
+		defer func() { contractsFlags = flagsContracts{} }()
+
+		result, err := contracts([]string{address.String()}, readerWriter, command.GlobalFlags{}, srv)
+		require.NoError(t, err)
+		assert.Equal(t, string(tests.ContractA.Source), result.String())
+	})
+
+	t.Run("Missing Contract", func(t *testing.T) {
+		readerWriter, _, srv, gw := setup()
+		deployed(gw)
+
+		contractsFlags = flagsContracts{Contract: "Missing"}
+		defer func() { contractsFlags = flagsContracts{} }()
+
+		_, err := contracts([]string{address.String()}, readerWriter, command.GlobalFlags{}, srv)
+		assert.EqualError(t, err, "contract Missing is not deployed on account 0000000000000001, deployed contracts: ContractA, Hello")
+	})
+}
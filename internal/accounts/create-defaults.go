@@ -0,0 +1,228 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsCreateDefaults struct{}
+
+var createDefaultsFlags = flagsCreateDefaults{}
+
+var CreateDefaultsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "create-defaults",
+		Short:   "Create the default test accounts alice, bob and charlie on the emulator",
+		Example: "flow accounts create-defaults",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &createDefaultsFlags,
+	RunS:  createDefaults,
+}
+
+// defaultAccountNames are the names of the default test accounts, created in this order.
+var defaultAccountNames = []string{"alice", "bob", "charlie"}
+
+// defaultAccountSeed returns the seed of the default account key, derived from the account name.
+func defaultAccountSeed(name string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("flow-cli default account %s", name)))
+	return hex.EncodeToString(hash[:])
+}
+
+func createDefaults(
+	_ []string,
+	loader flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	signer, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := state.Networks().ByName(globalFlags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := util.GetAddressNetwork(signer.Address())
+	if err != nil || chain != flow.Emulator || network.Name != config.DefaultEmulatorNetwork().Name {
+		return nil, fmt.Errorf("the default accounts can only be created on the emulator network")
+	}
+
+	result := &DefaultAccountsResult{}
+	for _, name := range defaultAccountNames {
+		key, err := services.Keys.Generate(defaultAccountSeed(name), crypto.ECDSA_P256)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err := existingDefaultAccount(name, key.PublicKey(), services, state)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			result.accounts = append(result.accounts, defaultAccount{name: name, address: existing.Address()})
+			continue
+		}
+
+		account, err := services.Accounts.Create(
+			signer,
+			[]crypto.PublicKey{key.PublicKey()},
+			[]int{flow.AccountKeyWeightThreshold},
+			[]crypto.SignatureAlgorithm{crypto.ECDSA_P256},
+			[]crypto.HashAlgorithm{crypto.SHA3_256},
+			nil,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		configAccount, err := accountWithKeys(
+			name,
+			account.Address,
+			[]crypto.PrivateKey{key},
+			nil,
+			[]crypto.HashAlgorithm{crypto.SHA3_256},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = saveAccounts(loader, state, []*flowkit.Account{configAccount}, *network)
+		if err != nil {
+			return nil, err
+		}
+
+		result.accounts = append(result.accounts, defaultAccount{name: name, address: account.Address, created: true})
+	}
+
+	return result, nil
+}
+
+// existingDefaultAccount returns the default account if it's already in the configuration and created on the emulator.
+//
+// Accounts in the configuration which don't exist on the emulator, for example after it was restarted, are created again,
+// but accounts with the name using other keys are not default accounts, and they are not replaced.
+func existingDefaultAccount(
+	name string,
+	publicKey crypto.PublicKey,
+	services *services.Services,
+	state *flowkit.State,
+) (*flowkit.Account, error) {
+	var account *flowkit.Account
+	for i, a := range *state.Accounts() {
+		if a.Name() == name {
+			account = &(*state.Accounts())[i]
+		}
+	}
+	if account == nil {
+		return nil, nil
+	}
+
+	notDefault := fmt.Errorf("account %s in the configuration is not a default account, remove it to create the default account", name)
+	if account.Key().Type() != config.KeyTypeHex {
+		return nil, notDefault
+	}
+	privateKey, err := account.Key().PrivateKey()
+	if err != nil || !(*privateKey).PublicKey().Equals(publicKey) {
+		return nil, notDefault
+	}
+
+	onChainAccount, err := services.Accounts.Get(account.Address())
+	if err != nil {
+		return nil, nil // not created on the emulator
+	}
+
+	for _, key := range onChainAccount.Keys {
+		if key.PublicKey.Equals(publicKey) && !key.Revoked {
+			return account, nil
+		}
+	}
+
+	return nil, nil
+}
+
+type defaultAccount struct {
+	name    string
+	address flow.Address
+	created bool // false if the account already existed
+}
+
+// DefaultAccountsResult lists the default accounts and if they were created or already existed.
+type DefaultAccountsResult struct {
+	accounts []defaultAccount
+}
+
+func (r *DefaultAccountsResult) JSON() interface{} {
+	accounts := make([]map[string]interface{}, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		accounts = append(accounts, map[string]interface{}{
+			"name":    account.name,
+			"address": account.address.String(),
+			"created": account.created,
+		})
+	}
+
+	return accounts
+}
+
+func (r *DefaultAccountsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Name\tAddress\tStatus\n")
+	for _, account := range r.accounts {
+		status := "already exists"
+		if account.created {
+			status = "created"
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t0x%s\t%s\n", account.name, account.address, status)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *DefaultAccountsResult) Oneliner() string {
+	var b bytes.Buffer
+	for i, account := range r.accounts {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		_, _ = fmt.Fprintf(&b, "%s:0x%s", account.name, account.address)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,148 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func Test_CreateDefaults(t *testing.T) {
+	globalFlags := command.GlobalFlags{Network: "emulator"}
+
+	// setupEmulator returns the services using an emulator
+	setupEmulator := func(t *testing.T) (flowkit.ReaderWriter, *flowkit.State, *services.Services) {
+		readerWriter, _ := tests.ReaderWriter()
+		state, err := flowkit.Init(readerWriter, crypto.ECDSA_P256, crypto.SHA3_256)
+		require.NoError(t, err)
+
+		service, err := state.EmulatorServiceAccount()
+		require.NoError(t, err)
+
+		srv := services.NewServices(gateway.NewEmulatorGateway(service), state, output.NewStdoutLogger(output.NoneLog))
+		return readerWriter, state, srv
+	}
+
+	defaultKey := func(t *testing.T, srv *services.Services, name string) crypto.PrivateKey {
+		key, err := srv.Keys.Generate(defaultAccountSeed(name), crypto.ECDSA_P256)
+		require.NoError(t, err)
+		return key
+	}
+
+	t.Run("Idempotent", func(t *testing.T) {
+		readerWriter, state, srv := setupEmulator(t)
+
+		result, err := createDefaults(nil, readerWriter, globalFlags, srv, state)
+		require.NoError(t, err)
+
+		created := result.(*DefaultAccountsResult).accounts
+		require.Len(t, created, 3)
+		for i, account := range created {
+			assert.Equal(t, defaultAccountNames[i], account.name)
+			assert.True(t, account.created)
+
+			saved, err := state.Accounts().ByName(account.name)
+			require.NoError(t, err)
+			assert.Equal(t, account.address, saved.Address())
+		}
+
+		// the accounts are only created once
+		result, err = createDefaults(nil, readerWriter, globalFlags, srv, state)
+		require.NoError(t, err)
+
+		existing := result.(*DefaultAccountsResult).accounts
+		require.Len(t, existing, 3)
+		for i, account := range existing {
+			assert.False(t, account.created)
+			assert.Equal(t, created[i].address, account.address)
+		}
+	})
+
+	t.Run("Non Hex Key", func(t *testing.T) {
+		readerWriter, state, srv := setupEmulator(t)
+
+		key, err := flowkit.NewAccountKey(config.AccountKey{
+			Type:     config.KeyTypeFile,
+			Location: "alice.pkey",
+			SigAlgo:  crypto.ECDSA_P256,
+			HashAlgo: crypto.SHA3_256,
+		})
+		require.NoError(t, err)
+		state.Accounts().AddOrUpdate(flowkit.NewAccount("alice").SetAddress(flow.HexToAddress("01cf0e2f2f715450")).SetKey(key))
+
+		_, err = createDefaults(nil, readerWriter, globalFlags, srv, state)
+		assert.EqualError(t, err, "account alice in the configuration is not a default account, remove it to create the default account")
+	})
+
+	t.Run("Mismatched Key", func(t *testing.T) {
+		readerWriter, state, srv := setupEmulator(t)
+
+		account, err := accountWithKeys(
+			"alice",
+			flow.HexToAddress("01cf0e2f2f715450"),
+			[]crypto.PrivateKey{tests.PrivKeys()[0]},
+			nil,
+			[]crypto.HashAlgorithm{crypto.SHA3_256},
+		)
+		require.NoError(t, err)
+		state.Accounts().AddOrUpdate(account)
+
+		_, err = createDefaults(nil, readerWriter, globalFlags, srv, state)
+		assert.EqualError(t, err, "account alice in the configuration is not a default account, remove it to create the default account")
+	})
+
+	t.Run("Missing On Chain", func(t *testing.T) {
+		readerWriter, state, srv := setupEmulator(t)
+
+		// the account is in the configuration, but the emulator was restarted
+		missing := flow.NewAddressGenerator(flow.Emulator).SetIndex(100).Address()
+		account, err := accountWithKeys(
+			"alice",
+			missing,
+			[]crypto.PrivateKey{defaultKey(t, srv, "alice")},
+			nil,
+			[]crypto.HashAlgorithm{crypto.SHA3_256},
+		)
+		require.NoError(t, err)
+		state.Accounts().AddOrUpdate(account)
+
+		result, err := createDefaults(nil, readerWriter, globalFlags, srv, state)
+		require.NoError(t, err)
+
+		alice := result.(*DefaultAccountsResult).accounts[0]
+		assert.True(t, alice.created)
+		assert.NotEqual(t, missing, alice.address)
+
+		saved, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, alice.address, saved.Address())
+	})
+}
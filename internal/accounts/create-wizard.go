@@ -0,0 +1,269 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/prompt"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+// key sources offered by the wizard, in the order they are listed
+const (
+	keyGenerate = iota
+	keyExisting
+	keyBrowser
+)
+
+var (
+	wizardSigAlgos  = []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1}
+	wizardHashAlgos = []crypto.HashAlgorithm{crypto.SHA3_256, crypto.SHA2_256}
+)
+
+// createAnswers are the inputs of the account creation collected by the wizard.
+type createAnswers struct {
+	keySource int
+	publicKey crypto.PublicKey // only set if an existing public key is used
+	sigAlgo   crypto.SignatureAlgorithm
+	hashAlgo  crypto.HashAlgorithm
+	signer    string
+	name      string // empty if the account is not saved to the configuration
+}
+
+// createWizard asks for the inputs of the account creation and for the confirmation of their summary.
+func createWizard(p prompt.Prompter, accounts *flowkit.Accounts, defaultSigner string) (*createAnswers, error) {
+	var answers createAnswers
+	var err error
+
+	answers.keySource, err = p.Select("Choose the key of the account", []string{
+		"Generate a new key pair",
+		"Use an existing public key",
+		"Create a testnet or mainnet account in the browser",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if answers.keySource == keyBrowser {
+		return &answers, nil
+	}
+
+	sigAlgo, err := p.Select("Choose the signature algorithm", algoNames(wizardSigAlgos))
+	if err != nil {
+		return nil, err
+	}
+	answers.sigAlgo = wizardSigAlgos[sigAlgo]
+
+	hashAlgo, err := p.Select("Choose the hash algorithm", algoNames(wizardHashAlgos))
+	if err != nil {
+		return nil, err
+	}
+	answers.hashAlgo = wizardHashAlgos[hashAlgo]
+
+	if answers.keySource == keyExisting {
+		key, err := p.Input("Enter the public key", "", func(s string) error {
+			_, err := crypto.DecodePublicKeyHex(answers.sigAlgo, strings.TrimPrefix(s, "0x"))
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		answers.publicKey, _ = crypto.DecodePublicKeyHex(answers.sigAlgo, strings.TrimPrefix(key, "0x"))
+	}
+
+	signers := make([]string, 0, len(*accounts))
+	for _, account := range *accounts {
+		signers = append(signers, account.Name())
+	}
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("no accounts in the configuration to sign the account creation")
+	}
+	// list the signer from the flag first, so it's chosen by default
+	if i := slices.Index(signers, defaultSigner); i > 0 {
+		signers = append([]string{defaultSigner}, slices.Delete(signers, i, i+1)...)
+	}
+
+	signer, err := p.Select("Choose the account signing the account creation", signers)
+	if err != nil {
+		return nil, err
+	}
+	answers.signer = signers[signer]
+
+	// only accounts with the private key can be saved
+	if answers.keySource == keyGenerate {
+		save, err := p.Confirm("Save the account to flow.json?")
+		if err != nil {
+			return nil, err
+		}
+
+		if save {
+			answers.name, err = p.Input("Enter the account name", "", func(s string) error {
+				if s == "" {
+					return fmt.Errorf("invalid name")
+				}
+				if slices.Contains(signers, s) {
+					return fmt.Errorf("name already exists")
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ok, err := p.Confirm(fmt.Sprintf("%s\nCreate the account?", answers.summary()))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("cancelled account creation")
+	}
+
+	return &answers, nil
+}
+
+// summary lists the actions taken to create the account.
+func (a *createAnswers) summary() string {
+	items := make([]string, 0, 4)
+	if a.keySource == keyGenerate {
+		items = append(items, fmt.Sprintf("Generate a new %s key pair, used with %s.", a.sigAlgo, a.hashAlgo))
+	} else {
+		items = append(items, fmt.Sprintf("Add the public key using %s with %s.", a.sigAlgo, a.hashAlgo))
+	}
+
+	items = append(items, fmt.Sprintf("Create the account signed by %s.", output.Bold(a.signer)))
+
+	if a.name != "" {
+		items = append(items, fmt.Sprintf("Save the account to %s as %s.", output.Bold("flow.json"), output.Bold(a.name)))
+	} else if a.keySource == keyGenerate {
+		items = append(items, "Output the private key, which is not saved.")
+	}
+
+	return fmt.Sprintf("%sThis command will perform the following:\n - %s\n", output.WarningEmoji(), strings.Join(items, "\n - "))
+}
+
+func algoNames[T fmt.Stringer](algos []T) []string {
+	names := make([]string, 0, len(algos))
+	for _, algo := range algos {
+		names = append(names, algo.String())
+	}
+	return names
+}
+
+// createWithWizard creates the account with the inputs collected by the wizard.
+func createWithWizard(
+	p prompt.Prompter,
+	contracts []contractFlag,
+	loader flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	answers, err := createWizard(p, state.Accounts(), createFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	if answers.keySource == keyBrowser {
+		_, err := createInteractive(state, loader)
+		return nil, err
+	}
+
+	// without the private key the new account can't sign the deployments,
+	// so the contracts are deployed by the account creation transaction
+	var creationContracts []string
+	if answers.keySource == keyExisting {
+		creationContracts, contracts = contractArgs(contracts), nil
+	}
+
+	signer, err := state.Accounts().ByName(answers.signer)
+	if err != nil {
+		return nil, err
+	}
+
+	var privateKey crypto.PrivateKey
+	publicKey := answers.publicKey
+	if answers.keySource == keyGenerate {
+		privateKey, err = services.Keys.Generate("", answers.sigAlgo)
+		if err != nil {
+			return nil, err
+		}
+		publicKey = privateKey.PublicKey()
+	}
+
+	account, err := services.Accounts.Create(
+		signer,
+		[]crypto.PublicKey{publicKey},
+		[]int{flow.AccountKeyWeightThreshold},
+		[]crypto.SignatureAlgorithm{answers.sigAlgo},
+		[]crypto.HashAlgorithm{answers.hashAlgo},
+		creationContracts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if answers.name == "" && privateKey != nil {
+		output.NewStdoutLogger(output.InfoLog).Info(fmt.Sprintf(
+			"%s The private key of the account is not saved, store it securely: %s\n",
+			output.WarningEmoji(),
+			privateKey,
+		))
+	}
+
+	if answers.name != "" || len(contracts) > 0 {
+		configAccount, err := accountWithKeys(
+			answers.name,
+			account.Address,
+			[]crypto.PrivateKey{privateKey},
+			nil,
+			[]crypto.HashAlgorithm{answers.hashAlgo},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		account, err = saveAndDeploy(configAccount, contracts, answers.name != "", loader, globalFlags, services, state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if createFlags.Fund {
+		funded, err := services.Faucet.Fund(account.Address, globalFlags.Network)
+		if err != nil {
+			return nil, fmt.Errorf("account %s was created but funding it failed: %w", account.Address, err)
+		}
+		account = funded
+	}
+
+	return &AccountResult{
+		Account: account,
+		include: createFlags.Include,
+	}, nil
+}
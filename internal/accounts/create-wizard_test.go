@@ -0,0 +1,135 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/internal/prompt"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+// answersPrompter answers the prompts in order with the selected indexes and input values.
+type answersPrompter struct {
+	selects []int
+	inputs  []string
+	labels  []string
+}
+
+var _ prompt.Prompter = &answersPrompter{}
+
+func (p *answersPrompter) Select(label string, items []string) (int, error) {
+	p.labels = append(p.labels, label)
+	index := p.selects[0]
+	p.selects = p.selects[1:]
+	return index, nil
+}
+
+func (p *answersPrompter) Input(label string, _ string, validate func(string) error) (string, error) {
+	p.labels = append(p.labels, label)
+	value := p.inputs[0]
+	p.inputs = p.inputs[1:]
+	return value, validate(value)
+}
+
+func (p *answersPrompter) Confirm(label string) (bool, error) {
+	index, err := p.Select(label, []string{"Yes", "No"})
+	return index == 0, err
+}
+
+func Test_CreateWizard(t *testing.T) {
+	accounts := &flowkit.Accounts{*flowkit.NewAccount("alice"), *flowkit.NewAccount("emulator-account")}
+
+	t.Run("Generate key and save", func(t *testing.T) {
+		p := &answersPrompter{
+			selects: []int{keyGenerate, 1, 1, 0, 0, 0}, // secp256k1, SHA2_256, emulator-account, save, confirm
+			inputs:  []string{"bob"},
+		}
+
+		answers, err := createWizard(p, accounts, "emulator-account")
+
+		assert.NoError(t, err)
+		assert.Equal(t, crypto.ECDSA_secp256k1, answers.sigAlgo)
+		assert.Equal(t, crypto.SHA2_256, answers.hashAlgo)
+		assert.Equal(t, "emulator-account", answers.signer)
+		assert.Equal(t, "bob", answers.name)
+		assert.Nil(t, answers.publicKey)
+	})
+
+	t.Run("Existing public key", func(t *testing.T) {
+		p := &answersPrompter{
+			selects: []int{keyExisting, 0, 0, 0, 0}, // P256, SHA3_256, alice, confirm
+			inputs:  []string{"0x5000676131ad3e22d853a3f75a5b5d0db4236d08dd6612e2baad771014b5266a242bccecc3522ff7207ac357dbe4f225c709d9b273ac484fed5d13976a39bdcd"},
+		}
+
+		answers, err := createWizard(p, accounts, "alice")
+
+		assert.NoError(t, err)
+		assert.Equal(t, crypto.ECDSA_P256, answers.publicKey.Algorithm())
+		assert.Equal(t, "alice", answers.signer)
+		assert.Equal(t, "", answers.name)
+		// the account can't be saved without the private key, so it isn't asked
+		assert.NotContains(t, p.labels, "Save the account to flow.json?")
+	})
+
+	t.Run("Default signer listed first", func(t *testing.T) {
+		p := &answersPrompter{
+			selects: []int{keyGenerate, 0, 0, 0, 1, 0}, // P256, SHA3_256, first signer, don't save, confirm
+		}
+
+		answers, err := createWizard(p, accounts, "emulator-account")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "emulator-account", answers.signer)
+	})
+
+	t.Run("Browser", func(t *testing.T) {
+		p := &answersPrompter{selects: []int{keyBrowser}}
+
+		answers, err := createWizard(p, accounts, "emulator-account")
+
+		assert.NoError(t, err)
+		assert.Equal(t, keyBrowser, answers.keySource)
+		assert.Len(t, p.labels, 1)
+	})
+
+	t.Run("Fail name exists", func(t *testing.T) {
+		p := &answersPrompter{
+			selects: []int{keyGenerate, 0, 0, 0, 0},
+			inputs:  []string{"alice"},
+		}
+
+		_, err := createWizard(p, accounts, "emulator-account")
+
+		assert.EqualError(t, err, "name already exists")
+	})
+
+	t.Run("Fail cancelled", func(t *testing.T) {
+		p := &answersPrompter{
+			selects: []int{keyGenerate, 0, 0, 0, 1, 1}, // don't save, don't confirm
+		}
+
+		_, err := createWizard(p, accounts, "emulator-account")
+
+		assert.EqualError(t, err, "cancelled account creation")
+	})
+}
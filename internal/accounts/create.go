@@ -26,33 +26,45 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/prompt"
 	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/project"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
 type flagsCreate struct {
-	Signer    string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
-	Keys      []string `flag:"key" info:"Public keys to attach to account"`
-	Weights   []int    `flag:"key-weight" info:"Weight for the key"`
-	SigAlgo   []string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm used to generate the keys"`
-	HashAlgo  []string `default:"SHA3_256" flag:"hash-algo" info:"Hash used for the digest"`
-	Contracts []string `flag:"contract" info:"Contract to be deployed during account creation. <name:filename>"`
-	Include   []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Signer      string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
+	Keys        []string `flag:"key" info:"Public keys to attach to account"`
+	PrivateKeys []string `flag:"private-key" info:"Private keys of the keys to attach to account, used instead of --key to save the account to the configuration"`
+	Name        string   `default:"" flag:"name" info:"Name of the account saved to the configuration, requires the keys to be provided with --private-key"`
+	Weights     []int    `flag:"key-weight" info:"Weight for each key provided to --key, in the same order"`
+	SigAlgo     []string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm used to generate the keys"`
+	HashAlgo    []string `default:"SHA3_256" flag:"hash-algo" info:"Hash used for the digest"`
+	Contracts   []string `flag:"contract" info:"Contract to be deployed during account creation. <name:filename>"`
+	Include     []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Count       int      `default:"0" flag:"count" info:"Number of accounts to create with generated keys and save to the configuration"`
+	Fund        bool     `default:"false" flag:"fund" info:"Fund the created account using the testnet faucet"`
+	Seed        string   `default:"" flag:"seed" info:"Seed of the generated account key, used to create deterministic accounts on the emulator"`
 }
 
 var createFlags = flagsCreate{}
 
 var CreateCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "create",
-		Short:   "Create a new account on network",
-		Example: `flow accounts create --key d651f1931a2...8745`,
+		Use:   "create",
+		Short: "Create a new account on network",
+		Example: `flow accounts create --key d651f1931a2...8745
+flow accounts create --key d651f1931a2...8745 --key-weight 500 --key 5a6a8e3c2b9...12df --key-weight 500
+flow accounts create --private-key 2b4c0ef3a1d...a14f --name alice
+flow accounts create --count 5
+flow accounts create --seed "my test account seed of at least 32 bytes" --name alice`,
 	},
 	Flags: &createFlags,
 	RunS:  create,
@@ -61,17 +73,43 @@ var CreateCommand = &command.Command{
 func create(
 	_ []string,
 	loader flowkit.ReaderWriter,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	services *services.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
-	// if user doesn't provide any flags go into interactive mode
-	if len(createFlags.Keys) == 0 {
-		_, err := createInteractive(state, loader)
+	if createFlags.Count != 0 {
+		if createFlags.Fund {
+			return nil, fmt.Errorf("the fund flag can't be combined with the count flag, fund the accounts with the fund command")
+		}
+		return createBatch(loader, globalFlags, services, state)
+	}
+
+	if len(createFlags.Keys) > 0 && len(createFlags.PrivateKeys) > 0 {
+		return nil, fmt.Errorf("keys can be provided either with the key or the private key flag, not both")
+	}
+	if createFlags.Seed != "" && (len(createFlags.Keys) > 0 || len(createFlags.PrivateKeys) > 0) {
+		return nil, fmt.Errorf("the seed flag generates the key of the account, so it can't be combined with the key and private key flags")
+	}
+	if createFlags.Name != "" && len(createFlags.PrivateKeys) == 0 && createFlags.Seed == "" {
+		return nil, fmt.Errorf("the account can only be saved to the configuration if the keys are provided with the private key flag")
+	}
+
+	contracts, err := parseContractFlags(createFlags.Contracts)
+	if err != nil {
+		return nil, err
+	}
+	err = checkContractNames(loader, contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	// if user doesn't provide any keys ask for the inputs, unless not running in a terminal
+	if len(createFlags.Keys) == 0 && len(createFlags.PrivateKeys) == 0 && createFlags.Seed == "" {
+		p, err := prompt.New()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("provide the keys of the account with the key or private key flag, %w", err)
 		}
-		return nil, nil
+		return createWithWizard(p, contracts, loader, globalFlags, services, state)
 	}
 
 	signer, err := state.Accounts().ByName(createFlags.Signer)
@@ -79,18 +117,31 @@ func create(
 		return nil, err
 	}
 
+	if createFlags.Seed != "" {
+		// keys generated from known seeds are not secure, so they are only used for testing
+		chain, err := util.GetAddressNetwork(signer.Address())
+		if err != nil || chain != flow.Emulator {
+			return nil, fmt.Errorf("the seed flag can only be used on the emulator network, keys generated from seeds are only meant for testing")
+		}
+	}
+
+	keyCount := len(createFlags.Keys) + len(createFlags.PrivateKeys)
+	if createFlags.Seed != "" {
+		keyCount = 1
+	}
+
 	if len(createFlags.SigAlgo) == 1 && len(createFlags.HashAlgo) == 1 {
 		// Fill up depending on size of key input
-		if len(createFlags.Keys) > 1 {
-			for i := 1; i < len(createFlags.Keys); i++ {
+		if keyCount > 1 {
+			for i := 1; i < keyCount; i++ {
 				createFlags.SigAlgo = append(createFlags.SigAlgo, createFlags.SigAlgo[0])
 				createFlags.HashAlgo = append(createFlags.HashAlgo, createFlags.HashAlgo[0])
 			}
 			// Deprecated usage message?
 		}
 
-	} else if len(createFlags.Keys) != len(createFlags.SigAlgo) || len(createFlags.SigAlgo) != len(createFlags.HashAlgo) { // double check matching array lengths on inputs
-		return nil, fmt.Errorf("must provide a signature and hash algorithm for every key provided to --key: %d keys, %d signature algo, %d hash algo", len(createFlags.Keys), len(createFlags.SigAlgo), len(createFlags.HashAlgo))
+	} else if keyCount != len(createFlags.SigAlgo) || len(createFlags.SigAlgo) != len(createFlags.HashAlgo) { // double check matching array lengths on inputs
+		return nil, fmt.Errorf("must provide a signature and hash algorithm for every key provided to --key: %d keys, %d signature algo, %d hash algo", keyCount, len(createFlags.SigAlgo), len(createFlags.HashAlgo))
 	}
 
 	// read all signature algorithms
@@ -127,25 +178,349 @@ func create(
 		pubKeys = append(pubKeys, key)
 	}
 
+	// decode private keys, the public keys are derived from them
+	privateKeys := make([]crypto.PrivateKey, 0, len(createFlags.PrivateKeys))
+	for i, k := range createFlags.PrivateKeys {
+		k = strings.TrimPrefix(k, "0x") // clear possible prefix
+		key, err := crypto.DecodePrivateKeyHex(sigAlgos[i], k)
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding private key at position %d: %w", i, err)
+		}
+		privateKeys = append(privateKeys, key)
+		pubKeys = append(pubKeys, key.PublicKey())
+	}
+
+	// generate the key from the seed, the same seed always generates the same key
+	if createFlags.Seed != "" {
+		key, err := services.Keys.Generate(createFlags.Seed, sigAlgos[0])
+		if err != nil {
+			return nil, err
+		}
+		privateKeys = append(privateKeys, key)
+		pubKeys = append(pubKeys, key.PublicKey())
+	}
+
+	if createFlags.Name != "" {
+		if _, err := state.Accounts().ByName(createFlags.Name); err == nil {
+			return nil, fmt.Errorf("account with name %s already exists in the configuration", createFlags.Name)
+		}
+		// a missing weight in the configuration means the full weight, so zero weight keys can't be recorded
+		if slices.Contains(keyWeights, 0) {
+			return nil, fmt.Errorf("keys with zero weight can't be saved to the configuration")
+		}
+	}
+
+	// without the private keys the new account can't sign the deployments,
+	// so the contracts are deployed by the account creation transaction
+	var creationContracts []string
+	if len(createFlags.Keys) > 0 {
+		creationContracts, contracts = contractArgs(contracts), nil
+	}
+
 	account, err := services.Accounts.Create(
 		signer,
 		pubKeys,
 		keyWeights,
 		sigAlgos,
 		hashAlgos,
-		createFlags.Contracts,
+		creationContracts,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if createFlags.Name != "" || len(contracts) > 0 {
+		configAccount, err := accountWithKeys(createFlags.Name, account.Address, privateKeys, keyWeights, hashAlgos)
+		if err != nil {
+			return nil, err
+		}
+
+		account, err = saveAndDeploy(configAccount, contracts, createFlags.Name != "", loader, globalFlags, services, state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if createFlags.Fund {
+		funded, err := services.Faucet.Fund(account.Address, globalFlags.Network)
+		if err != nil {
+			return nil, fmt.Errorf("account %s was created but funding it failed: %w", account.Address, err)
+		}
+		account = funded
+	}
+
 	return &AccountResult{
 		Account: account,
 		include: createFlags.Include,
 	}, nil
 }
 
+// contractFlag is a contract to deploy to the created account, provided as name:location.
+type contractFlag struct {
+	name     string
+	location string
+}
+
+func parseContractFlags(contracts []string) ([]contractFlag, error) {
+	parsed := make([]contractFlag, 0, len(contracts))
+	for _, contract := range contracts {
+		parts := strings.SplitN(contract, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("wrong format for contract. Correct format is name:path, but got: %s", contract)
+		}
+		parsed = append(parsed, contractFlag{name: parts[0], location: parts[1]})
+	}
+
+	return parsed, nil
+}
+
+// contractArgs formats the contracts as the name:path arguments of the account creation.
+func contractArgs(contracts []contractFlag) []string {
+	args := make([]string, len(contracts))
+	for i, contract := range contracts {
+		args[i] = fmt.Sprintf("%s:%s", contract.name, contract.location)
+	}
+
+	return args
+}
+
+// checkContractNames checks the name of each contract is the name of the contract declared in its file.
+func checkContractNames(loader flowkit.ReaderWriter, contracts []contractFlag) error {
+	for _, contract := range contracts {
+		code, err := loader.ReadFile(contract.location)
+		if err != nil {
+			return fmt.Errorf("failed to read contract %s: %w", contract.name, err)
+		}
+
+		program, err := project.NewProgram(flowkit.NewScript(code, nil, contract.location))
+		if err != nil {
+			return err
+		}
+
+		name, err := program.Name()
+		if err != nil {
+			return fmt.Errorf("failed to read the name of contract %s: %w", contract.name, err)
+		}
+		if name != contract.name {
+			return fmt.Errorf("contract %s in %s doesn't match the contract %s declared in the file", contract.name, contract.location, name)
+		}
+	}
+
+	return nil
+}
+
+// saveAndDeploy saves the created account to the configuration if requested and deploys the contracts to it,
+// and returns the account with the deployed contracts.
+//
+// The account is saved before deploying the contracts, so its keys are kept even if the deployment fails.
+// The contracts are deployed after the account is created like with the deploy command, so the imports are
+// resolved with the contracts and aliases of the network, and the saved account is added to the deployments.
+func saveAndDeploy(
+	account *flowkit.Account,
+	contracts []contractFlag,
+	save bool,
+	loader flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (*flow.Account, error) {
+	network, err := state.Networks().ByName(globalFlags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	if save {
+		err = saveAccounts(loader, state, []*flowkit.Account{account}, *network)
+		if err != nil {
+			return nil, fmt.Errorf("account %s was created but saving it failed: %w", account.Address(), err)
+		}
+	}
+
+	deployments := make([]config.ContractDeployment, 0, len(contracts))
+	for _, contract := range contracts {
+		code, err := loader.ReadFile(contract.location)
+		if err != nil {
+			return nil, fmt.Errorf("account %s was created but reading contract %s failed: %w", account.Address(), contract.name, err)
+		}
+
+		_, _, err = services.Accounts.AddContract(
+			account,
+			flowkit.NewScript(code, nil, contract.location),
+			network.Name,
+			false,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("account %s was created but deploying contract %s failed: %w", account.Address(), contract.name, err)
+		}
+
+		deployments = append(deployments, config.ContractDeployment{Name: contract.name})
+	}
+
+	if save && len(contracts) > 0 {
+		for _, contract := range contracts {
+			if _, err := state.Contracts().ByName(contract.name); err != nil {
+				state.Contracts().AddOrUpdate(contract.name, config.Contract{
+					Name:     contract.name,
+					Location: contract.location,
+				})
+			}
+		}
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   network.Name,
+			Account:   account.Name(),
+			Contracts: deployments,
+		})
+
+		err = state.SaveDefault()
+		if err != nil {
+			return nil, fmt.Errorf("account %s was created but saving the deployments failed: %w", account.Address(), err)
+		}
+	}
+
+	return services.Accounts.Get(account.Address())
+}
+
+// accountWithKeys returns the account with all the private keys, in the order they were added to the account.
+func accountWithKeys(
+	name string,
+	address flow.Address,
+	privateKeys []crypto.PrivateKey,
+	weights []int,
+	hashAlgos []crypto.HashAlgorithm,
+) (*flowkit.Account, error) {
+	account := flowkit.NewAccount(name).SetAddress(address)
+	for i, privateKey := range privateKeys {
+		weight := flow.AccountKeyWeightThreshold
+		if len(weights) > i {
+			weight = weights[i]
+		}
+
+		key, err := flowkit.NewAccountKey(config.AccountKey{
+			Type:       config.KeyTypeHex,
+			Index:      i,
+			SigAlgo:    privateKey.Algorithm(),
+			HashAlgo:   hashAlgos[i],
+			Weight:     weight,
+			PrivateKey: privateKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			account.SetKey(key)
+		} else {
+			account.AddKey(key)
+		}
+	}
+
+	return account, nil
+}
+
+// createBatch creates the number of accounts specified by the count flag with generated keys
+// and saves them to the configuration with generated names.
+func createBatch(
+	loader flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if createFlags.Count < 0 {
+		return nil, fmt.Errorf("count must be a positive number, got %d", createFlags.Count)
+	}
+	if len(createFlags.Keys) > 0 || len(createFlags.Contracts) > 0 {
+		return nil, fmt.Errorf("the count flag can't be combined with the key and contract flags, the keys are generated")
+	}
+	if len(createFlags.SigAlgo) != 1 || len(createFlags.HashAlgo) != 1 {
+		return nil, fmt.Errorf("only a single signature and hash algorithm can be used with the count flag")
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(createFlags.SigAlgo[0])
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", createFlags.SigAlgo[0])
+	}
+	hashAlgo := crypto.StringToHashAlgorithm(createFlags.HashAlgo[0])
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", createFlags.HashAlgo[0])
+	}
+
+	network, err := state.Networks().ByName(globalFlags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := state.Accounts().ByName(createFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKeys := make([]crypto.PrivateKey, 0, createFlags.Count)
+	pubKeys := make([]crypto.PublicKey, 0, createFlags.Count)
+	for i := 0; i < createFlags.Count; i++ {
+		key, err := services.Keys.Generate("", sigAlgo)
+		if err != nil {
+			return nil, err
+		}
+		privateKeys = append(privateKeys, key)
+		pubKeys = append(pubKeys, key.PublicKey())
+	}
+
+	onChainAccounts, createErr := services.Accounts.CreateBatch(signer, pubKeys, sigAlgo, hashAlgo)
+	if onChainAccounts == nil {
+		return nil, createErr
+	}
+
+	names := accountNames(state.Accounts(), "test")
+	accounts := make([]*flowkit.Account, 0, len(onChainAccounts))
+	createdKeys := make([]crypto.PrivateKey, 0, len(onChainAccounts))
+	for i, onChainAccount := range onChainAccounts {
+		if onChainAccount == nil {
+			continue
+		}
+
+		account, err := flowkit.NewAccountFromOnChainAccount(names(), onChainAccount, privateKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+		createdKeys = append(createdKeys, privateKeys[i])
+	}
+
+	if len(accounts) > 0 {
+		err = saveAccounts(loader, state, accounts, *network)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &BatchResult{
+		accounts:    accounts,
+		privateKeys: createdKeys,
+		count:       createFlags.Count,
+		err:         createErr,
+	}, nil
+}
+
+// accountNames returns a generator of account names with the prefix followed by
+// an increasing number, skipping the names already used by the accounts.
+func accountNames(accounts *flowkit.Accounts, prefix string) func() string {
+	n := 0
+	return func() string {
+		for {
+			n++
+			name := fmt.Sprintf("%s-%d", prefix, n)
+			used := slices.IndexFunc(*accounts, func(account flowkit.Account) bool {
+				return account.Name() == name
+			})
+			if used < 0 {
+				return name
+			}
+		}
+	}
+}
+
 func createInteractive(state *flowkit.State, loader flowkit.ReaderWriter) (*flow.Account, error) {
 	log := output.NewStdoutLogger(output.InfoLog)
 
@@ -266,7 +641,7 @@ func createInteractive(state *flowkit.State, loader flowkit.ReaderWriter) (*flow
 		output.Bold(name)),
 	)
 
-	err = saveAccount(loader, state, account, selectedNetwork)
+	err = saveAccounts(loader, state, []*flowkit.Account{account}, selectedNetwork)
 	if err != nil {
 		return nil, err
 	}
@@ -326,21 +701,23 @@ func getAccountCreatedAddressWithPubKey(
 	return address, nil
 }
 
-func saveAccount(
+func saveAccounts(
 	loader flowkit.ReaderWriter,
 	state *flowkit.State,
-	account *flowkit.Account,
+	accounts []*flowkit.Account,
 	network config.Network,
 ) error {
-	state.Accounts().AddOrUpdate(account)
-
-	// If not using emulator, save account private key private file for security.
-	if network != config.DefaultEmulatorNetwork() {
-		privateLocation := fmt.Sprintf("%s.private.json", account.Name())
-		state.SetAccountFileLocation(*account, privateLocation)
-		err := util.AddToGitIgnore(privateLocation, loader)
-		if err != nil {
-			return err
+	for _, account := range accounts {
+		state.Accounts().AddOrUpdate(account)
+
+		// If not using emulator, save account private key private file for security.
+		if network.Host != config.DefaultEmulatorNetwork().Host {
+			privateLocation := fmt.Sprintf("%s.private.json", account.Name())
+			state.SetAccountFileLocation(*account, privateLocation)
+			err := util.AddToGitIgnore(privateLocation, loader)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
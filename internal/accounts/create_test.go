@@ -0,0 +1,189 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func Test_Create(t *testing.T) {
+	newAddress := flow.HexToAddress("192440c99cb17282")
+	globalFlags := command.GlobalFlags{Network: "emulator"}
+	contract := fmt.Sprintf("%s:%s", tests.ContractHelloString.Name, tests.ContractHelloString.Filename)
+
+	// setupCreate mocks the account creation and returns the sent transactions
+	setupCreate := func(gw *tests.TestGateway) *[]*flow.Transaction {
+		sent := make([]*flow.Transaction, 0)
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			sent = append(sent, tx.FlowTransaction())
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			gw.GetTransactionResult.Return(tests.NewAccountCreateResult(newAddress), nil)
+		})
+		return &sent
+	}
+
+	// creationContracts returns the contracts deployed by the account creation transaction
+	creationContracts := func(t *testing.T, tx *flow.Transaction) []string {
+		value, err := jsoncdc.Decode(nil, tx.Arguments[1])
+		require.NoError(t, err)
+
+		names := make([]string, 0)
+		for _, pair := range value.(cadence.Dictionary).Pairs {
+			names = append(names, string(pair.Key.(cadence.String)))
+		}
+		return names
+	}
+
+	withFlags := func(flags flagsCreate) func() {
+		flags.Signer = "emulator-account"
+		flags.SigAlgo = []string{"ECDSA_P256"}
+		flags.HashAlgo = []string{"SHA3_256"}
+		createFlags = flags
+		return func() { createFlags = flagsCreate{} }
+	}
+
+	t.Run("Public Keys With Contract", func(t *testing.T) {
+		readerWriter, state, srv, gw := setup()
+		sent := setupCreate(gw)
+
+		defer withFlags(flagsCreate{
+			Keys:      []string{tests.PubKeys()[0].String()},
+			Contracts: []string{contract},
+		})()
+
+		result, err := create(nil, readerWriter, globalFlags, srv, state)
+		require.NoError(t, err)
+		assert.Equal(t, newAddress, result.(*AccountResult).Account.Address)
+
+		// the contract is deployed by the account creation transaction
+		require.Len(t, *sent, 1)
+		assert.Equal(t, []string{tests.ContractHelloString.Name}, creationContracts(t, (*sent)[0]))
+	})
+
+	t.Run("Private Keys With Contract", func(t *testing.T) {
+		readerWriter, state, srv, gw := setup()
+		sent := setupCreate(gw)
+
+		defer withFlags(flagsCreate{
+			PrivateKeys: []string{tests.PrivKeys()[0].String()},
+			Contracts:   []string{contract},
+		})()
+
+		_, err := create(nil, readerWriter, globalFlags, srv, state)
+		require.NoError(t, err)
+
+		// the contract is deployed by the new account once it is created
+		require.Len(t, *sent, 2)
+		assert.Empty(t, creationContracts(t, (*sent)[0]))
+		assert.Contains(t, string((*sent)[1].Script), "signer.contracts.add")
+		assert.Equal(t, newAddress, (*sent)[1].Authorizers[0])
+
+		// the account is not saved without a name
+		assert.Empty(t, state.Deployments().ByNetwork("emulator"))
+	})
+
+	t.Run("Save With Contract", func(t *testing.T) {
+		readerWriter, state, srv, gw := setup()
+		setupCreate(gw)
+
+		defer withFlags(flagsCreate{
+			PrivateKeys: []string{tests.PrivKeys()[0].String()},
+			Name:        "alice",
+			Contracts:   []string{contract},
+		})()
+
+		_, err := create(nil, readerWriter, globalFlags, srv, state)
+		require.NoError(t, err)
+
+		account, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, newAddress, account.Address())
+
+		saved, err := state.Contracts().ByName(tests.ContractHelloString.Name)
+		require.NoError(t, err)
+		assert.Equal(t, tests.ContractHelloString.Filename, saved.Location)
+
+		deployments := state.Deployments().ByAccountAndNetwork("alice", "emulator")
+		require.Len(t, deployments, 1)
+		assert.Equal(t, tests.ContractHelloString.Name, deployments[0].Contracts[0].Name)
+
+		// the configuration is written
+		config, err := readerWriter.ReadFile("flow.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(config), `"alice"`)
+		assert.Contains(t, string(config), tests.ContractHelloString.Filename)
+	})
+
+	t.Run("Deployment Fails", func(t *testing.T) {
+		readerWriter, state, srv, gw := setup()
+		setupCreate(gw)
+
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			if strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.add") {
+				gw.SendSignedTransaction.Return(nil, fmt.Errorf("failed"))
+				return
+			}
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		defer withFlags(flagsCreate{
+			PrivateKeys: []string{tests.PrivKeys()[0].String()},
+			Name:        "alice",
+			Contracts:   []string{contract},
+		})()
+
+		_, err := create(nil, readerWriter, globalFlags, srv, state)
+		require.Error(t, err)
+		// the created account is reported and saved, so it isn't lost
+		assert.Contains(t, err.Error(), fmt.Sprintf("account %s was created but deploying contract Hello failed", newAddress))
+		_, err = state.Accounts().ByName("alice")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Contract Name Mismatch", func(t *testing.T) {
+		readerWriter, state, srv, gw := setup()
+		setupCreate(gw)
+
+		defer withFlags(flagsCreate{
+			Keys:      []string{tests.PubKeys()[0].String()},
+			Contracts: []string{fmt.Sprintf("Foo:%s", tests.ContractHelloString.Filename)},
+		})()
+
+		_, err := create(nil, readerWriter, globalFlags, srv, state)
+		assert.EqualError(t, err, "contract Foo in contractHello.cdc doesn't match the contract Hello declared in the file")
+		gw.Mock.AssertNotCalled(t, tests.SendSignedTransactionFunc, mock.Anything)
+	})
+}
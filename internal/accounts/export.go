@@ -0,0 +1,130 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsExport struct {
+	IncludePrivateKey bool   `default:"false" flag:"include-private-key" info:"Confirm the export includes the private key of the account"`
+	PEM               string `default:"" flag:"pem" info:"File to save the private key to in the PEM format"`
+}
+
+var exportFlags = flagsExport{}
+
+var ExportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export an account with its private key to a standalone JSON document",
+		Example: `flow accounts export alice --include-private-key --save alice.json
+flow accounts export alice --include-private-key --pem alice.pem`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &exportFlags,
+	RunS:  export,
+}
+
+// accountExport is the standalone document of an exported account, read by the import command.
+type accountExport struct {
+	Name               string `json:"name"`
+	Address            string `json:"address"`
+	KeyIndex           int    `json:"keyIndex"`
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+	HashAlgorithm      string `json:"hashAlgorithm"`
+	PrivateKey         string `json:"privateKey"`
+}
+
+func export(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if !exportFlags.IncludePrivateKey {
+		return nil, fmt.Errorf("the exported account includes its private key, confirm it with the include-private-key flag")
+	}
+
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	key := account.Key()
+	privateKey, err := key.PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export account %s, only accounts with a private key in the configuration can be exported: %w", account.Name(), err)
+	}
+
+	logger := output.NewStdoutLogger(output.InfoLog)
+	logger.Info(output.Red(fmt.Sprintf(
+		"%s The export includes the private key of the account %s, anyone with the key controls the account, keep it secret.",
+		output.WarningEmoji(),
+		account.Name(),
+	)))
+
+	if exportFlags.PEM != "" {
+		encoded, err := flowkit.EncodePrivateKeyPEM(*privateKey, flowkit.FormatSEC1)
+		if err != nil {
+			return nil, err
+		}
+
+		err = readerWriter.WriteFile(exportFlags.PEM, encoded, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save the private key: %w", err)
+		}
+		logger.Info(fmt.Sprintf("%s Private key saved to %s", output.SaveEmoji(), exportFlags.PEM))
+	}
+
+	return &ExportResult{accountExport{
+		Name:               account.Name(),
+		Address:            account.Address().String(),
+		KeyIndex:           key.Index(),
+		SignatureAlgorithm: key.SigAlgo().String(),
+		HashAlgorithm:      key.HashAlgo().String(),
+		PrivateKey:         (*privateKey).String(),
+	}}, nil
+}
+
+// ExportResult is the exported account, the text output is the JSON document so it can be saved and imported.
+type ExportResult struct {
+	account accountExport
+}
+
+func (r *ExportResult) JSON() interface{} {
+	return r.account
+}
+
+func (r *ExportResult) String() string {
+	b, _ := json.MarshalIndent(r.account, "", "  ")
+	return string(b)
+}
+
+func (r *ExportResult) Oneliner() string {
+	return fmt.Sprintf("Name: %s, Address: 0x%s", r.account.Name, r.account.Address)
+}
@@ -0,0 +1,71 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsFund struct {
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+}
+
+var fundFlags = flagsFund{}
+
+var FundCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "fund <address>",
+		Short:   "Fund an account on testnet using the testnet faucet",
+		Example: `flow accounts fund 9a0766d93b6608b7 --network testnet`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &fundFlags,
+	RunS:  fund,
+}
+
+func fund(
+	args []string,
+	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	_ *flowkit.State,
+) (command.Result, error) {
+	address := flow.HexToAddress(strings.TrimPrefix(args[0], "0x"))
+	if address == flow.EmptyAddress {
+		return nil, fmt.Errorf("invalid address: %s", args[0])
+	}
+
+	account, err := services.Faucet.Fund(address, globalFlags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountResult{
+		Account: account,
+		include: fundFlags.Include,
+	}, nil
+}
@@ -19,6 +19,10 @@
 package accounts
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
@@ -28,17 +32,24 @@ import (
 )
 
 type flagsGet struct {
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	Include   []string      `default:"" flag:"include" info:"Fields to include in the output. Valid values: keys, contracts, storage."`
+	Watch     bool          `default:"false" flag:"watch" info:"Watch the account and print the changes of the balance, contracts and keys"`
+	Interval  time.Duration `flag:"interval" info:"Interval of polling the account when watching it, for example 3s"`
+	JSONLines bool          `default:"false" flag:"json-lines" info:"Print the changes of the watched account as JSON lines"`
 }
 
+// includeSections are the sections of the account which can be included in the output.
+var includeSections = []string{"keys", "contracts", "storage"}
+
 var getFlags = flagsGet{}
 
 var GetCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <address>",
-		Short:   "Gets an account by address",
-		Example: "flow accounts get f8d6e0586b0a20c7",
-		Args:    cobra.ExactArgs(1),
+		Use:   "get <address>",
+		Short: "Gets an account by address",
+		Example: `flow accounts get f8d6e0586b0a20c7 --include keys,storage
+flow accounts get f8d6e0586b0a20c7 --watch --interval 3s`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &getFlags,
 	Run:   get,
@@ -50,15 +61,38 @@ func get(
 	_ command.GlobalFlags,
 	services *services.Services,
 ) (command.Result, error) {
+	for _, include := range getFlags.Include {
+		if !command.ContainsFlag(includeSections, strings.ToLower(include)) {
+			return nil, fmt.Errorf("invalid include value %s, valid values are: %s", include, strings.Join(includeSections, ", "))
+		}
+	}
+
 	address := flow.HexToAddress(args[0])
 
+	if getFlags.Watch {
+		return nil, watchAccount(address, services, getFlags.Interval, getFlags.JSONLines)
+	}
+	if getFlags.Interval != 0 || getFlags.JSONLines {
+		return nil, fmt.Errorf("the interval and json-lines flags can only be used with the watch flag")
+	}
+
 	account, err := services.Accounts.Get(address)
 	if err != nil {
 		return nil, err
 	}
 
-	return &AccountResult{
+	result := &AccountResult{
 		Account: account,
 		include: getFlags.Include,
-	}, nil
+	}
+
+	// the storage is read with a script, so it is only fetched when requested
+	if command.ContainsFlag(getFlags.Include, "storage") {
+		result.storage, err = services.Accounts.Storage(address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
 }
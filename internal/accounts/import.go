@@ -0,0 +1,171 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsImport struct {
+	Name string `default:"" flag:"name" info:"Name of the imported account, defaults to the name in the exported file"`
+}
+
+var importFlags = flagsImport{}
+
+var ImportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import an account exported with the export command to the configuration",
+		Example: `flow accounts import alice.json
+flow accounts import alice.json --name alice-testnet`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &importFlags,
+	RunS:  importAccount,
+}
+
+func importAccount(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	data, err := readerWriter.ReadFile(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the exported account: %w", err)
+	}
+
+	var exported accountExport
+	err = json.Unmarshal(data, &exported)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the exported account: %w", err)
+	}
+
+	name := exported.Name
+	if importFlags.Name != "" {
+		name = importFlags.Name
+	}
+	if name == "" {
+		return nil, fmt.Errorf("the exported account has no name, provide it with the name flag")
+	}
+
+	address := flow.HexToAddress(exported.Address)
+	for _, account := range *state.Accounts() {
+		if account.Name() == name {
+			return nil, fmt.Errorf("account with name %s already exists in the configuration, use the name flag to import it with another name", name)
+		}
+		if account.Address() == address && importFlags.Name == "" {
+			return nil, fmt.Errorf(
+				"account with address 0x%s already exists in the configuration as %s, use the name flag to import it with another name",
+				address,
+				account.Name(),
+			)
+		}
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(exported.SignatureAlgorithm)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", exported.SignatureAlgorithm)
+	}
+	hashAlgo := crypto.StringToHashAlgorithm(exported.HashAlgorithm)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", exported.HashAlgorithm)
+	}
+
+	privateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(exported.PrivateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the private key: %w", err)
+	}
+
+	// the key must be one of the keys of the account on the network
+	onChainAccount, err := services.Accounts.Get(address)
+	if err != nil {
+		return nil, err
+	}
+
+	accountKey, err := matchingAccountKey(onChainAccount, privateKey.PublicKey(), exported.KeyIndex)
+	if err != nil {
+		return nil, err
+	}
+	if accountKey.HashAlgo != hashAlgo {
+		return nil, fmt.Errorf(
+			"the key %d of account 0x%s uses the hash algorithm %s, not %s",
+			accountKey.Index,
+			address,
+			accountKey.HashAlgo,
+			hashAlgo,
+		)
+	}
+
+	key, err := flowkit.NewAccountKey(config.AccountKey{
+		Type:       config.KeyTypeHex,
+		Index:      accountKey.Index,
+		SigAlgo:    sigAlgo,
+		HashAlgo:   hashAlgo,
+		PrivateKey: privateKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	account := flowkit.NewAccount(name).SetAddress(address).SetKey(key)
+
+	network, err := state.Networks().ByName(globalFlags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	err = saveAccounts(readerWriter, state, []*flowkit.Account{account}, *network)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountResult{Account: onChainAccount}, nil
+}
+
+// matchingAccountKey returns the key of the account with the public key, preferring the key at the index.
+func matchingAccountKey(account *flow.Account, publicKey crypto.PublicKey, index int) (*flow.AccountKey, error) {
+	var matching *flow.AccountKey
+	for _, key := range account.Keys {
+		if !key.PublicKey.Equals(publicKey) || key.Revoked {
+			continue
+		}
+		if matching == nil || key.Index == index {
+			matching = key
+		}
+	}
+
+	if matching == nil {
+		return nil, fmt.Errorf("the private key doesn't match any key of account 0x%s which is not revoked", account.Address)
+	}
+
+	return matching, nil
+}
@@ -0,0 +1,106 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsRevokeKey struct {
+	Index   int      `default:"-1" flag:"index" info:"Index of the account key to revoke"`
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+}
+
+var revokeKeyFlags = flagsRevokeKey{}
+
+var RevokeKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "revoke-key <account>",
+		Short:   "Revoke a key of an account",
+		Example: `flow accounts revoke-key alice --index 1`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &revokeKeyFlags,
+	RunS:  revokeKey,
+}
+
+func revokeKey(
+	args []string,
+	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if revokeKeyFlags.Index < 0 {
+		return nil, fmt.Errorf("the index of the key to revoke must be provided with the index flag")
+	}
+
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !globalFlags.Yes && !output.RevokeKeyPrompt(revokeKeyFlags.Index, account.Address().String()) {
+		return nil, fmt.Errorf("key revocation cancelled")
+	}
+
+	// sign with another key if the revoked key is the one used for signing
+	signer := account
+	if account.Key().Index() == revokeKeyFlags.Index {
+		for _, key := range account.Keys() {
+			if key.Index() != revokeKeyFlags.Index && !key.Revoked() {
+				signer, err = account.ForKeyIndex(key.Index())
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	}
+
+	_, err = services.Accounts.RevokeKey(signer, revokeKeyFlags.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	// mark the key as revoked if it's in the configuration
+	if account.RevokeKey(revokeKeyFlags.Index) == nil {
+		err = state.SaveDefault()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	flowAccount, err := services.Accounts.Get(account.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountResult{
+		Account: flowAccount,
+		include: revokeKeyFlags.Include,
+	}, nil
+}
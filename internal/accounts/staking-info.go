@@ -39,7 +39,7 @@ var StakingCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "staking-info <address>",
 		Short:   "Get account staking info",
-		Example: "flow accounts staking-info f8d6e0586b0a20c7",
+		Example: "flow accounts staking-info e467b9dd11fa00df --network mainnet",
 		Args:    cobra.ExactArgs(1),
 	},
 	Flags: &stakingFlags,
@@ -62,17 +62,59 @@ func stakingInfo(
 	return &StakingResult{staking, delegation}, nil
 }
 
+// StakingResult is the staking and delegation information of an account.
+//
+// The JSON output uses the field names of the Cadence structs, which should be kept stable as it's parsed by other tools.
 type StakingResult struct {
-	staking    []map[string]interface{} // stake as FlowIDTableStaking.NodeInfo
-	delegation []map[string]interface{} // delegation as FlowIDTableStaking.DelegatorInfo
+	staking    []flowkit.NodeInfo
+	delegation []flowkit.DelegatorInfo
 }
 
 func (r *StakingResult) JSON() interface{} {
-	result := make(map[string]interface{})
-	result["staking"] = r.staking
-	result["delegation"] = r.delegation
+	staking := make([]map[string]interface{}, 0, len(r.staking))
+	for _, node := range r.staking {
+		delegators := node.Delegators
+		if delegators == nil {
+			delegators = []uint32{}
+		}
+
+		staking = append(staking, map[string]interface{}{
+			"id":                       node.ID,
+			"role":                     node.Role,
+			"roleName":                 node.RoleName(),
+			"networkingAddress":        node.NetworkingAddress,
+			"networkingKey":            node.NetworkingKey,
+			"stakingKey":               node.StakingKey,
+			"initialWeight":            node.InitialWeight,
+			"tokensCommitted":          node.TokensCommitted.String(),
+			"tokensStaked":             node.TokensStaked.String(),
+			"tokensUnstaking":          node.TokensUnstaking.String(),
+			"tokensUnstaked":           node.TokensUnstaked.String(),
+			"tokensRewarded":           node.TokensRewarded.String(),
+			"tokensRequestedToUnstake": node.TokensRequestedToUnstake.String(),
+			"delegators":               delegators,
+			"nodeTotalStake":           node.TotalStake.String(),
+		})
+	}
 
-	return result
+	delegation := make([]map[string]interface{}, 0, len(r.delegation))
+	for _, delegator := range r.delegation {
+		delegation = append(delegation, map[string]interface{}{
+			"id":                       delegator.ID,
+			"nodeID":                   delegator.NodeID,
+			"tokensCommitted":          delegator.TokensCommitted.String(),
+			"tokensStaked":             delegator.TokensStaked.String(),
+			"tokensUnstaking":          delegator.TokensUnstaking.String(),
+			"tokensUnstaked":           delegator.TokensUnstaked.String(),
+			"tokensRewarded":           delegator.TokensRewarded.String(),
+			"tokensRequestedToUnstake": delegator.TokensRequestedToUnstake.String(),
+		})
+	}
+
+	return map[string]interface{}{
+		"staking":    staking,
+		"delegation": delegation,
+	}
 }
 
 func (r *StakingResult) String() string {
@@ -82,20 +124,21 @@ func (r *StakingResult) String() string {
 	if len(r.staking) != 0 {
 		_, _ = fmt.Fprintf(writer, "Account staking info:\n")
 
-		for _, stakingInfo := range r.staking {
-			_, _ = fmt.Fprintf(writer, "\tID: \t %v\n", stakingInfo["id"])
-			_, _ = fmt.Fprintf(writer, "\tInitial Weight: \t %v\n", stakingInfo["initialWeight"])
-			_, _ = fmt.Fprintf(writer, "\tNetworking Address: \t %v\n", stakingInfo["networkingAddress"])
-			_, _ = fmt.Fprintf(writer, "\tNetworking Key: \t %v\n", stakingInfo["networkingKey"])
-			_, _ = fmt.Fprintf(writer, "\tRole: \t %v\n", stakingInfo["role"])
-			_, _ = fmt.Fprintf(writer, "\tStaking Key: \t %v\n", stakingInfo["stakingKey"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Committed: \t %v\n", stakingInfo["tokensCommitted"])
-			_, _ = fmt.Fprintf(writer, "\tTokens To Unstake: \t %v\n", stakingInfo["tokensRequestedToUnstake"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Rewarded: \t %v\n", stakingInfo["tokensRewarded"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Staked: \t %v\n", stakingInfo["tokensStaked"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Unstaked: \t %v\n", stakingInfo["tokensUnstaked"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Unstaking: \t %v\n", stakingInfo["tokensUnstaking"])
-			_, _ = fmt.Fprintf(writer, "\tNode Total Stake (including delegators): \t %v\n", stakingInfo["nodeTotalStake"])
+		for _, node := range r.staking {
+			_, _ = fmt.Fprintf(writer, "\tID: \t %s\n", node.ID)
+			_, _ = fmt.Fprintf(writer, "\tInitial Weight: \t %d\n", node.InitialWeight)
+			_, _ = fmt.Fprintf(writer, "\tNetworking Address: \t %s\n", node.NetworkingAddress)
+			_, _ = fmt.Fprintf(writer, "\tNetworking Key: \t %s\n", node.NetworkingKey)
+			_, _ = fmt.Fprintf(writer, "\tRole: \t %d (%s)\n", node.Role, node.RoleName())
+			_, _ = fmt.Fprintf(writer, "\tStaking Key: \t %s\n", node.StakingKey)
+			_, _ = fmt.Fprintf(writer, "\tTokens Committed: \t %s\n", node.TokensCommitted)
+			_, _ = fmt.Fprintf(writer, "\tTokens To Unstake: \t %s\n", node.TokensRequestedToUnstake)
+			_, _ = fmt.Fprintf(writer, "\tTokens Rewarded: \t %s\n", node.TokensRewarded)
+			_, _ = fmt.Fprintf(writer, "\tTokens Staked: \t %s\n", node.TokensStaked)
+			_, _ = fmt.Fprintf(writer, "\tTokens Unstaked: \t %s\n", node.TokensUnstaked)
+			_, _ = fmt.Fprintf(writer, "\tTokens Unstaking: \t %s\n", node.TokensUnstaking)
+			_, _ = fmt.Fprintf(writer, "\tDelegators: \t %d\n", len(node.Delegators))
+			_, _ = fmt.Fprintf(writer, "\tNode Total Stake (including delegators): \t %s\n", node.TotalStake)
 			_, _ = fmt.Fprintf(writer, "\n")
 		}
 	} else {
@@ -105,15 +148,15 @@ func (r *StakingResult) String() string {
 	if len(r.delegation) != 0 {
 		_, _ = fmt.Fprintf(writer, "\nAccount delegation info:\n")
 
-		for _, delegationStakingInfo := range r.delegation {
-			_, _ = fmt.Fprintf(writer, "\tID: \t %v\n", delegationStakingInfo["id"])
-			_, _ = fmt.Fprintf(writer, "\tNode ID: \t %v\n", delegationStakingInfo["nodeID"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Committed: \t %v\n", delegationStakingInfo["tokensCommitted"])
-			_, _ = fmt.Fprintf(writer, "\tTokens To Unstake: \t %v\n", delegationStakingInfo["tokensRequestedToUnstake"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Rewarded: \t %v\n", delegationStakingInfo["tokensRewarded"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Staked: \t %v\n", delegationStakingInfo["tokensStaked"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Unstaked: \t %v\n", delegationStakingInfo["tokensUnstaked"])
-			_, _ = fmt.Fprintf(writer, "\tTokens Unstaking: \t %v\n", delegationStakingInfo["tokensUnstaking"])
+		for _, delegator := range r.delegation {
+			_, _ = fmt.Fprintf(writer, "\tID: \t %d\n", delegator.ID)
+			_, _ = fmt.Fprintf(writer, "\tNode ID: \t %s\n", delegator.NodeID)
+			_, _ = fmt.Fprintf(writer, "\tTokens Committed: \t %s\n", delegator.TokensCommitted)
+			_, _ = fmt.Fprintf(writer, "\tTokens To Unstake: \t %s\n", delegator.TokensRequestedToUnstake)
+			_, _ = fmt.Fprintf(writer, "\tTokens Rewarded: \t %s\n", delegator.TokensRewarded)
+			_, _ = fmt.Fprintf(writer, "\tTokens Staked: \t %s\n", delegator.TokensStaked)
+			_, _ = fmt.Fprintf(writer, "\tTokens Unstaked: \t %s\n", delegator.TokensUnstaked)
+			_, _ = fmt.Fprintf(writer, "\tTokens Unstaking: \t %s\n", delegator.TokensUnstaking)
 			_, _ = fmt.Fprintf(writer, "\n")
 		}
 	} else {
@@ -125,5 +168,5 @@ func (r *StakingResult) String() string {
 }
 
 func (r *StakingResult) Oneliner() string {
-	return ""
+	return fmt.Sprintf("Stakes: %d, Delegations: %d", len(r.staking), len(r.delegation))
 }
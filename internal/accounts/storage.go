@@ -0,0 +1,164 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsStorage struct {
+	Path string `default:"" flag:"path" info:"Storage path of a single item to output the fields of, for example /storage/flowTokenVault"`
+}
+
+var storageFlags = flagsStorage{}
+
+var StorageCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "storage <address>",
+		Short: "Inspect the storage paths and capability links of an account",
+		Example: `flow accounts storage f8d6e0586b0a20c7
+flow accounts storage f8d6e0586b0a20c7 --path /storage/flowTokenVault`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &storageFlags,
+	Run:   storage,
+}
+
+func storage(
+	args []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	address := flow.HexToAddress(args[0])
+
+	if storageFlags.Path != "" {
+		value, err := services.Scripts.StoredValue(address, storageFlags.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		return &StoredValueResult{path: storageFlags.Path, value: value}, nil
+	}
+
+	items, err := services.Scripts.StorageItems(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StorageResult{address: address, items: items}, nil
+}
+
+// StorageResult lists the storage paths and capability links of an account.
+type StorageResult struct {
+	address flow.Address
+	items   []services.StorageItem
+}
+
+func (r *StorageResult) JSON() interface{} {
+	result := make([]map[string]interface{}, 0, len(r.items))
+	for _, item := range r.items {
+		i := map[string]interface{}{
+			"path": item.Path.String(),
+			"type": item.Type,
+		}
+		if item.IsLink() {
+			i["target"] = item.Target.String()
+		}
+		result = append(result, i)
+	}
+
+	return result
+}
+
+func (r *StorageResult) String() string {
+	if len(r.items) == 0 {
+		return fmt.Sprintf("Storage of account 0x%s is empty", r.address)
+	}
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Path\tType\tTarget\n")
+	for _, item := range r.items {
+		target := ""
+		if item.IsLink() {
+			target = item.Target.String()
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", item.Path, item.Type, target)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *StorageResult) Oneliner() string {
+	return fmt.Sprintf("Paths: %d", len(r.items))
+}
+
+// StoredValueResult is the value stored on a storage path, decoded with its fields.
+type StoredValueResult struct {
+	path  string
+	value cadence.Value
+}
+
+func (r *StoredValueResult) JSON() interface{} {
+	return map[string]interface{}{
+		"path":   r.path,
+		"type":   typeID(r.value),
+		"fields": flowkit.DecodeValue(r.value),
+	}
+}
+
+func (r *StoredValueResult) String() string {
+	fields, _ := json.MarshalIndent(flowkit.DecodeValue(r.value), "", "  ")
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Path\t %s\n", r.path)
+	_, _ = fmt.Fprintf(writer, "Type\t %s\n", typeID(r.value))
+	_ = writer.Flush()
+
+	_, _ = fmt.Fprintf(&b, "\n%s\n", fields)
+	return b.String()
+}
+
+func (r *StoredValueResult) Oneliner() string {
+	return r.value.String()
+}
+
+// typeID returns the type ID of the value, values decoded without a type, like arrays, have an unknown type.
+func typeID(value cadence.Value) string {
+	if value == nil || value.Type() == nil {
+		return "unknown"
+	}
+	return value.Type().ID()
+}
@@ -0,0 +1,44 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StoredValueResult(t *testing.T) {
+	t.Run("Value Without Type", func(t *testing.T) {
+		// arrays decoded from JSON-Cadence have no type
+		result := &StoredValueResult{
+			path:  "/storage/ids",
+			value: cadence.NewArray([]cadence.Value{cadence.NewUInt64(1), cadence.NewUInt64(2)}),
+		}
+
+		assert.Equal(t, map[string]interface{}{
+			"path":   "/storage/ids",
+			"type":   "unknown",
+			"fields": []interface{}{"1", "2"},
+		}, result.JSON())
+		assert.Contains(t, result.String(), "unknown")
+		assert.Equal(t, "[1, 2]", result.Oneliner())
+	})
+}
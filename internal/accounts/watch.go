@@ -0,0 +1,252 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+const defaultWatchInterval = 3 * time.Second
+
+// accountState is the part of the account compared by the watcher.
+type accountState struct {
+	balance   uint64
+	contracts map[string]string // code hash by contract name
+	keys      map[int]string    // key description by key index
+}
+
+func newAccountState(account *flow.Account) accountState {
+	state := accountState{
+		balance:   account.Balance,
+		contracts: make(map[string]string, len(account.Contracts)),
+		keys:      make(map[int]string, len(account.Keys)),
+	}
+
+	for name, code := range account.Contracts {
+		state.contracts[name] = flowkit.ContractHash(code)
+	}
+
+	for _, key := range account.Keys {
+		description := fmt.Sprintf("%x weight %d", key.PublicKey.Encode(), key.Weight)
+		if key.Revoked {
+			description += " revoked"
+		}
+		state.keys[key.Index] = description
+	}
+
+	return state
+}
+
+// accountChange is a change of the balance, a contract or a key of the account.
+type accountChange struct {
+	Field string `json:"field"`
+	Name  string `json:"name,omitempty"` // contract name or key index
+	Old   string `json:"old,omitempty"`  // empty if added
+	New   string `json:"new,omitempty"`  // empty if removed
+}
+
+func (c accountChange) String() string {
+	name := c.Field
+	if c.Name != "" {
+		name = fmt.Sprintf("%s %s", c.Field, c.Name)
+	}
+
+	switch {
+	case c.Old == "":
+		return fmt.Sprintf("%s added: %s", name, c.New)
+	case c.New == "":
+		return fmt.Sprintf("%s removed: %s", name, c.Old)
+	default:
+		return fmt.Sprintf("%s: %s -> %s", name, c.Old, c.New)
+	}
+}
+
+// diffAccountStates returns the changes between the account states, contracts sorted by name and keys by index.
+func diffAccountStates(old accountState, new accountState) []accountChange {
+	var changes []accountChange
+
+	if old.balance != new.balance {
+		changes = append(changes, accountChange{
+			Field: "balance",
+			Old:   cadence.UFix64(old.balance).String(),
+			New:   cadence.UFix64(new.balance).String(),
+		})
+	}
+
+	names := make([]string, 0, len(old.contracts)+len(new.contracts))
+	for name := range old.contracts {
+		names = append(names, name)
+	}
+	for name := range new.contracts {
+		if _, ok := old.contracts[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if old.contracts[name] != new.contracts[name] {
+			changes = append(changes, accountChange{
+				Field: "contract",
+				Name:  name,
+				Old:   old.contracts[name],
+				New:   new.contracts[name],
+			})
+		}
+	}
+
+	indexes := make([]int, 0, len(old.keys)+len(new.keys))
+	for index := range old.keys {
+		indexes = append(indexes, index)
+	}
+	for index := range new.keys {
+		if _, ok := old.keys[index]; !ok {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Ints(indexes)
+
+	for _, index := range indexes {
+		if old.keys[index] != new.keys[index] {
+			changes = append(changes, accountChange{
+				Field: "key",
+				Name:  strconv.Itoa(index),
+				Old:   old.keys[index],
+				New:   new.keys[index],
+			})
+		}
+	}
+
+	return changes
+}
+
+// watchEvent is a line printed by the watcher.
+type watchEvent struct {
+	Time    time.Time       `json:"time"`
+	Height  uint64          `json:"height"`
+	Address string          `json:"address"`
+	Changes []accountChange `json:"changes"`
+}
+
+// watchAccount polls the account and prints the changes until interrupted.
+//
+// The account is only fetched once the sealed block height advances, so the same state isn't compared twice.
+func watchAccount(
+	address flow.Address,
+	services *services.Services,
+	interval time.Duration,
+	jsonLines bool,
+) error {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	// the progress of each poll would be printed between the changes
+	services.SetLogger(output.NewStdoutLogger(output.NoneLog))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *accountState
+	var lastHeight uint64
+	for {
+		height, err := services.Blocks.GetLatestBlockHeight()
+		if err != nil {
+			return err
+		}
+
+		if last == nil || height > lastHeight {
+			account, err := services.Accounts.Get(address)
+			if err != nil {
+				return err
+			}
+
+			state := newAccountState(account)
+			event := watchEvent{
+				Time:    time.Now(),
+				Height:  height,
+				Address: address.String(),
+			}
+
+			if last == nil {
+				printWatchStart(event, account, jsonLines)
+			} else if event.Changes = diffAccountStates(*last, state); len(event.Changes) > 0 {
+				printWatchChanges(event, jsonLines)
+			}
+
+			last = &state
+			lastHeight = height
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func printWatchStart(event watchEvent, account *flow.Account, jsonLines bool) {
+	if jsonLines {
+		event.Changes = []accountChange{}
+		line, _ := json.Marshal(event)
+		fmt.Println(string(line))
+		return
+	}
+
+	fmt.Printf(
+		"[%s] watching 0x%s at height %d, balance %s, %d keys, %d contracts (Ctrl-C to stop)\n",
+		event.Time.Format(time.RFC3339),
+		event.Address,
+		event.Height,
+		cadence.UFix64(account.Balance),
+		len(account.Keys),
+		len(account.Contracts),
+	)
+}
+
+func printWatchChanges(event watchEvent, jsonLines bool) {
+	if jsonLines {
+		line, _ := json.Marshal(event)
+		fmt.Println(string(line))
+		return
+	}
+
+	for _, change := range event.Changes {
+		fmt.Printf("[%s] height %d %s\n", event.Time.Format(time.RFC3339), event.Height, change)
+	}
+}
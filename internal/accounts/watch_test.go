@@ -0,0 +1,60 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DiffAccountStates(t *testing.T) {
+	old := accountState{
+		balance:   100000000,
+		contracts: map[string]string{"Bar": "b1", "Foo": "f1"},
+		keys:      map[int]string{0: "aa weight 1000"},
+	}
+
+	t.Run("No changes", func(t *testing.T) {
+		assert.Empty(t, diffAccountStates(old, old))
+	})
+
+	t.Run("All changes", func(t *testing.T) {
+		new := accountState{
+			balance:   250000000,
+			contracts: map[string]string{"Foo": "f2", "Zoo": "z1"},
+			keys:      map[int]string{0: "aa weight 1000 revoked", 1: "bb weight 1000"},
+		}
+
+		changes := diffAccountStates(old, new)
+
+		assert.Equal(t, []accountChange{
+			{Field: "balance", Old: "1.00000000", New: "2.50000000"},
+			{Field: "contract", Name: "Bar", Old: "b1"},
+			{Field: "contract", Name: "Foo", Old: "f1", New: "f2"},
+			{Field: "contract", Name: "Zoo", New: "z1"},
+			{Field: "key", Name: "0", Old: "aa weight 1000", New: "aa weight 1000 revoked"},
+			{Field: "key", Name: "1", New: "bb weight 1000"},
+		}, changes)
+
+		assert.Equal(t, "balance: 1.00000000 -> 2.50000000", changes[0].String())
+		assert.Equal(t, "contract Bar removed: b1", changes[1].String())
+		assert.Equal(t, "contract Zoo added: z1", changes[3].String())
+	})
+}
@@ -25,8 +25,9 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
-	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/transactions"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
@@ -42,10 +43,11 @@ func init() {
 }
 
 type BlockResult struct {
-	block       *flow.Block
-	events      []flow.BlockEvents
-	collections []*flow.Collection
-	included    []string
+	block  *flow.Block
+	events []flow.BlockEvents
+	// tree of the block, with the transactions and their results, when the transactions are included
+	tree     *services.BlockTree
+	included []string
 }
 
 func (r *BlockResult) JSON() interface{} {
@@ -61,10 +63,10 @@ func (r *BlockResult) JSON() interface{} {
 		collection := make(map[string]interface{})
 		collection["id"] = guarantee.CollectionID.String()
 
-		if command.ContainsFlag(r.included, "transactions") {
-			txs := make([]string, 0)
-			for _, tx := range r.collections[i].TransactionIDs {
-				txs = append(txs, tx.String())
+		if r.tree != nil {
+			txs := make([]interface{}, 0, len(r.tree.Collections[i].Transactions))
+			for _, tx := range r.tree.Collections[i].Transactions {
+				txs = append(txs, transactions.SummaryJSON(tx))
 			}
 			collection["transactions"] = txs
 		}
@@ -93,9 +95,9 @@ func (r *BlockResult) String() string {
 	for i, guarantee := range r.block.CollectionGuarantees {
 		_, _ = fmt.Fprintf(writer, "    Collection %d:\t%s\n", i, guarantee.CollectionID)
 
-		if command.ContainsFlag(r.included, "transactions") {
-			for x, tx := range r.collections[i].TransactionIDs {
-				_, _ = fmt.Fprintf(writer, "         Transaction %d: %s\n", x, tx)
+		if r.tree != nil {
+			for x, tx := range r.tree.Collections[i].Transactions {
+				transactions.WriteSummary(writer, x, tx, "         ")
 			}
 		}
 	}
@@ -19,6 +19,10 @@
 package blocks
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
@@ -27,18 +31,27 @@ import (
 )
 
 type flagsBlocks struct {
-	Events  string   `default:"" flag:"events" info:"List events of this type for the block"`
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: transactions."`
+	Events   string        `default:"" flag:"events" info:"List events of this type for the block"`
+	Include  []string      `default:"" flag:"include" info:"Fields to include in the output. Valid values: collections, transactions."`
+	Workers  int           `default:"10" flag:"workers" info:"Number of workers to use when fetching a block range or the transactions of a block"`
+	Watch    bool          `default:"false" flag:"watch" info:"Watch the new sealed blocks and print each block as it arrives"`
+	Interval time.Duration `flag:"interval" info:"Interval of polling the sealed block height when watching blocks, for example 1s"`
 }
 
 var blockFlags = flagsBlocks{}
 
 var GetCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <block_id|latest|block_height>",
-		Short:   "Get block info",
-		Example: "flow blocks get latest --network testnet",
-		Args:    cobra.ExactArgs(1),
+		Use:   "get <block_id|latest|block_height|start_height..end_height>",
+		Short: "Get block info",
+		Example: `flow blocks get latest --network testnet
+
+#get the blocks of a height range
+flow blocks get 1000..1100 --network testnet
+
+#watch the new sealed blocks
+flow blocks get --watch --network testnet`,
+		Args: cobra.RangeArgs(0, 1),
 	},
 	Flags: &blockFlags,
 	Run:   get,
@@ -47,22 +60,73 @@ var GetCommand = &command.Command{
 func get(
 	args []string,
 	_ flowkit.ReaderWriter,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	services *services.Services,
 ) (command.Result, error) {
-	block, events, collections, err := services.Blocks.GetBlock(
+	if blockFlags.Watch {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("the watch flag watches the new blocks, so it can't be combined with a block")
+		}
+		return nil, watchBlocks(services, blockFlags.Interval, blockFlags.Workers, blockFlags.Include, globalFlags.Format == "json")
+	}
+	if blockFlags.Interval != 0 {
+		return nil, fmt.Errorf("the interval flag can only be used with the watch flag")
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("provide a block or the watch flag")
+	}
+
+	start, end, isRange, err := parseRange(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if isRange {
+		if blockFlags.Events != "" {
+			return nil, fmt.Errorf("the events flag can't be combined with a block range")
+		}
+
+		blocks, err := services.Blocks.GetBlockRange(start, end, blockFlags.Workers)
+		if err != nil {
+			return nil, err
+		}
+
+		return &BlockRangeResult{blocks: blocks, included: blockFlags.Include}, nil
+	}
+
+	if command.ContainsFlag(blockFlags.Include, "transactions") {
+		tree, err := services.Blocks.GetBlockTree(args[0], blockFlags.Workers)
+		if err != nil {
+			return nil, err
+		}
+
+		var blockEvents []flow.BlockEvents
+		if blockFlags.Events != "" {
+			blockEvents, err = services.Events.Get([]string{blockFlags.Events}, tree.Height, tree.Height, 1, 1)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return &BlockResult{
+			block:    tree.Block,
+			events:   blockEvents,
+			tree:     tree,
+			included: blockFlags.Include,
+		}, nil
+	}
+
+	block, events, _, err := services.Blocks.GetBlock(
 		args[0], // block id
 		blockFlags.Events,
-		command.ContainsFlag(blockFlags.Include, "transactions"),
+		false,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	return &BlockResult{
-		block:       block,
-		events:      events,
-		collections: collections,
-		included:    blockFlags.Include,
+		block:    block,
+		events:   events,
+		included: blockFlags.Include,
 	}, nil
 }
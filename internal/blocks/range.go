@@ -0,0 +1,154 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+// parseRange parses a block range like 1000..1100, returning false if the query isn't a range.
+func parseRange(query string) (uint64, uint64, bool, error) {
+	from, to, ok := strings.Cut(query, "..")
+	if !ok {
+		return 0, 0, false, nil
+	}
+
+	start, err := strconv.ParseUint(from, 10, 64)
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("invalid start height of the block range %s", query)
+	}
+	end, err := strconv.ParseUint(to, 10, 64)
+	if err != nil {
+		return 0, 0, true, fmt.Errorf("invalid end height of the block range %s", query)
+	}
+	if end < start {
+		return 0, 0, true, fmt.Errorf("the end height of the block range %s is lower than the start height", query)
+	}
+
+	return start, end, true, nil
+}
+
+// blockSummary returns the block with the totals of its collections and transactions, and the details included.
+func blockSummary(block *services.BlockWithCollections, included []string) map[string]interface{} {
+	result := map[string]interface{}{
+		"height":            block.Height,
+		"blockId":           block.ID.String(),
+		"timestamp":         block.Timestamp,
+		"totalCollections":  len(block.Collections),
+		"totalTransactions": block.TransactionCount(),
+	}
+
+	if command.ContainsFlag(included, "collections") || command.ContainsFlag(included, "transactions") {
+		collections := make([]interface{}, 0, len(block.Collections))
+		for _, collection := range block.Collections {
+			c := map[string]interface{}{"id": collection.ID().String()}
+			if command.ContainsFlag(included, "transactions") {
+				txs := make([]string, 0, len(collection.TransactionIDs))
+				for _, tx := range collection.TransactionIDs {
+					txs = append(txs, tx.String())
+				}
+				c["transactions"] = txs
+			}
+			collections = append(collections, c)
+		}
+		result["collections"] = collections
+	}
+
+	return result
+}
+
+// writeBlockDetails writes the collections and the transactions of the block included with the include flag.
+func writeBlockDetails(b *bytes.Buffer, block *services.BlockWithCollections, included []string) {
+	if !command.ContainsFlag(included, "collections") && !command.ContainsFlag(included, "transactions") {
+		return
+	}
+
+	for i, collection := range block.Collections {
+		_, _ = fmt.Fprintf(b, "    Collection %d:\t%s\n", i, collection.ID())
+
+		if command.ContainsFlag(included, "transactions") {
+			for x, tx := range collection.TransactionIDs {
+				_, _ = fmt.Fprintf(b, "         Transaction %d: %s\n", x, tx)
+			}
+		}
+	}
+}
+
+// BlockRangeResult is the result of the blocks of a range.
+type BlockRangeResult struct {
+	blocks   []*services.BlockWithCollections
+	included []string
+}
+
+func (r *BlockRangeResult) JSON() interface{} {
+	result := make([]interface{}, 0, len(r.blocks))
+	for _, block := range r.blocks {
+		result = append(result, blockSummary(block, r.included))
+	}
+	return result
+}
+
+func (r *BlockRangeResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Height\tBlock ID\tTimestamp\tCollections\tTransactions\n")
+	for _, block := range r.blocks {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%d\t%s\t%s\t%d\t%d\n",
+			block.Height,
+			block.ID,
+			block.Timestamp.UTC().Format(time.RFC3339),
+			len(block.Collections),
+			block.TransactionCount(),
+		)
+	}
+	_ = writer.Flush()
+
+	for _, block := range r.blocks {
+		if len(block.Collections) == 0 {
+			continue
+		}
+
+		var details bytes.Buffer
+		writeBlockDetails(&details, block, r.included)
+		if details.Len() > 0 {
+			_, _ = fmt.Fprintf(&b, "\nBlock %d:\n%s", block.Height, details.String())
+		}
+	}
+
+	return b.String()
+}
+
+func (r *BlockRangeResult) Oneliner() string {
+	ids := make([]string, 0, len(r.blocks))
+	for _, block := range r.blocks {
+		ids = append(ids, block.ID.String())
+	}
+	return strings.Join(ids, ",")
+}
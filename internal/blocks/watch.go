@@ -0,0 +1,125 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+const (
+	defaultWatchInterval = time.Second
+	// maxWatchBlocks is the maximum number of blocks fetched by a poll, so the watcher catches up gradually
+	maxWatchBlocks = 100
+)
+
+// watchBlocks polls the sealed block height and prints each new block until interrupted.
+//
+// The blocks are printed with the time since their parent block, or as JSON lines.
+func watchBlocks(
+	services *services.Services,
+	interval time.Duration,
+	workerCount int,
+	included []string,
+	jsonLines bool,
+) error {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	// the progress of each poll would be printed between the blocks
+	services.SetLogger(output.NewStdoutLogger(output.NoneLog))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	next, err := services.Blocks.GetLatestBlockHeight()
+	if err != nil {
+		return err
+	}
+	if !jsonLines {
+		fmt.Printf("Watching blocks from height %d (Ctrl-C to stop)\n", next)
+	}
+
+	var previous *time.Time
+	for {
+		latest, err := services.Blocks.GetLatestBlockHeight()
+		if err != nil {
+			return err
+		}
+
+		if latest >= next {
+			end := latest
+			if end-next >= maxWatchBlocks {
+				end = next + maxWatchBlocks - 1
+			}
+
+			blocks, err := services.Blocks.GetBlockRange(next, end, workerCount)
+			if err != nil {
+				return err
+			}
+
+			for _, block := range blocks {
+				var delta time.Duration
+				if previous != nil {
+					delta = block.Timestamp.Sub(*previous)
+				}
+				timestamp := block.Timestamp
+				previous = &timestamp
+
+				if jsonLines {
+					summary := blockSummary(block, included)
+					summary["delta"] = delta.String()
+					line, _ := json.Marshal(summary)
+					fmt.Println(string(line))
+					continue
+				}
+
+				fmt.Printf(
+					"[%s] height %d %s, %d collections, %d transactions, +%s\n",
+					block.Timestamp.UTC().Format(time.RFC3339),
+					block.Height,
+					block.ID,
+					len(block.Collections),
+					block.TransactionCount(),
+					delta.Round(time.Millisecond),
+				)
+				var details bytes.Buffer
+				writeBlockDetails(&details, block, included)
+				fmt.Print(details.String())
+			}
+			next = end + 1
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
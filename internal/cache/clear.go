@@ -0,0 +1,67 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+var ClearCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "clear",
+		Short:   "Remove the cached script results",
+		Example: "flow cache clear",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	Run:   clearCache,
+}
+
+func clearCache(
+	_ []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	err := flowkit.NewScriptCache(readerWriter, flowkit.DefaultScriptCacheFile).Clear()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClearResult{}, nil
+}
+
+// ClearResult represents the result of the cache clear command.
+type ClearResult struct{}
+
+func (r *ClearResult) JSON() interface{} {
+	return map[string]string{"file": flowkit.DefaultScriptCacheFile}
+}
+
+func (r *ClearResult) String() string {
+	return "Script results cache cleared"
+}
+
+func (r *ClearResult) Oneliner() string {
+	return r.String()
+}
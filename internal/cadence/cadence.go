@@ -40,4 +40,5 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(languageserver.Cmd)
+	EncodeArgsCommand.AddToParent(Cmd)
 }
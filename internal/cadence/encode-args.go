@@ -0,0 +1,86 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cadence
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+var EncodeArgsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "encode-args <Type:value> [<Type:value> ...]",
+		Short:   "Encode arguments in the JSON-Cadence format",
+		Example: `flow cadence encode-args "UFix64:10.0" "Address:0x01cf0e2f2f715450" '[String]:["a", "b"]' --save args.json`,
+		Args:    cobra.MinimumNArgs(1),
+	},
+	Flags: &struct{}{},
+	Run:   encodeArgs,
+}
+
+func encodeArgs(
+	args []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	values := make([]cadence.Value, 0, len(args))
+	for i, arg := range args {
+		value, err := flowkit.ParseTypedArgument(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse argument %d: %w", i, err)
+		}
+		values = append(values, value)
+	}
+
+	return &EncodeArgsResult{values: values}, nil
+}
+
+// EncodeArgsResult is the arguments encoded as a JSON array of JSON-Cadence values,
+// which can be used with the --args-json-file flag.
+type EncodeArgsResult struct {
+	values []cadence.Value
+}
+
+func (r *EncodeArgsResult) JSON() interface{} {
+	result := make([]json.RawMessage, 0, len(r.values))
+	for _, value := range r.values {
+		result = append(result, jsoncdc.MustEncode(value))
+	}
+
+	return result
+}
+
+func (r *EncodeArgsResult) String() string {
+	b, _ := flowkit.EncodeArgumentsJSON(r.values)
+	return string(b)
+}
+
+func (r *EncodeArgsResult) Oneliner() string {
+	b, _ := json.Marshal(r.JSON())
+	return string(b)
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/internal/transactions"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
@@ -42,9 +44,23 @@ func init() {
 
 type CollectionResult struct {
 	*flow.Collection
+	// tree of the collection, with the transactions and their results, when the transactions are included
+	tree *services.CollectionTree
 }
 
 func (c *CollectionResult) JSON() interface{} {
+	if c.tree != nil {
+		txs := make([]interface{}, 0, len(c.tree.Transactions))
+		for _, tx := range c.tree.Transactions {
+			txs = append(txs, transactions.SummaryJSON(tx))
+		}
+
+		return map[string]interface{}{
+			"id":           c.Collection.ID().String(),
+			"transactions": txs,
+		}
+	}
+
 	txIDs := make([]string, 0)
 
 	for _, tx := range c.Collection.TransactionIDs {
@@ -60,8 +76,14 @@ func (c *CollectionResult) String() string {
 
 	_, _ = fmt.Fprintf(writer, "Collection ID %s:\n", c.Collection.ID())
 
-	for _, tx := range c.Collection.TransactionIDs {
-		_, _ = fmt.Fprintf(writer, "%s\n", tx.String())
+	if c.tree != nil {
+		for i, tx := range c.tree.Transactions {
+			transactions.WriteSummary(writer, i, tx, "")
+		}
+	} else {
+		for _, tx := range c.Collection.TransactionIDs {
+			_, _ = fmt.Fprintf(writer, "%s\n", tx.String())
+		}
 	}
 
 	_ = writer.Flush()
@@ -70,5 +92,10 @@ func (c *CollectionResult) String() string {
 }
 
 func (c *CollectionResult) Oneliner() string {
-	return strings.Join(c.JSON().([]string), ",")
+	txIDs := make([]string, 0, len(c.Collection.TransactionIDs))
+	for _, tx := range c.Collection.TransactionIDs {
+		txIDs = append(txIDs, tx.String())
+	}
+
+	return strings.Join(txIDs, ",")
 }
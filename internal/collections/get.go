@@ -19,6 +19,8 @@
 package collections
 
 import (
+	"fmt"
+
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
@@ -27,16 +29,24 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
-type flagsCollections struct{}
+type flagsCollections struct {
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: transactions."`
+	Block   uint64   `default:"0" flag:"block" info:"Height of the block of the collection, to get the collection by its index in the block"`
+	Index   int      `default:"0" flag:"index" info:"Index of the collection in the block of the block flag"`
+	Workers int      `default:"10" flag:"workers" info:"Number of workers to use when fetching the transactions"`
+}
 
 var collectionFlags = flagsCollections{}
 
 var GetCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <collection_id>",
-		Short:   "Get collection info",
-		Example: "flow collections get 270d...9c31e",
-		Args:    cobra.ExactArgs(1),
+		Use:   "get <collection_id>",
+		Short: "Get collection info",
+		Example: `flow collections get 270d...9c31e
+
+#get the first collection of a block with its transactions
+flow collections get --block 12345 --index 0 --include transactions`,
+		Args: cobra.RangeArgs(0, 1),
 	},
 	Flags: &collectionFlags,
 	Run:   get,
@@ -48,12 +58,34 @@ func get(
 	_ command.GlobalFlags,
 	services *services.Services,
 ) (command.Result, error) {
-	id := flow.HexToID(args[0])
-
-	collection, err := services.Collections.Get(id)
+	var collection *flow.Collection
+	var err error
+	if collectionFlags.Block != 0 {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("the block flag can't be combined with a collection ID")
+		}
+		collection, err = services.Collections.GetByBlock(collectionFlags.Block, collectionFlags.Index)
+	} else {
+		if collectionFlags.Index != 0 {
+			return nil, fmt.Errorf("the index flag can only be used with the block flag")
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("provide a collection ID or the block flag")
+		}
+		collection, err = services.Collections.Get(flow.HexToID(args[0]))
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &CollectionResult{collection}, nil
+	if command.ContainsFlag(collectionFlags.Include, "transactions") {
+		tree, err := services.Collections.GetTree(collection, collectionFlags.Workers)
+		if err != nil {
+			return nil, err
+		}
+
+		return &CollectionResult{Collection: collection, tree: tree}, nil
+	}
+
+	return &CollectionResult{Collection: collection}, nil
 }
@@ -0,0 +1,90 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+// ArgumentsFlags are the values of the flags providing the arguments of scripts and transactions.
+type ArgumentsFlags struct {
+	JSON     string   // JSON-Cadence encoded arguments
+	JSONFile string   // file containing the JSON-Cadence encoded arguments
+	Named    []string // arguments in the name:Type:value format
+}
+
+// ParseArguments parses the arguments of the code from the flags,
+// or from the positional arguments if none of the flags is used.
+func ParseArguments(
+	readerWriter flowkit.ReaderWriter,
+	filename string,
+	code []byte,
+	positional []string,
+	flags ArgumentsFlags,
+) ([]cadence.Value, error) {
+	used := 0
+	for _, set := range []bool{flags.JSON != "", flags.JSONFile != "", len(flags.Named) > 0} {
+		if set {
+			used++
+		}
+	}
+	if used > 1 {
+		return nil, fmt.Errorf("only one of the --args-json, --args-json-file and --arg flags can be used")
+	}
+	if used == 1 && len(positional) > 0 {
+		return nil, fmt.Errorf("positional arguments can't be combined with the --args-json, --args-json-file and --arg flags")
+	}
+
+	switch {
+	case flags.JSON != "":
+		return flowkit.ParseArgumentsJSON(flags.JSON)
+	case flags.JSONFile != "":
+		b, err := readerWriter.ReadFile(flags.JSONFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the arguments file %s: %w", flags.JSONFile, err)
+		}
+		return flowkit.ParseArgumentsJSON(string(b))
+	case len(flags.Named) > 0:
+		return flowkit.ParseNamedArguments(filename, code, flags.Named)
+	default:
+		return flowkit.ParseArgumentsWithoutType(filename, code, positional)
+	}
+}
+
+// ParseParams parses the values of the parameters of templates and saved scripts, given in the name=value format.
+func ParseParams(params []string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, param := range params {
+		name, value, found := strings.Cut(param, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid parameter %s, the format is name=value", param)
+		}
+		if _, exists := values[name]; exists {
+			return nil, fmt.Errorf("parameter %s is provided more than once", name)
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,63 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+// StdinFilename is the filename reading the code from the standard input.
+const StdinFilename = "-"
+
+// InlineLocation is the location of the code not read from a file, it's in the project root
+// so the imports of the code are resolved relative to the project root.
+const InlineLocation = "inline.cdc"
+
+// ReadCode reads the code of a script or transaction from the file, or from the standard input if the filename is "-".
+//
+// The inline code is used instead if it's provided. The location of the code is returned with the code,
+// the location of code not read from a file is the inline location.
+func ReadCode(readerWriter flowkit.ReaderWriter, filename string, inline string) ([]byte, string, error) {
+	return readCode(readerWriter, os.Stdin, filename, inline)
+}
+
+func readCode(readerWriter flowkit.ReaderWriter, stdin io.Reader, filename string, inline string) ([]byte, string, error) {
+	if inline != "" {
+		return []byte(inline), InlineLocation, nil
+	}
+
+	if filename == StdinFilename {
+		code, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read the code from the standard input: %w", err)
+		}
+		return code, InlineLocation, nil
+	}
+
+	code, err := readerWriter.ReadFile(filename)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return code, filename, nil
+}
@@ -0,0 +1,72 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func Test_ReadCode(t *testing.T) {
+	rw, _ := tests.ReaderWriter()
+	script := tests.ScriptArgString
+
+	t.Run("File", func(t *testing.T) {
+		code, location, err := readCode(rw, &bytes.Buffer{}, script.Filename, "")
+		assert.NoError(t, err)
+		assert.Equal(t, script.Source, code)
+		assert.Equal(t, script.Filename, location)
+
+		args, err := ParseArguments(rw, location, code, []string{"Foo"}, ArgumentsFlags{})
+		assert.NoError(t, err)
+		assert.Equal(t, []cadence.Value{cadence.String("Foo")}, args)
+	})
+
+	t.Run("Stdin", func(t *testing.T) {
+		code, location, err := readCode(rw, bytes.NewReader(script.Source), StdinFilename, "")
+		assert.NoError(t, err)
+		assert.Equal(t, script.Source, code)
+		assert.Equal(t, InlineLocation, location)
+
+		args, err := ParseArguments(rw, location, code, []string{"Foo"}, ArgumentsFlags{})
+		assert.NoError(t, err)
+		assert.Equal(t, []cadence.Value{cadence.String("Foo")}, args)
+
+		args, err = ParseArguments(rw, location, code, nil, ArgumentsFlags{Named: []string{"name:String:Bar"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []cadence.Value{cadence.String("Bar")}, args)
+	})
+
+	t.Run("Inline", func(t *testing.T) {
+		code, location, err := readCode(rw, &bytes.Buffer{}, "", string(script.Source))
+		assert.NoError(t, err)
+		assert.Equal(t, script.Source, code)
+		assert.Equal(t, InlineLocation, location)
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		_, _, err := readCode(rw, &bytes.Buffer{}, "missing.cdc", "")
+		assert.Error(t, err)
+	})
+}
@@ -71,6 +71,9 @@ type Command struct {
 	Flags interface{}
 	Run   Run
 	RunS  RunWithState
+	// AllowInvalidConfig runs the command even if the configuration can't be loaded,
+	// used by commands inspecting the configuration themselves.
+	AllowInvalidConfig bool
 }
 
 const (
@@ -86,6 +89,11 @@ const (
 	logLevelNone  = "none"
 )
 
+func init() {
+	// encrypted account keys prompt for the passphrase if it is not set in the environment
+	flowkit.PassphrasePrompt = output.PassphrasePrompt
+}
+
 // AddToParent add new command to main parent cmd
 // and initializes all necessary things as well as take care of errors and output
 // here we can do all boilerplate code that is else copied in each command and make sure
@@ -104,18 +112,39 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		loader := &afero.Afero{Fs: afero.NewOsFs()}
 
 		// if we receive a config error that isn't missing config we should handle it
-		state, confErr := flowkit.Load(Flags.ConfigPaths, loader)
-		if !errors.Is(confErr, config.ErrDoesNotExist) {
+		state, confErr := flowkit.Load(Flags.ConfigPaths, loader, Flags.LoadOptions()...)
+		if !errors.Is(confErr, config.ErrDoesNotExist) && !c.AllowInvalidConfig {
 			handleError("Config Error", confErr)
 		}
 
+		// seed the global flags with the settings from the configuration
+		if state != nil {
+			applySettings(cmd, state.Config().Settings)
+			// accounts defined for specific networks are resolved for the selected network
+			state.SetNetwork(Flags.Network)
+		} else {
+			globalSettings, err := flowkit.GlobalSettings(loader)
+			if !c.AllowInvalidConfig {
+				handleError("Config Error", err)
+			}
+			applySettings(cmd, globalSettings)
+		}
+
 		host, hostNetworkKey, err := resolveHost(state, Flags.Host, Flags.HostNetworkKey, Flags.Network)
 		handleError("Host Error", err)
 
 		clientGateway, err := createGateway(host, hostNetworkKey)
 		handleError("Gateway Error", err)
 
+		limits, err := resolveLimits(cmd, state, Flags)
+		handleError("Gateway Error", err)
+		if limits != (gateway.Limits{}) {
+			clientGateway = gateway.NewLimitedGateway(clientGateway, limits)
+		}
+
 		logger := createLogger(Flags.Log, Flags.Format)
+		// hardware wallet keys show the confirmation on the device while signing
+		flowkit.HardwareLogger = logger
 
 		// initialize services
 		service := services.NewServices(clientGateway, state, logger)
@@ -158,10 +187,15 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		handleError("Result", err)
 
 		// output result
-		err = outputResult(formattedResult, Flags.Save, Flags.Format, Flags.Filter)
+		rawResult, raw := result.(RawResult)
+		err = outputResult(formattedResult, Flags.Save, Flags.Format, Flags.Filter, raw && rawResult.Raw())
 		handleError("Output Error", err)
 
 		wg.Wait()
+
+		if failedResult, ok := result.(FailedResult); ok && failedResult.Failed() {
+			os.Exit(1)
+		}
 	}
 
 	bindFlags(c)
@@ -178,6 +212,40 @@ func createGateway(host, hostNetworkKey string) (gateway.Gateway, error) {
 	return gateway.NewGrpcGateway(host)
 }
 
+// resolveLimits returns the request limits of the network, overridden by the timeout and retries flags.
+//
+// The limits of the network configuration don't apply if the host flag is used.
+func resolveLimits(cmd *cobra.Command, state *flowkit.State, flags GlobalFlags) (gateway.Limits, error) {
+	var limits gateway.Limits
+	if state != nil && flags.Host == "" {
+		network, err := state.Networks().ByName(flags.Network)
+		if err == nil {
+			limits = gateway.Limits{
+				Timeout:              network.Timeout,
+				MaxRetries:           network.MaxRetries,
+				RetryBackoff:         network.RetryBackoff,
+				MaxRequestsPerSecond: network.MaxRequestsPerSecond,
+			}
+		}
+	}
+
+	if cmd.Flags().Changed("timeout") {
+		if flags.Timeout < 0 {
+			return gateway.Limits{}, fmt.Errorf("timeout flag can not be negative")
+		}
+		limits.Timeout = flags.Timeout
+	}
+
+	if cmd.Flags().Changed("retries") {
+		if flags.Retries < 0 {
+			return gateway.Limits{}, fmt.Errorf("retries flag can not be negative")
+		}
+		limits.MaxRetries = flags.Retries
+	}
+
+	return limits, nil
+}
+
 // resolveHost from the flags provided.
 //
 // Resolve the network host in the following order:
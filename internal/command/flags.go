@@ -21,40 +21,48 @@ package command
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/psiemens/sconfig"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
 // GlobalFlags contains all global flags definitions.
 type GlobalFlags struct {
-	Filter           string
-	Format           string
-	Save             string
-	Host             string
-	HostNetworkKey   string
-	Log              string
-	Network          string
-	Yes              bool
-	ConfigPaths      []string
-	SkipVersionCheck bool
+	Filter                string
+	Format                string
+	Save                  string
+	Host                  string
+	HostNetworkKey        string
+	Log                   string
+	Network               string
+	Yes                   bool
+	ConfigPaths           []string
+	SkipVersionCheck      bool
+	SkipAddressValidation bool
+	Timeout               time.Duration
+	Retries               int
 }
 
 // Flags initialized to default values.
 var Flags = GlobalFlags{
-	Filter:           "",
-	Format:           formatText,
-	Save:             "",
-	Host:             "",
-	HostNetworkKey:   "",
-	Network:          config.DefaultEmulatorNetwork().Name,
-	Log:              logLevelInfo,
-	Yes:              false,
-	ConfigPaths:      config.DefaultPaths(),
-	SkipVersionCheck: false,
+	Filter:                "",
+	Format:                formatText,
+	Save:                  "",
+	Host:                  "",
+	HostNetworkKey:        "",
+	Network:               config.DefaultEmulatorNetwork().Name,
+	Log:                   logLevelInfo,
+	Yes:                   false,
+	ConfigPaths:           config.DefaultPaths(),
+	SkipVersionCheck:      false,
+	SkipAddressValidation: false,
+	Timeout:               0,
+	Retries:               0,
 }
 
 // InitFlags init all the global persistent flags.
@@ -138,6 +146,62 @@ func InitFlags(cmd *cobra.Command) {
 		Flags.SkipVersionCheck,
 		"Skip version check during start up",
 	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.SkipAddressValidation,
+		"skip-address-validation",
+		"",
+		Flags.SkipAddressValidation,
+		"Skip checking the account addresses are valid on the networks using them, needed for custom networks",
+	)
+
+	cmd.PersistentFlags().DurationVarP(
+		&Flags.Timeout,
+		"timeout",
+		"",
+		Flags.Timeout,
+		"Timeout of the Flow Access API requests, overrides the timeout of the network configuration",
+	)
+
+	cmd.PersistentFlags().IntVarP(
+		&Flags.Retries,
+		"retries",
+		"",
+		Flags.Retries,
+		"Number of times failed Flow Access API requests are retried, overrides the retries of the network configuration",
+	)
+}
+
+// LoadOptions returns the options for loading the configuration with the global flags.
+func (f GlobalFlags) LoadOptions() []flowkit.LoadOption {
+	options := make([]flowkit.LoadOption, 0)
+	if f.SkipAddressValidation {
+		options = append(options, flowkit.WithoutAddressValidation())
+	}
+
+	return options
+}
+
+// settingFlags are the global flags which defaults can be changed by the configuration settings.
+var settingFlags = map[string]struct {
+	flag  string
+	value *string
+}{
+	"network": {flag: "network", value: &Flags.Network},
+	"format":  {flag: "output", value: &Flags.Format},
+	"filter":  {flag: "filter", value: &Flags.Filter},
+}
+
+// applySettings seeds the global flags not specified on the command line with the configuration settings.
+func applySettings(cmd *cobra.Command, settings config.Settings) {
+	for key, setting := range settingFlags {
+		value, _ := settings.Get(key)
+		if value == "" || cmd.Flags().Changed(setting.flag) {
+			continue
+		}
+
+		*setting.value = value
+	}
 }
 
 // bindFlags bind all the flags needed.
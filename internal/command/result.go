@@ -43,6 +43,22 @@ type Result interface {
 	JSON() interface{}
 }
 
+// FailedResult describes a result which is output even if the command failed.
+//
+// The command exits with an error after the result is output if the result failed.
+type FailedResult interface {
+	Result
+	Failed() bool
+}
+
+// RawResult describes a result which can be output as is, in the format chosen by the command flags.
+//
+// Raw results are output without the surrounding blank lines, so they can be consumed by other programs.
+type RawResult interface {
+	Result
+	Raw() bool
+}
+
 // ContainsFlag checks if output flag is present for the provided field.
 func ContainsFlag(flags []string, field string) bool {
 	for _, n := range flags {
@@ -69,6 +85,10 @@ func formatResult(result Result, filterFlag string, formatFlag string) (string,
 		return fmt.Sprintf("%v", value), nil
 	}
 
+	if rawResult, ok := result.(RawResult); ok && rawResult.Raw() {
+		return result.String(), nil
+	}
+
 	switch strings.ToLower(formatFlag) {
 	case formatJSON:
 		jsonRes, _ := json.Marshal(result.JSON())
@@ -81,7 +101,7 @@ func formatResult(result Result, filterFlag string, formatFlag string) (string,
 }
 
 // outputResult to selected media.
-func outputResult(result string, saveFlag string, formatFlag string, filterFlag string) error {
+func outputResult(result string, saveFlag string, formatFlag string, filterFlag string, raw bool) error {
 	if saveFlag != "" {
 		af := afero.Afero{
 			Fs: afero.NewOsFs(),
@@ -91,7 +111,9 @@ func outputResult(result string, saveFlag string, formatFlag string, filterFlag
 		return af.WriteFile(saveFlag, []byte(result), 0644)
 	}
 
-	if formatFlag == formatInline || filterFlag != "" {
+	if raw && filterFlag == "" {
+		_, _ = fmt.Fprintf(os.Stdout, "%s\n", result)
+	} else if formatFlag == formatInline || filterFlag != "" {
 		_, _ = fmt.Fprintf(os.Stdout, "%s", result)
 	} else { // default normal output
 		_, _ = fmt.Fprintf(os.Stdout, "\n%s\n\n", result)
@@ -131,7 +153,7 @@ func handleError(description string, err error) {
 	default:
 		if errors.Is(err, config.ErrOutdatedFormat) {
 			_, _ = fmt.Fprintf(os.Stderr, "%s Config Error: %s \n", output.ErrorEmoji(), err.Error())
-			_, _ = fmt.Fprintf(os.Stderr, "%s Please reset configuration using: 'flow init --reset'. Read more about new configuration here: https://github.com/onflow/flow-cli/releases/tag/v0.17.0", output.TryEmoji())
+			_, _ = fmt.Fprintf(os.Stderr, "%s Please migrate configuration to the current format using: 'flow config migrate'", output.TryEmoji())
 		} else if errors.Is(err, config.ErrDoesNotExist) {
 			_, _ = fmt.Fprintf(os.Stderr, "%s Config Error: %s \n", output.ErrorEmoji(), err.Error())
 			_, _ = fmt.Fprintf(os.Stderr, "%s Please create configuration using: flow init", output.TryEmoji())
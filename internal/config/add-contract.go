@@ -27,6 +27,7 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/project"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
@@ -98,7 +99,7 @@ func flagsToContractData(flags flagsAddContract) (map[string]string, bool, error
 		return nil, true, fmt.Errorf("name must be provided")
 	} else if flags.Filename == "" {
 		return nil, true, fmt.Errorf("contract file name must be provided")
-	} else if !config.Exists(flags.Filename) {
+	} else if !project.IsRemote(flags.Filename) && !config.Exists(flags.Filename) {
 		return nil, true, fmt.Errorf("contract file doesn't exist: %s", flags.Filename)
 	}
 
@@ -30,6 +30,12 @@ var Cmd = &cobra.Command{
 
 func init() {
 	InitCommand.AddToParent(Cmd)
+	ViewCommand.AddToParent(Cmd)
+	LintCommand.AddToParent(Cmd)
+	ConvertCommand.AddToParent(Cmd)
+	SetCommand.AddToParent(Cmd)
+	MigrateCommand.AddToParent(Cmd)
+	DoctorCommand.AddToParent(Cmd)
 	Cmd.AddCommand(AddCmd)
 	Cmd.AddCommand(RemoveCmd)
 }
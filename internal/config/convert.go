@@ -0,0 +1,69 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsConvert struct {
+	Force bool `default:"false" flag:"force" info:"Overwrite the destination file if it exists"`
+}
+
+var convertFlags = flagsConvert{}
+
+var ConvertCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "convert <source> <destination>",
+		Short:   "Convert the configuration to another format",
+		Example: "flow config convert flow.json flow.yaml",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &convertFlags,
+	Run:   convert,
+}
+
+func convert(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	source, destination := args[0], args[1]
+
+	if _, err := readerWriter.ReadFile(destination); err == nil && !convertFlags.Force {
+		return nil, fmt.Errorf("configuration already exists at: %s, use the --force flag to overwrite it", destination)
+	}
+
+	err := flowkit.ConvertConfig(source, destination, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		result: fmt.Sprintf("%s Configuration %s converted to %s", output.OkEmoji(), source, destination),
+	}, nil
+}
@@ -0,0 +1,118 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsDoctor struct{}
+
+var doctorFlags = flagsDoctor{}
+
+var DoctorCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "doctor",
+		Short:   "Check the configuration against the network",
+		Example: "flow config doctor --network testnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &doctorFlags,
+	RunS:  doctor,
+}
+
+func doctor(
+	_ []string,
+	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	services *services.Services,
+	_ *flowkit.State,
+) (command.Result, error) {
+	checks, err := services.Project.Doctor(globalFlags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DoctorResult{checks: checks}, nil
+}
+
+type DoctorResult struct {
+	checks []*services.DoctorCheck
+}
+
+func (r *DoctorResult) JSON() interface{} {
+	result := make([]map[string]interface{}, 0, len(r.checks))
+	for _, check := range r.checks {
+		result = append(result, map[string]interface{}{
+			"check":   check.Name,
+			"passed":  check.Passed,
+			"message": check.Message,
+		})
+	}
+
+	return result
+}
+
+func (r *DoctorResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Check\tStatus\tMessage\n")
+	for _, check := range r.checks {
+		status := fmt.Sprintf("%s PASS", output.OkEmoji())
+		if !check.Passed {
+			status = fmt.Sprintf("%s FAIL", output.ErrorEmoji())
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", check.Name, status, check.Message)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *DoctorResult) Oneliner() string {
+	failed := 0
+	for _, check := range r.checks {
+		if !check.Passed {
+			failed++
+		}
+	}
+
+	return fmt.Sprintf("%d checks, %d failed", len(r.checks), failed)
+}
+
+// Failed reports if any of the checks failed, so the command exits with an error.
+func (r *DoctorResult) Failed() bool {
+	for _, check := range r.checks {
+		if !check.Passed {
+			return true
+		}
+	}
+
+	return false
+}
@@ -34,30 +34,38 @@ import (
 
 type FlagsInit struct {
 	ServicePrivateKey  string `flag:"service-private-key" info:"Service account private key"`
+	ServiceKeySeed     string `flag:"service-key-seed" info:"Seed used to generate the service account private key"`
 	ServiceKeySigAlgo  string `default:"ECDSA_P256" flag:"service-sig-algo" info:"Service account key signature algorithm"`
 	ServiceKeyHashAlgo string `default:"SHA3_256" flag:"service-hash-algo" info:"Service account key hash algorithm"`
 	Reset              bool   `default:"false" flag:"reset" info:"Reset configuration file"`
 	Global             bool   `default:"false" flag:"global" info:"Initialize global user configuration"`
+	Format             string `default:"json" flag:"format" info:"Configuration file format, options: \"json\", \"yaml\""`
+	Template           string `default:"" flag:"template" info:"Scaffold the project from a template, options: \"basic\", \"ft\", \"nft\" or a git repository URL"`
+	Force              bool   `default:"false" flag:"force" info:"Overwrite existing files when scaffolding a template"`
 }
 
 var InitFlag = FlagsInit{}
 
 var InitCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:   "init",
-		Short: "Initialize a new configuration",
+		Use:     "init [<directory>]",
+		Short:   "Initialize a new configuration",
+		Example: "flow init my-project --template nft",
+		Args:    cobra.MaximumNArgs(1),
 	},
 	Flags: &InitFlag,
 	Run:   Initialise,
 }
 
 func Initialise(
-	_ []string,
+	args []string,
 	readerWriter flowkit.ReaderWriter,
 	_ command.GlobalFlags,
 	services *services.Services,
 ) (command.Result, error) {
-	fmt.Println("⚠️Notice: for starting a new project prefer using 'flow setup'.")
+	if InitFlag.Template == "" {
+		fmt.Println("⚠️Notice: for starting a new project prefer using 'flow setup' or a template.")
+	}
 
 	sigAlgo := crypto.StringToSignatureAlgorithm(InitFlag.ServiceKeySigAlgo)
 	if sigAlgo == crypto.UnknownSignatureAlgorithm {
@@ -69,19 +77,38 @@ func Initialise(
 		return nil, fmt.Errorf("invalid hash algorithm: %s", InitFlag.ServiceKeyHashAlgo)
 	}
 
+	if InitFlag.ServicePrivateKey != "" && InitFlag.ServiceKeySeed != "" {
+		return nil, fmt.Errorf("service private key and service key seed can not be used together")
+	}
+
 	var privateKey crypto.PrivateKey
+	var err error
 	if InitFlag.ServicePrivateKey != "" {
-		var err error
 		privateKey, err = crypto.DecodePrivateKeyHex(sigAlgo, InitFlag.ServicePrivateKey)
 		if err != nil {
 			return nil, fmt.Errorf("invalid private key: %w", err)
 		}
 	}
 
+	if InitFlag.ServiceKeySeed != "" {
+		privateKey, err = services.Keys.Generate(InitFlag.ServiceKeySeed, sigAlgo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if InitFlag.Template != "" {
+		return initialiseTemplate(args, readerWriter, sigAlgo, hashAlgo, privateKey)
+	}
+	if len(args) > 0 {
+		return nil, fmt.Errorf("the project directory can only be specified together with a template")
+	}
+
 	s, err := services.Project.Init(
 		readerWriter,
 		InitFlag.Reset,
 		InitFlag.Global,
+		InitFlag.Format,
 		sigAlgo,
 		hashAlgo,
 		privateKey,
@@ -93,6 +120,35 @@ func Initialise(
 	return &InitResult{State: s}, nil
 }
 
+// initialiseTemplate scaffolds the project from the template into the directory, which defaults to the current directory.
+func initialiseTemplate(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+	privateKey crypto.PrivateKey,
+) (command.Result, error) {
+	if InitFlag.Global {
+		return nil, fmt.Errorf("templates can not be used to initialize the global configuration")
+	}
+
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	state, err := flowkit.Init(readerWriter, sigAlgo, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	if privateKey != nil {
+		state.SetEmulatorKey(privateKey)
+	}
+
+	return initTemplate(InitFlag.Template, dir, readerWriter, state, InitFlag.Format, InitFlag.Force)
+}
+
 type InitResult struct {
 	*flowkit.State
 }
@@ -121,3 +177,49 @@ func (r *InitResult) String() string {
 func (r *InitResult) Oneliner() string {
 	return ""
 }
+
+type TemplateResult struct {
+	template string
+	dir      string
+	files    []string
+}
+
+func (r *TemplateResult) JSON() interface{} {
+	return map[string]interface{}{
+		"template":  r.template,
+		"directory": r.dir,
+		"files":     r.files,
+	}
+}
+
+func (r *TemplateResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Project initialized from the %s template\n\n", output.Bold(r.template))
+	for _, file := range r.files {
+		_, _ = fmt.Fprintf(writer, "Created: %s\n", file)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nStart developing by following these steps:\n")
+	if r.dir != "." {
+		_, _ = fmt.Fprintf(writer, "1. '%s' to change to your new project,\n", output.Bold(fmt.Sprintf("cd %s", r.dir)))
+	}
+	_, _ = fmt.Fprintf(writer, "%s '%s' to start a Flow emulator,\n", r.step(2), output.Bold("flow emulator"))
+	_, _ = fmt.Fprintf(writer, "%s '%s' to deploy the contracts.\n", r.step(3), output.Bold("flow project deploy"))
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+// step returns the number of the next step, which is lower when the directory doesn't need to be changed.
+func (r *TemplateResult) step(number int) string {
+	if r.dir == "." {
+		number--
+	}
+	return fmt.Sprintf("%d.", number)
+}
+
+func (r *TemplateResult) Oneliner() string {
+	return fmt.Sprintf("Project initialized in %s", r.dir)
+}
@@ -0,0 +1,69 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsLint struct{}
+
+var lintFlags = flagsLint{}
+
+var LintCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "lint",
+		Short:   "Check the configuration for problems",
+		Example: "flow config lint",
+		Args:    cobra.NoArgs,
+	},
+	Flags:              &lintFlags,
+	Run:                lint,
+	AllowInvalidConfig: true,
+}
+
+func lint(
+	_ []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	problems, err := flowkit.Lint(globalFlags.ConfigPaths, readerWriter, globalFlags.LoadOptions()...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Printf("%s %s\n", output.ErrorEmoji(), problem)
+		}
+		return nil, fmt.Errorf("found %d problems in the configuration", len(problems))
+	}
+
+	return &Result{
+		result: fmt.Sprintf("%s Configuration is valid", output.OkEmoji()),
+	}, nil
+}
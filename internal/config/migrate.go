@@ -0,0 +1,85 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsMigrate struct{}
+
+var migrateFlags = flagsMigrate{}
+
+var MigrateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "migrate",
+		Short:   "Migrate the configuration from a legacy format to the current format",
+		Example: "flow config migrate",
+		Args:    cobra.NoArgs,
+	},
+	Flags:              &migrateFlags,
+	Run:                migrate,
+	AllowInvalidConfig: true,
+}
+
+func migrate(
+	_ []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	path := config.DefaultPath
+	if !config.IsDefaultPath(globalFlags.ConfigPaths) {
+		if len(globalFlags.ConfigPaths) > 1 {
+			return nil, fmt.Errorf("specifying multiple paths is not supported when migrating configuration")
+		}
+		path = globalFlags.ConfigPaths[0]
+	}
+
+	backup := fmt.Sprintf("%s.bak", path)
+	changes, err := flowkit.MigrateConfig(path, backup, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return &Result{
+			result: fmt.Sprintf("%s Configuration %s is already in the current format", output.OkEmoji(), path),
+		}, nil
+	}
+
+	var b bytes.Buffer
+	_, _ = fmt.Fprintf(&b, "%s Configuration %s migrated, the original is saved to %s\n\nChanges:\n", output.OkEmoji(), path, backup)
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(&b, "  - %s\n", change)
+	}
+
+	return &Result{
+		result: b.String(),
+	}, nil
+}
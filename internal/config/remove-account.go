@@ -19,6 +19,9 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
@@ -27,7 +30,9 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
-type flagsRemoveAccount struct{}
+type flagsRemoveAccount struct {
+	Force bool `default:"false" flag:"force" info:"Remove the account even if used in deployments, removing the deployments as well"`
+}
 
 var removeAccountFlags = flagsRemoveAccount{}
 
@@ -56,11 +61,25 @@ func removeAccount(
 		name = output.RemoveAccountPrompt(state.Config().Accounts)
 	}
 
+	references := state.Config().AccountReferences(name)
+	if len(references) > 0 && !removeAccountFlags.Force {
+		return nil, fmt.Errorf(
+			"account %s is used by %s, use --force to remove it",
+			name,
+			strings.Join(references, ", "),
+		)
+	}
+
 	err := state.Accounts().Remove(name)
 	if err != nil {
 		return nil, err
 	}
 
+	// remove deployments to the account so the configuration stays valid
+	for _, deployment := range state.Config().Deployments.ByAccount(name) {
+		_ = state.Deployments().Remove(deployment.Account, deployment.Network)
+	}
+
 	err = state.SaveEdited(globalFlags.ConfigPaths)
 	if err != nil {
 		return nil, err
@@ -19,6 +19,9 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
@@ -27,7 +30,9 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
-type flagsRemoveContract struct{}
+type flagsRemoveContract struct {
+	Force bool `default:"false" flag:"force" info:"Remove the contract even if used in deployments, removing it from the deployments as well"`
+}
 
 var removeContractFlags = flagsRemoveContract{}
 
@@ -56,11 +61,25 @@ func removeContract(
 		name = output.RemoveContractPrompt(*state.Contracts())
 	}
 
+	references := state.Config().ContractReferences(name)
+	if len(references) > 0 && !removeContractFlags.Force {
+		return nil, fmt.Errorf(
+			"contract %s is used by %s, use --force to remove it",
+			name,
+			strings.Join(references, ", "),
+		)
+	}
+
 	err := state.Contracts().Remove(name)
 	if err != nil {
 		return nil, err
 	}
 
+	// remove the contract from deployments so the configuration stays valid
+	for _, deployment := range *state.Deployments() {
+		state.Deployments().RemoveContract(deployment.Account, deployment.Network, name)
+	}
+
 	err = state.SaveEdited(globalFlags.ConfigPaths)
 	if err != nil {
 		return nil, err
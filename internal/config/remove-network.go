@@ -19,15 +19,21 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
-type flagsRemoveNetwork struct{}
+type flagsRemoveNetwork struct {
+	Force bool `default:"false" flag:"force" info:"Remove the network even if used by deployments or aliases, removing them as well"`
+}
 
 var removeNetworkFlags = flagsRemoveNetwork{}
 
@@ -56,11 +62,33 @@ func removeNetwork(
 		name = output.RemoveNetworkPrompt(*state.Networks())
 	}
 
+	references := state.Config().NetworkReferences(name)
+	if len(references) > 0 && !removeNetworkFlags.Force {
+		return nil, fmt.Errorf(
+			"network %s is used by %s, use --force to remove it",
+			name,
+			strings.Join(references, ", "),
+		)
+	}
+
 	err := state.Networks().Remove(name)
 	if err != nil {
 		return nil, err
 	}
 
+	// remove deployments and aliases on the network so the configuration stays valid
+	for _, deployment := range state.Deployments().ByNetwork(name) {
+		_ = state.Deployments().Remove(deployment.Account, deployment.Network)
+	}
+
+	contracts := make(config.Contracts, 0)
+	for _, contract := range *state.Contracts() {
+		if contract.Network != name {
+			contracts = append(contracts, contract)
+		}
+	}
+	*state.Contracts() = contracts
+
 	err = state.SaveEdited(globalFlags.ConfigPaths)
 	if err != nil {
 		return nil, err
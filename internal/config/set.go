@@ -0,0 +1,99 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsSet struct {
+	Global bool `default:"false" flag:"global" info:"Save the setting to the global user configuration"`
+}
+
+var setFlags = flagsSet{}
+
+var SetCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "set <network|format|filter> <value>",
+		Short:   "Set the default value of a global flag in the configuration",
+		Example: "flow config set --global network testnet",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &setFlags,
+	Run:   set,
+}
+
+func set(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	key, value := args[0], args[1]
+
+	if setFlags.Global {
+		path := config.GlobalUserPath()
+		if path == "" {
+			return nil, fmt.Errorf("global configuration path can not be determined")
+		}
+
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			return nil, err
+		}
+
+		err = flowkit.SetGlobalSetting(key, value, readerWriter)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Result{
+			result: fmt.Sprintf("%s Setting %s saved to %s", output.OkEmoji(), key, path),
+		}, nil
+	}
+
+	state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter, globalFlags.LoadOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("project configuration not found, use the --global flag to save the setting to the global user configuration: %w", err)
+	}
+
+	err = state.Config().Settings.Set(key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		result: fmt.Sprintf("%s Setting %s saved to the project configuration", output.OkEmoji(), key),
+	}, nil
+}
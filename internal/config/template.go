@@ -0,0 +1,292 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/config/json"
+)
+
+// templates are the project templates built into the CLI.
+//
+//go:embed templates
+var templates embed.FS
+
+// templateConfig is the template configuration defining the contracts and deployments of the template.
+//
+// The template configuration is not copied, it is merged into the initialized project configuration
+// which contains the generated emulator service account.
+const templateConfig = "flow.json"
+
+// templateFiles is the file system the template is scaffolded to.
+type templateFiles interface {
+	flowkit.ReaderWriter
+	Exists(path string) (bool, error)
+	MkdirAll(path string, perm os.FileMode) error
+}
+
+// builtinTemplates returns the names of the templates built into the CLI.
+func builtinTemplates() []string {
+	entries, _ := templates.ReadDir("templates")
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// isGitTemplate checks if the template is a git repository URL.
+func isGitTemplate(template string) bool {
+	return strings.Contains(template, "://") || strings.HasPrefix(template, "git@")
+}
+
+// loadTemplate returns the files of the built-in template by name or of the template in the git repository.
+func loadTemplate(template string) (fs.FS, error) {
+	if isGitTemplate(template) {
+		return fetchTemplate(template)
+	}
+
+	for _, name := range builtinTemplates() {
+		if name == template {
+			return fs.Sub(templates, path.Join("templates", name))
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"template %s does not exist, use one of: %s or a git repository URL",
+		template,
+		strings.Join(builtinTemplates(), ", "),
+	)
+}
+
+// fetchTemplate clones the git repository of the template and caches it in the user cache directory.
+//
+// Cached templates are used without fetching the repository again.
+func fetchTemplate(url string) (fs.FS, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the templates cache directory: %w", err)
+	}
+
+	hash := sha256.Sum256([]byte(url))
+	dir := filepath.Join(cacheDir, "flow", "templates", hex.EncodeToString(hash[:]))
+	if _, err := os.Stat(dir); err == nil {
+		return os.DirFS(dir), nil
+	}
+
+	// clone to a temporary directory first so a failed clone is never cached
+	tmp, err := os.MkdirTemp("", "flow-template-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	_, err = git.PlainClone(tmp, false, &git.CloneOptions{
+		URL:   url,
+		Depth: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone template repository %s: %w", url, err)
+	}
+
+	err = os.RemoveAll(filepath.Join(tmp, ".git"))
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(dir), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the templates cache directory: %w", err)
+	}
+
+	err = os.Rename(tmp, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cache template repository %s: %w", url, err)
+	}
+
+	return os.DirFS(dir), nil
+}
+
+// scaffoldTemplate copies the template files to the directory and returns the paths of the copied files,
+// together with the template configuration to be merged into the project configuration.
+//
+// Existing files are only overwritten if forced, the project configuration paths are checked as well.
+func scaffoldTemplate(
+	files templateFiles,
+	template fs.FS,
+	dir string,
+	configPaths []string,
+	force bool,
+) ([]string, *config.Config, error) {
+	conf := config.Empty()
+	sources := make([]string, 0)
+
+	err := fs.WalkDir(template, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if name == ".git" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if name != templateConfig {
+			sources = append(sources, name)
+			return nil
+		}
+
+		raw, err := fs.ReadFile(template, name)
+		if err != nil {
+			return err
+		}
+
+		conf, err = json.NewParser().Deserialize(raw)
+		if err != nil {
+			return fmt.Errorf("invalid template configuration: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !force {
+		targets := make([]string, 0, len(sources)+len(configPaths))
+		for _, name := range sources {
+			targets = append(targets, filepath.Join(dir, filepath.FromSlash(name)))
+		}
+
+		for _, target := range append(targets, configPaths...) {
+			exists, err := files.Exists(target)
+			if err != nil {
+				return nil, nil, err
+			}
+			if exists {
+				return nil, nil, fmt.Errorf("file %s already exists, use the force flag to overwrite it", target)
+			}
+		}
+	}
+
+	err = files.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	written := make([]string, 0, len(sources))
+	for _, name := range sources {
+		code, err := fs.ReadFile(template, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		err = files.MkdirAll(filepath.Dir(target), 0755)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = files.WriteFile(target, code, 0644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to write template file %s: %w", target, err)
+		}
+		written = append(written, target)
+	}
+
+	return written, conf, nil
+}
+
+// initTemplate initializes a new project in the directory from the template.
+//
+// The project configuration contains the contracts, deployments and networks of the template
+// configuration, accounts are not copied since the emulator service account is generated.
+func initTemplate(
+	name string,
+	dir string,
+	readerWriter flowkit.ReaderWriter,
+	state *flowkit.State,
+	format string,
+	force bool,
+) (*TemplateResult, error) {
+	files, ok := readerWriter.(templateFiles)
+	if !ok {
+		return nil, errors.New("templates can only be used with a writable file system")
+	}
+
+	template, err := loadTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	configPath, err := config.DefaultPathForFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	configPath = filepath.Join(dir, configPath)
+
+	configPaths := make([]string, len(config.DefaultFormatPaths))
+	for i, defaultPath := range config.DefaultFormatPaths {
+		configPaths[i] = filepath.Join(dir, defaultPath)
+	}
+
+	written, conf, err := scaffoldTemplate(files, template, dir, configPaths, force)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, network := range conf.Networks {
+		state.Networks().AddOrUpdate(network.Name, network)
+	}
+	for _, contract := range conf.Contracts {
+		state.Contracts().AddOrUpdate(contract.Name, contract)
+	}
+	for _, deployment := range conf.Deployments {
+		state.Deployments().AddOrUpdate(deployment)
+	}
+
+	err = state.Save(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateResult{
+		template: name,
+		dir:      dir,
+		files:    append(written, configPath),
+	}, nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+func Test_InitTemplate(t *testing.T) {
+	for _, name := range builtinTemplates() {
+		t.Run(name, func(t *testing.T) {
+			rw := afero.Afero{Fs: afero.NewMemMapFs()}
+			state, err := flowkit.Init(rw, crypto.ECDSA_P256, crypto.SHA3_256)
+			require.NoError(t, err)
+
+			result, err := initTemplate(name, ".", rw, state, "json", false)
+			require.NoError(t, err)
+			assert.Contains(t, result.files, "README.md")
+			assert.Contains(t, result.files, "flow.json")
+
+			problems, err := flowkit.Lint([]string{"flow.json"}, rw)
+			require.NoError(t, err)
+			assert.Empty(t, problems)
+
+			loaded, err := flowkit.Load([]string{"flow.json"}, rw)
+			require.NoError(t, err)
+
+			service, err := loaded.EmulatorServiceAccount()
+			require.NoError(t, err)
+
+			srv := services.NewServices(gateway.NewEmulatorGateway(service), loaded, output.NewStdoutLogger(output.NoneLog))
+			contracts, err := srv.Project.Deploy("emulator", services.DeployOptions{})
+			require.NoError(t, err)
+			assert.NotEmpty(t, contracts)
+		})
+	}
+
+	t.Run("Existing Files", func(t *testing.T) {
+		rw := afero.Afero{Fs: afero.NewMemMapFs()}
+		state, err := flowkit.Init(rw, crypto.ECDSA_P256, crypto.SHA3_256)
+		require.NoError(t, err)
+
+		err = rw.WriteFile("project/README.md", []byte("existing"), 0644)
+		require.NoError(t, err)
+
+		_, err = initTemplate("basic", "project", rw, state, "json", false)
+		assert.EqualError(t, err, "file project/README.md already exists, use the force flag to overwrite it")
+
+		exists, _ := rw.Exists("project/flow.json")
+		assert.False(t, exists)
+
+		_, err = initTemplate("basic", "project", rw, state, "json", true)
+		require.NoError(t, err)
+
+		readme, _ := rw.ReadFile("project/README.md")
+		assert.NotEqual(t, "existing", string(readme))
+	})
+
+	t.Run("Unknown Template", func(t *testing.T) {
+		rw := afero.Afero{Fs: afero.NewMemMapFs()}
+		state, err := flowkit.Init(rw, crypto.ECDSA_P256, crypto.SHA3_256)
+		require.NoError(t, err)
+
+		_, err = initTemplate("dao", ".", rw, state, "json", false)
+		assert.EqualError(t, err, "template dao does not exist, use one of: basic, ft, nft or a git repository URL")
+	})
+}
+
+func Test_IsGitTemplate(t *testing.T) {
+	assert.True(t, isGitTemplate("https://github.com/onflow/kitty-items.git"))
+	assert.True(t, isGitTemplate("git@github.com:onflow/kitty-items.git"))
+	assert.False(t, isGitTemplate("nft"))
+}
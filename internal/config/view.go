@@ -0,0 +1,124 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsView struct {
+	Resolved bool `default:"false" flag:"resolved" info:"Show the configuration resolved by merging all the configuration files"`
+}
+
+var viewFlags = flagsView{}
+
+var ViewCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "view",
+		Short:   "View the configuration files",
+		Example: "flow config view --resolved",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &viewFlags,
+	RunS:  view,
+}
+
+func view(
+	_ []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if viewFlags.Resolved {
+		resolved, err := state.ResolvedConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		return &ViewResult{layers: []viewLayer{{path: "resolved", content: resolved}}}, nil
+	}
+
+	layers := make([]viewLayer, 0)
+	for _, path := range state.ConfigLayers() {
+		content, err := readerWriter.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration %s: %w", path, err)
+		}
+		layers = append(layers, viewLayer{path: path, content: content})
+	}
+
+	return &ViewResult{layers: layers}, nil
+}
+
+type viewLayer struct {
+	path    string
+	content []byte
+}
+
+type ViewResult struct {
+	layers []viewLayer
+}
+
+func (r *ViewResult) JSON() interface{} {
+	result := make(map[string]interface{})
+	for _, layer := range r.layers {
+		var content interface{}
+		if err := json.Unmarshal(layer.content, &content); err != nil {
+			content = string(layer.content)
+		}
+		result[layer.path] = content
+	}
+
+	return result
+}
+
+func (r *ViewResult) String() string {
+	var b bytes.Buffer
+	for i, layer := range r.layers {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if len(r.layers) > 1 {
+			_, _ = fmt.Fprintf(&b, "# %s\n", layer.path)
+		}
+		b.Write(bytes.TrimSpace(layer.content))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (r *ViewResult) Oneliner() string {
+	paths := make([]string, len(r.layers))
+	for i, layer := range r.layers {
+		paths[i] = layer.path
+	}
+
+	return strings.Join(paths, ", ")
+}
@@ -0,0 +1,158 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package contracts
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsDiff struct {
+	All bool `flag:"all" default:"false" info:"diff all the contracts in the deployments for the network"`
+}
+
+var diffFlags = flagsDiff{}
+
+var DiffCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "diff [<name>]",
+		Short:   "Compare the local contract code with the code deployed on the network",
+		Example: "flow contracts diff HelloWorld --network testnet",
+		Args:    cobra.MaximumNArgs(1),
+	},
+	Flags: &diffFlags,
+	RunS:  diff,
+}
+
+func diff(
+	args []string,
+	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	srv *services.Services,
+	_ *flowkit.State,
+) (command.Result, error) {
+	var names []string
+	if diffFlags.All {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("contract name can not be provided together with the --all flag")
+		}
+	} else {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("provide the contract name or use the --all flag")
+		}
+		names = args
+	}
+
+	diffs, err := srv.Project.Diff(globalFlags.Network, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffResult{network: globalFlags.Network, diffs: diffs}, nil
+}
+
+type DiffResult struct {
+	network string
+	diffs   []services.ContractDiff
+}
+
+// unified returns the unified diff between the deployed and the local contract code.
+func unified(d services.ContractDiff) string {
+	text, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(d.DeployedCode)),
+		B:        difflib.SplitLines(string(d.Code)),
+		FromFile: fmt.Sprintf("0x%s/%s", d.Contract.AccountAddress, d.Contract.Name),
+		ToFile:   d.Contract.Location(),
+		Context:  3,
+	})
+
+	return text
+}
+
+func (r *DiffResult) JSON() interface{} {
+	result := make([]map[string]interface{}, 0, len(r.diffs))
+
+	for _, d := range r.diffs {
+		item := map[string]interface{}{
+			"name":     d.Contract.Name,
+			"address":  d.Contract.AccountAddress.String(),
+			"deployed": d.Deployed(),
+			"differs":  d.Differs(),
+		}
+		if d.Deployed() && d.Differs() {
+			item["diff"] = unified(d)
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func (r *DiffResult) String() string {
+	var b bytes.Buffer
+
+	for _, d := range r.diffs {
+		switch {
+		case !d.Deployed():
+			_, _ = fmt.Fprintf(&b, "%s Contract %s is not deployed on account 0x%s on network %s\n",
+				output.ErrorEmoji(), d.Contract.Name, d.Contract.AccountAddress, r.network,
+			)
+		case d.Differs():
+			_, _ = fmt.Fprintf(&b, "%s Contract %s differs from the code deployed on account 0x%s\n\n%s\n",
+				output.ErrorEmoji(), d.Contract.Name, d.Contract.AccountAddress, unified(d),
+			)
+		default:
+			_, _ = fmt.Fprintf(&b, "%s Contract %s matches the code deployed on account 0x%s\n",
+				output.OkEmoji(), d.Contract.Name, d.Contract.AccountAddress,
+			)
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Failed is true if any of the contracts differs, so the command can be used to verify deployments.
+func (r *DiffResult) Failed() bool {
+	for _, d := range r.diffs {
+		if d.Differs() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *DiffResult) Oneliner() string {
+	names := make([]string, 0)
+	for _, d := range r.diffs {
+		if d.Differs() {
+			names = append(names, d.Contract.Name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,118 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+// setupProject is set by the setup flag to set up the project once the emulator is listening.
+var setupProject bool
+
+// listeningTimeout is the time to wait for the emulator to listen before the setup is abandoned.
+const listeningTimeout = 30 * time.Second
+
+// withSetup runs the emulator and sets up the project in the background if the setup flag is set.
+func withSetup(run func(cmd *cobra.Command, args []string)) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if setupProject {
+			port, _ := cmd.Flags().GetInt("port")
+			go func() {
+				logger := output.NewStdoutLogger(output.InfoLog)
+				err := SetupProject(fmt.Sprintf("127.0.0.1:%d", port), command.Flags, logger)
+				if err != nil {
+					logger.Info(fmt.Sprintf("%s Project setup failed, the emulator is still running: %s", output.WarningEmoji(), err))
+				}
+			}()
+		}
+
+		run(cmd, args)
+	}
+}
+
+// SetupProject creates the accounts of the emulator deployments which don't exist on the emulator and deploys
+// the project contracts, once the emulator on the host is listening.
+//
+// The addresses of the created accounts are saved in the configuration.
+func SetupProject(host string, flags command.GlobalFlags, logger output.Logger) error {
+	loader := &afero.Afero{Fs: afero.NewOsFs()}
+	state, err := flowkit.Load(flags.ConfigPaths, loader, flags.LoadOptions()...)
+	if err != nil {
+		return err
+	}
+
+	gw, err := gateway.NewGrpcGateway(host)
+	if err != nil {
+		return err
+	}
+
+	err = waitListening(gw)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%s Setting up the project on the emulator", output.TryEmoji()))
+	srv := services.NewServices(gw, state, logger)
+	result, setupErr := srv.Project.Setup(
+		context.Background(),
+		config.DefaultEmulatorNetwork().Name,
+		services.DeployOptions{Update: true},
+	)
+
+	// the accounts created before a failure are saved too
+	if result != nil && len(result.Accounts) > 0 {
+		err = state.SaveEdited(flags.ConfigPaths)
+		if err != nil {
+			return fmt.Errorf("failed to save the created accounts in the configuration: %w", err)
+		}
+	}
+	if setupErr != nil {
+		return setupErr
+	}
+
+	logger.Info(fmt.Sprintf("%s Project set up on the emulator", output.SuccessEmoji()))
+	return nil
+}
+
+// waitListening waits until the emulator responds or the listening timeout passes.
+func waitListening(gw gateway.Gateway) error {
+	deadline := time.Now().Add(listeningTimeout)
+	for {
+		err := gw.Ping()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the emulator is not listening after %s: %w", listeningTimeout, err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
@@ -0,0 +1,498 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+// SnapshotsDir is the directory the named emulator snapshots are stored in.
+const SnapshotsDir = ".flow/snapshots"
+
+const (
+	snapshotMetadataFile = "metadata.json"
+	snapshotDataDir      = "data"
+	emulatorModule       = "github.com/onflow/flow-emulator"
+)
+
+var snapshotNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+type flagsSnapshot struct {
+	AdminHost string `default:"127.0.0.1:8080" flag:"admin-host" info:"Host of the admin API of the running emulator"`
+	DBPath    string `default:"./flowdb" flag:"dbpath" info:"Path of the database directory of the stopped emulator"`
+}
+
+var snapshotFlags = flagsSnapshot{}
+
+var SnapshotCmd = &cobra.Command{
+	Use:              "snapshot",
+	Short:            "Create, list and restore named snapshots of the emulator state",
+	TraverseChildren: true,
+}
+
+var SnapshotCreateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "create <name>",
+		Short:   "Create a named snapshot of the emulator state",
+		Example: "flow emulator snapshot create deployed",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &snapshotFlags,
+	Run:   createSnapshot,
+}
+
+var SnapshotListCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "list",
+		Short:   "List the named snapshots of the emulator state",
+		Example: "flow emulator snapshot list",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &snapshotFlags,
+	Run:   listSnapshots,
+}
+
+var SnapshotRestoreCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "restore <name>",
+		Short:   "Restore the emulator state of a named snapshot",
+		Example: "flow emulator snapshot restore deployed",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &snapshotFlags,
+	Run:   restoreSnapshot,
+}
+
+func init() {
+	SnapshotCreateCommand.AddToParent(SnapshotCmd)
+	SnapshotListCommand.AddToParent(SnapshotCmd)
+	SnapshotRestoreCommand.AddToParent(SnapshotCmd)
+}
+
+// snapshotMetadata describes a named snapshot, it is stored with the snapshot.
+type snapshotMetadata struct {
+	Name            string    `json:"name"`
+	EmulatorVersion string    `json:"emulatorVersion"`
+	Created         time.Time `json:"created"`
+	// Running is true if the snapshot was created by the running emulator, which stores it in its database,
+	// otherwise the snapshot is a copy of the data directory of the stopped emulator.
+	Running bool   `json:"running"`
+	Height  uint64 `json:"height,omitempty"`
+	BlockID string `json:"blockId,omitempty"`
+}
+
+// snapshotResponse is the response of the snapshot endpoints of the emulator admin API.
+type snapshotResponse struct {
+	Height  uint64 `json:"height"`
+	BlockID string `json:"blockId"`
+}
+
+func createSnapshot(
+	args []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	name := args[0]
+	if !snapshotNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid snapshot name %s, only letters, digits, dots, dashes and underscores are allowed", name)
+	}
+
+	dir := filepath.Join(SnapshotsDir, name)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("snapshot %s already exists", name)
+	}
+
+	metadata := &snapshotMetadata{
+		Name:            name,
+		EmulatorVersion: emulatorVersion(),
+		Created:         time.Now().UTC(),
+	}
+
+	if emulatorRunning(snapshotFlags.AdminHost) {
+		response, err := snapshotRequest(
+			http.MethodPost,
+			"/emulator/snapshots",
+			url.Values{"name": []string{name}},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		metadata.Running = true
+		metadata.Height = response.Height
+		metadata.BlockID = response.BlockID
+	} else {
+		if _, err := os.Stat(snapshotFlags.DBPath); err != nil {
+			return nil, fmt.Errorf("the emulator is not running and there is no emulator data in %s, start the emulator with the --persist flag", snapshotFlags.DBPath)
+		}
+
+		err := copyDir(snapshotFlags.DBPath, filepath.Join(dir, snapshotDataDir))
+		if err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, fmt.Errorf("failed to copy the emulator data: %w", err)
+		}
+	}
+
+	err := writeSnapshotMetadata(dir, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SnapshotResult{metadata: metadata, action: "created"}, nil
+}
+
+func listSnapshots(
+	_ []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	entries, err := os.ReadDir(SnapshotsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	snapshots := make([]*snapshotMetadata, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		metadata, err := readSnapshotMetadata(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, metadata)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Created.Before(snapshots[j].Created)
+	})
+
+	return &SnapshotListResult{snapshots: snapshots}, nil
+}
+
+func restoreSnapshot(
+	args []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	metadata, err := readSnapshotMetadata(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	version := emulatorVersion()
+	if !compatibleVersions(metadata.EmulatorVersion, version) {
+		return nil, fmt.Errorf(
+			"snapshot %s was created with emulator %s, which is not compatible with emulator %s",
+			metadata.Name,
+			metadata.EmulatorVersion,
+			version,
+		)
+	}
+
+	if emulatorRunning(snapshotFlags.AdminHost) {
+		if !metadata.Running {
+			return nil, fmt.Errorf("snapshot %s is a copy of the emulator data directory, stop the emulator to restore it", metadata.Name)
+		}
+
+		_, err := snapshotRequest(http.MethodPut, "/emulator/snapshots/"+url.PathEscape(metadata.Name), nil)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if metadata.Running {
+			return nil, fmt.Errorf("snapshot %s is stored in the emulator database, start the emulator with the --snapshot flag to restore it", metadata.Name)
+		}
+
+		err := swapDataDir(filepath.Join(SnapshotsDir, metadata.Name, snapshotDataDir), snapshotFlags.DBPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &SnapshotResult{metadata: metadata, action: "restored"}, nil
+}
+
+// emulatorVersion returns the version of the emulator the CLI is built with.
+func emulatorVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if ok {
+		for _, dep := range info.Deps {
+			if dep.Path != emulatorModule {
+				continue
+			}
+			if dep.Replace != nil {
+				dep = dep.Replace
+			}
+			return dep.Version
+		}
+	}
+
+	return "unknown"
+}
+
+// compatibleVersions returns true if the snapshots of an emulator version can be restored by another version,
+// which is the case for the versions with the same major and minor version.
+func compatibleVersions(version string, other string) bool {
+	if version == other {
+		return true
+	}
+
+	minor := func(version string) string {
+		parts := strings.SplitN(version, ".", 3)
+		if len(parts) < 3 || !strings.HasPrefix(version, "v") {
+			return ""
+		}
+		return parts[0] + "." + parts[1]
+	}
+
+	return minor(version) != "" && minor(version) == minor(other)
+}
+
+// emulatorRunning returns true if the liveness endpoint of the admin API of the emulator responds.
+func emulatorRunning(adminHost string) bool {
+	client := http.Client{Timeout: time.Second}
+	response, err := client.Get(fmt.Sprintf("http://%s/live", adminHost))
+	if err != nil {
+		return false
+	}
+	_ = response.Body.Close()
+
+	return response.StatusCode == http.StatusOK
+}
+
+// snapshotRequest sends a request to a snapshot endpoint of the emulator admin API.
+func snapshotRequest(method string, path string, form url.Values) (*snapshotResponse, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	request, err := http.NewRequest(method, fmt.Sprintf("http://%s%s", snapshotFlags.AdminHost, path), body)
+	if err != nil {
+		return nil, err
+	}
+	if form != nil {
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	client := http.Client{Timeout: time.Minute}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the emulator admin API: %w", err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusConflict:
+		return nil, fmt.Errorf("the snapshot already exists in the emulator database")
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("the snapshot doesn't exist in the emulator database")
+	default:
+		return nil, fmt.Errorf("the emulator failed with status %d, snapshots require starting the emulator with the --snapshot flag", response.StatusCode)
+	}
+
+	var snapshot snapshotResponse
+	err = json.NewDecoder(response.Body).Decode(&snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the emulator admin API response: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+func writeSnapshotMetadata(dir string, metadata *snapshotMetadata) error {
+	data, err := json.MarshalIndent(metadata, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, snapshotMetadataFile), data, 0644)
+}
+
+func readSnapshotMetadata(name string) (*snapshotMetadata, error) {
+	data, err := os.ReadFile(filepath.Join(SnapshotsDir, name, snapshotMetadataFile))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("snapshot %s doesn't exist", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata snapshotMetadata
+	err = json.Unmarshal(data, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata of snapshot %s: %w", name, err)
+	}
+
+	return &metadata, nil
+}
+
+// swapDataDir replaces the data directory with a copy of the snapshot data,
+// the previous data is only removed once the copy is in place.
+func swapDataDir(snapshotDir string, dataDir string) error {
+	restoring := dataDir + ".restoring"
+	previous := dataDir + ".previous"
+	_ = os.RemoveAll(restoring)
+	_ = os.RemoveAll(previous)
+
+	err := copyDir(snapshotDir, restoring)
+	if err != nil {
+		_ = os.RemoveAll(restoring)
+		return fmt.Errorf("failed to copy the snapshot data: %w", err)
+	}
+
+	if _, err := os.Stat(dataDir); err == nil {
+		err = os.Rename(dataDir, previous)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.Rename(restoring, dataDir)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(previous)
+}
+
+// copyDir copies the files of the source directory to the destination directory.
+func copyDir(source string, destination string) error {
+	return filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destination, relative)
+
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+}
+
+// SnapshotResult is the result of a created or restored snapshot.
+type SnapshotResult struct {
+	metadata *snapshotMetadata
+	action   string
+}
+
+func (r *SnapshotResult) JSON() interface{} {
+	return r.metadata
+}
+
+func (r *SnapshotResult) String() string {
+	if r.metadata.Running {
+		return fmt.Sprintf("Snapshot %s %s at block height %d", r.metadata.Name, r.action, r.metadata.Height)
+	}
+	if r.action == "restored" {
+		return fmt.Sprintf("Snapshot %s restored to the data directory %s", r.metadata.Name, snapshotFlags.DBPath)
+	}
+	return fmt.Sprintf("Snapshot %s of the data directory %s %s", r.metadata.Name, snapshotFlags.DBPath, r.action)
+}
+
+func (r *SnapshotResult) Oneliner() string {
+	return r.metadata.Name
+}
+
+// SnapshotListResult is the result of the listed snapshots.
+type SnapshotListResult struct {
+	snapshots []*snapshotMetadata
+}
+
+func (r *SnapshotListResult) JSON() interface{} {
+	return r.snapshots
+}
+
+func (r *SnapshotListResult) String() string {
+	if len(r.snapshots) == 0 {
+		return "No snapshots"
+	}
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Name\tCreated\tEmulator\tStored In\n")
+	for _, snapshot := range r.snapshots {
+		storedIn := "data directory"
+		if snapshot.Running {
+			storedIn = "emulator database"
+		}
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\n",
+			snapshot.Name,
+			snapshot.Created.Format(time.RFC3339),
+			snapshot.EmulatorVersion,
+			storedIn,
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *SnapshotListResult) Oneliner() string {
+	names := make([]string, 0, len(r.snapshots))
+	for _, snapshot := range r.snapshots {
+		names = append(names, snapshot.Name)
+	}
+	return strings.Join(names, ",")
+}
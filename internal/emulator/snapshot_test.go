@@ -0,0 +1,61 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CompatibleVersions(t *testing.T) {
+	assert.True(t, compatibleVersions("v0.43.0", "v0.43.0"))
+	assert.True(t, compatibleVersions("v0.43.0", "v0.43.2"))
+	assert.False(t, compatibleVersions("v0.43.0", "v0.44.0"))
+	assert.False(t, compatibleVersions("v0.43.0", "v1.43.0"))
+	assert.True(t, compatibleVersions("unknown", "unknown"))
+	assert.False(t, compatibleVersions("unknown", "v0.43.0"))
+	assert.False(t, compatibleVersions("(devel)", "v0.43.0"))
+}
+
+func Test_SwapDataDir(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "flowdb")
+	snapshot := filepath.Join(dir, "snapshot")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(data, "nested"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(data, "nested", "000001.sst"), []byte("snapshot"), 0644))
+	require.NoError(t, copyDir(data, snapshot))
+
+	require.NoError(t, os.WriteFile(filepath.Join(data, "nested", "000001.sst"), []byte("changed"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(data, "000002.sst"), []byte("added"), 0644))
+
+	require.NoError(t, swapDataDir(snapshot, data))
+
+	content, err := os.ReadFile(filepath.Join(data, "nested", "000001.sst"))
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", string(content))
+	assert.NoFileExists(t, filepath.Join(data, "000002.sst"))
+	assert.NoDirExists(t, data+".previous")
+	assert.NoDirExists(t, data+".restoring")
+	assert.FileExists(t, filepath.Join(snapshot, "nested", "000001.sst"))
+}
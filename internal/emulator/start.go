@@ -71,7 +71,7 @@ func ConfiguredServiceKey(
 			}
 		}
 	} else {
-		state, err = flowkit.Load(command.Flags.ConfigPaths, loader)
+		state, err = flowkit.Load(command.Flags.ConfigPaths, loader, command.Flags.LoadOptions()...)
 		if err != nil {
 			if errors.Is(err, config.ErrDoesNotExist) {
 				Exitf(1, "🙏 Configuration is missing, initialize it with: 'flow init' and then rerun this command.")
@@ -110,6 +110,21 @@ func init() {
 	Cmd.Use = "emulator"
 	Cmd.Short = "Run Flow network for development"
 	Cmd.GroupID = "tools"
+	Cmd.Run = withDevWallet(withSetup(Cmd.Run))
+	Cmd.Flags().BoolVar(
+		&setupProject,
+		"setup",
+		false,
+		"Create the accounts and deploy the contracts of the emulator deployments once the emulator is listening",
+	)
+	Cmd.Flags().BoolVar(
+		&startDevWallet,
+		"dev-wallet",
+		false,
+		"Start the dev wallet connected to the emulator once the emulator is listening",
+	)
+	Cmd.Flags().UintVar(&devWalletPort, "dev-wallet-port", 8701, "Port the dev wallet listens on")
+	Cmd.AddCommand(SnapshotCmd)
 }
 
 func Exitf(code int, msg string, args ...interface{}) {
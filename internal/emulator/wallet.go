@@ -0,0 +1,228 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	devWallet "github.com/onflow/fcl-dev-wallet/go/wallet"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/tools"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+)
+
+// startDevWallet is set by the dev wallet flag to start the dev wallet together with the emulator.
+var startDevWallet bool
+
+// devWalletPort is the port the dev wallet started with the emulator listens on.
+var devWalletPort uint
+
+// emulatorPort is a port the emulator listens on, named by the component using it.
+type emulatorPort struct {
+	component string
+	port      int
+}
+
+// withDevWallet runs the emulator and starts the dev wallet once the emulator accepts connections
+// if the dev wallet flag is set, the output of the emulator and the dev wallet is prefixed.
+//
+// Both are stopped on interrupt, the dev wallet is stopped once the emulator stopped.
+func withDevWallet(run func(cmd *cobra.Command, args []string)) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if !startDevWallet {
+			run(cmd, args)
+			return
+		}
+
+		grpcPort, _ := cmd.Flags().GetInt("port")
+		restPort, _ := cmd.Flags().GetInt("rest-port")
+		adminPort, _ := cmd.Flags().GetInt("admin-port")
+		ports := []emulatorPort{
+			{component: "emulator gRPC API", port: grpcPort},
+			{component: "emulator REST API", port: restPort},
+			{component: "emulator admin API", port: adminPort},
+			{component: "dev wallet", port: int(devWalletPort)},
+		}
+		if err := checkPorts(ports); err != nil {
+			Exitf(1, err.Error())
+		}
+
+		loader := &afero.Afero{Fs: afero.NewOsFs()}
+		state, err := flowkit.Load(command.Flags.ConfigPaths, loader, command.Flags.LoadOptions()...)
+		if err != nil {
+			Exitf(1, err.Error())
+		}
+		conf, err := tools.DevWalletConfig(state, fmt.Sprintf("http://localhost:%d", restPort))
+		if err != nil {
+			Exitf(1, err.Error())
+		}
+		wallet, err := devWallet.NewHTTPServer(devWalletPort, conf)
+		if err != nil {
+			Exitf(1, err.Error())
+		}
+
+		stdout := os.Stdout
+		walletOut := newPrefixWriter(stdout, "[dev-wallet] ")
+		restoreStdout, err := prefixStdout(stdout, "[emulator]   ")
+		if err != nil {
+			Exitf(1, err.Error())
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := waitAccepting(grpcPort, restPort)
+			if err != nil {
+				_, _ = fmt.Fprintf(walletOut, "%s Dev wallet not started: %s\n", output.ErrorEmoji(), err)
+				return
+			}
+
+			_, _ = fmt.Fprintf(
+				walletOut,
+				"%s Starting dev wallet server on port %d, connected to the emulator REST API on port %d\n",
+				output.SuccessEmoji(),
+				devWalletPort,
+				restPort,
+			)
+			wallet.Start()
+			_, _ = fmt.Fprintf(walletOut, "Dev wallet stopped\n")
+		}()
+
+		run(cmd, args)
+
+		wallet.Stop()
+		wg.Wait()
+		restoreStdout()
+	}
+}
+
+// checkPorts returns an error naming the component of the first port which is already in use.
+func checkPorts(ports []emulatorPort) error {
+	used := make(map[int]string)
+	for _, p := range ports {
+		if component, ok := used[p.port]; ok {
+			return fmt.Errorf("port %d is used by both the %s and the %s", p.port, component, p.component)
+		}
+		used[p.port] = p.component
+
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", p.port))
+		if err != nil {
+			return fmt.Errorf("port %d of the %s is already in use: %w", p.port, p.component, err)
+		}
+		_ = listener.Close()
+	}
+
+	return nil
+}
+
+// waitAccepting waits until the ports on the local host accept connections or the listening timeout passes.
+func waitAccepting(ports ...int) error {
+	deadline := time.Now().Add(listeningTimeout)
+	for _, port := range ports {
+		address := fmt.Sprintf("127.0.0.1:%d", port)
+		for {
+			conn, err := net.DialTimeout("tcp", address, time.Second)
+			if err == nil {
+				_ = conn.Close()
+				break
+			}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("the emulator is not accepting connections on port %d after %s", port, listeningTimeout)
+			}
+			time.Sleep(200 * time.Millisecond)
+		}
+	}
+
+	return nil
+}
+
+// prefixWriter writes the lines written to it prefixed, a line is only written once it's complete.
+type prefixWriter struct {
+	out    io.Writer
+	prefix string
+	buf    []byte
+	mu     *sync.Mutex
+}
+
+// outputMu synchronizes the prefixed writers sharing the output, so their lines are not interleaved.
+var outputMu sync.Mutex
+
+func newPrefixWriter(out io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{out: out, prefix: prefix, mu: &outputMu}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+
+		_, err := fmt.Fprintf(w.out, "%s%s", w.prefix, w.buf[:i+1])
+		if err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// prefixStdout replaces the standard output with a pipe copied to the output with the lines prefixed,
+// so the output of the emulator, which writes to the standard output, can be told apart.
+//
+// The returned function closes the pipe, waits for it to be copied and restores the standard output.
+func prefixStdout(out *os.File, prefix string) (func(), error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	os.Stdout = writer
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		prefixed := newPrefixWriter(out, prefix)
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			_, _ = prefixed.Write(append(scanner.Bytes(), '\n'))
+		}
+	}()
+
+	return func() {
+		_ = writer.Close()
+		<-done
+		os.Stdout = out
+	}, nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckPorts(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer listener.Close()
+	used := listener.Addr().(*net.TCPAddr).Port
+
+	err = checkPorts([]emulatorPort{{component: "emulator gRPC API", port: used}})
+	assert.ErrorContains(t, err, fmt.Sprintf("port %d of the emulator gRPC API is already in use", used))
+
+	err = checkPorts([]emulatorPort{
+		{component: "emulator REST API", port: 0},
+		{component: "dev wallet", port: 0},
+	})
+	assert.EqualError(t, err, "port 0 is used by both the emulator REST API and the dev wallet")
+}
+
+func Test_PrefixWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := newPrefixWriter(&b, "[emulator] ")
+
+	_, err := w.Write([]byte("first\nsec"))
+	require.NoError(t, err)
+	assert.Equal(t, "[emulator] first\n", b.String())
+
+	_, err = w.Write([]byte("ond\nthird\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "[emulator] first\n[emulator] second\n[emulator] third\n", b.String())
+}
@@ -0,0 +1,120 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+)
+
+// maxValueLength is the length the values of the event fields are truncated to in the table view.
+const maxValueLength = 80
+
+// eventField is a field of the event payload decoded by its name.
+type eventField struct {
+	name   string
+	typeID string
+	value  cadence.Value
+}
+
+// decodeEventFields returns the fields of the event payload by their name,
+// or an error if the payload doesn't match the fields of the event type.
+func decodeEventFields(event flow.Event) (fields []eventField, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to decode the event payload: %v", r)
+		}
+	}()
+
+	if event.Value.EventType == nil {
+		return nil, fmt.Errorf("failed to decode the event payload: missing event type")
+	}
+	if len(event.Value.Fields) != len(event.Value.EventType.Fields) {
+		return nil, fmt.Errorf(
+			"failed to decode the event payload: %d values for %d fields",
+			len(event.Value.Fields),
+			len(event.Value.EventType.Fields),
+		)
+	}
+
+	fields = make([]eventField, 0, len(event.Value.Fields))
+	for i, field := range event.Value.EventType.Fields {
+		fields = append(fields, eventField{
+			name:   field.Identifier,
+			typeID: fieldTypeID(field.Type),
+			value:  event.Value.Fields[i],
+		})
+	}
+
+	return fields, nil
+}
+
+// fieldTypeID returns the ID of the field type, or ? if the type is unknown.
+func fieldTypeID(fieldType cadence.Type) (id string) {
+	defer func() {
+		//TODO: onflow/cadence issue #1672
+		//currently getting ID for cadence array will cause panic
+		if recover() != nil {
+			id = "?"
+		}
+	}()
+
+	if fieldType == nil || fieldType.ID() == "" {
+		return "?"
+	}
+	return fieldType.ID()
+}
+
+// simpleValueString returns the value simplified like in the json-simple format,
+// strings are returned without the quotes.
+func simpleValueString(value cadence.Value) string {
+	simple := output.SimpleJSON(value, output.ValueOptions{})
+	if s, ok := simple.(string); ok {
+		return s
+	}
+
+	out, err := json.Marshal(simple)
+	if err != nil {
+		return value.String()
+	}
+	return string(out)
+}
+
+// truncate shortens the value to the maximum length.
+func truncate(value string, length int) string {
+	runes := []rune(value)
+	if len(runes) <= length {
+		return value
+	}
+
+	return string(runes[:length-3]) + "..."
+}
+
+// rawPayload returns the payload of an event which can't be decoded.
+func rawPayload(event flow.Event) interface{} {
+	if json.Valid(event.Payload) {
+		return json.RawMessage(event.Payload)
+	}
+	return string(event.Payload)
+}
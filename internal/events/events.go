@@ -23,12 +23,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 
-	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
@@ -43,26 +44,70 @@ func init() {
 	GetCommand.AddToParent(Cmd)
 }
 
+// FormatJSONSimple is the format of the events with the values converted to simplified JSON, see output.SimpleJSON.
+const FormatJSONSimple = "json-simple"
+
+// GroupByType groups the events of the result by their type.
+const GroupByType = "type"
+
 type EventResult struct {
 	BlockEvents []flow.BlockEvents
 	Events      []flow.Event
+	Format      string
+	Options     output.ValueOptions // options of the values in the json-simple format
+	GroupBy     string
 }
 
 func (e *EventResult) JSON() interface{} {
+	return e.eventsJSON(func(event flow.Event) interface{} {
+		return json.RawMessage(
+			jsoncdc.MustEncode(event.Value),
+		)
+	})
+}
+
+// blockEvent is an event with the height of its block.
+type blockEvent struct {
+	height uint64
+	event  flow.Event
+}
+
+// groupByType returns the sorted types of the events and the events of each type in the block order.
+func (e *EventResult) groupByType() ([]string, map[string][]blockEvent) {
+	groups := make(map[string][]blockEvent)
+	for _, block := range e.BlockEvents {
+		for _, event := range block.Events {
+			groups[event.Type] = append(groups[event.Type], blockEvent{height: block.Height, event: event})
+		}
+	}
+
+	types := make([]string, 0, len(groups))
+	for eventType := range groups {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+
+	return types, groups
+}
+
+func (e *EventResult) eventsJSON(values func(event flow.Event) interface{}) interface{} {
+	if e.GroupBy == GroupByType {
+		types, groups := e.groupByType()
+		result := make(map[string][]interface{}, len(types))
+		for _, eventType := range types {
+			for _, grouped := range groups[eventType] {
+				result[eventType] = append(result[eventType], eventJSON(grouped.height, grouped.event, values))
+			}
+		}
+		return result
+	}
+
 	result := make([]interface{}, 0)
 
 	for _, blockEvent := range e.BlockEvents {
 		if len(blockEvent.Events) > 0 {
 			for _, event := range blockEvent.Events {
-				result = append(result, map[string]interface{}{
-					"blockID":       blockEvent.Height,
-					"index":         event.EventIndex,
-					"type":          event.Type,
-					"transactionId": event.TransactionID.String(),
-					"values": json.RawMessage(
-						jsoncdc.MustEncode(event.Value),
-					),
-				})
+				result = append(result, eventJSON(blockEvent.Height, event, values))
 			}
 		}
 	}
@@ -70,10 +115,69 @@ func (e *EventResult) JSON() interface{} {
 	return result
 }
 
+// eventValues returns the values of the events in the JSON-Cadence format, or simplified in the json-simple format.
+func eventValues(format string, options output.ValueOptions) func(event flow.Event) interface{} {
+	return func(event flow.Event) interface{} {
+		if format == FormatJSONSimple {
+			return output.SimpleJSON(event.Value, options)
+		}
+
+		return json.RawMessage(jsoncdc.MustEncode(event.Value))
+	}
+}
+
+// eventJSON returns the event with its values, or with its raw payload and the error if it can't be decoded.
+func eventJSON(height uint64, event flow.Event, values func(event flow.Event) interface{}) map[string]interface{} {
+	result := map[string]interface{}{
+		"blockID":       height,
+		"index":         event.EventIndex,
+		"type":          event.Type,
+		"transactionId": event.TransactionID.String(),
+	}
+
+	if _, err := decodeEventFields(event); err != nil {
+		result["values"] = rawPayload(event)
+		result["error"] = err.Error()
+		return result
+	}
+
+	result["values"] = values(event)
+	return result
+}
+
+// simpleJSON returns the events with the values converted to simplified JSON.
+func (e *EventResult) simpleJSON() string {
+	result := e.eventsJSON(func(event flow.Event) interface{} {
+		return output.SimpleJSON(event.Value, e.Options)
+	})
+
+	out, _ := json.MarshalIndent(result, "", "  ")
+	return string(out)
+}
+
 func (e *EventResult) String() string {
+	if e.Format == FormatJSONSimple {
+		return e.simpleJSON()
+	}
+
 	var b bytes.Buffer
 	writer := util.CreateTabWriter(&b)
 
+	if e.GroupBy == GroupByType {
+		types, groups := e.groupByType()
+		for _, eventType := range types {
+			_, _ = fmt.Fprintf(writer, "Events %s:", eventType)
+			for _, grouped := range groups[eventType] {
+				_, _ = fmt.Fprintf(writer, "\n    Block\t%d", grouped.height)
+				eventString(writer, grouped.event)
+			}
+			_, _ = fmt.Fprintf(writer, "\n")
+		}
+
+		_ = writer.Flush()
+		return b.String()
+	}
+
 	for _, blockEvent := range e.BlockEvents {
 		if len(blockEvent.Events) > 0 {
 			_, _ = fmt.Fprintf(writer, "Events Block #%v:", blockEvent.Height)
@@ -90,6 +194,10 @@ func (e *EventResult) String() string {
 }
 
 func (e *EventResult) Oneliner() string {
+	if e.Format == FormatJSONSimple {
+		return e.simpleJSON()
+	}
+
 	result := ""
 	for _, blockEvent := range e.BlockEvents {
 		if len(blockEvent.Events) > 0 {
@@ -107,6 +215,10 @@ func (e *EventResult) Oneliner() string {
 	return result
 }
 
+func (e *EventResult) Raw() bool {
+	return e.Format == FormatJSONSimple
+}
+
 func eventsString(writer io.Writer, events []flow.Event) {
 	for _, event := range events {
 		eventString(writer, event)
@@ -117,37 +229,22 @@ func eventString(writer io.Writer, event flow.Event) {
 	_, _ = fmt.Fprintf(writer, "\n    Index\t%d\n", event.EventIndex)
 	_, _ = fmt.Fprintf(writer, "    Type\t%s\n", event.Type)
 	_, _ = fmt.Fprintf(writer, "    Tx ID\t%s\n", event.TransactionID)
-	_, _ = fmt.Fprintf(writer, "    Values\n")
 
-	for i, field := range event.Value.EventType.Fields {
-		value := event.Value.Fields[i]
-		printField(writer, field, value)
+	fields, err := decodeEventFields(event)
+	if err != nil {
+		_, _ = fmt.Fprintf(writer, "    Payload\t%s %s\n", output.WarningEmoji(), err)
+		_, _ = fmt.Fprintf(writer, "\t\t%s\n", event.Payload)
+		return
 	}
-}
-
-func printValues(writer io.Writer, fieldIdentifier, typedId, valueString string) {
-	_, _ = fmt.Fprintf(writer, "\t\t- %s (%s): %s \n", fieldIdentifier, typedId, valueString)
-}
 
-func printField(writer io.Writer, field cadence.Field, value cadence.Value) {
-	v := value.String()
-	var typeId string
-
-	defer func() {
-		if err := recover(); err != nil {
-			printValues(writer, field.Identifier, "?", v)
-		}
-	}()
-
-	if field.Type != nil {
-		//TODO: onflow/cadence issue #1672
-		//currently getting ID for cadence array will cause panic
-		typeId = field.Type.ID()
-	}
-
-	if typeId == "" { // exception for not known typeId workaround for cadence arrays
-		v = fmt.Sprintf("%s\n\t\thex: %x", v, v)
-		typeId = "?"
+	_, _ = fmt.Fprintf(writer, "    Values\n")
+	for _, field := range fields {
+		_, _ = fmt.Fprintf(
+			writer,
+			"\t\t- %s (%s): %s \n",
+			field.name,
+			field.typeID,
+			truncate(simpleValueString(field.value), maxValueLength),
+		)
 	}
-	printValues(writer, field.Identifier, typeId, v)
 }
@@ -0,0 +1,353 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+)
+
+// csvColumns are the columns of every event in a CSV file, followed by a column for each decoded field.
+var csvColumns = []string{"height", "timestamp", "transactionId", "index", "type"}
+
+// checkpoint is the progress of an events file, written next to the file so an interrupted export can be resumed.
+type checkpoint struct {
+	Height  uint64   `json:"height"`            // last block height written to the file
+	Size    int64    `json:"size"`              // size of the file once the events of the height were written
+	Columns []string `json:"columns,omitempty"` // field columns of a CSV file
+}
+
+func checkpointFile(name string) string {
+	return name + ".checkpoint"
+}
+
+// eventsFile writes the events to a file while they are fetched, so the events of large
+// block ranges don't have to be kept in memory.
+//
+// Files with the csv extension are written in the CSV format, other files as JSON lines.
+// The checkpoint is updated after the events of each chunk of blocks are written.
+type eventsFile struct {
+	name    string
+	file    *os.File
+	writer  *bufio.Writer
+	csv     *csv.Writer // nil if the events are written as JSON lines
+	format  string
+	options output.ValueOptions
+	columns []string        // field columns of the CSV file
+	types   map[string]bool // types of the events which fields were discovered
+	header  bool            // whether the header of the CSV file is written
+	counter *countingWriter
+	resumed *checkpoint
+	events  int
+}
+
+// countingWriter counts the bytes written to the file, so the checkpoint has the size of the file.
+type countingWriter struct {
+	io.Writer
+	size int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// openEventsFile creates the events file, or opens it to append the events after its checkpoint if resumed.
+func openEventsFile(name string, format string, options output.ValueOptions, resume bool) (*eventsFile, error) {
+	f := &eventsFile{
+		name:    name,
+		format:  format,
+		options: options,
+		types:   make(map[string]bool),
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		data, err := os.ReadFile(checkpointFile(name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the checkpoint to resume writing %s: %w", name, err)
+		}
+
+		f.resumed = &checkpoint{}
+		if err := json.Unmarshal(data, f.resumed); err != nil {
+			return nil, fmt.Errorf("invalid checkpoint %s: %w", checkpointFile(name), err)
+		}
+
+		// the events written after the checkpoint are written again
+		if err := os.Truncate(name, f.resumed.Size); err != nil {
+			return nil, fmt.Errorf("failed to resume writing %s: %w", name, err)
+		}
+
+		flags = os.O_WRONLY | os.O_APPEND
+		f.columns = f.resumed.Columns
+		f.header = f.resumed.Size > 0
+	}
+
+	file, err := os.OpenFile(name, flags, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the events file: %w", err)
+	}
+
+	f.file = file
+	f.counter = &countingWriter{Writer: file}
+	if f.resumed != nil {
+		f.counter.size = f.resumed.Size
+	}
+	f.writer = bufio.NewWriter(f.counter)
+	if strings.EqualFold(filepath.Ext(name), ".csv") {
+		f.csv = csv.NewWriter(f.writer)
+	}
+
+	return f, nil
+}
+
+// resumeHeight returns the height after the checkpoint if the file is resumed.
+func (f *eventsFile) resumeHeight() (uint64, bool) {
+	if f.resumed == nil {
+		return 0, false
+	}
+	return f.resumed.Height + 1, true
+}
+
+// write writes the events of the blocks and updates the checkpoint to the last block.
+func (f *eventsFile) write(blockEvents []flow.BlockEvents) error {
+	for _, block := range blockEvents {
+		for _, event := range block.Events {
+			var err error
+			if f.csv != nil {
+				err = f.writeCSV(block, event)
+			} else {
+				err = f.writeJSON(block, event)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to write the events file: %w", err)
+			}
+			f.events++
+		}
+	}
+
+	if len(blockEvents) == 0 {
+		return nil
+	}
+
+	if f.csv != nil {
+		f.csv.Flush()
+		if err := f.csv.Error(); err != nil {
+			return fmt.Errorf("failed to write the events file: %w", err)
+		}
+	}
+	if err := f.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write the events file: %w", err)
+	}
+
+	data, err := json.Marshal(checkpoint{
+		Height:  blockEvents[len(blockEvents)-1].Height,
+		Size:    f.counter.size,
+		Columns: f.columns,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(checkpointFile(f.name), data, 0644)
+}
+
+func (f *eventsFile) writeJSON(block flow.BlockEvents, event flow.Event) error {
+	line, err := json.Marshal(eventJSON(block.Height, event, eventValues(f.format, f.options)))
+	if err != nil {
+		return err
+	}
+
+	_, err = f.writer.Write(append(line, '\n'))
+	return err
+}
+
+func (f *eventsFile) writeCSV(block flow.BlockEvents, event flow.Event) error {
+	if !f.header {
+		f.discoverColumns(event)
+		if err := f.csv.Write(append(csvColumns, f.columns...)); err != nil {
+			return err
+		}
+		f.header = true
+	}
+
+	// only the columns of the event are written if its payload can't be decoded
+	fields, _ := decodeEventFields(event)
+	if !f.types[event.Type] {
+		f.discoverColumns(event)
+	}
+
+	values := make(map[string]string, len(fields))
+	for _, field := range fields {
+		values[field.name] = simpleValueString(field.value)
+	}
+
+	record := []string{
+		strconv.FormatUint(block.Height, 10),
+		block.BlockTimestamp.UTC().Format(time.RFC3339),
+		event.TransactionID.String(),
+		strconv.Itoa(event.EventIndex),
+		event.Type,
+	}
+	for _, column := range f.columns {
+		record = append(record, values[column])
+	}
+
+	return f.csv.Write(record)
+}
+
+// discoverColumns adds a column for each field of the event which doesn't have a column yet.
+//
+// The columns are discovered from the first event of each type, and the fields which are only
+// discovered once the header is written are appended to the header once the file is closed.
+func (f *eventsFile) discoverColumns(event flow.Event) {
+	f.types[event.Type] = true
+
+	fields, err := decodeEventFields(event)
+	if err != nil {
+		return
+	}
+
+	for _, field := range fields {
+		if indexOf(f.columns, field.name) < 0 {
+			f.columns = append(f.columns, field.name)
+		}
+	}
+}
+
+func (f *eventsFile) close() error {
+	if f.csv != nil {
+		f.csv.Flush()
+	}
+	if err := f.writer.Flush(); err != nil {
+		_ = f.file.Close()
+		return fmt.Errorf("failed to write the events file: %w", err)
+	}
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	if f.csv != nil && f.header {
+		return rewriteHeader(f.name, append(csvColumns, f.columns...))
+	}
+	return nil
+}
+
+// rewriteHeader replaces the header of the CSV file if the columns were discovered after the header was written.
+func rewriteHeader(name string, header []string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	line, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if strings.TrimRight(line, "\r\n") == strings.Join(header, ",") {
+		return nil
+	}
+
+	tmp, err := os.Create(name + ".tmp")
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(tmp)
+	_ = writer.Write(header)
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if _, err := io.Copy(tmp, reader); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(name+".tmp", name)
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// FileResult is the result of the events written to a file.
+type FileResult struct {
+	File     string
+	Events   int
+	Blocks   uint64
+	Duration time.Duration
+}
+
+func (r *FileResult) JSON() interface{} {
+	return map[string]interface{}{
+		"file":     r.File,
+		"events":   r.Events,
+		"blocks":   r.Blocks,
+		"duration": r.Duration.String(),
+	}
+}
+
+func (r *FileResult) String() string {
+	seconds := r.Duration.Seconds()
+	if seconds == 0 {
+		seconds = 1
+	}
+
+	return fmt.Sprintf(
+		"%s Wrote %d events of %d blocks to %s in %s, %.1f blocks/s, %.1f events/s",
+		output.SuccessEmoji(),
+		r.Events,
+		r.Blocks,
+		r.File,
+		r.Duration.Round(time.Millisecond),
+		float64(r.Blocks)/seconds,
+		float64(r.Events)/seconds,
+	)
+}
+
+func (r *FileResult) Oneliner() string {
+	return fmt.Sprintf("%d events written to %s", r.Events, r.File)
+}
@@ -0,0 +1,98 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func Test_EventsFile(t *testing.T) {
+	withdrawn := *tests.NewEvent(
+		1,
+		"TokensWithdrawn",
+		[]cadence.Field{{Identifier: "amount", Type: cadence.UFix64Type{}}, {Identifier: "from", Type: cadence.AddressType{}}},
+		[]cadence.Value{cadence.UFix64(100000000), cadence.NewAddress(flow.HexToAddress("02"))},
+	)
+
+	t.Run("CSV", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "events.csv")
+
+		file, err := openEventsFile(name, "", output.ValueOptions{}, false)
+		require.NoError(t, err)
+		require.NoError(t, file.write([]flow.BlockEvents{{Height: 1, Events: []flow.Event{newDepositEvent(0, "01", 100000000)}}}))
+		require.NoError(t, file.write([]flow.BlockEvents{{Height: 2, Events: []flow.Event{withdrawn}}}))
+		require.NoError(t, file.close())
+
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "height,timestamp,transactionId,index,type,amount,to,from", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "1,"))
+		assert.True(t, strings.HasSuffix(lines[1], ",S.test.TokensDeposited,1.00000000,0x0000000000000001"))
+		assert.True(t, strings.HasSuffix(lines[2], ",S.test.TokensWithdrawn,1.00000000,,0x0000000000000002"))
+		assert.Equal(t, 2, file.events)
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "events.jsonl")
+
+		file, err := openEventsFile(name, FormatJSONSimple, output.ValueOptions{}, false)
+		require.NoError(t, err)
+		require.NoError(t, file.write([]flow.BlockEvents{{Height: 1, Events: []flow.Event{newDepositEvent(0, "01", 100000000)}}}))
+		// events written after the checkpoint of an interrupted export
+		_, err = file.writer.WriteString("{\"partial\"")
+		require.NoError(t, err)
+		require.NoError(t, file.close())
+
+		file, err = openEventsFile(name, FormatJSONSimple, output.ValueOptions{}, true)
+		require.NoError(t, err)
+		height, ok := file.resumeHeight()
+		require.True(t, ok)
+		assert.Equal(t, uint64(2), height)
+
+		require.NoError(t, file.write([]flow.BlockEvents{{Height: 2, Events: []flow.Event{withdrawn}}}))
+		require.NoError(t, file.close())
+
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"type":"S.test.TokensDeposited"`)
+		assert.Contains(t, lines[1], `"values":{"amount":"1.00000000","from":"0x0000000000000002"}`)
+	})
+
+	t.Run("Resume without checkpoint", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "events.csv")
+
+		_, err := openEventsFile(name, "", output.ValueOptions{}, true)
+		assert.ErrorContains(t, err, "failed to read the checkpoint to resume writing")
+	})
+}
@@ -0,0 +1,109 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// fieldFilter matches the events with the decoded field of the name equal to the value.
+type fieldFilter struct {
+	name  string
+	value string
+}
+
+// parseFieldFilters parses the filters in the name=value format.
+func parseFieldFilters(filters []string) ([]fieldFilter, error) {
+	parsed := make([]fieldFilter, 0, len(filters))
+	for _, filter := range filters {
+		name, value, ok := strings.Cut(filter, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid filter %s, the filter must be in the field=value format", filter)
+		}
+
+		parsed = append(parsed, fieldFilter{
+			name:  strings.TrimSpace(name),
+			value: strings.Trim(strings.TrimSpace(value), `"`),
+		})
+	}
+
+	return parsed, nil
+}
+
+// matches returns true if the field of the event is equal to the value of the filter.
+//
+// Addresses are compared after normalizing them, so 0x01 matches 0x0000000000000001.
+// Events which can't be decoded don't match.
+func (f fieldFilter) matches(event flow.Event) bool {
+	fields, err := decodeEventFields(event)
+	if err != nil {
+		return false
+	}
+
+	for _, field := range fields {
+		if field.name != f.name {
+			continue
+		}
+
+		value := field.value
+		if optional, ok := value.(cadence.Optional); ok {
+			value = optional.Value
+		}
+		if address, ok := value.(cadence.Address); ok {
+			return flow.HexToAddress(f.value) == flow.Address(address)
+		}
+		if value == nil {
+			return f.value == "nil"
+		}
+
+		return simpleValueString(value) == f.value
+	}
+
+	return false
+}
+
+// filterEvents returns the blocks with the events matching all the filters.
+func filterEvents(blockEvents []flow.BlockEvents, filters []fieldFilter) []flow.BlockEvents {
+	if len(filters) == 0 {
+		return blockEvents
+	}
+
+	filtered := make([]flow.BlockEvents, 0, len(blockEvents))
+	for _, block := range blockEvents {
+		events := make([]flow.Event, 0, len(block.Events))
+	events:
+		for _, event := range block.Events {
+			for _, filter := range filters {
+				if !filter.matches(event) {
+					continue events
+				}
+			}
+			events = append(events, event)
+		}
+
+		block.Events = events
+		filtered = append(filtered, block)
+	}
+
+	return filtered
+}
@@ -0,0 +1,130 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func newDepositEvent(index int, to string, amount uint64) flow.Event {
+	return *tests.NewEvent(
+		index,
+		"TokensDeposited",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "to", Type: &cadence.OptionalType{Type: cadence.AddressType{}}},
+		},
+		[]cadence.Value{
+			cadence.UFix64(amount),
+			cadence.NewOptional(cadence.NewAddress(flow.HexToAddress(to))),
+		},
+	)
+}
+
+func Test_FieldFilters(t *testing.T) {
+	blocks := []flow.BlockEvents{{
+		Height: 1,
+		Events: []flow.Event{
+			newDepositEvent(0, "01", 100000000),
+			newDepositEvent(1, "02", 100000000),
+			newDepositEvent(2, "01", 200000000),
+		},
+	}}
+
+	t.Run("Address", func(t *testing.T) {
+		filters, err := parseFieldFilters([]string{"to=0x0000000000000001"})
+		require.NoError(t, err)
+
+		filtered := filterEvents(blocks, filters)
+		require.Len(t, filtered[0].Events, 2)
+		assert.Equal(t, 0, filtered[0].Events[0].EventIndex)
+		assert.Equal(t, 2, filtered[0].Events[1].EventIndex)
+	})
+
+	t.Run("All filters", func(t *testing.T) {
+		filters, err := parseFieldFilters([]string{"to=0x01", `amount="2.00000000"`})
+		require.NoError(t, err)
+
+		filtered := filterEvents(blocks, filters)
+		require.Len(t, filtered[0].Events, 1)
+		assert.Equal(t, 2, filtered[0].Events[0].EventIndex)
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		filters, err := parseFieldFilters([]string{"from=0x01"})
+		require.NoError(t, err)
+
+		filtered := filterEvents(blocks, filters)
+		assert.Empty(t, filtered[0].Events)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := parseFieldFilters([]string{"to"})
+		assert.EqualError(t, err, "invalid filter to, the filter must be in the field=value format")
+	})
+}
+
+func Test_DecodeEvents(t *testing.T) {
+	t.Run("Fields", func(t *testing.T) {
+		var b bytes.Buffer
+		eventString(&b, newDepositEvent(0, "01", 100000000))
+
+		assert.Contains(t, b.String(), "- amount (UFix64): 1.00000000")
+		assert.Contains(t, b.String(), "- to (Address?): 0x0000000000000001")
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		event := *tests.NewEvent(
+			0,
+			"Minted",
+			[]cadence.Field{{Identifier: "metadata", Type: cadence.StringType{}}},
+			[]cadence.Value{cadence.String(strings.Repeat("a", 100))},
+		)
+
+		var b bytes.Buffer
+		eventString(&b, event)
+
+		assert.Contains(t, b.String(), "- metadata (String): "+strings.Repeat("a", maxValueLength-3)+"... ")
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		event := flow.Event{
+			Type:    "A.0000000000000001.Test.Broken",
+			Payload: []byte(`{"type":"Event"}`),
+		}
+
+		var b bytes.Buffer
+		eventString(&b, event)
+		assert.Contains(t, b.String(), "failed to decode the event payload: missing event type")
+		assert.Contains(t, b.String(), `{"type":"Event"}`)
+
+		result := &EventResult{BlockEvents: []flow.BlockEvents{{Height: 1, Events: []flow.Event{event}}}}
+		values := result.JSON().([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "failed to decode the event payload: missing event type", values["error"])
+	})
+}
@@ -0,0 +1,159 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+const defaultFollowInterval = 2 * time.Second
+
+// eventKey identifies an event, so the events fetched by overlapping polls are only printed once.
+type eventKey struct {
+	transactionID flow.Identifier
+	index         int
+}
+
+// follower prints the events of the new sealed blocks.
+type follower struct {
+	format    string
+	options   output.ValueOptions
+	jsonLines bool
+	seen      map[eventKey]bool // events printed by the previous poll
+}
+
+// followEvents polls the sealed block height and prints the events of the new blocks until interrupted.
+//
+// The events are fetched from the start height, or from the latest sealed block if the start height is zero.
+// Each poll fetches at most the blocks of a batch for each worker, so catching up with the sealed height
+// can be interrupted between the polls. The last processed height is printed once interrupted.
+func followEvents(
+	types []string,
+	start uint64,
+	services *services.Services,
+	interval time.Duration,
+	handler func([]flow.BlockEvents) error,
+) error {
+	if interval <= 0 {
+		interval = defaultFollowInterval
+	}
+
+	// the progress of each poll would be printed between the events
+	services.SetLogger(output.NewStdoutLogger(output.NoneLog))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if start == 0 {
+		latest, err := services.Blocks.GetLatestBlockHeight()
+		if err != nil {
+			return err
+		}
+		start = latest
+	}
+
+	if !eventsFlags.JSONLines {
+		fmt.Printf("Following events %v from height %d (Ctrl-C to stop)\n", types, start)
+	}
+
+	next := start
+	maxRange := eventsFlags.Batch * uint64(eventsFlags.Workers)
+	for {
+		latest, err := services.Blocks.GetLatestBlockHeight()
+		if err != nil {
+			return err
+		}
+
+		caughtUp := true
+		if latest >= next {
+			end := latest
+			if end-next+1 > maxRange {
+				end = next + maxRange - 1
+				caughtUp = false
+			}
+
+			err = services.Events.Stream(types, next, end, eventsFlags.Batch, eventsFlags.Workers, handler)
+			if err != nil {
+				return err
+			}
+			next = end + 1
+		}
+
+		if !caughtUp && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			// printed to the standard error so the piped events only contain the events
+			_, _ = fmt.Fprintf(os.Stderr, "Stopped following at height %d, resume with --start %d\n", next-1, next)
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// print prints the events of the blocks, skipping the events printed by the previous poll.
+func (f *follower) print(blockEvents []flow.BlockEvents) error {
+	seen := make(map[eventKey]bool)
+	for _, block := range blockEvents {
+		for _, event := range block.Events {
+			key := eventKey{transactionID: event.TransactionID, index: event.EventIndex}
+			seen[key] = true
+			if f.seen[key] {
+				continue
+			}
+
+			if f.jsonLines {
+				line, err := json.Marshal(eventJSON(block.Height, event, eventValues(f.format, f.options)))
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(line))
+				continue
+			}
+
+			fmt.Printf(
+				"[%s] height %d %s tx %s index %d %s\n",
+				block.BlockTimestamp.Format(time.RFC3339),
+				block.Height,
+				event.Type,
+				event.TransactionID,
+				event.EventIndex,
+				event.Value,
+			)
+		}
+	}
+
+	if len(seen) > 0 {
+		f.seen = seen
+	}
+	return nil
+}
@@ -20,40 +20,76 @@ package events
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
 type flagsEvents struct {
-	Start   uint64 `flag:"start" info:"Start block height"`
-	End     uint64 `flag:"end" info:"End block height"`
-	Last    uint64 `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
-	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
-	Batch   uint64 `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+	Start     string        `default:"" flag:"start" info:"Start block height, or a block relative to the latest block like latest-1000"`
+	End       string        `default:"" flag:"end" info:"End block height, or a block relative to the latest block like latest"`
+	Last      uint64        `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
+	Workers   int           `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+	Batch     uint64        `default:"25" flag:"batch" info:"Number of blocks each worker will fetch, at most 250"`
+	File      string        `default:"" flag:"output-file" info:"File the events are written to while they are fetched, in the CSV format if the file has the csv extension or as JSON lines otherwise"`
+	Resume    bool          `default:"false" flag:"resume" info:"Resume writing the output file from its checkpoint"`
+	Follow    bool          `default:"false" flag:"follow" info:"Follow the events of the new sealed blocks from the latest sealed block or the start height"`
+	Interval  time.Duration `flag:"interval" info:"Interval of polling the sealed block height when following events, for example 2s"`
+	JSONLines bool          `default:"false" flag:"json-lines" info:"Print the followed events as JSON lines"`
+	Contract  []string      `default:"" flag:"contract" info:"Fetch all the event types declared by the contract, like A.1654653399040a61.FlowToken.*"`
+	GroupBy   string        `default:"" flag:"group-by" info:"Group the events, options: \"type\""`
+	Where     []string      `default:"" flag:"where" info:"Only get the events with a decoded field equal to the value, in the field=value format, for example to=0x1234"`
+	Format    string        `default:"" flag:"format" info:"Format of the events, options: \"json-simple\""`
+	TypeHints bool          `default:"false" flag:"type-hints" info:"add the type of composite values in the _type field of the json-simple format"`
+	Depth     int           `default:"0" flag:"depth" info:"maximum nesting of values decoded in the json-simple format, unlimited if zero"`
 }
 
 var eventsFlags = flagsEvents{}
 
+// maxBatch is the maximum number of blocks of the event queries accepted by the access nodes.
+const maxBatch = 250
+
 var GetCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:   "get <event_name>",
 		Short: "Get events in a block range",
-		Args:  cobra.MinimumNArgs(1),
+		Args:  cobra.ArbitraryArgs,
 		Example: `#fetch events from the latest 10 blocks is the default behavior
 flow events get A.1654653399040a61.FlowToken.TokensDeposited
 
 #specify manual start and stop blocks
 flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11559500 --end 11559600
 
+#specify the blocks relative to the latest sealed block
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start latest-1000 --end latest
+
 #in order to get and event from the 20 latest blocks on a network run
 flow events get A.1654653399040a61.FlowToken.TokensDeposited --last 20 --network mainnet
 
 #if you want to fetch multiple event types that is done by sending in more events. Even fetching will be done in parallel.
 flow events get A.1654653399040a61.FlowToken.TokensDeposited A.1654653399040a61.FlowToken.TokensWithdrawn
+
+#fetch all the event types of a contract grouped by the type
+flow events get --contract A.1654653399040a61.FlowToken.* --group-by type --network mainnet
+
+#get the deposits to an account
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --where to=0x1654653399040a61 --network mainnet
+
+#write the events of a large range to a file while they are fetched
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11000000 --end 11559600 --workers 8 --batch 250 --output-file events.jsonl
+
+#export the events to a CSV file, and resume the export if it is interrupted
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11000000 --end 11559600 --output-file events.csv
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11000000 --end 11559600 --output-file events.csv --resume
+
+#follow the events of the new sealed blocks as JSON lines
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --follow --json-lines --network mainnet
 	`,
 	},
 	Flags: &eventsFlags,
@@ -66,9 +102,63 @@ func get(
 	_ command.GlobalFlags,
 	services *services.Services,
 ) (command.Result, error) {
-	var err error
-	start := eventsFlags.Start
-	end := eventsFlags.End
+	if eventsFlags.Format != "" && eventsFlags.Format != FormatJSONSimple {
+		return nil, fmt.Errorf("invalid format %s, options: json-simple", eventsFlags.Format)
+	}
+
+	if eventsFlags.GroupBy != "" && eventsFlags.GroupBy != GroupByType {
+		return nil, fmt.Errorf("invalid group-by %s, options: type", eventsFlags.GroupBy)
+	}
+
+	types, err := eventTypes(args, eventsFlags.Contract, services)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := parseFieldFilters(eventsFlags.Where)
+	if err != nil {
+		return nil, err
+	}
+	filtered := func(handler func([]flow.BlockEvents) error) func([]flow.BlockEvents) error {
+		return func(blockEvents []flow.BlockEvents) error {
+			return handler(filterEvents(blockEvents, filters))
+		}
+	}
+
+	if eventsFlags.Batch == 0 || eventsFlags.Batch > maxBatch {
+		return nil, fmt.Errorf("the batch must be between 1 and %d blocks", maxBatch)
+	}
+
+	options := output.ValueOptions{
+		TypeHints: eventsFlags.TypeHints,
+		Depth:     eventsFlags.Depth,
+	}
+
+	start, err := resolveHeight(eventsFlags.Start, services)
+	if err != nil {
+		return nil, err
+	}
+	end, err := resolveHeight(eventsFlags.End, services)
+	if err != nil {
+		return nil, err
+	}
+
+	if eventsFlags.Follow {
+		if eventsFlags.End != "" || eventsFlags.File != "" {
+			return nil, fmt.Errorf("the follow flag can't be combined with the end and output-file flags")
+		}
+
+		f := &follower{
+			format:    eventsFlags.Format,
+			options:   options,
+			jsonLines: eventsFlags.JSONLines,
+		}
+		return nil, followEvents(types, start, services, eventsFlags.Interval, filtered(f.print))
+	}
+	if eventsFlags.Interval != 0 || eventsFlags.JSONLines {
+		return nil, fmt.Errorf("the interval and json-lines flags can only be used with the follow flag")
+	}
+
 	last := eventsFlags.Last
 
 	// handle if not passing start and end
@@ -86,10 +176,100 @@ func get(
 		return nil, fmt.Errorf("please provide either both start and end for range or only last flag")
 	}
 
-	events, err := services.Events.Get(args, start, end, eventsFlags.Batch, eventsFlags.Workers)
+	if eventsFlags.File != "" {
+		return writeEventsFile(types, start, end, options, filtered, services)
+	}
+	if eventsFlags.Resume {
+		return nil, fmt.Errorf("the resume flag can only be used with the output-file flag")
+	}
+
+	events, err := services.Events.Get(types, start, end, eventsFlags.Batch, eventsFlags.Workers)
 	if err != nil {
 		return nil, err
 	}
 
-	return &EventResult{BlockEvents: events}, nil
+	return &EventResult{
+		BlockEvents: filterEvents(events, filters),
+		Format:      eventsFlags.Format,
+		Options:     options,
+		GroupBy:     eventsFlags.GroupBy,
+	}, nil
+}
+
+// resolveHeight returns the height of the block identifier of a flag, or zero if the flag is not set.
+func resolveHeight(identifier string, services *services.Services) (uint64, error) {
+	if identifier == "" {
+		return 0, nil
+	}
+	return services.Blocks.ResolveHeight(identifier)
+}
+
+// writeEventsFile writes the events of the block range to the output file while they are fetched,
+// starting after the checkpoint of the file if resumed.
+func writeEventsFile(
+	types []string,
+	start uint64,
+	end uint64,
+	options output.ValueOptions,
+	filtered func(func([]flow.BlockEvents) error) func([]flow.BlockEvents) error,
+	services *services.Services,
+) (command.Result, error) {
+	file, err := openEventsFile(eventsFlags.File, eventsFlags.Format, options, eventsFlags.Resume)
+	if err != nil {
+		return nil, err
+	}
+
+	if height, ok := file.resumeHeight(); ok {
+		start = height
+	}
+
+	started := time.Now()
+	if start <= end {
+		err = services.Events.Stream(types, start, end, eventsFlags.Batch, eventsFlags.Workers, filtered(file.write))
+	}
+	if closeErr := file.close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FileResult{
+		File:     eventsFlags.File,
+		Events:   file.events,
+		Duration: time.Since(started),
+	}
+	if start <= end {
+		result.Blocks = end - start + 1
+	}
+	return result, nil
+}
+
+// eventTypes returns the event types of the arguments and the event types declared by the contracts, without duplicates.
+func eventTypes(args []string, contracts []string, services *services.Services) ([]string, error) {
+	types := make([]string, 0, len(args))
+	seen := make(map[string]bool)
+	add := func(eventTypes []string) {
+		for _, eventType := range eventTypes {
+			if !seen[eventType] {
+				seen[eventType] = true
+				types = append(types, eventType)
+			}
+		}
+	}
+
+	add(args)
+	for _, contract := range contracts {
+		contractTypes, err := services.Events.ContractEventTypes(contract)
+		if err != nil {
+			return nil, err
+		}
+		add(contractTypes)
+	}
+
+	if len(types) == 0 {
+		return nil, fmt.Errorf("provide the event types as arguments or the contracts with the contract flag")
+	}
+
+	return types, nil
 }
@@ -19,11 +19,12 @@
 package keys
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
 	"strings"
 
 	"github.com/onflow/flow-go-sdk"
-	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
@@ -32,19 +33,22 @@ import (
 )
 
 type flagsDecode struct {
-	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
-	FromFile string `default:"" flag:"from-file" info:"Load key from file"`
+	SigAlgo     string `default:"ECDSA_P256" flag:"sig-algo" info:"Deprecated, the signature algorithm of PEM and DER keys is read from the key"`
+	FromFile    string `default:"" flag:"from-file" info:"Load key from file"`
+	FromAccount string `default:"" flag:"from-account" info:"Address of the account to fetch the key from instead of decoding it"`
+	Index       int    `default:"0" flag:"index" info:"Index of the key fetched from the account"`
 }
 
 var decodeFlags = flagsDecode{}
 
 var DecodeCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:       "decode <rlp|pem> <encoded public key>",
-		Short:     "Decode an encoded public key",
-		Args:      cobra.RangeArgs(1, 2),
-		ValidArgs: []string{"rlp", "pem"},
-		Example:   "flow keys decode rlp f847b8408...2402038203e8",
+		Use:       "decode <rlp|pem|der> <encoded key>",
+		Short:     "Decode an encoded public key, or a PEM or DER encoded private key",
+		Args:      cobra.RangeArgs(0, 2),
+		ValidArgs: []string{"rlp", "pem", "der"},
+		Example: "flow keys decode rlp f847b8408...2402038203e8\nflow keys decode pem --from-file key.pem\n" +
+			"flow keys decode --from-account 0xf8d6e0586b0a20c7 --index 1",
 	},
 	Flags: &decodeFlags,
 	Run:   decode,
@@ -56,20 +60,42 @@ func decode(
 	_ command.GlobalFlags,
 	services *services.Services,
 ) (command.Result, error) {
-	encoding := args[0]
+	if decodeFlags.FromAccount != "" {
+		if len(args) > 0 || decodeFlags.FromFile != "" {
+			return nil, fmt.Errorf("the from account flag can't be combined with an encoded key")
+		}
+
+		address := flow.HexToAddress(decodeFlags.FromAccount)
+		accountKey, err := services.Keys.GetAccountKey(address, decodeFlags.Index)
+		if err != nil {
+			return nil, err
+		}
+
+		return &KeyResult{
+			publicKey:  accountKey.PublicKey,
+			accountKey: accountKey,
+			address:    address,
+		}, nil
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("provide the encoding of the key, or the from account flag")
+	}
+
+	encoding := strings.ToLower(args[0])
 	fromFile := decodeFlags.FromFile
 
-	var encoded string
+	var encoded []byte
 	if len(args) > 1 {
-		encoded = args[1]
+		encoded = []byte(args[1])
 	}
 
 	/* TODO(sideninja) from file flag should be remove and should be replaced with $(echo file)
 	   but cobra has an issue with parsing pem content as it recognize it as flag due to ---- characters */
-	if encoded != "" && fromFile != "" {
+	if encoded != nil && fromFile != "" {
 		return nil, fmt.Errorf("can not pass both command argument and from file flag")
 	}
-	if encoded == "" && fromFile == "" {
+	if encoded == nil && fromFile == "" {
 		return nil, fmt.Errorf("provide argument for encoded key or use from file flag")
 	}
 
@@ -78,31 +104,46 @@ func decode(
 		if err != nil {
 			return nil, err
 		}
-		encoded = strings.TrimSpace(string(e))
+		encoded = e
 	}
 
-	var accountKey *flow.AccountKey
-	var err error
-	switch strings.ToLower(encoding) {
+	switch encoding {
 	case "pem":
-		sigAlgo := crypto.StringToSignatureAlgorithm(decodeFlags.SigAlgo)
-		if sigAlgo == crypto.UnknownSignatureAlgorithm {
-			return nil, fmt.Errorf("invalid signature algorithm: %s", decodeFlags.SigAlgo)
+		return decodeKey(bytes.TrimSpace(encoded), flowkit.EncodingPEM, services)
+	case "der":
+		// the DER bytes are either hex encoded or the raw content of the file
+		if der, err := hex.DecodeString(strings.TrimSpace(string(encoded))); err == nil {
+			encoded = der
 		}
-
-		accountKey, err = services.Keys.DecodePEM(encoded, sigAlgo)
+		return decodeKey(encoded, flowkit.EncodingDER, services)
 	case "rlp":
-		accountKey, err = services.Keys.DecodeRLP(encoded)
+		accountKey, err := services.Keys.DecodeRLP(strings.TrimSpace(string(encoded)))
+		if err != nil {
+			return nil, err
+		}
+
+		return &KeyResult{
+			publicKey:  accountKey.PublicKey,
+			accountKey: accountKey,
+		}, nil
 	default:
-		return nil, fmt.Errorf("encoding type not supported. Valid encoding: RLP and PEM")
+		return nil, fmt.Errorf("encoding type not supported. Valid encoding: RLP, PEM and DER")
 	}
+}
 
+func decodeKey(encoded []byte, encoding flowkit.KeyEncoding, services *services.Services) (command.Result, error) {
+	privateKey, publicKey, err := services.Keys.DecodeKey(encoded, encoding)
 	if err != nil {
 		return nil, err
 	}
 
 	return &KeyResult{
-		publicKey:  accountKey.PublicKey,
-		accountKey: accountKey,
-	}, err
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		accountKey: &flow.AccountKey{
+			PublicKey: publicKey,
+			SigAlgo:   publicKey.Algorithm(),
+			Weight:    -1,
+		},
+	}, nil
 }
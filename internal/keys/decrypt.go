@@ -0,0 +1,86 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsDecrypt struct{}
+
+var decryptFlags = flagsDecrypt{}
+
+var DecryptCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "decrypt <account name>",
+		Short:   "Decrypt the encrypted private key of the account and save it in plain text to the configuration",
+		Example: "flow keys decrypt alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &decryptFlags,
+	RunS:  decrypt,
+}
+
+func decrypt(
+	args []string,
+	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	_ *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	key := account.Key()
+	if key.Type() != config.KeyTypeEncrypted {
+		return nil, fmt.Errorf("account %s key is not encrypted", account.Name())
+	}
+
+	// the passphrase is read from the environment or prompted
+	privateKey, err := key.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	account.SetKey(flowkit.NewHexAccountKeyFromPrivateKey(key.Index(), key.HashAlgo(), *privateKey))
+	state.Accounts().AddOrUpdate(account)
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptionResult{
+		result: fmt.Sprintf(
+			"%s Private key of the account %s decrypted and saved in plain text, don't share the configuration with anyone!",
+			output.StopEmoji(),
+			account.Name(),
+		),
+	}, nil
+}
@@ -19,28 +19,39 @@
 package keys
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"strings"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
 type flagsDerive struct {
 	KeySigAlgo string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	Mnemonic   string `flag:"mnemonic" info:"BIP39 mnemonic to derive the key from instead of a private key"`
+	Index      int    `default:"0" flag:"index" info:"Index of the key derived from the mnemonic on the path m/44'/539'/0'/0/<index>"`
+	Check      string `default:"" flag:"check" info:"Address of an account to check for keys matching the derived public key"`
 }
 
 var deriveFlags = flagsDerive{}
 
 var DeriveCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "derive <encoded private key>",
-		Short:   "Derive public key from a private key",
-		Args:    cobra.ExactArgs(1),
-		Example: "flow keys derive 4247b8408...2402038203e8",
+		Use:   "derive [<encoded private key>]",
+		Short: "Derive public key from a private key or a key from a mnemonic",
+		Args:  cobra.MaximumNArgs(1),
+		Example: "flow keys derive 4247b8408...2402038203e8\n" +
+			"flow keys derive 4247b8408...2402038203e8 --check 0xf8d6e0586b0a20c7\n" +
+			"flow keys derive --mnemonic \"<24 words>\" --index 1",
 	},
 	Flags: &deriveFlags,
 	Run:   derive,
@@ -58,10 +69,136 @@ func derive(
 		return nil, fmt.Errorf("invalid signature algorithm: %s", deriveFlags.KeySigAlgo)
 	}
 
-	parsedPrivateKey, err := services.Keys.ParsePrivateKey(args[0], sigAlgo)
+	var result *KeyResult
+	var err error
+	if deriveFlags.Mnemonic != "" {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("provide either a private key or the mnemonic flag, not both")
+		}
+
+		result, err = deriveFromMnemonic(deriveFlags.Mnemonic, deriveFlags.Index, sigAlgo, services)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("provide the private key or the mnemonic flag")
+		}
+
+		parsedPrivateKey, err := services.Keys.ParsePrivateKey(args[0], sigAlgo)
+		if err != nil {
+			return nil, err
+		}
+
+		result = &KeyResult{privateKey: parsedPrivateKey, publicKey: parsedPrivateKey.PublicKey()}
+	}
+
+	if deriveFlags.Check == "" {
+		return result, nil
+	}
+
+	address := flow.HexToAddress(deriveFlags.Check)
+	account, err := services.Accounts.Get(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckResult{
+		KeyResult: result,
+		address:   address,
+		matches:   matchingKeys(account, result.publicKey),
+	}, nil
+}
+
+// deriveFromMnemonic derives the key with the index the same way as Flow wallets and the Ledger app.
+func deriveFromMnemonic(
+	mnemonic string,
+	index int,
+	sigAlgo crypto.SignatureAlgorithm,
+	s *services.Services,
+) (*KeyResult, error) {
+	// indexes from 2^31 are hardened and not used for the keys on the path
+	if index < 0 || index > math.MaxInt32 {
+		return nil, fmt.Errorf("invalid key index %d, must be between 0 and %d", index, math.MaxInt32)
+	}
+
+	path := services.DerivationPath(uint32(index))
+	privateKey, err := s.Keys.DerivePrivateKeyFromMnemonic(strings.Join(strings.Fields(mnemonic), " "), sigAlgo, path)
 	if err != nil {
 		return nil, err
 	}
 
-	return &KeyResult{privateKey: parsedPrivateKey, publicKey: parsedPrivateKey.PublicKey()}, nil
+	return &KeyResult{privateKey: privateKey, publicKey: privateKey.PublicKey(), derivationPath: path}, nil
+}
+
+// matchingKeys returns the keys of the account with the public key, including the revoked keys.
+func matchingKeys(account *flow.Account, publicKey crypto.PublicKey) []*flow.AccountKey {
+	matches := make([]*flow.AccountKey, 0)
+	for _, key := range account.Keys {
+		if key.PublicKey.Equals(publicKey) {
+			matches = append(matches, key)
+		}
+	}
+	return matches
+}
+
+// CheckResult is the derived key with the keys of the checked account matching the public key.
+type CheckResult struct {
+	*KeyResult
+	address flow.Address
+	matches []*flow.AccountKey
+}
+
+func (r *CheckResult) JSON() interface{} {
+	result := make(map[string]interface{})
+	for name, value := range r.KeyResult.JSON().(map[string]string) {
+		result[name] = value
+	}
+
+	matches := make([]map[string]interface{}, 0, len(r.matches))
+	for _, key := range r.matches {
+		matches = append(matches, map[string]interface{}{
+			"index":         key.Index,
+			"weight":        key.Weight,
+			"revoked":       key.Revoked,
+			"hashAlgorithm": key.HashAlgo.String(),
+		})
+	}
+
+	result["address"] = r.address.String()
+	result["matches"] = matches
+
+	return result
+}
+
+func (r *CheckResult) String() string {
+	var b bytes.Buffer
+	b.WriteString(r.KeyResult.String())
+
+	writer := util.CreateTabWriter(&b)
+	if len(r.matches) == 0 {
+		_, _ = fmt.Fprintf(writer, "\n%s The public key doesn't match any key of account 0x%s\n", output.ErrorEmoji(), r.address)
+	} else {
+		_, _ = fmt.Fprintf(writer, "\n%s The public key matches %d key(s) of account 0x%s\n", output.SuccessEmoji(), len(r.matches), r.address)
+		for _, key := range r.matches {
+			_, _ = fmt.Fprintf(writer, "Key %d\tWeight %d\tHash Algorithm %s\tRevoked %t\n", key.Index, key.Weight, key.HashAlgo, key.Revoked)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *CheckResult) Oneliner() string {
+	indexes := make([]string, 0, len(r.matches))
+	for _, key := range r.matches {
+		indexes = append(indexes, fmt.Sprintf("%d", key.Index))
+	}
+
+	return fmt.Sprintf(
+		"%s, Account: 0x%s, Matching Keys: [%s]",
+		strings.TrimSuffix(r.KeyResult.Oneliner(), ", "),
+		r.address,
+		strings.Join(indexes, ", "),
+	)
 }
@@ -0,0 +1,168 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsEncode struct {
+	SigAlgo string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the key"`
+	Format  string `default:"pkcs8" flag:"format" info:"Format of encoded private keys, sec1 or pkcs8"`
+	ToFile  string `default:"" flag:"to-file" info:"Write the encoded key to the file"`
+}
+
+var encodeFlags = flagsEncode{}
+
+var EncodeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:       "encode <pem|der> <hex private or public key>",
+		Short:     "Encode a private or public key to PEM or DER",
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: []string{"pem", "der"},
+		Example:   "flow keys encode pem 4247b8408...2402038203e8 --format sec1",
+	},
+	Flags: &encodeFlags,
+	Run:   encode,
+}
+
+// hex lengths of the flow encoded keys on the supported curves
+const (
+	privateKeyHexLength = 64
+	publicKeyHexLength  = 128
+)
+
+func encode(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	encoding := flowkit.KeyEncoding(strings.ToLower(args[0]))
+	key := strings.TrimPrefix(args[1], "0x")
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(encodeFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", encodeFlags.SigAlgo)
+	}
+
+	var encoded []byte
+	var publicKey crypto.PublicKey
+	switch len(key) {
+	case privateKeyHexLength:
+		privateKey, err := services.Keys.ParsePrivateKey(key, sigAlgo)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded, err = services.Keys.EncodePrivateKey(privateKey, encoding, flowkit.PrivateKeyFormat(encodeFlags.Format))
+		if err != nil {
+			return nil, err
+		}
+		publicKey = privateKey.PublicKey()
+	case publicKeyHexLength:
+		var err error
+		publicKey, err = crypto.DecodePublicKeyHex(sigAlgo, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key: %w", err)
+		}
+
+		encoded, err = services.Keys.EncodePublicKey(publicKey, encoding)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid key, provide a hex encoded private key of %d characters or public key of %d characters", privateKeyHexLength, publicKeyHexLength)
+	}
+
+	if encodeFlags.ToFile != "" {
+		err := readerWriter.WriteFile(encodeFlags.ToFile, encoded, 0600)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &EncodeResult{
+		encoding:  encoding,
+		encoded:   encoded,
+		publicKey: publicKey,
+		file:      encodeFlags.ToFile,
+	}, nil
+}
+
+// EncodeResult is the key encoded to PEM or DER.
+type EncodeResult struct {
+	encoding  flowkit.KeyEncoding
+	encoded   []byte
+	publicKey crypto.PublicKey
+	file      string // file the key is written to
+}
+
+// value returns the PEM text, or the hex of the DER bytes which can't be printed.
+func (r *EncodeResult) value() string {
+	if r.encoding == flowkit.EncodingDER {
+		return hex.EncodeToString(r.encoded)
+	}
+	return string(r.encoded)
+}
+
+func (r *EncodeResult) JSON() interface{} {
+	result := map[string]string{
+		"encoding": string(r.encoding),
+		"encoded":  r.value(),
+		"public":   hex.EncodeToString(r.publicKey.Encode()),
+	}
+	if r.file != "" {
+		result["file"] = r.file
+	}
+
+	return result
+}
+
+func (r *EncodeResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if r.file != "" {
+		_, _ = fmt.Fprintf(writer, "Key encoded to %s and written to %s\n", strings.ToUpper(string(r.encoding)), r.file)
+	} else {
+		_, _ = fmt.Fprintf(writer, "%s\n", strings.TrimSpace(r.value()))
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *EncodeResult) Oneliner() string {
+	if r.file != "" {
+		return r.file
+	}
+	return strings.TrimSpace(r.value())
+}
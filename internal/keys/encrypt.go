@@ -0,0 +1,111 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsEncrypt struct{}
+
+var encryptFlags = flagsEncrypt{}
+
+var EncryptCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "encrypt <account name>",
+		Short:   "Encrypt the private key of the account in the configuration with a passphrase",
+		Example: "flow keys encrypt alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &encryptFlags,
+	RunS:  encrypt,
+}
+
+func encrypt(
+	args []string,
+	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	_ *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	key := account.Key()
+	if key.Type() != config.KeyTypeHex {
+		return nil, fmt.Errorf("only hex keys can be encrypted, account %s has a %s key", account.Name(), key.Type())
+	}
+
+	privateKey, err := key.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase := os.Getenv(flowkit.PassphraseEnv)
+	if passphrase == "" {
+		passphrase, err = output.NewPassphrasePrompt()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	encryptedKey, err := flowkit.NewEncryptedAccountKey(key.Index(), key.HashAlgo(), *privateKey, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	account.SetKey(encryptedKey)
+	state.Accounts().AddOrUpdate(account)
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptionResult{
+		result: fmt.Sprintf("%s Private key of the account %s encrypted", output.OkEmoji(), account.Name()),
+	}, nil
+}
+
+type EncryptionResult struct {
+	result string
+}
+
+func (r *EncryptionResult) JSON() interface{} {
+	return nil
+}
+
+func (r *EncryptionResult) String() string {
+	return r.result
+}
+
+func (r *EncryptionResult) Oneliner() string {
+	return r.result
+}
@@ -19,20 +19,31 @@
 package keys
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
 type flagsGenerate struct {
-	Mnemonic       string `flag:"mnemonic" info:"Mnemonic seed to use"`
-	DerivationPath string `default:"m/44'/539'/0'/0/0" flag:"derivationPath" info:"Derivation path"`
+	Seed           string `flag:"seed" info:"Deterministic seed phrase"`
+	Mnemonic       bool   `default:"false" flag:"mnemonic" info:"Generate a 24 word BIP39 mnemonic and derive the key from it"`
+	DerivationPath string `default:"m/44'/539'/0'/0/0" flag:"derivationPath" info:"Derivation path of the key derived from the mnemonic"`
 	KeySigAlgo     string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	KeyHashAlgo    string `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm included in the generated keys of a batch"`
+	Count          int    `default:"0" flag:"count" info:"Number of keys to generate in a batch, written as rows of the output format"`
+	OutputFormat   string `default:"csv" flag:"output-format" info:"Format of the keys generated in a batch: csv or jsonl"`
+	ToFile         string `default:"" flag:"to-file" info:"File to write the keys generated in a batch to, instead of the standard output"`
 }
 
 var generateFlags = flagsGenerate{}
@@ -41,14 +52,15 @@ var GenerateCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "generate",
 		Short:   "Generate a new key-pair",
-		Example: "flow keys generate",
+		Example: "flow keys generate\nflow keys generate --mnemonic\nflow keys generate --count 500 --output-format csv --to-file keys.csv",
+		Args:    cobra.MaximumNArgs(1),
 	},
 	Flags: &generateFlags,
 	Run:   generate,
 }
 
 func generate(
-	_ []string,
+	args []string,
 	_ flowkit.ReaderWriter,
 	_ command.GlobalFlags,
 	services *services.Services,
@@ -58,13 +70,48 @@ func generate(
 		return nil, fmt.Errorf("invalid signature algorithm: %s", generateFlags.KeySigAlgo)
 	}
 
-	var err error
-	mnemonic := generateFlags.Mnemonic
-	if mnemonic == "" {
-		mnemonic, err = services.Keys.GetMnemonic()
+	if len(args) > 0 && !generateFlags.Mnemonic {
+		return nil, fmt.Errorf("unexpected argument %s", args[0])
+	}
+
+	if generateFlags.Count != 0 {
+		return generateBatch(sigAlgo, services)
+	}
+
+	if !generateFlags.Mnemonic {
+		privateKey, err := services.Keys.Generate(generateFlags.Seed, sigAlgo)
 		if err != nil {
 			return nil, err
 		}
+
+		return &KeyResult{privateKey: privateKey, publicKey: privateKey.PublicKey()}, nil
+	}
+
+	if generateFlags.Seed != "" {
+		return nil, fmt.Errorf("the seed flag can't be combined with the mnemonic flag")
+	}
+
+	// the mnemonic flag used to take the mnemonic to derive the key from, which is now done by the derive command,
+	// as the flag doesn't take a value anymore the mnemonic is passed as the argument
+	if len(args) > 0 {
+		output.NewStdoutLogger(output.InfoLog).Info(fmt.Sprintf(
+			"%s Deriving a key with 'flow keys generate --mnemonic <mnemonic>' is deprecated, use 'flow keys derive --mnemonic <mnemonic>' instead\n",
+			output.WarningEmoji(),
+		))
+
+		privateKey, err := services.Keys.DerivePrivateKeyFromMnemonic(args[0], sigAlgo, generateFlags.DerivationPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return &KeyResult{privateKey: privateKey, publicKey: privateKey.PublicKey(), derivationPath: generateFlags.DerivationPath}, nil
+	}
+
+	// the mnemonic is only part of the result and never saved to the configuration,
+	// it should be written down by the user as it's the backup of the key
+	mnemonic, err := services.Keys.GetMnemonic()
+	if err != nil {
+		return nil, err
 	}
 
 	privateKey, err := services.Keys.DerivePrivateKeyFromMnemonic(mnemonic, sigAlgo, generateFlags.DerivationPath)
@@ -72,7 +119,139 @@ func generate(
 		return nil, err
 	}
 
-	pubKey := privateKey.PublicKey()
-	return &KeyResult{privateKey: privateKey, publicKey: pubKey, mnemonic: mnemonic, derivationPath: generateFlags.DerivationPath}, nil
+	return &KeyResult{
+		privateKey:     privateKey,
+		publicKey:      privateKey.PublicKey(),
+		mnemonic:       mnemonic,
+		derivationPath: generateFlags.DerivationPath,
+	}, nil
+}
+
+// generateBatch streams the generated keys to the file or the standard output, row by row.
+func generateBatch(sigAlgo crypto.SignatureAlgorithm, s *services.Services) (command.Result, error) {
+	if generateFlags.Count < 0 {
+		return nil, fmt.Errorf("invalid count %d, must be a positive number", generateFlags.Count)
+	}
+	if generateFlags.Mnemonic {
+		return nil, fmt.Errorf("the count flag can't be combined with the mnemonic flag")
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(generateFlags.KeyHashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", generateFlags.KeyHashAlgo)
+	}
+
+	// the progress isn't shown when the keys are written to the standard output, so it doesn't mix with the keys
+	var out io.Writer = os.Stdout
+	keys := services.NewKeys(nil, nil, output.NewStdoutLogger(output.NoneLog))
+	if generateFlags.ToFile != "" {
+		keys = s.Keys
+		// the file contains private keys, so it's only readable by the owner
+		file, err := os.OpenFile(generateFlags.ToFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the file %s: %w", generateFlags.ToFile, err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	writer, err := newKeyWriter(generateFlags.OutputFormat, out, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = keys.GenerateBatch(generateFlags.Count, generateFlags.Seed, sigAlgo, writer.Write)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return nil, err
+	}
+
+	// the keys written to the standard output are the result
+	if generateFlags.ToFile == "" {
+		return nil, nil
+	}
+
+	return &BatchResult{count: generateFlags.Count, file: generateFlags.ToFile}, nil
+}
+
+// BatchResult is the summary of the keys generated in a batch and written to a file.
+type BatchResult struct {
+	count int
+	file  string
+}
+
+func (r *BatchResult) JSON() interface{} {
+	return map[string]interface{}{
+		"count": r.count,
+		"file":  r.file,
+	}
+}
+
+func (r *BatchResult) String() string {
+	return fmt.Sprintf("%s Generated %d keys written to %s", output.OkEmoji(), r.count, r.file)
+}
+
+func (r *BatchResult) Oneliner() string {
+	return r.String()
+}
+
+// keyWriter writes the generated keys in the output format.
+type keyWriter interface {
+	Write(key services.GeneratedKey) error
+	Flush() error
+}
+
+func newKeyWriter(format string, out io.Writer, hashAlgo crypto.HashAlgorithm) (keyWriter, error) {
+	switch format {
+	case "csv":
+		writer := &csvKeyWriter{writer: csv.NewWriter(out), hashAlgo: hashAlgo}
+		return writer, writer.writer.Write([]string{"index", "privateKey", "publicKey", "sigAlgo", "hashAlgo"})
+	case "jsonl":
+		return &jsonKeyWriter{encoder: json.NewEncoder(out), hashAlgo: hashAlgo}, nil
+	default:
+		return nil, fmt.Errorf("invalid output format %s, must be csv or jsonl", format)
+	}
+}
+
+type csvKeyWriter struct {
+	writer   *csv.Writer
+	hashAlgo crypto.HashAlgorithm
+}
+
+func (w *csvKeyWriter) Write(key services.GeneratedKey) error {
+	return w.writer.Write([]string{
+		strconv.Itoa(key.Index),
+		key.PrivateKey.String(),
+		key.PrivateKey.PublicKey().String(),
+		key.PrivateKey.Algorithm().String(),
+		w.hashAlgo.String(),
+	})
+}
+
+func (w *csvKeyWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type jsonKeyWriter struct {
+	encoder  *json.Encoder
+	hashAlgo crypto.HashAlgorithm
+}
+
+func (w *jsonKeyWriter) Write(key services.GeneratedKey) error {
+	return w.encoder.Encode(map[string]interface{}{
+		"index":      key.Index,
+		"privateKey": key.PrivateKey.String(),
+		"publicKey":  key.PrivateKey.PublicKey().String(),
+		"sigAlgo":    key.PrivateKey.Algorithm().String(),
+		"hashAlgo":   w.hashAlgo.String(),
+	})
+}
 
+func (w *jsonKeyWriter) Flush() error {
+	return nil
 }
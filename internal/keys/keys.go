@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"strconv"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -41,7 +42,12 @@ var Cmd = &cobra.Command{
 func init() {
 	GenerateCommand.AddToParent(Cmd)
 	DecodeCommand.AddToParent(Cmd)
+	EncodeCommand.AddToParent(Cmd)
 	DeriveCommand.AddToParent(Cmd)
+	EncryptCommand.AddToParent(Cmd)
+	DecryptCommand.AddToParent(Cmd)
+	ListCommand.AddToParent(Cmd)
+	Cmd.AddCommand(StoreCmd)
 }
 
 type KeyResult struct {
@@ -50,11 +56,12 @@ type KeyResult struct {
 	accountKey     *flow.AccountKey
 	mnemonic       string
 	derivationPath string
+	address        flow.Address // account the key is fetched from
 }
 
 func (k *KeyResult) JSON() interface{} {
 	result := make(map[string]string)
-	result["public"] = hex.EncodeToString(k.privateKey.PublicKey().Encode())
+	result["public"] = hex.EncodeToString(k.publicKey.Encode())
 
 	if k.privateKey != nil {
 		result["private"] = hex.EncodeToString(k.privateKey.Encode())
@@ -68,6 +75,20 @@ func (k *KeyResult) JSON() interface{} {
 		result["derivationPath"] = k.derivationPath
 	}
 
+	if k.accountKey != nil {
+		result["signatureAlgorithm"] = k.accountKey.SigAlgo.String()
+		result["hashAlgorithm"] = k.accountKey.HashAlgo.String()
+		result["revoked"] = strconv.FormatBool(k.accountKey.Revoked)
+		if k.accountKey.Weight >= 0 {
+			result["weight"] = strconv.Itoa(k.accountKey.Weight)
+		}
+	}
+
+	if k.address != flow.EmptyAddress {
+		result["address"] = k.address.String()
+		result["index"] = strconv.Itoa(k.accountKey.Index)
+	}
+
 	return result
 }
 
@@ -80,6 +101,11 @@ func (k *KeyResult) String() string {
 		_, _ = fmt.Fprintf(writer, "Private Key \t %x \n", k.privateKey.Encode())
 	}
 
+	if k.address != flow.EmptyAddress {
+		_, _ = fmt.Fprintf(writer, "Address \t 0x%s\n", k.address)
+		_, _ = fmt.Fprintf(writer, "Index \t %d\n", k.accountKey.Index)
+	}
+
 	_, _ = fmt.Fprintf(writer, "Public Key \t %x \n", k.publicKey.Encode())
 
 	if k.mnemonic != "" {
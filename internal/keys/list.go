@@ -0,0 +1,156 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+// ledgerPathFormat is the derivation path of the keys used by the Flow app and Flow Port.
+const ledgerPathFormat = "m/44'/539'/513'/0/%d"
+
+type flagsList struct {
+	Ledger   bool   `default:"false" flag:"ledger" info:"List the public keys on the connected Ledger device"`
+	Index    []int  `flag:"index" info:"Indexes of the keys on the path m/44'/539'/513'/0/<index>, the flag can be repeated, defaults to 0"`
+	SigAlgo  string `default:"ECDSA_secp256k1" flag:"sig-algo" info:"Signature algorithm of the keys"`
+	HashAlgo string `default:"SHA2_256" flag:"hash-algo" info:"Hash algorithm of the keys"`
+}
+
+var listFlags = flagsList{}
+
+var ListCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "list --ledger",
+		Short:   "List the public keys on a hardware wallet",
+		Args:    cobra.NoArgs,
+		Example: "flow keys list --ledger --index 0 --index 1",
+	},
+	Flags: &listFlags,
+	Run:   list,
+}
+
+func list(
+	_ []string,
+	_ flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	if !listFlags.Ledger {
+		return nil, fmt.Errorf("only the keys of Ledger devices can be listed, use the --ledger flag")
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(listFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", listFlags.SigAlgo)
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(listFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", listFlags.HashAlgo)
+	}
+
+	ledger, err := flowkit.OpenLedger()
+	if err != nil {
+		return nil, err
+	}
+	defer ledger.Close()
+
+	indexes := listFlags.Index
+	if len(indexes) == 0 {
+		indexes = []int{0}
+	}
+
+	result := &ListResult{sigAlgo: sigAlgo, hashAlgo: hashAlgo}
+	for _, index := range indexes {
+		if index < 0 || index > math.MaxInt32 {
+			return nil, fmt.Errorf("invalid key index %d, must be between 0 and %d", index, math.MaxInt32)
+		}
+
+		path := fmt.Sprintf(ledgerPathFormat, index)
+		publicKey, err := ledger.PublicKey(path, sigAlgo, hashAlgo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the public key on the path %s: %w", path, err)
+		}
+
+		result.keys = append(result.keys, ledgerKey{derivationPath: path, publicKey: publicKey})
+	}
+
+	return result, nil
+}
+
+type ledgerKey struct {
+	derivationPath string
+	publicKey      crypto.PublicKey
+}
+
+// ListResult is the public keys on the derivation paths of the hardware wallet.
+type ListResult struct {
+	sigAlgo  crypto.SignatureAlgorithm
+	hashAlgo crypto.HashAlgorithm
+	keys     []ledgerKey
+}
+
+func (r *ListResult) JSON() interface{} {
+	keys := make([]map[string]string, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, map[string]string{
+			"derivationPath":     key.derivationPath,
+			"public":             fmt.Sprintf("%x", key.publicKey.Encode()),
+			"signatureAlgorithm": r.sigAlgo.String(),
+			"hashAlgorithm":      r.hashAlgo.String(),
+		})
+	}
+
+	return keys
+}
+
+func (r *ListResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Signature Algorithm\t %s\n", r.sigAlgo)
+	_, _ = fmt.Fprintf(writer, "Hash Algorithm\t %s\n\n", r.hashAlgo)
+	_, _ = fmt.Fprintf(writer, "Derivation Path\tPublic Key\n")
+	for _, key := range r.keys {
+		_, _ = fmt.Fprintf(writer, "%s\t%x\n", key.derivationPath, key.publicKey.Encode())
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *ListResult) Oneliner() string {
+	keys := make([]string, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, fmt.Sprintf("%s:%x", key.derivationPath, key.publicKey.Encode()))
+	}
+
+	return strings.Join(keys, ", ")
+}
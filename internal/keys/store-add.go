@@ -0,0 +1,100 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsStoreAdd struct {
+	PrivateKey string `default:"" flag:"private-key" info:"Hex encoded private key to store, a new key is generated if not provided"`
+	KeySigAlgo string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+}
+
+var storeAddFlags = flagsStoreAdd{}
+
+var StoreAddCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "add <name>",
+		Short:   "Encrypt a private key with a passphrase and add it to the keystore",
+		Example: "flow keys store add alice\nflow keys store add alice --private-key 4247b8408...2402038203e8",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &storeAddFlags,
+	Run:   storeAdd,
+}
+
+func storeAdd(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	services *services.Services,
+) (command.Result, error) {
+	keystore, err := keystoreReaderWriter(readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(storeAddFlags.KeySigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", storeAddFlags.KeySigAlgo)
+	}
+
+	var privateKey crypto.PrivateKey
+	if storeAddFlags.PrivateKey != "" {
+		privateKey, err = services.Keys.ParsePrivateKey(storeAddFlags.PrivateKey, sigAlgo)
+	} else {
+		privateKey, err = services.Keys.Generate("", sigAlgo)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase := os.Getenv(flowkit.PassphraseEnv)
+	if passphrase == "" {
+		passphrase, err = output.NewPassphrasePrompt()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entry, err := flowkit.AddKeystoreEntry(keystore, args[0], privateKey, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoreResult{
+		entries: []*flowkit.KeystoreEntry{entry},
+		message: fmt.Sprintf(
+			"%s Key %s added to the keystore, use it in an account with the key {\"type\": \"keystore\", \"keystore\": \"%s\"}",
+			output.OkEmoji(),
+			entry.Name,
+			entry.Name,
+		),
+	}, nil
+}
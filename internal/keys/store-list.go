@@ -0,0 +1,61 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsStoreList struct{}
+
+var storeListFlags = flagsStoreList{}
+
+var StoreListCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "list",
+		Short:   "List the keys in the keystore",
+		Example: "flow keys store list",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &storeListFlags,
+	Run:   storeList,
+}
+
+func storeList(
+	_ []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	keystore, err := keystoreReaderWriter(readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := flowkit.ListKeystoreEntries(keystore)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoreResult{entries: entries}, nil
+}
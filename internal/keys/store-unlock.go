@@ -0,0 +1,77 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsStoreUnlock struct{}
+
+var storeUnlockFlags = flagsStoreUnlock{}
+
+var StoreUnlockCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "unlock <name>",
+		Short:   "Check the passphrase of a key in the keystore",
+		Example: "flow keys store unlock alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &storeUnlockFlags,
+	Run:   storeUnlock,
+}
+
+func storeUnlock(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+) (command.Result, error) {
+	entry, err := flowkit.ReadKeystoreEntry(readerWriter, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase := os.Getenv(flowkit.PassphraseEnv)
+	if passphrase == "" {
+		passphrase, err = output.PassphrasePrompt()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the decrypted key is only checked and never shown
+	_, err = entry.Unlock(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoreResult{
+		entries: []*flowkit.KeystoreEntry{entry},
+		message: fmt.Sprintf("%s Key %s unlocked with the passphrase", output.OkEmoji(), entry.Name),
+	}, nil
+}
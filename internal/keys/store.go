@@ -0,0 +1,104 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+var StoreCmd = &cobra.Command{
+	Use:              "store <add|list|unlock>",
+	Short:            "Manage the private keys in the encrypted keystore",
+	Example:          "flow keys store add alice",
+	Args:             cobra.ExactArgs(1),
+	TraverseChildren: true,
+}
+
+func init() {
+	StoreAddCommand.AddToParent(StoreCmd)
+	StoreListCommand.AddToParent(StoreCmd)
+	StoreUnlockCommand.AddToParent(StoreCmd)
+}
+
+// keystoreReaderWriter returns the file system of the keystore, which is the file system of the commands.
+func keystoreReaderWriter(readerWriter flowkit.ReaderWriter) (flowkit.KeystoreReaderWriter, error) {
+	keystore, ok := readerWriter.(flowkit.KeystoreReaderWriter)
+	if !ok {
+		return nil, fmt.Errorf("the keystore is not supported on this file system")
+	}
+
+	return keystore, nil
+}
+
+// StoreResult is the keystore entries of the store commands.
+type StoreResult struct {
+	entries []*flowkit.KeystoreEntry
+	message string
+}
+
+func (r *StoreResult) JSON() interface{} {
+	entries := make([]map[string]string, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, map[string]string{
+			"name":               entry.Name,
+			"signatureAlgorithm": entry.SigAlgo.String(),
+			"public":             fmt.Sprintf("%x", entry.PublicKey.Encode()),
+			"location":           flowkit.KeystorePath(entry.Name),
+		})
+	}
+
+	return entries
+}
+
+func (r *StoreResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if len(r.entries) == 0 {
+		_, _ = fmt.Fprintf(writer, "No keys in the keystore %s\n", flowkit.KeystoreDir)
+	} else {
+		_, _ = fmt.Fprintf(writer, "Name\tSignature Algorithm\tPublic Key\n")
+		for _, entry := range r.entries {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%x\n", entry.Name, entry.SigAlgo, entry.PublicKey.Encode())
+		}
+	}
+
+	if r.message != "" {
+		_, _ = fmt.Fprintf(writer, "\n%s\n", r.message)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *StoreResult) Oneliner() string {
+	names := make([]string, 0, len(r.entries))
+	for _, entry := range r.entries {
+		names = append(names, fmt.Sprintf("%s:%x", entry.Name, entry.PublicKey.Encode()))
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -19,10 +19,15 @@
 package project
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit"
@@ -30,10 +35,25 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/project"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
 type flagsDeploy struct {
-	Update bool `flag:"update" default:"false" info:"use update flag to update existing contracts"`
+	Update            bool     `flag:"update" default:"false" info:"use update flag to update existing contracts"`
+	Force             bool     `flag:"force" default:"false" info:"use force flag to deploy contracts even if deployed code is unchanged"`
+	Resume            bool     `flag:"resume" default:"false" info:"resume failed deployment skipping contracts already deployed by the previous run"`
+	RollbackOnFailure bool     `flag:"rollback-on-failure" default:"false" info:"remove contracts deployed during the run if the deployment fails"`
+	MaxContractSize   int      `flag:"max-contract-size" default:"1500000" info:"maximum size of a contract in bytes"`
+	Strict            bool     `flag:"strict" default:"false" info:"fail instead of warning when a contract exceeds the maximum size"`
+	Workers           int      `flag:"workers" default:"1" info:"number of independent contracts deployed concurrently"`
+	Prune             bool     `flag:"prune" default:"false" info:"remove contracts deployed on the accounts which are no longer in the deployment"`
+	DryRun            bool     `flag:"dry-run" default:"false" info:"print the deployment plan without sending any transactions"`
+	Manifest          string   `flag:"manifest" default:"" info:"path of the deployment manifest updated with the deployed contracts"`
+	Offline           bool     `flag:"offline" default:"false" info:"only use cached contracts for contracts with remote locations"`
+	SortBy            string   `flag:"sort-by" default:"" info:"order of contracts not depending on each other, options: \"name\", \"location\""`
+	NoDefaultAliases  bool     `flag:"no-default-aliases" default:"false" info:"don't use default aliases for imported core contracts"`
+	Tags              []string `flag:"tags" default:"" info:"only deploy contracts with any of the comma-separated deployment tags"`
+	Exclude           []string `flag:"exclude" default:"" info:"comma-separated names of contracts excluded from the deployment"`
 }
 
 var deployFlags = flagsDeploy{}
@@ -53,8 +73,9 @@ func deploy(
 	_ flowkit.ReaderWriter,
 	globalFlags command.GlobalFlags,
 	srv *services.Services,
-	_ *flowkit.State,
+	state *flowkit.State,
 ) (command.Result, error) {
+	state.RemoteContracts().SetOffline(deployFlags.Offline)
 
 	//precheck for standard contract on Mainnet
 	if globalFlags.Network == config.DefaultMainnetNetwork().Name {
@@ -65,7 +86,36 @@ func deploy(
 
 	}
 
-	c, err := srv.Project.Deploy(globalFlags.Network, deployFlags.Update)
+	sortBy, err := project.ParseSortOrder(deployFlags.SortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	options := services.DeployOptions{
+		Update:            deployFlags.Update,
+		Force:             deployFlags.Force,
+		Resume:            deployFlags.Resume,
+		RollbackOnFailure: deployFlags.RollbackOnFailure,
+		Journal:           journalPath(globalFlags.ConfigPaths),
+		MaxContractSize:   deployFlags.MaxContractSize,
+		Strict:            deployFlags.Strict,
+		Workers:           deployFlags.Workers,
+		Manifest:          deployFlags.Manifest,
+		SortBy:            sortBy,
+		NoDefaultAliases:  deployFlags.NoDefaultAliases,
+		Tags:              deployFlags.Tags,
+		Exclude:           deployFlags.Exclude,
+	}
+
+	if deployFlags.DryRun {
+		plan, err := srv.Project.Plan(globalFlags.Network, options)
+		if err != nil {
+			return nil, err
+		}
+		return &PlanResult{network: globalFlags.Network, plan: plan}, nil
+	}
+
+	c, err := srv.Project.Deploy(globalFlags.Network, options)
 	if err != nil {
 		var projectErr *services.ProjectDeploymentError
 		if errors.As(err, &projectErr) {
@@ -77,14 +127,66 @@ func deploy(
 					err.Error(),
 				)
 			}
+			if !deployFlags.RollbackOnFailure {
+				return nil, fmt.Errorf("failed deploying all contracts, use the --resume flag to continue the deployment from the failed contracts")
+			}
 			return nil, fmt.Errorf("failed deploying all contracts")
 		}
+
+		var collisionErr *project.NameCollisionError
+		if errors.As(err, &collisionErr) {
+			collisions := make([]string, len(collisionErr.Collisions))
+			for i, collision := range collisionErr.Collisions {
+				collisions[i] = collision.String()
+			}
+			return nil, fmt.Errorf(
+				"contract names must be unique on an account and when imported by name, name collisions:\n%s",
+				strings.Join(collisions, "\n"),
+			)
+		}
 		return nil, err
 	}
 
+	if deployFlags.Prune {
+		err = prune(globalFlags, srv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &DeployResult{c}, nil
 }
 
+// prune removes contracts deployed on the deployment accounts which were removed from the deployment.
+func prune(globalFlags command.GlobalFlags, srv *services.Services) error {
+	stale, err := srv.Project.StaleContracts(globalFlags.Network)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(stale))
+	for i, contract := range stale {
+		names[i] = fmt.Sprintf("%s (0x%s)", contract.Name, contract.Account.Address())
+	}
+
+	if !globalFlags.Yes && !output.RemoveStaleContractsPrompt(names) {
+		return nil
+	}
+
+	return srv.Project.RemoveStaleContracts(stale)
+}
+
+// journalPath returns the path of the deployment journal stored next to the project configuration.
+func journalPath(configPaths []string) string {
+	if len(configPaths) == 0 {
+		return services.DefaultJournalPath
+	}
+	return filepath.Join(filepath.Dir(configPaths[len(configPaths)-1]), services.DefaultJournalPath)
+}
+
 type DeployResult struct {
 	contracts []*project.Contract
 }
@@ -106,3 +208,72 @@ func (r *DeployResult) String() string {
 func (r *DeployResult) Oneliner() string {
 	return ""
 }
+
+type PlanResult struct {
+	network string
+	plan    []services.PlannedContract
+}
+
+func (r *PlanResult) JSON() interface{} {
+	result := make([]map[string]interface{}, 0, len(r.plan))
+
+	for _, planned := range r.plan {
+		args := make([]string, len(planned.Contract.Args))
+		for i, arg := range planned.Contract.Args {
+			args[i] = arg.String()
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":    planned.Contract.Name,
+			"account": planned.Contract.AccountName,
+			"address": planned.Contract.AccountAddress.String(),
+			"update":  planned.Update,
+			"args":    args,
+			"imports": planned.Imports,
+		})
+	}
+
+	return result
+}
+
+func (r *PlanResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Deployment plan for network %s\n\n", r.network)
+
+	for i, planned := range r.plan {
+		contract := planned.Contract
+		_, _ = fmt.Fprintf(
+			writer,
+			"%d. %s\t -> %s (0x%s)\t [%s]\n",
+			i+1,
+			contract.Name,
+			contract.AccountName,
+			contract.AccountAddress,
+			map[bool]string{true: "update", false: "new"}[planned.Update],
+		)
+
+		for j, arg := range contract.Args {
+			_, _ = fmt.Fprintf(writer, "\tArgument %d\t %s\n", j, arg)
+		}
+
+		locations := maps.Keys(planned.Imports)
+		sort.Strings(locations)
+		for _, location := range locations {
+			_, _ = fmt.Fprintf(writer, "\tImport %s\t 0x%s\n", location, planned.Imports[location])
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *PlanResult) Oneliner() string {
+	names := make([]string, len(r.plan))
+	for i, planned := range r.plan {
+		names[i] = planned.Contract.Name
+	}
+
+	return strings.Join(names, ", ")
+}
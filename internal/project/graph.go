@@ -0,0 +1,108 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/project"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsGraph struct {
+	Format string `flag:"format" default:"dot" info:"Graph format, options: \"dot\", \"mermaid\""`
+}
+
+var graphFlags = flagsGraph{}
+
+var GraphCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "deployment-graph",
+		Short:   "Output the contracts dependency graph in DOT or Mermaid format",
+		Example: "flow project deployment-graph --network testnet --format mermaid --save graph.mmd",
+	},
+	Flags: &graphFlags,
+	RunS:  graph,
+}
+
+func graph(
+	_ []string,
+	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	srv *services.Services,
+	_ *flowkit.State,
+) (command.Result, error) {
+	if graphFlags.Format != "dot" && graphFlags.Format != "mermaid" {
+		return nil, fmt.Errorf("invalid graph format %s, options: dot, mermaid", graphFlags.Format)
+	}
+
+	g, err := srv.Project.DeploymentGraph(globalFlags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphResult{graph: g, format: graphFlags.Format}, nil
+}
+
+type GraphResult struct {
+	graph  *project.DependencyGraph
+	format string
+}
+
+func (r *GraphResult) JSON() interface{} {
+	nodes := make([]map[string]interface{}, 0, len(r.graph.Nodes))
+	for _, n := range r.graph.Nodes {
+		nodes = append(nodes, map[string]interface{}{
+			"id":    n.ID,
+			"label": n.Label,
+			"alias": n.Alias,
+		})
+	}
+
+	edges := make([]map[string]interface{}, 0, len(r.graph.Edges))
+	for _, e := range r.graph.Edges {
+		edges = append(edges, map[string]interface{}{
+			"from":   e.From,
+			"to":     e.To,
+			"alias":  e.Alias,
+			"cyclic": e.Cyclic,
+		})
+	}
+
+	return map[string]interface{}{
+		"nodes": nodes,
+		"edges": edges,
+	}
+}
+
+func (r *GraphResult) String() string {
+	if r.format == "mermaid" {
+		return r.graph.Mermaid()
+	}
+
+	return r.graph.DOT()
+}
+
+func (r *GraphResult) Oneliner() string {
+	return r.String()
+}
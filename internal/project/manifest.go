@@ -0,0 +1,101 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsManifest struct {
+	Manifest string `flag:"manifest" default:"deployments.json" info:"path of the deployment manifest"`
+}
+
+var manifestFlags = flagsManifest{}
+
+var ManifestCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "manifest",
+		Short:   "Regenerate the deployment manifest from the contracts deployed on the network",
+		Example: "flow project manifest --network testnet --manifest deployments.json",
+	},
+	Flags: &manifestFlags,
+	RunS:  manifest,
+}
+
+func manifest(
+	_ []string,
+	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	srv *services.Services,
+	_ *flowkit.State,
+) (command.Result, error) {
+	m, err := srv.Project.Manifest(globalFlags.Network, manifestFlags.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ManifestResult{manifest: m}, nil
+}
+
+type ManifestResult struct {
+	manifest services.Manifest
+}
+
+func (r *ManifestResult) JSON() interface{} {
+	return r.manifest
+}
+
+func (r *ManifestResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	names := maps.Keys(r.manifest)
+	sort.Strings(names)
+	for _, name := range names {
+		_, _ = fmt.Fprintf(writer, "%s\n", name)
+
+		networks := maps.Keys(r.manifest[name])
+		sort.Strings(networks)
+		for _, network := range networks {
+			contract := r.manifest[name][network]
+			_, _ = fmt.Fprintf(writer, "\t%s\t 0x%s\t %s\n", network, contract.Address, contract.CodeHash)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *ManifestResult) Oneliner() string {
+	names := maps.Keys(r.manifest)
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
@@ -31,4 +31,6 @@ var Cmd = &cobra.Command{
 
 func init() {
 	DeployCommand.AddToParent(Cmd)
+	GraphCommand.AddToParent(Cmd)
+	ManifestCommand.AddToParent(Cmd)
 }
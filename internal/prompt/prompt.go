@@ -0,0 +1,113 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package prompt asks the user for input on the terminal.
+//
+// Commands take a Prompter instead of prompting directly, so the prompts can be replaced in tests,
+// and get it with New, which fails if the input is not a terminal instead of waiting for input forever.
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/manifoldco/promptui"
+	"github.com/mattn/go-isatty"
+)
+
+// ErrNotInteractive is returned by New if the standard input or output is not a terminal.
+var ErrNotInteractive = errors.New("not running in an interactive terminal")
+
+// ErrCanceled is returned by the prompts if the user cancels the input.
+var ErrCanceled = errors.New("canceled by the user")
+
+// Prompter asks the user for input.
+type Prompter interface {
+	// Select asks to choose one of the items and returns the index of the chosen item.
+	Select(label string, items []string) (int, error)
+	// Input asks for a text value, the value is accepted once the validate function returns no error.
+	Input(label string, defaultValue string, validate func(string) error) (string, error)
+	// Confirm asks a yes or no question.
+	Confirm(label string) (bool, error)
+}
+
+// IsInteractive reports if both the standard input and output are terminals.
+func IsInteractive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+func isTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// New returns a Prompter reading from the terminal,
+// or ErrNotInteractive if the command is not running in a terminal.
+func New() (Prompter, error) {
+	if !IsInteractive() {
+		return nil, ErrNotInteractive
+	}
+
+	return &terminal{}, nil
+}
+
+// terminal is a Prompter using the terminal.
+type terminal struct{}
+
+var _ Prompter = &terminal{}
+
+func (t *terminal) Select(label string, items []string) (int, error) {
+	prompt := promptui.Select{
+		Label: label,
+		Items: items,
+	}
+
+	index, _, err := prompt.Run()
+	return index, promptError(err)
+}
+
+func (t *terminal) Input(label string, defaultValue string, validate func(string) error) (string, error) {
+	prompt := promptui.Prompt{
+		Label:    label,
+		Default:  defaultValue,
+		Validate: validate,
+	}
+
+	value, err := prompt.Run()
+	return value, promptError(err)
+}
+
+func (t *terminal) Confirm(label string) (bool, error) {
+	index, err := t.Select(label, []string{"Yes", "No"})
+	if err != nil {
+		return false, err
+	}
+
+	return index == 0, nil
+}
+
+func promptError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, promptui.ErrInterrupt) || errors.Is(err, promptui.ErrEOF) {
+		return ErrCanceled
+	}
+
+	return fmt.Errorf("failed to read input: %w", err)
+}
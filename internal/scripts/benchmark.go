@@ -0,0 +1,347 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+const histogramBuckets = 10
+
+// benchmark executes the script the number of runs and reports the latency statistics of the runs.
+func benchmark(
+	script *flowkit.Script,
+	readerWriter flowkit.ReaderWriter,
+	network string,
+	srv *services.Services,
+) (command.Result, error) {
+	var baseline *benchmarkReport
+	if scriptFlags.Baseline != "" {
+		content, err := readerWriter.ReadFile(scriptFlags.Baseline)
+		if err != nil {
+			return nil, fmt.Errorf("error loading baseline file: %w", err)
+		}
+
+		baseline = &benchmarkReport{}
+		err = json.Unmarshal(content, baseline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse baseline file %s: %w", scriptFlags.Baseline, err)
+		}
+	}
+
+	start := time.Now()
+	runs, err := srv.Scripts.Benchmark(script, network, services.BenchmarkOptions{
+		Runs:        scriptFlags.Runs,
+		Parallelism: scriptFlags.Parallel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := newBenchmarkReport(runs, scriptFlags.Parallel, time.Since(start))
+
+	if scriptFlags.ResultsFile != "" {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		err = readerWriter.WriteFile(scriptFlags.ResultsFile, encoded, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write results file %s: %w", scriptFlags.ResultsFile, err)
+		}
+	}
+
+	return &BenchmarkResult{
+		report:    report,
+		baseline:  baseline,
+		threshold: float64(scriptFlags.Threshold),
+		errors:    runErrors(runs),
+	}, nil
+}
+
+// benchmarkReport is the latency statistics of the successful runs in milliseconds,
+// it's written to the results file and read as the baseline.
+type benchmarkReport struct {
+	Runs        int       `json:"runs"`
+	Failed      int       `json:"failed"`
+	Parallelism int       `json:"parallelism"`
+	Min         float64   `json:"minMs"`
+	Median      float64   `json:"medianMs"`
+	P95         float64   `json:"p95Ms"`
+	Max         float64   `json:"maxMs"`
+	Mean        float64   `json:"meanMs"`
+	Throughput  float64   `json:"runsPerSecond"`
+	Durations   []float64 `json:"durationsMs"`
+}
+
+func newBenchmarkReport(runs []services.BenchmarkRun, parallelism int, elapsed time.Duration) benchmarkReport {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	report := benchmarkReport{
+		Runs:        len(runs),
+		Parallelism: parallelism,
+		Durations:   make([]float64, 0, len(runs)),
+	}
+
+	for _, run := range runs {
+		if run.Error != nil {
+			report.Failed++
+			continue
+		}
+		report.Durations = append(report.Durations, float64(run.Duration.Microseconds())/1000)
+	}
+
+	durations := append([]float64(nil), report.Durations...)
+	sort.Float64s(durations)
+	if len(durations) == 0 {
+		return report
+	}
+
+	sum := 0.0
+	for _, d := range durations {
+		sum += d
+	}
+
+	report.Min = durations[0]
+	report.Max = durations[len(durations)-1]
+	report.Mean = sum / float64(len(durations))
+	report.Median = median(durations)
+	report.P95 = percentile(durations, 95)
+	if elapsed > 0 {
+		report.Throughput = float64(len(durations)) / elapsed.Seconds()
+	}
+
+	return report
+}
+
+func median(sorted []float64) float64 {
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// percentile returns the nearest-rank percentile of the sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// histogram returns the lines of the histogram of the durations, in buckets of equal width between min and max.
+func histogram(report benchmarkReport, width int) []string {
+	if len(report.Durations) == 0 {
+		return nil
+	}
+
+	buckets := histogramBuckets
+	size := (report.Max - report.Min) / float64(buckets)
+	if size == 0 {
+		buckets = 1
+	}
+
+	counts := make([]int, buckets)
+	for _, d := range report.Durations {
+		bucket := 0
+		if size > 0 {
+			bucket = int((d - report.Min) / size)
+		}
+		if bucket >= buckets {
+			bucket = buckets - 1
+		}
+		counts[bucket]++
+	}
+
+	highest := 0
+	for _, count := range counts {
+		if count > highest {
+			highest = count
+		}
+	}
+
+	lines := make([]string, 0, buckets)
+	for i, count := range counts {
+		from := report.Min + float64(i)*size
+		to := from + size
+		bar := strings.Repeat("█", int(math.Round(float64(count)/float64(highest)*float64(width))))
+		lines = append(lines, fmt.Sprintf("%9.2fms - %9.2fms │%s %d", from, to, bar, count))
+	}
+
+	return lines
+}
+
+// benchmarkComparison is the change of a statistic compared to the baseline.
+type benchmarkComparison struct {
+	Metric     string  `json:"metric"`
+	Baseline   float64 `json:"baselineMs"`
+	Current    float64 `json:"currentMs"`
+	Change     float64 `json:"changePercent"`
+	Regression bool    `json:"regression"`
+}
+
+func compareBenchmarks(baseline benchmarkReport, current benchmarkReport, threshold float64) []benchmarkComparison {
+	metrics := []struct {
+		name     string
+		baseline float64
+		current  float64
+	}{
+		{"min", baseline.Min, current.Min},
+		{"median", baseline.Median, current.Median},
+		{"p95", baseline.P95, current.P95},
+		{"max", baseline.Max, current.Max},
+	}
+
+	comparisons := make([]benchmarkComparison, 0, len(metrics))
+	for _, m := range metrics {
+		change := 0.0
+		if m.baseline > 0 {
+			change = (m.current - m.baseline) / m.baseline * 100
+		}
+		comparisons = append(comparisons, benchmarkComparison{
+			Metric:   m.name,
+			Baseline: m.baseline,
+			Current:  m.current,
+			Change:   change,
+			// the max latency is too noisy to be a regression
+			Regression: m.name != "max" && change > threshold,
+		})
+	}
+
+	return comparisons
+}
+
+func runErrors(runs []services.BenchmarkRun) []string {
+	errs := make([]string, 0)
+	for _, run := range runs {
+		if run.Error != nil {
+			errs = append(errs, fmt.Sprintf("run %d: %s", run.Index, run.Error))
+		}
+	}
+	return errs
+}
+
+type BenchmarkResult struct {
+	report    benchmarkReport
+	baseline  *benchmarkReport
+	threshold float64
+	errors    []string
+}
+
+func (r *BenchmarkResult) comparisons() []benchmarkComparison {
+	if r.baseline == nil {
+		return nil
+	}
+	return compareBenchmarks(*r.baseline, r.report, r.threshold)
+}
+
+func (r *BenchmarkResult) regressions() []benchmarkComparison {
+	regressions := make([]benchmarkComparison, 0)
+	for _, c := range r.comparisons() {
+		if c.Regression {
+			regressions = append(regressions, c)
+		}
+	}
+	return regressions
+}
+
+func (r *BenchmarkResult) JSON() interface{} {
+	result := map[string]interface{}{
+		"report": r.report,
+		"errors": r.errors,
+	}
+	if r.baseline != nil {
+		result["comparison"] = r.comparisons()
+		result["regressions"] = len(r.regressions())
+	}
+
+	return result
+}
+
+func (r *BenchmarkResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Runs\t%d\n", r.report.Runs)
+	_, _ = fmt.Fprintf(writer, "Failed\t%d\n", r.report.Failed)
+	_, _ = fmt.Fprintf(writer, "Parallelism\t%d\n", r.report.Parallelism)
+	_, _ = fmt.Fprintf(writer, "Min\t%.2fms\n", r.report.Min)
+	_, _ = fmt.Fprintf(writer, "Median\t%.2fms\n", r.report.Median)
+	_, _ = fmt.Fprintf(writer, "P95\t%.2fms\n", r.report.P95)
+	_, _ = fmt.Fprintf(writer, "Max\t%.2fms\n", r.report.Max)
+	_, _ = fmt.Fprintf(writer, "Mean\t%.2fms\n", r.report.Mean)
+	_, _ = fmt.Fprintf(writer, "Throughput\t%.2f runs/s\n", r.report.Throughput)
+	_ = writer.Flush()
+
+	if lines := histogram(r.report, 40); len(lines) > 0 {
+		_, _ = fmt.Fprintf(&b, "\nLatency Histogram\n")
+		for _, line := range lines {
+			_, _ = fmt.Fprintf(&b, "%s\n", line)
+		}
+	}
+
+	if comparisons := r.comparisons(); len(comparisons) > 0 {
+		_, _ = fmt.Fprintf(&b, "\n")
+		writer = util.CreateTabWriter(&b)
+		_, _ = fmt.Fprintf(writer, "Metric\tBaseline\tCurrent\tChange\t\n")
+		for _, c := range comparisons {
+			marker := ""
+			if c.Regression {
+				marker = fmt.Sprintf("%s regression", output.WarningEmoji())
+			}
+			_, _ = fmt.Fprintf(writer, "%s\t%.2fms\t%.2fms\t%+.1f%%\t%s\n", c.Metric, c.Baseline, c.Current, c.Change, marker)
+		}
+		_ = writer.Flush()
+	}
+
+	for _, err := range r.errors {
+		_, _ = fmt.Fprintf(&b, "\n%s %s", output.ErrorEmoji(), err)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (r *BenchmarkResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Runs: %d, Failed: %d, Median: %.2fms, P95: %.2fms, Regressions: %d",
+		r.report.Runs, r.report.Failed, r.report.Median, r.report.P95, len(r.regressions()),
+	)
+}
+
+// Failed reports if any run failed or any statistic regressed above the threshold, so the command exits with an error.
+func (r *BenchmarkResult) Failed() bool {
+	return r.report.Failed > 0 || len(r.regressions()) > 0
+}
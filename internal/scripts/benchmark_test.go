@@ -0,0 +1,63 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+func Test_BenchmarkReport(t *testing.T) {
+	runs := make([]services.BenchmarkRun, 0)
+	for i := 1; i <= 20; i++ {
+		runs = append(runs, services.BenchmarkRun{Index: i - 1, Duration: time.Duration(i) * time.Millisecond})
+	}
+	runs = append(runs, services.BenchmarkRun{Index: 20, Error: fmt.Errorf("failed")})
+
+	report := newBenchmarkReport(runs, 0, 2*time.Second)
+
+	assert.Equal(t, 21, report.Runs)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.Parallelism)
+	assert.Equal(t, 1.0, report.Min)
+	assert.Equal(t, 10.5, report.Median)
+	assert.Equal(t, 19.0, report.P95)
+	assert.Equal(t, 20.0, report.Max)
+	assert.Equal(t, 10.5, report.Mean)
+	assert.Equal(t, 10.0, report.Throughput)
+	assert.Len(t, histogram(report, 40), histogramBuckets)
+
+	t.Run("Compare", func(t *testing.T) {
+		current := report
+		current.Median = 12
+		current.P95 = 20
+
+		comparisons := compareBenchmarks(report, current, 10)
+
+		assert.Equal(t, "median", comparisons[1].Metric)
+		assert.True(t, comparisons[1].Regression)
+		assert.Equal(t, "p95", comparisons[2].Metric)
+		assert.False(t, comparisons[2].Regression)
+	})
+}
@@ -20,27 +20,49 @@ package scripts
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
 type flagsScripts struct {
-	ArgsJSON string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	ArgsJSON     string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	ArgsJSONFile string   `default:"" flag:"args-json-file" info:"file containing the arguments as a JSON array in JSON-Cadence format"`
+	Arg          []string `default:"" flag:"arg" info:"argument in the name:Type:value format, the flag can be repeated"`
+	BlockHeight  uint64   `default:"0" flag:"block-height" info:"block height to execute the script at"`
+	BlockID      string   `default:"" flag:"block-id" info:"block ID to execute the script at"`
+	Block        string   `default:"" flag:"block" info:"block to execute the script at: a block height, latest, sealed, final or a block relative to them like latest-1"`
+	Format       string   `default:"" flag:"format" info:"Format of the result, options: \"json\", \"json-simple\", \"csv\", \"value\""`
+	Simplified   bool     `default:"false" flag:"simplified" info:"output the JSON result as plain JSON values instead of JSON-Cadence"`
+	TypeHints    bool     `default:"false" flag:"type-hints" info:"add the type of composite values in the _type field of the json-simple format"`
+	Depth        int      `default:"0" flag:"depth" info:"maximum nesting of values decoded in the json-simple format, unlimited if zero"`
+	Benchmark    bool     `default:"false" flag:"benchmark" info:"execute the script multiple times and report the latency statistics"`
+	Runs         int      `default:"10" flag:"runs" info:"number of times the script is executed when benchmarking"`
+	Parallel     int      `default:"1" flag:"parallel" info:"maximum number of executions at once when benchmarking"`
+	Baseline     string   `default:"" flag:"baseline" info:"results file of a previous benchmark to compare the latency with"`
+	Threshold    int      `default:"10" flag:"threshold" info:"percentage increase of the latency over the baseline reported as a regression"`
+	ResultsFile  string   `default:"" flag:"results-file" info:"file to write the benchmark results to in JSON format"`
+	Code         string   `default:"" flag:"code" info:"script code to execute instead of the code of a file, all the positional arguments are script arguments"`
+	Watch        bool     `default:"false" flag:"watch" info:"execute the script again each time the script file or a file it imports changes"`
+	Cache        bool     `default:"false" flag:"cache" info:"use the cached result of the same script execution, and cache the result"`
+	CacheTTL     string   `default:"30s" flag:"cache-ttl" info:"time the results at the latest block are cached for, like 30s, results at a block height are cached forever"`
 }
 
 var scriptFlags = flagsScripts{}
 
 var ExecuteCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "execute <filename> [<argument> <argument> ...]",
+		Use:     "execute <filename | -> [<argument> <argument> ...]",
 		Short:   "Execute a script",
-		Example: `flow scripts execute script.cdc "Meow" "Woof"`,
-		Args:    cobra.MinimumNArgs(1),
+		Example: "flow scripts execute script.cdc \"Meow\" \"Woof\"\nflow scripts execute --code 'pub fun main(): UFix64 { return 1.0 }'\ncat script.cdc | flow scripts execute - \"Meow\" \"Woof\"",
+		Args:    cobra.ArbitraryArgs,
 	},
 	Flags: &scriptFlags,
 	Run:   execute,
@@ -52,31 +74,216 @@ func execute(
 	globalFlags command.GlobalFlags,
 	srv *services.Services,
 ) (command.Result, error) {
-	filename := args[0]
+	filename := ""
+	if scriptFlags.Code == "" {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("missing script filename, use - to read the script from the standard input or the --code flag")
+		}
+		filename, args = args[0], args[1:]
+	}
+
+	switch scriptFlags.Format {
+	case "", formatJSON, formatJSONSimple, formatCSV, formatValue:
+	default:
+		return nil, fmt.Errorf("invalid format %s, options: json, json-simple, csv, value", scriptFlags.Format)
+	}
+
+	if scriptFlags.Simplified && scriptFlags.Format != "" && scriptFlags.Format != formatJSON {
+		return nil, fmt.Errorf("the simplified flag can only be used with the json format")
+	}
+
+	if scriptFlags.BlockHeight != 0 && scriptFlags.BlockID != "" {
+		return nil, fmt.Errorf("the block height flag can't be combined with the block ID flag")
+	}
+
+	if scriptFlags.Block != "" && (scriptFlags.BlockHeight != 0 || scriptFlags.BlockID != "") {
+		return nil, fmt.Errorf("the block flag can't be combined with the block height and block ID flags")
+	}
 
-	code, err := readerWriter.ReadFile(filename)
+	if scriptFlags.Benchmark && (scriptFlags.BlockHeight != 0 || scriptFlags.BlockID != "" || scriptFlags.Block != "" || scriptFlags.Format != "") {
+		return nil, fmt.Errorf("the benchmark flag can't be combined with the block or format flags")
+	}
+
+	if scriptFlags.Cache && scriptFlags.Benchmark {
+		return nil, fmt.Errorf("the cache flag can't be combined with the benchmark flag")
+	}
+
+	if scriptFlags.Watch {
+		if scriptFlags.Benchmark {
+			return nil, fmt.Errorf("the watch flag can't be combined with the benchmark flag")
+		}
+		if filename == "" || filename == command.StdinFilename {
+			return nil, fmt.Errorf("the watch flag requires a script file")
+		}
+
+		return nil, watchScript(filename, args, readerWriter, globalFlags.Network, srv)
+	}
+
+	script, err := loadScript(readerWriter, filename, args)
 	if err != nil {
-		return nil, fmt.Errorf("error loading script file: %w", err)
+		return nil, err
 	}
 
-	var scriptArgs []cadence.Value
-	if scriptFlags.ArgsJSON != "" {
-		scriptArgs, err = flowkit.ParseArgumentsJSON(scriptFlags.ArgsJSON)
-	} else {
-		scriptArgs, err = flowkit.ParseArgumentsWithoutType(filename, code, args[1:])
+	if scriptFlags.Benchmark {
+		return benchmark(script, readerWriter, globalFlags.Network, srv)
 	}
 
+	return executeScript(script, readerWriter, globalFlags.Network, srv)
+}
+
+// loadScript reads the script code and parses its arguments.
+func loadScript(readerWriter flowkit.ReaderWriter, filename string, args []string) (*flowkit.Script, error) {
+	code, location, err := command.ReadCode(readerWriter, filename, scriptFlags.Code)
+	if err != nil {
+		return nil, fmt.Errorf("error loading script file: %w", err)
+	}
+
+	scriptArgs, err := command.ParseArguments(readerWriter, location, code, args, command.ArgumentsFlags{
+		JSON:     scriptFlags.ArgsJSON,
+		JSONFile: scriptFlags.ArgsJSONFile,
+		Named:    scriptFlags.Arg,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error parsing script arguments: %w", err)
 	}
 
-	value, err := srv.Scripts.Execute(
-		flowkit.NewScript(code, scriptArgs, filename),
-		globalFlags.Network,
-	)
+	return flowkit.NewScript(code, scriptArgs, location), nil
+}
+
+// executeScript executes the script at the block selected by the flags and formats the result.
+func executeScript(
+	script *flowkit.Script,
+	readerWriter flowkit.ReaderWriter,
+	network string,
+	srv *services.Services,
+) (*ScriptResult, error) {
+	if scriptFlags.Cache {
+		return executeCachedScript(script, readerWriter, network, srv)
+	}
+
+	atHeight, err := blockHeight(srv)
 	if err != nil {
 		return nil, err
 	}
 
-	return &ScriptResult{value}, nil
+	var value cadence.Value
+	var height *uint64
+	switch {
+	case atHeight != 0:
+		value, err = srv.Scripts.ExecuteAtHeight(script, network, atHeight)
+		height = &atHeight
+	case scriptFlags.BlockID != "":
+		id, err := parseBlockID(scriptFlags.BlockID)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err = srv.Scripts.ExecuteAtID(script, network, id)
+		if err != nil {
+			return nil, err
+		}
+
+		block, _, _, err := srv.Blocks.GetBlock(id.String(), "", false)
+		if err != nil {
+			return nil, err
+		}
+		height = &block.Height
+	default:
+		value, err = srv.Scripts.Execute(script, network)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newScriptResult(value, height)
+}
+
+// executeCachedScript executes the script at the block selected by the flags using the script results cache.
+//
+// The block ID is resolved to the height of the block, so the result is cached by the height.
+func executeCachedScript(
+	script *flowkit.Script,
+	readerWriter flowkit.ReaderWriter,
+	network string,
+	srv *services.Services,
+) (*ScriptResult, error) {
+	ttl, err := time.ParseDuration(scriptFlags.CacheTTL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache TTL %s: %w", scriptFlags.CacheTTL, err)
+	}
+
+	atHeight, err := blockHeight(srv)
+	if err != nil {
+		return nil, err
+	}
+
+	var height *uint64
+	switch {
+	case atHeight != 0:
+		height = &atHeight
+	case scriptFlags.BlockID != "":
+		id, err := parseBlockID(scriptFlags.BlockID)
+		if err != nil {
+			return nil, err
+		}
+
+		block, _, _, err := srv.Blocks.GetBlock(id.String(), "", false)
+		if err != nil {
+			return nil, err
+		}
+		height = &block.Height
+	}
+
+	if height != nil {
+		atHeight = *height
+	}
+
+	cache := flowkit.NewScriptCache(readerWriter, flowkit.DefaultScriptCacheFile)
+	value, err := srv.Scripts.ExecuteCached(script, network, atHeight, cache, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScriptResult(value, height)
+}
+
+// blockHeight returns the height of the block selected by the block or the block height flag, or zero if none is set.
+//
+// The block flag is resolved on each execution, so a block relative to the latest block follows the latest block.
+func blockHeight(srv *services.Services) (uint64, error) {
+	if scriptFlags.Block != "" {
+		return srv.Blocks.ResolveHeight(scriptFlags.Block)
+	}
+	return scriptFlags.BlockHeight, nil
+}
+
+func parseBlockID(blockID string) (flow.Identifier, error) {
+	id := flow.HexToID(blockID)
+	if id == flow.EmptyID {
+		return flow.EmptyID, fmt.Errorf("invalid block ID: %s", blockID)
+	}
+
+	return id, nil
+}
+
+// newScriptResult returns the result of the script executed at the block height, formatted in the format of the flags.
+func newScriptResult(value cadence.Value, height *uint64) (*ScriptResult, error) {
+	result := &ScriptResult{
+		Value:      value,
+		height:     height,
+		simplified: scriptFlags.Simplified,
+		format:     scriptFlags.Format,
+	}
+	if scriptFlags.Format != "" {
+		var err error
+		result.formatted, err = formatResultValue(value, scriptFlags.Format, scriptFlags.Simplified, output.ValueOptions{
+			TypeHints: scriptFlags.TypeHints,
+			Depth:     scriptFlags.Depth,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
 }
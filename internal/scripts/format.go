@@ -0,0 +1,243 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+)
+
+const (
+	formatJSON       = "json"
+	formatJSONSimple = "json-simple"
+	formatCSV        = "csv"
+	formatValue      = "value"
+)
+
+// formatResultValue formats the script result in the json, json-simple, csv or value format.
+//
+// The simplified JSON decodes the value to plain JSON values instead of JSON-Cadence,
+// the keys of composites and dictionaries are sorted so the output is deterministic.
+// The json-simple format converts the value with the options, see output.SimpleJSON.
+func formatResultValue(value cadence.Value, format string, simplified bool, options output.ValueOptions) (string, error) {
+	switch format {
+	case formatJSON:
+		if simplified {
+			out, err := json.MarshalIndent(flowkit.DecodeValue(value), "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("failed to encode the result: %w", err)
+			}
+			return string(out), nil
+		}
+
+		out, err := jsoncdc.Encode(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode the result: %w", err)
+		}
+		return strings.TrimSpace(string(out)), nil
+	case formatJSONSimple:
+		out, err := json.MarshalIndent(output.SimpleJSON(value, options), "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to encode the result: %w", err)
+		}
+		return string(out), nil
+	case formatCSV:
+		return formatCSVValue(value)
+	case formatValue:
+		s, ok := scalarString(value)
+		if !ok {
+			return "", fmt.Errorf("the result of type %s is not a scalar value, use the json or csv format", typeID(value))
+		}
+		return s, nil
+	default:
+		return "", fmt.Errorf("invalid format %s, options: json, json-simple, csv, value", format)
+	}
+}
+
+// scalarString returns the inner value of a scalar value, like a string without the quotes.
+func scalarString(value cadence.Value) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "nil", true
+	case cadence.Optional:
+		return scalarString(v.Value)
+	case cadence.String:
+		return string(v), true
+	case cadence.Character:
+		return string(v), true
+	case cadence.TypeValue:
+		if v.StaticType == nil {
+			return "", true
+		}
+		return v.StaticType.ID(), true
+	case cadence.Array, cadence.Dictionary, cadence.Struct, cadence.Resource,
+		cadence.Event, cadence.Contract, cadence.Enum, cadence.StorageCapability:
+		return "", false
+	default:
+		return v.String(), true
+	}
+}
+
+// formatCSVValue flattens the value to CSV rows.
+//
+// Arrays of composites are output with a row for each composite and a column for each field,
+// arrays of dictionaries with a column for each key, and arrays of scalars in a single value column.
+// Nested values are output in the cells as simplified JSON.
+func formatCSVValue(value cadence.Value) (string, error) {
+	if optional, ok := value.(cadence.Optional); ok {
+		return formatCSVValue(optional.Value)
+	}
+
+	var values []cadence.Value
+	switch v := value.(type) {
+	case cadence.Array:
+		values = v.Values
+	default:
+		if _, _, ok := compositeFields(v); !ok {
+			return "", fmt.Errorf("the result of type %s can't be output as CSV, only arrays and composite values are supported", typeID(v))
+		}
+		values = []cadence.Value{v}
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	rows := make([]map[string]cadence.Value, 0, len(values))
+	for _, element := range values {
+		names, fields, ok := compositeFields(element)
+		if !ok {
+			if dictionary, isDictionary := element.(cadence.Dictionary); isDictionary {
+				names, fields = dictionaryFields(dictionary)
+			} else if _, isScalar := scalarString(element); isScalar {
+				names, fields = []string{"value"}, map[string]cadence.Value{"value": element}
+			} else {
+				return "", fmt.Errorf("the array element of type %s can't be output as CSV", typeID(element))
+			}
+		}
+
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				columns = append(columns, name)
+			}
+		}
+		rows = append(rows, fields)
+	}
+
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+	_ = writer.Write(columns)
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			field, ok := row[column]
+			if !ok {
+				continue
+			}
+			cell, err := csvCell(field)
+			if err != nil {
+				return "", err
+			}
+			record[i] = cell
+		}
+		_ = writer.Write(record)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to write the result as CSV: %w", err)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+func csvCell(value cadence.Value) (string, error) {
+	if s, ok := scalarString(value); ok {
+		return s, nil
+	}
+
+	out, err := json.Marshal(flowkit.DecodeValue(value))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the value %s: %w", value, err)
+	}
+	return string(out), nil
+}
+
+// compositeFields returns the field names of the composite value in their declaration order.
+func compositeFields(value cadence.Value) ([]string, map[string]cadence.Value, bool) {
+	var compositeType cadence.CompositeType
+	var values []cadence.Value
+	switch v := value.(type) {
+	case cadence.Struct:
+		compositeType, values = v.StructType, v.Fields
+	case cadence.Resource:
+		compositeType, values = v.ResourceType, v.Fields
+	case cadence.Event:
+		compositeType, values = v.EventType, v.Fields
+	case cadence.Contract:
+		compositeType, values = v.ContractType, v.Fields
+	case cadence.Enum:
+		compositeType, values = v.EnumType, v.Fields
+	default:
+		return nil, nil, false
+	}
+
+	names := make([]string, 0, len(values))
+	fields := make(map[string]cadence.Value, len(values))
+	for i, field := range compositeType.CompositeFields() {
+		if i < len(values) {
+			names = append(names, field.Identifier)
+			fields[field.Identifier] = values[i]
+		}
+	}
+
+	return names, fields, true
+}
+
+// dictionaryFields returns the keys of the dictionary in sorted order.
+func dictionaryFields(dictionary cadence.Dictionary) ([]string, map[string]cadence.Value) {
+	names := make([]string, 0, len(dictionary.Pairs))
+	fields := make(map[string]cadence.Value, len(dictionary.Pairs))
+	for _, pair := range dictionary.Pairs {
+		name, ok := scalarString(pair.Key)
+		if !ok {
+			name = pair.Key.String()
+		}
+		names = append(names, name)
+		fields[name] = pair.Value
+	}
+	sort.Strings(names)
+
+	return names, fields
+}
+
+func typeID(value cadence.Value) string {
+	if value == nil || value.Type() == nil {
+		return "unknown"
+	}
+	return value.Type().ID()
+}
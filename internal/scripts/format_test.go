@@ -0,0 +1,115 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+)
+
+func Test_FormatResultValue(t *testing.T) {
+	balanceType := &cadence.StructType{
+		QualifiedIdentifier: "Balance",
+		Fields: []cadence.Field{
+			{Identifier: "owner", Type: cadence.AddressType{}},
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "tags", Type: &cadence.VariableSizedArrayType{ElementType: cadence.StringType{}}},
+		},
+	}
+
+	newBalance := func(owner string, amount string, tags ...string) cadence.Value {
+		tagValues := make([]cadence.Value, 0, len(tags))
+		for _, tag := range tags {
+			tagValues = append(tagValues, cadence.String(tag))
+		}
+		return cadence.NewStruct([]cadence.Value{
+			cadence.NewAddress(cadence.BytesToAddress([]byte(owner))),
+			mustParseUFix64(t, amount),
+			cadence.NewArray(tagValues),
+		}).WithType(balanceType)
+	}
+
+	balances := cadence.NewArray([]cadence.Value{
+		newBalance("\x01", "10.5", "a"),
+		newBalance("\x02", "0.25", "b", "c"),
+	}).WithType(&cadence.VariableSizedArrayType{ElementType: balanceType})
+
+	t.Run("Value", func(t *testing.T) {
+		out, err := formatResultValue(cadence.String("Hello"), formatValue, false, output.ValueOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello", out)
+
+		out, err = formatResultValue(cadence.NewOptional(cadence.UFix64(150000000)), formatValue, false, output.ValueOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "1.50000000", out)
+
+		_, err = formatResultValue(balances, formatValue, false, output.ValueOptions{})
+		assert.EqualError(t, err, "the result of type [Balance] is not a scalar value, use the json or csv format")
+	})
+
+	t.Run("Simplified JSON", func(t *testing.T) {
+		out, err := formatResultValue(balances.Values[0], formatJSON, true, output.ValueOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, `{
+  "amount": "10.50000000",
+  "owner": "0x0000000000000001",
+  "tags": [
+    "a"
+  ]
+}`, out)
+	})
+
+	t.Run("Simple JSON", func(t *testing.T) {
+		out, err := formatResultValue(balances.Values[0], formatJSONSimple, false, output.ValueOptions{TypeHints: true})
+		assert.NoError(t, err)
+		assert.Equal(t, `{
+  "_type": "Balance",
+  "amount": "10.50000000",
+  "owner": "0x0000000000000001",
+  "tags": [
+    "a"
+  ]
+}`, out)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		out, err := formatResultValue(balances, formatCSV, false, output.ValueOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, `owner,amount,tags
+0x0000000000000001,10.50000000,"[""a""]"
+0x0000000000000002,0.25000000,"[""b"",""c""]"`, out)
+
+		out, err = formatResultValue(cadence.NewArray([]cadence.Value{cadence.NewInt(1), cadence.NewInt(2)}), formatCSV, false, output.ValueOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, "value\n1\n2", out)
+
+		_, err = formatResultValue(cadence.String("Hello"), formatCSV, false, output.ValueOptions{})
+		assert.EqualError(t, err, "the result of type String can't be output as CSV, only arrays and composite values are supported")
+	})
+}
+
+func mustParseUFix64(t *testing.T, s string) cadence.UFix64 {
+	value, err := cadence.NewUFix64(s)
+	assert.NoError(t, err)
+	return value
+}
@@ -0,0 +1,155 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+var ListCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "list",
+		Short:   "List the scripts saved in the configuration",
+		Args:    cobra.NoArgs,
+		Example: "flow scripts list",
+	},
+	Flags: &struct{}{},
+	RunS:  list,
+}
+
+func list(
+	_ []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	_ *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	scripts := make([]savedScript, 0, len(*state.Scripts()))
+	for _, saved := range *state.Scripts() {
+		script := savedScript{
+			name: saved.Name,
+			file: saved.Location,
+		}
+
+		code, err := readerWriter.ReadFile(saved.Location)
+		if err == nil {
+			script.parameters, script.signature, err = flowkit.ScriptSignature(saved.Location, code)
+		}
+		if err != nil {
+			script.err = err
+		}
+
+		scripts = append(scripts, script)
+	}
+
+	sort.Slice(scripts, func(i, j int) bool { return scripts[i].name < scripts[j].name })
+
+	return &ListResult{scripts: scripts}, nil
+}
+
+// savedScript is a saved script with the parameters of its main function,
+// or the error if the script can't be read or parsed.
+type savedScript struct {
+	name       string
+	file       string
+	parameters []flowkit.ScriptParameter
+	signature  string
+	err        error
+}
+
+// parametersString returns the parameters in the name: Type format.
+func (s savedScript) parametersString() string {
+	if s.err != nil {
+		return fmt.Sprintf("unknown (%s)", s.err)
+	}
+
+	parameters := make([]string, 0, len(s.parameters))
+	for _, parameter := range s.parameters {
+		parameters = append(parameters, fmt.Sprintf("%s: %s", parameter.Name, parameter.Type))
+	}
+	if len(parameters) == 0 {
+		return "none"
+	}
+
+	return strings.Join(parameters, ", ")
+}
+
+// ListResult represents the result of the scripts list command.
+type ListResult struct {
+	scripts []savedScript
+}
+
+func (r *ListResult) JSON() interface{} {
+	result := make([]interface{}, 0, len(r.scripts))
+	for _, script := range r.scripts {
+		parameters := make(map[string]string, len(script.parameters))
+		for _, parameter := range script.parameters {
+			parameters[parameter.Name] = parameter.Type
+		}
+
+		item := map[string]interface{}{
+			"name":       script.name,
+			"file":       script.file,
+			"parameters": parameters,
+			"signature":  script.signature,
+		}
+		if script.err != nil {
+			item["error"] = script.err.Error()
+		}
+		result = append(result, item)
+	}
+
+	return result
+}
+
+func (r *ListResult) String() string {
+	if len(r.scripts) == 0 {
+		return "No scripts are saved in the configuration"
+	}
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Name\tFile\tParameters\n")
+	for _, script := range r.scripts {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", script.name, script.file, script.parametersString())
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *ListResult) Oneliner() string {
+	names := make([]string, 0, len(r.scripts))
+	for _, script := range r.scripts {
+		names = append(names, script.name)
+	}
+
+	return strings.Join(names, ", ")
+}
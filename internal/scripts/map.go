@@ -0,0 +1,284 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsMap struct {
+	AddressesFile string `default:"" flag:"addresses-file" info:"file containing the account addresses, one address per line"`
+	ArgsJSON      string `default:"" flag:"args-json" info:"arguments following the address in JSON-Cadence format"`
+	Parallel      int    `default:"4" flag:"parallel" info:"maximum number of executions at once"`
+	Rate          int    `default:"0" flag:"rate" info:"maximum number of executions per second, no limit by default"`
+	MaxRetries    int    `default:"3" flag:"max-retries" info:"number of times the execution for an account is retried if the access node is unavailable or overloaded"`
+	Format        string `default:"json" flag:"format" info:"Format of the results, options: \"json\", \"csv\""`
+	ResultsFile   string `default:"" flag:"results-file" info:"file to write the results to, the results are output by default"`
+}
+
+var mapFlags = flagsMap{}
+
+var MapCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "map <filename> --addresses-file <addresses file>",
+		Short:   "Execute a script for each account of a list",
+		Example: `flow scripts map balance.cdc --addresses-file addresses.txt --network mainnet --results-file balances.csv --format csv`,
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &mapFlags,
+	Run:   mapScript,
+}
+
+func mapScript(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	srv *services.Services,
+) (command.Result, error) {
+	filename := args[0]
+
+	if mapFlags.AddressesFile == "" {
+		return nil, fmt.Errorf("the addresses file flag is required")
+	}
+	if mapFlags.Format != formatJSON && mapFlags.Format != formatCSV {
+		return nil, fmt.Errorf("invalid format %s, options: json, csv", mapFlags.Format)
+	}
+	if mapFlags.Rate < 0 || mapFlags.MaxRetries < 0 {
+		return nil, fmt.Errorf("the rate and max retries flags can not be negative")
+	}
+
+	code, err := readerWriter.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading script file: %w", err)
+	}
+
+	content, err := readerWriter.ReadFile(mapFlags.AddressesFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading addresses file: %w", err)
+	}
+
+	addresses, err := parseAddresses(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid addresses file %s: %w", mapFlags.AddressesFile, err)
+	}
+
+	var scriptArgs []cadence.Value
+	if mapFlags.ArgsJSON != "" {
+		scriptArgs, err = flowkit.ParseArgumentsJSON(mapFlags.ArgsJSON)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing script arguments: %w", err)
+		}
+	}
+
+	done, failed := 0, 0
+	results, err := srv.Scripts.Map(
+		flowkit.NewScript(code, scriptArgs, filename),
+		addresses,
+		globalFlags.Network,
+		services.MapOptions{
+			Parallelism:          mapFlags.Parallel,
+			MaxRequestsPerSecond: float64(mapFlags.Rate),
+			MaxRetries:           mapFlags.MaxRetries,
+			Report: func(result services.MapResult) {
+				done++
+				if result.Error != nil {
+					failed++
+				}
+				// the progress is written to stderr so it's not mixed with the results
+				_, _ = fmt.Fprintf(os.Stderr, "\rExecuted %d/%d accounts, %d failed", done, len(addresses), failed)
+			},
+		},
+	)
+	_, _ = fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := formatMapResults(results, mapFlags.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MapResult{results: results, formatted: formatted}
+	if mapFlags.ResultsFile != "" {
+		err = readerWriter.WriteFile(mapFlags.ResultsFile, []byte(formatted+"\n"), 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write results file %s: %w", mapFlags.ResultsFile, err)
+		}
+		result.resultsFile = mapFlags.ResultsFile
+	}
+
+	return result, nil
+}
+
+// parseAddresses parses the addresses of the file, one address per line. Empty lines and lines starting with # are skipped.
+func parseAddresses(content []byte) ([]flow.Address, error) {
+	addresses := make([]flow.Address, 0)
+	seen := make(map[flow.Address]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		address, valid := util.ParseAddress(text)
+		if !valid {
+			return nil, fmt.Errorf("invalid address %s on line %d", text, line)
+		}
+		if seen[address] {
+			continue
+		}
+
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no addresses found")
+	}
+
+	return addresses, nil
+}
+
+// formatMapResults formats the results keyed by the address in the json or csv format.
+func formatMapResults(results []services.MapResult, format string) (string, error) {
+	if format == formatCSV {
+		var b bytes.Buffer
+		writer := csv.NewWriter(&b)
+		_ = writer.Write([]string{"address", "value", "error"})
+		for _, result := range results {
+			record := []string{"0x" + result.Address.Hex(), "", ""}
+			if result.Error != nil {
+				record[2] = result.Error.Error()
+			} else {
+				cell, err := csvCell(result.Value)
+				if err != nil {
+					return "", err
+				}
+				record[1] = cell
+			}
+			_ = writer.Write(record)
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return "", fmt.Errorf("failed to write the results as CSV: %w", err)
+		}
+
+		return strings.TrimSuffix(b.String(), "\n"), nil
+	}
+
+	out, err := json.MarshalIndent(mapResultsJSON(results), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the results: %w", err)
+	}
+	return string(out), nil
+}
+
+func mapResultsJSON(results []services.MapResult) map[string]interface{} {
+	values := make(map[string]interface{})
+	errs := make(map[string]string)
+	for _, result := range results {
+		if result.Error != nil {
+			errs["0x"+result.Address.Hex()] = result.Error.Error()
+		} else {
+			values["0x"+result.Address.Hex()] = flowkit.DecodeValue(result.Value)
+		}
+	}
+
+	return map[string]interface{}{
+		"results": values,
+		"errors":  errs,
+	}
+}
+
+type MapResult struct {
+	results     []services.MapResult
+	formatted   string
+	resultsFile string
+}
+
+func (r *MapResult) failed() []services.MapResult {
+	failed := make([]services.MapResult, 0)
+	for _, result := range r.results {
+		if result.Error != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+func (r *MapResult) JSON() interface{} {
+	return mapResultsJSON(r.results)
+}
+
+func (r *MapResult) String() string {
+	if r.resultsFile == "" {
+		return r.formatted
+	}
+
+	failed := r.failed()
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Accounts\t%d\n", len(r.results))
+	_, _ = fmt.Fprintf(writer, "Failed\t%d\n", len(failed))
+	_, _ = fmt.Fprintf(writer, "Results File\t%s\n", r.resultsFile)
+
+	for _, result := range failed {
+		_, _ = fmt.Fprintf(writer, "\n%s Account %s failed: %s\n", output.ErrorEmoji(), "0x"+result.Address.Hex(), result.Error)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *MapResult) Oneliner() string {
+	return fmt.Sprintf("Accounts: %d, Failed: %d", len(r.results), len(r.failed()))
+}
+
+// Raw reports if the results are output as is, they are when they aren't written to a file.
+func (r *MapResult) Raw() bool {
+	return r.resultsFile == ""
+}
+
+// Failed reports if the script failed for any account, so the command exits with an error.
+func (r *MapResult) Failed() bool {
+	return len(r.failed()) > 0
+}
@@ -0,0 +1,81 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsRun struct {
+	Param []string `default:"" flag:"param" info:"value of a script parameter in the name=value format, the flag can be repeated"`
+}
+
+var runFlags = flagsRun{}
+
+var RunCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "run <script name>",
+		Short:   "Run a script saved in the configuration",
+		Args:    cobra.ExactArgs(1),
+		Example: "flow scripts run get-balance --param addr=0xf8d6e0586b0a20c7",
+	},
+	Flags: &runFlags,
+	RunS:  run,
+}
+
+func run(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	srv *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	saved, err := state.Scripts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := command.ParseParams(runFlags.Param)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := readerWriter.ReadFile(saved.Location)
+	if err != nil {
+		return nil, fmt.Errorf("error loading script file: %w", err)
+	}
+
+	script, err := flowkit.NewSavedScript(*saved, code, params)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := srv.Scripts.Execute(script, globalFlags.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScriptResult{Value: value}, nil
+}
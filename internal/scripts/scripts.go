@@ -27,6 +27,7 @@ import (
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
@@ -39,23 +40,41 @@ var Cmd = &cobra.Command{
 
 func init() {
 	ExecuteCommand.AddToParent(Cmd)
+	MapCommand.AddToParent(Cmd)
+	RunCommand.AddToParent(Cmd)
+	ListCommand.AddToParent(Cmd)
 }
 
 type ScriptResult struct {
 	cadence.Value
+	height     *uint64 // height of the block the script was executed at, nil for the latest block
+	simplified bool
+	format     string
+	formatted  string // result in the format chosen with the format flag
 }
 
 func (r *ScriptResult) JSON() interface{} {
+	if r.simplified {
+		return flowkit.DecodeValue(r.Value)
+	}
+
 	return json.RawMessage(
 		jsoncdc.MustEncode(r.Value),
 	)
 }
 
 func (r *ScriptResult) String() string {
+	if r.format != "" {
+		return r.formatted
+	}
+
 	var b bytes.Buffer
 	writer := util.CreateTabWriter(&b)
 
 	_, _ = fmt.Fprintf(writer, "Result: %s\n", r.Value)
+	if r.height != nil {
+		_, _ = fmt.Fprintf(writer, "Block Height: %d\n", *r.height)
+	}
 
 	_ = writer.Flush()
 
@@ -63,5 +82,13 @@ func (r *ScriptResult) String() string {
 }
 
 func (r *ScriptResult) Oneliner() string {
+	if r.format != "" {
+		return r.formatted
+	}
+
 	return r.Value.String()
 }
+
+func (r *ScriptResult) Raw() bool {
+	return r.format != ""
+}
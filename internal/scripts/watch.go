@@ -0,0 +1,166 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scripts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/radovskyb/watcher"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+const (
+	watchPollInterval = 300 * time.Millisecond
+	// watchSettleDelay is the time waited for more changes after a change, so saving multiple files runs the script once
+	watchSettleDelay = 100 * time.Millisecond
+)
+
+// watchScript executes the script each time the script file or a local file it imports changes, until interrupted.
+//
+// The directories of the files are watched, so the files replaced by editors on save are still watched.
+// Failing loads and executions are printed without stopping the watcher.
+func watchScript(
+	filename string,
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	network string,
+	srv *services.Services,
+) error {
+	// the progress of each execution would be printed over the result
+	srv.SetLogger(output.NewStdoutLogger(output.NoneLog))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := watcher.New()
+	defer w.Close()
+
+	files := make(map[string]bool)
+	dirs := make(map[string]bool)
+	watchFiles := func(locations []string) {
+		files = make(map[string]bool)
+		for _, location := range locations {
+			file, err := filepath.Abs(location)
+			if err != nil {
+				continue
+			}
+			files[file] = true
+
+			dir := filepath.Dir(file)
+			if !dirs[dir] && w.Add(dir) == nil {
+				dirs[dir] = true
+			}
+		}
+	}
+
+	watchFiles([]string{filename})
+	if len(dirs) == 0 {
+		return fmt.Errorf("failed to watch the script file %s", filename)
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- w.Start(watchPollInterval)
+	}()
+
+	var previous *string
+	run := func() {
+		out, imported := runWatchedScript(filename, args, readerWriter, network, srv)
+		watchFiles(append([]string{filename}, imported...))
+
+		status := "Result"
+		if previous != nil && *previous != out {
+			status = "● Result changed"
+		}
+		previous = &out
+
+		// clear the screen so only the latest result is shown
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf(
+			"[%s] %s, watching %s and %d imported files (Ctrl-C to stop)\n\n%s\n",
+			time.Now().Format(time.RFC3339),
+			status,
+			filename,
+			len(imported),
+			out,
+		)
+	}
+
+	run()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case <-w.Error:
+			// deleted files are reported as errors, the script runs again once they are restored
+		case event := <-w.Event:
+			if !files[event.Path] && !files[event.OldPath] {
+				continue
+			}
+
+			settle := time.After(watchSettleDelay)
+		drain:
+			for {
+				select {
+				case <-w.Event:
+				case <-settle:
+					break drain
+				}
+			}
+			run()
+		}
+	}
+}
+
+// runWatchedScript loads and executes the script, and returns the output and the local files the script imports.
+func runWatchedScript(
+	filename string,
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	network string,
+	srv *services.Services,
+) (string, []string) {
+	script, err := loadScript(readerWriter, filename, args)
+	if err != nil {
+		return fmt.Sprintf("%s %s", output.ErrorEmoji(), err), nil
+	}
+
+	imported, err := srv.Scripts.ImportedFiles(script, network)
+	if err != nil {
+		return fmt.Sprintf("%s %s", output.ErrorEmoji(), err), nil
+	}
+
+	result, err := executeScript(script, readerWriter, network, srv)
+	if err != nil {
+		return fmt.Sprintf("%s %s", output.ErrorEmoji(), err), imported
+	}
+
+	return result.String(), imported
+}
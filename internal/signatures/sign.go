@@ -0,0 +1,158 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signatures
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsSign struct {
+	Signer   string `default:"emulator-account" flag:"signer" info:"name of the account used to sign"`
+	Encoding string `default:"utf8" flag:"encoding" info:"Encoding of the message: utf8, hex or base64"`
+	File     string `default:"" flag:"file" info:"File with the message to sign instead of the message argument"`
+}
+
+var signFlags = flagsSign{}
+
+var SignCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "sign [<message>]",
+		Aliases: []string{"generate"},
+		Short:   "Sign a message with the key of an account",
+		Example: "flow signatures sign 'The quick brown fox jumps over the lazy dog' --signer alice\n" +
+			"flow signatures sign 0x48656c6c6f --encoding hex --signer alice\n" +
+			"flow signatures sign --file message.bin --signer alice",
+		Args: cobra.MaximumNArgs(1),
+	},
+	Flags: &signFlags,
+	RunS:  sign,
+}
+
+func sign(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	s *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	message, err := readMessage(args, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := state.Accounts().ByName(signFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.Signatures.Sign(account, message)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SignatureResult{signature: signature, file: signFlags.File}
+	if len(args) > 0 {
+		result.message = args[0]
+	}
+
+	return result, nil
+}
+
+// readMessage reads the message from the file, or decodes the message argument with the encoding.
+func readMessage(args []string, readerWriter flowkit.ReaderWriter) ([]byte, error) {
+	if signFlags.File != "" {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("provide either the message or the file flag, not both")
+		}
+
+		message, err := readerWriter.ReadFile(signFlags.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the message file: %w", err)
+		}
+		return message, nil
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("provide the message or the file flag")
+	}
+
+	return services.DecodeMessage(args[0], signFlags.Encoding)
+}
+
+type SignatureResult struct {
+	signature *services.Signature
+	message   string // message as provided in the argument
+	file      string // file of the message if it wasn't provided in the argument
+}
+
+func (s *SignatureResult) JSON() interface{} {
+	result := map[string]interface{}{
+		"signature": fmt.Sprintf("%x", s.signature.Signature),
+		"keyIndex":  s.signature.KeyIndex,
+		"hashAlgo":  s.signature.HashAlgo.String(),
+		"sigAlgo":   s.signature.SigAlgo.String(),
+		"pubKey":    s.signature.PublicKey.String(),
+	}
+	if s.file != "" {
+		result["file"] = s.file
+	} else {
+		result["message"] = s.message
+	}
+
+	return result
+}
+
+func (s *SignatureResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Signature \t %x\n", s.signature.Signature)
+	if s.file != "" {
+		_, _ = fmt.Fprintf(writer, "Message File \t %s\n", s.file)
+	} else {
+		_, _ = fmt.Fprintf(writer, "Message \t %s\n", s.message)
+	}
+	_, _ = fmt.Fprintf(writer, "Public Key \t %s\n", s.signature.PublicKey)
+	_, _ = fmt.Fprintf(writer, "Key Index \t %d\n", s.signature.KeyIndex)
+	_, _ = fmt.Fprintf(writer, "Hash Algorithm \t %s\n", s.signature.HashAlgo)
+	_, _ = fmt.Fprintf(writer, "Signature Algorithm \t %s\n", s.signature.SigAlgo)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (s *SignatureResult) Oneliner() string {
+	message := s.message
+	if s.file != "" {
+		message = s.file
+	}
+
+	return fmt.Sprintf(
+		"signature: %x, message: %s, keyIndex: %d, hashAlgo: %s, sigAlgo: %s, pubKey: %s",
+		s.signature.Signature, message, s.signature.KeyIndex, s.signature.HashAlgo, s.signature.SigAlgo, s.signature.PublicKey,
+	)
+}
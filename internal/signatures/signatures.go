@@ -30,6 +30,6 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
-	GenerateCommand.AddToParent(Cmd)
+	SignCommand.AddToParent(Cmd)
 	VerifyCommand.AddToParent(Cmd)
 }
@@ -36,6 +36,7 @@ import (
 type flagsVerify struct {
 	SigAlgo  string `flag:"sig-algo" default:"ECDSA_P256" info:"Signature algorithm used to create the public key"`
 	HashAlgo string `flag:"hash-algo" default:"SHA3_256" info:"Hashing algorithm used to create signature"`
+	Encoding string `flag:"encoding" default:"utf8" info:"Encoding of the message: utf8, hex or base64"`
 }
 
 var verifyFlags = flagsVerify{}
@@ -43,7 +44,7 @@ var verifyFlags = flagsVerify{}
 var VerifyCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "verify <message> <signature> <public key>",
-		Short:   "Verify the signature",
+		Short:   "Verify the signature of a message signed with the sign command",
 		Example: "flow signatures verify 'The quick brown fox jumps over the lazy dog' 99fa...25b af3...52d",
 		Args:    cobra.ExactArgs(3),
 	},
@@ -55,10 +56,13 @@ func verify(
 	args []string,
 	_ flowkit.ReaderWriter,
 	_ command.GlobalFlags,
-	_ *services.Services,
+	s *services.Services,
 	_ *flowkit.State,
 ) (command.Result, error) {
-	message := []byte(args[0])
+	message, err := services.DecodeMessage(args[0], verifyFlags.Encoding)
+	if err != nil {
+		return nil, err
+	}
 
 	sig, err := hex.DecodeString(strings.ReplaceAll(args[1], "0x", ""))
 	if err != nil {
@@ -71,26 +75,28 @@ func verify(
 	}
 
 	sigAlgo := crypto.StringToSignatureAlgorithm(verifyFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", verifyFlags.SigAlgo)
+	}
+
 	hashAlgo := crypto.StringToHashAlgorithm(verifyFlags.HashAlgo)
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("invalid hash algorithm: %s", verifyFlags.HashAlgo)
+	}
 
 	pkey, err := crypto.DecodePublicKey(sigAlgo, key)
 	if err != nil {
 		return nil, fmt.Errorf("invalid public key: %w", err)
 	}
 
-	hasher, err := crypto.NewHasher(hashAlgo)
-	if err != nil {
-		return nil, err
-	}
-
-	valid, err := pkey.Verify(sig, message, hasher)
+	valid, err := s.Signatures.Verify(message, sig, pkey, hashAlgo)
 	if err != nil {
 		return nil, err
 	}
 
 	return &VerificationResult{
 		valid:     valid,
-		message:   message,
+		message:   args[0],
 		signature: sig,
 		hashAlgo:  hashAlgo,
 		sigAlgo:   sigAlgo,
@@ -100,7 +106,7 @@ func verify(
 
 type VerificationResult struct {
 	valid     bool
-	message   []byte
+	message   string
 	signature []byte
 	pubKey    []byte
 	sigAlgo   crypto.SignatureAlgorithm
@@ -110,8 +116,8 @@ type VerificationResult struct {
 func (s *VerificationResult) JSON() interface{} {
 	return map[string]string{
 		"valid":     fmt.Sprintf("%v", s.valid),
-		"message":   string(s.message),
-		"signature": string(s.signature),
+		"message":   s.message,
+		"signature": fmt.Sprintf("%x", s.signature),
 		"hashAlgo":  s.hashAlgo.String(),
 		"sigAlgo":   s.sigAlgo.String(),
 		"pubKey":    fmt.Sprintf("%x", s.pubKey),
@@ -139,3 +145,8 @@ func (s *VerificationResult) Oneliner() string {
 		s.valid, s.message, s.signature, s.sigAlgo, s.hashAlgo, s.pubKey,
 	)
 }
+
+// Failed reports invalid signatures, so the command exits with an error.
+func (s *VerificationResult) Failed() bool {
+	return !s.valid
+}
@@ -21,7 +21,9 @@ package status
 import (
 	"bytes"
 	"fmt"
+	"time"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
@@ -38,8 +40,9 @@ var statusFlags = FlagsStatus{}
 
 var Command = &command.Command{
 	Cmd: &cobra.Command{
-		Use:   "status",
-		Short: "Display the status of the Flow network",
+		Use:     "status",
+		Short:   "Display the status of the Flow network",
+		Example: "flow status --network testnet",
 	},
 	Flags: &statusFlags,
 	RunS:  status,
@@ -52,19 +55,25 @@ func status(
 	services *services.Services,
 	_ *flowkit.State,
 ) (command.Result, error) {
-	accessNode, err := services.Status.Ping(globalFlags.Network)
+	networkStatus, err := services.Status.Check(globalFlags.Network)
+	if networkStatus == nil {
+		return nil, err
+	}
 
 	return &Result{
-		network:    globalFlags.Network,
-		accessNode: accessNode,
-		err:        err,
+		network: globalFlags.Network,
+		status:  networkStatus,
+		checked: time.Now(),
+		err:     err,
 	}, nil
 }
 
 type Result struct {
-	network    string
-	accessNode string
-	err        error
+	network string
+	status  *services.NetworkStatus
+	// checked is the time of the check, the lag of the latest blocks is measured from
+	checked time.Time
+	err     error
 }
 
 // getStatus returns string representation for Flow network status.
@@ -94,6 +103,11 @@ func (r *Result) getIcon() string {
 	return output.StopEmoji()
 }
 
+// lag returns the time passed between the timestamp of the block and the check.
+func (r *Result) lag(block *flow.Block) time.Duration {
+	return r.checked.Sub(block.Timestamp).Round(time.Millisecond)
+}
+
 // String converts result to a string.
 func (r *Result) String() string {
 	var b bytes.Buffer
@@ -101,7 +115,37 @@ func (r *Result) String() string {
 
 	_, _ = fmt.Fprintf(writer, "Status:\t %s %s\n", r.getIcon(), r.getColoredStatus())
 	_, _ = fmt.Fprintf(writer, "Network:\t %s\n", r.network)
-	_, _ = fmt.Fprintf(writer, "Access Node:\t %s\n", r.accessNode)
+	_, _ = fmt.Fprintf(writer, "Access Node:\t %s\n", r.status.AccessNode)
+
+	if r.err != nil {
+		_, _ = fmt.Fprintf(writer, "Error:\t %s\n", r.err)
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "Latency:\t %s\n", r.status.Latency.Round(time.Millisecond))
+	if r.status.ChainMismatch() {
+		_, _ = fmt.Fprintf(
+			writer,
+			"Chain ID:\t %s %s\n",
+			output.ErrorEmoji(),
+			output.Red(fmt.Sprintf("%s, but the %s network is %s", r.status.ChainID, r.network, r.status.ExpectedChainID)),
+		)
+	} else {
+		_, _ = fmt.Fprintf(writer, "Chain ID:\t %s\n", r.status.ChainID)
+	}
+	_, _ = fmt.Fprintf(
+		writer,
+		"Latest Sealed Block:\t %d (%s ago)\n",
+		r.status.SealedBlock.Height,
+		r.lag(r.status.SealedBlock),
+	)
+	_, _ = fmt.Fprintf(
+		writer,
+		"Latest Finalized Block:\t %d (%s ago)\n",
+		r.status.FinalizedBlock.Height,
+		r.lag(r.status.FinalizedBlock),
+	)
 
 	_ = writer.Flush()
 	return b.String()
@@ -109,12 +153,30 @@ func (r *Result) String() string {
 
 // JSON converts result to a JSON.
 func (r *Result) JSON() interface{} {
-	result := make(map[string]string)
+	result := make(map[string]interface{})
 
 	result["network"] = r.network
-	result["accessNode"] = r.accessNode
+	result["accessNode"] = r.status.AccessNode
 	result["status"] = r.getStatus()
 
+	if r.err != nil {
+		result["error"] = r.err.Error()
+		return result
+	}
+
+	result["latencyMs"] = r.status.Latency.Milliseconds()
+	result["chainId"] = r.status.ChainID.String()
+	if r.status.ExpectedChainID != "" {
+		result["expectedChainId"] = r.status.ExpectedChainID.String()
+	}
+	result["chainMismatch"] = r.status.ChainMismatch()
+	result["sealedHeight"] = r.status.SealedBlock.Height
+	result["sealedTimestamp"] = r.status.SealedBlock.Timestamp
+	result["sealedLagMs"] = r.lag(r.status.SealedBlock).Milliseconds()
+	result["finalizedHeight"] = r.status.FinalizedBlock.Height
+	result["finalizedTimestamp"] = r.status.FinalizedBlock.Timestamp
+	result["finalizedLagMs"] = r.lag(r.status.FinalizedBlock).Milliseconds()
+
 	return result
 }
 
@@ -122,3 +184,9 @@ func (r *Result) JSON() interface{} {
 func (r *Result) Oneliner() string {
 	return r.getStatus()
 }
+
+// Failed returns true if the access node is unreachable or on another chain than the network,
+// so the command exits with an error.
+func (r *Result) Failed() bool {
+	return r.err != nil || r.status.ChainMismatch()
+}
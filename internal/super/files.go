@@ -73,11 +73,13 @@ func (f *projectFiles) exist() error {
 	if _, err := os.Stat(f.cadencePath); errors.Is(err, os.ErrNotExist) {
 		return fmt.Errorf("required cadence folder does not exist")
 	}
-	if _, err := os.Stat(config.DefaultPath); errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("required project configuration ('flow.json') does not exist")
+	for _, path := range config.DefaultFormatPaths {
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		}
 	}
 
-	return nil
+	return fmt.Errorf("required project configuration ('flow.json') does not exist")
 }
 
 // contracts returns a list of contracts in project.
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	devWallet "github.com/onflow/fcl-dev-wallet/go/wallet"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
@@ -57,21 +58,12 @@ func wallet(
 	_ *services.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
-	service, err := state.EmulatorServiceAccount()
+	conf, err := DevWalletConfig(state, walletFlags.Host)
 	if err != nil {
 		return nil, err
 	}
 
-	key := service.Key().ToConfig()
-
-	conf := devWallet.FlowConfig{
-		Address:    fmt.Sprintf("0x%s", service.Address().String()),
-		PrivateKey: strings.TrimPrefix(key.PrivateKey.String(), "0x"),
-		PublicKey:  strings.TrimPrefix(key.PrivateKey.PublicKey().String(), "0x"),
-		AccessNode: walletFlags.Host,
-	}
-
-	srv, err := devWallet.NewHTTPServer(walletFlags.Port, &conf)
+	srv, err := devWallet.NewHTTPServer(walletFlags.Port, conf)
 	if err != nil {
 		return nil, err
 	}
@@ -82,3 +74,36 @@ func wallet(
 	srv.Start()
 	return nil, nil
 }
+
+// DevWalletConfig returns the configuration of the dev wallet signing with the emulator service account,
+// connected to the REST API of the emulator on the access node host.
+func DevWalletConfig(state *flowkit.State, accessNode string) (*devWallet.FlowConfig, error) {
+	service, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := service.Key().PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("only hexadecimal keys can be used as the dev wallet service account key")
+	}
+
+	// the dev wallet signs in the browser and only supports the default emulator service key algorithms
+	if service.Key().SigAlgo() != crypto.ECDSA_P256 || service.Key().HashAlgo() != crypto.SHA3_256 {
+		return nil, fmt.Errorf(
+			"the dev wallet doesn't support the %s signature algorithm with the %s hash algorithm used by the %s account key, use %s with %s instead",
+			service.Key().SigAlgo(),
+			service.Key().HashAlgo(),
+			service.Name(),
+			crypto.ECDSA_P256,
+			crypto.SHA3_256,
+		)
+	}
+
+	return &devWallet.FlowConfig{
+		Address:    fmt.Sprintf("0x%s", service.Address().String()),
+		PrivateKey: strings.TrimPrefix((*privateKey).String(), "0x"),
+		PublicKey:  strings.TrimPrefix((*privateKey).PublicKey().String(), "0x"),
+		AccessNode: accessNode,
+	}, nil
+}
@@ -0,0 +1,346 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsBatch struct {
+	Parallel    int    `default:"1" flag:"parallel" info:"Maximum number of independent transactions sent at once, transactions are sent one by one by default"`
+	ResumeFrom  int    `default:"0" flag:"resume-from" info:"Index of the plan transaction to continue a partially completed batch from"`
+	ResultsFile string `default:"" flag:"results-file" info:"File the results are written to, <plan>.results.json by default"`
+}
+
+var batchFlags = flagsBatch{}
+
+var BatchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "batch <plan filename>",
+		Short:   "Send the transactions of a plan file in order",
+		Args:    cobra.ExactArgs(1),
+		Example: "flow transactions batch plan.json --network testnet",
+	},
+	Flags: &batchFlags,
+	RunS:  batch,
+}
+
+// defaultBatchGasLimit is the gas limit of the plan transactions without a gas limit.
+const defaultBatchGasLimit = 1000
+
+// batchPlan is the plan file of a batch of transactions.
+type batchPlan struct {
+	Transactions []batchPlanTransaction `json:"transactions"`
+}
+
+// batchPlanTransaction is a transaction of a plan, the code location is relative to the plan file.
+type batchPlanTransaction struct {
+	Code      string          `json:"code"`
+	Args      json.RawMessage `json:"args"`
+	Signer    string          `json:"signer"`
+	GasLimit  uint64          `json:"gasLimit"`
+	DependsOn *int            `json:"dependsOn"`
+}
+
+// batchResultEntry is a transaction result written to the results file.
+type batchResultEntry struct {
+	Index   int    `json:"index"`
+	ID      string `json:"id,omitempty"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Skipped bool   `json:"skipped,omitempty"`
+}
+
+func batch(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	srv *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	planFilename := args[0]
+	content, err := readerWriter.ReadFile(planFilename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading plan file: %w", err)
+	}
+
+	var plan batchPlan
+	err = json.Unmarshal(content, &plan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plan file %s: %w", planFilename, err)
+	}
+
+	if batchFlags.ResumeFrom < 0 || batchFlags.ResumeFrom >= len(plan.Transactions) {
+		return nil, fmt.Errorf("resume index %d is out of the range of the %d plan transactions", batchFlags.ResumeFrom, len(plan.Transactions))
+	}
+
+	transactions := make([]services.BatchTransaction, len(plan.Transactions))
+	for i, planTx := range plan.Transactions {
+		transactions[i], err = batchTransaction(readerWriter, state, filepath.Dir(planFilename), planTx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid plan transaction %d: %w", i, err)
+		}
+	}
+
+	resultsFilename := batchFlags.ResultsFile
+	if resultsFilename == "" {
+		resultsFilename = strings.TrimSuffix(planFilename, filepath.Ext(planFilename)) + ".results.json"
+	}
+
+	// the results of the transactions sent before the resume index are kept from the previous run
+	entries := make([]batchResultEntry, 0, len(transactions))
+	if batchFlags.ResumeFrom > 0 {
+		var previous []batchResultEntry
+		if content, err := readerWriter.ReadFile(resultsFilename); err == nil && json.Unmarshal(content, &previous) == nil {
+			for _, entry := range previous {
+				if entry.Index < batchFlags.ResumeFrom {
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+
+	fmt.Printf("%-6s %-64s %s\n", "Index", "Transaction ID", "Status")
+
+	results, err := srv.Transactions.SendBatch(transactions, globalFlags.Network, services.BatchOptions{
+		Start:       batchFlags.ResumeFrom,
+		Parallelism: batchFlags.Parallel,
+		Report: func(result services.BatchResult) {
+			entry := newBatchResultEntry(result)
+			id := entry.ID
+			if id == "" {
+				id = "-"
+			}
+			fmt.Printf("%-6d %-64s %s\n", entry.Index, id, entry.Status)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		entries = append(entries, newBatchResultEntry(result))
+	}
+
+	encoded, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = readerWriter.WriteFile(resultsFilename, encoded, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write results file %s: %w", resultsFilename, err)
+	}
+
+	return &BatchResult{
+		results:     results,
+		resultsFile: resultsFilename,
+	}, nil
+}
+
+// batchTransaction reads the code and parses the arguments of the plan transaction.
+func batchTransaction(
+	readerWriter flowkit.ReaderWriter,
+	state *flowkit.State,
+	planDir string,
+	planTx batchPlanTransaction,
+) (services.BatchTransaction, error) {
+	if planTx.Code == "" {
+		return services.BatchTransaction{}, fmt.Errorf("missing code")
+	}
+
+	codeFilename := planTx.Code
+	if !filepath.IsAbs(codeFilename) {
+		codeFilename = filepath.Join(planDir, codeFilename)
+	}
+
+	code, err := readerWriter.ReadFile(codeFilename)
+	if err != nil {
+		return services.BatchTransaction{}, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	var transactionArgs []cadence.Value
+	if len(planTx.Args) > 0 {
+		transactionArgs, err = flowkit.ParseArgumentsJSON(string(planTx.Args))
+		if err != nil {
+			return services.BatchTransaction{}, fmt.Errorf("error parsing transaction arguments: %w", err)
+		}
+	}
+
+	signerName := planTx.Signer
+	if signerName == "" {
+		signerName = state.Config().Emulators.Default().ServiceAccount
+	}
+	signer, err := state.Accounts().ByName(signerName)
+	if err != nil {
+		return services.BatchTransaction{}, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
+	}
+
+	gasLimit := planTx.GasLimit
+	if gasLimit == 0 {
+		gasLimit = defaultBatchGasLimit
+	}
+
+	dependsOn := -1
+	if planTx.DependsOn != nil {
+		dependsOn = *planTx.DependsOn
+	}
+
+	return services.BatchTransaction{
+		Script:    flowkit.NewScript(code, transactionArgs, codeFilename),
+		Signer:    signer,
+		GasLimit:  gasLimit,
+		DependsOn: dependsOn,
+	}, nil
+}
+
+func newBatchResultEntry(result services.BatchResult) batchResultEntry {
+	entry := batchResultEntry{
+		Index:   result.Index,
+		Status:  batchStatus(result),
+		Skipped: result.Skipped,
+	}
+	if result.ID != flow.EmptyID {
+		entry.ID = result.ID.String()
+	}
+	if result.Error != nil {
+		entry.Error = result.Error.Error()
+	} else if result.Result != nil && result.Result.Error != nil {
+		entry.Error = result.Result.Error.Error()
+	}
+
+	return entry
+}
+
+// batchStatus returns the status of the transaction result, or why the transaction has no status.
+func batchStatus(result services.BatchResult) string {
+	switch {
+	case result.Skipped:
+		return "SKIPPED"
+	case result.Result == nil:
+		return "FAILED"
+	case result.Result.Error != nil:
+		return fmt.Sprintf("%s (ERROR)", result.Result.Status)
+	default:
+		return result.Result.Status.String()
+	}
+}
+
+// BatchResult is the summary of the sent batch.
+type BatchResult struct {
+	results     []services.BatchResult
+	resultsFile string
+}
+
+// firstFailed returns the index of the first failed transaction, the batch can be resumed from it.
+func (r *BatchResult) firstFailed() (int, bool) {
+	for _, result := range r.results {
+		if result.Failed() {
+			return result.Index, true
+		}
+	}
+	return 0, false
+}
+
+func (r *BatchResult) counts() (sealed int, failed int, skipped int) {
+	for _, result := range r.results {
+		switch {
+		case result.Skipped:
+			skipped++
+		case result.Failed():
+			failed++
+		default:
+			sealed++
+		}
+	}
+	return
+}
+
+func (r *BatchResult) JSON() interface{} {
+	sealed, failed, skipped := r.counts()
+
+	entries := make([]batchResultEntry, 0, len(r.results))
+	for _, result := range r.results {
+		entries = append(entries, newBatchResultEntry(result))
+	}
+
+	result := map[string]interface{}{
+		"sealed":      sealed,
+		"failed":      failed,
+		"skipped":     skipped,
+		"results":     entries,
+		"resultsFile": r.resultsFile,
+	}
+	if index, ok := r.firstFailed(); ok {
+		result["resumeFrom"] = index
+	}
+
+	return result
+}
+
+func (r *BatchResult) String() string {
+	sealed, failed, skipped := r.counts()
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Sealed\t%d\n", sealed)
+	_, _ = fmt.Fprintf(writer, "Failed\t%d\n", failed)
+	_, _ = fmt.Fprintf(writer, "Skipped\t%d\n", skipped)
+	_, _ = fmt.Fprintf(writer, "Results File\t%s\n", r.resultsFile)
+
+	for _, result := range r.results {
+		entry := newBatchResultEntry(result)
+		if entry.Error != "" && !entry.Skipped {
+			_, _ = fmt.Fprintf(writer, "\n%s Transaction %d failed: %s\n", output.ErrorEmoji(), entry.Index, entry.Error)
+		}
+	}
+
+	if index, ok := r.firstFailed(); ok {
+		_, _ = fmt.Fprintf(writer, "\n%s Continue the batch with --resume-from %d\n", output.TryEmoji(), index)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *BatchResult) Oneliner() string {
+	sealed, failed, skipped := r.counts()
+	return fmt.Sprintf("Sealed: %d, Failed: %d, Skipped: %d, Results File: %s", sealed, failed, skipped, r.resultsFile)
+}
+
+// Failed reports if any transaction of the batch failed, so the command exits with an error.
+func (r *BatchResult) Failed() bool {
+	_, failed := r.firstFailed()
+	return failed
+}
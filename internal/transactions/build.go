@@ -19,6 +19,7 @@
 package transactions
 
 import (
+	"encoding/hex"
 	"fmt"
 
 	"github.com/onflow/cadence"
@@ -39,15 +40,16 @@ type flagsBuild struct {
 	Payer            string   `default:"emulator-account" flag:"payer" info:"transaction payer"`
 	Authorizer       []string `default:"emulator-account" flag:"authorizer" info:"transaction authorizer"`
 	GasLimit         uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	ToFile           string   `default:"" flag:"to-file" info:"file to write the RLP encoded payload of the built transaction to, for signing with the sign command"`
 }
 
 var buildFlags = flagsBuild{}
 
 var BuildCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "build <code filename>  [<argument> <argument> ...]",
+		Use:     "build <code filename | ->  [<argument> <argument> ...]",
 		Short:   "Build an unsigned transaction",
-		Example: `flow transactions build ./transaction.cdc "Hello" --proposer alice --authorizer alice --payer bob`,
+		Example: `flow transactions build ./transaction.cdc "Hello" --proposer alice --authorizer alice --payer bob --to-file built.rlp`,
 		Args:    cobra.MinimumNArgs(1),
 	},
 	Flags: &buildFlags,
@@ -81,8 +83,12 @@ func build(
 		return nil, err
 	}
 
-	filename := args[0]
-	code, err := readerWriter.ReadFile(filename)
+	// the transaction can't be approved on the standard input once the code is read from it
+	if args[0] == command.StdinFilename && !globalFlags.Yes {
+		return nil, fmt.Errorf("reading the transaction from the standard input requires the --yes flag")
+	}
+
+	code, location, err := command.ReadCode(readerWriter, args[0], "")
 	if err != nil {
 		return nil, fmt.Errorf("error loading transaction file: %w", err)
 	}
@@ -91,7 +97,7 @@ func build(
 	if buildFlags.ArgsJSON != "" {
 		transactionArgs, err = flowkit.ParseArgumentsJSON(buildFlags.ArgsJSON)
 	} else {
-		transactionArgs, err = flowkit.ParseArgumentsWithoutType(filename, code, args[1:])
+		transactionArgs, err = flowkit.ParseArgumentsWithoutType(location, code, args[1:])
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
@@ -100,7 +106,7 @@ func build(
 	tx, err := srv.Transactions.Build(
 		services.NewTransactionAddresses(proposer, payer, authorizers),
 		buildFlags.ProposerKeyIndex,
-		flowkit.NewScript(code, transactionArgs, filename),
+		flowkit.NewScript(code, transactionArgs, location),
 		buildFlags.GasLimit,
 		globalFlags.Network,
 	)
@@ -112,6 +118,13 @@ func build(
 		return nil, fmt.Errorf("transaction was not approved")
 	}
 
+	if buildFlags.ToFile != "" {
+		payload := []byte(hex.EncodeToString(tx.FlowTransaction().Encode()))
+		if err := readerWriter.WriteFile(buildFlags.ToFile, payload, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write the transaction payload to %s: %w", buildFlags.ToFile, err)
+		}
+	}
+
 	return &TransactionResult{
 		tx:      tx.FlowTransaction(),
 		include: []string{"code", "payload", "signatures"},
@@ -19,28 +19,38 @@
 package transactions
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
 type flagsDecode struct {
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: payload."`
 }
 
 var decodeFlags = flagsDecode{}
 
+// decodeCmd is referenced by the command to check if the network flag is provided.
+var decodeCmd = &cobra.Command{
+	Use:   "decode <transaction hex or filename>",
+	Short: "Decode a transaction",
+	Example: `flow transactions decode ./transaction.rlp
+flow transactions decode f9015ff9015bb8...c0 --network testnet`,
+	Args: cobra.ExactArgs(1),
+}
+
 var DecodeCommand = &command.Command{
-	Cmd: &cobra.Command{
-		Use:     "decode <transaction filename>",
-		Short:   "Decode a transaction",
-		Example: "flow transactions decode ./transaction.rlp",
-		Args:    cobra.ExactArgs(1),
-	},
+	Cmd:   decodeCmd,
 	Flags: &decodeFlags,
 	RunS:  decode,
 }
@@ -49,13 +59,12 @@ func decode(
 	args []string,
 	readerWriter flowkit.ReaderWriter,
 	globalFlags command.GlobalFlags,
-	services *services.Services,
+	srv *services.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
-	filename := args[0]
-	payload, err := readerWriter.ReadFile(filename)
+	payload, err := readPayload(readerWriter, args[0])
 	if err != nil {
-		return nil, fmt.Errorf("failed to read transaction from %s: %v", filename, err)
+		return nil, err
 	}
 
 	tx, err := flowkit.NewTransactionFromPayload(payload)
@@ -63,8 +72,198 @@ func decode(
 		return nil, err
 	}
 
-	return &TransactionResult{
+	result := &DecodeResult{
 		tx:      tx.FlowTransaction(),
 		include: decodeFlags.Include,
-	}, nil
+	}
+
+	// the signatures are only verified on the network explicitly selected
+	if decodeCmd.Flags().Changed("network") || globalFlags.Host != "" {
+		result.verifications, err = srv.Transactions.VerifySignatures(result.tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify the signatures: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// readPayload returns the hex-encoded transaction given directly or read from the file.
+func readPayload(readerWriter flowkit.ReaderWriter, hexOrFilename string) ([]byte, error) {
+	payload := strings.TrimPrefix(strings.TrimSpace(hexOrFilename), "0x")
+	if _, err := hex.DecodeString(payload); err == nil && payload != "" {
+		return []byte(payload), nil
+	}
+
+	content, err := readerWriter.ReadFile(hexOrFilename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction from %s: %v", hexOrFilename, err)
+	}
+
+	return []byte(strings.TrimPrefix(strings.TrimSpace(string(content)), "0x")), nil
+}
+
+// DecodeResult represents the decoded transaction with the verification of its signatures.
+type DecodeResult struct {
+	tx            *flow.Transaction
+	verifications []services.SignatureVerification // only set if the signatures are verified
+	include       []string
+}
+
+// signatures returns the payload and envelope signatures, verified if the verification is available.
+func (r *DecodeResult) signatures() []services.SignatureVerification {
+	if r.verifications != nil {
+		return r.verifications
+	}
+
+	signatures := make([]services.SignatureVerification, 0, len(r.tx.PayloadSignatures)+len(r.tx.EnvelopeSignatures))
+	for _, signature := range r.tx.PayloadSignatures {
+		signatures = append(signatures, services.SignatureVerification{TransactionSignature: signature})
+	}
+	for _, signature := range r.tx.EnvelopeSignatures {
+		signatures = append(signatures, services.SignatureVerification{TransactionSignature: signature, Envelope: true})
+	}
+
+	return signatures
+}
+
+func signatureKind(signature services.SignatureVerification) string {
+	if signature.Envelope {
+		return "envelope"
+	}
+	return "payload"
+}
+
+func (r *DecodeResult) JSON() interface{} {
+	authorizers := make([]string, 0, len(r.tx.Authorizers))
+	for _, authorizer := range r.tx.Authorizers {
+		authorizers = append(authorizers, authorizer.String())
+	}
+
+	signatures := make([]map[string]interface{}, 0)
+	for _, signature := range r.signatures() {
+		s := map[string]interface{}{
+			"kind":      signatureKind(signature),
+			"address":   signature.Address.String(),
+			"keyIndex":  signature.KeyIndex,
+			"signature": fmt.Sprintf("%x", signature.Signature),
+		}
+		if r.verifications != nil {
+			s["valid"] = signature.Valid
+			if !signature.Valid {
+				s["reason"] = signature.Reason
+			}
+		}
+		signatures = append(signatures, s)
+	}
+
+	return map[string]interface{}{
+		"id": r.tx.ID().String(),
+		"proposalKey": map[string]interface{}{
+			"address":        r.tx.ProposalKey.Address.String(),
+			"keyIndex":       r.tx.ProposalKey.KeyIndex,
+			"sequenceNumber": r.tx.ProposalKey.SequenceNumber,
+		},
+		"payer":          r.tx.Payer.String(),
+		"authorizers":    authorizers,
+		"gasLimit":       r.tx.GasLimit,
+		"referenceBlock": r.tx.ReferenceBlockID.String(),
+		"arguments":      decodeArguments(r.tx.Arguments),
+		"code":           string(r.tx.Script),
+		"signatures":     signatures,
+		"payload":        fmt.Sprintf("%x", r.tx.Encode()),
+	}
+}
+
+func (r *DecodeResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.tx.ID())
+	_, _ = fmt.Fprintf(writer, "Payer\t%s\n", r.tx.Payer.Hex())
+	_, _ = fmt.Fprintf(writer, "Authorizers\t%s\n", r.tx.Authorizers)
+	_, _ = fmt.Fprintf(writer, "Gas Limit\t%d\n", r.tx.GasLimit)
+	_, _ = fmt.Fprintf(writer, "Reference Block\t%s\n", r.tx.ReferenceBlockID)
+
+	_, _ = fmt.Fprintf(writer,
+		"\nProposal Key:\t\n    Address\t%s\n    Index\t%v\n    Sequence\t%v\n",
+		r.tx.ProposalKey.Address, r.tx.ProposalKey.KeyIndex, r.tx.ProposalKey.SequenceNumber,
+	)
+
+	signatures := r.signatures()
+	if len(signatures) == 0 {
+		_, _ = fmt.Fprintf(writer, "\nNo Signatures\n")
+	}
+
+	payloadIndex, envelopeIndex := 0, 0
+	for _, signature := range signatures {
+		if signature.Envelope {
+			_, _ = fmt.Fprintf(writer, "\nEnvelope Signature %d:\n", envelopeIndex)
+			envelopeIndex++
+		} else {
+			_, _ = fmt.Fprintf(writer, "\nPayload Signature %d:\n", payloadIndex)
+			payloadIndex++
+		}
+		_, _ = fmt.Fprintf(writer, "    Address\t%s\n", signature.Address)
+		_, _ = fmt.Fprintf(writer, "    Key Index\t%d\n", signature.KeyIndex)
+		_, _ = fmt.Fprintf(writer, "    Signature\t%x\n", signature.Signature)
+
+		if r.verifications != nil {
+			if signature.Valid {
+				_, _ = fmt.Fprintf(writer, "    Valid\t%s\n", output.OkEmoji())
+			} else {
+				_, _ = fmt.Fprintf(writer, "    Valid\t%s %s\n", output.ErrorEmoji(), signature.Reason)
+			}
+		}
+	}
+
+	arguments := decodeArguments(r.tx.Arguments)
+	if len(arguments) == 0 {
+		_, _ = fmt.Fprintf(writer, "\nArguments\tNo arguments\n")
+	} else {
+		_, _ = fmt.Fprintf(writer, "\nArguments (%d):\n", len(arguments))
+		for i, argument := range arguments {
+			_, _ = fmt.Fprintf(writer, "    - Argument %d: %s\n", i, argument)
+		}
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nCode\n\n%s\n", r.tx.Script)
+
+	if command.ContainsFlag(r.include, "payload") {
+		_, _ = fmt.Fprintf(writer, "\n\nPayload:\n%x", r.tx.Encode())
+	} else {
+		_, _ = fmt.Fprint(writer, "\n\nPayload (hidden, use --include payload)")
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *DecodeResult) Oneliner() string {
+	result := fmt.Sprintf(
+		"ID: %s, Payer: %s, Authorizers: %s, Gas Limit: %d, Signatures: %d",
+		r.tx.ID(), r.tx.Payer, r.tx.Authorizers, r.tx.GasLimit, len(r.signatures()),
+	)
+
+	if r.verifications != nil {
+		invalid := 0
+		for _, verification := range r.verifications {
+			if !verification.Valid {
+				invalid++
+			}
+		}
+		result += fmt.Sprintf(", Invalid Signatures: %d", invalid)
+	}
+
+	return result
+}
+
+// Failed reports if some of the signatures are invalid, so the command exits with an error.
+func (r *DecodeResult) Failed() bool {
+	for _, verification := range r.verifications {
+		if !verification.Valid {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,209 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsEstimate struct {
+	ArgsJSON     string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	ArgsJSONFile string   `default:"" flag:"args-json-file" info:"file containing the arguments as a JSON array in JSON-Cadence format"`
+	Arg          []string `default:"" flag:"arg" info:"argument in the name:Type:value format, the flag can be repeated"`
+	GasLimit     uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit the estimate is compared to"`
+}
+
+var estimateFlags = flagsEstimate{}
+
+var EstimateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "estimate <code filename> [<argument> <argument> ...]",
+		Short:   "Estimate the computation used by a transaction",
+		Args:    cobra.MinimumNArgs(1),
+		Example: `flow transactions estimate tx.cdc "Hello world"`,
+	},
+	Flags: &estimateFlags,
+	RunS:  estimate,
+}
+
+func estimate(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	_ command.GlobalFlags,
+	srv *services.Services,
+	_ *flowkit.State,
+) (command.Result, error) {
+	if estimateFlags.GasLimit == 0 {
+		return nil, fmt.Errorf("the gas limit must be greater than zero")
+	}
+
+	codeFilename := args[0]
+	code, err := readerWriter.ReadFile(codeFilename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	transactionArgs, err := command.ParseArguments(readerWriter, codeFilename, code, args[1:], command.ArgumentsFlags{
+		JSON:     estimateFlags.ArgsJSON,
+		JSONFile: estimateFlags.ArgsJSONFile,
+		Named:    estimateFlags.Arg,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+	}
+
+	// the estimate is cached for the code as it is in the file, before the imports are resolved
+	cachePath, cacheErr := estimatePath(code, transactionArgs)
+
+	result, err := srv.Transactions.Estimate(flowkit.NewScript(code, transactionArgs, codeFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Error == nil && cacheErr == nil {
+		_ = saveEstimate(cachePath, result.ComputationUsed)
+	}
+
+	return &EstimateResult{
+		Estimate: result,
+		gasLimit: estimateFlags.GasLimit,
+	}, nil
+}
+
+// estimatePath returns the path in the user cache directory of the estimate of the transaction code with the arguments.
+func estimatePath(code []byte, args []cadence.Value) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	encodedArgs, err := flowkit.EncodeArgumentsJSON(args)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(append(append([]byte{}, code...), encodedArgs...))
+	return filepath.Join(cacheDir, "flow", "estimates", hex.EncodeToString(hash[:])), nil
+}
+
+func saveEstimate(path string, computation uint64) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(strconv.FormatUint(computation, 10)), 0644)
+}
+
+// priorEstimate returns the cached estimate of the transaction code with the arguments, if it was estimated before.
+func priorEstimate(code []byte, args []cadence.Value) (uint64, bool) {
+	path, err := estimatePath(code, args)
+	if err != nil {
+		return 0, false
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+
+	computation, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return computation, true
+}
+
+// formatComputation formats the computation in units and as the percentage of the gas limit.
+func formatComputation(computation uint64, gasLimit uint64) string {
+	if gasLimit == 0 {
+		return fmt.Sprintf("%d", computation)
+	}
+
+	return fmt.Sprintf("%d (%.2f%% of the %d gas limit)", computation, float64(computation)*100/float64(gasLimit), gasLimit)
+}
+
+// EstimateResult is the estimated computation of a transaction compared to the gas limit.
+type EstimateResult struct {
+	*services.Estimate
+	gasLimit uint64
+}
+
+func (r *EstimateResult) JSON() interface{} {
+	result := map[string]interface{}{
+		"computationUsed":   r.ComputationUsed,
+		"gasLimit":          r.gasLimit,
+		"limitPercentage":   float64(r.ComputationUsed) * 100 / float64(r.gasLimit),
+		"suggestedGasLimit": r.SuggestedGasLimit(),
+	}
+	if r.Error != nil {
+		result["error"] = r.Error.Error()
+	}
+
+	return result
+}
+
+func (r *EstimateResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if r.Error != nil {
+		_, _ = fmt.Fprintf(writer, "%s Transaction Error \n%s\n\n", output.ErrorEmoji(), r.Error.Error())
+	}
+
+	_, _ = fmt.Fprintf(writer, "Computation Used\t%s\n", formatComputation(r.ComputationUsed, r.gasLimit))
+	_, _ = fmt.Fprintf(writer, "Suggested Gas Limit\t%d\n", r.SuggestedGasLimit())
+
+	if r.ComputationUsed > r.gasLimit {
+		_, _ = fmt.Fprintf(writer, "\n%s The computation exceeds the gas limit, use --gas-limit %d\n", output.WarningEmoji(), r.SuggestedGasLimit())
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *EstimateResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Computation Used: %s, Suggested Gas Limit: %d",
+		formatComputation(r.ComputationUsed, r.gasLimit),
+		r.SuggestedGasLimit(),
+	)
+}
+
+// Failed reports if the transaction failed to execute, so the command exits with an error.
+func (r *EstimateResult) Failed() bool {
+	return r.Error != nil
+}
@@ -26,13 +26,18 @@ import (
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
 )
 
 type flagsGet struct {
-	Sealed  bool     `default:"true" flag:"sealed" info:"Wait for a sealed result"`
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
-	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output. Valid values: events."`
+	Sealed    bool     `default:"true" flag:"sealed" info:"Wait for a sealed result"`
+	Wait      bool     `default:"false" flag:"wait" info:"Wait for the transaction to be sealed, reporting each change of its status"`
+	Include   []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: events, signatures, code, payload."`
+	Exclude   []string `default:"" flag:"exclude" info:"Fields to exclude from the output. Valid values: events."`
+	Format    string   `default:"" flag:"format" info:"Format of the result, options: \"json-simple\""`
+	TypeHints bool     `default:"false" flag:"type-hints" info:"add the type of composite values in the _type field of the json-simple format"`
+	Depth     int      `default:"0" flag:"depth" info:"maximum nesting of values decoded in the json-simple format, unlimited if zero"`
 }
 
 var getFlags = flagsGet{}
@@ -53,19 +58,35 @@ func get(
 	args []string,
 	_ flowkit.ReaderWriter,
 	_ command.GlobalFlags,
-	services *services.Services,
+	srv *services.Services,
 ) (command.Result, error) {
+	if err := validateFormat(getFlags.Format); err != nil {
+		return nil, err
+	}
+
 	id := flow.HexToID(strings.TrimPrefix(args[0], "0x"))
 
-	tx, result, err := services.Transactions.GetStatus(id, getFlags.Sealed)
+	tx, result, err := srv.Transactions.GetStatus(id, getFlags.Sealed && !getFlags.Wait)
 	if err != nil {
 		return nil, err
 	}
 
+	if getFlags.Wait {
+		result, err = srv.Transactions.WaitForStatus(id, services.DefaultWaitOptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &TransactionResult{
 		result:  result,
 		tx:      tx,
 		include: getFlags.Include,
 		exclude: getFlags.Exclude,
+		format:  getFlags.Format,
+		valueOptions: output.ValueOptions{
+			TypeHints: getFlags.TypeHints,
+			Depth:     getFlags.Depth,
+		},
 	}, nil
 }
@@ -0,0 +1,281 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+type flagsReplay struct {
+	ExportDir string `default:"." flag:"export-dir" info:"Directory the transaction code and arguments are exported to"`
+	Emulator  bool   `default:"false" flag:"emulator" info:"Replay the transaction on the running emulator and compare the results"`
+}
+
+var replayFlags = flagsReplay{}
+
+var ReplayCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "replay <tx_id>",
+		Short:   "Export a sealed transaction and replay it on the emulator",
+		Args:    cobra.ExactArgs(1),
+		Example: "flow transactions replay 07a8...b433 --network mainnet --emulator",
+	},
+	Flags: &replayFlags,
+	RunS:  replay,
+}
+
+func replay(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	srv *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	id := flow.HexToID(strings.TrimPrefix(args[0], "0x"))
+
+	tx, result, err := srv.Transactions.GetStatus(id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _, _, err := srv.Blocks.GetBlock(tx.ReferenceBlockID.String(), "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the reference block: %w", err)
+	}
+
+	codeFilename, argsFilename, err := exportTransaction(readerWriter, replayFlags.ExportDir, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	replayResult := &ReplayResult{
+		tx:             tx,
+		result:         result,
+		referenceBlock: block,
+		codeFilename:   codeFilename,
+		argsFilename:   argsFilename,
+	}
+
+	if !replayFlags.Emulator {
+		return replayResult, nil
+	}
+
+	emulator, err := state.Networks().ByName(config.DefaultEmulatorNetwork().Name)
+	if err != nil {
+		return nil, err
+	}
+
+	emulatorGateway, err := gateway.NewGrpcGateway(emulator.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	emulatorServices := services.NewServices(emulatorGateway, state, output.NewStdoutLogger(output.InfoLog))
+	replayResult.replayedTx, replayResult.replayed, err = emulatorServices.Transactions.Replay(tx, globalFlags.Network)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay the transaction on the emulator: %w", err)
+	}
+
+	return replayResult, nil
+}
+
+// exportTransaction writes the transaction code and a file with the JSON-Cadence arguments to the directory.
+func exportTransaction(readerWriter flowkit.ReaderWriter, dir string, tx *flow.Transaction) (string, string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create the export directory %s: %w", dir, err)
+	}
+
+	codeFilename := filepath.Join(dir, fmt.Sprintf("%s.cdc", tx.ID()))
+	err = readerWriter.WriteFile(codeFilename, tx.Script, 0644)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to export the transaction code: %w", err)
+	}
+
+	arguments := make([]json.RawMessage, len(tx.Arguments))
+	for i, argument := range tx.Arguments {
+		arguments[i] = bytes.TrimSpace(argument)
+	}
+
+	encoded, err := json.MarshalIndent(arguments, "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+
+	argsFilename := filepath.Join(dir, fmt.Sprintf("%s.args.json", tx.ID()))
+	err = readerWriter.WriteFile(argsFilename, encoded, 0644)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to export the transaction arguments: %w", err)
+	}
+
+	return codeFilename, argsFilename, nil
+}
+
+// ReplayResult is the exported transaction with the comparison of the live and the replayed results.
+type ReplayResult struct {
+	tx             *flow.Transaction
+	result         *flow.TransactionResult
+	referenceBlock *flow.Block
+	codeFilename   string
+	argsFilename   string
+	replayedTx     *flow.Transaction       // only set if the transaction is replayed
+	replayed       *flow.TransactionResult // only set if the transaction is replayed
+}
+
+// differences returns the differences of the live and the replayed results.
+//
+// The events are compared by their types without the contract addresses, as the addresses of the
+// contracts on the emulator are different.
+func (r *ReplayResult) differences() []string {
+	if r.replayed == nil {
+		return nil
+	}
+
+	differences := make([]string, 0)
+	if r.result.Status != r.replayed.Status {
+		differences = append(differences, fmt.Sprintf("status is %s live, but %s replayed", r.result.Status, r.replayed.Status))
+	}
+
+	switch {
+	case r.result.Error == nil && r.replayed.Error != nil:
+		differences = append(differences, fmt.Sprintf("succeeded live, but failed replayed: %s", r.replayed.Error))
+	case r.result.Error != nil && r.replayed.Error == nil:
+		differences = append(differences, fmt.Sprintf("failed live, but succeeded replayed: %s", r.result.Error))
+	case r.result.Error != nil && r.replayed.Error != nil && r.result.Error.Error() != r.replayed.Error.Error():
+		differences = append(differences, fmt.Sprintf("failed with a different error replayed: %s", r.replayed.Error))
+	}
+
+	live, replayed := eventNames(r.result.Events), eventNames(r.replayed.Events)
+	if strings.Join(live, ",") != strings.Join(replayed, ",") {
+		differences = append(differences, fmt.Sprintf("emitted events %s live, but %s replayed", live, replayed))
+	}
+
+	return differences
+}
+
+// eventNames returns the event types without the contract addresses, like FlowToken.TokensWithdrawn.
+func eventNames(events []flow.Event) []string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		parts := strings.SplitN(event.Type, ".", 3)
+		if len(parts) == 3 && parts[0] == "A" {
+			names[i] = parts[2]
+		} else {
+			names[i] = event.Type
+		}
+	}
+	return names
+}
+
+// rerunCommand returns the command sending the exported transaction to the emulator.
+func (r *ReplayResult) rerunCommand() string {
+	return fmt.Sprintf("flow transactions send %s --args-json-file %s --network emulator", r.codeFilename, r.argsFilename)
+}
+
+func (r *ReplayResult) JSON() interface{} {
+	result := map[string]interface{}{
+		"id":                   r.tx.ID().String(),
+		"status":               r.result.Status.String(),
+		"referenceBlockId":     r.referenceBlock.ID.String(),
+		"referenceBlockHeight": r.referenceBlock.Height,
+		"code":                 r.codeFilename,
+		"arguments":            r.argsFilename,
+		"rerun":                r.rerunCommand(),
+	}
+	if r.result.Error != nil {
+		result["error"] = r.result.Error.Error()
+	}
+
+	if r.replayed != nil {
+		replayed := map[string]interface{}{
+			"id":          r.replayedTx.ID().String(),
+			"status":      r.replayed.Status.String(),
+			"differences": r.differences(),
+		}
+		if r.replayed.Error != nil {
+			replayed["error"] = r.replayed.Error.Error()
+		}
+		result["replayed"] = replayed
+	}
+
+	return result
+}
+
+func (r *ReplayResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.tx.ID())
+	_, _ = fmt.Fprintf(writer, "Status\t%s\n", r.result.Status)
+	_, _ = fmt.Fprintf(writer, "Reference Block\t%s (height %d)\n", r.referenceBlock.ID, r.referenceBlock.Height)
+	if r.result.Error != nil {
+		_, _ = fmt.Fprintf(writer, "Error\t%s\n", strings.TrimSpace(formatTransactionError(r.result.Error)))
+	}
+	_, _ = fmt.Fprintf(writer, "Code\t%s\n", r.codeFilename)
+	_, _ = fmt.Fprintf(writer, "Arguments\t%s\n", r.argsFilename)
+
+	if r.replayed == nil {
+		_, _ = fmt.Fprintf(writer, "\n%s Replay it on the emulator with:\n    %s\n", output.TryEmoji(), r.rerunCommand())
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nReplayed ID\t%s\n", r.replayedTx.ID())
+	_, _ = fmt.Fprintf(writer, "Replayed Status\t%s\n", r.replayed.Status)
+	if r.replayed.Error != nil {
+		_, _ = fmt.Fprintf(writer, "Replayed Error\t%s\n", strings.TrimSpace(formatTransactionError(r.replayed.Error)))
+	}
+
+	differences := r.differences()
+	if len(differences) == 0 {
+		_, _ = fmt.Fprintf(writer, "\n%s The replayed result matches the live result\n", output.OkEmoji())
+	} else {
+		_, _ = fmt.Fprintf(writer, "\n%s The replayed result differs from the live result:\n", output.WarningEmoji())
+		for _, difference := range differences {
+			_, _ = fmt.Fprintf(writer, "    - %s\n", difference)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *ReplayResult) Oneliner() string {
+	result := fmt.Sprintf("ID: %s, Status: %s, Code: %s, Arguments: %s", r.tx.ID(), r.result.Status, r.codeFilename, r.argsFilename)
+	if r.replayed != nil {
+		result += fmt.Sprintf(", Replayed Status: %s, Differences: %d", r.replayed.Status, len(r.differences()))
+	}
+	return result
+}
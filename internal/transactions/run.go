@@ -0,0 +1,106 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+type flagsRun struct {
+	Param    []string `default:"" flag:"param" info:"value of a transaction parameter in the name=value format, the flag can be repeated"`
+	Signer   string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and authorizer"`
+	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude  []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	GasLimit uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+}
+
+var runFlags = flagsRun{}
+
+var RunCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "run <transaction name>",
+		Short:   "Run a transaction template defined in the configuration",
+		Args:    cobra.ExactArgs(1),
+		Example: "flow transactions run transfer-flow --param to=0x01 --param amount=10.0",
+	},
+	Flags: &runFlags,
+	RunS:  run,
+}
+
+func run(
+	args []string,
+	readerWriter flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	srv *services.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	template, err := state.Transactions().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := command.ParseParams(runFlags.Param)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := readerWriter.ReadFile(template.Location)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	script, err := flowkit.NewTemplateScript(*template, code, params)
+	if err != nil {
+		return nil, err
+	}
+
+	signerName := runFlags.Signer
+	if signerName == "" {
+		signerName = state.Config().Emulators.Default().ServiceAccount
+	}
+
+	signer, err := state.Accounts().ByName(signerName)
+	if err != nil {
+		return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
+	}
+
+	tx, result, err := srv.Transactions.SendWithOptions(
+		services.NewSingleTransactionAccount(signer),
+		script,
+		runFlags.GasLimit,
+		globalFlags.Network,
+		services.SendOptions{WaitOptions: services.DefaultWaitOptions, Retries: globalFlags.Retries},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionResult{
+		result:  result,
+		tx:      tx,
+		include: runFlags.Include,
+		exclude: runFlags.Exclude,
+	}, nil
+}
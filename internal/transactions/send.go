@@ -20,31 +20,45 @@ package transactions
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
-	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/services"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
 type flagsSend struct {
-	ArgsJSON  string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	Signer    string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and suthorizer"`
-	Proposer  string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer"`
-	Payer     string   `default:"" flag:"payer" info:"Account name from configuration used as payer"`
-	Autorizer []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
-	Include   []string `default:"" flag:"include" info:"Fields to include in the output"`
-	Exclude   []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
-	GasLimit  uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	ArgsJSON     string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	ArgsJSONFile string   `default:"" flag:"args-json-file" info:"file containing the arguments as a JSON array in JSON-Cadence format"`
+	Arg          []string `default:"" flag:"arg" info:"argument in the name:Type:value format, the flag can be repeated"`
+	Signer       string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and suthorizer"`
+	KeyIndex     int      `default:"-1" flag:"key-index" info:"Index of the signer account key used to sign the transaction, the first configured key is used by default"`
+	Proposer     string   `default:"" flag:"proposer" info:"Account name from configuration or address used as proposer"`
+	Payer        string   `default:"" flag:"payer" info:"Account name from configuration or address used as payer"`
+	Autorizer    []string `default:"" flag:"authorizer" info:"Name or address of a single or multiple comma-separated accounts used as authorizers, the flag can be repeated"`
+	Include      []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude      []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	GasLimit     uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	Status       string   `default:"sealed" flag:"status" info:"Status of the transaction to wait for: finalized, executed or sealed"`
+	WaitTimeout  string   `default:"" flag:"wait-timeout" info:"Maximum time to wait for the transaction status, like 60s, there is no limit by default"`
+	NoWait       bool     `default:"false" flag:"no-wait" info:"Return right after the transaction is sent, without waiting for the result"`
+	Format       string   `default:"" flag:"format" info:"Format of the result, options: \"json-simple\""`
+	TypeHints    bool     `default:"false" flag:"type-hints" info:"add the type of composite values in the _type field of the json-simple format"`
+	Depth        int      `default:"0" flag:"depth" info:"maximum nesting of values decoded in the json-simple format, unlimited if zero"`
 }
 
 var sendFlags = flagsSend{}
 
 var SendCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "send <code filename> [<argument> <argument> ...]",
+		Use:     "send <code filename | -> [<argument> <argument> ...]",
 		Short:   "Send a transaction",
 		Args:    cobra.MinimumNArgs(1),
 		Example: `flow transactions send tx.cdc "Hello world"`,
@@ -62,78 +76,147 @@ func send(
 ) (result command.Result, err error) {
 	codeFilename := args[0]
 
-	proposerName := sendFlags.Proposer
+	if err := validateFormat(sendFlags.Format); err != nil {
+		return nil, err
+	}
+
+	wait, err := parseWaitOptions(sendFlags.Status, sendFlags.WaitTimeout, sendFlags.NoWait)
+	if err != nil {
+		return nil, err
+	}
+
+	// accounts of the roles that aren't configured, given by the address, must sign offline
+	offline := make(map[flow.Address][]string)
+
 	var proposer *flowkit.Account
-	if proposerName != "" {
-		proposer, err = state.Accounts().ByName(proposerName)
+	var proposerAddress flow.Address
+	if sendFlags.Proposer != "" {
+		proposer, proposerAddress, err = roleAccount(state, "proposer", sendFlags.Proposer)
 		if err != nil {
-			return nil, fmt.Errorf("proposer account: [%s] doesn't exists in configuration", proposerName)
+			return nil, err
+		}
+		if proposer == nil {
+			offline[proposerAddress] = append(offline[proposerAddress], string(flowkit.SigningRoleProposer))
 		}
 	}
 
-	payerName := sendFlags.Payer
 	var payer *flowkit.Account
-	if payerName != "" {
-		payer, err = state.Accounts().ByName(payerName)
+	var payerAddress flow.Address
+	if sendFlags.Payer != "" {
+		payer, payerAddress, err = roleAccount(state, "payer", sendFlags.Payer)
 		if err != nil {
-			return nil, fmt.Errorf("payer account: [%s] doesn't exists in configuration", payerName)
+			return nil, err
+		}
+		if payer == nil {
+			offline[payerAddress] = append(offline[payerAddress], string(flowkit.SigningRolePayer))
 		}
 	}
 
 	var authorizers []*flowkit.Account
+	var authorizerAddresses []flow.Address
 	for _, authorizerName := range sendFlags.Autorizer {
-		authorizer, err := state.Accounts().ByName(authorizerName)
+		authorizer, address, err := roleAccount(state, "authorizer", authorizerName)
 		if err != nil {
-			return nil, fmt.Errorf("authorizer account: [%s] doesn't exists in configuration", authorizerName)
+			return nil, err
+		}
+		if authorizer == nil {
+			offline[address] = append(offline[address], string(flowkit.SigningRoleAuthorizer))
 		}
 		authorizers = append(authorizers, authorizer)
+		authorizerAddresses = append(authorizerAddresses, address)
 	}
 
 	signerName := sendFlags.Signer
 
-	if signerName == "" && proposer == nil && payer == nil && len(authorizers) == 0 {
+	if signerName == "" && sendFlags.Proposer == "" && sendFlags.Payer == "" && len(authorizers) == 0 {
 		signerName = state.Config().Emulators.Default().ServiceAccount
 	}
 
 	if signerName != "" {
-		if proposer != nil || payer != nil || len(authorizers) > 0 {
+		if sendFlags.Proposer != "" || sendFlags.Payer != "" || len(authorizers) > 0 {
 			return nil, fmt.Errorf("signer flag cannot be combined with payer/proposer/authorizer flags")
 		}
 		signer, err := state.Accounts().ByName(signerName)
 		if err != nil {
 			return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
 		}
+		if sendFlags.KeyIndex >= 0 {
+			signer, err = signer.ForKeyIndex(sendFlags.KeyIndex)
+			if err != nil {
+				return nil, err
+			}
+		}
 		proposer = signer
 		payer = signer
 		authorizers = append(authorizers, signer)
 	}
 
-	code, err := readerWriter.ReadFile(codeFilename)
+	code, location, err := command.ReadCode(readerWriter, codeFilename, "")
 	if err != nil {
 		return nil, fmt.Errorf("error loading transaction file: %w", err)
 	}
 
-	var transactionArgs []cadence.Value
-	if sendFlags.ArgsJSON != "" {
-		transactionArgs, err = flowkit.ParseArgumentsJSON(sendFlags.ArgsJSON)
-	} else {
-		transactionArgs, err = flowkit.ParseArgumentsWithoutType(codeFilename, code, args[1:])
-	}
+	transactionArgs, err := command.ParseArguments(readerWriter, location, code, args[1:], command.ArgumentsFlags{
+		JSON:     sendFlags.ArgsJSON,
+		JSONFile: sendFlags.ArgsJSONFile,
+		Named:    sendFlags.Arg,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
 	}
 
+	// warn if the gas limit doesn't leave a margin of 20% over the computation estimated before
+	if computation, ok := priorEstimate(code, transactionArgs); ok {
+		estimate := services.Estimate{ComputationUsed: computation}
+		if sendFlags.GasLimit < estimate.SuggestedGasLimit() {
+			fmt.Printf(
+				"%s The gas limit %d is less than 1.2x the estimated computation %d, consider using --gas-limit %d\n",
+				output.WarningEmoji(),
+				sendFlags.GasLimit,
+				computation,
+				estimate.SuggestedGasLimit(),
+			)
+		}
+	}
+
+	script := flowkit.NewScript(code, transactionArgs, location)
+
+	if len(offline) > 0 {
+		if proposerAddress == flow.EmptyAddress || payerAddress == flow.EmptyAddress {
+			return nil, fmt.Errorf("error parsing transaction roles: must provide both proposer and payer")
+		}
+
+		proposerKeyIndex := 0
+		if proposer != nil {
+			proposerKeyIndex = proposer.Key().Index()
+		}
+
+		tx, err := srv.Transactions.Build(
+			services.NewTransactionAddresses(proposerAddress, payerAddress, authorizerAddresses),
+			proposerKeyIndex,
+			script,
+			sendFlags.GasLimit,
+			globalFlags.Network,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return signOffline(tx, append([]*flowkit.Account{proposer}, authorizers...), offline)
+	}
+
 	roles, err := services.NewTransactionAccountRoles(proposer, payer, authorizers)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing transaction roles: %w", err)
 	}
 
-	tx, txResult, err := srv.Transactions.Send(
+	tx, txResult, err := srv.Transactions.SendWithOptions(
 		roles,
-		flowkit.NewScript(code, transactionArgs, codeFilename),
+		script,
 		sendFlags.GasLimit,
-		globalFlags.Network)
-
+		globalFlags.Network,
+		services.SendOptions{WaitOptions: wait, Retries: globalFlags.Retries},
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -143,5 +226,110 @@ func send(
 		tx:      tx,
 		include: sendFlags.Include,
 		exclude: sendFlags.Exclude,
+		format:  sendFlags.Format,
+		valueOptions: output.ValueOptions{
+			TypeHints: sendFlags.TypeHints,
+			Depth:     sendFlags.Depth,
+		},
+	}, nil
+}
+
+// parseWaitOptions parses the flags controlling how long the sent transaction is waited for.
+func parseWaitOptions(status string, timeout string, noWait bool) (services.WaitOptions, error) {
+	wait := services.WaitOptions{NoWait: noWait}
+
+	switch strings.ToLower(status) {
+	case "finalized":
+		wait.Status = flow.TransactionStatusFinalized
+	case "executed":
+		wait.Status = flow.TransactionStatusExecuted
+	case "sealed":
+		wait.Status = flow.TransactionStatusSealed
+	default:
+		return wait, fmt.Errorf("invalid status %s, valid statuses are finalized, executed and sealed", status)
+	}
+
+	if timeout != "" {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil {
+			return wait, fmt.Errorf("invalid wait timeout %s: %w", timeout, err)
+		}
+		if duration <= 0 {
+			return wait, fmt.Errorf("the wait timeout must be positive, got %s", timeout)
+		}
+		wait.Timeout = duration
+	}
+
+	if noWait && timeout != "" {
+		return wait, fmt.Errorf("the wait timeout can't be combined with the no-wait flag")
+	}
+
+	return wait, nil
+}
+
+// roleAccount returns the configured account with the name or the address, or only the address
+// if no account with the address is configured.
+func roleAccount(state *flowkit.State, role string, nameOrAddress string) (*flowkit.Account, flow.Address, error) {
+	account, err := state.Accounts().ByName(nameOrAddress)
+	if err == nil {
+		return account, account.Address(), nil
+	}
+
+	address, valid := util.ParseAddress(nameOrAddress)
+	if !valid {
+		return nil, flow.EmptyAddress, fmt.Errorf("%s account: [%s] doesn't exists in configuration", role, nameOrAddress)
+	}
+
+	account, err = state.Accounts().ByAddress(address)
+	if err != nil {
+		return nil, address, nil
+	}
+
+	return account, address, nil
+}
+
+// signOffline signs the built transaction with the configured accounts when some of the accounts
+// aren't configured, the transaction must then be signed by the other accounts and sent with send-signed.
+func signOffline(
+	tx *flowkit.Transaction,
+	signers []*flowkit.Account,
+	offline map[flow.Address][]string,
+) (command.Result, error) {
+	var err error
+
+	// the payer can't sign before the other accounts, so only the payload is signed
+	signed := make(map[flow.Address]bool)
+	for _, signer := range signers {
+		if signer == nil || signer.Address() == tx.FlowTransaction().Payer || signed[signer.Address()] {
+			continue
+		}
+		signed[signer.Address()] = true
+
+		if err := tx.SetSigner(signer); err != nil {
+			return nil, err
+		}
+		if tx, err = tx.Sign(); err != nil {
+			return nil, err
+		}
+	}
+
+	missing := make([]string, 0, len(offline))
+	for address, roles := range offline {
+		missing = append(missing, fmt.Sprintf("0x%s (%s)", address, strings.Join(roles, ", ")))
+	}
+	sort.Strings(missing)
+
+	fmt.Printf(
+		"%s The transaction was not sent, the accounts %s are not configured so they must sign it offline.\n"+
+			"Save the transaction with --filter payload --save tx.rlp, sign it with each of the other accounts using "+
+			"'flow transactions sign tx.rlp --signer <name> --role <role>', with the payer last, "+
+			"and send it with 'flow transactions send-signed tx.rlp'.\n\n",
+		output.TryEmoji(),
+		strings.Join(missing, ", "),
+	)
+
+	return &TransactionResult{
+		tx:      tx.FlowTransaction(),
+		include: []string{"payload"},
 	}, nil
 }
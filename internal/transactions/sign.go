@@ -33,6 +33,8 @@ import (
 
 type flagsSign struct {
 	Signer        []string `default:"emulator-account" flag:"signer" info:"name of a single or multiple comma-separated accounts used to sign"`
+	KeyIndex      int      `default:"-1" flag:"key-index" info:"index of the signer account key used to sign, the first configured key is used by default"`
+	Role          string   `default:"" flag:"role" info:"role of the signer in the transaction: proposer, authorizer or payer, the payer signs the envelope and the other roles the payload"`
 	Include       []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
 	FromRemoteUrl string   `default:"" flag:"from-remote-url" info:"server URL where RLP can be fetched, signed RLP will be posted back to remote URL."`
 }
@@ -43,7 +45,7 @@ var SignCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "sign [<built transaction filename> | --from-remote-url <url>]",
 		Short:   "Sign built transaction",
-		Example: "flow transactions sign ./built.rlp --signer alice",
+		Example: "flow transactions sign ./built.rlp --signer alice --role authorizer",
 		Args:    cobra.MaximumNArgs(1),
 	},
 	Flags: &signFlags,
@@ -65,6 +67,11 @@ func sign(
 		return nil, fmt.Errorf("only use one, filename argument or --from-remote-url <url>")
 	}
 
+	role := flowkit.SigningRole(signFlags.Role)
+	if role != flowkit.SigningRoleAuto && len(signFlags.Signer) > 1 {
+		return nil, fmt.Errorf("the role can only be used with a single signer")
+	}
+
 	if signFlags.FromRemoteUrl != "" {
 		if globalFlags.Yes {
 			return nil, fmt.Errorf("--yes is not supported with this flag")
@@ -96,6 +103,12 @@ func sign(
 		if err != nil {
 			return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
 		}
+		if signFlags.KeyIndex >= 0 {
+			signer, err = signer.ForKeyIndex(signFlags.KeyIndex)
+			if err != nil {
+				return nil, err
+			}
+		}
 		signers = append(signers, signer)
 	}
 
@@ -109,7 +122,7 @@ func sign(
 			return nil, fmt.Errorf("transaction was not approved for signing")
 		}
 
-		signed, err = services.Transactions.Sign(signer, payload)
+		signed, err = services.Transactions.SignWithRole(signer, payload, role)
 		if err != nil {
 			return nil, err
 		}
@@ -125,6 +138,12 @@ func sign(
 			return nil, err
 		}
 		fmt.Printf("%s Signed RLP Posted successfully\n", output.SuccessEmoji())
+	} else {
+		// the file is rewritten with the signatures, so it can be passed on to the next signer
+		err = readerWriter.WriteFile(filenameOrUrl, payload, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write the signed transaction to %s: %w", filenameOrUrl, err)
+		}
 	}
 
 	return &TransactionResult{
@@ -0,0 +1,62 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/services"
+)
+
+// SummaryJSON returns the ID, the proposer, the payer, the script size, the arguments count,
+// the status, the error and the events count of the transaction.
+func SummaryJSON(tx *services.TransactionWithResult) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":         tx.Transaction.ID().String(),
+		"proposer":   tx.Transaction.ProposalKey.Address.Hex(),
+		"payer":      tx.Transaction.Payer.Hex(),
+		"scriptSize": len(tx.Transaction.Script),
+		"arguments":  len(tx.Transaction.Arguments),
+		"status":     tx.Result.Status.String(),
+		"events":     len(tx.Result.Events),
+	}
+	if tx.Result.Error != nil {
+		result["error"] = tx.Result.Error.Error()
+	}
+
+	return result
+}
+
+// WriteSummary writes the ID, the proposer, the payer, the script size, the arguments count, the status,
+// the first line of the error and the events count of the transaction to the tab writer, with the lines indented.
+func WriteSummary(writer io.Writer, index int, tx *services.TransactionWithResult, indent string) {
+	_, _ = fmt.Fprintf(writer, "%sTransaction %d:\t%s\n", indent, index, tx.Transaction.ID())
+	_, _ = fmt.Fprintf(writer, "%s    Proposer\t%s\n", indent, tx.Transaction.ProposalKey.Address.Hex())
+	_, _ = fmt.Fprintf(writer, "%s    Payer\t%s\n", indent, tx.Transaction.Payer.Hex())
+	_, _ = fmt.Fprintf(writer, "%s    Script Size\t%d bytes\n", indent, len(tx.Transaction.Script))
+	_, _ = fmt.Fprintf(writer, "%s    Arguments\t%d\n", indent, len(tx.Transaction.Arguments))
+	_, _ = fmt.Fprintf(writer, "%s    Status\t%s\n", indent, tx.Result.Status)
+	if tx.Result.Error != nil {
+		message, _, _ := strings.Cut(strings.TrimSpace(tx.Result.Error.Error()), "\n")
+		_, _ = fmt.Fprintf(writer, "%s    Error\t%s\n", indent, message)
+	}
+	_, _ = fmt.Fprintf(writer, "%s    Events\t%d\n", indent, len(tx.Result.Events))
+}
@@ -22,12 +22,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
@@ -46,13 +50,19 @@ func init() {
 	BuildCommand.AddToParent(Cmd)
 	SendSignedCommand.AddToParent(Cmd)
 	DecodeCommand.AddToParent(Cmd)
+	EstimateCommand.AddToParent(Cmd)
+	RunCommand.AddToParent(Cmd)
+	BatchCommand.AddToParent(Cmd)
+	ReplayCommand.AddToParent(Cmd)
 }
 
 type TransactionResult struct {
-	result  *flow.TransactionResult
-	tx      *flow.Transaction
-	include []string
-	exclude []string
+	result       *flow.TransactionResult
+	tx           *flow.Transaction
+	include      []string
+	exclude      []string
+	format       string              // the json-simple format outputs the JSON result with the event values in simplified JSON
+	valueOptions output.ValueOptions // options of the event values in the json-simple format
 }
 
 func (r *TransactionResult) JSON() interface{} {
@@ -61,22 +71,35 @@ func (r *TransactionResult) JSON() interface{} {
 	result["payload"] = fmt.Sprintf("%x", r.tx.Encode())
 	result["authorizers"] = fmt.Sprintf("%s", r.tx.Authorizers)
 	result["payer"] = r.tx.Payer.String()
+	result["arguments"] = decodeArguments(r.tx.Arguments)
 
 	if r.result != nil {
 		result["status"] = r.result.Status.String()
 
+		if r.result.BlockID != flow.EmptyID {
+			result["blockId"] = r.result.BlockID.String()
+			result["blockHeight"] = r.result.BlockHeight
+		}
+
+		// the event values are decoded, so they can be read without a JSON-Cadence decoder
 		txEvents := make([]interface{}, 0, len(r.result.Events))
 		for _, event := range r.result.Events {
 			txEvents = append(txEvents, map[string]interface{}{
-				"index": event.EventIndex,
-				"type":  event.Type,
-				"values": json.RawMessage(
-					event.Payload,
-				),
+				"index":  event.EventIndex,
+				"type":   event.Type,
+				"values": r.eventValues(event.Value),
 			})
 		}
 		result["events"] = txEvents
 
+		if computation, ok := r.computationUsed(); ok {
+			result["computationUsed"] = computation
+			result["gasLimit"] = r.tx.GasLimit
+			if r.tx.GasLimit > 0 {
+				result["limitPercentage"] = float64(computation) * 100 / float64(r.tx.GasLimit)
+			}
+		}
+
 		if r.result.Error != nil {
 			result["error"] = r.result.Error.Error()
 		}
@@ -85,13 +108,37 @@ func (r *TransactionResult) JSON() interface{} {
 	return result
 }
 
+// eventValues decodes the event values, with the value options in the json-simple format.
+func (r *TransactionResult) eventValues(value cadence.Event) interface{} {
+	if r.format == events.FormatJSONSimple {
+		return output.SimpleJSON(value, r.valueOptions)
+	}
+
+	return flowkit.DecodeValue(value)
+}
+
+// computationUsed returns the computation used by the transaction, if it's reported by the events of the result.
+func (r *TransactionResult) computationUsed() (uint64, bool) {
+	if r.result == nil {
+		return 0, false
+	}
+
+	events := flowkit.NewEvents(r.result.Events)
+	return events.GetComputationUsed()
+}
+
 func (r *TransactionResult) String() string {
+	if r.format == events.FormatJSONSimple {
+		out, _ := json.MarshalIndent(r.JSON(), "", "  ")
+		return string(out)
+	}
+
 	var b bytes.Buffer
 	writer := util.CreateTabWriter(&b)
 
 	if r.result != nil {
 		if r.result.Error != nil {
-			_, _ = fmt.Fprintf(writer, "%s Transaction Error \n%s\n\n\n", output.ErrorEmoji(), r.result.Error.Error())
+			_, _ = fmt.Fprintf(writer, "%s Transaction Error \n%s\n\n\n", output.ErrorEmoji(), formatTransactionError(r.result.Error))
 		}
 
 		statusBadge := ""
@@ -99,6 +146,15 @@ func (r *TransactionResult) String() string {
 			statusBadge = output.OkEmoji()
 		}
 		_, _ = fmt.Fprintf(writer, "Status\t%s %s\n", statusBadge, r.result.Status)
+
+		if r.result.BlockID != flow.EmptyID {
+			_, _ = fmt.Fprintf(writer, "Block ID\t%s\n", r.result.BlockID)
+			_, _ = fmt.Fprintf(writer, "Block Height\t%d\n", r.result.BlockHeight)
+		}
+
+		if computation, ok := r.computationUsed(); ok {
+			_, _ = fmt.Fprintf(writer, "Computation Used\t%s\n", formatComputation(computation, r.tx.GasLimit))
+		}
 	}
 
 	_, _ = fmt.Fprintf(writer, "ID\t%s\n", r.tx.ID())
@@ -144,7 +200,8 @@ func (r *TransactionResult) String() string {
 		_, _ = fmt.Fprintf(writer, "\nSignatures (minimized, use --include signatures)")
 	}
 
-	if r.result != nil && !command.ContainsFlag(r.exclude, "events") {
+	showEvents := command.ContainsFlag(r.include, "events") || !command.ContainsFlag(r.exclude, "events")
+	if r.result != nil && showEvents {
 		e := events.EventResult{
 			Events: r.result.Events,
 		}
@@ -163,7 +220,7 @@ func (r *TransactionResult) String() string {
 				_, _ = fmt.Fprintf(writer, "\n\nArguments\tNo arguments\n")
 			} else {
 				_, _ = fmt.Fprintf(writer, "\n\nArguments (%d):\n", len(r.tx.Arguments))
-				for i, argument := range r.tx.Arguments {
+				for i, argument := range decodeArguments(r.tx.Arguments) {
 					_, _ = fmt.Fprintf(writer, "    - Argument %d: %s\n", i, argument)
 				}
 			}
@@ -185,6 +242,10 @@ func (r *TransactionResult) String() string {
 }
 
 func (r *TransactionResult) Oneliner() string {
+	if r.format == events.FormatJSONSimple {
+		return r.String()
+	}
+
 	result := fmt.Sprintf(
 		"ID: %s, Payer: %s, Authorizer: %s",
 		r.tx.ID(), r.tx.Payer, r.tx.Authorizers)
@@ -195,3 +256,45 @@ func (r *TransactionResult) Oneliner() string {
 
 	return result
 }
+
+func (r *TransactionResult) Raw() bool {
+	return r.format == events.FormatJSONSimple
+}
+
+// validateFormat checks the format of the transaction result is empty or json-simple.
+func validateFormat(format string) error {
+	if format != "" && format != events.FormatJSONSimple {
+		return fmt.Errorf("invalid format %s, options: json-simple", format)
+	}
+
+	return nil
+}
+
+// decodeArguments returns the transaction arguments decoded from JSON-Cadence, or as they are if they can't be decoded.
+func decodeArguments(arguments [][]byte) []string {
+	decoded := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		value, err := jsoncdc.Decode(nil, argument)
+		if err != nil {
+			decoded = append(decoded, string(argument))
+			continue
+		}
+		decoded = append(decoded, value.String())
+	}
+
+	return decoded
+}
+
+// formatTransactionError formats the transaction error with each line of the Cadence stack trace indented.
+//
+// Some access nodes return the error with escaped new lines, which are unescaped.
+func formatTransactionError(err error) string {
+	message := strings.ReplaceAll(strings.TrimSpace(err.Error()), `\n`, "\n")
+
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		lines[i] = "    " + strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,124 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func Test_TransactionResult(t *testing.T) {
+	event := tests.NewEvent(
+		0,
+		"TokensDeposited",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "to", Type: &cadence.OptionalType{Type: cadence.AddressType{}}},
+		},
+		[]cadence.Value{
+			cadence.UFix64(150000000),
+			cadence.NewOptional(cadence.NewAddress(flow.HexToAddress("01"))),
+		},
+	)
+
+	newResult := func(include []string, exclude []string) *TransactionResult {
+		tx := tests.NewTransaction()
+		tx.Arguments = [][]byte{[]byte(`{"type":"String","value":"Hello"}`)}
+
+		return &TransactionResult{
+			result:  tests.NewTransactionResult([]flow.Event{*event}),
+			tx:      tx,
+			include: include,
+			exclude: exclude,
+		}
+	}
+
+	t.Run("JSON decoded events", func(t *testing.T) {
+		result := newResult(nil, nil).JSON().(map[string]interface{})
+
+		assert.Equal(t, []string{`"Hello"`}, result["arguments"])
+
+		events, ok := result["events"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, events, 1)
+		assert.Equal(t, map[string]interface{}{
+			"index": 0,
+			"type":  event.Type,
+			"values": map[string]interface{}{
+				"amount": "1.50000000",
+				"to":     "0x0000000000000001",
+			},
+		}, events[0])
+	})
+
+	t.Run("JSON simple events", func(t *testing.T) {
+		result := newResult(nil, nil)
+		result.format = events.FormatJSONSimple
+		result.valueOptions = output.ValueOptions{TypeHints: true}
+
+		assert.True(t, result.Raw())
+		txEvents := result.JSON().(map[string]interface{})["events"].([]interface{})
+		require.Len(t, txEvents, 1)
+		assert.Equal(t, map[string]interface{}{
+			"_type":  event.Type,
+			"amount": "1.50000000",
+			"to":     "0x0000000000000001",
+		}, txEvents[0].(map[string]interface{})["values"])
+		assert.Contains(t, result.String(), `"_type": "`+event.Type+`"`)
+	})
+
+	t.Run("Default fields", func(t *testing.T) {
+		out := newResult(nil, nil).String()
+
+		assert.Contains(t, out, "Events:")
+		assert.Contains(t, out, "amount")
+		assert.Contains(t, out, "Code (hidden, use --include code)")
+		assert.Contains(t, out, "Signatures (minimized, use --include signatures)")
+		assert.Contains(t, out, "Payload (hidden, use --include payload)")
+		assert.NotContains(t, out, "Arguments")
+	})
+
+	t.Run("Include code and signatures", func(t *testing.T) {
+		tx := newResult([]string{"code", "signatures"}, nil)
+		out := tx.String()
+
+		assert.Contains(t, out, "Arguments (1):")
+		assert.Contains(t, out, `Argument 0: "Hello"`)
+		assert.Contains(t, out, "Code\n\n"+string(tx.tx.Script))
+		assert.NotContains(t, out, "Code (hidden")
+		assert.NotContains(t, out, "Signatures (minimized")
+	})
+
+	t.Run("Exclude events", func(t *testing.T) {
+		out := newResult(nil, []string{"events"}).String()
+		assert.NotContains(t, out, "Events:")
+
+		// including the events takes precedence over excluding them
+		out = newResult([]string{"events"}, []string{"events"}).String()
+		assert.Contains(t, out, "Events:")
+	})
+}
@@ -20,6 +20,7 @@ package flowkit
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/onflow/flow-go-sdk"
@@ -30,8 +31,20 @@ import (
 )
 
 // Account is a flowkit-specific account implementation.
+//
+// The account can be defined differently on specific networks, the address and key
+// of the account are returned for the network the account is used on.
 type Account struct {
-	name    string
+	name           string
+	address        flow.Address
+	key            AccountKey
+	additionalKeys []AccountKey
+	networks       map[string]*networkAccount
+	network        string
+}
+
+// networkAccount is the definition of the account on a specific network.
+type networkAccount struct {
 	address flow.Address
 	key     AccountKey
 }
@@ -95,8 +108,12 @@ func NewAccountFromOnChainAccount(
 	return account, nil
 }
 
-// Address get account address.
+// Address get account address on the network the account is used on.
 func (a *Account) Address() flow.Address {
+	if n, ok := a.networks[a.network]; ok {
+		return n.address
+	}
+
 	return a.address
 }
 
@@ -105,13 +122,77 @@ func (a *Account) Name() string {
 	return a.name
 }
 
-// Key get account key.
+// Key get account key on the network the account is used on.
 func (a *Account) Key() AccountKey {
+	if n, ok := a.networks[a.network]; ok {
+		return n.key
+	}
+
 	return a.key
 }
 
-// SetAddress sets the account address.
+// Keys get all the account keys on the network the account is used on, starting with the key used for signing.
+func (a *Account) Keys() []AccountKey {
+	if n, ok := a.networks[a.network]; ok {
+		return []AccountKey{n.key}
+	}
+
+	return append([]AccountKey{a.key}, a.additionalKeys...)
+}
+
+// AddKey adds a key to the keys of the account, the key is only used for signing if the
+// other keys don't reach the signature weight threshold.
+func (a *Account) AddKey(key AccountKey) *Account {
+	a.additionalKeys = append(a.additionalKeys, key)
+	return a
+}
+
+// RevokeKey marks the key with the index as revoked, so it's no longer used for signing.
+func (a *Account) RevokeKey(index int) error {
+	for _, key := range a.Keys() {
+		if key.Index() != index {
+			continue
+		}
+
+		revocable, ok := key.(interface{ setRevoked() })
+		if !ok {
+			return fmt.Errorf("key %d of account %s can't be revoked", index, a.name)
+		}
+		revocable.setRevoked()
+		return nil
+	}
+
+	return fmt.Errorf("account %s doesn't have a key with index %d", a.name, index)
+}
+
+// ForKeyIndex returns the account using the key with the index for signing.
+//
+// The other keys of the account are still used if the key doesn't have the full weight.
+func (a Account) ForKeyIndex(index int) (*Account, error) {
+	keys := a.Keys()
+	for i, key := range keys {
+		if key.Index() != index {
+			continue
+		}
+		if i == 0 {
+			return &a, nil
+		}
+
+		a.key = key
+		a.additionalKeys = append(append([]AccountKey{}, keys[:i]...), keys[i+1:]...)
+		return &a, nil
+	}
+
+	return nil, fmt.Errorf("account %s doesn't have a key with index %d", a.name, index)
+}
+
+// SetAddress sets the account address on the network the account is used on.
 func (a *Account) SetAddress(address flow.Address) *Account {
+	if n, ok := a.networks[a.network]; ok {
+		n.address = address
+		return a
+	}
+
 	a.address = address
 	return a
 }
@@ -122,16 +203,49 @@ func (a *Account) SetName(name string) *Account {
 	return a
 }
 
-// SetKey sets account key.
+// SetKey sets account key on the network the account is used on.
 func (a *Account) SetKey(key AccountKey) *Account {
+	if n, ok := a.networks[a.network]; ok {
+		n.key = key
+		return a
+	}
+
 	a.key = key
 	return a
 }
 
-func accountsFromConfig(conf *config.Config) (Accounts, error) {
+// ForNetwork returns the account used on the network.
+//
+// An error is returned if the account is not defined for the network.
+func (a Account) ForNetwork(network string) (*Account, error) {
+	a.network = network
+	if err := a.checkDefined(); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// checkDefined checks if the account is defined for the network it is used on.
+func (a *Account) checkDefined() error {
+	if len(a.networks) == 0 || a.address != flow.EmptyAddress {
+		return nil
+	}
+	if _, ok := a.networks[a.network]; ok {
+		return nil
+	}
+
+	if a.network == "" {
+		return fmt.Errorf("account %s is only defined for specific networks, please specify the network", a.name)
+	}
+
+	return fmt.Errorf("account %s is not defined for network %s", a.name, a.network)
+}
+
+func accountsFromConfig(conf *config.Config, readerWriter ReaderWriter) (Accounts, error) {
 	var accounts Accounts
 	for _, accountConf := range conf.Accounts {
-		acc, err := fromConfig(accountConf)
+		acc, err := fromConfig(accountConf, readerWriter)
 		if err != nil {
 			return nil, err
 		}
@@ -151,17 +265,45 @@ func accountsToConfig(accounts Accounts, accountLocations map[string]string) con
 	return accountConfs
 }
 
-func fromConfig(account config.Account) (*Account, error) {
-	key, err := NewAccountKey(account.Key)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Account{
+func fromConfig(account config.Account, readerWriter ReaderWriter) (*Account, error) {
+	acc := &Account{
 		name:    account.Name,
 		address: account.Address,
-		key:     key,
-	}, nil
+	}
+
+	// accounts only defined for specific networks don't have a key
+	if account.Address != flow.EmptyAddress || len(account.Networks) == 0 {
+		key, err := newAccountKey(account.Key, readerWriter)
+		if err != nil {
+			return nil, err
+		}
+		acc.key = key
+	}
+
+	for _, keyConf := range account.AdditionalKeys {
+		key, err := newAccountKey(keyConf, readerWriter)
+		if err != nil {
+			return nil, err
+		}
+		acc.additionalKeys = append(acc.additionalKeys, key)
+	}
+
+	if len(account.Networks) > 0 {
+		acc.networks = make(map[string]*networkAccount)
+	}
+	for _, n := range account.Networks {
+		key, err := newAccountKey(n.Key, readerWriter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key of account %s on network %s: %w", account.Name, n.Network, err)
+		}
+
+		acc.networks[n.Network] = &networkAccount{
+			address: n.Address,
+			key:     key,
+		}
+	}
+
+	return acc, nil
 }
 
 func toConfig(account Account, accountLocations map[string]string) config.Account {
@@ -178,14 +320,42 @@ func toConfig(account Account, accountLocations map[string]string) config.Accoun
 		key = account.key.ToConfig()
 	}
 
+	var additionalKeys []config.AccountKey
+	for _, k := range account.additionalKeys {
+		additionalKeys = append(additionalKeys, k.ToConfig())
+	}
+
+	names := make([]string, 0, len(account.networks))
+	for network := range account.networks {
+		names = append(names, network)
+	}
+	sort.Strings(names)
+
+	var networks []config.NetworkAccount
+	for _, network := range names {
+		n := account.networks[network]
+		networks = append(networks, config.NetworkAccount{
+			Network: network,
+			Address: n.address,
+			Key:     n.key.ToConfig(),
+		})
+	}
+
 	return config.Account{
-		Name:    account.name,
-		Address: account.address,
-		Key:     key,
+		Name:           account.name,
+		Address:        account.address,
+		Key:            key,
+		AdditionalKeys: additionalKeys,
+		Networks:       networks,
 	}
 }
 
 func generateEmulatorServiceAccount(sigAlgo crypto.SignatureAlgorithm, hashAlgo crypto.HashAlgorithm) (*Account, error) {
+	err := flow.AccountKey{SigAlgo: sigAlgo, HashAlgo: hashAlgo}.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid emulator service key: %w", err)
+	}
+
 	seed, err := util.RandomSeed(crypto.MinSeedLength)
 	if err != nil {
 		return nil, err
@@ -237,7 +407,7 @@ func (a Accounts) String() string {
 // ByAddress get an account by address.
 func (a Accounts) ByAddress(address flow.Address) (*Account, error) {
 	for i := range a {
-		if a[i].address == address {
+		if a[i].Address() == address {
 			return &a[i], nil
 		}
 	}
@@ -246,9 +416,14 @@ func (a Accounts) ByAddress(address flow.Address) (*Account, error) {
 }
 
 // ByName get an account by name or returns and error if no account found
+//
+// An error is also returned if the account is not defined for the network it is used on.
 func (a Accounts) ByName(name string) (*Account, error) {
 	for i := range a {
 		if a[i].name == name {
+			if err := a[i].checkDefined(); err != nil {
+				return nil, err
+			}
 			return &a[i], nil
 		}
 	}
@@ -260,7 +435,7 @@ func (a Accounts) ByName(name string) (*Account, error) {
 func (a *Accounts) AddOrUpdate(account *Account) {
 	for i, acc := range *a {
 		if acc.name == account.name {
-			(*a)[i] = acc
+			(*a)[i] = *account
 			return
 		}
 	}
@@ -31,6 +31,7 @@ import (
 	"github.com/onflow/cadence/runtime/cmd"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -50,22 +51,35 @@ func (v *CadenceArgument) UnmarshalJSON(b []byte) (err error) {
 	return nil
 }
 
+// ParseArgumentsJSON decodes the arguments from a JSON array of JSON-Cadence encoded values.
 func ParseArgumentsJSON(input string) ([]cadence.Value, error) {
-	var args []CadenceArgument
-	b := []byte(input)
-	err := json.Unmarshal(b, &args)
-
+	var args []json.RawMessage
+	err := json.Unmarshal([]byte(input), &args)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("arguments must be a JSON array of JSON-Cadence encoded values: %w", err)
 	}
 
 	cadenceArgs := make([]cadence.Value, len(args))
 	for i, arg := range args {
-		cadenceArgs[i] = arg.Value
+		cadenceArgs[i], err = jsoncdc.Decode(nil, arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode argument %d: %w", i, err)
+		}
 	}
 	return cadenceArgs, nil
 }
 
+// EncodeArgumentsJSON encodes the arguments as a JSON array of JSON-Cadence encoded values,
+// which can be decoded with ParseArgumentsJSON.
+func EncodeArgumentsJSON(args []cadence.Value) ([]byte, error) {
+	encoded := make([]CadenceArgument, len(args))
+	for i, arg := range args {
+		encoded[i] = CadenceArgument{Value: arg}
+	}
+
+	return json.MarshalIndent(encoded, "", "  ")
+}
+
 // sanitizeAddressArg sanitize address and make sure it has 0x prefix
 func processValue(argType string, argValue string) interface{} {
 	if argType == "Address" && !strings.Contains(argValue, "0x") {
@@ -100,6 +114,151 @@ func ParseArgumentsWithoutType(fileName string, code []byte, args []string) (scr
 
 	resultArgs := make([]cadence.Value, 0, len(args))
 
+	parameterList, checker := prepareParameters(fileName, code)
+	if parameterList == nil {
+		return resultArgs, nil
+	}
+
+	if len(parameterList) != len(args) {
+		return nil, fmt.Errorf("argument count is %d, expected %d", len(args), len(parameterList))
+	}
+
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	for index, argumentString := range args {
+		astType := parameterList[index].TypeAnnotation.Type
+		semaType := checker.ConvertType(astType)
+
+		value, err := parseLiteral(argumentString, semaType, inter)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"argument `%s` is not expected type `%s`",
+				parameterList[index].Identifier,
+				semaType.QualifiedString(),
+			)
+		}
+		resultArgs = append(resultArgs, value)
+	}
+	return resultArgs, nil
+}
+
+// ParseNamedArguments parses the arguments of the code given by the parameter names and types, in any order.
+//
+// Each argument has the format `name:Type:value`, like `amount:UFix64:10.0`, and the type must
+// be the type of the parameter.
+func ParseNamedArguments(fileName string, code []byte, args []string) ([]cadence.Value, error) {
+	parameterList, checker := prepareParameters(fileName, code)
+
+	names := make([]string, len(parameterList))
+	for i, parameter := range parameterList {
+		names[i] = parameter.Identifier.Identifier
+	}
+
+	literals := make(map[string]string)
+	for _, arg := range args {
+		name, typedLiteral, found := strings.Cut(arg, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid argument %s, the format is name:Type:value", arg)
+		}
+
+		index := indexOf(names, name)
+		if index < 0 {
+			return nil, fmt.Errorf("argument %s doesn't match any of the parameters %s", name, names)
+		}
+		if _, exists := literals[name]; exists {
+			return nil, fmt.Errorf("argument %s is provided more than once", name)
+		}
+
+		typeString, literal, err := splitTypedLiteral(typedLiteral)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %s: %w", name, err)
+		}
+
+		astType, errs := parser.ParseType(nil, []byte(typeString), parser.Config{})
+		parameterType := parameterList[index].TypeAnnotation.Type
+		if len(errs) > 0 || astType.String() != parameterType.String() {
+			return nil, fmt.Errorf("argument %s has the type %s, but the parameter type is %s", name, typeString, parameterType)
+		}
+
+		literals[name] = literal
+	}
+
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]cadence.Value, 0, len(parameterList))
+	for _, parameter := range parameterList {
+		name := parameter.Identifier.Identifier
+		literal, ok := literals[name]
+		if !ok {
+			return nil, fmt.Errorf("missing argument %s", name)
+		}
+
+		semaType := checker.ConvertType(parameter.TypeAnnotation.Type)
+		value, err := parseLiteral(literal, semaType, inter)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s is not a valid %s value: %w", name, semaType.QualifiedString(), err)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// ParseTypedArgument parses the value of an argument given with the type, like `UFix64:10.0` or `[String]:["a", "b"]`.
+func ParseTypedArgument(typedLiteral string) (cadence.Value, error) {
+	typeString, literal, err := splitTypedLiteral(typedLiteral)
+	if err != nil {
+		return nil, err
+	}
+
+	astType, errs := parser.ParseType(nil, []byte(typeString), parser.Config{})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid type %s", typeString)
+	}
+
+	location := common.StringLocation("arguments")
+	program, err := parser.ParseProgram(nil, nil, parser.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err := sema.NewChecker(
+		program,
+		location,
+		nil,
+		cmd.DefaultCheckerConfig(map[common.Location]*sema.Checker{}, map[common.Location][]byte{}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	semaType := checker.ConvertType(astType)
+	if semaType.IsInvalidType() {
+		return nil, fmt.Errorf("invalid type %s", typeString)
+	}
+
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := parseLiteral(literal, semaType, inter)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid %s value: %w", literal, semaType.QualifiedString(), err)
+	}
+
+	return value, nil
+}
+
+// prepareParameters returns the parameters of the script, transaction or contract initializer,
+// and the checker used to convert the parameter types.
+func prepareParameters(fileName string, code []byte) ([]*ast.Parameter, *sema.Checker) {
 	codes := map[common.Location][]byte{}
 	location := common.StringLocation(fileName)
 	program, must := cmd.PrepareProgram(code, location, codes)
@@ -131,53 +290,61 @@ func ParseArgumentsWithoutType(fileName string, code []byte, args []string) (scr
 		}
 	}
 
-	if parameterList == nil {
-		return resultArgs, nil
-	}
+	return parameterList, checker
+}
 
-	if len(parameterList) != len(args) {
-		return nil, fmt.Errorf("argument count is %d, expected %d", len(args), len(parameterList))
-	}
+// parseLiteral parses the literal of the type, strings don't need to be quoted and addresses don't need the 0x prefix.
+func parseLiteral(argumentString string, semaType sema.Type, inter *interpreter.Interpreter) (cadence.Value, error) {
+	literalType := semaType
+	for {
+		switch v := literalType.(type) {
+		case *sema.OptionalType:
+			literalType = v.Type
+			continue
 
-	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{})
-	if err != nil {
-		return nil, err
-	}
+		case *sema.SimpleType:
+			if v == sema.StringType {
+				if len(argumentString) > 0 && !strings.HasPrefix(argumentString, "\"") {
+					argumentString = "\"" + argumentString + "\""
+				}
+			}
 
-	for index, argumentString := range args {
-		astType := parameterList[index].TypeAnnotation.Type
-		semaType := checker.ConvertType(astType)
+		case *sema.AddressType:
+			if !strings.Contains(argumentString, "0x") {
+				argumentString = fmt.Sprintf("0x%s", argumentString)
+			}
+		}
+		break
+	}
 
-		for {
-			switch v := semaType.(type) {
-			case *sema.OptionalType:
-				semaType = v.Type
-				continue
-
-			case *sema.SimpleType:
-				if v == sema.StringType {
-					if len(argumentString) > 0 && !strings.HasPrefix(argumentString, "\"") {
-						argumentString = "\"" + argumentString + "\""
-					}
-				}
+	return runtime.ParseLiteral(argumentString, literalType, inter)
+}
 
-			case *sema.AddressType:
-				if !strings.Contains(argumentString, "0x") {
-					argumentString = fmt.Sprintf("0x%s", argumentString)
-				}
+// splitTypedLiteral splits the type and the literal separated by the first colon outside of
+// the brackets of the type, so dictionary types like `{String: Int}` can be used.
+func splitTypedLiteral(typedLiteral string) (string, string, error) {
+	depth := 0
+	for i, c := range typedLiteral {
+		switch c {
+		case '{', '[', '<', '(':
+			depth++
+		case '}', ']', '>', ')':
+			depth--
+		case ':':
+			if depth == 0 {
+				return strings.TrimSpace(typedLiteral[:i]), typedLiteral[i+1:], nil
 			}
-			break
 		}
+	}
 
-		var value, err = runtime.ParseLiteral(argumentString, semaType, inter)
-		if err != nil {
-			return nil, fmt.Errorf(
-				"argument `%s` is not expected type `%s`",
-				parameterList[index].Identifier,
-				semaType.QualifiedString(),
-			)
+	return "", "", fmt.Errorf("missing type of %s, the format is Type:value", typedLiteral)
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
 		}
-		resultArgs = append(resultArgs, value)
 	}
-	return resultArgs, nil
+	return -1
 }
@@ -24,6 +24,7 @@ import (
 
 	"github.com/onflow/cadence"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 )
@@ -58,3 +59,77 @@ func TestArguments(t *testing.T) {
 		assert.Equal(t, []cadence.Value{sample}, args)
 	}
 }
+
+func TestParseArgumentsJSON(t *testing.T) {
+	args, err := flowkit.ParseArgumentsJSON(`[{"type": "String", "value": "Hello"}, {"type": "Array", "value": [{"type": "UInt8", "value": "1"}]}]`)
+	require.NoError(t, err)
+	require.Len(t, args, 2)
+	assert.Equal(t, cadence.String("Hello"), args[0])
+	assert.Equal(t, "[1]", args[1].String())
+
+	_, err = flowkit.ParseArgumentsJSON(`{"type": "String", "value": "Hello"}`)
+	assert.ErrorContains(t, err, "arguments must be a JSON array of JSON-Cadence encoded values")
+
+	_, err = flowkit.ParseArgumentsJSON(`[{"type": "String", "value": "Hello"}, {"type": "UInt8", "value": "-1"}]`)
+	assert.ErrorContains(t, err, "failed to decode argument 1")
+}
+
+func TestEncodeArgumentsJSON(t *testing.T) {
+	values := []cadence.Value{
+		cadence.String("Hello"),
+		cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1}),
+	}
+
+	b, err := flowkit.EncodeArgumentsJSON(values)
+	require.NoError(t, err)
+
+	args, err := flowkit.ParseArgumentsJSON(string(b))
+	require.NoError(t, err)
+	assert.Equal(t, values, args)
+}
+
+func TestParseNamedArguments(t *testing.T) {
+	code := []byte(`transaction(amount: UFix64, to: Address, tags: {String: Int}) {}`)
+
+	amount, _ := cadence.NewUFix64("10.5")
+
+	args, err := flowkit.ParseNamedArguments("", code, []string{
+		`tags:{String: Int}:{"a": 1}`,
+		"to:Address:01",
+		"amount:UFix64:10.5",
+	})
+	require.NoError(t, err)
+	require.Len(t, args, 3)
+	assert.Equal(t, amount, args[0])
+	assert.Equal(t, cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1}), args[1])
+	assert.Equal(t, `{"a": 1}`, args[2].String())
+
+	tests := map[string][]string{
+		"missing argument tags":                                   {"amount:UFix64:10.5", "to:Address:01"},
+		"argument from doesn't match any of the parameters":       {"from:Address:01"},
+		"argument to is provided more than once":                  {"to:Address:01", "to:Address:02"},
+		"argument to has the type String, but the parameter type": {"to:String:01"},
+		"argument amount is not a valid UFix64 value":             {"amount:UFix64:ten", "to:Address:01", `tags:{String: Int}:{}`},
+		"invalid argument amount, the format is name:Type:value":  {"amount"},
+	}
+	for message, args := range tests {
+		_, err := flowkit.ParseNamedArguments("", code, args)
+		assert.ErrorContains(t, err, message)
+	}
+}
+
+func TestParseTypedArgument(t *testing.T) {
+	value, err := flowkit.ParseTypedArgument(`[String]:["a", "b"]`)
+	require.NoError(t, err)
+	assert.Equal(t, `["a", "b"]`, value.String())
+
+	value, err = flowkit.ParseTypedArgument("String:Hello: World")
+	require.NoError(t, err)
+	assert.Equal(t, cadence.String("Hello: World"), value)
+
+	_, err = flowkit.ParseTypedArgument("Foo:1")
+	assert.EqualError(t, err, "invalid type Foo")
+
+	_, err = flowkit.ParseTypedArgument("UInt8")
+	assert.EqualError(t, err, "missing type of UInt8, the format is Type:value")
+}
@@ -0,0 +1,207 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// awsKMSClient is the part of the AWS KMS client used for signing.
+type awsKMSClient interface {
+	GetPublicKey(ctx context.Context, params *awskms.GetPublicKeyInput, optFns ...func(*awskms.Options)) (*awskms.GetPublicKeyOutput, error)
+	Sign(ctx context.Context, params *awskms.SignInput, optFns ...func(*awskms.Options)) (*awskms.SignOutput, error)
+}
+
+// newAWSKMSClient returns a client for the region, resolving the credentials through the standard AWS chain
+// of the environment variables, shared configuration files and instance roles.
+func newAWSKMSClient(ctx context.Context, region string) (awsKMSClient, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the AWS configuration: %w", err)
+	}
+
+	return awskms.NewFromConfig(cfg), nil
+}
+
+// parseAWSKeyARN returns the region of the AWS KMS key ARN, like arn:aws:kms:us-east-1:111122223333:key/<key id>.
+func parseAWSKeyARN(resourceID string) (string, error) {
+	parsed, err := arn.Parse(resourceID)
+	if err != nil || parsed.Service != "kms" || !strings.HasPrefix(parsed.Resource, "key/") || parsed.Region == "" {
+		return "", fmt.Errorf("invalid AWS KMS key ARN %s, expected arn:aws:kms:<region>:<account>:key/<key id>", resourceID)
+	}
+
+	return parsed.Region, nil
+}
+
+// awsKMS is the provider shared by all the keys stored in AWS KMS, so the clients
+// and the public keys are reused when signing with the same keys multiple times.
+var awsKMS = &awsKMSProvider{newClient: newAWSKMSClient}
+
+// awsKMSProvider resolves keys stored in AWS KMS identified by the key ARN.
+type awsKMSProvider struct {
+	newClient func(ctx context.Context, region string) (awsKMSClient, error)
+
+	mu         sync.Mutex
+	clients    map[string]awsKMSClient     // clients by the region
+	publicKeys map[string]crypto.PublicKey // public keys by the key ARN
+}
+
+func (a *awsKMSProvider) Supports(resourceID string) bool {
+	_, err := parseAWSKeyARN(resourceID)
+	return err == nil
+}
+
+// Validate checks the credentials can access the key by fetching the public key.
+func (a *awsKMSProvider) Validate(resourceID string) error {
+	_, _, err := a.key(context.Background(), resourceID)
+	return err
+}
+
+func (a *awsKMSProvider) Signer(ctx context.Context, resourceID string) (crypto.Signer, error) {
+	client, publicKey, err := a.key(ctx, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &awsKMSSigner{
+		ctx:       ctx,
+		client:    client,
+		keyARN:    resourceID,
+		publicKey: publicKey,
+	}, nil
+}
+
+// key returns the client of the key region and the public key of the key.
+func (a *awsKMSProvider) key(ctx context.Context, resourceID string) (awsKMSClient, crypto.PublicKey, error) {
+	region, err := parseAWSKeyARN(resourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	client, ok := a.clients[region]
+	if !ok {
+		client, err = a.newClient(ctx, region)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if a.clients == nil {
+			a.clients = make(map[string]awsKMSClient)
+		}
+		a.clients[region] = client
+	}
+
+	publicKey, ok := a.publicKeys[resourceID]
+	if !ok {
+		publicKey, err = fetchAWSPublicKey(ctx, client, resourceID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if a.publicKeys == nil {
+			a.publicKeys = make(map[string]crypto.PublicKey)
+		}
+		a.publicKeys[resourceID] = publicKey
+	}
+
+	return client, publicKey, nil
+}
+
+func fetchAWSPublicKey(ctx context.Context, client awsKMSClient, keyARN string) (crypto.PublicKey, error) {
+	result, err := client.GetPublicKey(ctx, &awskms.GetPublicKeyInput{KeyId: &keyARN})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the public key of KMS key %s: %w", keyARN, err)
+	}
+
+	if result.KeyUsage != types.KeyUsageTypeSignVerify {
+		return nil, fmt.Errorf("KMS key %s can't be used for signing, the key usage is %s", keyARN, result.KeyUsage)
+	}
+	if result.KeySpec != types.KeySpecEccNistP256 && result.KeySpec != types.KeySpecEccSecgP256k1 {
+		return nil, fmt.Errorf(
+			"unsupported key spec %s of KMS key %s, only %s and %s are supported",
+			result.KeySpec,
+			keyARN,
+			types.KeySpecEccNistP256,
+			types.KeySpecEccSecgP256k1,
+		)
+	}
+
+	_, publicKey, err := DecodeKeyDER(result.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the public key of KMS key %s: %w", keyARN, err)
+	}
+
+	return publicKey, nil
+}
+
+var _ crypto.Signer = &awsKMSSigner{}
+
+// awsKMSSigner signs with an ECDSA key stored in AWS KMS using SHA2-256.
+type awsKMSSigner struct {
+	ctx       context.Context
+	client    awsKMSClient
+	keyARN    string
+	publicKey crypto.PublicKey
+}
+
+// awsKMSMaxMessageLength is the maximum length of messages hashed by KMS, longer messages are hashed before signing.
+const awsKMSMaxMessageLength = 4096
+
+func (s *awsKMSSigner) Sign(message []byte) ([]byte, error) {
+	input := &awskms.SignInput{
+		KeyId:            &s.keyARN,
+		Message:          message,
+		MessageType:      types.MessageTypeRaw,
+		SigningAlgorithm: types.SigningAlgorithmSpecEcdsaSha256,
+	}
+	if len(message) > awsKMSMaxMessageLength {
+		digest := sha256.Sum256(message)
+		input.Message = digest[:]
+		input.MessageType = types.MessageTypeDigest
+	}
+
+	result, err := s.client.Sign(s.ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with KMS key %s: %w", s.keyARN, err)
+	}
+
+	signature, err := rawSignature(result.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with KMS key %s: %w", s.keyARN, err)
+	}
+
+	return signature, nil
+}
+
+func (s *awsKMSSigner) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
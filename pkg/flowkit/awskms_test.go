@@ -0,0 +1,160 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"testing"
+
+	awskms "github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+const (
+	awsKeyARN       = "arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+	awsDeniedKeyARN = "arn:aws:kms:us-east-1:111122223333:key/0987dcba-09fe-87dc-65ba-ab0987654321"
+)
+
+// fakeAWSKMS signs with a P-256 key like AWS KMS, returning ASN.1 encoded signatures.
+type fakeAWSKMS struct {
+	key            *ecdsa.PrivateKey
+	publicKeyCalls int
+}
+
+func (f *fakeAWSKMS) GetPublicKey(_ context.Context, params *awskms.GetPublicKeyInput, _ ...func(*awskms.Options)) (*awskms.GetPublicKeyOutput, error) {
+	if *params.KeyId == awsDeniedKeyARN {
+		return nil, fmt.Errorf("AccessDeniedException: not authorized to perform kms:GetPublicKey")
+	}
+	f.publicKeyCalls++
+
+	der, err := x509.MarshalPKIXPublicKey(&f.key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &awskms.GetPublicKeyOutput{
+		KeyId:     params.KeyId,
+		KeySpec:   types.KeySpecEccNistP256,
+		KeyUsage:  types.KeyUsageTypeSignVerify,
+		PublicKey: der,
+	}, nil
+}
+
+func (f *fakeAWSKMS) Sign(_ context.Context, params *awskms.SignInput, _ ...func(*awskms.Options)) (*awskms.SignOutput, error) {
+	digest := params.Message
+	if params.MessageType == types.MessageTypeRaw {
+		hash := sha256.Sum256(params.Message)
+		digest = hash[:]
+	}
+
+	signature, err := ecdsa.SignASN1(rand.Reader, f.key, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &awskms.SignOutput{
+		KeyId:            params.KeyId,
+		Signature:        signature,
+		SigningAlgorithm: params.SigningAlgorithm,
+	}, nil
+}
+
+func TestAWSKMSSigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	fake := &fakeAWSKMS{key: key}
+	var regions []string
+	provider := &awsKMSProvider{
+		newClient: func(_ context.Context, region string) (awsKMSClient, error) {
+			regions = append(regions, region)
+			return fake, nil
+		},
+	}
+
+	require.NoError(t, provider.Validate(awsKeyARN))
+
+	signer, err := provider.Signer(context.Background(), awsKeyARN)
+	require.NoError(t, err)
+
+	publicKey := signer.PublicKey()
+	assert.Equal(t, crypto.ECDSA_P256, publicKey.Algorithm())
+
+	// the client and public key are created once
+	assert.Equal(t, []string{"us-east-1"}, regions)
+	assert.Equal(t, 1, fake.publicKeyCalls)
+
+	t.Run("Raw signature", func(t *testing.T) {
+		for _, message := range [][]byte{
+			[]byte("flow transaction"),
+			make([]byte, awsKMSMaxMessageLength+1), // hashed before signing
+		} {
+			signature, err := signer.Sign(message)
+			require.NoError(t, err)
+			assert.Len(t, signature, 64)
+
+			valid, err := publicKey.Verify(signature, message, crypto.NewSHA2_256())
+			require.NoError(t, err)
+			assert.True(t, valid)
+		}
+	})
+
+	t.Run("Fail access denied", func(t *testing.T) {
+		_, err := provider.Signer(context.Background(), awsDeniedKeyARN)
+		assert.EqualError(t, err, "failed to get the public key of KMS key "+awsDeniedKeyARN+": AccessDeniedException: not authorized to perform kms:GetPublicKey")
+	})
+
+	t.Run("Fail invalid ARN", func(t *testing.T) {
+		assert.False(t, provider.Supports("arn:aws:s3:::bucket"))
+
+		_, err := provider.Signer(context.Background(), "alias/flow")
+		assert.EqualError(t, err, "invalid AWS KMS key ARN alias/flow, expected arn:aws:kms:<region>:<account>:key/<key id>")
+	})
+}
+
+func TestAWSKMSAccountKey(t *testing.T) {
+	conf := config.AccountKey{
+		Type:       config.KeyTypeAWSKMS,
+		SigAlgo:    crypto.ECDSA_P256,
+		HashAlgo:   crypto.SHA2_256,
+		ResourceID: awsKeyARN,
+	}
+
+	key, err := NewAccountKey(conf)
+	require.NoError(t, err)
+	assert.Same(t, awsKMS, key.(*KmsAccountKey).provider)
+	assert.Equal(t, conf, key.ToConfig())
+
+	// AWS key ARNs are also resolved for the kms key type
+	conf.Type = config.KeyTypeKMS
+	key, err = NewAccountKey(conf)
+	require.NoError(t, err)
+	assert.Same(t, awsKMS, key.(*KmsAccountKey).provider)
+}
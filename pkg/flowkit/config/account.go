@@ -31,6 +31,12 @@ type Account struct {
 	Address flow.Address
 	Key     AccountKey
 
+	// AdditionalKeys are the keys following the Key on accounts defining multiple keys.
+	//
+	// The Key is used for signing by default, the other keys are used to reach the
+	// signature weight threshold when the Key doesn't have the full weight.
+	AdditionalKeys []AccountKey
+
 	// Location is the configuration file containing this account.
 	//
 	// This field is only set if the external "location"
@@ -39,6 +45,46 @@ type Account struct {
 	// Ref: https://docs.onflow.org/flow-cli/security/#private-account-configuration-file
 	Location         string
 	UseAdvanceFormat bool
+
+	// Networks are the definitions of the account on specific networks, overriding the address and key.
+	//
+	// The address and key of the account are used on all the other networks, and the account
+	// is only defined on these networks if it doesn't define the address itself.
+	Networks []NetworkAccount
+}
+
+// NetworkAccount defines the address and key of the account used on a network.
+type NetworkAccount struct {
+	Network string
+	Address flow.Address
+	Key     AccountKey
+}
+
+// ForNetwork returns the account with the address and key used on the network.
+//
+// An error is returned if the account is not defined for the network.
+func (a Account) ForNetwork(network string) (*Account, error) {
+	for _, n := range a.Networks {
+		if n.Network == network {
+			a.Address = n.Address
+			a.Key = n.Key
+			a.AdditionalKeys = nil
+			a.Networks = nil
+			return &a, nil
+		}
+	}
+
+	if a.Address == flow.EmptyAddress {
+		return nil, fmt.Errorf("account %s is not defined for network %s", a.Name, network)
+	}
+
+	a.Networks = nil
+	return &a, nil
+}
+
+// Keys returns all the keys of the account, starting with the Key.
+func (a Account) Keys() []AccountKey {
+	return append([]AccountKey{a.Key}, a.AdditionalKeys...)
 }
 
 type Accounts []Account
@@ -49,10 +95,34 @@ type AccountKey struct {
 	Index          int
 	SigAlgo        crypto.SignatureAlgorithm
 	HashAlgo       crypto.HashAlgorithm
+	Weight         int  // zero value is the full weight of the key
+	Revoked        bool // revoked keys are kept in the configuration but not used for signing
 	ResourceID     string
+	Location       string
 	Mnemonic       string
 	DerivationPath string
 	PrivateKey     crypto.PrivateKey
+	Encrypted      EncryptedKey
+	Keystore       string // name of the keystore entry
+}
+
+// EncryptedKey is a private key encrypted with a key derived from a passphrase.
+//
+// Only the encrypted private key is stored in the configuration, it is decrypted in memory when the key is used.
+type EncryptedKey struct {
+	Cipher     string // cipher used for encryption, only "aes-256-gcm" is supported
+	Ciphertext string // hex encoded encrypted private key
+	Nonce      string // hex encoded cipher nonce
+	KDF        KDF
+}
+
+// KDF defines the key derivation function used to derive the encryption key from the passphrase.
+type KDF struct {
+	Name string // only "scrypt" is supported
+	Salt string // hex encoded salt
+	N    int
+	R    int
+	P    int
 }
 
 // ByName get account by name.
@@ -22,6 +22,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
 )
 
 // Config contains all the configuration for CLI and implements getters and setters for properties.
@@ -32,12 +36,18 @@ import (
 // Networks defines all the Flow networks addresses
 // Accounts defines Flow accounts and their addresses, private key and more properties
 // Deployments describes which contracts should be deployed to which accounts
+// Transactions defines the template transactions run by their names
+// Scripts defines the saved scripts run by their names
+// Settings defines the defaults of the command flags
 type Config struct {
-	Emulators   Emulators
-	Contracts   Contracts
-	Networks    Networks
-	Accounts    Accounts
-	Deployments Deployments
+	Emulators    Emulators
+	Contracts    Contracts
+	Networks     Networks
+	Accounts     Accounts
+	Deployments  Deployments
+	Transactions Transactions
+	Scripts      Scripts
+	Settings     Settings
 }
 
 type KeyType string
@@ -45,7 +55,13 @@ type KeyType string
 const (
 	KeyTypeHex                        KeyType = "hex"
 	KeyTypeGoogleKMS                  KeyType = "google-kms"
+	KeyTypeAWSKMS                     KeyType = "aws-kms"
 	KeyTypeBip44                      KeyType = "bip44"
+	KeyTypeEncrypted                  KeyType = "encrypted"
+	KeyTypeKMS                        KeyType = "kms"
+	KeyTypeFile                       KeyType = "file"
+	KeyTypeHardware                   KeyType = "hardware"
+	KeyTypeKeystore                   KeyType = "keystore"
 	DefaultEmulatorConfigName                 = "default"
 	DefaultEmulatorServiceAccountName         = "emulator-account"
 	DefaultEmulatorPort                       = 3569
@@ -80,15 +96,141 @@ func (c *Config) Validate() error {
 			}
 		}
 
-		_, err = c.Accounts.ByName(d.Account)
+		account, err := c.Accounts.ByName(d.Account)
 		if err != nil {
 			return fmt.Errorf("deployment contains nonexisting account %s", d.Account)
 		}
+
+		_, err = account.ForNetwork(d.Network)
+		if err != nil {
+			return fmt.Errorf("deployment contains account %s not defined for network %s", d.Account, d.Network)
+		}
 	}
 
 	return nil
 }
 
+// ValidateAddresses checks the account addresses are valid on the chains of the networks using them.
+//
+// Flow addresses are generated per chain, so an address of another chain is rejected by the network.
+// Networks which chain can't be determined from the host or the name, like custom networks, aren't checked.
+func (c *Config) ValidateAddresses() error {
+	for _, account := range c.Accounts {
+		for _, n := range account.Networks {
+			if err := c.validateAddress(account.Name, n.Address, n.Network); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, d := range c.Deployments {
+		account, err := c.Accounts.ByName(d.Account)
+		if err != nil {
+			continue
+		}
+		account, err = account.ForNetwork(d.Network)
+		if err != nil {
+			continue
+		}
+
+		if err := c.validateAddress(account.Name, account.Address, d.Network); err != nil {
+			return fmt.Errorf("deployment on network %s is invalid: %w", d.Network, err)
+		}
+	}
+
+	return nil
+}
+
+// validateAddress checks the account address is valid on the chain of the network.
+func (c *Config) validateAddress(account string, address flow.Address, networkName string) error {
+	network, err := c.Networks.ByName(networkName)
+	if err != nil {
+		return nil // missing networks are reported by Validate
+	}
+
+	if chain, ok := network.ChainID(); ok && !address.IsValid(chain) {
+		return fmt.Errorf(
+			"account %s address %s is not valid on network %s, expected an address of chain %s",
+			account,
+			address,
+			networkName,
+			chain,
+		)
+	}
+
+	return nil
+}
+
+// AccountReferences returns descriptions of the configuration items referencing the account.
+func (c *Config) AccountReferences(name string) []string {
+	references := make([]string, 0)
+	for _, em := range c.Emulators {
+		if em.ServiceAccount == name {
+			references = append(references, fmt.Sprintf("emulator %s", em.Name))
+		}
+	}
+
+	for _, d := range c.Deployments {
+		if d.Account == name {
+			references = append(references, fmt.Sprintf("deployment on network %s", d.Network))
+			continue
+		}
+
+		for _, con := range d.Contracts {
+			hooks := make([]DeploymentHook, 0, len(con.Pre)+len(con.Post))
+			hooks = append(hooks, con.Pre...)
+			hooks = append(hooks, con.Post...)
+			for _, hook := range hooks {
+				if hook.Signer == name {
+					references = append(references, fmt.Sprintf(
+						"deployment hook of contract %s on network %s",
+						con.Name,
+						d.Network,
+					))
+				}
+			}
+		}
+	}
+
+	return references
+}
+
+// ContractReferences returns descriptions of the configuration items referencing the contract.
+func (c *Config) ContractReferences(name string) []string {
+	references := make([]string, 0)
+	for _, d := range c.Deployments {
+		for _, con := range d.Contracts {
+			if con.Name == name {
+				references = append(references, fmt.Sprintf(
+					"deployment to account %s on network %s",
+					d.Account,
+					d.Network,
+				))
+			}
+		}
+	}
+
+	return references
+}
+
+// NetworkReferences returns descriptions of the configuration items referencing the network.
+func (c *Config) NetworkReferences(name string) []string {
+	references := make([]string, 0)
+	for _, con := range c.Contracts {
+		if con.Network == name {
+			references = append(references, fmt.Sprintf("alias of contract %s", con.Name))
+		}
+	}
+
+	for _, d := range c.Deployments {
+		if d.Network == name {
+			references = append(references, fmt.Sprintf("deployment to account %s", d.Account))
+		}
+	}
+
+	return references
+}
+
 // Default returns the default configuration.
 func Default() *Config {
 	return &Config{
@@ -102,10 +244,25 @@ func Empty() *Config {
 	return &Config{}
 }
 
+// ErrOutdatedFormat is returned when the configuration is in a legacy format which needs to be migrated.
 var ErrOutdatedFormat = errors.New("you are using old configuration format")
 
 const DefaultPath = "flow.json"
 
+// DefaultFormatPaths are the default configuration paths in all the supported formats, in the order they are looked up.
+var DefaultFormatPaths = []string{DefaultPath, "flow.yaml", "flow.yml"}
+
+// DefaultPathForFormat returns the default configuration path in the format.
+func DefaultPathForFormat(format string) (string, error) {
+	for _, path := range DefaultFormatPaths {
+		if strings.TrimPrefix(filepath.Ext(path), ".") == format {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported configuration format %s, supported formats: json, yaml", format)
+}
+
 func IsDefaultPath(paths []string) bool {
 	return len(paths) == 2 && paths[0] == GlobalPath() && paths[1] == DefaultPath
 }
@@ -120,6 +277,22 @@ func GlobalPath() string {
 	return fmt.Sprintf("%s/%s", dirname, DefaultPath)
 }
 
+// GlobalUserPath returns the path of the global user configuration merged under the project configuration.
+//
+// The configuration is located in the flow directory of XDG_CONFIG_HOME if set, otherwise in ~/.flow.
+func GlobalUserPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "flow", "config.json")
+	}
+
+	dirname, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dirname, ".flow", "config.json")
+}
+
 // DefaultPaths determines default paths for configuration.
 func DefaultPaths() []string {
 	return []string{
@@ -202,3 +202,135 @@ func Test_GetNetworkByNameComplex(t *testing.T) {
 	assert.Equal(t, network.Host, "access.devnet.nodes.onflow.org:9000")
 	assert.Equal(t, network.Key, "5000676131ad3e22d853a3f75a5b5d0db4236d08dd6612e2baad771014b5266a242bccecc3522ff7207ac357dbe4f225c709d9b273ac484fed5d13976a39bdcd")
 }
+
+func Test_ReferencesComplex(t *testing.T) {
+	conf := generateComplexConfig()
+
+	assert.Equal(t, []string{
+		"emulator default",
+		"deployment on network emulator",
+	}, conf.AccountReferences("emulator-account"))
+	assert.Empty(t, conf.AccountReferences("account-3"))
+
+	assert.Equal(t, []string{
+		"deployment to account account-4 on network emulator",
+		"deployment to account account-2 on network testnet",
+	}, conf.ContractReferences("Kibble"))
+
+	assert.Equal(t, []string{
+		"alias of contract KittyItemsMarket",
+		"deployment to account account-2",
+	}, conf.NetworkReferences("testnet"))
+}
+
+func Test_Lint(t *testing.T) {
+	conf := config.Config{
+		Emulators: config.Emulators{{
+			Name:           "default",
+			ServiceAccount: "missing-account",
+		}},
+		Contracts: config.Contracts{{
+			Name:     "Foo",
+			Location: "./Foo.cdc",
+		}, {
+			Name:     "Foo",
+			Location: "./Foo.cdc",
+			Network:  "testnet",
+			Alias:    flow.ServiceAddress(flow.Emulator).String(),
+		}, {
+			Name:     "Foo",
+			Location: "./Foo.cdc",
+			Network:  "mainnet",
+			Alias:    flow.ServiceAddress(flow.Mainnet).String(),
+		}},
+		Deployments: config.Deployments{{
+			Network:   "emulator",
+			Account:   "alice",
+			Contracts: []config.ContractDeployment{{Name: "Foo"}, {Name: "Bar"}},
+		}, {
+			Network:   "emulator",
+			Account:   "bob",
+			Contracts: []config.ContractDeployment{{Name: "Foo"}},
+		}, {
+			Network: "testnet",
+			Account: "carol",
+			Contracts: []config.ContractDeployment{{
+				Name: "Foo",
+				Pre:  []config.DeploymentHook{{Location: "./setup.cdc", Signer: "dave"}},
+			}},
+		}},
+		Accounts: config.Accounts{{
+			Name:    "alice",
+			Address: flow.ServiceAddress(flow.Emulator),
+		}, {
+			Name:    "bob",
+			Address: flow.ServiceAddress(flow.Mainnet),
+		}},
+		Networks: config.Networks{
+			config.DefaultEmulatorNetwork(),
+			config.DefaultTestnetNetwork(),
+		},
+	}
+
+	assert.Equal(t, []config.Problem{{
+		Path:    "/emulators/default/serviceAccount",
+		Message: "service account missing-account does not exist",
+	}, {
+		Path:    "/contracts/Foo/aliases/testnet",
+		Message: "alias address f8d6e0586b0a20c7 is not valid on network testnet, expected an address of chain flow-testnet",
+	}, {
+		Path:    "/contracts/Foo/aliases/mainnet",
+		Message: "network mainnet does not exist",
+	}, {
+		Path:    "/deployments/emulator/alice/1",
+		Message: "contract Bar does not exist",
+	}, {
+		Path:    "/deployments/emulator/bob",
+		Message: "account bob address e467b9dd11fa00df is not valid on network emulator, expected an address of chain flow-emulator",
+	}, {
+		Path:    "/deployments/testnet/carol",
+		Message: "account carol does not exist",
+	}, {
+		Path:    "/deployments/testnet/carol/0/pre/0/signer",
+		Message: "signer account dave does not exist",
+	}}, conf.Lint())
+}
+
+func Test_ValidateAddresses(t *testing.T) {
+	conf := config.Config{
+		Deployments: config.Deployments{{
+			Network:   "testnet",
+			Account:   "alice",
+			Contracts: []config.ContractDeployment{{Name: "Foo"}},
+		}},
+		Accounts: config.Accounts{{
+			Name:    "alice",
+			Address: flow.ServiceAddress(flow.Emulator),
+		}},
+		Networks: config.Networks{
+			config.DefaultEmulatorNetwork(),
+			config.DefaultTestnetNetwork(),
+			{Name: "custom", Host: "127.0.0.1:3570"},
+		},
+	}
+
+	err := conf.ValidateAddresses()
+	assert.EqualError(t, err, "deployment on network testnet is invalid: account alice address f8d6e0586b0a20c7 is not valid on network testnet, expected an address of chain flow-testnet")
+
+	// the address for the network is validated instead of the default address
+	conf.Accounts[0].Networks = []config.NetworkAccount{{
+		Network: "testnet",
+		Address: flow.HexToAddress("e467b9dd11fa00df"),
+	}}
+	err = conf.ValidateAddresses()
+	assert.EqualError(t, err, "account alice address e467b9dd11fa00df is not valid on network testnet, expected an address of chain flow-testnet")
+
+	conf.Accounts[0].Networks[0].Address = flow.HexToAddress("9a0766d93b6608b7")
+	assert.NoError(t, conf.ValidateAddresses())
+
+	// the chain of custom networks is unknown so their addresses are not checked
+	conf.Deployments[0].Network = "custom"
+	conf.Accounts[0].Networks = nil
+	conf.Accounts[0].Address = flow.HexToAddress("e467b9dd11fa00df")
+	assert.NoError(t, conf.ValidateAddresses())
+}
@@ -26,6 +26,7 @@ type Contract struct {
 	Location string
 	Network  string
 	Alias    string
+	Hash     string // expected hash of the contract code fetched from a remote location
 }
 
 type Contracts []Contract
@@ -54,6 +55,7 @@ func (c *Contracts) ByNameAndNetwork(name string, network string) (*Contract, er
 		Name:     cName.Name,
 		Network:  network,
 		Location: cName.Location,
+		Hash:     cName.Hash,
 	}, nil
 }
 
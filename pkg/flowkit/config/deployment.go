@@ -28,6 +28,19 @@ import (
 type ContractDeployment struct {
 	Name string
 	Args []cadence.Value
+	Pre  []DeploymentHook // transactions sent before the contract is deployed
+	Post []DeploymentHook // transactions sent after the contract is deployed
+	// Imports maps names of imported contracts deployed to multiple accounts to the account name of the imported instance
+	Imports map[string]string
+	// Tags select the contract when deploying a subset of contracts
+	Tags []string
+}
+
+// DeploymentHook defines a transaction sent as part of the contract deployment.
+type DeploymentHook struct {
+	Location string          // transaction file location
+	Signer   string          // account name signing the transaction
+	Args     []cadence.Value // transaction arguments
 }
 
 type Deployments []Deployment
@@ -52,6 +65,19 @@ func (d *Deployments) ByNetwork(network string) Deployments {
 	return deployments
 }
 
+// ByAccount get all deployments to the account.
+func (d *Deployments) ByAccount(account string) Deployments {
+	var deployments Deployments
+
+	for _, deploy := range *d {
+		if deploy.Account == account {
+			deployments = append(deployments, deploy)
+		}
+	}
+
+	return deployments
+}
+
 // ByAccountAndNetwork get deploy by account and network.
 func (d *Deployments) ByAccountAndNetwork(account string, network string) Deployments {
 	var deployments Deployments
@@ -65,6 +91,19 @@ func (d *Deployments) ByAccountAndNetwork(account string, network string) Deploy
 	return deployments
 }
 
+// ContractByAccountAndNetwork get the contract deployment by contract name, account and network.
+func (d *Deployments) ContractByAccountAndNetwork(name string, account string, network string) *ContractDeployment {
+	for _, deploy := range d.ByAccountAndNetwork(account, network) {
+		for i, c := range deploy.Contracts {
+			if c.Name == name {
+				return &deploy.Contracts[i]
+			}
+		}
+	}
+
+	return nil
+}
+
 // AddOrUpdate add new or update if already present.
 func (d *Deployments) AddOrUpdate(deployment Deployment) {
 	for i, existingDeployment := range *d {
@@ -0,0 +1,123 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+var (
+	stringLiteralRegex = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+	placeholderRegex   = regexp.MustCompile(`\$\{(env|file):([^}]+)}`)
+)
+
+// interpolate replaces the placeholders in the string values of the raw configuration with resolved values.
+//
+// The placeholder "${env:NAME}" is replaced with the value of the environment variable and the placeholder
+// "${file:path}" with the content of the file. The string values with placeholders are returned mapped by
+// the resolved values, so the placeholders can be restored when the configuration is saved.
+func interpolate(raw []byte, readerWriter ReaderWriter) ([]byte, map[string]string, error) {
+	_ = godotenv.Load() // try to load .env file
+
+	interpolated := make(map[string]string)
+	var resolveErr error
+
+	result := stringLiteralRegex.ReplaceAllFunc(raw, func(literal []byte) []byte {
+		if resolveErr != nil || !placeholderRegex.Match(literal) {
+			return literal
+		}
+
+		var value string
+		if err := json.Unmarshal(literal, &value); err != nil {
+			resolveErr = err
+			return literal
+		}
+
+		resolved, err := resolvePlaceholders(value, readerWriter)
+		if err != nil {
+			resolveErr = err
+			return literal
+		}
+
+		resolvedLiteral, err := json.Marshal(resolved)
+		if err != nil {
+			resolveErr = err
+			return literal
+		}
+
+		interpolated[string(resolvedLiteral)] = string(literal)
+		return resolvedLiteral
+	})
+	if resolveErr != nil {
+		return nil, nil, resolveErr
+	}
+
+	return result, interpolated, nil
+}
+
+// resolvePlaceholders replaces all the placeholders in the value.
+func resolvePlaceholders(value string, readerWriter ReaderWriter) (string, error) {
+	var resolveErr error
+
+	resolved := placeholderRegex.ReplaceAllStringFunc(value, func(placeholder string) string {
+		match := placeholderRegex.FindStringSubmatch(placeholder)
+		source, name := match[1], strings.TrimSpace(match[2])
+
+		switch source {
+		case "env":
+			env, ok := os.LookupEnv(name)
+			if !ok {
+				resolveErr = fmt.Errorf("environment variable %s used in configuration value %s is not set", name, placeholder)
+			}
+			return env
+		default:
+			content, err := readerWriter.ReadFile(name)
+			if err != nil {
+				resolveErr = fmt.Errorf("file %s used in configuration value %s could not be read: %w", name, placeholder, err)
+			}
+			return strings.TrimSpace(string(content))
+		}
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	return resolved, nil
+}
+
+// restorePlaceholders replaces the interpolated string values in the serialized configuration with the
+// original values containing placeholders, so the resolved secrets are not saved.
+func restorePlaceholders(data []byte, interpolated map[string]string) []byte {
+	if len(interpolated) == 0 {
+		return data
+	}
+
+	return stringLiteralRegex.ReplaceAllFunc(data, func(literal []byte) []byte {
+		if original, ok := interpolated[string(literal)]; ok {
+			return []byte(original)
+		}
+		return literal
+	})
+}
@@ -21,6 +21,7 @@ package json
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/onflow/flow-go-sdk"
@@ -78,7 +79,9 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 	sigAlgo := crypto.StringToSignatureAlgorithm(a.Key.SigAlgo)
 	hashAlgo := crypto.StringToHashAlgorithm(a.Key.HashAlgo)
 
-	if a.Key.Type != config.KeyTypeHex && a.Key.Type != config.KeyTypeGoogleKMS && a.Key.Type != config.KeyTypeBip44 {
+	// key types other than the built-in ones are registered by the programs using flowkit,
+	// and are checked when the account keys are created
+	if a.Key.Type == "" {
 		return nil, fmt.Errorf("invalid key type for account %s", accountName)
 	}
 
@@ -94,6 +97,14 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 		return nil, fmt.Errorf("invalid hash algorithm for account %s", accountName)
 	}
 
+	if a.Key.Weight < 0 || a.Key.Weight > flow.AccountKeyWeightThreshold {
+		return nil, fmt.Errorf(
+			"invalid key weight for account %s, weight must be between 0 and %d",
+			accountName,
+			flow.AccountKeyWeightThreshold,
+		)
+	}
+
 	address, err := transformAddress(a.Address)
 	if err != nil {
 		return nil, err
@@ -104,6 +115,8 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 		Index:    a.Key.Index,
 		SigAlgo:  sigAlgo,
 		HashAlgo: hashAlgo,
+		Weight:   a.Key.Weight,
+		Revoked:  a.Key.Revoked,
 	}
 
 	switch a.Key.Type {
@@ -130,11 +143,39 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 			key.DerivationPath = "m/44'/539'/0'/0/0"
 		}
 
-	case config.KeyTypeGoogleKMS:
+	case config.KeyTypeGoogleKMS, config.KeyTypeAWSKMS, config.KeyTypeKMS:
 		if a.Key.ResourceID == "" {
 			return nil, fmt.Errorf("missing resource ID value for key on account %s", accountName)
 		}
 		key.ResourceID = a.Key.ResourceID
+
+	case config.KeyTypeFile:
+		if a.Key.Location == "" {
+			return nil, fmt.Errorf("missing location value for file key type on account %s", accountName)
+		}
+		key.Location = a.Key.Location
+
+	case config.KeyTypeEncrypted:
+		if a.Key.Encrypted == nil || a.Key.Encrypted.Ciphertext == "" {
+			return nil, fmt.Errorf("missing encrypted value for encrypted key type on account %s", accountName)
+		}
+		key.Encrypted = a.Key.Encrypted.transformToConfig()
+
+	case config.KeyTypeHardware:
+		if a.Key.DerivationPath == "" {
+			return nil, fmt.Errorf("missing derivation path value for hardware key type on account %s", accountName)
+		}
+		key.DerivationPath = a.Key.DerivationPath
+
+	case config.KeyTypeKeystore:
+		if a.Key.Keystore == "" {
+			return nil, fmt.Errorf("missing keystore value for keystore key type on account %s", accountName)
+		}
+		key.Keystore = a.Key.Keystore
+
+	default:
+		key.ResourceID = a.Key.ResourceID
+		key.Location = a.Key.Location
 	}
 
 	return &config.Account{
@@ -144,23 +185,95 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 	}, nil
 }
 
+// transformNetworksToConfig transforms the account definitions for specific networks.
+func transformNetworksToConfig(account *config.Account, networks map[string]account) error {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, network := range names {
+		n := networks[network]
+		if len(n.Networks) > 0 {
+			return fmt.Errorf("account %s definition for network %s can not define networks", account.Name, network)
+		}
+
+		networkAccount, err := transformAccountToConfig(account.Name, n)
+		if err != nil {
+			return err
+		}
+
+		account.Networks = append(account.Networks, config.NetworkAccount{
+			Network: network,
+			Address: networkAccount.Address,
+			Key:     networkAccount.Key,
+		})
+	}
+
+	return nil
+}
+
+// transformMultiKeyToConfig transforms account with multiple keys to config account.
+func transformMultiKeyToConfig(accountName string, a multiKeyAccount) (*config.Account, error) {
+	var acc *config.Account
+	indexes := make(map[int]bool)
+
+	for _, key := range a.Keys {
+		keyAccount, err := transformAdvancedToConfig(accountName, advancedAccount{
+			Address: a.Address,
+			Key:     key,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if indexes[key.Index] {
+			return nil, fmt.Errorf("duplicate key index %d on account %s", key.Index, accountName)
+		}
+		indexes[key.Index] = true
+
+		if acc == nil {
+			acc = keyAccount
+			continue
+		}
+		acc.AdditionalKeys = append(acc.AdditionalKeys, keyAccount.Key)
+	}
+
+	return acc, nil
+}
+
+// transformAccountToConfig transforms the account in any of the formats to config account.
+func transformAccountToConfig(accountName string, a account) (*config.Account, error) {
+	if a.Simple.Address != "" {
+		return transformSimpleToConfig(accountName, a.Simple)
+	}
+
+	if len(a.MultiKey.Keys) > 0 {
+		return transformMultiKeyToConfig(accountName, a.MultiKey)
+	}
+
+	// account only defined for specific networks
+	if len(a.Networks) > 0 && a.Advanced.Address == "" && a.Advanced.Key.Type == "" {
+		return &config.Account{Name: accountName}, nil
+	}
+
+	return transformAdvancedToConfig(accountName, a.Advanced)
+}
+
 // transformToConfig transforms json structures to config structure.
 func (j jsonAccounts) transformToConfig() (config.Accounts, error) {
 	accounts := make(config.Accounts, 0)
 
 	for accountName, a := range j {
-		var account *config.Account
-		var err error
-		if a.Simple.Address != "" {
-			account, err = transformSimpleToConfig(accountName, a.Simple)
-			if err != nil {
-				return nil, err
-			}
-		} else { // advanced format
-			account, err = transformAdvancedToConfig(accountName, a.Advanced)
-			if err != nil {
-				return nil, err
-			}
+		account, err := transformAccountToConfig(accountName, a)
+		if err != nil {
+			return nil, err
+		}
+
+		err = transformNetworksToConfig(account, a.Networks)
+		if err != nil {
+			return nil, err
 		}
 
 		accounts = append(accounts, *account)
@@ -176,16 +289,43 @@ func transformAccountsToJSON(accounts config.Accounts) jsonAccounts {
 	for _, a := range accounts {
 		if a.Location != "" {
 			jsonAccounts[a.Name] = transformFromFileAccountToJSON(a)
-		} else if isDefaultKeyFormat(a.Key) && !a.UseAdvanceFormat {
-			jsonAccounts[a.Name] = transformSimpleAccountToJSON(a)
-		} else {
-			jsonAccounts[a.Name] = transformAdvancedAccountToJSON(a)
+			continue
 		}
+
+		var jsonAccount account
+		if a.Address != flow.EmptyAddress {
+			jsonAccount = transformAccountDefinitionToJSON(a)
+		}
+
+		if len(a.Networks) > 0 {
+			jsonAccount.Networks = make(map[string]account)
+			for _, n := range a.Networks {
+				jsonAccount.Networks[n.Network] = transformAccountDefinitionToJSON(config.Account{
+					Address: n.Address,
+					Key:     n.Key,
+				})
+			}
+		}
+
+		jsonAccounts[a.Name] = jsonAccount
 	}
 
 	return jsonAccounts
 }
 
+// transformAccountDefinitionToJSON transforms the account address and key in the simple format if possible.
+func transformAccountDefinitionToJSON(a config.Account) account {
+	if len(a.AdditionalKeys) > 0 {
+		return transformMultiKeyAccountToJSON(a)
+	}
+
+	if isDefaultKeyFormat(a.Key) && !a.UseAdvanceFormat {
+		return transformSimpleAccountToJSON(a)
+	}
+
+	return transformAdvancedAccountToJSON(a)
+}
+
 func transformFromFileAccountToJSON(a config.Account) account {
 	return account{
 		FromFile: fromFileAccount{
@@ -212,12 +352,28 @@ func transformAdvancedAccountToJSON(a config.Account) account {
 	}
 }
 
+func transformMultiKeyAccountToJSON(a config.Account) account {
+	keys := make([]advanceKey, 0, len(a.AdditionalKeys)+1)
+	for _, key := range a.Keys() {
+		keys = append(keys, transformAdvancedKeyToJSON(key))
+	}
+
+	return account{
+		MultiKey: multiKeyAccount{
+			Address: a.Address.String(),
+			Keys:    keys,
+		},
+	}
+}
+
 func transformAdvancedKeyToJSON(key config.AccountKey) advanceKey {
 	advancedKey := advanceKey{
 		Type:     key.Type,
 		Index:    key.Index,
 		SigAlgo:  key.SigAlgo.String(),
 		HashAlgo: key.HashAlgo.String(),
+		Weight:   key.Weight,
+		Revoked:  key.Revoked,
 	}
 
 	switch key.Type {
@@ -226,8 +382,19 @@ func transformAdvancedKeyToJSON(key config.AccountKey) advanceKey {
 	case config.KeyTypeBip44:
 		advancedKey.Mnemonic = key.Mnemonic
 		advancedKey.DerivationPath = key.DerivationPath
-	case config.KeyTypeGoogleKMS:
+	case config.KeyTypeGoogleKMS, config.KeyTypeAWSKMS, config.KeyTypeKMS:
 		advancedKey.ResourceID = key.ResourceID
+	case config.KeyTypeFile:
+		advancedKey.Location = key.Location
+	case config.KeyTypeEncrypted:
+		advancedKey.Encrypted = transformEncryptedKeyToJSON(key.Encrypted)
+	case config.KeyTypeHardware:
+		advancedKey.DerivationPath = key.DerivationPath
+	case config.KeyTypeKeystore:
+		advancedKey.Keystore = key.Keystore
+	default:
+		advancedKey.ResourceID = key.ResourceID
+		advancedKey.Location = key.Location
 	}
 
 	return advancedKey
@@ -237,13 +404,17 @@ func isDefaultKeyFormat(key config.AccountKey) bool {
 	return key.Index == 0 &&
 		key.Type == config.KeyTypeHex &&
 		key.SigAlgo == crypto.ECDSA_P256 &&
-		key.HashAlgo == crypto.SHA3_256
+		key.HashAlgo == crypto.SHA3_256 &&
+		key.Weight == 0 &&
+		!key.Revoked
 }
 
 type account struct {
 	FromFile fromFileAccount
 	Simple   simpleAccount
 	Advanced advancedAccount
+	MultiKey multiKeyAccount
+	Networks map[string]account
 }
 
 type fromFileAccount struct {
@@ -260,22 +431,81 @@ type advancedAccount struct {
 	Key     advanceKey `json:"key"`
 }
 
+// multiKeyAccount defines an account with a list of keys.
+type multiKeyAccount struct {
+	Address string       `json:"address"`
+	Keys    []advanceKey `json:"key"`
+}
+
 type advanceKey struct {
 	Type     config.KeyType `json:"type"`
 	Index    int            `json:"index"`
 	SigAlgo  string         `json:"signatureAlgorithm"`
 	HashAlgo string         `json:"hashAlgorithm"`
+	Weight   int            `json:"weight,omitempty"`
+	Revoked  bool           `json:"revoked,omitempty"`
 	// hex key type
 	PrivateKey string `json:"privateKey,omitempty"`
 	// bip44 key type
 	Mnemonic       string `json:"mnemonic,omitempty"`
 	DerivationPath string `json:"derivationPath,omitempty"`
-	// kms key type
+	// kms and registered key types
 	ResourceID string `json:"resourceID,omitempty"`
+	// file and registered key types
+	Location string `json:"location,omitempty"`
+	// encrypted key type
+	Encrypted *encryptedKey `json:"encrypted,omitempty"`
+	// keystore key type
+	Keystore string `json:"keystore,omitempty"`
 	// old key format
 	Context map[string]string `json:"context,omitempty"`
 }
 
+type encryptedKey struct {
+	Cipher     string `json:"cipher"`
+	Ciphertext string `json:"ciphertext"`
+	Nonce      string `json:"nonce"`
+	KDF        kdf    `json:"kdf"`
+}
+
+type kdf struct {
+	Name string `json:"name"`
+	Salt string `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+func (e *encryptedKey) transformToConfig() config.EncryptedKey {
+	return config.EncryptedKey{
+		Cipher:     e.Cipher,
+		Ciphertext: e.Ciphertext,
+		Nonce:      e.Nonce,
+		KDF: config.KDF{
+			Name: e.KDF.Name,
+			Salt: e.KDF.Salt,
+			N:    e.KDF.N,
+			R:    e.KDF.R,
+			P:    e.KDF.P,
+		},
+	}
+}
+
+func transformEncryptedKeyToJSON(key config.EncryptedKey) *encryptedKey {
+	return &encryptedKey{
+		Cipher:     key.Cipher,
+		Ciphertext: key.Ciphertext,
+		Nonce:      key.Nonce,
+		KDF: kdf{
+			Name: key.KDF.Name,
+			Salt: key.KDF.Salt,
+			N:    key.KDF.N,
+			R:    key.KDF.R,
+			P:    key.KDF.P,
+		},
+	}
+}
+
 // support for pre v0.22 formats
 type simpleAccountPre022 struct {
 	Address string `json:"address"`
@@ -295,6 +525,7 @@ const (
 	advancedFormat       FormatType = 1
 	simpleFormatPre022   FormatType = 2 // pre v.022 format
 	advancedFormatPre022 FormatType = 3 // pre v.022 format
+	multiKeyFormat       FormatType = 4
 )
 
 func decideFormat(b []byte) (FormatType, error) {
@@ -316,6 +547,8 @@ func decideFormat(b []byte) (FormatType, error) {
 	switch raw["key"].(type) {
 	case string:
 		return simpleFormat, nil
+	case []interface{}:
+		return multiKeyFormat, nil
 	default:
 		return advancedFormat, nil
 	}
@@ -356,8 +589,22 @@ func (j *account) UnmarshalJSON(b []byte) error {
 		var advanced advancedAccount
 		err = json.Unmarshal(b, &advanced)
 		j.Advanced = advanced
+
+	case multiKeyFormat:
+		var multiKey multiKeyAccount
+		err = json.Unmarshal(b, &multiKey)
+		j.MultiKey = multiKey
+	}
+	if err != nil {
+		return err
 	}
 
+	var networks struct {
+		Networks map[string]account `json:"networks"`
+	}
+	err = json.Unmarshal(b, &networks)
+	j.Networks = networks.Networks
+
 	return err
 }
 
@@ -366,9 +613,39 @@ func (j account) MarshalJSON() ([]byte, error) {
 		return json.Marshal(j.FromFile)
 	}
 
+	if len(j.Networks) > 0 {
+		return j.marshalWithNetworks()
+	}
+
 	if j.Simple != (simpleAccount{}) {
 		return json.Marshal(j.Simple)
 	}
 
+	if len(j.MultiKey.Keys) > 0 {
+		return json.Marshal(j.MultiKey)
+	}
+
 	return json.Marshal(j.Advanced)
 }
+
+// marshalWithNetworks marshals the account together with the definitions for specific networks.
+func (j account) marshalWithNetworks() ([]byte, error) {
+	withNetworks := make(map[string]interface{})
+
+	// the account might only be defined for specific networks
+	if j.Simple != (simpleAccount{}) || j.Advanced.Address != "" || j.MultiKey.Address != "" {
+		base := j
+		base.Networks = nil
+		data, err := json.Marshal(base)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(data, &withNetworks)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	withNetworks["networks"] = j.Networks
+	return json.Marshal(withNetworks)
+}
@@ -19,6 +19,7 @@ package json
 
 import (
 	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk"
@@ -120,6 +121,164 @@ func Test_ConfigAccountKeysAdvancedKMS(t *testing.T) {
 	assert.Nil(t, key.PrivateKey)
 }
 
+func Test_ConfigAccountKeysAdvancedEncrypted(t *testing.T) {
+	b := []byte(`{"test":{"address":"f8d6e0586b0a20c7","key":{"type":"encrypted","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","encrypted":{"cipher":"aes-256-gcm","ciphertext":"8f2c0b3e","nonce":"a1b2c3d4e5f6a1b2c3d4e5f6","kdf":{"name":"scrypt","salt":"0102030405060708090a0b0c0d0e0f10","n":32768,"r":8,"p":1}}}}}`)
+
+	var jsonAccounts jsonAccounts
+	err := json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := jsonAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	account, err := accounts.ByName("test")
+	assert.NoError(t, err)
+	key := account.Key
+
+	assert.Equal(t, config.KeyTypeEncrypted, key.Type)
+	assert.Equal(t, "aes-256-gcm", key.Encrypted.Cipher)
+	assert.Equal(t, "8f2c0b3e", key.Encrypted.Ciphertext)
+	assert.Equal(t, "scrypt", key.Encrypted.KDF.Name)
+	assert.Equal(t, 32768, key.Encrypted.KDF.N)
+	assert.Nil(t, key.PrivateKey)
+
+	// the encrypted key is saved back unchanged
+	x, _ := json.Marshal(transformAccountsToJSON(accounts))
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigAccountKeysEncryptedMissingValue(t *testing.T) {
+	b := []byte(`{"test":{"address":"f8d6e0586b0a20c7","key":{"type":"encrypted","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256"}}}`)
+
+	var jsonAccounts jsonAccounts
+	err := json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	_, err = jsonAccounts.transformToConfig()
+	assert.EqualError(t, err, "missing encrypted value for encrypted key type on account test")
+}
+
+func Test_ConfigAccountKeysRoundTrip(t *testing.T) {
+	keys := map[string]string{
+		"hex":        `{"type":"hex","index":1,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","privateKey":"1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"}`,
+		"bip44":      `{"type":"bip44","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","mnemonic":"skull design wagon top faith actor valley crystal subject volcano access join","derivationPath":"m/44'/539'/0'/0/0"}`,
+		"google-kms": `{"type":"google-kms","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","resourceID":"projects/flow/locations/us/keyRings/foo/cryptoKeys/bar/cryptoKeyVersions/1"}`,
+		"kms":        `{"type":"kms","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","resourceID":"projects/flow/locations/us/keyRings/foo/cryptoKeys/bar/cryptoKeyVersions/1"}`,
+		"file":       `{"type":"file","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","location":"./keys/admin.pem"}`,
+		"encrypted":  `{"type":"encrypted","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","encrypted":{"cipher":"aes-256-gcm","ciphertext":"ab","nonce":"cd","kdf":{"name":"scrypt","salt":"ef","n":32768,"r":8,"p":1}}}`,
+		"hardware":   `{"type":"hardware","index":0,"signatureAlgorithm":"ECDSA_secp256k1","hashAlgorithm":"SHA2_256","derivationPath":"m/44'/539'/513'/0/0"}`,
+		"keystore":   `{"type":"keystore","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","keystore":"alice"}`,
+		"vault":      `{"type":"vault","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","resourceID":"transit/keys/alice"}`,
+	}
+
+	for keyType, key := range keys {
+		t.Run(keyType, func(t *testing.T) {
+			b := []byte(fmt.Sprintf(`{"test":{"address":"f8d6e0586b0a20c7","key":%s}}`, key))
+
+			var jsonAccounts jsonAccounts
+			err := json.Unmarshal(b, &jsonAccounts)
+			assert.NoError(t, err)
+
+			accounts, err := jsonAccounts.transformToConfig()
+			assert.NoError(t, err)
+			assert.Equal(t, config.KeyType(keyType), accounts[0].Key.Type)
+
+			result, err := json.Marshal(transformAccountsToJSON(accounts))
+			assert.NoError(t, err)
+			assert.Equal(t, string(b), string(result))
+		})
+	}
+}
+
+func Test_ConfigAccountKeysMissingValues(t *testing.T) {
+	keys := map[string]string{
+		"kms":      "missing resource ID value for key on account test",
+		"file":     "missing location value for file key type on account test",
+		"hardware": "missing derivation path value for hardware key type on account test",
+		"keystore": "missing keystore value for keystore key type on account test",
+	}
+
+	for keyType, message := range keys {
+		b := []byte(fmt.Sprintf(`{"test":{"address":"f8d6e0586b0a20c7","key":{"type":"%s","signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256"}}}`, keyType))
+
+		var jsonAccounts jsonAccounts
+		err := json.Unmarshal(b, &jsonAccounts)
+		assert.NoError(t, err)
+
+		_, err = jsonAccounts.transformToConfig()
+		assert.EqualError(t, err, message)
+	}
+}
+
+func Test_ConfigAccountMultipleKeys(t *testing.T) {
+	b := []byte(`{"test":{"address":"f8d6e0586b0a20c7","key":[{"type":"hex","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","weight":500,"privateKey":"1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"},{"type":"file","index":1,"signatureAlgorithm":"ECDSA_secp256k1","hashAlgorithm":"SHA2_256","weight":500,"location":"./test.pkey"}]}}`)
+
+	var parsed jsonAccounts
+	err := json.Unmarshal(b, &parsed)
+	assert.NoError(t, err)
+
+	accounts, err := parsed.transformToConfig()
+	assert.NoError(t, err)
+
+	account, err := accounts.ByName("test")
+	assert.NoError(t, err)
+
+	keys := account.Keys()
+	assert.Len(t, keys, 2)
+	assert.Equal(t, 0, keys[0].Index)
+	assert.Equal(t, 500, keys[0].Weight)
+	assert.Equal(t, config.KeyTypeFile, keys[1].Type)
+	assert.Equal(t, 1, keys[1].Index)
+	assert.Equal(t, "ECDSA_secp256k1", keys[1].SigAlgo.String())
+	assert.Equal(t, 500, keys[1].Weight)
+
+	result, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), string(result))
+
+	t.Run("Fail duplicate index", func(t *testing.T) {
+		b := []byte(`{"test":{"address":"f8d6e0586b0a20c7","key":[{"type":"file","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","location":"./a.pkey"},{"type":"file","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","location":"./b.pkey"}]}}`)
+
+		var jsonAccounts jsonAccounts
+		err := json.Unmarshal(b, &jsonAccounts)
+		assert.NoError(t, err)
+
+		_, err = jsonAccounts.transformToConfig()
+		assert.EqualError(t, err, "duplicate key index 0 on account test")
+	})
+
+	t.Run("Fail invalid weight", func(t *testing.T) {
+		b := []byte(`{"test":{"address":"f8d6e0586b0a20c7","key":[{"type":"file","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","weight":1001,"location":"./a.pkey"}]}}`)
+
+		var jsonAccounts jsonAccounts
+		err := json.Unmarshal(b, &jsonAccounts)
+		assert.NoError(t, err)
+
+		_, err = jsonAccounts.transformToConfig()
+		assert.EqualError(t, err, "invalid key weight for account test, weight must be between 0 and 1000")
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		b := []byte(`{"test":{"address":"f8d6e0586b0a20c7","key":[{"type":"hex","index":0,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","revoked":true,"privateKey":"1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"},{"type":"file","index":1,"signatureAlgorithm":"ECDSA_P256","hashAlgorithm":"SHA3_256","location":"./test.pkey"}]}}`)
+
+		var jsonAccounts jsonAccounts
+		err := json.Unmarshal(b, &jsonAccounts)
+		assert.NoError(t, err)
+
+		accounts, err := jsonAccounts.transformToConfig()
+		assert.NoError(t, err)
+
+		account, err := accounts.ByName("test")
+		assert.NoError(t, err)
+		assert.True(t, account.Key.Revoked)
+		assert.False(t, account.AdditionalKeys[0].Revoked)
+
+		result, err := json.Marshal(transformAccountsToJSON(accounts))
+		assert.NoError(t, err)
+		assert.Equal(t, string(b), string(result))
+	})
+}
+
 func Test_ConfigAccountOldFormats(t *testing.T) {
 	b := []byte(`{
 		"old-format-1": {
@@ -390,6 +549,40 @@ func Test_TransformDefaultAccountToJSONAdvanced(t *testing.T) {
 	assert.Equal(t, string(b), string(x))
 }
 
+func Test_ConfigAccountNetworks(t *testing.T) {
+	b := []byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":"1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47","networks":{"testnet":{"address":"3c1162386b0a245f","key":"2272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"}}},"tester":{"networks":{"testnet":{"address":"3c1162386b0a245f","key":"2272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"}}}}`)
+
+	var jsonAccounts jsonAccounts
+	err := json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := jsonAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	admin, err := accounts.ByName("admin")
+	assert.NoError(t, err)
+	assert.Equal(t, "f8d6e0586b0a20c7", admin.Address.String())
+	assert.Len(t, admin.Networks, 1)
+	assert.Equal(t, "testnet", admin.Networks[0].Network)
+	assert.Equal(t, "3c1162386b0a245f", admin.Networks[0].Address.String())
+
+	testnetAdmin, err := admin.ForNetwork("testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, "3c1162386b0a245f", testnetAdmin.Address.String())
+
+	tester, err := accounts.ByName("tester")
+	assert.NoError(t, err)
+	assert.Equal(t, flow.EmptyAddress, tester.Address)
+
+	_, err = tester.ForNetwork("emulator")
+	assert.EqualError(t, err, "account tester is not defined for network emulator")
+
+	j := transformAccountsToJSON(accounts)
+	x, _ := json.Marshal(j)
+
+	assert.Equal(t, string(b), string(x))
+}
+
 func Test_SupportForOldFormatWithMultipleKeys(t *testing.T) {
 	b := []byte(`{
 		"emulator-account": {
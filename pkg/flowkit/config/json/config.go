@@ -19,19 +19,25 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"unicode"
 
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 )
 
 // jsonConfig implements JSON format for persisting and parsing configuration.
 type jsonConfig struct {
-	Emulators   jsonEmulators   `json:"emulators,omitempty"`
-	Contracts   jsonContracts   `json:"contracts,omitempty"`
-	Networks    jsonNetworks    `json:"networks,omitempty"`
-	Accounts    jsonAccounts    `json:"accounts,omitempty"`
-	Deployments jsonDeployments `json:"deployments,omitempty"`
+	Emulators    jsonEmulators    `json:"emulators,omitempty"`
+	Contracts    jsonContracts    `json:"contracts,omitempty"`
+	Networks     jsonNetworks     `json:"networks,omitempty"`
+	Accounts     jsonAccounts     `json:"accounts,omitempty"`
+	Deployments  jsonDeployments  `json:"deployments,omitempty"`
+	Transactions jsonTransactions `json:"transactions,omitempty"`
+	Scripts      jsonScripts      `json:"scripts,omitempty"`
+	Settings     *jsonSettings    `json:"settings,omitempty"`
 }
 
 func (j *jsonConfig) transformToConfig() (*config.Config, error) {
@@ -60,12 +66,25 @@ func (j *jsonConfig) transformToConfig() (*config.Config, error) {
 		return nil, err
 	}
 
+	transactions, err := j.Transactions.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scripts, err := j.Scripts.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	conf := &config.Config{
-		Emulators:   emulators,
-		Contracts:   contracts,
-		Networks:    networks,
-		Accounts:    accounts,
-		Deployments: deployments,
+		Emulators:    emulators,
+		Contracts:    contracts,
+		Networks:     networks,
+		Accounts:     accounts,
+		Deployments:  deployments,
+		Transactions: transactions,
+		Scripts:      scripts,
+		Settings:     j.Settings.transformToConfig(),
 	}
 
 	return conf, nil
@@ -73,11 +92,14 @@ func (j *jsonConfig) transformToConfig() (*config.Config, error) {
 
 func transformConfigToJSON(config *config.Config) jsonConfig {
 	return jsonConfig{
-		Emulators:   transformEmulatorsToJSON(config.Emulators),
-		Contracts:   transformContractsToJSON(config.Contracts),
-		Networks:    transformNetworksToJSON(config.Networks),
-		Accounts:    transformAccountsToJSON(config.Accounts),
-		Deployments: transformDeploymentsToJSON(config.Deployments),
+		Emulators:    transformEmulatorsToJSON(config.Emulators),
+		Contracts:    transformContractsToJSON(config.Contracts),
+		Networks:     transformNetworksToJSON(config.Networks),
+		Accounts:     transformAccountsToJSON(config.Accounts),
+		Deployments:  transformDeploymentsToJSON(config.Deployments),
+		Transactions: transformTransactionsToJSON(config.Transactions),
+		Scripts:      transformScriptsToJSON(config.Scripts),
+		Settings:     transformSettingsToJSON(config.Settings),
 	}
 }
 
@@ -129,11 +151,81 @@ func (p *Parser) Deserialize(raw []byte) (*config.Config, error) {
 
 	var jsonConf jsonConfig
 	err := json.Unmarshal(raw, &jsonConf)
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return nil, syntaxError(raw, syntaxErr)
+	}
+
+	// schema problems describe invalid values better than the errors of parsing them
+	if problems := validateSchema(raw); len(problems) > 0 {
+		return nil, &config.ProblemsError{Problems: problems}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("configuration syntax error: %w", err)
 	}
 
-	return jsonConf.transformToConfig()
+	conf, err := jsonConf.transformToConfig()
+	if err != nil && p.isLegacy(raw) {
+		return nil, fmt.Errorf("%w: %s", config.ErrOutdatedFormat, err)
+	}
+
+	return conf, err
+}
+
+// syntaxError describes the syntax error with the line and column of the error,
+// and explains trailing commas which are commonly left when editing the configuration.
+func syntaxError(raw []byte, err *json.SyntaxError) error {
+	offset := int(err.Offset)
+	if offset > len(raw) {
+		offset = len(raw)
+	}
+
+	// the offset is after the invalid character, so the position of the invalid character is reported
+	position := offset - 1
+	if position < 0 {
+		position = 0
+	}
+
+	line, column := 1, 1
+	for _, c := range raw[:position] {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+
+	// the error is reported after the character closing the object or array preceded by the trailing comma
+	preceding := bytes.TrimRightFunc(raw[:offset], unicode.IsSpace)
+	if len(preceding) > 0 && (preceding[len(preceding)-1] == '}' || preceding[len(preceding)-1] == ']') {
+		preceding = bytes.TrimRightFunc(preceding[:len(preceding)-1], unicode.IsSpace)
+		if len(preceding) > 0 && preceding[len(preceding)-1] == ',' {
+			return fmt.Errorf("configuration syntax error at line %d, column %d: trailing comma is not allowed", line, column)
+		}
+	}
+
+	return fmt.Errorf("configuration syntax error at line %d, column %d: %w", line, column, err)
+}
+
+// ToJSON returns the configuration as is, since it is already in the JSON format.
+func (p *Parser) ToJSON(raw []byte) ([]byte, error) {
+	return raw, nil
+}
+
+// FromJSON formats the JSON configuration the same way as serialized configuration.
+func (p *Parser) FromJSON(data []byte) ([]byte, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return nil, fmt.Errorf("configuration syntax error: %w", err)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact.Bytes(), "", "\t"); err != nil {
+		return nil, err
+	}
+
+	return indented.Bytes(), nil
 }
 
 // SupportsFormat check if the file format is supported.
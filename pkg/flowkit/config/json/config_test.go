@@ -23,6 +23,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 )
@@ -255,3 +256,51 @@ func Test_SerializeConfigToJsonEmulatorNotDefault(t *testing.T) {
 	assert.JSONEq(t, string(configJson), string(conf))
 
 }
+
+func Test_ConfigSyntaxError(t *testing.T) {
+	parser := NewParser()
+
+	_, err := parser.Deserialize([]byte("{\n\t\"networks\": {\n\t\t\"emulator\": \"127.0.0.1:3569\",\n\t}\n}"))
+	assert.EqualError(t, err, "configuration syntax error at line 4, column 2: trailing comma is not allowed")
+
+	_, err = parser.Deserialize([]byte("{\n\t\"networks\": {\n\t\t\"emulator\" \"127.0.0.1:3569\"\n\t}\n}"))
+	assert.EqualError(t, err, "configuration syntax error at line 3, column 14: invalid character '\"' after object key")
+}
+
+func Test_ConfigSchema(t *testing.T) {
+	parser := NewParser()
+
+	_, err := parser.Deserialize([]byte(`{
+		"depoyments": {},
+		"accounts": {
+			"alice": {
+				"address": "01cf0e2f2f715450",
+				"key": {
+					"type": "hex",
+					"index": 0,
+					"hashAlgorithm": "SHA3_256",
+					"privateKey": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+				}
+			}
+		},
+		"emulators": {
+			"default": {
+				"port": "3569",
+				"serviceAccount": "alice"
+			}
+		}
+	}`))
+
+	var problemsErr *config.ProblemsError
+	require.ErrorAs(t, err, &problemsErr)
+	assert.Equal(t, []config.Problem{{
+		Path:    "/accounts/alice/key",
+		Message: "missing required property signatureAlgorithm",
+	}, {
+		Path:    "/depoyments",
+		Message: "unknown property depoyments, did you mean deployments?",
+	}, {
+		Path:    "/emulators/default/port",
+		Message: "expected integer but found string",
+	}}, problemsErr.Problems)
+}
@@ -40,6 +40,14 @@ func (j jsonContracts) transformToConfig() (config.Contracts, error) {
 
 			contracts = append(contracts, contract)
 		} else {
+			if len(c.Advanced.Aliases) == 0 {
+				contracts = append(contracts, config.Contract{
+					Name:     contractName,
+					Location: c.Advanced.Source,
+					Hash:     c.Advanced.Hash,
+				})
+			}
+
 			for network, alias := range c.Advanced.Aliases {
 				_, err := config.StringToAddress(alias)
 				if err != nil {
@@ -51,6 +59,7 @@ func (j jsonContracts) transformToConfig() (config.Contracts, error) {
 					Location: c.Advanced.Source,
 					Network:  network,
 					Alias:    alias,
+					Hash:     c.Advanced.Hash,
 				}
 
 				contracts = append(contracts, contract)
@@ -67,10 +76,17 @@ func transformContractsToJSON(contracts config.Contracts) jsonContracts {
 
 	for _, c := range contracts {
 		// if simple case
-		if c.Network == "" {
+		if c.Network == "" && c.Hash == "" {
 			jsonContracts[c.Name] = jsonContract{
 				Simple: c.Location,
 			}
+		} else if c.Network == "" { // if advanced config without aliases
+			jsonContracts[c.Name] = jsonContract{
+				Advanced: jsonContractAdvanced{
+					Source: c.Location,
+					Hash:   c.Hash,
+				},
+			}
 		} else { // if advanced config
 			// check if we already created for this name then add or create
 			if _, exists := jsonContracts[c.Name]; exists && jsonContracts[c.Name].Advanced.Aliases != nil {
@@ -79,6 +95,7 @@ func transformContractsToJSON(contracts config.Contracts) jsonContracts {
 				jsonContracts[c.Name] = jsonContract{
 					Advanced: jsonContractAdvanced{
 						Source:  c.Location,
+						Hash:    c.Hash,
 						Aliases: map[string]string{c.Network: c.Alias},
 					},
 				}
@@ -92,7 +109,8 @@ func transformContractsToJSON(contracts config.Contracts) jsonContracts {
 // jsonContractAdvanced for json parsing advanced config.
 type jsonContractAdvanced struct {
 	Source  string            `json:"source"`
-	Aliases map[string]string `json:"aliases"`
+	Hash    string            `json:"hash,omitempty"`
+	Aliases map[string]string `json:"aliases,omitempty"`
 }
 
 // jsonContract structure for json parsing.
@@ -206,3 +206,41 @@ func Test_TransformComplexContractToJSON(t *testing.T) {
 
 	assert.JSONEq(t, string(b), string(x))
 }
+
+func Test_ConfigContractsRemote(t *testing.T) {
+	b := []byte(`{
+		"NonFungibleToken": {
+			"source": "https://raw.githubusercontent.com/onflow/flow-nft/master/contracts/NonFungibleToken.cdc",
+			"hash": "0ae1ba4b8c8a39f1f1a63ea4bd3f1eb2b0e2cd7e1e2e1b6e0c9e5b0c1d0f7a3e"
+		},
+		"MetadataViews": {
+			"source": "git+https://github.com/onflow/flow-nft.git//contracts/MetadataViews.cdc?ref=master",
+			"hash": "9a1c2b3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9",
+			"aliases": {
+				"testnet": "631e88ae7f1d7c20"
+			}
+		}
+	}`)
+
+	var jsonContracts jsonContracts
+	err := json.Unmarshal(b, &jsonContracts)
+	assert.NoError(t, err)
+
+	contracts, err := jsonContracts.transformToConfig()
+	assert.NoError(t, err)
+	assert.Len(t, contracts, 2)
+
+	nft, err := contracts.ByName("NonFungibleToken")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://raw.githubusercontent.com/onflow/flow-nft/master/contracts/NonFungibleToken.cdc", nft.Location)
+	assert.Equal(t, "0ae1ba4b8c8a39f1f1a63ea4bd3f1eb2b0e2cd7e1e2e1b6e0c9e5b0c1d0f7a3e", nft.Hash)
+
+	views, err := contracts.ByNameAndNetwork("MetadataViews", "testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, "9a1c2b3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9", views.Hash)
+
+	j := transformContractsToJSON(contracts)
+	x, _ := json.Marshal(j)
+
+	assert.JSONEq(t, string(b), string(x))
+}
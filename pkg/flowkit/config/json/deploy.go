@@ -54,26 +54,30 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 						},
 					)
 				} else {
-					args := make([]cadence.Value, 0)
-					for _, arg := range contract.advanced.Args {
-						b, err := json.Marshal(arg)
-						if err != nil {
-							return nil, err
-						}
-
-						cadenceArg, err := jsoncdc.Decode(nil, b)
-						if err != nil {
-							return nil, err
-						}
-
-						args = append(args, cadenceArg)
+					args, err := transformArgsToConfig(contract.advanced.Args)
+					if err != nil {
+						return nil, err
+					}
+
+					pre, err := transformHooksToConfig(contract.advanced.Pre)
+					if err != nil {
+						return nil, err
+					}
+
+					post, err := transformHooksToConfig(contract.advanced.Post)
+					if err != nil {
+						return nil, err
 					}
 
 					contractDeploys = append(
 						contractDeploys,
 						config.ContractDeployment{
-							Name: contract.advanced.Name,
-							Args: args,
+							Name:    contract.advanced.Name,
+							Args:    args,
+							Pre:     pre,
+							Post:    post,
+							Imports: contract.advanced.Imports,
+							Tags:    contract.advanced.Tags,
 						},
 					)
 				}
@@ -95,31 +99,19 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 
 		deployments := make([]deployment, 0)
 		for _, c := range d.Contracts {
-			if len(c.Args) == 0 {
+			if len(c.Args) == 0 && len(c.Pre) == 0 && len(c.Post) == 0 && len(c.Imports) == 0 && len(c.Tags) == 0 {
 				deployments = append(deployments, deployment{
 					simple: c.Name,
 				})
 			} else {
-				args := make([]map[string]interface{}, 0)
-				for _, arg := range c.Args {
-					switch arg.Type().ID() {
-					case "Bool":
-						args = append(args, map[string]interface{}{
-							"type":  arg.Type().ID(),
-							"value": arg.ToGoValue(),
-						})
-					default:
-						args = append(args, map[string]interface{}{
-							"type":  arg.Type().ID(),
-							"value": fmt.Sprintf("%v", arg.ToGoValue()),
-						})
-					}
-				}
-
 				deployments = append(deployments, deployment{
 					advanced: contractDeployment{
-						Name: c.Name,
-						Args: args,
+						Name:    c.Name,
+						Args:    transformArgsToJSON(c.Args),
+						Pre:     transformHooksToJSON(c.Pre),
+						Post:    transformHooksToJSON(c.Post),
+						Imports: c.Imports,
+						Tags:    c.Tags,
 					},
 				})
 			}
@@ -138,9 +130,94 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 	return jsonDeploys
 }
 
+// transformArgsToConfig decodes the JSON-Cadence arguments.
+func transformArgsToConfig(jsonArgs []map[string]interface{}) ([]cadence.Value, error) {
+	args := make([]cadence.Value, 0)
+	for _, arg := range jsonArgs {
+		b, err := json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		cadenceArg, err := jsoncdc.Decode(nil, b)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, cadenceArg)
+	}
+
+	return args, nil
+}
+
+// transformArgsToJSON encodes the arguments in the JSON-Cadence format.
+func transformArgsToJSON(configArgs []cadence.Value) []map[string]interface{} {
+	args := make([]map[string]interface{}, 0)
+	for _, arg := range configArgs {
+		switch arg.Type().ID() {
+		case "Bool":
+			args = append(args, map[string]interface{}{
+				"type":  arg.Type().ID(),
+				"value": arg.ToGoValue(),
+			})
+		default:
+			args = append(args, map[string]interface{}{
+				"type":  arg.Type().ID(),
+				"value": fmt.Sprintf("%v", arg.ToGoValue()),
+			})
+		}
+	}
+
+	return args
+}
+
+func transformHooksToConfig(jsonHooks []deploymentHook) ([]config.DeploymentHook, error) {
+	var hooks []config.DeploymentHook
+	for _, hook := range jsonHooks {
+		args, err := transformArgsToConfig(hook.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		hooks = append(hooks, config.DeploymentHook{
+			Location: hook.Location,
+			Signer:   hook.Signer,
+			Args:     args,
+		})
+	}
+
+	return hooks, nil
+}
+
+func transformHooksToJSON(configHooks []config.DeploymentHook) []deploymentHook {
+	var hooks []deploymentHook
+	for _, hook := range configHooks {
+		h := deploymentHook{
+			Location: hook.Location,
+			Signer:   hook.Signer,
+		}
+		if len(hook.Args) > 0 {
+			h.Args = transformArgsToJSON(hook.Args)
+		}
+		hooks = append(hooks, h)
+	}
+
+	return hooks
+}
+
 type contractDeployment struct {
-	Name string                   `json:"name"`
-	Args []map[string]interface{} `json:"args"`
+	Name    string                   `json:"name"`
+	Args    []map[string]interface{} `json:"args"`
+	Pre     []deploymentHook         `json:"pre,omitempty"`
+	Post    []deploymentHook         `json:"post,omitempty"`
+	Imports map[string]string        `json:"imports,omitempty"`
+	Tags    []string                 `json:"tags,omitempty"`
+}
+
+type deploymentHook struct {
+	Location string                   `json:"location"`
+	Signer   string                   `json:"signer"`
+	Args     []map[string]interface{} `json:"args,omitempty"`
 }
 
 type deployment struct {
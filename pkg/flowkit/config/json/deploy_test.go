@@ -151,3 +151,115 @@ func Test_DeploymentAdvanced(t *testing.T) {
 	assert.Equal(t, "KittyItemsMarket", alice[0].Contracts[1].Name)
 	assert.Len(t, alice[0].Contracts[1].Args, 0)
 }
+
+func Test_DeploymentHooks(t *testing.T) {
+	b := []byte(`{
+		"emulator": {
+			"alice": [
+				{
+					"name": "Kibble",
+					"args": [],
+					"pre": [
+						{ "location": "./transactions/prepare.cdc", "signer": "alice" }
+					],
+					"post": [
+						{
+							"location": "./transactions/mint_admin.cdc",
+							"signer": "bob",
+							"args": [{ "type": "String", "value": "admin" }]
+						}
+					]
+				}
+			]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	assert.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	assert.NoError(t, err)
+
+	kibble := deployments.ContractByAccountAndNetwork("Kibble", "alice", "emulator")
+	assert.NotNil(t, kibble)
+	assert.Len(t, kibble.Pre, 1)
+	assert.Equal(t, "./transactions/prepare.cdc", kibble.Pre[0].Location)
+	assert.Equal(t, "alice", kibble.Pre[0].Signer)
+	assert.Len(t, kibble.Pre[0].Args, 0)
+	assert.Len(t, kibble.Post, 1)
+	assert.Equal(t, "bob", kibble.Post[0].Signer)
+	assert.Equal(t, `"admin"`, kibble.Post[0].Args[0].String())
+
+	j := transformDeploymentsToJSON(deployments)
+	x, _ := json.Marshal(j)
+
+	assert.Equal(t, cleanSpecialChars(b), cleanSpecialChars(x))
+}
+
+func Test_DeploymentImports(t *testing.T) {
+	b := []byte(`{
+		"emulator": {
+			"alice": ["Market"],
+			"bob": ["Market"],
+			"charlie": [
+				{
+					"name": "Shop",
+					"args": [],
+					"imports": { "Market": "bob" }
+				}
+			]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	assert.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	assert.NoError(t, err)
+
+	shop := deployments.ContractByAccountAndNetwork("Shop", "charlie", "emulator")
+	assert.NotNil(t, shop)
+	assert.Equal(t, map[string]string{"Market": "bob"}, shop.Imports)
+
+	j := transformDeploymentsToJSON(deployments)
+	x, _ := json.Marshal(j)
+
+	assert.JSONEq(t, string(b), string(x))
+}
+
+func Test_DeploymentTags(t *testing.T) {
+	b := []byte(`{
+		"emulator": {
+			"alice": [
+				{
+					"name": "Market",
+					"args": [],
+					"tags": ["core", "markets"]
+				},
+				"Shop"
+			]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	assert.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	assert.NoError(t, err)
+
+	market := deployments.ContractByAccountAndNetwork("Market", "alice", "emulator")
+	assert.NotNil(t, market)
+	assert.Equal(t, []string{"core", "markets"}, market.Tags)
+
+	shop := deployments.ContractByAccountAndNetwork("Shop", "alice", "emulator")
+	assert.NotNil(t, shop)
+	assert.Empty(t, shop.Tags)
+
+	j := transformDeploymentsToJSON(deployments)
+	x, _ := json.Marshal(j)
+
+	assert.JSONEq(t, string(b), string(x))
+}
@@ -0,0 +1,239 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// Migrate converts the configuration from the legacy formats to the current format.
+//
+// The configuration format before v0.17 defined a single host and accounts with flat keys, and the format
+// before v0.22 defined account keys as a list and the chain of accounts and networks. The changes made by
+// the migration are described in the returned list, which is empty if the configuration is already current.
+func (p *Parser) Migrate(raw []byte) ([]byte, []string, error) {
+	var conf map[string]interface{}
+	err := json.Unmarshal(raw, &conf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("configuration syntax error: %w", err)
+	}
+
+	var changes []string
+	if oldConfigFormat(raw) {
+		changes = append(changes, migrateHost(conf)...)
+	}
+	changes = append(changes, migrateNetworks(conf)...)
+	changes = append(changes, migrateAccounts(conf)...)
+
+	if len(changes) == 0 {
+		return raw, nil, nil
+	}
+
+	migrated, err := json.Marshal(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the migrated configuration is validated and formatted as any saved configuration
+	migratedConf, err := p.Deserialize(migrated)
+	if err != nil {
+		return nil, nil, fmt.Errorf("migrated configuration is invalid: %w", err)
+	}
+
+	data, err := p.Serialize(migratedConf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, changes, nil
+}
+
+// isLegacy checks if the configuration contains any of the legacy formats which can be migrated.
+func (p *Parser) isLegacy(raw []byte) bool {
+	_, changes, err := p.Migrate(raw)
+	return err == nil && len(changes) > 0
+}
+
+// migrateHost converts the host and the flat accounts used before v0.17.
+func migrateHost(conf map[string]interface{}) []string {
+	var changes []string
+
+	host, _ := conf["host"].(string)
+	delete(conf, "host")
+
+	network := networkForHost(host)
+	conf["networks"] = map[string]interface{}{network: host}
+	changes = append(changes, fmt.Sprintf("host %s converted to network %s", host, network))
+
+	accounts, _ := conf["accounts"].(map[string]interface{})
+	migratedAccounts := make(map[string]interface{})
+	for _, name := range sortedKeys(accounts) {
+		account, ok := accounts[name].(map[string]interface{})
+		if !ok {
+			migratedAccounts[name] = accounts[name]
+			continue
+		}
+
+		// the service account of the emulator is expected under the default name
+		migratedName := name
+		if name == "service" && network == config.DefaultEmulatorNetwork().Name {
+			migratedName = config.DefaultEmulatorServiceAccountName
+			changes = append(changes, fmt.Sprintf("account %s renamed to %s", name, migratedName))
+		}
+
+		if privateKey, ok := account["privateKey"]; ok {
+			sigAlgo := stringOrDefault(account["sigAlgorithm"], crypto.ECDSA_P256.String())
+			hashAlgo := stringOrDefault(account["hashAlgorithm"], crypto.SHA3_256.String())
+
+			account = map[string]interface{}{
+				"address": account["address"],
+				"key": map[string]interface{}{
+					"type":               config.KeyTypeHex,
+					"index":              0,
+					"signatureAlgorithm": sigAlgo,
+					"hashAlgorithm":      hashAlgo,
+					"privateKey":         privateKey,
+				},
+			}
+			changes = append(changes, fmt.Sprintf("account %s private key converted to key", migratedName))
+		}
+
+		migratedAccounts[migratedName] = account
+	}
+	conf["accounts"] = migratedAccounts
+
+	return changes
+}
+
+// migrateNetworks removes the chain of the networks used before v0.22.
+func migrateNetworks(conf map[string]interface{}) []string {
+	var changes []string
+
+	networks, _ := conf["networks"].(map[string]interface{})
+	for _, name := range sortedKeys(networks) {
+		network, ok := networks[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if _, ok := network["chain"]; ok {
+			delete(network, "chain")
+			changes = append(changes, fmt.Sprintf("network %s chain removed", name))
+		}
+
+		// networks without a key are defined only by the host
+		if key, _ := network["key"].(string); key == "" {
+			networks[name] = network["host"]
+		}
+	}
+
+	return changes
+}
+
+// migrateAccounts converts the list of keys and removes the chain of the accounts used before v0.22.
+func migrateAccounts(conf map[string]interface{}) []string {
+	var changes []string
+
+	accounts, _ := conf["accounts"].(map[string]interface{})
+	for _, name := range sortedKeys(accounts) {
+		account, ok := accounts[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if _, ok := account["chain"]; ok {
+			delete(account, "chain")
+			changes = append(changes, fmt.Sprintf("account %s chain removed", name))
+		}
+
+		keys, ok := account["keys"]
+		if !ok {
+			continue
+		}
+		delete(account, "keys")
+
+		switch keys := keys.(type) {
+		case string:
+			account["key"] = keys
+		case []interface{}:
+			if len(keys) == 0 {
+				continue
+			}
+			for _, key := range keys {
+				key, _ := key.(map[string]interface{})
+				if context, ok := key["context"].(map[string]interface{}); ok {
+					if privateKey, ok := context["privateKey"]; ok {
+						key["privateKey"] = privateKey
+					}
+					delete(key, "context")
+				}
+			}
+
+			if len(keys) == 1 {
+				account["key"] = keys[0]
+			} else {
+				account["key"] = keys
+			}
+		}
+		changes = append(changes, fmt.Sprintf("account %s keys converted to key", name))
+	}
+
+	return changes
+}
+
+// networkForHost returns the name of the default network with the host, or a custom network name.
+func networkForHost(host string) string {
+	for _, network := range config.DefaultNetworks() {
+		if network.Host == host {
+			return network.Name
+		}
+	}
+
+	if strings.HasPrefix(host, "127.0.0.1") || strings.HasPrefix(host, "localhost") {
+		return config.DefaultEmulatorNetwork().Name
+	}
+
+	return "custom"
+}
+
+func stringOrDefault(value interface{}, defaultValue string) string {
+	if s, ok := value.(string); ok && s != "" {
+		return s
+	}
+
+	return defaultValue
+}
+
+// sortedKeys returns the keys of the map sorted, so the changes are reported in a stable order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
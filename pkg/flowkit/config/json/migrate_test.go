@@ -0,0 +1,138 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+func Test_MigratePre017(t *testing.T) {
+	b := []byte(`{
+		"host": "127.0.0.1:3569",
+		"accounts": {
+			"service": {
+				"address": "f8d6e0586b0a20c7",
+				"privateKey": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47",
+				"sigAlgorithm": "ECDSA_P256",
+				"hashAlgorithm": "SHA3_256"
+			}
+		}
+	}`)
+
+	parser := NewParser()
+
+	// loading the legacy configuration asks for a migration
+	_, err := parser.Deserialize(b)
+	assert.ErrorIs(t, err, config.ErrOutdatedFormat)
+
+	migrated, changes, err := parser.Migrate(b)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"host 127.0.0.1:3569 converted to network emulator",
+		"account service renamed to emulator-account",
+		"account emulator-account private key converted to key",
+	}, changes)
+
+	conf, err := parser.Deserialize(migrated)
+	require.NoError(t, err)
+
+	network, err := conf.Networks.ByName("emulator")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:3569", network.Host)
+
+	account, err := conf.Accounts.ByName("emulator-account")
+	require.NoError(t, err)
+	assert.Equal(t, "f8d6e0586b0a20c7", account.Address.String())
+	assert.Equal(t, "0xdd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47", account.Key.PrivateKey.String())
+}
+
+func Test_MigratePre022(t *testing.T) {
+	b := []byte(`{
+		"networks": {
+			"emulator": {
+				"host": "127.0.0.1:3569",
+				"chain": "flow-emulator"
+			}
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"chain": "flow-emulator",
+				"keys": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			},
+			"testnet-account": {
+				"address": "3c1162386b0a245f",
+				"chain": "testnet",
+				"keys": [{
+					"type": "hex",
+					"index": 1,
+					"signatureAlgorithm": "ECDSA_P256",
+					"hashAlgorithm": "SHA3_256",
+					"context": {
+						"privateKey": "1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+					}
+				}, {
+					"type": "hex",
+					"index": 2,
+					"signatureAlgorithm": "ECDSA_P256",
+					"hashAlgorithm": "SHA3_256",
+					"context": {
+						"privateKey": "2272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+					}
+				}]
+			}
+		}
+	}`)
+
+	parser := NewParser()
+
+	_, err := parser.Deserialize(b)
+	assert.ErrorIs(t, err, config.ErrOutdatedFormat)
+
+	migrated, changes, err := parser.Migrate(b)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"network emulator chain removed",
+		"account emulator-account chain removed",
+		"account emulator-account keys converted to key",
+		"account testnet-account chain removed",
+		"account testnet-account keys converted to key",
+	}, changes)
+	assert.NotContains(t, string(migrated), "chain")
+
+	conf, err := parser.Deserialize(migrated)
+	require.NoError(t, err)
+
+	account, err := conf.Accounts.ByName("testnet-account")
+	require.NoError(t, err)
+	assert.Equal(t, 1, account.Key.Index)
+	assert.Equal(t, "0x1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47", account.Key.PrivateKey.String())
+	require.Len(t, account.AdditionalKeys, 1)
+	assert.Equal(t, 2, account.AdditionalKeys[0].Index)
+
+	// the current format is not changed
+	_, changes, err = parser.Migrate(migrated)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
@@ -21,6 +21,7 @@ package json
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
@@ -33,17 +34,36 @@ func (j jsonNetworks) transformToConfig() (config.Networks, error) {
 	networks := make(config.Networks, 0)
 
 	for networkName, n := range j {
-		if n.Advanced.Key != "" && n.Advanced.Host != "" {
-			err := util.ValidateECDSAP256Pub(n.Advanced.Key)
+		if n.Advanced.Host != "" && (n.Advanced.Key != "" || n.Advanced.hasRequestSettings()) {
+			if n.Advanced.Key != "" {
+				err := util.ValidateECDSAP256Pub(n.Advanced.Key)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
+				}
+			}
+
+			network := config.Network{
+				Name:                 networkName,
+				Host:                 n.Advanced.Host,
+				Key:                  n.Advanced.Key,
+				MaxRetries:           n.Advanced.MaxRetries,
+				MaxRequestsPerSecond: n.Advanced.MaxRequestsPerSecond,
+			}
+
+			var err error
+			network.Timeout, err = parseDuration(n.Advanced.Timeout)
 			if err != nil {
-				return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
+				return nil, fmt.Errorf("invalid timeout %s for network with name %s", n.Advanced.Timeout, networkName)
+			}
+			network.RetryBackoff, err = parseDuration(n.Advanced.RetryBackoff)
+			if err != nil {
+				return nil, fmt.Errorf("invalid retry backoff %s for network with name %s", n.Advanced.RetryBackoff, networkName)
+			}
+			if network.MaxRetries < 0 || network.MaxRequestsPerSecond < 0 {
+				return nil, fmt.Errorf("request settings for network with name %s can not be negative", networkName)
 			}
 
-			networks = append(networks, config.Network{
-				Name: networkName,
-				Host: n.Advanced.Host,
-				Key:  n.Advanced.Key,
-			})
+			networks = append(networks, network)
 		} else if n.Simple.Host != "" {
 			networks = append(networks, config.Network{
 				Name: networkName,
@@ -62,7 +82,7 @@ func transformNetworksToJSON(networks config.Networks) jsonNetworks {
 	jsonNetworks := jsonNetworks{}
 
 	for _, n := range networks {
-		if n.Key != "" {
+		if n.Key != "" || n.HasRequestSettings() {
 			jsonNetworks[n.Name] = transformAdvancedNetworkToJSON(n)
 		} else {
 			jsonNetworks[n.Name] = transformSimpleNetworkToJSON(n)
@@ -83,12 +103,41 @@ func transformSimpleNetworkToJSON(n config.Network) jsonNetwork {
 func transformAdvancedNetworkToJSON(n config.Network) jsonNetwork {
 	return jsonNetwork{
 		Advanced: advancedNetwork{
-			Host: n.Host,
-			Key:  n.Key,
+			Host:                 n.Host,
+			Key:                  n.Key,
+			Timeout:              formatDuration(n.Timeout),
+			MaxRetries:           n.MaxRetries,
+			RetryBackoff:         formatDuration(n.RetryBackoff),
+			MaxRequestsPerSecond: n.MaxRequestsPerSecond,
 		},
 	}
 }
 
+// parseDuration parses an optional duration of the request settings, like "30s" or "500ms".
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("negative duration %s", value)
+	}
+
+	return duration, nil
+}
+
+func formatDuration(duration time.Duration) string {
+	if duration == 0 {
+		return ""
+	}
+
+	return duration.String()
+}
+
 type jsonNetwork struct {
 	Simple   simpleNetwork
 	Advanced advancedNetwork
@@ -99,8 +148,16 @@ type simpleNetwork struct {
 }
 
 type advancedNetwork struct {
-	Host string `json:"host"`
-	Key  string `json:"key"`
+	Host                 string  `json:"host"`
+	Key                  string  `json:"key,omitempty"`
+	Timeout              string  `json:"timeout,omitempty"`
+	MaxRetries           int     `json:"maxRetries,omitempty"`
+	RetryBackoff         string  `json:"retryBackoff,omitempty"`
+	MaxRequestsPerSecond float64 `json:"maxRequestsPerSecond,omitempty"`
+}
+
+func (a advancedNetwork) hasRequestSettings() bool {
+	return a.Timeout != "" || a.MaxRetries != 0 || a.RetryBackoff != "" || a.MaxRequestsPerSecond != 0
 }
 
 func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
@@ -115,8 +172,7 @@ func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
 	var advanced advancedNetwork
 	err = json.Unmarshal(b, &advanced)
 	if err == nil {
-		j.Advanced.Host = advanced.Host
-		j.Advanced.Key = advanced.Key
+		j.Advanced = advanced
 	}
 
 	return err
@@ -20,6 +20,7 @@ package json
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -153,3 +154,56 @@ func Test_TransformConfigAdvanced(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func Test_ConfigNetworkRequestSettings(t *testing.T) {
+	t.Run("should parse request settings", func(t *testing.T) {
+		b := []byte(`{"emulator":"127.0.0.1:3569","testnet":{"host":"access.testnet.nodes.onflow.org:9000","timeout":"30s","maxRetries":3,"retryBackoff":"500ms","maxRequestsPerSecond":10}}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		networks, err := jsonNetworks.transformToConfig()
+		assert.NoError(t, err)
+
+		testnet, err := networks.ByName("testnet")
+		assert.NoError(t, err)
+		assert.Equal(t, "access.testnet.nodes.onflow.org:9000", testnet.Host)
+		assert.Equal(t, "", testnet.Key)
+		assert.Equal(t, 30*time.Second, testnet.Timeout)
+		assert.Equal(t, 3, testnet.MaxRetries)
+		assert.Equal(t, 500*time.Millisecond, testnet.RetryBackoff)
+		assert.Equal(t, float64(10), testnet.MaxRequestsPerSecond)
+
+		emulator, err := networks.ByName("emulator")
+		assert.NoError(t, err)
+		assert.False(t, emulator.HasRequestSettings())
+
+		x, err := json.Marshal(transformNetworksToJSON(networks))
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(b), string(x))
+	})
+
+	t.Run("should return error for invalid durations", func(t *testing.T) {
+		for _, b := range [][]byte{
+			[]byte(`{"testnet":{"host":"access.testnet.nodes.onflow.org:9000","timeout":"30"}}`),
+			[]byte(`{"testnet":{"host":"access.testnet.nodes.onflow.org:9000","retryBackoff":"-1s"}}`),
+		} {
+			var jsonNetworks jsonNetworks
+			err := json.Unmarshal(b, &jsonNetworks)
+			assert.NoError(t, err)
+
+			_, err = jsonNetworks.transformToConfig()
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("should return error for negative settings", func(t *testing.T) {
+		b := []byte(`{"testnet":{"host":"access.testnet.nodes.onflow.org:9000","maxRetries":-1}}`)
+		var jsonNetworks jsonNetworks
+		err := json.Unmarshal(b, &jsonNetworks)
+		assert.NoError(t, err)
+
+		_, err = jsonNetworks.transformToConfig()
+		assert.EqualError(t, err, "request settings for network with name testnet can not be negative")
+	})
+}
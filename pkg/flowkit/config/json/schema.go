@@ -0,0 +1,333 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// schemaSource is the JSON Schema of the configuration format.
+//
+//go:embed schema.json
+var schemaSource []byte
+
+var configSchema = mustParseSchema(schemaSource)
+
+// schema is the subset of the JSON Schema used to describe the configuration format.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaTypes        `json:"type"`
+	Enum                 []interface{}      `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *additional        `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	AnyOf                []*schema          `json:"anyOf"`
+	Definitions          map[string]*schema `json:"definitions"`
+}
+
+// schemaTypes are the allowed types of a value, the type can be specified as a string or a list.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+	*t = multiple
+	return nil
+}
+
+// additional describes the additional properties which are either not allowed or must match the schema.
+type additional struct {
+	allowed bool
+	schema  *schema
+}
+
+func (a *additional) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &a.allowed); err == nil {
+		return nil
+	}
+
+	a.allowed = true
+	return json.Unmarshal(b, &a.schema)
+}
+
+func mustParseSchema(source []byte) *schema {
+	var s schema
+	if err := json.Unmarshal(source, &s); err != nil {
+		panic(fmt.Sprintf("invalid configuration schema: %s", err))
+	}
+	return &s
+}
+
+// validateSchema validates the raw configuration against the schema and returns all the problems found.
+func validateSchema(raw []byte) []config.Problem {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			err = syntaxError(raw, syntaxErr)
+		}
+		return []config.Problem{{Message: err.Error()}}
+	}
+
+	return configSchema.validate(value, "")
+}
+
+// resolve returns the schema definition referenced by the schema.
+func (s *schema) resolve() *schema {
+	if s.Ref == "" {
+		return s
+	}
+
+	return configSchema.Definitions[strings.TrimPrefix(s.Ref, "#/definitions/")].resolve()
+}
+
+func (s *schema) validate(value interface{}, path string) []config.Problem {
+	s = s.resolve()
+
+	if len(s.Type) > 0 && !s.Type.matches(value) {
+		return []config.Problem{{
+			Path:    path,
+			Message: fmt.Sprintf("expected %s but found %s", strings.Join(s.Type, " or "), typeOf(value)),
+		}}
+	}
+
+	if len(s.AnyOf) > 0 {
+		return s.validateAnyOf(value, path)
+	}
+
+	if len(s.Enum) > 0 && !s.enumContains(value) {
+		allowed := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			allowed[i] = fmt.Sprint(v)
+		}
+		return []config.Problem{{
+			Path:    path,
+			Message: fmt.Sprintf("value %v is not one of: %s", value, strings.Join(allowed, ", ")),
+		}}
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return []config.Problem{{Path: path, Message: fmt.Sprintf("value %v is lower than %v", v, *s.Minimum)}}
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			return []config.Problem{{Path: path, Message: fmt.Sprintf("value %v is greater than %v", v, *s.Maximum)}}
+		}
+	case []interface{}:
+		if s.Items == nil {
+			return nil
+		}
+		problems := make([]config.Problem, 0)
+		for i, item := range v {
+			problems = append(problems, s.Items.validate(item, path+config.Pointer(i))...)
+		}
+		return problems
+	case map[string]interface{}:
+		return s.validateObject(v, path)
+	}
+
+	return nil
+}
+
+// validateAnyOf validates the value against the alternatives of matching type and
+// returns the problems of the closest matching alternative.
+func (s *schema) validateAnyOf(value interface{}, path string) []config.Problem {
+	var closest []config.Problem
+	types := make([]string, 0)
+
+	for _, alternative := range s.AnyOf {
+		alternative = alternative.resolve()
+		types = append(types, alternative.Type...)
+		if len(alternative.Type) > 0 && !alternative.Type.matches(value) {
+			continue
+		}
+
+		problems := alternative.validate(value, path)
+		if len(problems) == 0 {
+			return nil
+		}
+		if closest == nil || len(problems) < len(closest) {
+			closest = problems
+		}
+	}
+
+	if closest == nil {
+		return []config.Problem{{
+			Path:    path,
+			Message: fmt.Sprintf("expected %s but found %s", strings.Join(types, " or "), typeOf(value)),
+		}}
+	}
+
+	return closest
+}
+
+func (s *schema) validateObject(object map[string]interface{}, path string) []config.Problem {
+	problems := make([]config.Problem, 0)
+
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			problems = append(problems, config.Problem{
+				Path:    path,
+				Message: fmt.Sprintf("missing required property %s", name),
+			})
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := path + config.Pointer(name)
+
+		if property, ok := s.Properties[name]; ok {
+			problems = append(problems, property.validate(object[name], propertyPath)...)
+			continue
+		}
+
+		if s.AdditionalProperties == nil || s.AdditionalProperties.allowed {
+			if s.AdditionalProperties != nil && s.AdditionalProperties.schema != nil {
+				problems = append(problems, s.AdditionalProperties.schema.validate(object[name], propertyPath)...)
+			}
+			continue
+		}
+
+		message := fmt.Sprintf("unknown property %s", name)
+		if suggestion := s.suggestProperty(name); suggestion != "" {
+			message = fmt.Sprintf("%s, did you mean %s?", message, suggestion)
+		}
+		problems = append(problems, config.Problem{Path: propertyPath, Message: message})
+	}
+
+	return problems
+}
+
+// suggestProperty returns the known property closest to the unknown name, if the name looks like its misspelling.
+func (s *schema) suggestProperty(name string) string {
+	suggestion := ""
+	best := len(name)/3 + 1 // allow a typo for every three characters
+
+	for property := range s.Properties {
+		distance := editDistance(strings.ToLower(name), strings.ToLower(property))
+		if distance < best || (distance == best && suggestion != "" && property < suggestion) {
+			best = distance
+			suggestion = property
+		}
+	}
+
+	return suggestion
+}
+
+func (s *schema) enumContains(value interface{}) bool {
+	for _, v := range s.Enum {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (t schemaTypes) matches(value interface{}) bool {
+	for _, name := range t {
+		actual := typeOf(value)
+		if name == actual {
+			return true
+		}
+		if name == "number" && actual == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+// typeOf returns the JSON Schema type name of the decoded JSON value.
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// editDistance returns the Levenshtein distance between the strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
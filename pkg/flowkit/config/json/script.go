@@ -0,0 +1,100 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// jsonScripts are the saved scripts, in the same format as the template transactions.
+type jsonScripts map[string]jsonTransaction
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonScripts) transformToConfig() (config.Scripts, error) {
+	scripts := make(config.Scripts, 0)
+
+	for name, s := range j {
+		script := config.Script{
+			Name:     name,
+			Location: s.Simple,
+		}
+
+		if s.Simple == "" {
+			script.Location = s.Advanced.Source
+
+			// parameters are sorted by name so the configuration is deterministic
+			names := make([]string, 0, len(s.Advanced.Parameters))
+			for parameterName := range s.Advanced.Parameters {
+				names = append(names, parameterName)
+			}
+			sort.Strings(names)
+
+			for _, parameterName := range names {
+				parameterType := s.Advanced.Parameters[parameterName]
+				if parameterType == "" {
+					return nil, fmt.Errorf("missing type of the parameter %s of the script %s", parameterName, name)
+				}
+
+				script.Parameters = append(script.Parameters, config.ScriptParameter{
+					Name: parameterName,
+					Type: parameterType,
+				})
+			}
+		}
+
+		if script.Location == "" {
+			return nil, fmt.Errorf("missing source of the script %s", name)
+		}
+
+		scripts = append(scripts, script)
+	}
+
+	return scripts, nil
+}
+
+// transformScriptsToJSON transforms config structure to json structures for saving.
+func transformScriptsToJSON(scripts config.Scripts) jsonScripts {
+	jsonScripts := jsonScripts{}
+
+	for _, s := range scripts {
+		if len(s.Parameters) == 0 {
+			jsonScripts[s.Name] = jsonTransaction{
+				Simple: s.Location,
+			}
+			continue
+		}
+
+		parameters := make(map[string]string, len(s.Parameters))
+		for _, parameter := range s.Parameters {
+			parameters[parameter.Name] = parameter.Type
+		}
+
+		jsonScripts[s.Name] = jsonTransaction{
+			Advanced: jsonTransactionAdvanced{
+				Source:     s.Location,
+				Parameters: parameters,
+			},
+		}
+	}
+
+	return jsonScripts
+}
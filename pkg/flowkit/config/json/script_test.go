@@ -0,0 +1,79 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+func Test_ConfigScripts(t *testing.T) {
+	b := []byte(`{
+		"get-balance": {
+			"source": "./scripts/balance.cdc",
+			"parameters": {
+				"addr": "Address"
+			}
+		},
+		"get-supply": "./scripts/supply.cdc"
+	}`)
+
+	var jsonScripts jsonScripts
+	err := json.Unmarshal(b, &jsonScripts)
+	require.NoError(t, err)
+
+	scripts, err := jsonScripts.transformToConfig()
+	require.NoError(t, err)
+	require.Len(t, scripts, 2)
+
+	balance, err := scripts.ByName("get-balance")
+	require.NoError(t, err)
+	assert.Equal(t, "./scripts/balance.cdc", balance.Location)
+	assert.Equal(t, []config.ScriptParameter{{Name: "addr", Type: "Address"}}, balance.Parameters)
+
+	supply, err := scripts.ByName("get-supply")
+	require.NoError(t, err)
+	assert.Equal(t, "./scripts/supply.cdc", supply.Location)
+	assert.Empty(t, supply.Parameters)
+
+	result, err := json.Marshal(transformScriptsToJSON(scripts))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(b), string(result))
+}
+
+func Test_ConfigScriptsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing source of the script get-balance":                     `{"get-balance": {"parameters": {"addr": "Address"}}}`,
+		"missing type of the parameter addr of the script get-balance": `{"get-balance": {"source": "./balance.cdc", "parameters": {"addr": ""}}}`,
+	}
+
+	for message, b := range tests {
+		var jsonScripts jsonScripts
+		err := json.Unmarshal([]byte(b), &jsonScripts)
+		require.NoError(t, err)
+
+		_, err = jsonScripts.transformToConfig()
+		assert.EqualError(t, err, message)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+type jsonSettings struct {
+	Network string `json:"network,omitempty"`
+	Format  string `json:"format,omitempty"`
+	Filter  string `json:"filter,omitempty"`
+}
+
+// transformToConfig transforms json structures to config structure.
+func (j *jsonSettings) transformToConfig() config.Settings {
+	if j == nil {
+		return config.Settings{}
+	}
+
+	return config.Settings{
+		Network: j.Network,
+		Format:  j.Format,
+		Filter:  j.Filter,
+	}
+}
+
+// transformToJSON transforms config structure to json structures for saving.
+func transformSettingsToJSON(settings config.Settings) *jsonSettings {
+	if settings.IsEmpty() {
+		return nil
+	}
+
+	return &jsonSettings{
+		Network: settings.Network,
+		Format:  settings.Format,
+		Filter:  settings.Filter,
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+type jsonTransactions map[string]jsonTransaction
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonTransactions) transformToConfig() (config.Transactions, error) {
+	transactions := make(config.Transactions, 0)
+
+	for name, t := range j {
+		transaction := config.Transaction{
+			Name:     name,
+			Location: t.Simple,
+		}
+
+		if t.Simple == "" {
+			transaction.Location = t.Advanced.Source
+
+			// parameters are sorted by name so the configuration is deterministic
+			names := make([]string, 0, len(t.Advanced.Parameters))
+			for parameterName := range t.Advanced.Parameters {
+				names = append(names, parameterName)
+			}
+			sort.Strings(names)
+
+			for _, parameterName := range names {
+				parameterType := t.Advanced.Parameters[parameterName]
+				if parameterType == "" {
+					return nil, fmt.Errorf("missing type of the parameter %s of the transaction %s", parameterName, name)
+				}
+
+				transaction.Parameters = append(transaction.Parameters, config.TransactionParameter{
+					Name: parameterName,
+					Type: parameterType,
+				})
+			}
+		}
+
+		if transaction.Location == "" {
+			return nil, fmt.Errorf("missing source of the transaction %s", name)
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, nil
+}
+
+// transformToJSON transforms config structure to json structures for saving.
+func transformTransactionsToJSON(transactions config.Transactions) jsonTransactions {
+	jsonTransactions := jsonTransactions{}
+
+	for _, t := range transactions {
+		if len(t.Parameters) == 0 {
+			jsonTransactions[t.Name] = jsonTransaction{
+				Simple: t.Location,
+			}
+			continue
+		}
+
+		parameters := make(map[string]string, len(t.Parameters))
+		for _, parameter := range t.Parameters {
+			parameters[parameter.Name] = parameter.Type
+		}
+
+		jsonTransactions[t.Name] = jsonTransaction{
+			Advanced: jsonTransactionAdvanced{
+				Source:     t.Location,
+				Parameters: parameters,
+			},
+		}
+	}
+
+	return jsonTransactions
+}
+
+// jsonTransactionAdvanced for json parsing advanced config.
+type jsonTransactionAdvanced struct {
+	Source     string            `json:"source"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// jsonTransaction structure for json parsing.
+type jsonTransaction struct {
+	Simple   string
+	Advanced jsonTransactionAdvanced
+}
+
+func (j *jsonTransaction) UnmarshalJSON(b []byte) error {
+	var source string
+	var advancedFormat jsonTransactionAdvanced
+
+	// simple
+	err := json.Unmarshal(b, &source)
+	if err == nil {
+		j.Simple = source
+		return nil
+	}
+
+	// advanced
+	err = json.Unmarshal(b, &advancedFormat)
+	if err != nil {
+		return err
+	}
+
+	j.Advanced = advancedFormat
+	return nil
+}
+
+func (j jsonTransaction) MarshalJSON() ([]byte, error) {
+	if j.Simple != "" {
+		return json.Marshal(j.Simple)
+	}
+
+	return json.Marshal(j.Advanced)
+}
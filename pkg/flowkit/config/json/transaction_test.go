@@ -0,0 +1,83 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+func Test_ConfigTransactions(t *testing.T) {
+	b := []byte(`{
+		"transfer-flow": {
+			"source": "./transactions/transfer.cdc",
+			"parameters": {
+				"to": "Address",
+				"amount": "UFix64"
+			}
+		},
+		"setup": "./transactions/setup.cdc"
+	}`)
+
+	var jsonTransactions jsonTransactions
+	err := json.Unmarshal(b, &jsonTransactions)
+	require.NoError(t, err)
+
+	transactions, err := jsonTransactions.transformToConfig()
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+
+	transfer, err := transactions.ByName("transfer-flow")
+	require.NoError(t, err)
+	assert.Equal(t, "./transactions/transfer.cdc", transfer.Location)
+	assert.Equal(t, []config.TransactionParameter{
+		{Name: "amount", Type: "UFix64"},
+		{Name: "to", Type: "Address"},
+	}, transfer.Parameters)
+
+	setup, err := transactions.ByName("setup")
+	require.NoError(t, err)
+	assert.Equal(t, "./transactions/setup.cdc", setup.Location)
+	assert.Empty(t, setup.Parameters)
+
+	result, err := json.Marshal(transformTransactionsToJSON(transactions))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(b), string(result))
+}
+
+func Test_ConfigTransactionsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing source of the transaction transfer":                   `{"transfer": {"parameters": {"to": "Address"}}}`,
+		"missing type of the parameter to of the transaction transfer": `{"transfer": {"source": "./transfer.cdc", "parameters": {"to": ""}}}`,
+	}
+
+	for message, b := range tests {
+		var jsonTransactions jsonTransactions
+		err := json.Unmarshal([]byte(b), &jsonTransactions)
+		require.NoError(t, err)
+
+		_, err = jsonTransactions.transformToConfig()
+		assert.EqualError(t, err, message)
+	}
+}
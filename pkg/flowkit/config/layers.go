@@ -0,0 +1,273 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/slices"
+)
+
+// DefaultLocalPath is the path of the local configuration merged over the default configuration.
+//
+// The local configuration is meant to be kept out of the source control and contain personal
+// accounts and overrides of the shared configuration.
+const DefaultLocalPath = "flow.local.json"
+
+// layer is a configuration file merged into the loaded configuration.
+type layer struct {
+	path string
+	conf *Config
+}
+
+// Layers returns the paths of the configuration files merged into the loaded configuration, in the merge order.
+func (l *Loader) Layers() []string {
+	paths := make([]string, len(l.layers))
+	for i, layer := range l.layers {
+		paths[i] = layer.path
+	}
+
+	return paths
+}
+
+// IsLayer checks if the path is one of the merged configuration files.
+func (l *Loader) IsLayer(path string) bool {
+	return slices.Contains(l.Layers(), path)
+}
+
+// merge the layers into a new configuration, items of later layers override items of earlier layers.
+//
+// Accounts, networks, emulators, contracts, transactions and scripts are merged by name (contracts also by network),
+// and deployments by network and account.
+func (l *Loader) merge() *Config {
+	// the merged items are empty rather than nil when no layer defines them, like the items of a parsed file
+	merged := &Config{
+		Emulators:   Emulators{},
+		Contracts:   Contracts{},
+		Networks:    Networks{},
+		Accounts:    Accounts{},
+		Deployments: Deployments{},
+	}
+	for _, layer := range l.layers {
+		l.composeConfig(merged, layer.conf)
+	}
+
+	// deployment contracts are copied so changing the merged configuration doesn't change the layers
+	for i, deployment := range merged.Deployments {
+		merged.Deployments[i].Contracts = slices.Clone(deployment.Contracts)
+	}
+
+	return merged
+}
+
+// SaveLayers saves the configuration to the merged configuration files.
+//
+// Each item is saved to the last layer defining it, so the items overridden by a layer are only changed in
+// that layer and the other layers keep their values. New items are saved to the first project layer, after
+// the global user configuration, and items removed from the configuration are removed from all the layers.
+func (l *Loader) SaveLayers(conf *Config) error {
+	for i, layer := range l.layers {
+		layerConf := &Config{
+			Emulators:    layerItems(l.layers, i, l.primary, conf.Emulators, func(c *Config) []Emulator { return c.Emulators }, emulatorKey),
+			Contracts:    layerItems(l.layers, i, l.primary, conf.Contracts, func(c *Config) []Contract { return c.Contracts }, contractKey),
+			Networks:     layerItems(l.layers, i, l.primary, conf.Networks, func(c *Config) []Network { return c.Networks }, networkKey),
+			Accounts:     layerItems(l.layers, i, l.primary, conf.Accounts, func(c *Config) []Account { return c.Accounts }, accountKey),
+			Deployments:  layerItems(l.layers, i, l.primary, conf.Deployments, func(c *Config) []Deployment { return c.Deployments }, deploymentKey),
+			Transactions: layerItems(l.layers, i, l.primary, conf.Transactions, func(c *Config) []Transaction { return c.Transactions }, transactionKey),
+			Scripts:      layerItems(l.layers, i, l.primary, conf.Scripts, func(c *Config) []Script { return c.Scripts }, scriptKey),
+			Settings:     layerSettings(l.layers, i, l.primary, conf.Settings),
+		}
+
+		err := l.Save(layerConf, layer.path)
+		if err != nil {
+			return fmt.Errorf("failed to save configuration layer %s: %w", layer.path, err)
+		}
+		l.layers[i].conf = layerConf
+	}
+
+	return nil
+}
+
+// WithoutGlobal returns the configuration without the items only defined in the global user configuration.
+//
+// It is used when the configuration is saved to a file which is not one of the merged layers, so the
+// personal items of the global user configuration are not copied to the project configuration.
+func (l *Loader) WithoutGlobal(conf *Config) *Config {
+	if l.primary == 0 {
+		return conf
+	}
+
+	settings := conf.Settings
+	for _, key := range SettingKeys {
+		origin := settingOrigin(l.layers, key)
+		if origin == -1 || origin >= l.primary {
+			continue
+		}
+
+		// global settings are kept only if they were changed
+		value, _ := settings.Get(key)
+		if existing, _ := l.layers[origin].conf.Settings.Get(key); existing == value {
+			_ = settings.Set(key, "")
+		}
+	}
+
+	return &Config{
+		Emulators:    projectItems(l.layers, l.primary, conf.Emulators, func(c *Config) []Emulator { return c.Emulators }, emulatorKey),
+		Contracts:    projectItems(l.layers, l.primary, conf.Contracts, func(c *Config) []Contract { return c.Contracts }, contractKey),
+		Networks:     projectItems(l.layers, l.primary, conf.Networks, func(c *Config) []Network { return c.Networks }, networkKey),
+		Accounts:     projectItems(l.layers, l.primary, conf.Accounts, func(c *Config) []Account { return c.Accounts }, accountKey),
+		Deployments:  projectItems(l.layers, l.primary, conf.Deployments, func(c *Config) []Deployment { return c.Deployments }, deploymentKey),
+		Transactions: projectItems(l.layers, l.primary, conf.Transactions, func(c *Config) []Transaction { return c.Transactions }, transactionKey),
+		Scripts:      projectItems(l.layers, l.primary, conf.Scripts, func(c *Config) []Script { return c.Scripts }, scriptKey),
+		Settings:     settings,
+	}
+}
+
+// layerItems returns the items of the configuration saved to the layer at the index.
+func layerItems[T any](
+	layers []layer,
+	index int,
+	primary int,
+	items []T,
+	layerConfItems func(*Config) []T,
+	key func(T) string,
+) []T {
+	result := make([]T, 0)
+
+	for _, item := range items {
+		origin := primary // new items are saved to the primary layer
+		var existing *T
+		for i, layer := range layers {
+			for j, layerItem := range layerConfItems(layer.conf) {
+				if key(layerItem) != key(item) {
+					continue
+				}
+				origin = i
+				if i == index {
+					existing = &layerConfItems(layer.conf)[j]
+				}
+			}
+		}
+
+		if origin == index {
+			result = append(result, item)
+		} else if existing != nil { // item is overridden by a later layer so the layer keeps its value
+			result = append(result, *existing)
+		}
+	}
+
+	return result
+}
+
+// layerSettings returns the settings of the configuration saved to the layer at the index.
+//
+// Each setting is saved the same way as the items, the value is saved to the last layer defining it,
+// and a setting which is cleared is removed from all the layers. A global setting changed in the project
+// is an exception, the global value is kept and the new value overrides it in the primary layer.
+func layerSettings(layers []layer, index int, primary int, settings Settings) Settings {
+	var result Settings
+
+	for _, key := range SettingKeys {
+		value, _ := settings.Get(key)
+		if value == "" {
+			continue
+		}
+
+		origin := settingOrigin(layers, key)
+		if origin == -1 {
+			origin = primary // new settings are saved to the primary layer
+		} else if existing, _ := layers[origin].conf.Settings.Get(key); origin < primary && existing != value {
+			origin = primary
+		}
+
+		if origin == index {
+			_ = result.Set(key, value)
+		} else { // setting is overridden by a later layer so the layer keeps its value
+			existing, _ := layers[index].conf.Settings.Get(key)
+			_ = result.Set(key, existing)
+		}
+	}
+
+	return result
+}
+
+// settingOrigin returns the index of the last layer defining the setting, or -1 if no layer defines it.
+func settingOrigin(layers []layer, key string) int {
+	origin := -1
+	for i, layer := range layers {
+		if value, _ := layer.conf.Settings.Get(key); value != "" {
+			origin = i
+		}
+	}
+
+	return origin
+}
+
+// projectItems returns the items which are not only defined in the global layers before the primary layer.
+func projectItems[T any](
+	layers []layer,
+	primary int,
+	items []T,
+	layerConfItems func(*Config) []T,
+	key func(T) string,
+) []T {
+	origins := make(map[string]int)
+	for i, layer := range layers {
+		for _, item := range layerConfItems(layer.conf) {
+			origins[key(item)] = i
+		}
+	}
+
+	result := make([]T, 0)
+	for _, item := range items {
+		if origin, ok := origins[key(item)]; !ok || origin >= primary {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+func emulatorKey(e Emulator) string {
+	return e.Name
+}
+
+func contractKey(c Contract) string {
+	return fmt.Sprintf("%s@%s", c.Name, c.Network)
+}
+
+func networkKey(n Network) string {
+	return n.Name
+}
+
+func accountKey(a Account) string {
+	return a.Name
+}
+
+func deploymentKey(d Deployment) string {
+	return fmt.Sprintf("%s/%s", d.Network, d.Account)
+}
+
+func transactionKey(t Transaction) string {
+	return t.Name
+}
+
+func scriptKey(s Script) string {
+	return s.Name
+}
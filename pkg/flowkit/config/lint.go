@@ -0,0 +1,206 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Problem is an issue found in the configuration.
+type Problem struct {
+	File    string // configuration file containing the problem, empty if found in the merged configuration
+	Path    string // JSON pointer to the configuration value
+	Message string
+}
+
+func (p Problem) String() string {
+	location := p.Path
+	if p.File != "" && p.Path != "" {
+		location = fmt.Sprintf("%s#%s", p.File, p.Path)
+	} else if p.File != "" {
+		location = p.File
+	}
+	if location == "" {
+		return p.Message
+	}
+
+	return fmt.Sprintf("%s: %s", location, p.Message)
+}
+
+// ProblemsError is returned when problems are found in the configuration.
+type ProblemsError struct {
+	Problems []Problem
+}
+
+func (e *ProblemsError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		problems[i] = problem.String()
+	}
+
+	return fmt.Sprintf("invalid configuration:\n\t%s", strings.Join(problems, "\n\t"))
+}
+
+// setFile sets the file of the problems found in a single configuration file.
+func (e *ProblemsError) setFile(file string) {
+	for i := range e.Problems {
+		e.Problems[i].File = file
+	}
+}
+
+// Pointer returns the JSON pointer to the configuration value with the path tokens.
+func Pointer(tokens ...interface{}) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		switch t := token.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(t))
+		default:
+			escaped := strings.ReplaceAll(fmt.Sprint(t), "~", "~0")
+			b.WriteString(strings.ReplaceAll(escaped, "/", "~1"))
+		}
+	}
+
+	return b.String()
+}
+
+// Lint checks the references between the configuration items and the addresses used on networks.
+//
+// Unlike Validate it doesn't stop at the first problem, all the problems found are returned.
+func (c *Config) Lint() []Problem {
+	return c.lint(true)
+}
+
+// lint returns the problems found in the configuration, the addresses are only checked if requested.
+func (c *Config) lint(checkAddresses bool) []Problem {
+	problems := make([]Problem, 0)
+	report := func(path string, format string, args ...interface{}) {
+		problems = append(problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, em := range c.Emulators {
+		if _, err := c.Accounts.ByName(em.ServiceAccount); err != nil {
+			report(Pointer("emulators", em.Name, "serviceAccount"), "service account %s does not exist", em.ServiceAccount)
+		}
+	}
+
+	for _, account := range c.Accounts {
+		for _, n := range account.Networks {
+			network, err := c.Networks.ByName(n.Network)
+			if err != nil {
+				report(Pointer("accounts", account.Name, "networks", n.Network), "network %s does not exist", n.Network)
+				continue
+			}
+			if chain, ok := network.ChainID(); checkAddresses && ok && !n.Address.IsValid(chain) {
+				report(
+					Pointer("accounts", account.Name, "networks", n.Network),
+					"account %s address %s is not valid on network %s, expected an address of chain %s",
+					account.Name,
+					n.Address,
+					n.Network,
+					chain,
+				)
+			}
+		}
+	}
+
+	for _, con := range c.Contracts {
+		if con.Network == "" || con.Alias == "" {
+			continue
+		}
+
+		network, err := c.Networks.ByName(con.Network)
+		if err != nil {
+			report(Pointer("contracts", con.Name, "aliases", con.Network), "network %s does not exist", con.Network)
+			continue
+		}
+
+		address, err := StringToAddress(con.Alias)
+		if err != nil {
+			report(Pointer("contracts", con.Name, "aliases", con.Network), "invalid alias address %s", con.Alias)
+			continue
+		}
+		if chain, ok := network.ChainID(); checkAddresses && ok && !address.IsValid(chain) {
+			report(
+				Pointer("contracts", con.Name, "aliases", con.Network),
+				"alias address %s is not valid on network %s, expected an address of chain %s",
+				con.Alias,
+				con.Network,
+				chain,
+			)
+		}
+	}
+
+	for _, d := range c.Deployments {
+		network, err := c.Networks.ByName(d.Network)
+		if err != nil {
+			report(Pointer("deployments", d.Network), "network %s does not exist", d.Network)
+		}
+
+		account, err := c.Accounts.ByName(d.Account)
+		if err == nil {
+			account, err = account.ForNetwork(d.Network)
+			if err != nil {
+				report(Pointer("deployments", d.Network, d.Account), "account %s is not defined for network %s", d.Account, d.Network)
+			}
+		} else {
+			report(Pointer("deployments", d.Network, d.Account), "account %s does not exist", d.Account)
+		}
+
+		if err == nil && network != nil {
+			if chain, ok := network.ChainID(); checkAddresses && ok && !account.Address.IsValid(chain) {
+				report(
+					Pointer("deployments", d.Network, d.Account),
+					"account %s address %s is not valid on network %s, expected an address of chain %s",
+					d.Account,
+					account.Address,
+					d.Network,
+					chain,
+				)
+			}
+		}
+
+		for i, con := range d.Contracts {
+			if _, err := c.Contracts.ByName(con.Name); err != nil {
+				report(Pointer("deployments", d.Network, d.Account, i), "contract %s does not exist", con.Name)
+			}
+
+			hooks := map[string][]DeploymentHook{"pre": con.Pre, "post": con.Post}
+			for _, stage := range []string{"pre", "post"} {
+				for j, hook := range hooks[stage] {
+					if hook.Signer == "" {
+						continue
+					}
+					if _, err := c.Accounts.ByName(hook.Signer); err != nil {
+						report(
+							Pointer("deployments", d.Network, d.Account, i, stage, j, "signer"),
+							"signer account %s does not exist",
+							hook.Signer,
+						)
+					}
+				}
+			}
+		}
+	}
+
+	return problems
+}
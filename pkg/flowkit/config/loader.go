@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"golang.org/x/exp/slices"
 )
 
 // ErrDoesNotExist is error to be returned when config file does not exists.
@@ -44,6 +46,22 @@ type Parser interface {
 	SupportsFormat(string) bool
 }
 
+// Transcoder is implemented by parsers which can convert their format to JSON and back.
+//
+// The configuration is transcoded to JSON when it is loaded, so the processing of the
+// configuration values like interpolation applies to all the formats.
+type Transcoder interface {
+	ToJSON(raw []byte) ([]byte, error)
+	FromJSON(data []byte) ([]byte, error)
+}
+
+// Migrator is implemented by parsers which can convert the legacy configuration formats to the current format.
+type Migrator interface {
+	// Migrate returns the migrated configuration and the description of the changes,
+	// no changes are returned if the configuration is already in the current format.
+	Migrate(raw []byte) ([]byte, []string, error)
+}
+
 type ReaderWriter interface {
 	ReadFile(source string) ([]byte, error)
 	WriteFile(filename string, data []byte, perm os.FileMode) error
@@ -68,6 +86,11 @@ type Loader struct {
 	readerWriter     ReaderWriter
 	configParsers    Parsers
 	accountsFromFile map[string]string
+	interpolated     map[string]string // original values with placeholders by the interpolated values
+	layers           []layer           // configuration files merged into the loaded configuration
+	primary          int               // index of the layer new items are saved to, layers before it are global
+
+	skipAddressValidation bool // account addresses aren't checked against the chains of the networks
 }
 
 // NewLoader returns a new loader.
@@ -75,6 +98,7 @@ func NewLoader(readerWriter ReaderWriter) *Loader {
 	return &Loader{
 		readerWriter:     readerWriter,
 		accountsFromFile: map[string]string{},
+		interpolated:     map[string]string{},
 	}
 }
 
@@ -91,27 +115,153 @@ func (l *Loader) SetAccountFromFile(name string, location string) {
 	l.accountsFromFile[name] = location
 }
 
+// SetSkipAddressValidation sets whether checking the account addresses against the network chains is skipped.
+//
+// It is needed for custom networks which use the name of a known network but run on another chain.
+func (l *Loader) SetSkipAddressValidation(skip bool) {
+	l.skipAddressValidation = skip
+}
+
 // Save saves a configuration to a path with correct serializer.
 func (l *Loader) Save(conf *Config, path string) error {
+	data, err := l.Serialize(conf, path)
+	if err != nil {
+		return err
+	}
+
+	err = l.readerWriter.WriteFile(path, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Serialize serializes a configuration with the serializer for the path format.
+//
+// Interpolated values are replaced with the original placeholders, so the resolved secrets are not exposed.
+func (l *Loader) Serialize(conf *Config, path string) ([]byte, error) {
 	configFormat := l.configParsers.FindForFormat(
 		filepath.Ext(path),
 	)
 
 	if configFormat == nil {
-		return fmt.Errorf("parser not found for format")
+		return nil, fmt.Errorf("parser not found for format")
 	}
 
 	data, err := configFormat.Serialize(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	transcoder, ok := configFormat.(Transcoder)
+	if !ok || len(l.interpolated) == 0 {
+		return restorePlaceholders(data, l.interpolated), nil
+	}
+
+	// placeholders are restored in the JSON values they were interpolated in
+	data, err = transcoder.ToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return transcoder.FromJSON(restorePlaceholders(data, l.interpolated))
+}
+
+// Convert converts the configuration file to the format of the destination file.
+//
+// The configuration is transcoded without being loaded, so the placeholders and the
+// accounts loaded from separate files are preserved.
+func (l *Loader) Convert(source string, destination string) error {
+	from, err := l.transcoder(source)
 	if err != nil {
 		return err
 	}
 
-	err = l.readerWriter.WriteFile(path, data, 0644)
+	to, err := l.transcoder(destination)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	raw, err := l.loadFile(source)
+	if err != nil {
+		return err
+	}
+
+	data, err := from.ToJSON(raw)
+	if err != nil {
+		return fmt.Errorf("failed to convert configuration %s: %w", source, err)
+	}
+
+	data, err = to.FromJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert configuration %s: %w", source, err)
+	}
+
+	return l.readerWriter.WriteFile(destination, data, 0644)
+}
+
+// Migrate converts the configuration file from a legacy format to the current format and returns the changes.
+//
+// The original configuration is saved to the backup path before it is overwritten,
+// nothing is written if the configuration is already in the current format.
+func (l *Loader) Migrate(path string, backup string) ([]string, error) {
+	migrator, ok := l.configParsers.FindForFormat(filepath.Ext(path)).(Migrator)
+	if !ok {
+		return nil, fmt.Errorf("migration is not supported for config: %s", path)
+	}
+
+	raw, err := l.loadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, changes, err := migrator.Migrate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate configuration %s: %w", path, err)
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	err = l.readerWriter.WriteFile(backup, raw, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save configuration backup %s: %w", backup, err)
+	}
+
+	err = l.readerWriter.WriteFile(path, data, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// transcoder returns the transcoder for the format of the configuration path.
+func (l *Loader) transcoder(path string) (Transcoder, error) {
+	transcoder, ok := l.configParsers.FindForFormat(filepath.Ext(path)).(Transcoder)
+	if !ok {
+		return nil, fmt.Errorf("parser not found for config: %s", path)
+	}
+
+	return transcoder, nil
+}
+
+// DefaultPath returns the path of the project configuration in one of the supported formats.
+//
+// ErrDoesNotExist is returned if the project configuration doesn't exist in any of the formats.
+func (l *Loader) DefaultPath() (string, error) {
+	for _, path := range DefaultFormatPaths {
+		_, err := l.loadFile(path)
+		if err == nil {
+			return path, nil
+		}
+		if !errors.Is(err, ErrDoesNotExist) {
+			return "", err
+		}
+	}
+
+	return "", ErrDoesNotExist
 }
 
 func (l *Loader) loadConfig(confPath string) (*Config, error) {
@@ -121,60 +271,174 @@ func (l *Loader) loadConfig(confPath string) (*Config, error) {
 		return nil, err
 	}
 
-	preProcessed := l.preprocess(raw)
 	configParser := l.configParsers.FindForFormat(filepath.Ext(confPath))
 	if configParser == nil {
 		return nil, fmt.Errorf("parser not found for config: %s", confPath)
 	}
 
-	return configParser.Deserialize(preProcessed)
+	if transcoder, ok := configParser.(Transcoder); ok {
+		raw, err = transcoder.ToJSON(raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	raw, interpolated, err := interpolate(raw, l.readerWriter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to interpolate configuration %s: %w", confPath, err)
+	}
+	for value, original := range interpolated {
+		l.interpolated[value] = original
+	}
+
+	preProcessed := l.preprocess(raw)
+	conf, err := configParser.Deserialize(preProcessed)
+	var problemsErr *ProblemsError
+	if errors.As(err, &problemsErr) {
+		problemsErr.setFile(confPath)
+	}
+
+	return conf, err
 }
 
 // Load loads configuration from one or more file paths.
 //
 // If more than one path is specified, their contents are merged
-// together into on configuration object.
+// together into on configuration object, later files override earlier ones.
 func (l *Loader) Load(paths []string) (*Config, error) {
+	err := l.loadGlobalUserLayer(paths)
+	if err != nil {
+		return nil, err
+	}
+
 	// special case for default configs
 	// try to load local config and only if not found try to load global config
 	if IsDefaultPath(paths) {
-		conf, err := l.loadConfig(DefaultPath)
-		if err == nil { // if we could load it then process it
-			return l.postprocess(conf)
+		path, err := l.DefaultPath()
+		if err == nil {
+			err = l.loadLayer(path)
+		}
+		if err == nil { // if we could load it then merge the local overrides and process it
+			err = l.loadLayer(DefaultLocalPath)
+			if err != nil && !errors.Is(err, ErrDoesNotExist) {
+				return nil, err
+			}
+			return l.postprocess(l.merge())
 		}
 		if !errors.Is(err, ErrDoesNotExist) {
 			return nil, err
 		}
 
-		conf, err = l.loadConfig(GlobalPath())
+		err = l.loadLayer(GlobalPath())
 		if err != nil {
 			return nil, ErrDoesNotExist
 		} else {
-			return l.postprocess(conf)
+			return l.postprocess(l.merge())
 		}
 	}
 
-	var baseConf *Config
 	for _, confPath := range paths {
-		conf, err := l.loadConfig(confPath)
+		err := l.loadLayer(confPath)
 		if err != nil {
 			return nil, err
 		}
-		// if first conf just assign as baseConf
-		if baseConf == nil {
-			baseConf = conf
-			continue
-		}
-
-		l.composeConfig(baseConf, conf)
 	}
 
 	// if no config was loaded - neither local nor global return an error.
-	if baseConf == nil {
+	if len(l.layers) == l.primary {
 		return nil, ErrDoesNotExist
 	}
 
-	return l.postprocess(baseConf)
+	return l.postprocess(l.merge())
+}
+
+// Lint loads the configuration files and returns all the problems found in them.
+//
+// Unlike loading, linting doesn't stop at the first problem. If all the configuration files
+// could be parsed the merged configuration is returned as well, so it can be checked further.
+func (l *Loader) Lint(paths []string) (*Config, []Problem, error) {
+	l.layers = nil
+	l.primary = 0
+
+	optional := make(map[string]bool)
+	if IsDefaultPath(paths) {
+		path, err := l.DefaultPath()
+		if err != nil {
+			return nil, nil, err
+		}
+		paths = []string{path, DefaultLocalPath}
+		optional[DefaultLocalPath] = true
+	}
+
+	if userPath := GlobalUserPath(); userPath != "" && !slices.Contains(paths, userPath) {
+		paths = append([]string{userPath}, paths...)
+		optional[userPath] = true
+	}
+
+	problems := make([]Problem, 0)
+	for _, path := range paths {
+		err := l.loadLayer(path)
+		if errors.Is(err, ErrDoesNotExist) {
+			if optional[path] {
+				continue
+			}
+			return nil, nil, err
+		}
+
+		var problemsErr *ProblemsError
+		if errors.As(err, &problemsErr) {
+			problems = append(problems, problemsErr.Problems...)
+		} else if err != nil {
+			problems = append(problems, Problem{File: path, Message: err.Error()})
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, problems, nil
+	}
+
+	conf := l.merge()
+	err := l.composeAccountsFromFile(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conf, conf.lint(!l.skipAddressValidation), nil
+}
+
+// loadGlobalUserLayer resets the layers and loads the global user configuration as the first layer if it exists.
+//
+// The global user configuration is skipped if it is loaded explicitly as one of the paths.
+func (l *Loader) loadGlobalUserLayer(paths []string) error {
+	l.layers = nil
+	l.primary = 0
+
+	path := GlobalUserPath()
+	if path == "" || slices.Contains(paths, path) {
+		return nil
+	}
+
+	err := l.loadLayer(path)
+	if errors.Is(err, ErrDoesNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	l.primary = len(l.layers)
+	return nil
+}
+
+// loadLayer loads the configuration file and adds it to the merged layers.
+func (l *Loader) loadLayer(path string) error {
+	conf, err := l.loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	l.layers = append(l.layers, layer{path: path, conf: conf})
+	return nil
 }
 
 // preprocess does all manipulations to the raw configuration format happens here.
@@ -189,25 +453,52 @@ func (l *Loader) preprocess(raw []byte) []byte {
 
 // postprocess does all stateful changes to configuration structures here after it is parsed.
 func (l *Loader) postprocess(baseConf *Config) (*Config, error) {
+	err := l.composeAccountsFromFile(baseConf)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate as part of post processing
+	err = baseConf.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if !l.skipAddressValidation {
+		err = baseConf.ValidateAddresses()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return baseConf, nil
+}
+
+// composeAccountsFromFile adds the accounts loaded from separate files to the configuration.
+func (l *Loader) composeAccountsFromFile(baseConf *Config) error {
 	for name, path := range l.accountsFromFile {
 		raw, err := l.loadFile(path)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		configParser := l.configParsers.FindForFormat(filepath.Ext(path))
 		if configParser == nil {
-			return nil, fmt.Errorf("parser not found for config: %s", path)
+			return fmt.Errorf("parser not found for config: %s", path)
 		}
 
 		conf, err := configParser.Deserialize(raw)
+		var problemsErr *ProblemsError
+		if errors.As(err, &problemsErr) {
+			problemsErr.setFile(path)
+		}
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		account, err := conf.Accounts.ByName(name)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		// IMPORTANT: save the original filepath so that this account's
@@ -223,18 +514,15 @@ func (l *Loader) postprocess(baseConf *Config) (*Config, error) {
 		l.composeConfig(baseConf, accountConf)
 	}
 
-	// validate as part of post processing
-	err := baseConf.Validate()
-	if err != nil {
-		return nil, err
-	}
-
-	return baseConf, nil
+	return nil
 }
 
 // composeConfig merges multiple configuration files from right to left.
 func (l *Loader) composeConfig(baseConf *Config, conf *Config) {
 	// overwrite base config with the provided one
+	for _, emulator := range conf.Emulators {
+		baseConf.Emulators.AddOrUpdate(emulator.Name, emulator)
+	}
 	for _, account := range conf.Accounts {
 		baseConf.Accounts.AddOrUpdate(account.Name, account)
 	}
@@ -247,6 +535,13 @@ func (l *Loader) composeConfig(baseConf *Config, conf *Config) {
 	for _, deployment := range conf.Deployments {
 		baseConf.Deployments.AddOrUpdate(deployment)
 	}
+	for _, transaction := range conf.Transactions {
+		baseConf.Transactions.AddOrUpdate(transaction.Name, transaction)
+	}
+	for _, script := range conf.Scripts {
+		baseConf.Scripts.AddOrUpdate(script.Name, script)
+	}
+	baseConf.Settings.merge(conf.Settings)
 }
 
 // loadFile simple file loader.
@@ -26,6 +26,7 @@ import (
 
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/config/json"
+	"github.com/onflow/flow-cli/pkg/flowkit/config/yaml"
 )
 
 var mockFS = afero.NewMemMapFs()
@@ -182,7 +183,10 @@ func Test_ConfigurationWrongFormat(t *testing.T) {
 	composer.AddConfigParser(json.NewParser())
 
 	conf, err := composer.Load(config.DefaultPaths())
-	assert.EqualError(t, err, "configuration syntax error: json: cannot unmarshal string into Go struct field jsonConfig.deployments of type []json.deployment")
+	assert.EqualError(t, err, "invalid configuration:\n"+
+		"\tflow.json#/deployments/emulator-account/address: expected array but found string\n"+
+		"\tflow.json#/deployments/emulator-account/key: expected array but found string",
+	)
 	assert.Nil(t, conf)
 }
 
@@ -235,7 +239,7 @@ func Test_ComposeCrossReference(t *testing.T) {
 	b := []byte(`{
 		"accounts": {
 			"test": {
-				"address":"f1d6e0586b0a20c7",
+				"address":"9a0766d93b6608b7",
 				"key":"3335dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
 			}
 		},
@@ -461,3 +465,370 @@ func Test_JSONEnv(t *testing.T) {
 	assert.Equal(t, 1, len(conf.Accounts))
 	assert.Equal(t, "0x21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7", conf.Accounts[0].Key.PrivateKey.String())
 }
+
+func Test_Interpolation(t *testing.T) {
+	b := []byte(`{
+		"networks": {
+			"testnet": "${env:FLOW_TEST_HOST}"
+		},
+		"accounts": {
+			"service": {
+				"address": "${file:keys/service.address}",
+				"key": "${env:FLOW_TEST_KEY}"
+			}
+		}
+	}`)
+
+	t.Setenv("FLOW_TEST_HOST", "access.devnet.nodes.onflow.org:9000")
+	t.Setenv("FLOW_TEST_KEY", "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7")
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := fs.WriteFile("flow.json", b, 0644)
+	assert.NoError(t, err)
+	err = fs.WriteFile("keys/service.address", []byte("f8d6e0586b0a20c7\n"), 0644)
+	assert.NoError(t, err)
+
+	loader := config.NewLoader(fs)
+	loader.AddConfigParser(json.NewParser())
+	conf, err := loader.Load([]string{"flow.json"})
+	assert.NoError(t, err)
+
+	network, err := conf.Networks.ByName("testnet")
+	assert.NoError(t, err)
+	assert.Equal(t, "access.devnet.nodes.onflow.org:9000", network.Host)
+
+	account, err := conf.Accounts.ByName("service")
+	assert.NoError(t, err)
+	assert.Equal(t, "f8d6e0586b0a20c7", account.Address.String())
+	assert.Equal(t,
+		"0x21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7",
+		account.Key.PrivateKey.String(),
+	)
+
+	// placeholders are saved instead of the resolved values
+	err = loader.Save(conf, "saved.json")
+	assert.NoError(t, err)
+	saved, err := fs.ReadFile("saved.json")
+	assert.NoError(t, err)
+	assert.Contains(t, string(saved), "${env:FLOW_TEST_KEY}")
+	assert.Contains(t, string(saved), "${file:keys/service.address}")
+	assert.Contains(t, string(saved), "${env:FLOW_TEST_HOST}")
+	assert.NotContains(t, string(saved), "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7")
+
+	err = fs.WriteFile("missing.json", []byte(`{ "networks": { "testnet": "${env:FLOW_TEST_MISSING}" } }`), 0644)
+	assert.NoError(t, err)
+
+	_, err = loader.Load([]string{"missing.json"})
+	assert.EqualError(t, err, "failed to interpolate configuration missing.json: environment variable FLOW_TEST_MISSING used in configuration value ${env:FLOW_TEST_MISSING} is not set")
+}
+
+func Test_LoadLayers(t *testing.T) {
+	base := []byte(`{
+		"networks": {
+			"emulator": "127.0.0.1:3569",
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		},
+		"accounts": {
+			"alice": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		}
+	}`)
+
+	local := []byte(`{
+		"accounts": {
+			"alice": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			},
+			"bob": {
+				"address": "179b6b1cb6755e31",
+				"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			}
+		}
+	}`)
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := fs.WriteFile(config.DefaultPath, base, 0644)
+	assert.NoError(t, err)
+	err = fs.WriteFile(config.DefaultLocalPath, local, 0644)
+	assert.NoError(t, err)
+
+	loader := config.NewLoader(fs)
+	loader.AddConfigParser(json.NewParser())
+	conf, err := loader.Load(config.DefaultPaths())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{config.DefaultPath, config.DefaultLocalPath}, loader.Layers())
+
+	// local accounts override accounts by name
+	assert.Len(t, conf.Accounts, 2)
+	alice, err := conf.Accounts.ByName("alice")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"0xdd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47",
+		alice.Key.PrivateKey.String(),
+	)
+	assert.Len(t, conf.Networks, 2)
+
+	// changes are saved to the layer defining the item, new items to the first layer
+	testnet, _ := conf.Networks.ByName("testnet")
+	testnet.Host = "access.testnet.nodes.onflow.org:9000"
+	conf.Networks.AddOrUpdate(testnet.Name, *testnet)
+	conf.Networks.AddOrUpdate("mainnet", config.DefaultMainnetNetwork())
+	conf.Accounts.Remove("bob")
+
+	err = loader.SaveLayers(conf)
+	assert.NoError(t, err)
+
+	saved, err := fs.ReadFile(config.DefaultPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(saved), "access.testnet.nodes.onflow.org:9000")
+	assert.Contains(t, string(saved), "mainnet")
+	assert.Contains(t, string(saved), "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7")
+
+	savedLocal, err := fs.ReadFile(config.DefaultLocalPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(savedLocal), "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+	assert.NotContains(t, string(savedLocal), "bob")
+	assert.NotContains(t, string(savedLocal), "testnet")
+}
+
+func Test_LoaderLint(t *testing.T) {
+	base := []byte(`{
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"accounts": {
+			"alice": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"deployments": {
+			"emulator": {
+				"bob": []
+			}
+		}
+	}`)
+
+	local := []byte(`{
+		"acounts": {}
+	}`)
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := fs.WriteFile(config.DefaultPath, base, 0644)
+	assert.NoError(t, err)
+
+	loader := config.NewLoader(fs)
+	loader.AddConfigParser(json.NewParser())
+
+	// problems which would fail loading are all reported
+	conf, problems, err := loader.Lint(config.DefaultPaths())
+	assert.NoError(t, err)
+	assert.NotNil(t, conf)
+	assert.Equal(t, []config.Problem{{
+		Path:    "/deployments/emulator/bob",
+		Message: "account bob does not exist",
+	}}, problems)
+
+	err = fs.WriteFile(config.DefaultLocalPath, local, 0644)
+	assert.NoError(t, err)
+
+	conf, problems, err = loader.Lint(config.DefaultPaths())
+	assert.NoError(t, err)
+	assert.Nil(t, conf)
+	assert.Equal(t, []config.Problem{{
+		File:    config.DefaultLocalPath,
+		Path:    "/acounts",
+		Message: "unknown property acounts, did you mean accounts?",
+	}}, problems)
+}
+
+func Test_AddressValidation(t *testing.T) {
+	b := []byte(`{
+		"networks": {
+			"testnet": "127.0.0.1:3570"
+		},
+		"contracts": { "NFT": "./NFT.cdc" },
+		"accounts": {
+			"alice": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"deployments": {
+			"testnet": {
+				"alice": ["NFT"]
+			}
+		}
+	}`)
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := fs.WriteFile("flow.json", b, 0644)
+	assert.NoError(t, err)
+
+	loader := config.NewLoader(fs)
+	loader.AddConfigParser(json.NewParser())
+
+	conf, err := loader.Load([]string{"flow.json"})
+	assert.Nil(t, conf)
+	assert.EqualError(t, err, "deployment on network testnet is invalid: account alice address f8d6e0586b0a20c7 is not valid on network testnet, expected an address of chain flow-testnet")
+
+	_, problems, err := loader.Lint([]string{"flow.json"})
+	assert.NoError(t, err)
+	assert.Equal(t, []config.Problem{{
+		Path:    "/deployments/testnet/alice",
+		Message: "account alice address f8d6e0586b0a20c7 is not valid on network testnet, expected an address of chain flow-testnet",
+	}}, problems)
+
+	// custom networks named like the known networks skip the validation
+	loader.SetSkipAddressValidation(true)
+
+	conf, err = loader.Load([]string{"flow.json"})
+	assert.NoError(t, err)
+	assert.Len(t, conf.Deployments, 1)
+
+	_, problems, err = loader.Lint([]string{"flow.json"})
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func Test_LoadYAML(t *testing.T) {
+	b := []byte(`# emulator configuration
+networks:
+  emulator: 127.0.0.1:3569
+accounts:
+  emulator-account:
+    address: f8d6e0586b0a20c7
+    key: ${env:FLOW_TEST_KEY}
+`)
+
+	t.Setenv("FLOW_TEST_KEY", "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7")
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := fs.WriteFile("flow.yaml", b, 0644)
+	assert.NoError(t, err)
+
+	loader := config.NewLoader(fs)
+	loader.AddConfigParser(json.NewParser())
+	loader.AddConfigParser(yaml.NewParser())
+
+	// the default configuration is found in any of the supported formats
+	path, err := loader.DefaultPath()
+	assert.NoError(t, err)
+	assert.Equal(t, "flow.yaml", path)
+
+	conf, err := loader.Load(config.DefaultPaths())
+	assert.NoError(t, err)
+
+	account, err := conf.Accounts.ByName("emulator-account")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"0x21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7",
+		account.Key.PrivateKey.String(),
+	)
+
+	// placeholders are restored in the saved YAML configuration
+	err = loader.Save(conf, "flow.yaml")
+	assert.NoError(t, err)
+	saved, err := fs.ReadFile("flow.yaml")
+	assert.NoError(t, err)
+	assert.Contains(t, string(saved), "key: ${env:FLOW_TEST_KEY}")
+
+	// the configuration is converted without resolving the placeholders
+	err = loader.Convert("flow.yaml", "flow.json")
+	assert.NoError(t, err)
+	converted, err := fs.ReadFile("flow.json")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "${env:FLOW_TEST_KEY}"
+			}
+		}
+	}`, string(converted))
+
+	jsonConf, err := loader.Load([]string{"flow.json"})
+	assert.NoError(t, err)
+	jsonAccount, err := jsonConf.Accounts.ByName("emulator-account")
+	assert.NoError(t, err)
+	assert.Equal(t, account.Key.PrivateKey.String(), jsonAccount.Key.PrivateKey.String())
+}
+
+func Test_LoadGlobalUser(t *testing.T) {
+	global := []byte(`{
+		"settings": {
+			"network": "testnet",
+			"format": "json"
+		},
+		"accounts": {
+			"personal": {
+				"address": "179b6b1cb6755e31",
+				"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			}
+		}
+	}`)
+
+	project := []byte(`{
+		"settings": {
+			"format": "inline"
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		}
+	}`)
+
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	assert.Equal(t, "/xdg/flow/config.json", config.GlobalUserPath())
+
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := fs.WriteFile(config.GlobalUserPath(), global, 0644)
+	assert.NoError(t, err)
+
+	loader := config.NewLoader(fs)
+	loader.AddConfigParser(json.NewParser())
+
+	// the global user configuration alone is not a project configuration
+	_, err = loader.Load(config.DefaultPaths())
+	assert.ErrorIs(t, err, config.ErrDoesNotExist)
+
+	err = fs.WriteFile(config.DefaultPath, project, 0644)
+	assert.NoError(t, err)
+
+	// the project configuration is merged over the global user configuration
+	conf, err := loader.Load(config.DefaultPaths())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{config.GlobalUserPath(), config.DefaultPath}, loader.Layers())
+	assert.Equal(t, config.Settings{Network: "testnet", Format: "inline"}, conf.Settings)
+
+	_, err = conf.Accounts.ByName("personal")
+	assert.NoError(t, err)
+
+	// new items and changed global settings are saved to the project configuration
+	conf.Networks.AddOrUpdate("mainnet", config.DefaultMainnetNetwork())
+	conf.Settings.Network = "mainnet"
+
+	err = loader.SaveLayers(conf)
+	assert.NoError(t, err)
+
+	saved, err := fs.ReadFile(config.DefaultPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(saved), "access.mainnet.nodes.onflow.org:9000")
+	assert.Contains(t, string(saved), `"network": "mainnet"`)
+	assert.NotContains(t, string(saved), "personal")
+
+	savedGlobal, err := fs.ReadFile(config.GlobalUserPath())
+	assert.NoError(t, err)
+	assert.Contains(t, string(savedGlobal), `"network": "testnet"`)
+	assert.Contains(t, string(savedGlobal), "personal")
+	assert.NotContains(t, string(savedGlobal), "mainnet")
+
+	// the personal items are not copied when saving to another file
+	assert.Len(t, loader.WithoutGlobal(conf).Accounts, 0)
+	assert.Equal(t, config.Settings{Network: "mainnet", Format: "inline"}, loader.WithoutGlobal(conf).Settings)
+}
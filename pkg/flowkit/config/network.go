@@ -20,15 +20,43 @@ package config
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
 )
 
 type Networks []Network
 
 // Network defines the configuration for a Flow network.
+//
+// The request settings are optional, their zero values don't limit the requests sent to the network.
 type Network struct {
-	Name string
-	Host string
-	Key  string
+	Name                 string
+	Host                 string
+	Key                  string
+	Timeout              time.Duration // maximum duration of a request, zero for no timeout
+	MaxRetries           int           // number of times a failed request is retried
+	RetryBackoff         time.Duration // delay before the first retry, doubled for each following retry
+	MaxRequestsPerSecond float64       // maximum rate of the requests, zero for no limit
+}
+
+// HasRequestSettings returns true if any of the request settings are defined for the network.
+func (n Network) HasRequestSettings() bool {
+	return n.Timeout != 0 || n.MaxRetries != 0 || n.RetryBackoff != 0 || n.MaxRequestsPerSecond != 0
+}
+
+// ChainID returns the chain of a known network, the chain of custom networks can't be determined.
+func (n Network) ChainID() (flow.ChainID, bool) {
+	switch {
+	case n.Host == DefaultEmulatorNetwork().Host || n.Name == DefaultEmulatorNetwork().Name:
+		return flow.Emulator, true
+	case n.Host == DefaultTestnetNetwork().Host || n.Name == DefaultTestnetNetwork().Name:
+		return flow.Testnet, true
+	case n.Host == DefaultMainnetNetwork().Host || n.Name == DefaultMainnetNetwork().Name:
+		return flow.Mainnet, true
+	}
+
+	return "", false
 }
 
 // ByName get network by name.
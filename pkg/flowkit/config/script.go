@@ -0,0 +1,88 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import "fmt"
+
+// Script defines a saved script, which is run by its name with the values of its parameters.
+type Script struct {
+	Name     string
+	Location string
+	// Parameters declare the Cadence types of the parameters by their names,
+	// the declared types must be the types of the parameters of the main function.
+	Parameters []ScriptParameter
+}
+
+// ScriptParameter declares the Cadence type of a saved script parameter.
+type ScriptParameter struct {
+	Name string
+	Type string
+}
+
+type Scripts []Script
+
+// ParameterType returns the declared type of the parameter, or an empty string if it's not declared.
+func (s *Script) ParameterType(name string) string {
+	for _, parameter := range s.Parameters {
+		if parameter.Name == name {
+			return parameter.Type
+		}
+	}
+
+	return ""
+}
+
+// ByName get script by name.
+func (s *Scripts) ByName(name string) (*Script, error) {
+	for _, script := range *s {
+		if script.Name == name {
+			return &script, nil
+		}
+	}
+
+	return nil, fmt.Errorf("script named %s does not exist in configuration", name)
+}
+
+// AddOrUpdate add new or update if already present.
+func (s *Scripts) AddOrUpdate(name string, script Script) {
+	for i, existing := range *s {
+		if existing.Name == name {
+			(*s)[i] = script
+			return
+		}
+	}
+
+	*s = append(*s, script)
+}
+
+// Remove script by its name.
+func (s *Scripts) Remove(name string) error {
+	_, err := s.ByName(name)
+	if err != nil {
+		return err
+	}
+
+	for i, script := range *s {
+		if script.Name == name {
+			*s = append((*s)[0:i], (*s)[i+1:]...)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Settings defines the defaults of the global command flags.
+//
+// Settings are usually defined in the global user configuration, so they apply to all the projects,
+// but the project configuration can override them as well.
+type Settings struct {
+	Network string
+	Format  string
+	Filter  string
+}
+
+// SettingKeys are the names of all the settings.
+var SettingKeys = []string{"network", "format", "filter"}
+
+// Get returns the value of the setting by the name.
+func (s *Settings) Get(key string) (string, error) {
+	value, err := s.field(key)
+	if err != nil {
+		return "", err
+	}
+
+	return *value, nil
+}
+
+// Set changes the value of the setting by the name.
+func (s *Settings) Set(key string, value string) error {
+	field, err := s.field(key)
+	if err != nil {
+		return err
+	}
+
+	*field = value
+	return nil
+}
+
+// IsEmpty checks if none of the settings is defined.
+func (s *Settings) IsEmpty() bool {
+	return *s == Settings{}
+}
+
+func (s *Settings) field(key string) (*string, error) {
+	switch key {
+	case "network":
+		return &s.Network, nil
+	case "format":
+		return &s.Format, nil
+	case "filter":
+		return &s.Filter, nil
+	}
+
+	return nil, fmt.Errorf("unknown setting %s, available settings: %s", key, strings.Join(SettingKeys, ", "))
+}
+
+// merge overrides the settings with the settings defined in other settings.
+func (s *Settings) merge(other Settings) {
+	for _, key := range SettingKeys {
+		if value, _ := other.Get(key); value != "" {
+			_ = s.Set(key, value)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import "fmt"
+
+// Transaction defines a template transaction, which is run by its name with the values of its parameters.
+type Transaction struct {
+	Name     string
+	Location string
+	// Parameters declare the Cadence types of the parameters by their names, the types of the
+	// placeholders in the transaction code must be declared.
+	Parameters []TransactionParameter
+}
+
+// TransactionParameter declares the Cadence type of a template transaction parameter.
+type TransactionParameter struct {
+	Name string
+	Type string
+}
+
+type Transactions []Transaction
+
+// ParameterType returns the declared type of the parameter, or an empty string if it's not declared.
+func (t *Transaction) ParameterType(name string) string {
+	for _, parameter := range t.Parameters {
+		if parameter.Name == name {
+			return parameter.Type
+		}
+	}
+
+	return ""
+}
+
+// ByName get transaction by name.
+func (t *Transactions) ByName(name string) (*Transaction, error) {
+	for _, transaction := range *t {
+		if transaction.Name == name {
+			return &transaction, nil
+		}
+	}
+
+	return nil, fmt.Errorf("transaction named %s does not exist in configuration", name)
+}
+
+// AddOrUpdate add new or update if already present.
+func (t *Transactions) AddOrUpdate(name string, transaction Transaction) {
+	for i, existing := range *t {
+		if existing.Name == name {
+			(*t)[i] = transaction
+			return
+		}
+	}
+
+	*t = append(*t, transaction)
+}
+
+// Remove transaction by its name.
+func (t *Transactions) Remove(name string) error {
+	_, err := t.ByName(name)
+	if err != nil {
+		return err
+	}
+
+	for i, transaction := range *t {
+		if transaction.Name == name {
+			*t = append((*t)[0:i], (*t)[i+1:]...)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	configjson "github.com/onflow/flow-cli/pkg/flowkit/config/json"
+)
+
+// Parser for YAML configuration format.
+//
+// The YAML configuration has the same structure as the JSON configuration and it is
+// transcoded to JSON when parsed, so the formats are interchangeable.
+type Parser struct {
+	json *configjson.Parser
+}
+
+// NewParser returns a YAML parser.
+func NewParser() *Parser {
+	return &Parser{
+		json: configjson.NewParser(),
+	}
+}
+
+// Serialize configuration to raw.
+func (p *Parser) Serialize(conf *config.Config) ([]byte, error) {
+	data, err := p.json.Serialize(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.FromJSON(data)
+}
+
+// Deserialize configuration to config structure.
+func (p *Parser) Deserialize(raw []byte) (*config.Config, error) {
+	data, err := p.ToJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.json.Deserialize(data)
+}
+
+// ToJSON transcodes the YAML configuration to JSON keeping the order of the properties.
+func (p *Parser) ToJSON(raw []byte) ([]byte, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(raw, &document)
+	if err != nil {
+		return nil, fmt.Errorf("configuration syntax error: %w", err)
+	}
+
+	// empty document is an empty configuration
+	if len(document.Content) == 0 {
+		return []byte("{}"), nil
+	}
+
+	var b bytes.Buffer
+	err = writeJSON(&b, document.Content[0])
+	if err != nil {
+		return nil, fmt.Errorf("configuration syntax error: %w", err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// FromJSON transcodes the JSON configuration to YAML keeping the order of the properties.
+func (p *Parser) FromJSON(data []byte) ([]byte, error) {
+	// JSON is valid YAML, so it is parsed as is and written in the block style
+	var document yaml.Node
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, fmt.Errorf("configuration syntax error: %w", err)
+	}
+	resetStyle(&document)
+
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	err = encoder.Encode(&document)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// SupportsFormat check if the file format is supported.
+func (p *Parser) SupportsFormat(extension string) bool {
+	return extension == ".yaml" || extension == ".yml"
+}
+
+// writeJSON writes the YAML node as JSON.
+func writeJSON(b *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeJSON(b, node.Alias)
+
+	case yaml.MappingNode:
+		b.WriteString("{")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				b.WriteString(",")
+			}
+
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+			b.Write(key)
+			b.WriteString(":")
+
+			err = writeJSON(b, node.Content[i+1])
+			if err != nil {
+				return err
+			}
+		}
+		b.WriteString("}")
+
+	case yaml.SequenceNode:
+		b.WriteString("[")
+		for i, item := range node.Content {
+			if i > 0 {
+				b.WriteString(",")
+			}
+
+			err := writeJSON(b, item)
+			if err != nil {
+				return err
+			}
+		}
+		b.WriteString("]")
+
+	default:
+		var value interface{}
+		err := node.Decode(&value)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("invalid value at line %d: %w", node.Line, err)
+		}
+		b.Write(data)
+	}
+
+	return nil
+}
+
+// resetStyle removes the flow style and quotes of the values, so they are written in the block style
+// and quoted only when needed.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
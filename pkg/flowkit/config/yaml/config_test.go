@@ -0,0 +1,109 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config/json"
+)
+
+const configYAML = `emulators:
+  default:
+    port: 3570
+    serviceAccount: emulator-account
+contracts:
+  Hello:
+    source: ./Hello.cdc
+    aliases:
+      testnet: "0000000000000001"
+networks:
+  emulator: 127.0.0.1:3569
+accounts:
+  emulator-account:
+    address: f8d6e0586b0a20c7
+    key: dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47
+deployments:
+  emulator:
+    emulator-account:
+      - name: Hello
+        args:
+          - type: String
+            value: |-
+              Hello
+              World
+`
+
+func Test_ConfigYAML(t *testing.T) {
+	parser := NewParser()
+
+	conf, err := parser.Deserialize([]byte(configYAML))
+	require.NoError(t, err)
+
+	contract, err := conf.Contracts.ByNameAndNetwork("Hello", "testnet")
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000000001", contract.Alias)
+
+	deployment := conf.Deployments.ByAccountAndNetwork("emulator-account", "emulator")
+	require.Len(t, deployment, 1)
+	assert.Equal(t, `"Hello\nWorld"`, deployment[0].Contracts[0].Args[0].String())
+
+	serialized, err := parser.Serialize(conf)
+	require.NoError(t, err)
+	assert.Equal(t, configYAML, string(serialized))
+}
+
+func Test_ConfigYAMLInterchangeable(t *testing.T) {
+	parser := NewParser()
+	jsonParser := json.NewParser()
+
+	conf, err := parser.Deserialize([]byte(configYAML))
+	require.NoError(t, err)
+
+	data, err := jsonParser.Serialize(conf)
+	require.NoError(t, err)
+
+	converted, err := parser.FromJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, configYAML, string(converted))
+
+	data, err = parser.ToJSON(converted)
+	require.NoError(t, err)
+
+	jsonConf, err := jsonParser.Deserialize(data)
+	require.NoError(t, err)
+
+	serialized, err := jsonParser.Serialize(jsonConf)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), string(serialized))
+}
+
+func Test_ConfigYAMLErrors(t *testing.T) {
+	parser := NewParser()
+
+	_, err := parser.Deserialize([]byte("accounts: [\n"))
+	assert.EqualError(t, err, "configuration syntax error: yaml: line 1: did not find expected node content")
+
+	// unquoted numbers are not strings
+	_, err = parser.Deserialize([]byte("networks:\n  emulator: 3569\n"))
+	assert.EqualError(t, err, "invalid configuration:\n\t/networks/emulator: expected string or object but found integer")
+}
@@ -0,0 +1,155 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// PassphraseEnv is the environment variable read for the passphrase of the encrypted keys.
+const PassphraseEnv = "FLOW_KEY_PASSPHRASE"
+
+const (
+	encryptionCipher = "aes-256-gcm"
+	encryptionKDF    = "scrypt"
+	encryptionKeyLen = 32
+	saltLen          = 16
+)
+
+// default scrypt parameters recommended for interactive use
+const (
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+// ErrInvalidPassphrase is returned when an encrypted key can't be decrypted with the passphrase.
+var ErrInvalidPassphrase = errors.New("invalid passphrase, the encrypted key could not be decrypted")
+
+// EncryptPrivateKey encrypts the private key with a key derived from the passphrase.
+func EncryptPrivateKey(privateKey crypto.PrivateKey, passphrase string) (config.EncryptedKey, error) {
+	if passphrase == "" {
+		return config.EncryptedKey{}, fmt.Errorf("passphrase can not be empty")
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return config.EncryptedKey{}, err
+	}
+
+	kdf := config.KDF{
+		Name: encryptionKDF,
+		Salt: hex.EncodeToString(salt),
+		N:    scryptN,
+		R:    scryptR,
+		P:    scryptP,
+	}
+
+	gcm, err := newCipher(passphrase, kdf)
+	if err != nil {
+		return config.EncryptedKey{}, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return config.EncryptedKey{}, err
+	}
+
+	return config.EncryptedKey{
+		Cipher:     encryptionCipher,
+		Ciphertext: hex.EncodeToString(gcm.Seal(nil, nonce, privateKey.Encode(), nil)),
+		Nonce:      hex.EncodeToString(nonce),
+		KDF:        kdf,
+	}, nil
+}
+
+// DecryptPrivateKey decrypts the encrypted private key with the passphrase.
+//
+// ErrInvalidPassphrase is returned if the passphrase doesn't match the one used for encryption.
+func DecryptPrivateKey(
+	encrypted config.EncryptedKey,
+	sigAlgo crypto.SignatureAlgorithm,
+	passphrase string,
+) (crypto.PrivateKey, error) {
+	if encrypted.Cipher != encryptionCipher {
+		return nil, fmt.Errorf("unsupported key encryption cipher %s, only %s is supported", encrypted.Cipher, encryptionCipher)
+	}
+
+	gcm, err := newCipher(passphrase, encrypted.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := hex.DecodeString(encrypted.Nonce)
+	if err != nil || len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid encrypted key nonce")
+	}
+
+	ciphertext, err := hex.DecodeString(encrypted.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted key ciphertext")
+	}
+
+	// authentication of the ciphertext fails if the derived key is different
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	privateKey, err := crypto.DecodePrivateKey(sigAlgo, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decrypted private key: %w", err)
+	}
+
+	return privateKey, nil
+}
+
+// newCipher returns the AES-GCM cipher with the key derived from the passphrase.
+func newCipher(passphrase string, kdf config.KDF) (cipher.AEAD, error) {
+	if kdf.Name != encryptionKDF {
+		return nil, fmt.Errorf("unsupported key derivation function %s, only %s is supported", kdf.Name, encryptionKDF)
+	}
+
+	salt, err := hex.DecodeString(kdf.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key derivation salt")
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, kdf.N, kdf.R, kdf.P, encryptionKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the encryption key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
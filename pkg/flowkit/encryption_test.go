@@ -0,0 +1,82 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+func TestEncryptedAccountKey(t *testing.T) {
+	privateKey, err := crypto.DecodePrivateKeyHex(
+		crypto.ECDSA_P256,
+		"dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47",
+	)
+	require.NoError(t, err)
+
+	key, err := NewEncryptedAccountKey(0, crypto.SHA3_256, privateKey, "secret")
+	require.NoError(t, err)
+
+	// the configuration only contains the encrypted private key
+	conf := key.ToConfig()
+	assert.Equal(t, config.KeyTypeEncrypted, conf.Type)
+	assert.Nil(t, conf.PrivateKey)
+	assert.Equal(t, "aes-256-gcm", conf.Encrypted.Cipher)
+	assert.Equal(t, "scrypt", conf.Encrypted.KDF.Name)
+	assert.NotContains(t, conf.Encrypted.Ciphertext, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+
+	t.Run("Decrypt from environment", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "secret")
+
+		loaded, err := NewAccountKey(conf)
+		require.NoError(t, err)
+		require.NoError(t, loaded.Validate())
+
+		decrypted, err := loaded.PrivateKey()
+		require.NoError(t, err)
+		assert.True(t, privateKey.Equals(*decrypted))
+		assert.Equal(t, conf, loaded.ToConfig())
+	})
+
+	t.Run("Invalid passphrase", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "wrong")
+
+		loaded, err := NewAccountKey(conf)
+		require.NoError(t, err)
+
+		_, err = loaded.Signer(context.Background())
+		assert.ErrorIs(t, err, ErrInvalidPassphrase)
+	})
+
+	t.Run("Missing passphrase", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "")
+
+		loaded, err := NewAccountKey(conf)
+		require.NoError(t, err)
+
+		err = loaded.Validate()
+		assert.EqualError(t, err, "the account key is encrypted, provide the passphrase with the FLOW_KEY_PASSPHRASE environment variable")
+	})
+}
@@ -106,6 +106,27 @@ func (e *Events) GetCreatedAddresses() []*flow.Address {
 	return addresses
 }
 
+// feesDeductedEvent is the suffix of the event emitted by the FlowFees contract when the transaction fees are deducted.
+const feesDeductedEvent = ".FlowFees.FeesDeducted"
+
+// GetComputationUsed returns the computation used by the transaction, from the execution effort of the fees deducted event.
+//
+// The event is only emitted on networks with the transaction fees enabled, otherwise the computation used is not available.
+func (e *Events) GetComputationUsed() (uint64, bool) {
+	for _, event := range *e {
+		if !strings.HasSuffix(event.Type, feesDeductedEvent) {
+			continue
+		}
+
+		// the raw value of the execution effort is the computation used
+		if effort, ok := event.Values["executionEffort"].(cadence.UFix64); ok {
+			return uint64(effort), true
+		}
+	}
+
+	return 0, false
+}
+
 func handleCadenceArrayValues(keyArray cadence.Array) []byte {
 	parsedKey := make([]byte, len(keyArray.Values))
 	for i, val := range keyArray.Values {
@@ -50,3 +50,24 @@ func TestAddress(t *testing.T) {
 	address := flow.HexToAddress("cdfef0f4f0786e9")
 	assert.Equal(t, "0cdfef0f4f0786e9", address.String())
 }
+
+func TestComputationUsed(t *testing.T) {
+	feesEvent := tests.NewEvent(0,
+		"A.f919ee77447b7497.FlowFees.FeesDeducted",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "inclusionEffort", Type: cadence.UFix64Type{}},
+			{Identifier: "executionEffort", Type: cadence.UFix64Type{}},
+		},
+		[]cadence.Value{cadence.UFix64(1000), cadence.UFix64(100000000), cadence.UFix64(42)},
+	)
+
+	events := flowkit.EventsFromTransaction(tests.NewTransactionResult([]flow.Event{*feesEvent}))
+	computation, ok := events.GetComputationUsed()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), computation)
+
+	events = flowkit.EventsFromTransaction(tests.NewTransactionResult(nil))
+	_, ok = events.GetComputationUsed()
+	assert.False(t, ok)
+}
@@ -27,6 +27,7 @@ import (
 	emulator "github.com/onflow/flow-emulator"
 	"github.com/onflow/flow-emulator/convert/sdk"
 	"github.com/onflow/flow-emulator/server/backend"
+	"github.com/onflow/flow-emulator/types"
 	"github.com/onflow/flow-go-sdk"
 	flowGo "github.com/onflow/flow-go/model/flow"
 	"github.com/rs/zerolog"
@@ -104,14 +105,14 @@ func newEmulator(serviceAccount *flowkit.Account, emulatorOptions ...emulator.Op
 	if serviceAccount != nil && serviceAccount.Key().Type() == config.KeyTypeHex {
 		privKey, _ := serviceAccount.Key().PrivateKey()
 
+		// the emulator must use the same algorithms as the configured service key to verify its signatures
 		opts = append(opts, emulator.WithServicePublicKey(
 			(*privKey).PublicKey(),
 			serviceAccount.Key().SigAlgo(),
 			serviceAccount.Key().HashAlgo(),
 		))
-		opts = append(opts, emulatorOptions...)
-
 	}
+	opts = append(opts, emulatorOptions...)
 
 	b, err := emulator.NewBlockchain(opts...)
 	if err != nil {
@@ -137,6 +138,24 @@ func (g *EmulatorGateway) SendSignedTransaction(tx *flowkit.Transaction) (*flow.
 	return tx.FlowTransaction(), nil
 }
 
+// DryRunTransaction executes the transaction without committing it and returns the result with the computation used.
+func (g *EmulatorGateway) DryRunTransaction(tx *flowkit.Transaction) (*types.TransactionResult, error) {
+	err := g.emulator.AddTransaction(*tx.FlowTransaction())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := g.emulator.ExecuteNextTransaction()
+	if resetErr := g.emulator.ResetPendingBlock(); err == nil {
+		err = resetErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (g *EmulatorGateway) GetTransactionResult(ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
 	result, err := g.backend.GetTransactionResult(g.ctx, ID)
 	if err != nil {
@@ -183,6 +202,40 @@ func (g *EmulatorGateway) ExecuteScript(script []byte, arguments []cadence.Value
 		return nil, UnwrapStatusError(err)
 	}
 
+	return scriptResultToCadenceValue(result)
+}
+
+func (g *EmulatorGateway) ExecuteScriptAtHeight(script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+
+	args, err := cadenceValuesToMessages(arguments)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+
+	result, err := g.backend.ExecuteScriptAtBlockHeight(g.ctx, height, script, args)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+
+	return scriptResultToCadenceValue(result)
+}
+
+func (g *EmulatorGateway) ExecuteScriptAtID(script []byte, arguments []cadence.Value, id flow.Identifier) (cadence.Value, error) {
+
+	args, err := cadenceValuesToMessages(arguments)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+
+	result, err := g.backend.ExecuteScriptAtBlockID(g.ctx, id, script, args)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+
+	return scriptResultToCadenceValue(result)
+}
+
+func scriptResultToCadenceValue(result []byte) (cadence.Value, error) {
 	value, err := messageToCadenceValue(result)
 	if err != nil {
 		return nil, UnwrapStatusError(err)
@@ -200,6 +253,15 @@ func (g *EmulatorGateway) GetLatestBlock() (*flow.Block, error) {
 	return convertBlock(block), nil
 }
 
+func (g *EmulatorGateway) GetLatestFinalizedBlock() (*flow.Block, error) {
+	block, _, err := g.backend.GetLatestBlock(g.ctx, false)
+	if err != nil {
+		return nil, UnwrapStatusError(err)
+	}
+
+	return convertBlock(block), nil
+}
+
 func cadenceValuesToMessages(values []cadence.Value) ([][]byte, error) {
 	msgs := make([][]byte, len(values))
 	for i, val := range values {
@@ -303,6 +365,10 @@ func (g *EmulatorGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
 	return snapshot, nil
 }
 
+func (g *EmulatorGateway) GetChainID() (flow.ChainID, error) {
+	return flow.ChainID(g.backend.GetNetworkParameters(g.ctx).ChainID), nil
+}
+
 // SecureConnection placeholder func to complete gateway interface implementation
 func (g *EmulatorGateway) SecureConnection() bool {
 	return false
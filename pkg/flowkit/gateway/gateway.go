@@ -34,12 +34,16 @@ type Gateway interface {
 	GetTransactionResult(flow.Identifier, bool) (*flow.TransactionResult, error)
 	GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error)
 	ExecuteScript([]byte, []cadence.Value) (cadence.Value, error)
+	ExecuteScriptAtHeight([]byte, []cadence.Value, uint64) (cadence.Value, error)
+	ExecuteScriptAtID([]byte, []cadence.Value, flow.Identifier) (cadence.Value, error)
 	GetLatestBlock() (*flow.Block, error)
+	GetLatestFinalizedBlock() (*flow.Block, error)
 	GetBlockByHeight(uint64) (*flow.Block, error)
 	GetBlockByID(flow.Identifier) (*flow.Block, error)
 	GetEvents(string, uint64, uint64) ([]flow.BlockEvents, error)
 	GetCollection(flow.Identifier) (*flow.Collection, error)
 	GetLatestProtocolStateSnapshot() ([]byte, error)
+	GetChainID() (flow.ChainID, error)
 	Ping() error
 	SecureConnection() bool
 }
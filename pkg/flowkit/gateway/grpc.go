@@ -28,6 +28,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	grpcAccess "github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow-go/utils/grpcutils"
+	"github.com/onflow/flow/protobuf/go/flow/access"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
@@ -43,16 +44,18 @@ type GrpcGateway struct {
 	client       *grpcAccess.Client
 	ctx          context.Context
 	secureClient bool
+	host         string
+	dialOptions  []grpc.DialOption
 }
 
 // NewGrpcGateway returns a new gRPC gateway.
 func NewGrpcGateway(host string) (*GrpcGateway, error) {
-
-	gClient, err := grpcAccess.NewClient(
-		host,
+	dialOptions := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
-	)
+	}
+
+	gClient, err := grpcAccess.NewClient(host, dialOptions...)
 	ctx := context.Background()
 
 	if err != nil || gClient == nil {
@@ -63,6 +66,8 @@ func NewGrpcGateway(host string) (*GrpcGateway, error) {
 		client:       gClient,
 		ctx:          ctx,
 		secureClient: false,
+		host:         host,
+		dialOptions:  dialOptions,
 	}, nil
 }
 
@@ -73,11 +78,12 @@ func NewSecureGrpcGateway(host, hostNetworkKey string) (*GrpcGateway, error) {
 		return nil, fmt.Errorf("failed to create secure GRPC dial options with network key \"%s\": %w", hostNetworkKey, err)
 	}
 
-	gClient, err := grpcAccess.NewClient(
-		host,
+	dialOptions := []grpc.DialOption{
 		secureDialOpts,
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
-	)
+	}
+
+	gClient, err := grpcAccess.NewClient(host, dialOptions...)
 	ctx := context.Background()
 
 	if err != nil || gClient == nil {
@@ -88,9 +94,18 @@ func NewSecureGrpcGateway(host, hostNetworkKey string) (*GrpcGateway, error) {
 		client:       gClient,
 		ctx:          ctx,
 		secureClient: true,
+		host:         host,
+		dialOptions:  dialOptions,
 	}, nil
 }
 
+// WithContext returns a copy of the gateway sending its requests with the context, so they can be cancelled.
+func (g *GrpcGateway) WithContext(ctx context.Context) Gateway {
+	gateway := *g
+	gateway.ctx = ctx
+	return &gateway
+}
+
 // GetAccount gets an account by address from the Flow Access API.
 func (g *GrpcGateway) GetAccount(address flow.Address) (*flow.Account, error) {
 	account, err := g.client.GetAccountAtLatestBlock(g.ctx, address)
@@ -152,11 +167,38 @@ func (g *GrpcGateway) ExecuteScript(script []byte, arguments []cadence.Value) (c
 	return value, nil
 }
 
+// ExecuteScriptAtHeight executes a script at the block height on Flow through the Access API.
+func (g *GrpcGateway) ExecuteScriptAtHeight(script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+
+	value, err := g.client.ExecuteScriptAtBlockHeight(g.ctx, height, script, arguments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit executable script at block height %d: %w", height, err)
+	}
+
+	return value, nil
+}
+
+// ExecuteScriptAtID executes a script at the block ID on Flow through the Access API.
+func (g *GrpcGateway) ExecuteScriptAtID(script []byte, arguments []cadence.Value, id flow.Identifier) (cadence.Value, error) {
+
+	value, err := g.client.ExecuteScriptAtBlockID(g.ctx, id, script, arguments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit executable script at block %s: %w", id, err)
+	}
+
+	return value, nil
+}
+
 // GetLatestBlock gets the latest block on Flow through the Access API.
 func (g *GrpcGateway) GetLatestBlock() (*flow.Block, error) {
 	return g.client.GetLatestBlock(g.ctx, true)
 }
 
+// GetLatestFinalizedBlock gets the latest finalized block, which is not sealed yet, on Flow through the Access API.
+func (g *GrpcGateway) GetLatestFinalizedBlock() (*flow.Block, error) {
+	return g.client.GetLatestBlock(g.ctx, false)
+}
+
 // GetBlockByID get block by ID from the Flow Access API.
 func (g *GrpcGateway) GetBlockByID(id flow.Identifier) (*flow.Block, error) {
 	return g.client.GetBlockByID(g.ctx, id)
@@ -194,6 +236,24 @@ func (g *GrpcGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
 	return g.client.GetLatestProtocolStateSnapshot(g.ctx)
 }
 
+// GetChainID gets the chain ID of the network parameters from the Flow Access API.
+//
+// The client of the SDK doesn't provide the network parameters, so they are requested on a new connection.
+func (g *GrpcGateway) GetChainID() (flow.ChainID, error) {
+	conn, err := grpc.Dial(g.host, g.dialOptions...)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	params, err := access.NewAccessAPIClient(conn).GetNetworkParameters(g.ctx, &access.GetNetworkParametersRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	return flow.ChainID(params.GetChainId()), nil
+}
+
 // Ping is used to check if the access node is alive and healthy.
 func (g *GrpcGateway) Ping() error {
 	return g.client.Ping(g.ctx)
@@ -0,0 +1,321 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+)
+
+// defaultRetryBackoff is the delay before the first retry if the backoff isn't configured.
+const defaultRetryBackoff = 500 * time.Millisecond
+
+// ErrTimeout is returned when a request doesn't complete within the configured timeout.
+var ErrTimeout = errors.New("request timed out")
+
+// Limits are the limits applied to the requests sent through a gateway.
+//
+// The zero value doesn't limit the requests.
+type Limits struct {
+	Timeout              time.Duration // maximum duration of a request, zero for no timeout
+	MaxRetries           int           // number of times a failed request is retried
+	RetryBackoff         time.Duration // delay before the first retry, doubled for each following retry
+	MaxRequestsPerSecond float64       // maximum rate of the requests, zero for no limit
+}
+
+// LimitedGateway is a gateway wrapping another gateway which applies the request limits to all the calls.
+//
+// Requests failing because the access node is unavailable, overloaded or too slow are retried.
+// Transactions are not resent, as a transaction which failed to be sent might have been received already,
+// they are resent by the transactions service which checks if the network received them.
+type LimitedGateway struct {
+	gateway Gateway
+	limits  Limits
+	limiter *limiter
+}
+
+var _ Gateway = &LimitedGateway{}
+
+// NewLimitedGateway returns a gateway applying the limits to the requests of the wrapped gateway.
+func NewLimitedGateway(gateway Gateway, limits Limits) *LimitedGateway {
+	if limits.RetryBackoff == 0 {
+		limits.RetryBackoff = defaultRetryBackoff
+	}
+
+	return &LimitedGateway{
+		gateway: gateway,
+		limits:  limits,
+		limiter: newLimiter(limits.MaxRequestsPerSecond),
+	}
+}
+
+func (g *LimitedGateway) GetAccount(address flow.Address) (*flow.Account, error) {
+	return call(g, isRetryable, func(gw Gateway) (*flow.Account, error) {
+		return gw.GetAccount(address)
+	})
+}
+
+func (g *LimitedGateway) SendSignedTransaction(tx *flowkit.Transaction) (*flow.Transaction, error) {
+	return call(g, neverRetryable, func(gw Gateway) (*flow.Transaction, error) {
+		return gw.SendSignedTransaction(tx)
+	})
+}
+
+func (g *LimitedGateway) GetTransaction(ID flow.Identifier) (*flow.Transaction, error) {
+	return call(g, isRetryable, func(gw Gateway) (*flow.Transaction, error) {
+		return gw.GetTransaction(ID)
+	})
+}
+
+func (g *LimitedGateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return call(g, isRetryable, func(gw Gateway) ([]*flow.TransactionResult, error) {
+		return gw.GetTransactionResultsByBlockID(blockID)
+	})
+}
+
+// GetTransactionResult gets the transaction result, waiting for the seal polls the result
+// so the timeout applies to each request instead of the whole wait.
+func (g *LimitedGateway) GetTransactionResult(ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	for {
+		result, err := call(g, isRetryable, func(gw Gateway) (*flow.TransactionResult, error) {
+			return gw.GetTransactionResult(ID, false)
+		})
+		if err != nil || !waitSeal || result.Status == flow.TransactionStatusSealed {
+			return result, err
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+func (g *LimitedGateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return call(g, isRetryable, func(gw Gateway) ([]*flow.Transaction, error) {
+		return gw.GetTransactionsByBlockID(blockID)
+	})
+}
+
+func (g *LimitedGateway) ExecuteScript(script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return call(g, isRetryable, func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScript(script, arguments)
+	})
+}
+
+func (g *LimitedGateway) ExecuteScriptAtHeight(script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	return call(g, isRetryable, func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScriptAtHeight(script, arguments, height)
+	})
+}
+
+func (g *LimitedGateway) ExecuteScriptAtID(script []byte, arguments []cadence.Value, id flow.Identifier) (cadence.Value, error) {
+	return call(g, isRetryable, func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScriptAtID(script, arguments, id)
+	})
+}
+
+func (g *LimitedGateway) GetLatestBlock() (*flow.Block, error) {
+	return call(g, isRetryable, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetLatestBlock()
+	})
+}
+
+func (g *LimitedGateway) GetLatestFinalizedBlock() (*flow.Block, error) {
+	return call(g, isRetryable, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetLatestFinalizedBlock()
+	})
+}
+
+func (g *LimitedGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
+	return call(g, isRetryable, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetBlockByHeight(height)
+	})
+}
+
+func (g *LimitedGateway) GetBlockByID(ID flow.Identifier) (*flow.Block, error) {
+	return call(g, isRetryable, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetBlockByID(ID)
+	})
+}
+
+func (g *LimitedGateway) GetEvents(eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return call(g, isRetryable, func(gw Gateway) ([]flow.BlockEvents, error) {
+		return gw.GetEvents(eventType, startHeight, endHeight)
+	})
+}
+
+func (g *LimitedGateway) GetCollection(ID flow.Identifier) (*flow.Collection, error) {
+	return call(g, isRetryable, func(gw Gateway) (*flow.Collection, error) {
+		return gw.GetCollection(ID)
+	})
+}
+
+func (g *LimitedGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
+	return call(g, isRetryable, func(gw Gateway) ([]byte, error) {
+		return gw.GetLatestProtocolStateSnapshot()
+	})
+}
+
+func (g *LimitedGateway) GetChainID() (flow.ChainID, error) {
+	return call(g, isRetryable, func(gw Gateway) (flow.ChainID, error) {
+		return gw.GetChainID()
+	})
+}
+
+func (g *LimitedGateway) Ping() error {
+	_, err := call(g, isRetryable, func(gw Gateway) (struct{}, error) {
+		return struct{}{}, gw.Ping()
+	})
+	return err
+}
+
+func (g *LimitedGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
+
+// call sends the request within the limits, retrying it while it fails with a retryable error.
+func call[T any](g *LimitedGateway, retryable func(error) bool, request func(gw Gateway) (T, error)) (T, error) {
+	backoff := g.limits.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		g.limiter.wait()
+
+		result, err := withTimeout(g.gateway, g.limits.Timeout, request)
+		if err == nil || attempt >= g.limits.MaxRetries || !retryable(err) {
+			return result, err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// contextGateway is a gateway which can send its requests with a context, so they can be cancelled once timed out.
+type contextGateway interface {
+	WithContext(ctx context.Context) Gateway
+}
+
+// withTimeout returns the timeout error if the request doesn't complete in time.
+//
+// The requests of gateways supporting contexts are cancelled, the requests of other gateways
+// are left to complete in the background.
+func withTimeout[T any](gateway Gateway, timeout time.Duration, request func(gw Gateway) (T, error)) (T, error) {
+	if timeout == 0 {
+		return request(gateway)
+	}
+
+	if gw, ok := gateway.(contextGateway); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result, err := request(gw.WithContext(ctx))
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return result, fmt.Errorf("%w after %s", ErrTimeout, timeout)
+		}
+		return result, err
+	}
+
+	type response struct {
+		result T
+		err    error
+	}
+
+	responses := make(chan response, 1)
+	go func() {
+		result, err := request(gateway)
+		responses <- response{result: result, err: err}
+	}()
+
+	select {
+	case res := <-responses:
+		return res.result, res.err
+	case <-time.After(timeout):
+		var empty T
+		return empty, fmt.Errorf("%w after %s", ErrTimeout, timeout)
+	}
+}
+
+// isRetryable returns true if the request failed because the access node is unavailable, overloaded or too slow.
+func isRetryable(err error) bool {
+	if errors.Is(err, ErrTimeout) {
+		return true
+	}
+
+	switch GRPCCode(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	}
+
+	return false
+}
+
+// neverRetryable doesn't retry the failed requests.
+func neverRetryable(error) bool {
+	return false
+}
+
+// GRPCCode returns the gRPC status code of the error, the errors returned by the gateways can be wrapped.
+func GRPCCode(err error) codes.Code {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return grpcErr.GRPCStatus().Code()
+	}
+
+	return codes.Unknown
+}
+
+// limiter spaces the requests so they don't exceed the maximum rate.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newLimiter(maxRequestsPerSecond float64) *limiter {
+	if maxRequestsPerSecond == 0 {
+		return &limiter{}
+	}
+
+	return &limiter{interval: time.Duration(float64(time.Second) / maxRequestsPerSecond)}
+}
+
+// wait blocks until the next request can be sent.
+func (l *limiter) wait() {
+	if l.interval == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(delay)
+}
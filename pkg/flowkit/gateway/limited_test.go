@@ -0,0 +1,208 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+// slowGateway returns a mock gateway which accounts are returned after the delay for the first slow calls.
+func slowGateway(delay time.Duration, slow int32) (*tests.TestGateway, *int32) {
+	gw := tests.DefaultMockGateway()
+	calls := new(int32)
+
+	gw.GetAccount.Run(func(args mock.Arguments) {
+		if atomic.AddInt32(calls, 1) <= slow {
+			time.Sleep(delay)
+		}
+	}).Return(tests.NewAccountWithAddress("01"), nil)
+
+	return gw, calls
+}
+
+// contextGateway is a mock gateway which account requests block until their context is cancelled.
+type contextGateway struct {
+	gateway.Gateway
+	ctx context.Context
+}
+
+func (g *contextGateway) WithContext(ctx context.Context) gateway.Gateway {
+	return &contextGateway{Gateway: g.Gateway, ctx: ctx}
+}
+
+func (g *contextGateway) GetAccount(flow.Address) (*flow.Account, error) {
+	<-g.ctx.Done()
+	return nil, status.Error(codes.DeadlineExceeded, g.ctx.Err().Error())
+}
+
+func TestLimitedGateway(t *testing.T) {
+	address := flow.HexToAddress("01")
+
+	t.Run("No Limits", func(t *testing.T) {
+		gw, calls := slowGateway(50*time.Millisecond, 1)
+		limited := gateway.NewLimitedGateway(gw.Mock, gateway.Limits{})
+
+		account, err := limited.GetAccount(address)
+		assert.NoError(t, err)
+		assert.Equal(t, address, account.Address)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		gw, calls := slowGateway(time.Second, 1)
+		limited := gateway.NewLimitedGateway(gw.Mock, gateway.Limits{Timeout: 20 * time.Millisecond})
+
+		start := time.Now()
+		account, err := limited.GetAccount(address)
+		assert.ErrorIs(t, err, gateway.ErrTimeout)
+		assert.EqualError(t, err, "request timed out after 20ms")
+		assert.Nil(t, account)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Timeout Cancels Request", func(t *testing.T) {
+		gw := &contextGateway{Gateway: tests.DefaultMockGateway().Mock, ctx: context.Background()}
+		limited := gateway.NewLimitedGateway(gw, gateway.Limits{Timeout: 20 * time.Millisecond})
+
+		start := time.Now()
+		_, err := limited.GetAccount(address)
+		assert.ErrorIs(t, err, gateway.ErrTimeout)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Retry Timeout", func(t *testing.T) {
+		gw, calls := slowGateway(time.Second, 2)
+		limited := gateway.NewLimitedGateway(gw.Mock, gateway.Limits{
+			Timeout:      20 * time.Millisecond,
+			MaxRetries:   2,
+			RetryBackoff: time.Millisecond,
+		})
+
+		account, err := limited.GetAccount(address)
+		assert.NoError(t, err)
+		assert.Equal(t, address, account.Address)
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Retries Exhausted", func(t *testing.T) {
+		gw, calls := slowGateway(time.Second, 3)
+		limited := gateway.NewLimitedGateway(gw.Mock, gateway.Limits{
+			Timeout:      20 * time.Millisecond,
+			MaxRetries:   1,
+			RetryBackoff: time.Millisecond,
+		})
+
+		_, err := limited.GetAccount(address)
+		assert.ErrorIs(t, err, gateway.ErrTimeout)
+		assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Retry Unavailable", func(t *testing.T) {
+		gw := tests.DefaultMockGateway()
+		calls := 0
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			calls++
+			if calls == 1 {
+				gw.GetAccount.Return(nil, fmt.Errorf("failed to get account: %w", status.Error(codes.Unavailable, "connection refused")))
+			} else {
+				gw.GetAccount.Return(tests.NewAccountWithAddress("01"), nil)
+			}
+		})
+		limited := gateway.NewLimitedGateway(gw.Mock, gateway.Limits{MaxRetries: 3, RetryBackoff: time.Millisecond})
+
+		account, err := limited.GetAccount(address)
+		assert.NoError(t, err)
+		assert.Equal(t, address, account.Address)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("No Retry Failed Request", func(t *testing.T) {
+		gw := tests.DefaultMockGateway()
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			gw.GetAccount.Return(nil, errors.New("account not found"))
+		})
+		limited := gateway.NewLimitedGateway(gw.Mock, gateway.Limits{MaxRetries: 3, RetryBackoff: time.Millisecond})
+
+		_, err := limited.GetAccount(address)
+		assert.EqualError(t, err, "account not found")
+		gw.Mock.AssertNumberOfCalls(t, tests.GetAccountFunc, 1)
+	})
+
+	t.Run("No Resend Timed Out Transaction", func(t *testing.T) {
+		gw := tests.DefaultMockGateway()
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			time.Sleep(time.Second)
+		}).Return(tests.NewTransaction(), nil)
+		limited := gateway.NewLimitedGateway(gw.Mock, gateway.Limits{
+			Timeout:      20 * time.Millisecond,
+			MaxRetries:   2,
+			RetryBackoff: time.Millisecond,
+		})
+
+		_, err := limited.SendSignedTransaction(flowkit.NewTransaction())
+		assert.ErrorIs(t, err, gateway.ErrTimeout)
+		gw.Mock.AssertNumberOfCalls(t, tests.SendSignedTransactionFunc, 1)
+	})
+
+	t.Run("No Resend Unavailable Transaction", func(t *testing.T) {
+		gw := tests.DefaultMockGateway()
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			gw.SendSignedTransaction.Return(nil, status.Error(codes.Unavailable, "unavailable"))
+		})
+		limited := gateway.NewLimitedGateway(gw.Mock, gateway.Limits{
+			MaxRetries:   2,
+			RetryBackoff: time.Millisecond,
+		})
+
+		_, err := limited.SendSignedTransaction(flowkit.NewTransaction())
+		assert.Equal(t, codes.Unavailable, gateway.GRPCCode(err))
+		gw.Mock.AssertNumberOfCalls(t, tests.SendSignedTransactionFunc, 1)
+	})
+
+	t.Run("Rate Limit", func(t *testing.T) {
+		gw := tests.DefaultMockGateway()
+		limited := gateway.NewLimitedGateway(gw.Mock, gateway.Limits{MaxRequestsPerSecond: 50})
+
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			_, err := limited.GetLatestBlock()
+			assert.NoError(t, err)
+		}
+
+		// the first request is sent immediately and the following ones are spaced by 20ms
+		assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+		gw.Mock.AssertNumberOfCalls(t, tests.GetLatestBlockFunc, 5)
+	})
+}
@@ -20,14 +20,19 @@ package flowkit
 
 import (
 	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"os/exec"
 	"regexp"
+	"strings"
 
 	goeth "github.com/ethereum/go-ethereum/accounts"
 	slip10 "github.com/lmars/go-slip10"
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/onflow/flow-go-sdk/crypto/cloudkms"
 	bip39 "github.com/tyler-smith/go-bip39"
@@ -42,6 +47,8 @@ type AccountKey interface {
 	Index() int
 	SigAlgo() crypto.SignatureAlgorithm
 	HashAlgo() crypto.HashAlgorithm
+	Weight() int
+	Revoked() bool
 	Signer(ctx context.Context) (crypto.Signer, error)
 	ToConfig() config.AccountKey
 	Validate() error
@@ -54,17 +61,29 @@ var _ AccountKey = &KmsAccountKey{}
 
 var _ AccountKey = &Bip44AccountKey{}
 
+var _ AccountKey = &EncryptedAccountKey{}
+
+var _ AccountKey = &FileAccountKey{}
+
+var _ AccountKey = &HardwareAccountKey{}
+
+var _ AccountKey = &KeystoreAccountKey{}
+
+// NewAccountKey creates an account key from the configuration.
+//
+// Keys of the file and keystore types are read from the local filesystem.
 func NewAccountKey(accountKeyConf config.AccountKey) (AccountKey, error) {
-	switch accountKeyConf.Type {
-	case config.KeyTypeHex:
-		return newHexAccountKey(accountKeyConf)
-	case config.KeyTypeBip44:
-		return newBip44AccountKey(accountKeyConf)
-	case config.KeyTypeGoogleKMS:
-		return newKmsAccountKey(accountKeyConf)
+	return newAccountKey(accountKeyConf, nil)
+}
+
+// newAccountKey creates the account key with the factory registered for the key type.
+func newAccountKey(accountKeyConf config.AccountKey, readerWriter ReaderWriter) (AccountKey, error) {
+	factory, err := accountKeyFactory(accountKeyConf.Type)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf(`invalid key type: "%s"`, accountKeyConf.Type)
+	return factory(accountKeyConf, readerWriter)
 }
 
 type baseAccountKey struct {
@@ -72,6 +91,8 @@ type baseAccountKey struct {
 	index    int
 	sigAlgo  crypto.SignatureAlgorithm
 	hashAlgo crypto.HashAlgorithm
+	weight   int
+	revoked  bool
 }
 
 func newBaseAccountKey(accountKeyConf config.AccountKey) *baseAccountKey {
@@ -80,6 +101,8 @@ func newBaseAccountKey(accountKeyConf config.AccountKey) *baseAccountKey {
 		index:    accountKeyConf.Index,
 		sigAlgo:  accountKeyConf.SigAlgo,
 		hashAlgo: accountKeyConf.HashAlgo,
+		weight:   accountKeyConf.Weight,
+		revoked:  accountKeyConf.Revoked,
 	}
 }
 
@@ -99,14 +122,36 @@ func (a *baseAccountKey) Index() int {
 	return a.index
 }
 
+// Weight returns the signature weight of the key, keys without a configured weight have the full weight.
+func (a *baseAccountKey) Weight() int {
+	if a.weight == 0 {
+		return flow.AccountKeyWeightThreshold
+	}
+
+	return a.weight
+}
+
+// Revoked returns true if the key is marked as revoked, revoked keys are not used for signing.
+func (a *baseAccountKey) Revoked() bool {
+	return a.revoked
+}
+
+func (a *baseAccountKey) setRevoked() {
+	a.revoked = true
+}
+
 func (a *baseAccountKey) Validate() error {
 	return nil
 }
 
-// KmsAccountKey implements Gcloud KMS system for signing.
+// KmsAccountKey implements signing with keys stored in a key management service.
+//
+// Keys of the google-kms type always use Google Cloud KMS and keys of the aws-kms type AWS KMS,
+// keys of the kms type use the registered KMSProvider supporting the resource ID.
 type KmsAccountKey struct {
 	*baseAccountKey
-	kmsKey cloudkms.Key
+	resourceID string
+	provider   KMSProvider
 }
 
 // ToConfig convert account key to configuration.
@@ -116,29 +161,18 @@ func (a *KmsAccountKey) ToConfig() config.AccountKey {
 		Index:      a.index,
 		SigAlgo:    a.sigAlgo,
 		HashAlgo:   a.hashAlgo,
-		ResourceID: a.kmsKey.ResourceID(),
+		Weight:     a.weight,
+		Revoked:    a.revoked,
+		ResourceID: a.resourceID,
 	}
 }
 
 func (a *KmsAccountKey) Signer(ctx context.Context) (crypto.Signer, error) {
-	kmsClient, err := cloudkms.NewClient(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	accountKMSSigner, err := kmsClient.SignerForKey(
-		ctx,
-		a.kmsKey,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return accountKMSSigner, nil
+	return a.provider.Signer(ctx, a.resourceID)
 }
 
 func (a *KmsAccountKey) Validate() error {
-	return gcloudApplicationSignin(a.kmsKey.ResourceID())
+	return a.provider.Validate(a.resourceID)
 }
 
 func (a *KmsAccountKey) PrivateKey() (*crypto.PrivateKey, error) {
@@ -184,19 +218,30 @@ func gcloudApplicationSignin(resourceID string) error {
 }
 
 func newKmsAccountKey(key config.AccountKey) (AccountKey, error) {
-	accountKMSKey, err := cloudkms.KeyFromResourceID(key.ResourceID)
-	if err != nil {
-		return nil, err
+	var provider KMSProvider
+	switch key.Type {
+	case config.KeyTypeKMS:
+		var err error
+		provider, err = kmsProviderFor(key.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+	case config.KeyTypeAWSKMS:
+		if _, err := parseAWSKeyARN(key.ResourceID); err != nil {
+			return nil, err
+		}
+		provider = awsKMS
+	default:
+		if _, err := cloudkms.KeyFromResourceID(key.ResourceID); err != nil {
+			return nil, err
+		}
+		provider = googleKMS
 	}
 
 	return &KmsAccountKey{
-		baseAccountKey: &baseAccountKey{
-			keyType:  config.KeyTypeGoogleKMS,
-			index:    key.Index,
-			sigAlgo:  key.SigAlgo,
-			hashAlgo: key.HashAlgo,
-		},
-		kmsKey: accountKMSKey,
+		baseAccountKey: newBaseAccountKey(key),
+		resourceID:     key.ResourceID,
+		provider:       provider,
 	}, nil
 }
 
@@ -243,6 +288,8 @@ func (a *HexAccountKey) ToConfig() config.AccountKey {
 		Index:      a.index,
 		SigAlgo:    a.sigAlgo,
 		HashAlgo:   a.hashAlgo,
+		Weight:     a.weight,
+		Revoked:    a.revoked,
 		PrivateKey: a.privateKey,
 	}
 }
@@ -275,6 +322,7 @@ func newBip44AccountKey(key config.AccountKey) (AccountKey, error) {
 			index:    key.Index,
 			sigAlgo:  key.SigAlgo,
 			hashAlgo: key.HashAlgo,
+			weight:   key.Weight,
 		},
 		derivationPath: key.DerivationPath,
 		mnemonic:       key.Mnemonic,
@@ -295,6 +343,8 @@ func (a *Bip44AccountKey) ToConfig() config.AccountKey {
 		Index:          a.index,
 		SigAlgo:        a.sigAlgo,
 		HashAlgo:       a.hashAlgo,
+		Weight:         a.weight,
+		Revoked:        a.revoked,
 		PrivateKey:     a.privateKey,
 		Mnemonic:       a.mnemonic,
 		DerivationPath: a.derivationPath,
@@ -339,3 +389,290 @@ func (a *Bip44AccountKey) Validate() error {
 func (a *Bip44AccountKey) PrivateKeyHex() string {
 	return hex.EncodeToString(a.privateKey.Encode())
 }
+
+// HardwareAccountKey implements an account key stored on a Ledger hardware wallet.
+//
+// The key is identified by the derivation path on the device, which must be connected
+// with the Flow app open when signing, and every transaction is confirmed on the device.
+type HardwareAccountKey struct {
+	*baseAccountKey
+	derivationPath string
+}
+
+func newHardwareAccountKey(key config.AccountKey) *HardwareAccountKey {
+	return &HardwareAccountKey{
+		baseAccountKey: newBaseAccountKey(key),
+		derivationPath: key.DerivationPath,
+	}
+}
+
+func (a *HardwareAccountKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	return newLedgerSigner(a.derivationPath, a.sigAlgo, a.hashAlgo)
+}
+
+func (a *HardwareAccountKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible, the key is stored on a hardware wallet")
+}
+
+func (a *HardwareAccountKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:           a.keyType,
+		Index:          a.index,
+		SigAlgo:        a.sigAlgo,
+		HashAlgo:       a.hashAlgo,
+		Weight:         a.weight,
+		Revoked:        a.revoked,
+		DerivationPath: a.derivationPath,
+	}
+}
+
+// Validate checks the derivation path and algorithms, the device is only accessed when signing.
+func (a *HardwareAccountKey) Validate() error {
+	_, err := ledgerKeyData(a.derivationPath, a.sigAlgo, a.hashAlgo)
+	if err != nil {
+		return fmt.Errorf("invalid hardware key: %w", err)
+	}
+
+	return nil
+}
+
+// FileAccountKey implements an account key stored in a separate file.
+//
+// The file contains a hex or PEM encoded private key and is only read when the key is used,
+// the configuration only references the file location.
+type FileAccountKey struct {
+	*baseAccountKey
+	location     string
+	readerWriter ReaderWriter
+	privateKey   *crypto.PrivateKey
+}
+
+func newFileAccountKey(key config.AccountKey, readerWriter ReaderWriter) *FileAccountKey {
+	return &FileAccountKey{
+		baseAccountKey: newBaseAccountKey(key),
+		location:       key.Location,
+		readerWriter:   readerWriter,
+	}
+}
+
+func (a *FileAccountKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	privateKey, err := a.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewInMemorySigner(*privateKey, a.HashAlgo())
+}
+
+func (a *FileAccountKey) PrivateKey() (*crypto.PrivateKey, error) {
+	if a.privateKey != nil {
+		return a.privateKey, nil
+	}
+
+	var content []byte
+	var err error
+	if a.readerWriter != nil {
+		content, err = a.readerWriter.ReadFile(a.location)
+	} else {
+		content, err = os.ReadFile(a.location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read the account key file %s: %w", a.location, err)
+	}
+
+	privateKey, err := decodePrivateKey(a.sigAlgo, content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key in the account key file %s: %w", a.location, err)
+	}
+
+	a.privateKey = &privateKey
+	return a.privateKey, nil
+}
+
+// ToConfig only references the key file, the private key is never included in the configuration.
+func (a *FileAccountKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:     a.keyType,
+		Index:    a.index,
+		SigAlgo:  a.sigAlgo,
+		HashAlgo: a.hashAlgo,
+		Weight:   a.weight,
+		Revoked:  a.revoked,
+		Location: a.location,
+	}
+}
+
+func (a *FileAccountKey) Validate() error {
+	_, err := a.PrivateKey()
+	return err
+}
+
+// decodePrivateKey decodes a hex or PEM encoded private key.
+//
+// PEM encoded keys must be ECDSA keys in the SEC 1 or PKCS #8 format.
+func decodePrivateKey(sigAlgo crypto.SignatureAlgorithm, content []byte) (crypto.PrivateKey, error) {
+	data := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(data, "-----BEGIN") {
+		return crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(data, "0x"))
+	}
+
+	if sigAlgo != crypto.ECDSA_P256 && sigAlgo != crypto.ECDSA_secp256k1 {
+		return nil, fmt.Errorf("only ECDSA private keys can be PEM encoded")
+	}
+
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM block")
+	}
+
+	der := block.Bytes
+	if block.Type == "PRIVATE KEY" {
+		var pkcs8 struct {
+			Version    int
+			Algo       pkix.AlgorithmIdentifier
+			PrivateKey []byte
+		}
+		if _, err := asn1.Unmarshal(der, &pkcs8); err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS #8 private key: %w", err)
+		}
+		der = pkcs8.PrivateKey
+	}
+
+	var ecKey struct {
+		Version       int
+		PrivateKey    []byte
+		NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+		PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+	}
+	if _, err := asn1.Unmarshal(der, &ecKey); err != nil {
+		return nil, fmt.Errorf("failed to parse EC private key: %w", err)
+	}
+
+	// the private scalar might be encoded without the leading zeros
+	scalar := ecKey.PrivateKey
+	if len(scalar) < ecdsaPrivateKeyLength {
+		scalar = append(make([]byte, ecdsaPrivateKeyLength-len(scalar)), scalar...)
+	}
+
+	return crypto.DecodePrivateKey(sigAlgo, scalar)
+}
+
+// ecdsaPrivateKeyLength is the length of the private scalar of the supported ECDSA curves.
+const ecdsaPrivateKeyLength = 32
+
+// PassphrasePrompt asks for the passphrase of the encrypted keys if it is not set in the environment.
+//
+// The prompt is not set by default, so the passphrase must be provided with the environment variable.
+var PassphrasePrompt func() (string, error)
+
+// EncryptedAccountKey implements a private key encrypted with a passphrase.
+//
+// The private key is only decrypted in memory when the key is used, and the configuration
+// always contains the encrypted private key, so the decrypted key is never saved.
+type EncryptedAccountKey struct {
+	*baseAccountKey
+	encrypted  config.EncryptedKey
+	privateKey crypto.PrivateKey
+}
+
+// NewEncryptedAccountKey encrypts the private key with the passphrase.
+func NewEncryptedAccountKey(
+	index int,
+	hashAlgo crypto.HashAlgorithm,
+	privateKey crypto.PrivateKey,
+	passphrase string,
+) (*EncryptedAccountKey, error) {
+	encrypted, err := EncryptPrivateKey(privateKey, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedAccountKey{
+		baseAccountKey: &baseAccountKey{
+			keyType:  config.KeyTypeEncrypted,
+			index:    index,
+			sigAlgo:  privateKey.Algorithm(),
+			hashAlgo: hashAlgo,
+		},
+		encrypted:  encrypted,
+		privateKey: privateKey,
+	}, nil
+}
+
+func newEncryptedAccountKey(key config.AccountKey) *EncryptedAccountKey {
+	return &EncryptedAccountKey{
+		baseAccountKey: newBaseAccountKey(key),
+		encrypted:      key.Encrypted,
+	}
+}
+
+func (a *EncryptedAccountKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	privateKey, err := a.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewInMemorySigner(*privateKey, a.HashAlgo())
+}
+
+// PrivateKey returns the decrypted private key, the passphrase is requested the first time the key is used.
+func (a *EncryptedAccountKey) PrivateKey() (*crypto.PrivateKey, error) {
+	if a.privateKey != nil {
+		return &a.privateKey, nil
+	}
+
+	passphrase, err := keyPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.Unlock(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &a.privateKey, nil
+}
+
+// Unlock decrypts the private key with the passphrase and keeps it in memory.
+func (a *EncryptedAccountKey) Unlock(passphrase string) error {
+	privateKey, err := DecryptPrivateKey(a.encrypted, a.sigAlgo, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt the account key: %w", err)
+	}
+
+	a.privateKey = privateKey
+	return nil
+}
+
+// keyPassphrase returns the passphrase of the encrypted keys from the environment or the prompt.
+func keyPassphrase() (string, error) {
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	if PassphrasePrompt == nil {
+		return "", fmt.Errorf("the account key is encrypted, provide the passphrase with the %s environment variable", PassphraseEnv)
+	}
+
+	return PassphrasePrompt()
+}
+
+// ToConfig converts the account key to configuration, only the encrypted private key is included.
+func (a *EncryptedAccountKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:      a.keyType,
+		Index:     a.index,
+		SigAlgo:   a.sigAlgo,
+		HashAlgo:  a.hashAlgo,
+		Weight:    a.weight,
+		Revoked:   a.revoked,
+		Encrypted: a.encrypted,
+	}
+}
+
+// Validate decrypts the private key, so invalid passphrases are reported before the key is used.
+func (a *EncryptedAccountKey) Validate() error {
+	_, err := a.PrivateKey()
+	return err
+}
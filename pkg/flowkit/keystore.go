@@ -0,0 +1,335 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// KeystoreDir is the directory of the keystore, relative to the working directory.
+const KeystoreDir = ".flow/keystore"
+
+// keystoreVersion is the version of the keystore file format, increased when the format changes.
+const keystoreVersion = 1
+
+var keystoreNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// KeystoreReaderWriter is the file system of the keystore, which also creates and lists the keystore directory.
+type KeystoreReaderWriter interface {
+	ReaderWriter
+	MkdirAll(path string, perm os.FileMode) error
+	ReadDir(dirname string) ([]os.FileInfo, error)
+}
+
+// KeystoreEntry is a private key stored encrypted in the keystore.
+type KeystoreEntry struct {
+	Name      string
+	SigAlgo   crypto.SignatureAlgorithm
+	PublicKey crypto.PublicKey
+	encrypted config.EncryptedKey
+}
+
+// Unlock decrypts the private key of the entry.
+//
+// ErrInvalidPassphrase is returned if the passphrase doesn't match the one used for encryption.
+func (e *KeystoreEntry) Unlock(passphrase string) (crypto.PrivateKey, error) {
+	privateKey, err := DecryptPrivateKey(e.encrypted, e.SigAlgo, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock the keystore entry %s: %w", e.Name, err)
+	}
+
+	return privateKey, nil
+}
+
+// keystoreFile is the JSON file of a keystore entry.
+type keystoreFile struct {
+	Version   int            `json:"version"`
+	Name      string         `json:"name"`
+	SigAlgo   string         `json:"signatureAlgorithm"`
+	PublicKey string         `json:"publicKey"`
+	Crypto    keystoreCrypto `json:"crypto"`
+}
+
+type keystoreCrypto struct {
+	Cipher     string      `json:"cipher"`
+	Ciphertext string      `json:"ciphertext"`
+	Nonce      string      `json:"nonce"`
+	KDF        keystoreKDF `json:"kdf"`
+}
+
+type keystoreKDF struct {
+	Name string `json:"name"`
+	Salt string `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+}
+
+// KeystorePath returns the location of the keystore entry file.
+func KeystorePath(name string) string {
+	return filepath.Join(KeystoreDir, fmt.Sprintf("%s.json", name))
+}
+
+// AddKeystoreEntry encrypts the private key with the passphrase and writes it to the keystore.
+//
+// Existing entries are not overwritten, the name can only contain letters, digits, dashes and underscores.
+func AddKeystoreEntry(
+	readerWriter KeystoreReaderWriter,
+	name string,
+	privateKey crypto.PrivateKey,
+	passphrase string,
+) (*KeystoreEntry, error) {
+	if !keystoreNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("invalid keystore entry name %s, only letters, digits, dashes and underscores are allowed", name)
+	}
+
+	if _, err := readerWriter.ReadFile(KeystorePath(name)); err == nil {
+		return nil, fmt.Errorf("keystore entry %s already exists", name)
+	}
+
+	encrypted, err := EncryptPrivateKey(privateKey, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &KeystoreEntry{
+		Name:      name,
+		SigAlgo:   privateKey.Algorithm(),
+		PublicKey: privateKey.PublicKey(),
+		encrypted: encrypted,
+	}
+
+	data, err := json.MarshalIndent(keystoreFile{
+		Version:   keystoreVersion,
+		Name:      entry.Name,
+		SigAlgo:   entry.SigAlgo.String(),
+		PublicKey: hex.EncodeToString(entry.PublicKey.Encode()),
+		Crypto: keystoreCrypto{
+			Cipher:     encrypted.Cipher,
+			Ciphertext: encrypted.Ciphertext,
+			Nonce:      encrypted.Nonce,
+			KDF: keystoreKDF{
+				Name: encrypted.KDF.Name,
+				Salt: encrypted.KDF.Salt,
+				N:    encrypted.KDF.N,
+				R:    encrypted.KDF.R,
+				P:    encrypted.KDF.P,
+			},
+		},
+	}, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	err = readerWriter.MkdirAll(KeystoreDir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the keystore directory: %w", err)
+	}
+
+	err = readerWriter.WriteFile(KeystorePath(name), data, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the keystore entry %s: %w", name, err)
+	}
+
+	return entry, nil
+}
+
+// ReadKeystoreEntry reads the keystore entry, the private key stays encrypted until the entry is unlocked.
+func ReadKeystoreEntry(readerWriter ReaderWriter, name string) (*KeystoreEntry, error) {
+	var data []byte
+	var err error
+	if readerWriter != nil {
+		data, err = readerWriter.ReadFile(KeystorePath(name))
+	} else {
+		data, err = os.ReadFile(KeystorePath(name))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read the keystore entry %s: %w", name, err)
+	}
+
+	var file keystoreFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore entry %s: %w", name, err)
+	}
+
+	if file.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported version %d of the keystore entry %s, only version %d is supported", file.Version, name, keystoreVersion)
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(file.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm %s of the keystore entry %s", file.SigAlgo, name)
+	}
+
+	publicKey, err := crypto.DecodePublicKeyHex(sigAlgo, file.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of the keystore entry %s: %w", name, err)
+	}
+
+	return &KeystoreEntry{
+		Name:      name,
+		SigAlgo:   sigAlgo,
+		PublicKey: publicKey,
+		encrypted: config.EncryptedKey{
+			Cipher:     file.Crypto.Cipher,
+			Ciphertext: file.Crypto.Ciphertext,
+			Nonce:      file.Crypto.Nonce,
+			KDF: config.KDF{
+				Name: file.Crypto.KDF.Name,
+				Salt: file.Crypto.KDF.Salt,
+				N:    file.Crypto.KDF.N,
+				R:    file.Crypto.KDF.R,
+				P:    file.Crypto.KDF.P,
+			},
+		},
+	}, nil
+}
+
+// ListKeystoreEntries returns the entries of the keystore sorted by name, an empty list if the keystore doesn't exist.
+func ListKeystoreEntries(readerWriter KeystoreReaderWriter) ([]*KeystoreEntry, error) {
+	files, err := readerWriter.ReadDir(KeystoreDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the keystore: %w", err)
+	}
+
+	entries := make([]*KeystoreEntry, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		entry, err := ReadKeystoreEntry(readerWriter, strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries, nil
+}
+
+// unlockedKeystoreKeys caches the decrypted keystore keys by name, so the passphrase is asked once per process.
+var unlockedKeystoreKeys = struct {
+	mu   sync.Mutex
+	keys map[string]crypto.PrivateKey
+}{keys: make(map[string]crypto.PrivateKey)}
+
+// KeystoreAccountKey implements an account key referencing an entry of the keystore.
+//
+// The entry is read and decrypted when the key is first used, and the decrypted key
+// is only kept in memory.
+type KeystoreAccountKey struct {
+	*baseAccountKey
+	name         string
+	readerWriter ReaderWriter
+}
+
+func newKeystoreAccountKey(key config.AccountKey, readerWriter ReaderWriter) *KeystoreAccountKey {
+	return &KeystoreAccountKey{
+		baseAccountKey: newBaseAccountKey(key),
+		name:           key.Keystore,
+		readerWriter:   readerWriter,
+	}
+}
+
+func (a *KeystoreAccountKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	privateKey, err := a.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewInMemorySigner(*privateKey, a.HashAlgo())
+}
+
+// PrivateKey returns the decrypted private key of the entry, the passphrase is requested the first time the key is used.
+func (a *KeystoreAccountKey) PrivateKey() (*crypto.PrivateKey, error) {
+	unlockedKeystoreKeys.mu.Lock()
+	defer unlockedKeystoreKeys.mu.Unlock()
+
+	if privateKey, ok := unlockedKeystoreKeys.keys[a.name]; ok {
+		return &privateKey, nil
+	}
+
+	entry, err := ReadKeystoreEntry(a.readerWriter, a.name)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.SigAlgo != a.sigAlgo {
+		return nil, fmt.Errorf(
+			"keystore entry %s uses %s, but the account key uses %s",
+			a.name,
+			entry.SigAlgo,
+			a.sigAlgo,
+		)
+	}
+
+	passphrase, err := keyPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := entry.Unlock(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	unlockedKeystoreKeys.keys[a.name] = privateKey
+	return &privateKey, nil
+}
+
+func (a *KeystoreAccountKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:     a.keyType,
+		Index:    a.index,
+		SigAlgo:  a.sigAlgo,
+		HashAlgo: a.hashAlgo,
+		Weight:   a.weight,
+		Revoked:  a.revoked,
+		Keystore: a.name,
+	}
+}
+
+// Validate decrypts the private key, so invalid passphrases are reported before the key is used.
+func (a *KeystoreAccountKey) Validate() error {
+	_, err := a.PrivateKey()
+	return err
+}
@@ -0,0 +1,136 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+func Test_Keystore(t *testing.T) {
+	privateKey, err := crypto.DecodePrivateKeyHex(
+		crypto.ECDSA_P256,
+		"dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47",
+	)
+	require.NoError(t, err)
+
+	rw := afero.Afero{Fs: afero.NewMemMapFs()}
+
+	entry, err := AddKeystoreEntry(rw, "alice", privateKey, "secret")
+	require.NoError(t, err)
+	assert.True(t, privateKey.PublicKey().Equals(entry.PublicKey))
+
+	t.Run("File format", func(t *testing.T) {
+		data, err := rw.ReadFile(".flow/keystore/alice.json")
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+
+		var file map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &file))
+		assert.Equal(t, float64(1), file["version"])
+		assert.Equal(t, "ECDSA_P256", file["signatureAlgorithm"])
+
+		info, err := rw.Stat(".flow/keystore/alice.json")
+		require.NoError(t, err)
+		assert.Equal(t, "-rw-------", info.Mode().String())
+	})
+
+	t.Run("Fail existing entry", func(t *testing.T) {
+		_, err := AddKeystoreEntry(rw, "alice", privateKey, "secret")
+		assert.EqualError(t, err, "keystore entry alice already exists")
+	})
+
+	t.Run("Fail invalid name", func(t *testing.T) {
+		_, err := AddKeystoreEntry(rw, "../alice", privateKey, "secret")
+		assert.EqualError(t, err, "invalid keystore entry name ../alice, only letters, digits, dashes and underscores are allowed")
+	})
+
+	t.Run("List", func(t *testing.T) {
+		_, err := AddKeystoreEntry(rw, "bob", privateKey, "other")
+		require.NoError(t, err)
+
+		entries, err := ListKeystoreEntries(rw)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "alice", entries[0].Name)
+		assert.Equal(t, "bob", entries[1].Name)
+
+		empty, err := ListKeystoreEntries(afero.Afero{Fs: afero.NewMemMapFs()})
+		require.NoError(t, err)
+		assert.Empty(t, empty)
+	})
+
+	t.Run("Fail unsupported version", func(t *testing.T) {
+		err := rw.WriteFile(".flow/keystore/future.json", []byte(`{"version":2,"name":"future"}`), 0600)
+		require.NoError(t, err)
+
+		_, err = ReadKeystoreEntry(rw, "future")
+		assert.EqualError(t, err, "unsupported version 2 of the keystore entry future, only version 1 is supported")
+		require.NoError(t, rw.Remove(".flow/keystore/future.json"))
+	})
+
+	t.Run("Account key", func(t *testing.T) {
+		conf := config.AccountKey{
+			Type:     config.KeyTypeKeystore,
+			SigAlgo:  crypto.ECDSA_P256,
+			HashAlgo: crypto.SHA3_256,
+			Keystore: "alice",
+		}
+
+		key, err := newAccountKey(conf, rw)
+		require.NoError(t, err)
+		assert.Equal(t, conf, key.ToConfig())
+
+		t.Setenv(PassphraseEnv, "wrong")
+		_, err = key.Signer(context.Background())
+		assert.ErrorIs(t, err, ErrInvalidPassphrase)
+
+		t.Setenv(PassphraseEnv, "secret")
+		decrypted, err := key.PrivateKey()
+		require.NoError(t, err)
+		assert.True(t, privateKey.Equals(*decrypted))
+
+		// the decrypted key is cached for the other keys of the entry
+		t.Setenv(PassphraseEnv, "wrong")
+		other, err := newAccountKey(conf, rw)
+		require.NoError(t, err)
+		require.NoError(t, other.Validate())
+	})
+
+	t.Run("Fail signature algorithm mismatch", func(t *testing.T) {
+		key, err := newAccountKey(config.AccountKey{
+			Type:     config.KeyTypeKeystore,
+			SigAlgo:  crypto.ECDSA_secp256k1,
+			HashAlgo: crypto.SHA3_256,
+			Keystore: "bob",
+		}, rw)
+		require.NoError(t, err)
+
+		err = key.Validate()
+		assert.EqualError(t, err, "keystore entry bob uses ECDSA_P256, but the account key uses ECDSA_secp256k1")
+	})
+}
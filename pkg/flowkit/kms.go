@@ -0,0 +1,242 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"sync"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/crypto/cloudkms"
+	"google.golang.org/api/option"
+	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// KMSProvider resolves signers for account keys stored in a key management service.
+type KMSProvider interface {
+	// Supports returns true if the resource ID identifies a key managed by the provider.
+	Supports(resourceID string) bool
+	// Validate checks the key can be accessed, for example by signing in to the service.
+	Validate(resourceID string) error
+	// Signer returns a signer using the key identified by the resource ID.
+	Signer(ctx context.Context, resourceID string) (crypto.Signer, error)
+}
+
+// kmsProviders is the registry of providers used to resolve keys of the kms type.
+var kmsProviders = []KMSProvider{googleKMS, awsKMS}
+
+// RegisterKMSProvider adds a provider used to resolve keys of the kms type.
+//
+// Providers are matched in the order of registration, after the built-in Google and AWS KMS providers.
+func RegisterKMSProvider(provider KMSProvider) {
+	kmsProviders = append(kmsProviders, provider)
+}
+
+func kmsProviderFor(resourceID string) (KMSProvider, error) {
+	for _, provider := range kmsProviders {
+		if provider.Supports(resourceID) {
+			return provider, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no key management service provider supports the resource ID %s", resourceID)
+}
+
+// googleKMS is the provider shared by all the keys stored in Google Cloud KMS, so the client
+// and the public keys are reused when signing with the same keys multiple times.
+var googleKMS = &googleKMSProvider{}
+
+// googleKMSProvider resolves keys stored in Google Cloud KMS.
+type googleKMSProvider struct {
+	clientOptions []option.ClientOption // used to connect to other endpoints in tests
+
+	mu         sync.Mutex
+	client     *kms.KeyManagementClient
+	publicKeys map[string]crypto.PublicKey // public keys by the resource ID
+}
+
+func (g *googleKMSProvider) Supports(resourceID string) bool {
+	_, err := cloudkms.KeyFromResourceID(resourceID)
+	return err == nil
+}
+
+func (g *googleKMSProvider) Validate(resourceID string) error {
+	return gcloudApplicationSignin(resourceID)
+}
+
+func (g *googleKMSProvider) Signer(ctx context.Context, resourceID string) (crypto.Signer, error) {
+	if _, err := cloudkms.KeyFromResourceID(resourceID); err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.client == nil {
+		client, err := kms.NewKeyManagementClient(ctx, g.clientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Google Cloud KMS: %w", err)
+		}
+		g.client = client
+	}
+
+	publicKey, ok := g.publicKeys[resourceID]
+	if !ok {
+		var err error
+		publicKey, err = g.fetchPublicKey(ctx, resourceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if g.publicKeys == nil {
+			g.publicKeys = make(map[string]crypto.PublicKey)
+		}
+		g.publicKeys[resourceID] = publicKey
+	}
+
+	return &googleKMSSigner{
+		ctx:        ctx,
+		client:     g.client,
+		resourceID: resourceID,
+		publicKey:  publicKey,
+	}, nil
+}
+
+func (g *googleKMSProvider) fetchPublicKey(ctx context.Context, resourceID string) (crypto.PublicKey, error) {
+	result, err := g.client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: resourceID})
+	if err != nil {
+		return nil, kmsError("failed to get the public key of", resourceID, err)
+	}
+
+	sigAlgo := cloudkms.ParseSignatureAlgorithm(result.Algorithm)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm || cloudkms.ParseHashAlgorithm(result.Algorithm) != crypto.SHA2_256 {
+		return nil, fmt.Errorf(
+			"unsupported algorithm %s of KMS key %s, only EC_SIGN_P256_SHA256 and EC_SIGN_SECP256K1_SHA256 are supported",
+			result.Algorithm,
+			resourceID,
+		)
+	}
+
+	_, publicKey, err := DecodeKeyPEM([]byte(result.Pem))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the public key of KMS key %s: %w", resourceID, err)
+	}
+
+	return publicKey, nil
+}
+
+// kmsError includes the resource ID in errors of the KMS API, and explains the missing permissions.
+func kmsError(action string, resourceID string, err error) error {
+	if status.Code(err) == codes.PermissionDenied {
+		return fmt.Errorf(
+			"%s KMS key %s, permission denied, make sure the credentials have the "+
+				"cloudkms.cryptoKeyVersions.viewPublicKey and cloudkms.cryptoKeyVersions.useToSign permissions: %w",
+			action,
+			resourceID,
+			err,
+		)
+	}
+	return fmt.Errorf("%s KMS key %s: %w", action, resourceID, err)
+}
+
+var _ crypto.Signer = &googleKMSSigner{}
+
+// googleKMSSigner signs with an ECDSA key stored in Google Cloud KMS using SHA2-256.
+type googleKMSSigner struct {
+	ctx        context.Context
+	client     *kms.KeyManagementClient
+	resourceID string
+	publicKey  crypto.PublicKey
+}
+
+// kmsMaxDataLength is the maximum length of messages hashed by KMS, longer messages are hashed before signing.
+const kmsMaxDataLength = 64 * 1024
+
+func (s *googleKMSSigner) Sign(message []byte) ([]byte, error) {
+	request := &kmspb.AsymmetricSignRequest{Name: s.resourceID}
+	if len(message) <= kmsMaxDataLength {
+		request.Data = message
+		request.DataCrc32C = crc32c(message)
+	} else {
+		digest := sha256.Sum256(message)
+		request.Digest = &kmspb.Digest{Digest: &kmspb.Digest_Sha256{Sha256: digest[:]}}
+		request.DigestCrc32C = crc32c(digest[:])
+	}
+
+	result, err := s.client.AsymmetricSign(s.ctx, request)
+	if err != nil {
+		return nil, kmsError("failed to sign with", s.resourceID, err)
+	}
+
+	verified := result.VerifiedDataCrc32C
+	if request.Digest != nil {
+		verified = result.VerifiedDigestCrc32C
+	}
+	if !verified || result.SignatureCrc32C.GetValue() != crc32c(result.Signature).GetValue() {
+		return nil, fmt.Errorf("failed to sign with KMS key %s: the request or response was corrupted in transit", s.resourceID)
+	}
+
+	signature, err := rawSignature(result.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with KMS key %s: %w", s.resourceID, err)
+	}
+
+	return signature, nil
+}
+
+func (s *googleKMSSigner) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+func crc32c(data []byte) *wrapperspb.Int64Value {
+	return wrapperspb.Int64(int64(crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))))
+}
+
+// signatureScalarLength is the length in bytes of the signature scalars on the supported curves.
+const signatureScalarLength = 32
+
+// rawSignature converts the ASN.1 encoded ECDSA signature returned by KMS to the
+// concatenation of the scalars r and s padded to the curve order length, which is used by Flow.
+func rawSignature(der []byte) ([]byte, error) {
+	var parsed struct{ R, S *big.Int }
+	rest, err := asn1.Unmarshal(der, &parsed)
+	if err != nil || len(rest) > 0 {
+		return nil, fmt.Errorf("invalid signature encoding")
+	}
+
+	if parsed.R.Sign() <= 0 || parsed.S.Sign() <= 0 ||
+		parsed.R.BitLen() > signatureScalarLength*8 || parsed.S.BitLen() > signatureScalarLength*8 {
+		return nil, fmt.Errorf("invalid signature values")
+	}
+
+	signature := make([]byte, 2*signatureScalarLength)
+	parsed.R.FillBytes(signature[:signatureScalarLength])
+	parsed.S.FillBytes(signature[signatureScalarLength:])
+
+	return signature, nil
+}
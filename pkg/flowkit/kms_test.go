@@ -0,0 +1,188 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	kmspb "google.golang.org/genproto/googleapis/cloud/kms/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	kmsResourceID       = "projects/flow/locations/global/keyRings/ring/cryptoKeys/signer/cryptoKeyVersions/1"
+	kmsDeniedResourceID = "projects/flow/locations/global/keyRings/ring/cryptoKeys/denied/cryptoKeyVersions/1"
+)
+
+// fakeKMS implements the signing API of Google Cloud KMS with a P-256 key like KMS,
+// returning ASN.1 encoded signatures.
+type fakeKMS struct {
+	kmspb.UnimplementedKeyManagementServiceServer
+	key            *ecdsa.PrivateKey
+	publicKeyCalls int
+}
+
+func (f *fakeKMS) GetPublicKey(_ context.Context, req *kmspb.GetPublicKeyRequest) (*kmspb.PublicKey, error) {
+	if req.Name == kmsDeniedResourceID {
+		return nil, status.Errorf(codes.PermissionDenied, "Permission 'cloudkms.cryptoKeyVersions.viewPublicKey' denied on resource '%s'", req.Name)
+	}
+	f.publicKeyCalls++
+
+	der, err := x509.MarshalPKIXPublicKey(&f.key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kmspb.PublicKey{
+		Name:      req.Name,
+		Algorithm: kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256,
+		Pem:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}, nil
+}
+
+func (f *fakeKMS) AsymmetricSign(_ context.Context, req *kmspb.AsymmetricSignRequest) (*kmspb.AsymmetricSignResponse, error) {
+	digest := req.GetDigest().GetSha256()
+	if req.Data != nil {
+		hash := sha256.Sum256(req.Data)
+		digest = hash[:]
+	}
+
+	signature, err := ecdsa.SignASN1(rand.Reader, f.key, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kmspb.AsymmetricSignResponse{
+		Name:                 req.Name,
+		Signature:            signature,
+		SignatureCrc32C:      crc32c(signature),
+		VerifiedDataCrc32C:   req.Data != nil,
+		VerifiedDigestCrc32C: req.Digest != nil,
+	}, nil
+}
+
+func startFakeKMS(t *testing.T) (*fakeKMS, *googleKMSProvider) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	fake := &fakeKMS{key: key}
+	server := grpc.NewServer()
+	kmspb.RegisterKeyManagementServiceServer(server, fake)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	provider := &googleKMSProvider{
+		clientOptions: []option.ClientOption{
+			option.WithEndpoint(listener.Addr().String()),
+			option.WithoutAuthentication(),
+			option.WithGRPCDialOption(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		},
+	}
+
+	return fake, provider
+}
+
+func TestGoogleKMSSigner(t *testing.T) {
+	fake, provider := startFakeKMS(t)
+
+	signer, err := provider.Signer(context.Background(), kmsResourceID)
+	require.NoError(t, err)
+
+	publicKey := signer.PublicKey()
+	assert.Equal(t, crypto.ECDSA_P256, publicKey.Algorithm())
+
+	t.Run("Raw signature", func(t *testing.T) {
+		for _, message := range [][]byte{
+			[]byte("flow transaction"),
+			make([]byte, kmsMaxDataLength+1), // hashed before signing
+		} {
+			signature, err := signer.Sign(message)
+			require.NoError(t, err)
+
+			// r || s instead of the ASN.1 encoding
+			assert.Len(t, signature, 64)
+
+			valid, err := publicKey.Verify(signature, message, crypto.NewSHA2_256())
+			require.NoError(t, err)
+			assert.True(t, valid)
+		}
+	})
+
+	t.Run("Cached public key", func(t *testing.T) {
+		_, err := provider.Signer(context.Background(), kmsResourceID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, fake.publicKeyCalls)
+	})
+
+	t.Run("Fail permission denied", func(t *testing.T) {
+		_, err := provider.Signer(context.Background(), kmsDeniedResourceID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get the public key of KMS key "+kmsDeniedResourceID+", permission denied")
+	})
+}
+
+func TestRawSignature(t *testing.T) {
+	t.Run("Padded scalars", func(t *testing.T) {
+		der, err := asn1Signature(big.NewInt(1), big.NewInt(2))
+		require.NoError(t, err)
+
+		signature, err := rawSignature(der)
+		require.NoError(t, err)
+
+		expected := make([]byte, 64)
+		expected[31] = 1
+		expected[63] = 2
+		assert.Equal(t, expected, signature)
+	})
+
+	t.Run("Fail invalid", func(t *testing.T) {
+		_, err := rawSignature([]byte{0x30, 0x01})
+		assert.EqualError(t, err, "invalid signature encoding")
+
+		der, err := asn1Signature(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(2))
+		require.NoError(t, err)
+
+		_, err = rawSignature(der)
+		assert.EqualError(t, err, "invalid signature values")
+	})
+}
+
+func asn1Signature(r, s *big.Int) ([]byte, error) {
+	return asn1.Marshal(struct{ R, S *big.Int }{r, s})
+}
@@ -0,0 +1,352 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	goeth "github.com/ethereum/go-ethereum/accounts"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+var (
+	// ErrLedgerNotConnected is returned if no Ledger device is connected.
+	ErrLedgerNotConnected = errors.New("no Ledger device is connected, connect the device and open the Flow app")
+	// ErrLedgerRejected is returned if the user rejects the transaction on the Ledger device.
+	ErrLedgerRejected = errors.New("the transaction was rejected on the Ledger device")
+)
+
+// ProgressLogger shows the progress of long-running operations, like the confirmation on a hardware wallet.
+type ProgressLogger interface {
+	StartProgress(string)
+	StopProgress()
+}
+
+// HardwareLogger shows the confirmation of the transactions on hardware wallets.
+//
+// The logger is not set by default, so the confirmation is not shown.
+var HardwareLogger ProgressLogger
+
+// ledgerDevice exchanges APDU commands with a Ledger device, the replies include the status word.
+type ledgerDevice interface {
+	Exchange(apdu []byte) ([]byte, error)
+	Close() error
+}
+
+// openLedgerDevice opens the connected Ledger device, replaced in tests.
+//
+// The USB devices are only supported in builds with the ledger tag, as they require cgo.
+var openLedgerDevice = openUSBLedger
+
+const (
+	ledgerPacketSize = 64
+	ledgerChannel    = 0x0101
+	ledgerTagAPDU    = 0x05
+)
+
+// usbLedger implements the HID transport of Ledger devices.
+type usbLedger struct {
+	device io.ReadWriteCloser
+}
+
+// Exchange sends the APDU in packets prefixed with the channel, tag and sequence number,
+// with the length of the APDU in the first packet, and reads the reply in the same framing.
+func (u *usbLedger) Exchange(apdu []byte) ([]byte, error) {
+	data := make([]byte, 2, len(apdu)+2)
+	binary.BigEndian.PutUint16(data, uint16(len(apdu)))
+	data = append(data, apdu...)
+	for seq := uint16(0); len(data) > 0; seq++ {
+		packet := make([]byte, ledgerPacketSize)
+		header := ledgerPacketHeader(seq)
+		copy(packet, header)
+		data = data[copy(packet[len(header):], data):]
+
+		if _, err := u.device.Write(packet); err != nil {
+			return nil, fmt.Errorf("failed to write to the Ledger device: %w", err)
+		}
+	}
+
+	var reply []byte
+	length := -1
+	packet := make([]byte, ledgerPacketSize)
+	for seq := uint16(0); length < 0 || len(reply) < length; seq++ {
+		if _, err := io.ReadFull(u.device, packet); err != nil {
+			return nil, fmt.Errorf("failed to read from the Ledger device: %w", err)
+		}
+
+		header := ledgerPacketHeader(seq)
+		if !bytes.Equal(packet[:len(header)], header) {
+			return nil, fmt.Errorf("invalid reply from the Ledger device")
+		}
+
+		payload := packet[len(header):]
+		if seq == 0 {
+			length = int(binary.BigEndian.Uint16(payload))
+			payload = payload[2:]
+		}
+		reply = append(reply, payload...)
+	}
+
+	return reply[:length], nil
+}
+
+func (u *usbLedger) Close() error {
+	return u.device.Close()
+}
+
+func ledgerPacketHeader(seq uint16) []byte {
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint16(header, ledgerChannel)
+	header[2] = ledgerTagAPDU
+	binary.BigEndian.PutUint16(header[3:], seq)
+	return header
+}
+
+// Flow app commands, see https://github.com/onflow/ledger-app-flow/blob/main/docs/APDUSPEC.md
+const (
+	ledgerCLA          = 0x33
+	ledgerInsPublicKey = 0x01
+	ledgerInsSign      = 0x02
+
+	ledgerSignInit = 0x00
+	ledgerSignAdd  = 0x01
+	ledgerSignLast = 0x02
+
+	ledgerChunkSize = 250
+)
+
+// ledgerStatusError returns the error of the status word of the reply.
+func ledgerStatusError(status uint16) error {
+	switch status {
+	case 0x9000:
+		return nil
+	case 0x6986:
+		return ErrLedgerRejected
+	case 0x6e00, 0x6e01, 0x6d00, 0x6511:
+		return fmt.Errorf("the Flow app is not open on the Ledger device")
+	case 0x5515, 0x6b0c:
+		return fmt.Errorf("the Ledger device is locked, unlock the device and open the Flow app")
+	case 0x6984:
+		return fmt.Errorf("the Flow app couldn't parse the transaction, update the Flow app on the Ledger device")
+	default:
+		return fmt.Errorf("the Ledger device returned the error status 0x%04x", status)
+	}
+}
+
+// Ledger is a connected Ledger device running the Flow app.
+type Ledger struct {
+	device ledgerDevice
+}
+
+// OpenLedger connects to the Ledger device, the device must be closed after use.
+func OpenLedger() (*Ledger, error) {
+	device, err := openLedgerDevice()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ledger{device: device}, nil
+}
+
+// Close closes the connection to the device.
+func (l *Ledger) Close() error {
+	return l.device.Close()
+}
+
+func (l *Ledger) exchange(ins byte, p1 byte, data []byte) ([]byte, error) {
+	apdu := append([]byte{ledgerCLA, ins, p1, 0x00, byte(len(data))}, data...)
+
+	reply, err := l.device.Exchange(apdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) < 2 {
+		return nil, fmt.Errorf("invalid reply from the Ledger device")
+	}
+
+	status := binary.BigEndian.Uint16(reply[len(reply)-2:])
+	if err := ledgerStatusError(status); err != nil {
+		return nil, err
+	}
+
+	return reply[:len(reply)-2], nil
+}
+
+// ledgerKeyData encodes the derivation path and the algorithms of the key used by the commands.
+func ledgerKeyData(
+	derivationPath string,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) ([]byte, error) {
+	path, err := goeth.ParseDerivationPath(derivationPath)
+	if err != nil || len(path) != 5 {
+		return nil, fmt.Errorf("invalid derivation path %s, expected a path like m/44'/539'/0'/0/0", derivationPath)
+	}
+
+	if sigAlgo != crypto.ECDSA_P256 && sigAlgo != crypto.ECDSA_secp256k1 {
+		return nil, fmt.Errorf("unsupported signature algorithm %s", sigAlgo)
+	}
+	if hashAlgo != crypto.SHA2_256 && hashAlgo != crypto.SHA3_256 {
+		return nil, fmt.Errorf("unsupported hash algorithm %s", hashAlgo)
+	}
+
+	data := make([]byte, len(path)*4+2)
+	for i, n := range path {
+		binary.LittleEndian.PutUint32(data[i*4:], n)
+	}
+
+	// the curve is in the high byte and the hash in the low byte, with the values of the crypto package
+	binary.LittleEndian.PutUint16(data[len(path)*4:], uint16(sigAlgo)<<8|uint16(hashAlgo))
+	return data, nil
+}
+
+// PublicKey returns the public key on the derivation path.
+func (l *Ledger) PublicKey(
+	derivationPath string,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (crypto.PublicKey, error) {
+	data, err := ledgerKeyData(derivationPath, sigAlgo, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := l.exchange(ledgerInsPublicKey, 0x00, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the uncompressed point is followed by its hex encoding
+	if len(reply) < 65 || reply[0] != 0x04 {
+		return nil, fmt.Errorf("invalid public key returned by the Ledger device")
+	}
+
+	return crypto.DecodePublicKey(sigAlgo, reply[1:65])
+}
+
+// Sign signs the transaction message with the key on the derivation path, once the user confirms it on the device.
+//
+// The message must start with the transaction domain tag, the device only receives the encoded
+// transaction and adds the domain tag itself, so the hash signed on the device matches the message.
+func (l *Ledger) Sign(
+	derivationPath string,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+	message []byte,
+) ([]byte, error) {
+	if !bytes.HasPrefix(message, flow.TransactionDomainTag[:]) {
+		return nil, fmt.Errorf("only transactions can be signed with the Ledger device")
+	}
+	payload := message[len(flow.TransactionDomainTag):]
+
+	data, err := ledgerKeyData(derivationPath, sigAlgo, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = l.exchange(ledgerInsSign, ledgerSignInit, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if HardwareLogger != nil {
+		HardwareLogger.StartProgress("Please confirm the transaction on the Ledger device...")
+		defer HardwareLogger.StopProgress()
+	}
+
+	var reply []byte
+	for len(payload) > 0 {
+		chunk := payload
+		if len(chunk) > ledgerChunkSize {
+			chunk = chunk[:ledgerChunkSize]
+		}
+		payload = payload[len(chunk):]
+
+		p1 := byte(ledgerSignAdd)
+		if len(payload) == 0 {
+			p1 = ledgerSignLast
+		}
+
+		reply, err = l.exchange(ledgerInsSign, p1, chunk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the signature r || s is followed by the recovery id and the DER encoded signature
+	if len(reply) < 64 {
+		return nil, fmt.Errorf("invalid signature returned by the Ledger device")
+	}
+
+	return reply[:64], nil
+}
+
+var _ crypto.Signer = &ledgerSigner{}
+
+// ledgerSigner signs with the key on the derivation path of the connected Ledger device.
+type ledgerSigner struct {
+	derivationPath string
+	sigAlgo        crypto.SignatureAlgorithm
+	hashAlgo       crypto.HashAlgorithm
+	publicKey      crypto.PublicKey
+}
+
+// newLedgerSigner reads the public key from the device, so a missing device is reported before signing.
+func newLedgerSigner(
+	derivationPath string,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*ledgerSigner, error) {
+	ledger, err := OpenLedger()
+	if err != nil {
+		return nil, err
+	}
+	defer ledger.Close()
+
+	publicKey, err := ledger.PublicKey(derivationPath, sigAlgo, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ledgerSigner{
+		derivationPath: derivationPath,
+		sigAlgo:        sigAlgo,
+		hashAlgo:       hashAlgo,
+		publicKey:      publicKey,
+	}, nil
+}
+
+func (s *ledgerSigner) Sign(message []byte) ([]byte, error) {
+	ledger, err := OpenLedger()
+	if err != nil {
+		return nil, err
+	}
+	defer ledger.Close()
+
+	return ledger.Sign(s.derivationPath, s.sigAlgo, s.hashAlgo, message)
+}
+
+func (s *ledgerSigner) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
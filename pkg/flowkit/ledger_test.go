@@ -0,0 +1,249 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// fakeLedger emulates the Flow app signing with a P-256 key and SHA2-256.
+type fakeLedger struct {
+	key      *ecdsa.PrivateKey
+	reject   bool
+	apdus    [][]byte
+	keyData  []byte
+	payload  []byte
+	progress []string
+}
+
+func (f *fakeLedger) Exchange(apdu []byte) ([]byte, error) {
+	f.apdus = append(f.apdus, apdu)
+	ins, p1, data := apdu[1], apdu[2], apdu[5:]
+
+	switch {
+	case ins == ledgerInsPublicKey:
+		point := elliptic.Marshal(elliptic.P256(), f.key.X, f.key.Y)
+		return append(append(point, []byte("04abcd")...), 0x90, 0x00), nil
+	case ins == ledgerInsSign && p1 == ledgerSignInit:
+		f.keyData = data
+		f.payload = nil
+	case ins == ledgerInsSign && p1 == ledgerSignAdd:
+		f.payload = append(f.payload, data...)
+	case ins == ledgerInsSign && p1 == ledgerSignLast:
+		f.payload = append(f.payload, data...)
+		if f.reject {
+			return []byte{0x69, 0x86}, nil
+		}
+
+		hash := sha256.Sum256(append(flow.TransactionDomainTag[:], f.payload...))
+		r, s, err := ecdsa.Sign(rand.Reader, f.key, hash[:])
+		if err != nil {
+			return nil, err
+		}
+
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+		return append(append(signature, 0x01, 0x30), 0x90, 0x00), nil
+	}
+
+	return []byte{0x90, 0x00}, nil
+}
+
+func (f *fakeLedger) Close() error {
+	return nil
+}
+
+func (f *fakeLedger) StartProgress(msg string) {
+	f.progress = append(f.progress, msg)
+}
+
+func (f *fakeLedger) StopProgress() {}
+
+func setupFakeLedger(t *testing.T) *fakeLedger {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ledger := &fakeLedger{key: key}
+	openLedgerDevice = func() (ledgerDevice, error) {
+		return ledger, nil
+	}
+	HardwareLogger = ledger
+	t.Cleanup(func() {
+		openLedgerDevice = openUSBLedger
+		HardwareLogger = nil
+	})
+
+	return ledger
+}
+
+func hardwareKey() AccountKey {
+	return newHardwareAccountKey(config.AccountKey{
+		Type:           config.KeyTypeHardware,
+		SigAlgo:        crypto.ECDSA_P256,
+		HashAlgo:       crypto.SHA2_256,
+		DerivationPath: "m/44'/539'/513'/0/2",
+	})
+}
+
+func Test_LedgerSigner(t *testing.T) {
+
+	t.Run("Sign transaction", func(t *testing.T) {
+		ledger := setupFakeLedger(t)
+		key := hardwareKey()
+		require.NoError(t, key.Validate())
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+
+		// the payload is split into multiple chunks
+		payload := bytes.Repeat([]byte{0xab}, 2*ledgerChunkSize+10)
+		message := append(flow.TransactionDomainTag[:], payload...)
+
+		signature, err := signer.Sign(message)
+		require.NoError(t, err)
+		assert.Len(t, signature, 64)
+
+		valid, err := signer.PublicKey().Verify(signature, message, crypto.NewSHA2_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		// the device receives the payload without the domain tag
+		assert.Equal(t, payload, ledger.payload)
+		assert.Equal(t, []string{"Please confirm the transaction on the Ledger device..."}, ledger.progress)
+
+		path := []uint32{0x8000002c, 0x8000021b, 0x80000201, 0, 2}
+		for i, n := range path {
+			assert.Equal(t, n, binary.LittleEndian.Uint32(ledger.keyData[i*4:]))
+		}
+		assert.Equal(t, []byte{0x01, 0x02}, ledger.keyData[20:]) // SHA2_256, ECDSA_P256
+
+		sign := ledger.apdus[1:]
+		require.Len(t, sign, 4)
+		assert.Equal(t, []byte{ledgerCLA, ledgerInsSign, ledgerSignAdd, 0x00, ledgerChunkSize}, sign[1][:5])
+		assert.Equal(t, []byte{ledgerCLA, ledgerInsSign, ledgerSignLast, 0x00, 10}, sign[3][:5])
+	})
+
+	t.Run("Fail user rejection", func(t *testing.T) {
+		ledger := setupFakeLedger(t)
+		ledger.reject = true
+
+		signer, err := hardwareKey().Signer(context.Background())
+		require.NoError(t, err)
+
+		_, err = signer.Sign(append(flow.TransactionDomainTag[:], 0x01))
+		assert.ErrorIs(t, err, ErrLedgerRejected)
+	})
+
+	t.Run("Fail sign other messages", func(t *testing.T) {
+		setupFakeLedger(t)
+
+		signer, err := hardwareKey().Signer(context.Background())
+		require.NoError(t, err)
+
+		_, err = signer.Sign(append(flow.UserDomainTag[:], 0x01))
+		assert.EqualError(t, err, "only transactions can be signed with the Ledger device")
+	})
+
+	t.Run("Fail device not connected", func(t *testing.T) {
+		openLedgerDevice = func() (ledgerDevice, error) {
+			return nil, ErrLedgerNotConnected
+		}
+		t.Cleanup(func() { openLedgerDevice = openUSBLedger })
+
+		_, err := hardwareKey().Signer(context.Background())
+		assert.ErrorIs(t, err, ErrLedgerNotConnected)
+	})
+
+	t.Run("Fail invalid derivation path", func(t *testing.T) {
+		key := newHardwareAccountKey(config.AccountKey{
+			Type:           config.KeyTypeHardware,
+			SigAlgo:        crypto.ECDSA_P256,
+			HashAlgo:       crypto.SHA2_256,
+			DerivationPath: "m/44'/539'",
+		})
+
+		err := key.Validate()
+		assert.EqualError(t, err, "invalid hardware key: invalid derivation path m/44'/539', expected a path like m/44'/539'/0'/0/0")
+	})
+}
+
+func Test_LedgerStatusErrors(t *testing.T) {
+	assert.NoError(t, ledgerStatusError(0x9000))
+	assert.ErrorIs(t, ledgerStatusError(0x6986), ErrLedgerRejected)
+	assert.EqualError(t, ledgerStatusError(0x6e00), "the Flow app is not open on the Ledger device")
+	assert.EqualError(t, ledgerStatusError(0x5515), "the Ledger device is locked, unlock the device and open the Flow app")
+	assert.EqualError(t, ledgerStatusError(0x6f00), "the Ledger device returned the error status 0x6f00")
+}
+
+// fakeHID replies with the queued packets and records the written packets.
+type fakeHID struct {
+	written [][]byte
+	replies [][]byte
+}
+
+func (f *fakeHID) Write(b []byte) (int, error) {
+	f.written = append(f.written, append([]byte{}, b...))
+	return len(b), nil
+}
+
+func (f *fakeHID) Read(b []byte) (int, error) {
+	n := copy(b, f.replies[0])
+	f.replies = f.replies[1:]
+	return n, nil
+}
+
+func (f *fakeHID) Close() error {
+	return nil
+}
+
+func Test_LedgerHIDFraming(t *testing.T) {
+	reply := bytes.Repeat([]byte{0x11}, 70)
+	first := append(append(ledgerPacketHeader(0), 0x00, byte(len(reply))), reply[:57]...)
+	second := append(ledgerPacketHeader(1), reply[57:]...)
+	hid := &fakeHID{replies: [][]byte{pad(first), pad(second)}}
+
+	apdu := bytes.Repeat([]byte{0x22}, 100)
+	result, err := (&usbLedger{device: hid}).Exchange(apdu)
+	require.NoError(t, err)
+	assert.Equal(t, reply, result)
+
+	require.Len(t, hid.written, 2)
+	assert.Equal(t, []byte{0x01, 0x01, 0x05, 0x00, 0x00, 0x00, 100}, hid.written[0][:7])
+	assert.Equal(t, []byte{0x01, 0x01, 0x05, 0x00, 0x01}, hid.written[1][:5])
+	assert.Equal(t, apdu, append(hid.written[0][7:], hid.written[1][5:5+100-57]...))
+}
+
+func pad(packet []byte) []byte {
+	return append(packet, make([]byte, ledgerPacketSize-len(packet))...)
+}
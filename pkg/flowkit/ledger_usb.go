@@ -0,0 +1,59 @@
+//go:build ledger
+
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"fmt"
+
+	"github.com/karalabe/usb"
+)
+
+const (
+	ledgerVendorID  = 0x2c97
+	ledgerUsagePage = 0xffa0
+)
+
+// openUSBLedger opens the first connected Ledger device over USB HID.
+func openUSBLedger() (ledgerDevice, error) {
+	if !usb.Supported() {
+		return nil, fmt.Errorf("USB devices are not supported on this platform")
+	}
+
+	devices, err := usb.EnumerateHid(ledgerVendorID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the USB devices: %w", err)
+	}
+
+	for _, info := range devices {
+		// the device exposes multiple interfaces, only the first one accepts APDU commands
+		if info.UsagePage != ledgerUsagePage && info.Interface != 0 {
+			continue
+		}
+
+		device, err := info.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the Ledger device: %w", err)
+		}
+		return &usbLedger{device: device}, nil
+	}
+
+	return nil, ErrLedgerNotConnected
+}
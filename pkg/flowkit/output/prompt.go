@@ -30,6 +30,7 @@ import (
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/project"
 	"github.com/onflow/flow-cli/pkg/flowkit/util"
 )
 
@@ -204,6 +205,61 @@ func secureNetworkKeyPrompt() string {
 	return networkKey
 }
 
+// PassphrasePrompt asks for the passphrase of the encrypted account key.
+func PassphrasePrompt() (string, error) {
+	passphrasePrompt := promptui.Prompt{
+		Label: "Passphrase of the encrypted account key",
+		Mask:  '*',
+	}
+
+	passphrase, err := passphrasePrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return passphrase, err
+}
+
+// NewPassphrasePrompt asks for a new passphrase used to encrypt the account key and its confirmation.
+func NewPassphrasePrompt() (string, error) {
+	passphrasePrompt := promptui.Prompt{
+		Label: "New passphrase",
+		Mask:  '*',
+		Validate: func(s string) error {
+			if s == "" {
+				return fmt.Errorf("passphrase can not be empty")
+			}
+			return nil
+		},
+	}
+
+	passphrase, err := passphrasePrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	confirmPrompt := promptui.Prompt{
+		Label: "Confirm passphrase",
+		Mask:  '*',
+		Validate: func(s string) error {
+			if s != passphrase {
+				return fmt.Errorf("passphrases don't match")
+			}
+			return nil
+		},
+	}
+
+	_, err = confirmPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return passphrase, err
+}
+
 func addressPrompt() string {
 	addressPrompt := promptui.Prompt{
 		Label: "Enter address",
@@ -234,6 +290,39 @@ func contractPrompt(contractNames []string) string {
 	return contractName
 }
 
+// RemoveStaleContractsPrompt asks for approval to remove contracts which are no longer part of the deployment.
+func RemoveStaleContractsPrompt(contracts []string) bool {
+	prompt := promptui.Select{
+		Label: fmt.Sprintf(
+			"⚠️  Do you want to REMOVE contracts no longer in the deployment: %s?",
+			strings.Join(contracts, ", "),
+		),
+		Items: []string{"No", "Yes"},
+	}
+
+	_, result, err := prompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return result == "Yes"
+}
+
+// RevokeKeyPrompt asks for approval to revoke the account key.
+func RevokeKeyPrompt(index int, address string) bool {
+	prompt := promptui.Select{
+		Label: fmt.Sprintf("⚠️  Do you want to REVOKE key %d of account %s?", index, address),
+		Items: []string{"No", "Yes"},
+	}
+
+	_, result, err := prompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return result == "Yes"
+}
+
 func addAnotherContractToDeploymentPrompt() bool {
 	addContractPrompt := promptui.Select{
 		Label: "Do you wish to add another contract for deployment?",
@@ -310,7 +399,7 @@ func NewContractPrompt() map[string]string {
 	sourcePrompt := promptui.Prompt{
 		Label: "Enter contract file location",
 		Validate: func(s string) error {
-			if !config.Exists(s) {
+			if !project.IsRemote(s) && !config.Exists(s) {
 				return fmt.Errorf("contract file doesn't exist: %s", s)
 			}
 
@@ -0,0 +1,161 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"encoding/json"
+
+	"github.com/onflow/cadence"
+)
+
+// TypeField is the field of the simplified composite values containing their type ID.
+const TypeField = "_type"
+
+// ValueOptions are the options of the conversion of Cadence values to simplified JSON values.
+type ValueOptions struct {
+	// TypeHints adds the type ID of structs, resources, events, contracts and enums in the _type field.
+	TypeHints bool
+	// Depth is the maximum nesting of the converted containers, the values nested deeper are
+	// converted to their Cadence string. The depth is unlimited if it's zero.
+	Depth int
+}
+
+// SimpleJSON converts the Cadence value to plain values which can be encoded to JSON, instead of JSON-Cadence.
+//
+// Optionals are converted to their value or null, dictionaries to objects with the keys converted to strings,
+// and composites to objects of their fields. Addresses are converted to 0x prefixed hex strings, integers to
+// JSON numbers and fixed-point numbers to strings, so they keep their precision.
+func SimpleJSON(value cadence.Value, options ValueOptions) interface{} {
+	return simpleValue(value, options, 1)
+}
+
+func simpleValue(value cadence.Value, options ValueOptions, depth int) interface{} {
+	if options.Depth > 0 && depth > options.Depth && isContainer(value) {
+		return value.String()
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case cadence.Void:
+		return nil
+	case cadence.Optional:
+		return simpleValue(v.Value, options, depth)
+	case cadence.Bool:
+		return bool(v)
+	case cadence.String:
+		return string(v)
+	case cadence.Character:
+		return string(v)
+	case cadence.Address:
+		return v.String()
+	case cadence.Fix64, cadence.UFix64:
+		return v.String()
+	case cadence.NumberValue:
+		return json.Number(v.String())
+	case cadence.Path:
+		return v.String()
+	case cadence.TypeValue:
+		if v.StaticType == nil {
+			return ""
+		}
+		return v.StaticType.ID()
+	case cadence.StorageCapability:
+		capability := map[string]interface{}{
+			"path":    v.Path.String(),
+			"address": v.Address.String(),
+		}
+		if v.BorrowType != nil {
+			capability["borrowType"] = v.BorrowType.ID()
+		}
+		return capability
+	case cadence.Array:
+		values := make([]interface{}, 0, len(v.Values))
+		for _, element := range v.Values {
+			values = append(values, simpleValue(element, options, depth+1))
+		}
+		return values
+	case cadence.Dictionary:
+		pairs := make(map[string]interface{}, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			pairs[simpleKey(pair.Key)] = simpleValue(pair.Value, options, depth+1)
+		}
+		return pairs
+	case cadence.Struct:
+		return simpleComposite(v.StructType, v.Fields, options, depth)
+	case cadence.Resource:
+		return simpleComposite(v.ResourceType, v.Fields, options, depth)
+	case cadence.Event:
+		return simpleComposite(v.EventType, v.Fields, options, depth)
+	case cadence.Contract:
+		return simpleComposite(v.ContractType, v.Fields, options, depth)
+	case cadence.Enum:
+		return simpleComposite(v.EnumType, v.Fields, options, depth)
+	default:
+		return v.String()
+	}
+}
+
+// simpleKey converts the dictionary key to a string, strings are used without the quotes.
+func simpleKey(key cadence.Value) string {
+	switch k := key.(type) {
+	case cadence.String:
+		return string(k)
+	case cadence.Character:
+		return string(k)
+	case cadence.TypeValue:
+		if k.StaticType == nil {
+			return ""
+		}
+		return k.StaticType.ID()
+	default:
+		return key.String()
+	}
+}
+
+func simpleComposite(
+	compositeType cadence.CompositeType,
+	values []cadence.Value,
+	options ValueOptions,
+	depth int,
+) map[string]interface{} {
+	fields := make(map[string]interface{}, len(values)+1)
+	for i, field := range compositeType.CompositeFields() {
+		if i < len(values) {
+			fields[field.Identifier] = simpleValue(values[i], options, depth+1)
+		}
+	}
+	if options.TypeHints {
+		fields[TypeField] = compositeType.ID()
+	}
+
+	return fields
+}
+
+func isContainer(value cadence.Value) bool {
+	switch v := value.(type) {
+	case cadence.Optional:
+		return isContainer(v.Value)
+	case cadence.Array, cadence.Dictionary, cadence.Struct, cadence.Resource,
+		cadence.Event, cadence.Contract, cadence.Enum:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,150 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func Test_SimpleJSON(t *testing.T) {
+	listing := testListing(t)
+
+	tests := []struct {
+		name    string
+		options ValueOptions
+		golden  string
+	}{
+		{name: "Values", golden: "listing.golden"},
+		{name: "Type Hints", options: ValueOptions{TypeHints: true}, golden: "listing_types.golden"},
+		{name: "Depth", options: ValueOptions{Depth: 1}, golden: "listing_depth.golden"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := json.MarshalIndent(SimpleJSON(listing, test.options), "", "  ")
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", test.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(golden, append(out, '\n'), 0644))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(out)+"\n")
+		})
+	}
+
+	t.Run("Optional", func(t *testing.T) {
+		assert.Nil(t, SimpleJSON(cadence.NewOptional(nil), ValueOptions{}))
+		assert.Equal(t, "0x0000000000000001", SimpleJSON(
+			cadence.NewOptional(cadence.BytesToAddress([]byte{0x1})),
+			ValueOptions{},
+		))
+	})
+}
+
+// testListing returns a struct with the representative Cadence values in its fields.
+func testListing(t *testing.T) cadence.Value {
+	location := common.AddressLocation{
+		Address: common.MustBytesToAddress([]byte{0x1}),
+		Name:    "Market",
+	}
+
+	sellerType := &cadence.ResourceType{
+		Location:            location,
+		QualifiedIdentifier: "Market.Seller",
+		Fields: []cadence.Field{
+			{Identifier: "name", Type: cadence.StringType{}},
+			{Identifier: "verified", Type: cadence.BoolType{}},
+		},
+	}
+
+	statusType := &cadence.EnumType{
+		Location:            location,
+		QualifiedIdentifier: "Market.Status",
+		RawType:             cadence.UInt8Type{},
+		Fields: []cadence.Field{
+			{Identifier: "rawValue", Type: cadence.UInt8Type{}},
+		},
+	}
+
+	listingType := &cadence.StructType{
+		Location:            location,
+		QualifiedIdentifier: "Market.Listing",
+		Fields: []cadence.Field{
+			{Identifier: "id", Type: cadence.UInt64Type{}},
+			{Identifier: "price", Type: cadence.UFix64Type{}},
+			{Identifier: "change", Type: cadence.Fix64Type{}},
+			{Identifier: "score", Type: cadence.IntType{}},
+			{Identifier: "owner", Type: cadence.AddressType{}},
+			{Identifier: "note", Type: &cadence.OptionalType{Type: cadence.StringType{}}},
+			{Identifier: "title", Type: &cadence.OptionalType{Type: cadence.StringType{}}},
+			{Identifier: "tags", Type: &cadence.VariableSizedArrayType{ElementType: cadence.StringType{}}},
+			{Identifier: "bids", Type: &cadence.DictionaryType{KeyType: cadence.AddressType{}, ElementType: cadence.UFix64Type{}}},
+			{Identifier: "counts", Type: &cadence.DictionaryType{KeyType: cadence.IntType{}, ElementType: cadence.StringType{}}},
+			{Identifier: "path", Type: cadence.StoragePathType{}},
+			{Identifier: "kind", Type: cadence.MetaType{}},
+			{Identifier: "seller", Type: sellerType},
+			{Identifier: "status", Type: statusType},
+		},
+	}
+
+	price, err := cadence.NewUFix64("10.5")
+	require.NoError(t, err)
+	change, err := cadence.NewFix64("-1.25")
+	require.NoError(t, err)
+	bid, err := cadence.NewUFix64("1.0")
+	require.NoError(t, err)
+
+	return cadence.NewStruct([]cadence.Value{
+		cadence.NewUInt64(18446744073709551615),
+		price,
+		change,
+		cadence.NewInt(-42),
+		cadence.BytesToAddress([]byte{0x1}),
+		cadence.NewOptional(nil),
+		cadence.NewOptional(cadence.String("Lamp")),
+		cadence.NewArray([]cadence.Value{cadence.String("a"), cadence.String("b")}),
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.BytesToAddress([]byte{0x2}), Value: bid},
+		}),
+		cadence.NewDictionary([]cadence.KeyValuePair{
+			{Key: cadence.NewInt(1), Value: cadence.String("one")},
+		}),
+		cadence.NewPath("storage", "listing"),
+		cadence.NewTypeValue(cadence.IntType{}),
+		cadence.NewResource([]cadence.Value{
+			cadence.String("Alice"),
+			cadence.NewBool(true),
+		}).WithType(sellerType),
+		cadence.NewEnum([]cadence.Value{cadence.NewUInt8(1)}).WithType(statusType),
+	}).WithType(listingType)
+}
@@ -0,0 +1,350 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+const (
+	pemECPrivateKey = "EC PRIVATE KEY"
+	pemPrivateKey   = "PRIVATE KEY"
+	pemPublicKey    = "PUBLIC KEY"
+)
+
+// KeyEncoding is the encoding of keys shared with other tools.
+type KeyEncoding string
+
+const (
+	EncodingPEM KeyEncoding = "pem"
+	EncodingDER KeyEncoding = "der"
+)
+
+// PrivateKeyFormat is the structure private keys are encoded with.
+type PrivateKeyFormat string
+
+const (
+	FormatSEC1  PrivateKeyFormat = "sec1"
+	FormatPKCS8 PrivateKeyFormat = "pkcs8"
+)
+
+// ecPrivateKey is the SEC 1 structure of an elliptic curve private key, as defined in RFC 5915.
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// pkcs8PrivateKey is the PKCS #8 structure of a private key, as defined in RFC 5208.
+type pkcs8PrivateKey struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// subjectPublicKeyInfo is the structure of a public key, as defined in RFC 5280.
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// oidPublicKeyECDSA is the algorithm identifier of elliptic curve keys, as defined in RFC 5480.
+var oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+// curveOIDs are the identifiers of the named curves of the signature algorithms.
+var curveOIDs = map[crypto.SignatureAlgorithm]asn1.ObjectIdentifier{
+	crypto.ECDSA_P256:      {1, 2, 840, 10045, 3, 1, 7},
+	crypto.ECDSA_secp256k1: {1, 3, 132, 0, 10},
+}
+
+func curveOID(sigAlgo crypto.SignatureAlgorithm) (asn1.ObjectIdentifier, error) {
+	oid, ok := curveOIDs[sigAlgo]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signature algorithm %s", sigAlgo)
+	}
+	return oid, nil
+}
+
+func curveSigAlgo(oid asn1.ObjectIdentifier) (crypto.SignatureAlgorithm, error) {
+	for sigAlgo, curve := range curveOIDs {
+		if curve.Equal(oid) {
+			return sigAlgo, nil
+		}
+	}
+	return crypto.UnknownSignatureAlgorithm, fmt.Errorf("unsupported curve %s", oid)
+}
+
+// algorithmIdentifier returns the identifier of elliptic curve keys on the curve of the signature algorithm.
+func algorithmIdentifier(sigAlgo crypto.SignatureAlgorithm) (pkix.AlgorithmIdentifier, error) {
+	oid, err := curveOID(sigAlgo)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+
+	params, err := asn1.Marshal(oid)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  oidPublicKeyECDSA,
+		Parameters: asn1.RawValue{FullBytes: params},
+	}, nil
+}
+
+// algorithmSigAlgo returns the signature algorithm of the curve in the identifier of elliptic curve keys.
+func algorithmSigAlgo(algorithm pkix.AlgorithmIdentifier) (crypto.SignatureAlgorithm, error) {
+	if !algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return crypto.UnknownSignatureAlgorithm, fmt.Errorf("unsupported key algorithm %s", algorithm.Algorithm)
+	}
+
+	var oid asn1.ObjectIdentifier
+	_, err := asn1.Unmarshal(algorithm.Parameters.FullBytes, &oid)
+	if err != nil {
+		return crypto.UnknownSignatureAlgorithm, fmt.Errorf("invalid curve parameters: %w", err)
+	}
+
+	return curveSigAlgo(oid)
+}
+
+// uncompressedPoint returns the public key point with the uncompressed prefix, flow encodes the point without it.
+func uncompressedPoint(publicKey crypto.PublicKey) asn1.BitString {
+	point := append([]byte{0x04}, publicKey.Encode()...)
+	return asn1.BitString{Bytes: point, BitLength: len(point) * 8}
+}
+
+func decodePoint(sigAlgo crypto.SignatureAlgorithm, point asn1.BitString) (crypto.PublicKey, error) {
+	if len(point.Bytes) == 0 || point.Bytes[0] != 0x04 {
+		return nil, fmt.Errorf("only uncompressed public keys are supported")
+	}
+	return crypto.DecodePublicKey(sigAlgo, point.Bytes[1:])
+}
+
+// MarshalPrivateKey encodes the private key to DER in the SEC 1 or PKCS #8 format.
+//
+// Like OpenSSL, the curve is only included in the outer structure of the PKCS #8 format.
+func MarshalPrivateKey(privateKey crypto.PrivateKey, format PrivateKeyFormat) ([]byte, error) {
+	oid, err := curveOID(privateKey.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+
+	key := ecPrivateKey{
+		Version:    1,
+		PrivateKey: privateKey.Encode(),
+		PublicKey:  uncompressedPoint(privateKey.PublicKey()),
+	}
+
+	switch format {
+	case FormatSEC1:
+		key.NamedCurveOID = oid
+		return asn1.Marshal(key)
+	case FormatPKCS8:
+		inner, err := asn1.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		algorithm, err := algorithmIdentifier(privateKey.Algorithm())
+		if err != nil {
+			return nil, err
+		}
+
+		return asn1.Marshal(pkcs8PrivateKey{
+			Algorithm:  algorithm,
+			PrivateKey: inner,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported private key format %s, valid formats are %s and %s", format, FormatSEC1, FormatPKCS8)
+	}
+}
+
+// MarshalPublicKey encodes the public key to DER in the subject public key info format.
+func MarshalPublicKey(publicKey crypto.PublicKey) ([]byte, error) {
+	algorithm, err := algorithmIdentifier(publicKey.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: algorithm,
+		PublicKey: uncompressedPoint(publicKey),
+	})
+}
+
+func parseSEC1(der []byte, sigAlgo crypto.SignatureAlgorithm) (crypto.PrivateKey, error) {
+	var key ecPrivateKey
+	rest, err := asn1.Unmarshal(der, &key)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("trailing data after the private key")
+	}
+
+	if key.NamedCurveOID != nil {
+		curve, err := curveSigAlgo(key.NamedCurveOID)
+		if err != nil {
+			return nil, err
+		}
+		if sigAlgo != crypto.UnknownSignatureAlgorithm && sigAlgo != curve {
+			return nil, fmt.Errorf("curve of the private key doesn't match the key algorithm")
+		}
+		sigAlgo = curve
+	}
+
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("missing curve of the private key")
+	}
+
+	return crypto.DecodePrivateKey(sigAlgo, key.PrivateKey)
+}
+
+func parsePKCS8(der []byte) (crypto.PrivateKey, error) {
+	var key pkcs8PrivateKey
+	rest, err := asn1.Unmarshal(der, &key)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("trailing data after the private key")
+	}
+
+	sigAlgo, err := algorithmSigAlgo(key.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSEC1(key.PrivateKey, sigAlgo)
+}
+
+func parsePublicKey(der []byte) (crypto.PublicKey, error) {
+	var key subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &key)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("trailing data after the public key")
+	}
+
+	sigAlgo, err := algorithmSigAlgo(key.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodePoint(sigAlgo, key.PublicKey)
+}
+
+// DecodeKeyDER decodes a private key in the PKCS #8 or SEC 1 format, or a public key in
+// the subject public key info format from DER. The private key is nil if a public key is decoded.
+func DecodeKeyDER(der []byte) (crypto.PrivateKey, crypto.PublicKey, error) {
+	if privateKey, err := parsePKCS8(der); err == nil {
+		return privateKey, privateKey.PublicKey(), nil
+	}
+
+	if privateKey, err := parseSEC1(der, crypto.UnknownSignatureAlgorithm); err == nil {
+		return privateKey, privateKey.PublicKey(), nil
+	}
+
+	publicKey, err := parsePublicKey(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode key, only PKCS #8 and SEC 1 private keys and public keys on the P-256 and secp256k1 curves are supported: %w", err)
+	}
+
+	return nil, publicKey, nil
+}
+
+// EncodePrivateKeyPEM encodes the private key to a PEM "EC PRIVATE KEY" block in the SEC 1 format,
+// or a "PRIVATE KEY" block in the PKCS #8 format, which are read by other tools like OpenSSL.
+func EncodePrivateKeyPEM(privateKey crypto.PrivateKey, format PrivateKeyFormat) ([]byte, error) {
+	der, err := MarshalPrivateKey(privateKey, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	blockType := pemPrivateKey
+	if format == FormatSEC1 {
+		blockType = pemECPrivateKey
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), nil
+}
+
+// EncodePublicKeyPEM encodes the public key to a PEM "PUBLIC KEY" block.
+func EncodePublicKeyPEM(publicKey crypto.PublicKey) ([]byte, error) {
+	der, err := MarshalPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKey, Bytes: der}), nil
+}
+
+// DecodeKeyPEM decodes the first private or public key block from PEM, skipping other blocks
+// like the "EC PARAMETERS" written by OpenSSL. The private key is nil if a public key is decoded.
+func DecodeKeyPEM(data []byte) (crypto.PrivateKey, crypto.PublicKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, nil, fmt.Errorf("no %s, %s or %s PEM block found", pemECPrivateKey, pemPrivateKey, pemPublicKey)
+		}
+
+		switch block.Type {
+		case pemECPrivateKey:
+			privateKey, err := parseSEC1(block.Bytes, crypto.UnknownSignatureAlgorithm)
+			if err != nil {
+				return nil, nil, err
+			}
+			return privateKey, privateKey.PublicKey(), nil
+		case pemPrivateKey:
+			privateKey, err := parsePKCS8(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			return privateKey, privateKey.PublicKey(), nil
+		case pemPublicKey:
+			publicKey, err := parsePublicKey(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, publicKey, nil
+		}
+	}
+}
+
+// DecodePrivateKeyPEM decodes the private key from a PEM "EC PRIVATE KEY" or "PRIVATE KEY" block.
+func DecodePrivateKeyPEM(data []byte) (crypto.PrivateKey, error) {
+	privateKey, _, err := DecodeKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode private key: %w", err)
+	}
+	if privateKey == nil {
+		return nil, fmt.Errorf("failed to decode private key: the PEM block contains a public key")
+	}
+
+	return privateKey, nil
+}
@@ -0,0 +1,158 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// initParameters returns the parameters of the contract initializer, if the contract doesn't declare an initializer
+// an empty list is returned.
+func (p *Program) initParameters() []*ast.Parameter {
+	for _, compositeDeclaration := range p.astProgram.CompositeDeclarations() {
+		if compositeDeclaration.CompositeKind != common.CompositeKindContract {
+			continue
+		}
+
+		for _, initializer := range compositeDeclaration.Members.Initializers() {
+			if initializer.FunctionDeclaration.ParameterList == nil {
+				continue
+			}
+			return initializer.FunctionDeclaration.ParameterList.Parameters
+		}
+	}
+
+	return []*ast.Parameter{}
+}
+
+// validateInitArguments validates the contract arguments match the parameters of the contract initializer.
+//
+// The number of arguments must match the number of parameters, and arguments of built-in types
+// must have the same type as the parameter. Optional parameters accept nil, an optional or a value of the inner type.
+func validateInitArguments(contract *Contract, program *Program) error {
+	parameters := program.initParameters()
+
+	if len(parameters) != len(contract.Args) {
+		return fmt.Errorf(
+			"contract %s initializer %s expects %d arguments but %d were provided%s",
+			contract.Name,
+			initSignature(parameters),
+			len(parameters),
+			len(contract.Args),
+			formatArguments(contract.Args),
+		)
+	}
+
+	for i, parameter := range parameters {
+		if !argumentMatches(parameter.TypeAnnotation.Type, contract.Args[i]) {
+			return fmt.Errorf(
+				"contract %s initializer %s argument %s must be of type %s but %s was provided: %s",
+				contract.Name,
+				initSignature(parameters),
+				parameter.Identifier.Identifier,
+				parameter.TypeAnnotation.Type.String(),
+				contract.Args[i].Type().ID(),
+				contract.Args[i].String(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// abstractTypes are the built-in types which are supertypes of other types, values of the subtypes are accepted.
+var abstractTypes = map[string]bool{
+	"Any":              true,
+	"AnyStruct":        true,
+	"AnyResource":      true,
+	"Number":           true,
+	"SignedNumber":     true,
+	"Integer":          true,
+	"SignedInteger":    true,
+	"FixedPoint":       true,
+	"SignedFixedPoint": true,
+	"Path":             true,
+	"CapabilityPath":   true,
+}
+
+// argumentMatches checks the argument value is compatible with the parameter type.
+//
+// Only built-in types and optionals of them are validated, user defined types are checked when the contract is deployed.
+func argumentMatches(expected ast.Type, value cadence.Value) bool {
+	switch expected := expected.(type) {
+	case *ast.OptionalType:
+		optional, ok := value.(cadence.Optional)
+		if !ok {
+			return argumentMatches(expected.Type, value) // values are accepted as optionals of their type
+		}
+		if optional.Value == nil {
+			return true
+		}
+		return argumentMatches(expected.Type, optional.Value)
+
+	case *ast.NominalType:
+		if len(expected.NestedIdentifiers) > 0 || abstractTypes[expected.Identifier.Identifier] {
+			return true
+		}
+
+		// values decoded without type information, like arrays, are checked when the contract is deployed
+		if value.Type() == nil {
+			return true
+		}
+
+		provided := value.Type().ID()
+		if strings.Contains(provided, ".") {
+			return true
+		}
+
+		return expected.Identifier.Identifier == provided
+
+	default:
+		return true
+	}
+}
+
+// formatArguments formats the provided argument values to be appended to an error message.
+func formatArguments(args []cadence.Value) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	values := make([]string, len(args))
+	for i, arg := range args {
+		values[i] = arg.String()
+	}
+
+	return fmt.Sprintf(": %s", strings.Join(values, ", "))
+}
+
+// initSignature formats the initializer parameters as a signature (e.g. "init(a: String, b: UFix64)").
+func initSignature(parameters []*ast.Parameter) string {
+	params := make([]string, len(parameters))
+	for i, parameter := range parameters {
+		params[i] = fmt.Sprintf("%s: %s", parameter.Identifier.Identifier, parameter.TypeAnnotation.Type.String())
+	}
+
+	return fmt.Sprintf("init(%s)", strings.Join(params, ", "))
+}
@@ -0,0 +1,145 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateInitArguments(t *testing.T) {
+	code := []byte(`
+		pub contract Foo {
+			pub struct Bar {}
+
+			init(admin: Address, fee: UFix64, bar: Bar) {}
+		}
+	`)
+
+	optionalCode := []byte(`
+		pub contract Foo {
+			init(admin: Address?, fee: UFix64?) {}
+		}
+	`)
+
+	fee, _ := cadence.NewUFix64("0.1")
+	bar := cadence.NewStruct(nil).WithType(&cadence.StructType{
+		Location:            common.StringLocation("Foo"),
+		QualifiedIdentifier: "Foo.Bar",
+	})
+
+	tests := []struct {
+		name string
+		code []byte
+		args []cadence.Value
+		err  string
+	}{{
+		name: "valid arguments",
+		code: code,
+		args: []cadence.Value{cadence.NewAddress(flow.HexToAddress("0x01")), fee, bar},
+	}, {
+		name: "no initializer",
+		code: []byte(`pub contract Foo {}`),
+		args: nil,
+	}, {
+		name: "no initializer with arguments",
+		code: []byte(`pub contract Foo {}`),
+		args: []cadence.Value{cadence.String("foo")},
+		err:  `contract Foo initializer init() expects 0 arguments but 1 were provided: "foo"`,
+	}, {
+		name: "missing arguments",
+		code: code,
+		args: []cadence.Value{cadence.NewAddress(flow.HexToAddress("0x01"))},
+		err:  "contract Foo initializer init(admin: Address, fee: UFix64, bar: Bar) expects 3 arguments but 1 were provided: 0x0000000000000001",
+	}, {
+		name: "invalid argument type",
+		code: code,
+		args: []cadence.Value{cadence.String("0x01"), fee, bar},
+		err:  `contract Foo initializer init(admin: Address, fee: UFix64, bar: Bar) argument admin must be of type Address but String was provided: "0x01"`,
+	}, {
+		name: "argument without type",
+		code: code,
+		args: []cadence.Value{cadence.NewArray([]cadence.Value{cadence.NewUInt64(1)}), fee, bar},
+	}, {
+		name: "abstract argument types",
+		code: []byte(`
+			pub contract Foo {
+				init(a: AnyStruct, b: Number, c: Integer, d: FixedPoint, e: Path, f: CapabilityPath) {}
+			}
+		`),
+		args: []cadence.Value{
+			cadence.String("foo"),
+			fee,
+			cadence.NewInt(1),
+			fee,
+			cadence.Path{Domain: "storage", Identifier: "foo"},
+			cadence.Path{Domain: "public", Identifier: "foo"},
+		},
+	}, {
+		name: "abstract optional argument type",
+		code: []byte(`pub contract Foo { init(a: Number?) {} }`),
+		args: []cadence.Value{cadence.NewOptional(fee)},
+	}, {
+		name: "no-arg initializer",
+		code: []byte(`pub contract Foo { init() {} }`),
+		args: nil,
+	}, {
+		name: "no-arg initializer with arguments",
+		code: []byte(`pub contract Foo { init() {} }`),
+		args: []cadence.Value{fee},
+		err:  "contract Foo initializer init() expects 0 arguments but 1 were provided: 0.10000000",
+	}, {
+		name: "optional argument",
+		code: optionalCode,
+		args: []cadence.Value{cadence.NewOptional(cadence.NewAddress(flow.HexToAddress("0x01"))), fee},
+	}, {
+		name: "nil optional argument",
+		code: optionalCode,
+		args: []cadence.Value{cadence.NewOptional(nil), cadence.NewOptional(nil)},
+	}, {
+		name: "invalid optional argument type",
+		code: optionalCode,
+		args: []cadence.Value{cadence.NewOptional(cadence.String("0x01")), fee},
+		err:  `contract Foo initializer init(admin: Address?, fee: UFix64?) argument admin must be of type Address? but String? was provided: "0x01"`,
+	}, {
+		name: "missing optional argument",
+		code: optionalCode,
+		args: []cadence.Value{cadence.NewOptional(nil)},
+		err:  "contract Foo initializer init(admin: Address?, fee: UFix64?) expects 2 arguments but 1 were provided: nil",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			contract := NewContract("Foo", "Foo.cdc", test.code, flow.HexToAddress("0x01"), "", test.args)
+			program, err := NewProgram(contract)
+			assert.NoError(t, err)
+
+			err = validateInitArguments(contract, program)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
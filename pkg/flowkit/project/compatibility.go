@@ -0,0 +1,228 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+)
+
+// UpdateIncompatibility is a change in the updated contract code which prevents the deployed contract from being updated.
+type UpdateIncompatibility struct {
+	// Declaration is the qualified name of the changed declaration (e.g. "Kitty.NFT").
+	Declaration string
+	// Position is the position of the declaration in the deployed code if removed, otherwise in the updated code.
+	Position ast.Position
+	Message  string
+}
+
+func (u UpdateIncompatibility) String() string {
+	return fmt.Sprintf("%s (line %d): %s", u.Declaration, u.Position.Line, u.Message)
+}
+
+// UpdateIncompatibleError is returned when the contract update contains incompatible changes.
+type UpdateIncompatibleError struct {
+	Contract          string
+	Incompatibilities []UpdateIncompatibility
+}
+
+func (e *UpdateIncompatibleError) Error() string {
+	changes := make([]string, len(e.Incompatibilities))
+	for i, incompatibility := range e.Incompatibilities {
+		changes[i] = incompatibility.String()
+	}
+
+	return fmt.Sprintf(
+		"contract %s update is incompatible with the deployed contract: %s",
+		e.Contract,
+		strings.Join(changes, ", "),
+	)
+}
+
+// CheckUpdateCompatibility compares the deployed and the updated contract code and returns the changes
+// which are known to fail the contract update.
+//
+// The check is not exhaustive, it only covers the common breaking changes: removed composite declarations,
+// added or removed fields, changed field types and removed or reordered enum cases.
+func CheckUpdateCompatibility(deployed []byte, updated []byte) ([]UpdateIncompatibility, error) {
+	deployedProgram, err := parser.ParseProgram(nil, deployed, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deployed contract: %w", err)
+	}
+
+	updatedProgram, err := parser.ParseProgram(nil, updated, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse updated contract: %w", err)
+	}
+
+	checker := &compatibilityChecker{}
+	checker.compareDeclarations(
+		"",
+		declarationsOf(deployedProgram.CompositeDeclarations(), deployedProgram.InterfaceDeclarations()),
+		declarationsOf(updatedProgram.CompositeDeclarations(), updatedProgram.InterfaceDeclarations()),
+	)
+
+	return checker.incompatibilities, nil
+}
+
+// compositeLike is a composite or an interface declaration.
+type compositeLike struct {
+	identifier ast.Identifier
+	kind       string
+	members    *ast.Members
+}
+
+func declarationsOf(composites []*ast.CompositeDeclaration, interfaces []*ast.InterfaceDeclaration) []compositeLike {
+	declarations := make([]compositeLike, 0, len(composites)+len(interfaces))
+	for _, composite := range composites {
+		declarations = append(declarations, compositeLike{
+			identifier: composite.Identifier,
+			kind:       composite.CompositeKind.Keyword(),
+			members:    composite.Members,
+		})
+	}
+	for _, i := range interfaces {
+		declarations = append(declarations, compositeLike{
+			identifier: i.Identifier,
+			kind:       i.CompositeKind.Keyword() + " interface",
+			members:    i.Members,
+		})
+	}
+
+	return declarations
+}
+
+type compatibilityChecker struct {
+	incompatibilities []UpdateIncompatibility
+}
+
+func (c *compatibilityChecker) report(declaration string, position ast.Position, format string, args ...any) {
+	c.incompatibilities = append(c.incompatibilities, UpdateIncompatibility{
+		Declaration: declaration,
+		Position:    position,
+		Message:     fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *compatibilityChecker) compareDeclarations(parent string, deployed []compositeLike, updated []compositeLike) {
+	updatedByName := make(map[string]compositeLike, len(updated))
+	for _, declaration := range updated {
+		updatedByName[declaration.identifier.Identifier] = declaration
+	}
+
+	for _, old := range deployed {
+		name := qualifiedName(parent, old.identifier.Identifier)
+
+		current, exists := updatedByName[old.identifier.Identifier]
+		if !exists {
+			c.report(name, old.identifier.Pos, "%s declaration was removed", old.kind)
+			continue
+		}
+		if current.kind != old.kind {
+			c.report(name, current.identifier.Pos, "declaration kind changed from %s to %s", old.kind, current.kind)
+			continue
+		}
+
+		c.compareFields(name, old.members, current.members)
+		c.compareEnumCases(name, old.members, current.members)
+		c.compareDeclarations(
+			name,
+			declarationsOf(old.members.Composites(), old.members.Interfaces()),
+			declarationsOf(current.members.Composites(), current.members.Interfaces()),
+		)
+	}
+}
+
+func (c *compatibilityChecker) compareFields(declaration string, deployed *ast.Members, updated *ast.Members) {
+	updatedFields := make(map[string]*ast.FieldDeclaration)
+	for _, field := range updated.Fields() {
+		updatedFields[field.Identifier.Identifier] = field
+	}
+
+	deployedFields := make(map[string]bool)
+	for _, old := range deployed.Fields() {
+		deployedFields[old.Identifier.Identifier] = true
+
+		current, exists := updatedFields[old.Identifier.Identifier]
+		if !exists {
+			c.report(declaration, old.Identifier.Pos, "field %s was removed", old.Identifier.Identifier)
+			continue
+		}
+
+		oldType, currentType := fieldType(old), fieldType(current)
+		if oldType != currentType {
+			c.report(
+				declaration,
+				current.Identifier.Pos,
+				"field %s type changed from %s to %s",
+				old.Identifier.Identifier,
+				oldType,
+				currentType,
+			)
+		}
+	}
+
+	for _, field := range updated.Fields() {
+		if !deployedFields[field.Identifier.Identifier] {
+			c.report(declaration, field.Identifier.Pos, "field %s was added", field.Identifier.Identifier)
+		}
+	}
+}
+
+func (c *compatibilityChecker) compareEnumCases(declaration string, deployed *ast.Members, updated *ast.Members) {
+	deployedCases := deployed.EnumCases()
+	updatedCases := updated.EnumCases()
+
+	for i, old := range deployedCases {
+		if i >= len(updatedCases) {
+			c.report(declaration, old.Identifier.Pos, "enum case %s was removed", old.Identifier.Identifier)
+			continue
+		}
+
+		current := updatedCases[i]
+		if current.Identifier.Identifier != old.Identifier.Identifier {
+			c.report(
+				declaration,
+				current.Identifier.Pos,
+				"enum case %s was changed to %s, existing cases can not be removed or reordered",
+				old.Identifier.Identifier,
+				current.Identifier.Identifier,
+			)
+		}
+	}
+}
+
+func fieldType(field *ast.FieldDeclaration) string {
+	if field.TypeAnnotation == nil {
+		return ""
+	}
+
+	return field.TypeAnnotation.String()
+}
+
+func qualifiedName(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	return parent + "." + name
+}
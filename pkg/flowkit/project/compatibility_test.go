@@ -0,0 +1,131 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckUpdateCompatibility(t *testing.T) {
+	deployed := []byte(`
+		pub contract Kitty {
+			pub let name: String
+			pub var count: Int
+
+			pub resource NFT {
+				pub let id: UInt64
+			}
+
+			pub struct Info {}
+
+			pub enum Color: UInt8 {
+				pub case red
+				pub case green
+			}
+
+			init() {
+				self.name = "kitty"
+				self.count = 0
+			}
+		}
+	`)
+
+	t.Run("Compatible", func(t *testing.T) {
+		updated := []byte(`
+			pub contract Kitty {
+				pub let name: String
+				pub var count: Int
+
+				pub resource NFT {
+					pub let id: UInt64
+
+					pub fun hello(): String { return "hello" }
+				}
+
+				pub struct Info {}
+
+				pub struct Added {}
+
+				pub enum Color: UInt8 {
+					pub case red
+					pub case green
+					pub case blue
+				}
+
+				init() {
+					self.name = "kitty"
+					self.count = 0
+				}
+			}
+		`)
+
+		incompatibilities, err := CheckUpdateCompatibility(deployed, updated)
+		require.NoError(t, err)
+		assert.Empty(t, incompatibilities)
+	})
+
+	t.Run("Incompatible", func(t *testing.T) {
+		updated := []byte(`
+			pub contract Kitty {
+				pub let name: Int
+				pub let added: String
+
+				pub resource NFT {}
+
+				pub enum Color: UInt8 {
+					pub case green
+				}
+
+				init() {
+					self.name = 1
+					self.added = ""
+				}
+			}
+		`)
+
+		incompatibilities, err := CheckUpdateCompatibility(deployed, updated)
+		require.NoError(t, err)
+
+		messages := make([]string, len(incompatibilities))
+		for i, incompatibility := range incompatibilities {
+			messages[i] = incompatibility.Declaration + ": " + incompatibility.Message
+		}
+
+		assert.ElementsMatch(t, []string{
+			"Kitty: field name type changed from String to Int",
+			"Kitty: field count was removed",
+			"Kitty: field added was added",
+			"Kitty.NFT: field id was removed",
+			"Kitty.Info: struct declaration was removed",
+			"Kitty.Color: enum case red was changed to green, existing cases can not be removed or reordered",
+			"Kitty.Color: enum case green was removed",
+		}, messages)
+
+		err = &UpdateIncompatibleError{Contract: "Kitty", Incompatibilities: incompatibilities[:1]}
+		assert.Equal(t, "contract Kitty update is incompatible with the deployed contract: Kitty (line 3): field name type changed from String to Int", err.Error())
+	})
+
+	t.Run("Invalid Code", func(t *testing.T) {
+		_, err := CheckUpdateCompatibility(deployed, []byte(`pub contract Kitty {`))
+		assert.ErrorContains(t, err, "failed to parse updated contract")
+	})
+}
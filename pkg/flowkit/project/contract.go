@@ -31,6 +31,11 @@ type Contract struct {
 	AccountAddress flow.Address
 	AccountName    string
 	Args           []cadence.Value
+	// ImportAccounts maps names of imported contracts deployed to multiple accounts
+	// to the name of the account the imported instance is deployed to.
+	ImportAccounts map[string]string
+	// Tags of the contract deployment used to select the deployed contracts.
+	Tags []string
 }
 
 func NewContract(
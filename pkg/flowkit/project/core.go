@@ -0,0 +1,211 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go-sdk"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// coreContracts maps the core contract names to their addresses by the network name.
+var coreContracts = map[string]map[string]string{
+	"FungibleToken": {
+		"emulator": "ee82856bf20e2aa6",
+		"testnet":  "9a0766d93b6608b7",
+		"mainnet":  "f233dcee88fe0abe",
+	},
+	"FungibleTokenMetadataViews": {
+		"emulator": "ee82856bf20e2aa6",
+		"testnet":  "9a0766d93b6608b7",
+		"mainnet":  "f233dcee88fe0abe",
+	},
+	"FlowToken": {
+		"emulator": "0ae53cb6e3f42a79",
+		"testnet":  "7e60df042a9c0868",
+		"mainnet":  "1654653399040a61",
+	},
+	"FlowFees": {
+		"emulator": "e5a8b7f23e8b548f",
+		"testnet":  "912d5440f7e3769e",
+		"mainnet":  "f919ee77447b7497",
+	},
+	"FlowServiceAccount": {
+		"emulator": "f8d6e0586b0a20c7",
+		"testnet":  "8c5303eaa26202d6",
+		"mainnet":  "e467b9dd11fa00df",
+	},
+	"FlowStorageFees": {
+		"emulator": "f8d6e0586b0a20c7",
+		"testnet":  "8c5303eaa26202d6",
+		"mainnet":  "e467b9dd11fa00df",
+	},
+	"FlowIDTableStaking": {
+		"emulator": "f8d6e0586b0a20c7",
+		"testnet":  "9eca2b38b18b5dfe",
+		"mainnet":  "8624b52f9ddcd04a",
+	},
+	"FlowEpoch": {
+		"emulator": "f8d6e0586b0a20c7",
+		"testnet":  "9eca2b38b18b5dfe",
+		"mainnet":  "8624b52f9ddcd04a",
+	},
+	"FlowClusterQC": {
+		"emulator": "f8d6e0586b0a20c7",
+		"testnet":  "9eca2b38b18b5dfe",
+		"mainnet":  "8624b52f9ddcd04a",
+	},
+	"FlowDKG": {
+		"emulator": "f8d6e0586b0a20c7",
+		"testnet":  "9eca2b38b18b5dfe",
+		"mainnet":  "8624b52f9ddcd04a",
+	},
+	"NonFungibleToken": {
+		"emulator": "f8d6e0586b0a20c7",
+		"testnet":  "631e88ae7f1d7c20",
+		"mainnet":  "1d7e57aa55817448",
+	},
+	"MetadataViews": {
+		"emulator": "f8d6e0586b0a20c7",
+		"testnet":  "631e88ae7f1d7c20",
+		"mainnet":  "1d7e57aa55817448",
+	},
+}
+
+// CoreContractAliases returns the aliases of core contracts imported by the contracts on the network.
+//
+// Core contracts are matched by the imported contract name, and only contracts which are
+// not part of the provided contracts and don't have an alias already are returned.
+func CoreContractAliases(network string, contracts []*Contract, aliases Aliases) (Aliases, error) {
+	defaults := make(Aliases)
+
+	for _, contract := range contracts {
+		program, err := NewProgram(contract)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range program.importedNames() {
+			address, ok := coreContracts[name][network]
+			if !ok {
+				continue
+			}
+			if _, aliased := aliases[name]; aliased {
+				continue
+			}
+			if slices.IndexFunc(contracts, func(c *Contract) bool { return c.Name == name }) >= 0 {
+				continue
+			}
+
+			defaults[name] = address
+		}
+	}
+
+	return defaults, nil
+}
+
+// IsCoreContract returns true if the contract with the name deployed on the address is a core contract of the network.
+func IsCoreContract(name string, network string, address flow.Address) bool {
+	coreAddress, ok := coreContracts[name][network]
+	return ok && flow.HexToAddress(coreAddress) == address
+}
+
+// importedNames returns the names of the contracts imported by the program, except the contracts imported from an address.
+func (p *Program) importedNames() []string {
+	names := make(map[string]bool)
+
+	for _, location := range p.imports() {
+		identifiers := p.importIdentifiers(location)
+		if len(identifiers) == 0 { // import "X"
+			identifiers = []string{location}
+		}
+		for _, identifier := range identifiers {
+			names[identifier] = true
+		}
+	}
+
+	for _, contractImport := range p.contractImports() {
+		if !contractImport.fromAddress {
+			names[contractImport.name] = true
+		}
+	}
+
+	result := maps.Keys(names)
+	slices.Sort(result)
+	return result
+}
+
+// ReplaceCoreContractImports replaces the addresses of the core contracts imported from their addresses on the network
+// with their addresses on the target network, so for example a mainnet transaction can be executed on the emulator.
+//
+// An import declaration is only replaced if all the contracts it imports are core contracts on the same target address.
+// The names of the contracts with replaced imports are returned.
+func ReplaceCoreContractImports(program *Program, network string, target string) []string {
+	code := program.Code()
+	declarations := program.astProgram.ImportDeclarations()
+	replaced := make([]string, 0)
+
+	// replace from the last declaration so positions of preceding declarations remain valid
+	for i := len(declarations) - 1; i >= 0; i-- {
+		declaration := declarations[i]
+		location, isAddressImport := declaration.Location.(common.AddressLocation)
+		if !isAddressImport || len(declaration.Identifiers) == 0 {
+			continue
+		}
+
+		identifiers := make([]string, len(declaration.Identifiers))
+		targetAddress := ""
+		for j, identifier := range declaration.Identifiers {
+			identifiers[j] = identifier.Identifier
+
+			addresses := coreContracts[identifier.Identifier]
+			if flow.HexToAddress(addresses[network]) != flow.Address(location.Address) || addresses[target] == "" {
+				targetAddress = ""
+				break
+			}
+			if targetAddress != "" && targetAddress != addresses[target] {
+				targetAddress = ""
+				break
+			}
+			targetAddress = addresses[target]
+		}
+		if targetAddress == "" {
+			continue
+		}
+
+		replacement := fmt.Sprintf("import %s from 0x%s", strings.Join(identifiers, ", "), targetAddress)
+		replacedCode := make([]byte, 0, len(code))
+		replacedCode = append(replacedCode, code[:declaration.StartPos.Offset]...)
+		replacedCode = append(replacedCode, replacement...)
+		replacedCode = append(replacedCode, code[declaration.EndPos.Offset+1:]...)
+		code = replacedCode
+
+		replaced = append(replaced, identifiers...)
+	}
+
+	program.script.SetCode(code)
+	program.reload()
+
+	slices.Sort(replaced)
+	return replaced
+}
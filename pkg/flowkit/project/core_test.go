@@ -0,0 +1,83 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoreContractAliases(t *testing.T) {
+	address := flow.HexToAddress("0x01")
+
+	contracts := []*Contract{
+		NewContract("Kitty", "contracts/Kitty.cdc", []byte(`
+			import FungibleToken from "./FungibleToken.cdc"
+			import "NonFungibleToken"
+			import MetadataViews from "./MetadataViews.cdc"
+			import FlowToken from 0x0ae53cb6e3f42a79
+			import Foo from "./Foo.cdc"
+			pub contract Kitty {}
+		`), address, "", nil),
+		NewContract("Foo", "contracts/Foo.cdc", []byte(`pub contract Foo {}`), address, "", nil),
+	}
+
+	aliases := Aliases{
+		"MetadataViews": "0000000000000002",
+	}
+
+	defaults, err := CoreContractAliases("testnet", contracts, aliases)
+	require.NoError(t, err)
+	assert.Equal(t, Aliases{
+		"FungibleToken":    "9a0766d93b6608b7",
+		"NonFungibleToken": "631e88ae7f1d7c20",
+	}, defaults)
+
+	defaults, err = CoreContractAliases("my-network", contracts, aliases)
+	require.NoError(t, err)
+	assert.Empty(t, defaults)
+}
+
+func TestReplaceCoreContractImports(t *testing.T) {
+	code := []byte(`
+		import FungibleToken from 0xf233dcee88fe0abe
+		import FlowToken from 0x1654653399040a61
+		import NonFungibleToken, MetadataViews from 0x1d7e57aa55817448
+		import Foo from 0x1654653399040a61
+		transaction {}
+	`)
+
+	program, err := NewProgram(&testScript{code: code, location: "tx.cdc"})
+	require.NoError(t, err)
+
+	replaced := ReplaceCoreContractImports(program, "mainnet", "emulator")
+	assert.Equal(t, []string{"FlowToken", "FungibleToken", "MetadataViews", "NonFungibleToken"}, replaced)
+
+	expected := []byte(`
+		import FungibleToken from 0xee82856bf20e2aa6
+		import FlowToken from 0x0ae53cb6e3f42a79
+		import NonFungibleToken, MetadataViews from 0xf8d6e0586b0a20c7
+		import Foo from 0x1654653399040a61
+		transaction {}
+	`)
+	assert.Equal(t, string(expected), string(program.Code()))
+}
@@ -20,7 +20,12 @@ package project
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/flow-go-sdk"
+	"golang.org/x/exp/maps"
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
 	"gonum.org/v1/gonum/graph/topo"
@@ -30,15 +35,29 @@ type deployContract struct {
 	index int64
 	*Contract
 	program      *Program
-	dependencies map[string]*deployContract
+	dependencies map[string]*dependency
+	aliases      map[string]string
+}
+
+// dependency is an imported contract together with the source range of the import declaration.
+type dependency struct {
+	contract    *deployContract
+	declaration ast.Range
 }
 
 func (d *deployContract) ID() int64 {
 	return d.index
 }
 
-func (d *deployContract) addDependency(location string, dep *deployContract) {
-	d.dependencies[location] = dep
+func (d *deployContract) addDependency(location string, dep *deployContract, declaration ast.Range) {
+	d.dependencies[location] = &dependency{
+		contract:    dep,
+		declaration: declaration,
+	}
+}
+
+func (d *deployContract) addAlias(location string, address string) {
+	d.aliases[location] = address
 }
 
 // Deployment contains logic to sort deployment order of contracts.
@@ -48,18 +67,75 @@ func (d *deployContract) addDependency(location string, dep *deployContract) {
 // Contracts are iterated and dependency graph is built which is then later sorted
 type Deployment struct {
 	contracts []*deployContract
-	// map of contracts by their location specified in state
-	contractsByLocation map[string]*deployContract
-	contractsByName     map[string]*deployContract
+	// map of contracts by their location specified in state, a location can declare multiple contracts
+	// and the same contract can be deployed to multiple accounts
+	contractsByLocation map[string][]*deployContract
+	contractsByName     map[string][]*deployContract
 	aliases             Aliases
+	loader              Loader
+	order               SortOrder
+}
+
+// SortOrder defines the deployment order of contracts which don't depend on each other.
+type SortOrder string
+
+const (
+	// SortByConfig keeps the order in which the contracts are defined in the configuration.
+	SortByConfig SortOrder = ""
+	// SortByName orders the contracts by name, and contracts with the same name by location.
+	SortByName SortOrder = "name"
+	// SortByLocation orders the contracts by location, and contracts in the same location by name.
+	SortByLocation SortOrder = "location"
+)
+
+// ParseSortOrder parses the sort order, an empty value keeps the configuration order.
+func ParseSortOrder(order string) (SortOrder, error) {
+	switch SortOrder(order) {
+	case SortByConfig, SortByName, SortByLocation:
+		return SortOrder(order), nil
+	default:
+		return "", fmt.Errorf("invalid sort order %s, options: %s, %s", order, SortByName, SortByLocation)
+	}
+}
+
+// less compares contracts by the sort order, ties are broken by the configuration order.
+func (o SortOrder) less(a, b *deployContract) bool {
+	keys := func(c *deployContract) []string {
+		switch o {
+		case SortByName:
+			return []string{c.Name, c.Location(), c.AccountAddress.String()}
+		case SortByLocation:
+			return []string{c.Location(), c.Name, c.AccountAddress.String()}
+		default:
+			return nil
+		}
+	}
+
+	aKeys, bKeys := keys(a), keys(b)
+	for i := range aKeys {
+		if aKeys[i] != bKeys[i] {
+			return aKeys[i] < bKeys[i]
+		}
+	}
+
+	return a.index < b.index
 }
 
 // NewDeployment from the flowkit Contracts and loaded from the contract location using a loader.
-func NewDeployment(contracts []*Contract, aliases Aliases) (*Deployment, error) {
+//
+// The loader is used to normalize contract locations and imports, so the same contract
+// can be imported using different relative paths.
+func NewDeployment(contracts []*Contract, aliases Aliases, loader Loader) (*Deployment, error) {
 	deployment := &Deployment{
-		contractsByLocation: make(map[string]*deployContract),
-		contractsByName:     make(map[string]*deployContract),
-		aliases:             aliases,
+		contractsByLocation: make(map[string][]*deployContract),
+		contractsByName:     make(map[string][]*deployContract),
+		aliases:             make(Aliases),
+		loader:              loader,
+	}
+
+	for source, target := range aliases {
+		deployment.aliases[source] = target // keep original so the alias by name is matched
+		deployment.aliases[loader.Normalize("", source)] = target
 	}
 
 	for _, contract := range contracts {
@@ -78,59 +154,240 @@ func (d *Deployment) add(contract *Contract) error {
 		return err
 	}
 
+	// if the location declares multiple contracts only the declaration of this contract is deployed
+	if len(program.contractDeclarations()) > 1 {
+		code, err := program.contractCode(contract.Name)
+		if err != nil {
+			return err
+		}
+
+		contract.SetCode(code)
+		program, err = NewProgram(contract)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = validateInitArguments(contract, program)
+	if err != nil {
+		return err
+	}
+
 	c := &deployContract{
 		index:        int64(len(d.contracts)),
 		Contract:     contract,
 		program:      program,
-		dependencies: make(map[string]*deployContract),
+		dependencies: make(map[string]*dependency),
+		aliases:      make(map[string]string),
 	}
 
 	d.contracts = append(d.contracts, c)
-	d.contractsByLocation[c.Location()] = c
-	d.contractsByName[c.Name] = c
+	location := d.loader.Normalize("", c.Location())
+	d.contractsByLocation[location] = append(d.contractsByLocation[location], c)
+	d.contractsByName[c.Name] = append(d.contractsByName[c.Name], c)
 
 	return nil
 }
 
+// SetSortOrder sets the order of contracts which don't depend on each other, by default the configuration order is kept.
+func (d *Deployment) SetSortOrder(order SortOrder) {
+	d.order = order
+}
+
 // Sort contracts by deployment order.
 //
 // Order of sorting is dependent on the possible imports contract contains, since
 // any imported contract must be deployed before deploying the contract with that import.
 // Only applicable to contracts.
 func (d *Deployment) Sort() ([]*Contract, error) {
-	if d.conflictExists() {
-		return nil, fmt.Errorf("the same contract cannot be deployed to multiple accounts on the same network")
+	sorted, err := d.sort()
+	if err != nil {
+		return nil, err
 	}
 
-	err := d.buildDependencies()
+	contracts := make([]*Contract, len(d.contracts))
+	for i, s := range sorted {
+		contracts[i] = s.Contract
+	}
+
+	return contracts, nil
+}
+
+// SortLevels sorts contracts by deployment order grouped into levels.
+//
+// Contracts in a level only depend on contracts from the preceding levels, so
+// all the contracts in the same level can be deployed concurrently.
+func (d *Deployment) SortLevels() ([][]*Contract, error) {
+	sorted, err := d.sort()
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([][]*Contract, 0)
+	contractLevels := make(map[*deployContract]int)
+	for _, c := range sorted {
+		level := 0
+		for _, dep := range c.dependencies {
+			if contractLevels[dep.contract] >= level {
+				level = contractLevels[dep.contract] + 1
+			}
+		}
+
+		contractLevels[c] = level
+		if level == len(levels) {
+			levels = append(levels, make([]*Contract, 0))
+		}
+		levels[level] = append(levels[level], c.Contract)
+	}
+
+	return levels, nil
+}
+
+func (d *Deployment) sort() ([]*deployContract, error) {
+	err := d.checkNameCollisions()
 	if err != nil {
 		return nil, err
 	}
 
-	sorted, err := sortByDeploymentOrder(d.contracts)
+	err = d.buildDependencies()
 	if err != nil {
 		return nil, err
 	}
 
-	contracts := make([]*Contract, len(d.contracts))
-	for i, s := range sorted {
-		contracts[i] = s.Contract
+	return sortByDeploymentOrder(d.contracts, d.order)
+}
+
+// Dependencies returns the contracts of the deployment imported by each contract, in the order they were added.
+func (d *Deployment) Dependencies() (map[*Contract][]*Contract, error) {
+	err := d.buildDependencies()
+	if err != nil {
+		return nil, err
 	}
 
-	return contracts, nil
+	dependencies := make(map[*Contract][]*Contract, len(d.contracts))
+	for _, c := range d.contracts {
+		imported := make([]*deployContract, 0, len(c.dependencies))
+		seen := make(map[int64]bool)
+		for _, dep := range c.dependencies {
+			if !seen[dep.contract.index] {
+				seen[dep.contract.index] = true
+				imported = append(imported, dep.contract)
+			}
+		}
+		sort.Slice(imported, func(i, j int) bool {
+			return imported[i].index < imported[j].index
+		})
+
+		contracts := make([]*Contract, len(imported))
+		for i, dep := range imported {
+			contracts[i] = dep.Contract
+		}
+		dependencies[c.Contract] = contracts
+	}
+
+	return dependencies, nil
+}
+
+// ContractNamesByAccount returns the names of the deployment contracts grouped by the address of the target account.
+func (d *Deployment) ContractNamesByAccount() map[flow.Address][]string {
+	names := make(map[flow.Address][]string)
+	for _, c := range d.contracts {
+		names[c.AccountAddress] = append(names[c.AccountAddress], c.Name)
+	}
+
+	return names
+}
+
+// checkNameCollisions returns an error if multiple contracts with the same name are deployed to the same account,
+// or to different accounts if the name is used in identifier imports and the imported contract is ambiguous.
+func (d *Deployment) checkNameCollisions() error {
+	byName := make(map[string][]*deployContract)
+	names := make([]string, 0)
+	for _, c := range d.contracts {
+		if _, exists := byName[c.Name]; !exists {
+			names = append(names, c.Name)
+		}
+		byName[c.Name] = append(byName[c.Name], c)
+	}
+
+	identifierImports := d.identifierImports()
+	collisions := make([]NameCollision, 0)
+	for _, name := range names {
+		contracts := byName[name]
+		if len(contracts) < 2 {
+			continue
+		}
+
+		// instances of the same contract deployed to multiple accounts are selected by the importers
+		colliding := contracts
+		if !identifierImports[name] || d.sameLocation(contracts) {
+			colliding = sameAccountContracts(contracts)
+		}
+		if len(colliding) == 0 {
+			continue
+		}
+
+		collision := NameCollision{Name: name}
+		for _, c := range colliding {
+			collision.Locations = append(collision.Locations, c.Location())
+			collision.Accounts = append(collision.Accounts, c.AccountName)
+		}
+		collisions = append(collisions, collision)
+	}
+
+	if len(collisions) > 0 {
+		return &NameCollisionError{Collisions: collisions}
+	}
+
+	return nil
 }
 
-// conflictExists returns true if the same contract is configured to deploy to more than one account for the same network.
-func (d *Deployment) conflictExists() bool {
-	uniq := make(map[string]bool)
+// identifierImports returns the names of the contracts imported by identifier (e.g. "import "X"" or "import X").
+func (d *Deployment) identifierImports() map[string]bool {
+	names := make(map[string]bool)
 	for _, c := range d.contracts {
-		if _, exists := uniq[c.Name]; exists {
-			return true
+		for _, location := range c.program.imports() {
+			if len(d.contractsByLocation[d.loader.Normalize(c.location, location)]) == 0 {
+				names[location] = true
+			}
+		}
+		for _, contractImport := range c.program.contractImports() {
+			if !contractImport.fromAddress {
+				names[contractImport.name] = true
+			}
+		}
+	}
+
+	return names
+}
+
+// sameLocation checks all the contracts are deployed from the same location.
+func (d *Deployment) sameLocation(contracts []*deployContract) bool {
+	location := d.loader.Normalize("", contracts[0].Location())
+	for _, c := range contracts {
+		if d.loader.Normalize("", c.Location()) != location {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameAccountContracts returns the contracts deployed to an account together with another contract from the list.
+func sameAccountContracts(contracts []*deployContract) []*deployContract {
+	byAccount := make(map[flow.Address]int)
+	for _, c := range contracts {
+		byAccount[c.AccountAddress]++
+	}
+
+	same := make([]*deployContract, 0)
+	for _, c := range contracts {
+		if byAccount[c.AccountAddress] > 1 {
+			same = append(same, c)
 		}
-		uniq[c.Name] = true
 	}
 
-	return false
+	return same
 }
 
 // buildDependencies iterates over all contracts and checks the imports which are added as its dependencies.
@@ -138,35 +395,167 @@ func (d *Deployment) buildDependencies() error {
 	for _, contract := range d.contracts {
 		for _, location := range contract.program.imports() {
 			// find contract by the path import
-			importPath := absolutePath(contract.location, location)
-			importContract, isPath := d.contractsByLocation[importPath]
-			if isPath {
-				contract.addDependency(location, importContract)
+			importPath := d.loader.Normalize(contract.location, location)
+			imported := importedContracts(d.contractsByLocation[importPath], contract.program.importIdentifiers(location))
+			if len(imported) > 0 {
+				imported, err := importedInstances(contract, imported)
+				if err != nil {
+					return err
+				}
+				for _, importContract := range imported {
+					key := location
+					if len(imported) > 1 { // keep a dependency for each contract declared in the location
+						key = fmt.Sprintf("%s.%s", location, importContract.Name)
+					}
+					contract.addDependency(key, importContract, contract.program.importRange(location))
+				}
 				continue
 			}
 			// find contract by identifier import - new schema
-			importContract, isIdentifier := d.contractsByName[location]
+			instances, isIdentifier := d.contractsByName[location]
 			if isIdentifier {
-				contract.addDependency(location, importContract)
+				importContract, err := importedInstance(contract, instances)
+				if err != nil {
+					return err
+				}
+				contract.addDependency(location, importContract, contract.program.importRange(location))
 				continue
 			}
 
 			// todo identifier aliases
-			if _, exists := d.aliases[importPath]; exists {
+			if address, exists := d.aliases[importPath]; exists {
+				contract.addAlias(location, address)
 				continue // if aliased then skip, not a dependency
 			}
+			// find alias by the imported contract name, so the aliased contract can be imported using any path
+			if address, exists := aliasByName(d.aliases, contract.program.importIdentifiers(location)); exists {
+				contract.addAlias(location, address)
+				continue
+			}
 
 			return fmt.Errorf(
-				"import from %s could not be found: %s, make sure import path is correct, and the contract is added to deployments or has an alias",
+				"import from %s could not be found: %s (normalized: %s), make sure import path is correct, and the contract is added to deployments or has an alias",
 				contract.Name,
 				location,
+				importPath,
 			)
 		}
+
+		// imports by address or identifier are dependencies only if the contract is part of the deployment,
+		// otherwise they are expected to be already deployed (e.g. core contracts) or built-in (e.g. Crypto)
+		for _, contractImport := range contract.program.contractImports() {
+			importContract, err := d.contractByImport(contract, contractImport)
+			if err != nil {
+				return err
+			}
+			if importContract == nil {
+				continue
+			}
+
+			contract.addDependency(contractImport.location(), importContract, contractImport.declaration)
+		}
 	}
 
 	return nil
 }
 
+// contractByImport returns the contract imported by the address or identifier import,
+// or nil if the contract is not part of the deployment.
+func (d *Deployment) contractByImport(importer *deployContract, contractImport contractImport) (*deployContract, error) {
+	if !contractImport.fromAddress {
+		instances, exists := d.contractsByName[contractImport.name]
+		if !exists {
+			return nil, nil
+		}
+		return importedInstance(importer, instances)
+	}
+
+	for _, c := range d.contracts {
+		if c.Name == contractImport.name && c.AccountAddress == contractImport.address {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// importedInstance returns the instance imported by the importer from the instances of a contract
+// deployed to multiple accounts.
+func importedInstance(importer *deployContract, instances []*deployContract) (*deployContract, error) {
+	contracts := make([]*Contract, len(instances))
+	for i, instance := range instances {
+		contracts[i] = instance.Contract
+	}
+
+	selected, err := selectInstance(importer.Contract, contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, instance := range instances {
+		if instance.Contract == selected {
+			return instance, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// importedInstances returns a single imported instance for each of the imported contracts.
+func importedInstances(importer *deployContract, imported []*deployContract) ([]*deployContract, error) {
+	byName := make(map[string][]*deployContract)
+	names := make([]string, 0)
+	for _, c := range imported {
+		if _, exists := byName[c.Name]; !exists {
+			names = append(names, c.Name)
+		}
+		byName[c.Name] = append(byName[c.Name], c)
+	}
+
+	instances := make([]*deployContract, 0, len(names))
+	for _, name := range names {
+		instance, err := importedInstance(importer, byName[name])
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
+// importedContracts returns the contracts declared in the same location which are imported by the identifiers.
+//
+// If the location declares a single contract it is returned regardless of the identifiers,
+// together with its instances if the contract is deployed to multiple accounts.
+func importedContracts(contracts []*deployContract, identifiers []string) []*deployContract {
+	if len(contracts) == 1 || sameName(contracts) {
+		return contracts
+	}
+
+	imported := make([]*deployContract, 0)
+	for _, contract := range contracts {
+		for _, identifier := range identifiers {
+			if contract.Name == identifier {
+				imported = append(imported, contract)
+			}
+		}
+	}
+
+	return imported
+}
+
+// sameName checks all the contracts have the same name.
+func sameName(contracts []*deployContract) bool {
+	for _, c := range contracts {
+		if c.Name != contracts[0].Name {
+			return false
+		}
+	}
+
+	return true
+}
+
 // sortByDeploymentOrder sorts the given set of contracts in order of deployment.
 //
 // The resulting ordering ensures that each contract is deployed after all of its
@@ -174,7 +563,7 @@ func (d *Deployment) buildDependencies() error {
 //
 // This function constructs a directed graph in which contracts are nodes and imports are edges.
 // The ordering is computed by performing a topological sort on the constructed graph.
-func sortByDeploymentOrder(contracts []*deployContract) ([]*deployContract, error) {
+func sortByDeploymentOrder(contracts []*deployContract, order SortOrder) ([]*deployContract, error) {
 	g := simple.NewDirectedGraph()
 
 	for _, c := range contracts {
@@ -183,15 +572,24 @@ func sortByDeploymentOrder(contracts []*deployContract) ([]*deployContract, erro
 
 	for _, c := range contracts {
 		for _, dep := range c.dependencies {
-			g.SetEdge(g.NewEdge(dep, c))
+			g.SetEdge(g.NewEdge(dep.contract, c))
+		}
+	}
+
+	var nodeOrder func([]graph.Node)
+	if order != SortByConfig {
+		nodeOrder = func(nodes []graph.Node) {
+			sort.SliceStable(nodes, func(i, j int) bool {
+				return order.less(nodes[i].(*deployContract), nodes[j].(*deployContract))
+			})
 		}
 	}
 
-	sorted, err := topo.SortStabilized(g, nil)
+	sorted, err := topo.SortStabilized(g, nodeOrder)
 	if err != nil {
 		switch topoErr := err.(type) {
 		case topo.Unorderable:
-			return nil, &CyclicImportError{Cycles: nodeSetsToContractSets(topoErr)}
+			return nil, newCyclicImportError(nodeSetsToContractSets(topoErr))
 		default:
 			return nil, err
 		}
@@ -220,30 +618,159 @@ func nodesToContracts(nodes []graph.Node) []*deployContract {
 	return contracts
 }
 
+// NameCollisionError is returned when multiple contracts in the deployment declare the same name
+// and can not be distinguished, either because they are deployed to the same account or imported by name.
+type NameCollisionError struct {
+	Collisions []NameCollision
+}
+
+// NameCollision is a contract name declared by multiple contracts together with their locations
+// and names of the accounts the contracts are deployed to.
+type NameCollision struct {
+	Name      string
+	Locations []string
+	Accounts  []string
+}
+
+func (c NameCollision) String() string {
+	contracts := make([]string, len(c.Locations))
+	for i, location := range c.Locations {
+		contracts[i] = fmt.Sprintf("%s (%s)", location, c.Accounts[i])
+	}
+
+	return fmt.Sprintf("%s declared in %s", c.Name, strings.Join(contracts, ", "))
+}
+
+func (e *NameCollisionError) Error() string {
+	collisions := make([]string, len(e.Collisions))
+	for i, collision := range e.Collisions {
+		collisions[i] = collision.String()
+	}
+
+	return fmt.Sprintf("contracts: name collision(s) detected: %s", strings.Join(collisions, "; "))
+}
+
 // CyclicImportError is returned when contract contain cyclic imports one to the
 // other which is not possible to be resolved and deployed.
 type CyclicImportError struct {
-	Cycles [][]*deployContract
+	Cycles [][]CycleElement
 }
 
-func (e *CyclicImportError) contractNames() [][]string {
-	cycles := make([][]string, 0, len(e.Cycles))
+// CycleElement is a contract in the import cycle together with the position
+// of the import declaration of the next contract in the cycle.
+type CycleElement struct {
+	Name     string
+	Location string
+	Import   ast.Position
+}
 
-	for _, cycle := range e.Cycles {
-		contracts := make([]string, 0, len(cycle))
-		for _, contract := range cycle {
-			contracts = append(contracts, contract.Name)
+func (e CycleElement) String() string {
+	return fmt.Sprintf("%s (%s:%d)", e.Name, e.Location, e.Import.Line)
+}
+
+// newCyclicImportError creates the error with cycles ordered in the import order
+// from the sets of contracts forming each cycle.
+func newCyclicImportError(contractSets [][]*deployContract) *CyclicImportError {
+	cycles := make([][]CycleElement, 0, len(contractSets))
+
+	for _, set := range contractSets {
+		path := importCycle(set)
+
+		cycle := make([]CycleElement, len(path))
+		for i, contract := range path {
+			next := path[(i+1)%len(path)]
+			cycle[i] = CycleElement{
+				Name:     contract.Name,
+				Location: contract.Location(),
+				Import:   importPosition(contract, next),
+			}
+		}
+
+		cycles = append(cycles, cycle)
+	}
+
+	return &CyclicImportError{Cycles: cycles}
+}
+
+// importCycle orders contracts of the set so each contract imports the next one,
+// and the last one imports the first one.
+func importCycle(set []*deployContract) []*deployContract {
+	if len(set) == 0 {
+		return nil
+	}
+
+	inSet := make(map[int64]bool)
+	start := set[0]
+	for _, c := range set {
+		inSet[c.ID()] = true
+		if c.ID() < start.ID() {
+			start = c
+		}
+	}
+
+	visited := map[int64]bool{start.ID(): true}
+	path := []*deployContract{start}
+
+	var visit func(c *deployContract) bool
+	visit = func(c *deployContract) bool {
+		locations := maps.Keys(c.dependencies)
+		sort.Strings(locations)
+
+		for _, location := range locations {
+			dep := c.dependencies[location].contract
+			if dep.ID() == start.ID() {
+				return true
+			}
+			if !inSet[dep.ID()] || visited[dep.ID()] {
+				continue
+			}
+
+			visited[dep.ID()] = true
+			path = append(path, dep)
+			if visit(dep) {
+				return true
+			}
+			path = path[:len(path)-1]
 		}
 
-		cycles = append(cycles, contracts)
+		return false
 	}
 
-	return cycles
+	if !visit(start) {
+		return set // not expected for a cycle, but fallback to the set order
+	}
+
+	return path
+}
+
+// importPosition returns the position of the import declaration of the imported contract.
+func importPosition(contract *deployContract, imported *deployContract) ast.Position {
+	for _, dep := range contract.dependencies {
+		if dep.contract.ID() == imported.ID() {
+			return dep.declaration.StartPos
+		}
+	}
+
+	return ast.Position{}
 }
 
 func (e *CyclicImportError) Error() string {
+	cycles := make([]string, 0, len(e.Cycles))
+
+	for _, cycle := range e.Cycles {
+		chain := make([]string, 0, len(cycle)+1)
+		for _, element := range cycle {
+			chain = append(chain, element.String())
+		}
+		if len(cycle) > 0 {
+			chain = append(chain, cycle[0].Name)
+		}
+
+		cycles = append(cycles, strings.Join(chain, " -> "))
+	}
+
 	return fmt.Sprintf(
-		"contracts: import cycle(s) detected: %v",
-		e.contractNames(),
+		"contracts: import cycle(s) detected: %s",
+		strings.Join(cycles, "; "),
 	)
 }
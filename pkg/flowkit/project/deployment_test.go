@@ -27,6 +27,7 @@ import (
 	"github.com/onflow/flow-go-sdk/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
 )
 
 type testContract struct {
@@ -211,7 +212,7 @@ func TestContractDeploymentOrder(t *testing.T) {
 				)
 			}
 
-			deployment, err := NewDeployment(contracts, nil)
+			deployment, err := NewDeployment(contracts, nil, testLoader{})
 
 			contracts, err = deployment.Sort()
 			if !strings.Contains(testCase.name, "unresolved") && !strings.Contains(testCase.name, "cycle") {
@@ -219,7 +220,7 @@ func TestContractDeploymentOrder(t *testing.T) {
 			}
 
 			if strings.Contains(testCase.name, "unresolved") {
-				assert.EqualError(t, err, "import from ContractH could not be found: Foo.cdc, make sure import path is correct, and the contract is added to deployments or has an alias")
+				assert.EqualError(t, err, "import from ContractH could not be found: Foo.cdc (normalized: Foo.cdc), make sure import path is correct, and the contract is added to deployments or has an alias")
 				return
 			}
 
@@ -243,3 +244,544 @@ func TestContractDeploymentOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestContractDeploymentNormalizedImports(t *testing.T) {
+	bar := []byte(`pub contract Bar {}`)
+	foo := func(location string) []byte {
+		return []byte(fmt.Sprintf(`
+			import Bar from "%s"
+			pub contract Foo {}
+		`, location))
+	}
+
+	tests := []struct {
+		name        string
+		barLocation string
+		fooLocation string
+		importPath  string
+	}{{
+		name:        "relative import",
+		barLocation: "cadence/contracts/utils/Bar.cdc",
+		fooLocation: "cadence/contracts/Foo.cdc",
+		importPath:  "./utils/Bar.cdc",
+	}, {
+		name:        "parent relative import",
+		barLocation: "cadence/utils/Bar.cdc",
+		fooLocation: "cadence/contracts/Foo.cdc",
+		importPath:  "../utils/Bar.cdc",
+	}, {
+		name:        "redundant slashes",
+		barLocation: "./cadence//contracts/utils/Bar.cdc",
+		fooLocation: "cadence/contracts/Foo.cdc",
+		importPath:  ".//utils///Bar.cdc",
+	}, {
+		name:        "absolute import",
+		barLocation: "cadence/contracts/utils/Bar.cdc",
+		fooLocation: "cadence/contracts/Foo.cdc",
+		importPath:  "/project/cadence/contracts/utils/Bar.cdc",
+	}, {
+		name:        "windows paths",
+		barLocation: `cadence\contracts\utils\Bar.cdc`,
+		fooLocation: `cadence\contracts\Foo.cdc`,
+		importPath:  `.\\utils\\Bar.cdc`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			contracts := []*Contract{
+				NewContract("Foo", test.fooLocation, foo(test.importPath), addresses.New(), "", nil),
+				NewContract("Bar", test.barLocation, bar, addresses.New(), "", nil),
+			}
+
+			deployment, err := NewDeployment(contracts, nil, NewFilesystemLoader("/project"))
+			require.NoError(t, err)
+
+			sorted, err := deployment.Sort()
+			require.NoError(t, err)
+			require.Len(t, sorted, 2)
+			assert.Equal(t, "Bar", sorted[0].Name)
+			assert.Equal(t, "Foo", sorted[1].Name)
+		})
+	}
+
+	t.Run("unresolved import", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Foo", "cadence/contracts/Foo.cdc", foo("./utils/Bar.cdc"), addresses.New(), "", nil),
+			NewContract("Bar", "cadence/Bar.cdc", bar, addresses.New(), "", nil),
+		}
+
+		deployment, err := NewDeployment(contracts, nil, NewFilesystemLoader("/project"))
+		require.NoError(t, err)
+
+		_, err = deployment.Sort()
+		assert.EqualError(t, err, "import from Foo could not be found: ./utils/Bar.cdc (normalized: /project/cadence/contracts/utils/Bar.cdc), make sure import path is correct, and the contract is added to deployments or has an alias")
+	})
+}
+
+func TestContractDeploymentCycleError(t *testing.T) {
+	contracts := []*Contract{
+		NewContract("ContractE", testContractE.location, testContractE.code, testContractE.accountAddress, "", nil),
+		NewContract("ContractF", testContractF.location, testContractF.code, testContractF.accountAddress, "", nil),
+	}
+
+	deployment, err := NewDeployment(contracts, nil, testLoader{})
+	require.NoError(t, err)
+
+	_, err = deployment.Sort()
+
+	var cycleErr *CyclicImportError
+	require.ErrorAs(t, err, &cycleErr)
+	require.Len(t, cycleErr.Cycles, 1)
+	assert.Equal(t, []CycleElement{{
+		Name:     "ContractE",
+		Location: "ContractE.cdc",
+		Import:   cycleErr.Cycles[0][0].Import,
+	}, {
+		Name:     "ContractF",
+		Location: "ContractF.cdc",
+		Import:   cycleErr.Cycles[0][1].Import,
+	}}, cycleErr.Cycles[0])
+	assert.Equal(t, 2, cycleErr.Cycles[0][0].Import.Line)
+	assert.Equal(t, 2, cycleErr.Cycles[0][1].Import.Line)
+	assert.EqualError(t, err, "contracts: import cycle(s) detected: ContractE (ContractE.cdc:2) -> ContractF (ContractF.cdc:2) -> ContractE")
+}
+
+func TestContractDeploymentAddressImports(t *testing.T) {
+	addressA := flow.HexToAddress("0x01")
+	addressB := flow.HexToAddress("0x02")
+
+	contractA := []byte(`pub contract ContractA {}`)
+	contractB := []byte(`
+		import ContractA from 0x01
+		import FungibleToken from 0xee82856bf20e2aa6
+		import Crypto
+
+		pub contract ContractB {}
+	`)
+	contractC := []byte(`
+		import ContractA from 0x02
+		import ContractB from 0x02
+
+		pub contract ContractC {}
+	`)
+
+	t.Run("dependency by address", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("ContractB", "ContractB.cdc", contractB, addressB, "", nil),
+			NewContract("ContractA", "ContractA.cdc", contractA, addressA, "", nil),
+		}
+
+		deployment, err := NewDeployment(contracts, nil, testLoader{})
+		require.NoError(t, err)
+
+		sorted, err := deployment.Sort()
+		require.NoError(t, err)
+		require.Len(t, sorted, 2)
+		assert.Equal(t, "ContractA", sorted[0].Name)
+		assert.Equal(t, "ContractB", sorted[1].Name)
+	})
+
+	t.Run("dependency matched by name and address", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("ContractC", "ContractC.cdc", contractC, addressB, "", nil),
+			NewContract("ContractB", "ContractB.cdc", contractB, addressB, "", nil),
+			NewContract("ContractA", "ContractA.cdc", contractA, addressA, "", nil),
+		}
+
+		deployment, err := NewDeployment(contracts, nil, testLoader{})
+		require.NoError(t, err)
+
+		sorted, err := deployment.Sort()
+		require.NoError(t, err)
+		require.Len(t, sorted, 3)
+		// ContractC imports ContractA from a different address so it only depends on ContractB
+		assert.Equal(t, "ContractA", sorted[0].Name)
+		assert.Equal(t, "ContractB", sorted[1].Name)
+		assert.Equal(t, "ContractC", sorted[2].Name)
+	})
+}
+
+func TestContractDeploymentMultipleDeclarations(t *testing.T) {
+	address := flow.HexToAddress("0x01")
+
+	tokens := []byte(`
+		import Crypto
+
+		pub contract Token: TokenInterface {}
+
+		pub contract interface TokenInterface {}
+	`)
+	market := []byte(`
+		import Token from "Tokens.cdc"
+
+		pub contract Market {}
+	`)
+
+	contracts := []*Contract{
+		NewContract("Market", "Market.cdc", market, address, "", nil),
+		NewContract("Token", "Tokens.cdc", tokens, address, "", nil),
+		NewContract("TokenInterface", "Tokens.cdc", tokens, address, "", nil),
+	}
+
+	deployment, err := NewDeployment(contracts, nil, testLoader{})
+	require.NoError(t, err)
+
+	sorted, err := deployment.Sort()
+	require.NoError(t, err)
+	require.Len(t, sorted, 3)
+	assert.Equal(t, "TokenInterface", sorted[0].Name)
+	assert.Equal(t, "Token", sorted[1].Name)
+	assert.Equal(t, "Market", sorted[2].Name)
+
+	// each contract only contains its own declaration
+	assert.Equal(t, "import Crypto\n\npub contract interface TokenInterface {}\n", string(sorted[0].Code()))
+	assert.Equal(t, "import Crypto\nimport \"TokenInterface\"\n\npub contract Token: TokenInterface {}\n", string(sorted[1].Code()))
+}
+
+func TestContractDeploymentDeclarationReferences(t *testing.T) {
+	address := flow.HexToAddress("0x01")
+
+	code := []byte(`
+		pub contract Vault {
+			pub let balance: UFix64
+			init() { self.balance = 0.0 }
+		}
+
+		// the Vault contract stores the balance
+		pub contract Ledger {
+			pub let description: String
+			init() { self.description = "the Vault balance" }
+		}
+
+		pub contract Bank {
+			pub fun balance(): UFix64 {
+				return Vault.balance
+			}
+		}
+
+		pub contract Store {
+			pub let vaults: {String: &Vault}
+			init() { self.vaults = {} }
+		}
+	`)
+
+	contracts := []*Contract{
+		NewContract("Vault", "Contracts.cdc", code, address, "", nil),
+		NewContract("Ledger", "Contracts.cdc", code, address, "", nil),
+		NewContract("Bank", "Contracts.cdc", code, address, "", nil),
+		NewContract("Store", "Contracts.cdc", code, address, "", nil),
+	}
+
+	deployment, err := NewDeployment(contracts, nil, testLoader{})
+	require.NoError(t, err)
+
+	sorted, err := deployment.Sort()
+	require.NoError(t, err)
+	require.Len(t, sorted, 4)
+
+	codes := make(map[string]string)
+	for _, contract := range sorted {
+		codes[contract.Name] = string(contract.Code())
+	}
+
+	// names in comments and strings are not references
+	assert.NotContains(t, codes["Ledger"], `import "Vault"`)
+	// references by expressions and by types are imported
+	assert.Contains(t, codes["Bank"], `import "Vault"`)
+	assert.Contains(t, codes["Store"], `import "Vault"`)
+}
+
+func TestContractDeploymentLevels(t *testing.T) {
+	address := flow.HexToAddress("0x01")
+
+	contracts := []*Contract{
+		NewContract("ContractB", "ContractB.cdc", []byte(`
+			import ContractA from "ContractA.cdc"
+			pub contract ContractB {}
+		`), address, "", nil),
+		NewContract("ContractC", "ContractC.cdc", []byte(`
+			import ContractA from "ContractA.cdc"
+			import ContractB from "ContractB.cdc"
+			pub contract ContractC {}
+		`), address, "", nil),
+		NewContract("ContractA", "ContractA.cdc", []byte(`pub contract ContractA {}`), address, "", nil),
+		NewContract("ContractD", "ContractD.cdc", []byte(`pub contract ContractD {}`), address, "", nil),
+	}
+
+	deployment, err := NewDeployment(contracts, nil, testLoader{})
+	require.NoError(t, err)
+
+	levels, err := deployment.SortLevels()
+	require.NoError(t, err)
+
+	expected := [][]string{{"ContractA", "ContractD"}, {"ContractB"}, {"ContractC"}}
+	require.Len(t, levels, len(expected))
+	for i, level := range levels {
+		names := make([]string, len(level))
+		for j, contract := range level {
+			names[j] = contract.Name
+		}
+		assert.ElementsMatch(t, expected[i], names)
+	}
+}
+
+func TestContractDeploymentNameCollision(t *testing.T) {
+	alice := flow.HexToAddress("0x01")
+	bob := flow.HexToAddress("0x02")
+
+	foo := []byte(`pub contract Foo {}`)
+	bar := []byte(`
+		import "Foo"
+		pub contract Bar {}
+	`)
+
+	t.Run("same account", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Foo", "Foo.cdc", foo, alice, "alice", nil),
+			NewContract("Foo", "other/Foo.cdc", foo, alice, "alice", nil),
+		}
+
+		deployment, err := NewDeployment(contracts, nil, testLoader{})
+		require.NoError(t, err)
+
+		_, err = deployment.Sort()
+		var collisionErr *NameCollisionError
+		require.ErrorAs(t, err, &collisionErr)
+		assert.Equal(t, []NameCollision{{
+			Name:      "Foo",
+			Locations: []string{"Foo.cdc", "other/Foo.cdc"},
+			Accounts:  []string{"alice", "alice"},
+		}}, collisionErr.Collisions)
+		assert.EqualError(t, err, "contracts: name collision(s) detected: Foo declared in Foo.cdc (alice), other/Foo.cdc (alice)")
+	})
+
+	t.Run("different accounts", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Foo", "Foo.cdc", foo, alice, "alice", nil),
+			NewContract("Foo", "other/Foo.cdc", foo, bob, "bob", nil),
+		}
+
+		deployment, err := NewDeployment(contracts, nil, testLoader{})
+		require.NoError(t, err)
+
+		sorted, err := deployment.Sort()
+		require.NoError(t, err)
+		assert.Len(t, sorted, 2)
+	})
+
+	t.Run("different accounts imported by identifier", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Foo", "Foo.cdc", foo, alice, "alice", nil),
+			NewContract("Foo", "other/Foo.cdc", foo, bob, "bob", nil),
+			NewContract("Bar", "Bar.cdc", bar, bob, "bob", nil),
+		}
+
+		deployment, err := NewDeployment(contracts, nil, testLoader{})
+		require.NoError(t, err)
+
+		_, err = deployment.Sort()
+		assert.EqualError(t, err, "contracts: name collision(s) detected: Foo declared in Foo.cdc (alice), other/Foo.cdc (bob)")
+	})
+}
+
+func TestContractDeploymentMultipleInstances(t *testing.T) {
+	alice := flow.HexToAddress("0x01")
+	bob := flow.HexToAddress("0x02")
+	charlie := flow.HexToAddress("0x03")
+
+	market := []byte(`pub contract Market {}`)
+	shop := []byte(`
+		import Market from "Market.cdc"
+		pub contract Shop {}
+	`)
+
+	newContracts := func(imports map[string]string) []*Contract {
+		shopContract := NewContract("Shop", "Shop.cdc", shop, charlie, "charlie", nil)
+		shopContract.ImportAccounts = imports
+
+		return []*Contract{
+			NewContract("Market", "Market.cdc", market, alice, "alice", nil),
+			shopContract,
+			NewContract("Market", "Market.cdc", market, bob, "bob", nil),
+		}
+	}
+
+	t.Run("import selected by account", func(t *testing.T) {
+		contracts := newContracts(map[string]string{"Market": "bob"})
+		deployment, err := NewDeployment(contracts, nil, testLoader{})
+		require.NoError(t, err)
+
+		sorted, err := deployment.Sort()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, contracts, sorted)
+		assert.Less(t, slices.Index(sorted, contracts[2]), slices.Index(sorted, contracts[1]))
+
+		program, err := NewProgram(contracts[1])
+		require.NoError(t, err)
+		program, err = NewImportReplacer(contracts, nil, NewFilesystemLoader("")).ForContract(contracts[1]).Replace(program)
+		require.NoError(t, err)
+		assert.Contains(t, string(program.Code()), "import Market from 0x0000000000000002")
+	})
+
+	t.Run("import from the same account", func(t *testing.T) {
+		contracts := newContracts(nil)
+		contracts[1].AccountAddress = alice
+		contracts[1].AccountName = "alice"
+
+		deployment, err := NewDeployment(contracts, nil, testLoader{})
+		require.NoError(t, err)
+
+		sorted, err := deployment.Sort()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, contracts, sorted)
+		assert.Less(t, slices.Index(sorted, contracts[0]), slices.Index(sorted, contracts[1]))
+	})
+
+	t.Run("ambiguous import", func(t *testing.T) {
+		deployment, err := NewDeployment(newContracts(nil), nil, testLoader{})
+		require.NoError(t, err)
+
+		_, err = deployment.Sort()
+		assert.EqualError(t, err, "contract Shop import of Market is ambiguous, Market is deployed to accounts alice, bob, select the account in the deployment imports")
+	})
+
+	t.Run("import from not deployed account", func(t *testing.T) {
+		deployment, err := NewDeployment(newContracts(map[string]string{"Market": "charlie"}), nil, testLoader{})
+		require.NoError(t, err)
+
+		_, err = deployment.Sort()
+		assert.EqualError(t, err, "contract Shop imports Market from account charlie, but Market is not deployed to the account")
+	})
+}
+
+func TestContractDeploymentAliasByName(t *testing.T) {
+	address := flow.HexToAddress("0x01")
+
+	contracts := []*Contract{
+		NewContract("Foo", "contracts/Foo.cdc", []byte(`
+			import FungibleToken from "../../standard/FungibleToken.cdc"
+			pub contract Foo {}
+		`), address, "", nil),
+		NewContract("Bar", "contracts/nested/Bar.cdc", []byte(`
+			import FungibleToken from "../../../standard/FungibleToken.cdc"
+			pub contract Bar {}
+		`), address, "", nil),
+	}
+
+	aliases := Aliases{
+		"standard/FungibleToken.cdc": "ee82856bf20e2aa6",
+		"FungibleToken":              "ee82856bf20e2aa6",
+	}
+
+	deployment, err := NewDeployment(contracts, aliases, testLoader{})
+	require.NoError(t, err)
+
+	sorted, err := deployment.Sort()
+	require.NoError(t, err)
+	assert.Len(t, sorted, 2)
+}
+
+func TestContractDeploymentRemoteImports(t *testing.T) {
+	address := flow.HexToAddress("0x01")
+	remote := "https://raw.githubusercontent.com/onflow/flow-nft/master/contracts/NonFungibleToken.cdc"
+
+	contracts := []*Contract{
+		NewContract("Kitty", "contracts/Kitty.cdc", []byte(fmt.Sprintf(`
+			import NonFungibleToken from "%s"
+			pub contract Kitty {}
+		`, remote)), address, "", nil),
+		NewContract("NonFungibleToken", remote, []byte(`
+			import ViewResolver from "./ViewResolver.cdc"
+			pub contract NonFungibleToken {}
+		`), address, "", nil),
+		NewContract(
+			"ViewResolver",
+			"https://raw.githubusercontent.com/onflow/flow-nft/master/contracts/ViewResolver.cdc",
+			[]byte(`pub contract ViewResolver {}`),
+			address,
+			"",
+			nil,
+		),
+	}
+
+	deployment, err := NewDeployment(contracts, Aliases{}, NewFilesystemLoader("/project"))
+	require.NoError(t, err)
+
+	sorted, err := deployment.Sort()
+	require.NoError(t, err)
+	require.Len(t, sorted, 3)
+	assert.Equal(t, "ViewResolver", sorted[0].Name)
+	assert.Equal(t, "NonFungibleToken", sorted[1].Name)
+	assert.Equal(t, "Kitty", sorted[2].Name)
+}
+
+func TestContractDeploymentSortOrder(t *testing.T) {
+	contracts := func(order ...testContract) []*Contract {
+		result := make([]*Contract, len(order))
+		for i, contract := range order {
+			result[i] = NewContract(
+				strings.Split(contract.location, ".")[0],
+				contract.location,
+				contract.code,
+				contract.accountAddress,
+				contract.accountName,
+				nil,
+			)
+		}
+		return result
+	}
+
+	permutations := [][]*Contract{
+		contracts(testContractG, testContractB, testContractC, testContractA, testContractD),
+		contracts(testContractD, testContractC, testContractA, testContractG, testContractB),
+		contracts(testContractA, testContractB, testContractC, testContractD, testContractG),
+	}
+
+	orders := make([][]string, len(permutations))
+	for i, permutation := range permutations {
+		deployment, err := NewDeployment(permutation, nil, testLoader{})
+		require.NoError(t, err)
+		deployment.SetSortOrder(SortByName)
+
+		sorted, err := deployment.Sort()
+		require.NoError(t, err)
+
+		for _, contract := range sorted {
+			orders[i] = append(orders[i], contract.Name)
+		}
+	}
+
+	assert.Equal(t, []string{"ContractA", "ContractB", "ContractC", "ContractD", "ContractG"}, orders[0])
+	assert.Equal(t, orders[0], orders[1])
+	assert.Equal(t, orders[0], orders[2])
+
+	_, err := ParseSortOrder("size")
+	assert.EqualError(t, err, "invalid sort order size, options: name, location")
+}
+
+func TestContractDeploymentDependencies(t *testing.T) {
+	address := flow.HexToAddress("0x01")
+
+	contracts := []*Contract{
+		NewContract("ContractC", "ContractC.cdc", []byte(`
+			import ContractB from "ContractB.cdc"
+			import ContractA from "ContractA.cdc"
+			pub contract ContractC {}
+		`), address, "", nil),
+		NewContract("ContractA", "ContractA.cdc", []byte(`pub contract ContractA {}`), address, "", nil),
+		NewContract("ContractB", "ContractB.cdc", []byte(`
+			import ContractA from "ContractA.cdc"
+			pub contract ContractB {}
+		`), address, "", nil),
+	}
+
+	deployment, err := NewDeployment(contracts, nil, testLoader{})
+	require.NoError(t, err)
+
+	dependencies, err := deployment.Dependencies()
+	require.NoError(t, err)
+
+	assert.Equal(t, []*Contract{contracts[1], contracts[2]}, dependencies[contracts[0]])
+	assert.Empty(t, dependencies[contracts[1]])
+	assert.Equal(t, []*Contract{contracts[1]}, dependencies[contracts[2]])
+}
@@ -0,0 +1,204 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// GraphNode is a contract or an aliased import in the dependency graph.
+type GraphNode struct {
+	ID    string
+	Label string
+	Alias bool
+}
+
+// GraphEdge connects the importing contract with the imported contract or alias.
+type GraphEdge struct {
+	From   string
+	To     string
+	Alias  bool
+	Cyclic bool
+}
+
+// DependencyGraph is a graph of contracts in the deployment and their imports.
+type DependencyGraph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// Graph builds the dependency graph of the contracts in the deployment.
+//
+// Edges point from the importing contract to the imported contract or alias, and the
+// edges being part of an import cycle are marked as cyclic.
+func (d *Deployment) Graph() (*DependencyGraph, error) {
+	err := d.buildDependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	g := simple.NewDirectedGraph()
+	for _, c := range d.contracts {
+		g.AddNode(c)
+	}
+	for _, c := range d.contracts {
+		for _, dep := range c.dependencies {
+			if dep.contract.ID() != c.ID() {
+				g.SetEdge(g.NewEdge(dep.contract, c))
+			}
+		}
+	}
+
+	// contracts in the same strongly connected component import each other
+	components := make(map[int64]int)
+	for i, component := range topo.TarjanSCC(g) {
+		if len(component) < 2 {
+			continue
+		}
+		for _, node := range component {
+			components[node.ID()] = i
+		}
+	}
+
+	cyclic := func(from *deployContract, to *deployContract) bool {
+		if from.ID() == to.ID() {
+			return true
+		}
+		fromComponent, fromOk := components[from.ID()]
+		toComponent, toOk := components[to.ID()]
+		return fromOk && toOk && fromComponent == toComponent
+	}
+
+	graph := &DependencyGraph{}
+	aliasIDs := make(map[string]string)
+
+	for _, c := range d.contracts {
+		label := c.Name
+		if c.AccountName != "" {
+			label = fmt.Sprintf("%s (%s)", c.Name, c.AccountName)
+		}
+		graph.Nodes = append(graph.Nodes, GraphNode{ID: contractNodeID(c), Label: label})
+	}
+
+	for _, c := range d.contracts {
+		locations := maps.Keys(c.dependencies)
+		sort.Strings(locations)
+		for _, location := range locations {
+			dep := c.dependencies[location].contract
+			graph.Edges = append(graph.Edges, GraphEdge{
+				From:   contractNodeID(c),
+				To:     contractNodeID(dep),
+				Cyclic: cyclic(c, dep),
+			})
+		}
+
+		locations = maps.Keys(c.aliases)
+		sort.Strings(locations)
+		for _, location := range locations {
+			address := c.aliases[location]
+			label := fmt.Sprintf("%s (0x%s)", location, strings.TrimPrefix(address, "0x"))
+
+			id, exists := aliasIDs[label]
+			if !exists {
+				id = fmt.Sprintf("a%d", len(aliasIDs))
+				aliasIDs[label] = id
+				graph.Nodes = append(graph.Nodes, GraphNode{ID: id, Label: label, Alias: true})
+			}
+
+			graph.Edges = append(graph.Edges, GraphEdge{
+				From:  contractNodeID(c),
+				To:    id,
+				Alias: true,
+			})
+		}
+	}
+
+	return graph, nil
+}
+
+func contractNodeID(c *deployContract) string {
+	return fmt.Sprintf("c%d", c.index)
+}
+
+// DOT returns the graph in the Graphviz DOT format.
+func (g *DependencyGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph deployment {\n")
+
+	for _, node := range g.Nodes {
+		attributes := fmt.Sprintf(`label="%s"`, strings.ReplaceAll(node.Label, `"`, `\"`))
+		if node.Alias {
+			attributes += ", style=dashed"
+		}
+		b.WriteString(fmt.Sprintf("\t\"%s\" [%s];\n", node.ID, attributes))
+	}
+
+	for _, edge := range g.Edges {
+		attributes := ""
+		if edge.Alias {
+			attributes = " [style=dashed]"
+		}
+		if edge.Cyclic {
+			attributes = " [color=red]"
+		}
+		b.WriteString(fmt.Sprintf("\t\"%s\" -> \"%s\"%s;\n", edge.From, edge.To, attributes))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid returns the graph in the Mermaid flowchart format.
+func (g *DependencyGraph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("graph TD\n")
+
+	for _, node := range g.Nodes {
+		label := strings.ReplaceAll(node.Label, `"`, "#quot;")
+		if node.Alias {
+			b.WriteString(fmt.Sprintf("\t%s([\"%s\"])\n", node.ID, label))
+		} else {
+			b.WriteString(fmt.Sprintf("\t%s[\"%s\"]\n", node.ID, label))
+		}
+	}
+
+	var cyclic []string
+	for i, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Alias {
+			arrow = "-.->"
+		}
+		if edge.Cyclic {
+			cyclic = append(cyclic, fmt.Sprintf("%d", i))
+		}
+		b.WriteString(fmt.Sprintf("\t%s %s %s\n", edge.From, arrow, edge.To))
+	}
+
+	if len(cyclic) > 0 {
+		b.WriteString(fmt.Sprintf("\tlinkStyle %s stroke:red\n", strings.Join(cyclic, ",")))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,72 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeployment_Graph(t *testing.T) {
+	contracts := []*Contract{
+		NewContract("ContractA", testContractA.location, testContractA.code, testContractA.accountAddress, "alice", nil),
+		NewContract("ContractC", testContractC.location, testContractC.code, testContractC.accountAddress, "bob", nil),
+		NewContract("ContractE", testContractE.location, testContractE.code, testContractE.accountAddress, "bob", nil),
+		NewContract("ContractF", testContractF.location, testContractF.code, testContractF.accountAddress, "bob", nil),
+		NewContract("ContractH", testContractH.location, testContractH.code, testContractH.accountAddress, "bob", nil),
+	}
+	aliases := Aliases{"Foo.cdc": flow.HexToAddress("0x01").String()}
+
+	deployment, err := NewDeployment(contracts, aliases, testLoader{})
+	require.NoError(t, err)
+
+	graph, err := deployment.Graph()
+	require.NoError(t, err)
+
+	assert.Equal(t, `digraph deployment {
+	"c0" [label="ContractA (alice)"];
+	"c1" [label="ContractC (bob)"];
+	"c2" [label="ContractE (bob)"];
+	"c3" [label="ContractF (bob)"];
+	"c4" [label="ContractH (bob)"];
+	"a0" [label="Foo.cdc (0x0000000000000001)", style=dashed];
+	"c1" -> "c0";
+	"c2" -> "c3" [color=red];
+	"c3" -> "c2" [color=red];
+	"c4" -> "a0" [style=dashed];
+}
+`, graph.DOT())
+
+	assert.Equal(t, `graph TD
+	c0["ContractA (alice)"]
+	c1["ContractC (bob)"]
+	c2["ContractE (bob)"]
+	c3["ContractF (bob)"]
+	c4["ContractH (bob)"]
+	a0(["Foo.cdc (0x0000000000000001)"])
+	c1 --> c0
+	c2 --> c3
+	c3 --> c2
+	c4 -.-> a0
+	linkStyle 1,2 stroke:red
+`, graph.Mermaid())
+}
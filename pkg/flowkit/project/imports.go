@@ -21,6 +21,7 @@ package project
 import (
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/onflow/flow-go-sdk"
 )
@@ -31,59 +32,241 @@ type Account interface {
 }
 
 // ImportReplacer implements file import replacements functionality for the project contracts with optionally included aliases.
+//
+// The loader normalizes the contract, alias and import locations, so the imports are matched the same way as
+// the imports of the contracts in a deployment.
 type ImportReplacer struct {
 	contracts []*Contract
 	aliases   Aliases
+	loader    Loader
+	importer  *Contract
 }
 
-func NewImportReplacer(contracts []*Contract, aliases Aliases) *ImportReplacer {
+func NewImportReplacer(contracts []*Contract, aliases Aliases, loader Loader) *ImportReplacer {
 	return &ImportReplacer{
 		contracts: contracts,
 		aliases:   aliases,
+		loader:    loader,
+	}
+}
+
+// ForContract returns an import replacer for the imports of the contract, so the imports of contracts
+// deployed to multiple accounts are replaced with the address of the instance the contract depends on.
+func (i *ImportReplacer) ForContract(contract *Contract) *ImportReplacer {
+	return &ImportReplacer{
+		contracts: i.contracts,
+		aliases:   i.aliases,
+		loader:    i.loader,
+		importer:  contract,
 	}
 }
 
 func (i *ImportReplacer) Replace(program *Program) (*Program, error) {
-	imports := program.imports()
+	addresses, err := i.Addresses(program)
+	if err != nil {
+		return nil, err
+	}
+
+	for location, address := range addresses {
+		program.replaceImport(location, address)
+	}
+
+	return program, nil
+}
+
+// Addresses returns the addresses the program imports are replaced with, mapped by the import location.
+func (i *ImportReplacer) Addresses(program *Program) (map[string]string, error) {
+	addresses := make(map[string]string)
 	contractsLocations := i.getContractsLocations()
+	aliasLocations := i.getAliasLocations()
+
+	for _, imp := range program.imports() {
+		// check if import by path exists (e.g. import X from ["./X.cdc"]), and then
+		// if import by identifier exists (e.g. import ["X"]), aliases take precedence over the contracts
+		importLocation := i.loader.Normalize(program.Location(), imp)
+		resolved := false
+		for _, location := range []string{importLocation, imp} {
+			if address, isAliased := aliasLocations[location]; isAliased {
+				addresses[imp] = address
+				resolved = true
+				break
+			}
 
-	for _, imp := range imports {
-		// check if import by path exists (e.g. import X from ["./X.cdc"])
-		importLocation := path.Clean(absolutePath(program.Location(), imp))
-		address, isPath := contractsLocations[importLocation]
-		if isPath {
-			program.replaceImport(imp, address)
+			if instances, exists := contractsLocations[location]; exists {
+				instance, err := selectInstance(i.importer, instances)
+				if err != nil {
+					return nil, err
+				}
+				addresses[imp] = instance.AccountAddress.String()
+				resolved = true
+				break
+			}
+		}
+		if resolved {
 			continue
 		}
-		// check if import by identifier exists (e.g. import ["X"])
-		address, isIdentifier := contractsLocations[imp]
-		if isIdentifier {
-			program.replaceImport(imp, address)
+		// check if the imported contract name is aliased (e.g. import [X] from "../other/path/X.cdc")
+		alias, isAliased := aliasByName(i.aliases, program.importIdentifiers(imp))
+		if isAliased {
+			addresses[imp] = flow.HexToAddress(alias).String()
 			continue
 		}
 
-		return nil, fmt.Errorf("import %s could not be resolved from provided contracts", imp)
+		return nil, fmt.Errorf(
+			"import from %s could not be found: %s (normalized: %s), make sure import path is correct, and the contract is added to deployments or has an alias",
+			program.Location(),
+			imp,
+			CleanLocation(absolutePath(program.Location(), imp)), // relative to the project root, unlike the normalized location
+		)
 	}
 
-	return program, nil
+	return addresses, nil
 }
 
-// getContractsLocations return a map with contract locations as keys and addresses where they are deployed as values.
-func (i *ImportReplacer) getContractsLocations() map[string]string {
-	locationAddress := make(map[string]string)
+// ImportedFiles returns the locations of the local files imported by the program, the files imported by
+// their location and the files of the project contracts imported by their name.
+//
+// The imports resolved to aliases and remote locations are not included.
+func (i *ImportReplacer) ImportedFiles(program *Program) []string {
+	contractsLocations := i.getContractsLocations()
+	aliasLocations := i.getAliasLocations()
+
+	files := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(location string) {
+		if !IsRemote(location) && !seen[location] {
+			seen[location] = true
+			files = append(files, location)
+		}
+	}
+
+	for _, imp := range program.imports() {
+		importLocation := i.loader.Normalize(program.Location(), imp)
+		if _, isAliased := aliasLocations[importLocation]; isAliased {
+			continue
+		}
+
+		if instances, exists := contractsLocations[importLocation]; exists {
+			add(CleanLocation(instances[0].Location()))
+		} else if instances, exists := contractsLocations[imp]; exists {
+			add(CleanLocation(instances[0].Location()))
+		} else if strings.HasSuffix(imp, ".cdc") {
+			add(CleanLocation(absolutePath(program.Location(), imp)))
+		}
+	}
+
+	return files
+}
+
+// getContractsLocations return a map with normalized contract locations as keys and the contracts deployed from the location as values.
+//
+// The same contract can be deployed to multiple accounts, so a location can have multiple contract instances.
+func (i *ImportReplacer) getContractsLocations() map[string][]*Contract {
+	locationContracts := make(map[string][]*Contract)
 	for _, contract := range i.contracts {
-		locationAddress[path.Clean(contract.Location())] = contract.AccountAddress.String()
+		location := i.loader.Normalize("", contract.Location())
+		locationContracts[location] = append(locationContracts[location], contract)
 		// add also by name since we might use the new import schema
-		locationAddress[contract.Name] = contract.AccountAddress.String()
+		if location != contract.Name {
+			locationContracts[contract.Name] = append(locationContracts[contract.Name], contract)
+		}
 	}
 
+	return locationContracts
+}
+
+// getAliasLocations return a map with normalized alias locations as keys and aliased addresses as values.
+//
+// The original alias location is kept too, so the aliases are also matched by the imported contract name.
+func (i *ImportReplacer) getAliasLocations() map[string]string {
+	locationAddress := make(map[string]string)
 	for source, target := range i.aliases {
-		locationAddress[path.Clean(source)] = flow.HexToAddress(target).String()
+		address := flow.HexToAddress(target).String()
+		locationAddress[source] = address
+		locationAddress[i.loader.Normalize("", source)] = address
 	}
 
 	return locationAddress
 }
 
+// selectInstance selects the imported contract instance from the instances of a contract deployed to multiple accounts.
+//
+// The importer can select the instance by the account name in the deployment imports, otherwise the instance
+// deployed to the same account as the importer is selected. If the importer is not a project contract
+// (e.g. a transaction) the last instance is selected, as the import can't be disambiguated.
+func selectInstance(importer *Contract, instances []*Contract) (*Contract, error) {
+	last := instances[len(instances)-1]
+	if importer == nil || sameAddress(instances) {
+		return last, nil
+	}
+
+	name := last.Name
+	if account, ok := importer.ImportAccounts[name]; ok {
+		for _, instance := range instances {
+			if instance.AccountName == account {
+				return instance, nil
+			}
+		}
+		return nil, fmt.Errorf(
+			"contract %s imports %s from account %s, but %s is not deployed to the account",
+			importer.Name,
+			name,
+			account,
+			name,
+		)
+	}
+
+	for _, instance := range instances {
+		if instance.AccountAddress == importer.AccountAddress {
+			return instance, nil
+		}
+	}
+
+	accounts := make([]string, len(instances))
+	for j, instance := range instances {
+		accounts[j] = instance.AccountName
+	}
+
+	return nil, fmt.Errorf(
+		"contract %s import of %s is ambiguous, %s is deployed to accounts %s, select the account in the deployment imports",
+		importer.Name,
+		name,
+		name,
+		strings.Join(accounts, ", "),
+	)
+}
+
+// sameAddress checks all the contracts are deployed to the same account.
+func sameAddress(contracts []*Contract) bool {
+	for _, contract := range contracts {
+		if contract.AccountAddress != contracts[0].AccountAddress {
+			return false
+		}
+	}
+
+	return true
+}
+
+// aliasByName returns the alias of the first imported contract name which has an alias.
+//
+// This allows the same aliased contract to be imported using any path.
+func aliasByName(aliases Aliases, names []string) (string, bool) {
+	for _, name := range names {
+		if alias, exists := aliases[name]; exists {
+			return alias, true
+		}
+	}
+
+	return "", false
+}
+
 func absolutePath(basePath, relativePath string) string {
+	if IsRemote(relativePath) {
+		return relativePath
+	}
+	if IsRemote(basePath) {
+		return resolveRemote(basePath, relativePath)
+	}
+
 	return path.Join(path.Dir(basePath), relativePath)
 }
@@ -106,7 +106,7 @@ func TestResolver(t *testing.T) {
 	`),
 		}
 
-		replacer := NewImportReplacer(contracts, aliases)
+		replacer := NewImportReplacer(contracts, aliases, NewFilesystemLoader(""))
 		for i, script := range scripts {
 			program, err := NewProgram(&testScript{
 				code:     script,
@@ -127,7 +127,7 @@ func TestResolver(t *testing.T) {
 			NewContract("Zoo", "./Zoo.cdc", nil, flow.HexToAddress("0x2"), "", nil),
 		}
 
-		replacer := NewImportReplacer(contracts, nil)
+		replacer := NewImportReplacer(contracts, nil, NewFilesystemLoader(""))
 
 		code := []byte(`
 			import Foo from "./Foo.cdc"
@@ -151,4 +151,147 @@ func TestResolver(t *testing.T) {
 		assert.Equal(t, cleanCode(expected), cleanCode(replaced.Code()))
 	})
 
+	t.Run("Resolve only import declarations", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Bar", "./Bar.cdc", nil, flow.HexToAddress("0x2"), "", nil),
+		}
+
+		replacer := NewImportReplacer(contracts, nil, NewFilesystemLoader(""))
+
+		code := []byte(`
+			import Bar from "./Bar.cdc"
+
+			// import Bar from "./Bar.cdc"
+			pub contract Foo {
+				pub let location: String
+				init() {
+					self.location = "import Bar from \"./Bar.cdc\""
+				}
+			}
+		`)
+		program, err := NewProgram(&testScript{code: code, location: "./Foo.cdc"})
+		require.NoError(t, err)
+
+		replaced, err := replacer.Replace(program)
+		require.NoError(t, err)
+
+		expected := []byte(`
+			import Bar from 0x0000000000000002
+
+			// import Bar from "./Bar.cdc"
+			pub contract Foo {
+				pub let location: String
+				init() {
+					self.location = "import Bar from \"./Bar.cdc\""
+				}
+			}
+		`)
+
+		assert.Equal(t, string(expected), string(replaced.Code()))
+	})
+
+	t.Run("Resolve aliases by name", func(t *testing.T) {
+		aliases := Aliases{
+			"./contracts/standard/FungibleToken.cdc": flow.HexToAddress("0xee82856bf20e2aa6").String(),
+			"FungibleToken":                          flow.HexToAddress("0xee82856bf20e2aa6").String(),
+		}
+
+		replacer := NewImportReplacer(nil, aliases, NewFilesystemLoader(""))
+
+		importers := map[string][]byte{
+			"./contracts/Foo.cdc": []byte(`
+				import FungibleToken from "../../contracts/standard/FungibleToken.cdc"
+				pub contract Foo {}
+			`),
+			"./contracts/nested/Bar.cdc": []byte(`
+				import FungibleToken from "../../../contracts/standard/FungibleToken.cdc"
+				pub contract Bar {}
+			`),
+		}
+
+		for location, code := range importers {
+			program, err := NewProgram(&testScript{code: code, location: location})
+			require.NoError(t, err)
+
+			replaced, err := replacer.Replace(program)
+			require.NoError(t, err)
+			assert.Contains(t, string(replaced.Code()), "import FungibleToken from 0xee82856bf20e2aa6")
+		}
+	})
+
+	t.Run("Resolve normalized locations", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Foo", `contracts\Foo.cdc`, nil, flow.HexToAddress("0x1"), "", nil),
+			NewContract("Bar", "/home/flow/project/contracts/Bar.cdc", nil, flow.HexToAddress("0x2"), "", nil),
+		}
+		aliases := Aliases{
+			`contracts\standard\NFT.cdc`: flow.HexToAddress("0x4").String(),
+		}
+
+		replacer := NewImportReplacer(contracts, aliases, NewFilesystemLoader("/home/flow/project"))
+
+		code := []byte(`
+			import Foo from "./Foo.cdc"
+			import Bar from "./Bar.cdc"
+			import NFT from "./standard/NFT.cdc"
+			pub contract Zoo {}
+		`)
+		program, err := NewProgram(&testScript{code: code, location: "contracts/Zoo.cdc"})
+		require.NoError(t, err)
+
+		replaced, err := replacer.Replace(program)
+		require.NoError(t, err)
+
+		expected := []byte(`
+			import Foo from 0x0000000000000001
+			import Bar from 0x0000000000000002
+			import NFT from 0x0000000000000004
+			pub contract Zoo {}
+		`)
+		assert.Equal(t, cleanCode(expected), cleanCode(replaced.Code()))
+	})
+
+	t.Run("Unresolved import", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Foo", "./contracts/Foo.cdc", nil, flow.HexToAddress("0x1"), "", nil),
+		}
+
+		replacer := NewImportReplacer(contracts, nil, NewFilesystemLoader(""))
+
+		code := []byte(`
+			import Foo from "../contracts/Foo.cdc"
+			import Bar from "../contracts/Bar.cdc"
+			transaction {}
+		`)
+		program, err := NewProgram(&testScript{code: code, location: "./transactions/tx.cdc"})
+		require.NoError(t, err)
+
+		_, err = replacer.Replace(program)
+		assert.EqualError(t, err, "import from ./transactions/tx.cdc could not be found: ../contracts/Bar.cdc (normalized: contracts/Bar.cdc), make sure import path is correct, and the contract is added to deployments or has an alias")
+	})
+
+	t.Run("Imported files", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Foo", "./contracts/Foo.cdc", nil, flow.HexToAddress("0x1"), "", nil),
+			NewContract("Zoo", "./contracts/Zoo.cdc", nil, flow.HexToAddress("0x1"), "", nil),
+		}
+		aliases := map[string]string{
+			"./contracts/NFT.cdc": flow.HexToAddress("0x4").String(),
+		}
+
+		replacer := NewImportReplacer(contracts, aliases, NewFilesystemLoader(""))
+
+		code := []byte(`
+			import Foo from "../contracts/Foo.cdc"
+			import "Zoo"
+			import Bar from "../contracts/Bar.cdc"
+			import NFT from "../contracts/NFT.cdc"
+			import Crypto
+			pub fun main() {}
+		`)
+		program, err := NewProgram(&testScript{code: code, location: "./scripts/main.cdc"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"contracts/Foo.cdc", "contracts/Zoo.cdc", "contracts/Bar.cdc"}, replacer.ImportedFiles(program))
+	})
 }
@@ -0,0 +1,204 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Loader resolves contract locations so the same contract referenced
+// from different places maps to the same canonical location.
+type Loader interface {
+	// Normalize resolves the relative location against the base location and returns the canonical location.
+	Normalize(base, relative string) string
+}
+
+var _ Loader = &FilesystemLoader{}
+
+// FilesystemLoader normalizes contract locations as file system paths.
+//
+// Both forward and backward slashes are accepted as separators so Windows paths
+// resolve to the same location. Relative locations are resolved against the root
+// which allows absolute and relative paths to be matched, if root is empty relative
+// locations are kept relative.
+type FilesystemLoader struct {
+	root string
+}
+
+// NewFilesystemLoader returns a new loader resolving relative locations against the root directory.
+func NewFilesystemLoader(root string) *FilesystemLoader {
+	return &FilesystemLoader{
+		root: toSlash(root),
+	}
+}
+
+func (f *FilesystemLoader) Normalize(base, relative string) string {
+	if IsRemote(relative) {
+		return relative
+	}
+	if IsRemote(base) {
+		return resolveRemote(base, relative)
+	}
+
+	location := toSlash(relative)
+
+	if !isAbsolute(location) {
+		location = path.Join(path.Dir(toSlash(base)), location)
+	}
+	if !isAbsolute(location) && f.root != "" {
+		location = path.Join(f.root, location)
+	}
+
+	return path.Clean(location)
+}
+
+// toSlash converts Windows separators to forward slashes and upper-cases the volume name.
+func toSlash(location string) string {
+	location = strings.ReplaceAll(location, `\`, "/")
+	if hasVolumeName(location) {
+		location = strings.ToUpper(location[:1]) + location[1:]
+	}
+
+	return location
+}
+
+// isAbsolute checks if location is absolute on either Unix or Windows.
+func isAbsolute(location string) bool {
+	return strings.HasPrefix(location, "/") || hasVolumeName(location)
+}
+
+// hasVolumeName checks if location starts with a Windows drive letter, e.g. "C:/".
+func hasVolumeName(location string) bool {
+	if len(location) < 2 || location[1] != ':' {
+		return false
+	}
+
+	letter := location[0]
+	return (letter >= 'a' && letter <= 'z') || (letter >= 'A' && letter <= 'Z')
+}
+
+// IsRemote checks if the location is a remote contract source fetched over HTTP or from a Git repository.
+func IsRemote(location string) bool {
+	return strings.HasPrefix(location, "https://") ||
+		strings.HasPrefix(location, "http://") ||
+		strings.HasPrefix(location, "git+")
+}
+
+// CleanLocation returns the shortest equivalent path of the location, remote locations are not changed.
+func CleanLocation(location string) string {
+	if IsRemote(location) {
+		return location
+	}
+
+	return path.Clean(location)
+}
+
+// resolveRemote resolves the relative location against the remote base location.
+//
+// If the base location can not be parsed the relative location is returned unchanged.
+func resolveRemote(base, relative string) string {
+	if strings.HasPrefix(base, "git+") {
+		git, err := ParseGitLocation(base)
+		if err != nil {
+			return relative
+		}
+
+		git.Path = path.Join(path.Dir(git.Path), relative)
+		return git.String()
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return relative
+	}
+	relativeURL, err := url.Parse(relative)
+	if err != nil {
+		return relative
+	}
+
+	return baseURL.ResolveReference(relativeURL).String()
+}
+
+// GitLocation is a contract source in a Git repository.
+//
+// The location is written as "git+<repository>//<path>?ref=<ref>", for example
+// "git+https://github.com/onflow/flow-nft.git//contracts/NonFungibleToken.cdc?ref=master",
+// if the ref is omitted the default branch is used.
+type GitLocation struct {
+	Repository string
+	Path       string
+	Ref        string
+}
+
+// ParseGitLocation parses the Git location.
+func ParseGitLocation(location string) (GitLocation, error) {
+	if !strings.HasPrefix(location, "git+") {
+		return GitLocation{}, fmt.Errorf("git location %s must start with git+", location)
+	}
+
+	location = strings.TrimPrefix(location, "git+")
+
+	var ref string
+	if i := strings.LastIndex(location, "?ref="); i >= 0 {
+		ref = location[i+len("?ref="):]
+		location = location[:i]
+	}
+
+	// skip the scheme separator when looking for the repository path separator
+	start := strings.Index(location, "://")
+	if start < 0 {
+		start = 0
+	} else {
+		start += len("://")
+	}
+
+	i := strings.Index(location[start:], "//")
+	if i < 0 {
+		return GitLocation{}, fmt.Errorf("git location %s is missing the path of the contract in the repository", location)
+	}
+
+	repository := location[:start+i]
+	// the repository and the ref are passed to git, so they can't be options
+	if strings.HasPrefix(repository, "-") || strings.HasPrefix(ref, "-") {
+		return GitLocation{}, fmt.Errorf("git location %s has an invalid repository or ref", location)
+	}
+
+	contractPath := path.Clean(location[start+i+len("//"):])
+	if contractPath == ".." || strings.HasPrefix(contractPath, "../") {
+		return GitLocation{}, fmt.Errorf("git location %s has a contract path outside of the repository", location)
+	}
+
+	return GitLocation{
+		Repository: repository,
+		Path:       contractPath,
+		Ref:        ref,
+	}, nil
+}
+
+func (g GitLocation) String() string {
+	location := fmt.Sprintf("git+%s//%s", g.Repository, g.Path)
+	if g.Ref != "" {
+		location = fmt.Sprintf("%s?ref=%s", location, g.Ref)
+	}
+
+	return location
+}
@@ -0,0 +1,86 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesystemLoader_Normalize(t *testing.T) {
+	tests := []struct {
+		root     string
+		base     string
+		relative string
+		expected string
+	}{
+		{"", "", "Foo.cdc", "Foo.cdc"},
+		{"", "contracts/Bar.cdc", "./Foo.cdc", "contracts/Foo.cdc"},
+		{"", "contracts/Bar.cdc", "../Foo.cdc", "Foo.cdc"},
+		{"", "contracts/Bar.cdc", ".//utils//Foo.cdc", "contracts/utils/Foo.cdc"},
+		{"", "contracts/Bar.cdc", "/abs/Foo.cdc", "/abs/Foo.cdc"},
+		{"/project", "contracts/Bar.cdc", "./Foo.cdc", "/project/contracts/Foo.cdc"},
+		{"/project", "", "/project/contracts/Foo.cdc", "/project/contracts/Foo.cdc"},
+		{`C:\project`, `contracts\Bar.cdc`, `.\utils\Foo.cdc`, "C:/project/contracts/utils/Foo.cdc"},
+		{`C:\project`, "", `c:\project\contracts\Foo.cdc`, "C:/project/contracts/Foo.cdc"},
+		{"/project", "contracts/Bar.cdc", "https://example.com/contracts/Foo.cdc", "https://example.com/contracts/Foo.cdc"},
+		{"/project", "https://example.com/contracts/Bar.cdc", "./Foo.cdc", "https://example.com/contracts/Foo.cdc"},
+		{"/project", "https://example.com/contracts/Bar.cdc", "../Foo.cdc", "https://example.com/Foo.cdc"},
+		{
+			"/project",
+			"git+https://github.com/onflow/flow-nft.git//contracts/Bar.cdc?ref=master",
+			"./Foo.cdc",
+			"git+https://github.com/onflow/flow-nft.git//contracts/Foo.cdc?ref=master",
+		},
+	}
+
+	for _, test := range tests {
+		loader := NewFilesystemLoader(test.root)
+		assert.Equal(t, test.expected, loader.Normalize(test.base, test.relative), test)
+	}
+}
+
+func TestParseGitLocation(t *testing.T) {
+	location, err := ParseGitLocation("git+https://github.com/onflow/flow-nft.git//contracts/NonFungibleToken.cdc?ref=v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, GitLocation{
+		Repository: "https://github.com/onflow/flow-nft.git",
+		Path:       "contracts/NonFungibleToken.cdc",
+		Ref:        "v1.0.0",
+	}, location)
+	assert.Equal(t, "git+https://github.com/onflow/flow-nft.git//contracts/NonFungibleToken.cdc?ref=v1.0.0", location.String())
+
+	location, err = ParseGitLocation("git+https://github.com/onflow/flow-nft.git//NonFungibleToken.cdc")
+	assert.NoError(t, err)
+	assert.Equal(t, "", location.Ref)
+	assert.Equal(t, "NonFungibleToken.cdc", location.Path)
+
+	_, err = ParseGitLocation("git+https://github.com/onflow/flow-nft.git")
+	assert.EqualError(t, err, "git location https://github.com/onflow/flow-nft.git is missing the path of the contract in the repository")
+
+	_, err = ParseGitLocation("git+--upload-pack=touch /tmp/x//NonFungibleToken.cdc")
+	assert.EqualError(t, err, "git location --upload-pack=touch /tmp/x//NonFungibleToken.cdc has an invalid repository or ref")
+
+	_, err = ParseGitLocation("git+https://github.com/onflow/flow-nft.git//NonFungibleToken.cdc?ref=--upload-pack=x")
+	assert.EqualError(t, err, "git location https://github.com/onflow/flow-nft.git//NonFungibleToken.cdc has an invalid repository or ref")
+
+	_, err = ParseGitLocation("git+https://github.com/onflow/flow-nft.git//../NonFungibleToken.cdc")
+	assert.EqualError(t, err, "git location https://github.com/onflow/flow-nft.git//../NonFungibleToken.cdc has a contract path outside of the repository")
+}
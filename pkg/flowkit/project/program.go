@@ -20,11 +20,13 @@ package project
 
 import (
 	"fmt"
-	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/flow-go-sdk"
 )
 
 type Program struct {
@@ -68,21 +70,307 @@ func (p *Program) imports() []string {
 	return imports
 }
 
+// contractImport is an import of a contract by the contract name, either from an address
+// (e.g. "import X from 0x01") or by an identifier (e.g. "import X").
+type contractImport struct {
+	name        string
+	address     flow.Address
+	fromAddress bool
+	declaration ast.Range
+}
+
+// location returns a unique location of the imported contract.
+func (c contractImport) location() string {
+	if c.fromAddress {
+		return fmt.Sprintf("0x%s.%s", c.address.String(), c.name)
+	}
+	return c.name
+}
+
+// contractImports builds an array of all the imports by address or identifier.
+func (p *Program) contractImports() []contractImport {
+	imports := make([]contractImport, 0)
+
+	for _, importDeclaration := range p.astProgram.ImportDeclarations() {
+		switch location := importDeclaration.Location.(type) {
+		case common.AddressLocation:
+			for _, identifier := range importDeclaration.Identifiers {
+				imports = append(imports, contractImport{
+					name:        identifier.Identifier,
+					address:     flow.Address(location.Address),
+					fromAddress: true,
+					declaration: importDeclaration.Range,
+				})
+			}
+		case common.IdentifierLocation:
+			imports = append(imports, contractImport{
+				name:        string(location),
+				declaration: importDeclaration.Range,
+			})
+		}
+	}
+
+	return imports
+}
+
+// importRange returns the source range of the import declaration for the location.
+func (p *Program) importRange(location string) ast.Range {
+	for _, importDeclaration := range p.astProgram.ImportDeclarations() {
+		if importDeclaration.Location.String() == location {
+			return importDeclaration.Range
+		}
+	}
+
+	return ast.Range{}
+}
+
+// importIdentifiers returns the identifiers imported from the location (e.g. "X" and "Y" in "import X, Y from "Z"").
+func (p *Program) importIdentifiers(location string) []string {
+	identifiers := make([]string, 0)
+	for _, importDeclaration := range p.astProgram.ImportDeclarations() {
+		if importDeclaration.Location.String() != location {
+			continue
+		}
+		for _, identifier := range importDeclaration.Identifiers {
+			identifiers = append(identifiers, identifier.Identifier)
+		}
+	}
+
+	return identifiers
+}
+
+// contractDeclaration is a contract or contract interface declared in the program.
+type contractDeclaration struct {
+	name        string
+	declaration ast.Range
+	element     ast.Element
+}
+
+// contractDeclarations returns all the contract and contract interface declarations in order of appearance.
+func (p *Program) contractDeclarations() []contractDeclaration {
+	declarations := make([]contractDeclaration, 0)
+
+	for _, compositeDeclaration := range p.astProgram.CompositeDeclarations() {
+		if compositeDeclaration.CompositeKind == common.CompositeKindContract {
+			declarations = append(declarations, contractDeclaration{
+				name:        compositeDeclaration.Identifier.Identifier,
+				declaration: compositeDeclaration.Range,
+				element:     compositeDeclaration,
+			})
+		}
+	}
+
+	for _, interfaceDeclaration := range p.astProgram.InterfaceDeclarations() {
+		if interfaceDeclaration.CompositeKind == common.CompositeKindContract {
+			declarations = append(declarations, contractDeclaration{
+				name:        interfaceDeclaration.Identifier.Identifier,
+				declaration: interfaceDeclaration.Range,
+				element:     interfaceDeclaration,
+			})
+		}
+	}
+
+	sort.Slice(declarations, func(i, j int) bool {
+		return declarations[i].declaration.StartPos.Offset < declarations[j].declaration.StartPos.Offset
+	})
+
+	return declarations
+}
+
+// contractCode returns code declaring only the named contract out of the program declaring multiple contracts.
+//
+// All the imports of the program are kept, and the sibling contracts the contract refers to
+// are imported by their name (e.g. "import "Sibling""), so they can be resolved as dependencies.
+func (p *Program) contractCode(name string) ([]byte, error) {
+	code := p.Code()
+	declarations := p.contractDeclarations()
+
+	var contract *contractDeclaration
+	for i, declaration := range declarations {
+		if declaration.name == name {
+			contract = &declarations[i]
+		}
+	}
+	if contract == nil {
+		return nil, fmt.Errorf("contract %s is not declared in %s", name, p.Location())
+	}
+
+	body := sourceRange(code, contract.declaration)
+
+	references := referencedNames(contract.element)
+
+	lines := make([]string, 0)
+	for _, importDeclaration := range p.astProgram.ImportDeclarations() {
+		lines = append(lines, string(sourceRange(code, importDeclaration.Range)))
+	}
+	for _, declaration := range declarations {
+		if declaration.name != name && references[declaration.name] {
+			lines = append(lines, fmt.Sprintf(`import "%s"`, declaration.name))
+		}
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "") // separate imports from the declaration
+	}
+	lines = append(lines, string(body), "")
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// referencedNames returns the names the element refers to, the names of the nominal types
+// and the identifiers of the expressions in the element and its children.
+func referencedNames(element ast.Element) map[string]bool {
+	collector := &nameCollector{names: make(map[string]bool)}
+	ast.Walk(collector, element)
+	return collector.names
+}
+
+// nameCollector is an AST walker collecting the referenced names.
+//
+// The AST walk doesn't visit the types, the function parameters and the function conditions,
+// so they are visited by the collector.
+type nameCollector struct {
+	names map[string]bool
+}
+
+func (c *nameCollector) Walk(element ast.Element) ast.Walker {
+	switch e := element.(type) {
+	case *ast.IdentifierExpression:
+		c.names[e.Identifier.Identifier] = true
+	case *ast.CompositeDeclaration:
+		for _, conformance := range e.Conformances {
+			c.addType(conformance)
+		}
+	case *ast.FieldDeclaration:
+		c.addTypeAnnotation(e.TypeAnnotation)
+	case *ast.FunctionDeclaration:
+		c.addFunction(e.ParameterList, e.ReturnTypeAnnotation, e.FunctionBlock)
+	case *ast.SpecialFunctionDeclaration:
+		c.addFunction(e.FunctionDeclaration.ParameterList, e.FunctionDeclaration.ReturnTypeAnnotation, e.FunctionDeclaration.FunctionBlock)
+	case *ast.FunctionExpression:
+		c.addFunction(e.ParameterList, e.ReturnTypeAnnotation, e.FunctionBlock)
+	case *ast.VariableDeclaration:
+		c.addTypeAnnotation(e.TypeAnnotation)
+	case *ast.CastingExpression:
+		c.addTypeAnnotation(e.TypeAnnotation)
+	case *ast.ReferenceExpression:
+		c.addType(e.Type)
+	case *ast.InvocationExpression:
+		for _, typeArgument := range e.TypeArguments {
+			c.addTypeAnnotation(typeArgument)
+		}
+	}
+
+	return c
+}
+
+// addFunction collects the names referenced by the parameters, the return type and the conditions of a function.
+func (c *nameCollector) addFunction(parameters *ast.ParameterList, returnType *ast.TypeAnnotation, block *ast.FunctionBlock) {
+	if parameters != nil {
+		for _, parameter := range parameters.Parameters {
+			c.addTypeAnnotation(parameter.TypeAnnotation)
+		}
+	}
+	c.addTypeAnnotation(returnType)
+
+	if block == nil {
+		return
+	}
+	for _, conditions := range []*ast.Conditions{block.PreConditions, block.PostConditions} {
+		if conditions == nil {
+			continue
+		}
+		for _, condition := range *conditions {
+			ast.Walk(c, condition.Test)
+			if condition.Message != nil {
+				ast.Walk(c, condition.Message)
+			}
+		}
+	}
+}
+
+func (c *nameCollector) addTypeAnnotation(annotation *ast.TypeAnnotation) {
+	if annotation != nil {
+		c.addType(annotation.Type)
+	}
+}
+
+func (c *nameCollector) addType(t ast.Type) {
+	switch t := t.(type) {
+	case *ast.NominalType:
+		c.names[t.Identifier.Identifier] = true
+	case *ast.OptionalType:
+		c.addType(t.Type)
+	case *ast.VariableSizedType:
+		c.addType(t.Type)
+	case *ast.ConstantSizedType:
+		c.addType(t.Type)
+	case *ast.ReferenceType:
+		c.addType(t.Type)
+	case *ast.DictionaryType:
+		c.addType(t.KeyType)
+		c.addType(t.ValueType)
+	case *ast.FunctionType:
+		c.addTypeAnnotation(t.ReturnTypeAnnotation)
+		for _, parameter := range t.ParameterTypeAnnotations {
+			c.addTypeAnnotation(parameter)
+		}
+	case *ast.RestrictedType:
+		c.addType(t.Type)
+		for _, restriction := range t.Restrictions {
+			c.addType(restriction)
+		}
+	case *ast.InstantiationType:
+		c.addType(t.Type)
+		for _, argument := range t.TypeArguments {
+			c.addTypeAnnotation(argument)
+		}
+	}
+}
+
+// sourceRange returns the source code of the range, end position of the range is inclusive.
+func sourceRange(code []byte, r ast.Range) []byte {
+	return code[r.StartPos.Offset : r.EndPos.Offset+1]
+}
+
 func (p *Program) HasImports() bool {
 	return len(p.imports()) > 0
 }
 
+// replaceImport replaces all import declarations of the provided location with an address import.
+//
+// Import declarations are replaced using their positions in the parsed program, so only
+// actual imports are changed and other occurrences of the location (e.g. in comments or
+// string constants) are left unchanged.
 func (p *Program) replaceImport(from string, to string) *Program {
-	code := string(p.Code())
+	code := p.Code()
+	declarations := p.astProgram.ImportDeclarations()
 
-	pathRegex := regexp.MustCompile(fmt.Sprintf(`import (\w+) from "%s"`, from))
-	identifierRegex := regexp.MustCompile(fmt.Sprintf(`import "(%s)"`, from))
+	// replace from the last declaration so positions of preceding declarations remain valid
+	for i := len(declarations) - 1; i >= 0; i-- {
+		declaration := declarations[i]
+		location, isStringImport := declaration.Location.(common.StringLocation)
+		if !isStringImport || location.String() != from {
+			continue
+		}
+
+		identifiers := make([]string, len(declaration.Identifiers))
+		for j, identifier := range declaration.Identifiers {
+			identifiers[j] = identifier.Identifier
+		}
+		if len(identifiers) == 0 { // identifier import (e.g. import "X") uses location as the name
+			identifiers = append(identifiers, location.String())
+		}
+
+		replacement := fmt.Sprintf("import %s from 0x%s", strings.Join(identifiers, ", "), to)
 
-	replacement := fmt.Sprintf(`import $1 from 0x%s`, to)
-	code = pathRegex.ReplaceAllString(code, replacement)
-	code = identifierRegex.ReplaceAllString(code, replacement)
+		replaced := make([]byte, 0, len(code))
+		replaced = append(replaced, code[:declaration.StartPos.Offset]...)
+		replaced = append(replaced, replacement...)
+		replaced = append(replaced, code[declaration.EndPos.Offset+1:]...)
+		code = replaced
+	}
 
-	p.script.SetCode([]byte(code))
+	p.script.SetCode(code)
 	p.reload()
 	return p
 }
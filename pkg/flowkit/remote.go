@@ -0,0 +1,204 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/project"
+)
+
+// DefaultRemoteCacheDir is the directory where contracts fetched from remote locations are cached.
+const DefaultRemoteCacheDir = ".flow/cache"
+
+// RemoteContracts loads contract code from remote locations over HTTP or from Git repositories.
+//
+// Fetched contracts are cached in the cache directory by the hash of the code, so they
+// can be loaded in offline mode, in which case only the cached contracts are used.
+type RemoteContracts struct {
+	readerWriter ReaderWriter
+	cacheDir     string
+	offline      bool
+}
+
+// NewRemoteContracts returns a new remote contracts loader caching the contracts in the cache directory.
+func NewRemoteContracts(readerWriter ReaderWriter, cacheDir string) *RemoteContracts {
+	return &RemoteContracts{
+		readerWriter: readerWriter,
+		cacheDir:     cacheDir,
+	}
+}
+
+// SetOffline sets the offline mode, in which contracts are only loaded from the cache.
+func (r *RemoteContracts) SetOffline(offline bool) {
+	r.offline = offline
+}
+
+// Load the contract code from the remote location.
+//
+// If the expected hash is provided the fetched code must match the hash, cached code
+// matching the expected hash is used without fetching the contract.
+func (r *RemoteContracts) Load(location string, expectedHash string) ([]byte, error) {
+	if expectedHash != "" {
+		code, err := r.cached(expectedHash)
+		if err == nil {
+			return code, nil
+		}
+	}
+
+	if r.offline {
+		hash := expectedHash
+		if hash == "" {
+			hash = r.index()[location]
+		}
+
+		code, err := r.cached(hash)
+		if hash == "" || err != nil {
+			return nil, fmt.Errorf("contract %s is not cached and can not be fetched in offline mode", location)
+		}
+		return code, nil
+	}
+
+	var code []byte
+	var err error
+	if strings.HasPrefix(location, "git+") {
+		code, err = fetchGit(location)
+	} else {
+		code, err = fetchHTTP(location)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hash := ContractHash(code)
+	if expectedHash != "" && !strings.EqualFold(hash, expectedHash) {
+		return nil, fmt.Errorf("contract %s hash %s does not match the expected hash %s", location, hash, expectedHash)
+	}
+
+	err = r.cache(location, hash, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return code, nil
+}
+
+// cached returns the cached contract code by the hash.
+func (r *RemoteContracts) cached(hash string) ([]byte, error) {
+	return r.readerWriter.ReadFile(path.Join(r.cacheDir, strings.ToLower(hash)+".cdc"))
+}
+
+// index returns the hashes of the cached contracts by the remote location.
+func (r *RemoteContracts) index() map[string]string {
+	index := make(map[string]string)
+
+	data, err := r.readerWriter.ReadFile(path.Join(r.cacheDir, "index.json"))
+	if err != nil {
+		return index
+	}
+	_ = json.Unmarshal(data, &index) // corrupted index is ignored and rewritten
+
+	return index
+}
+
+// cache the contract code by the hash and record the hash of the remote location in the index.
+func (r *RemoteContracts) cache(location string, hash string, code []byte) error {
+	if mkdir, ok := r.readerWriter.(interface {
+		MkdirAll(path string, perm os.FileMode) error
+	}); ok {
+		err := mkdir.MkdirAll(r.cacheDir, 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create contracts cache directory: %w", err)
+		}
+	}
+
+	err := r.readerWriter.WriteFile(path.Join(r.cacheDir, hash+".cdc"), code, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to cache contract %s: %w", location, err)
+	}
+
+	index := r.index()
+	index[location] = hash
+	data, err := json.MarshalIndent(index, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return r.readerWriter.WriteFile(path.Join(r.cacheDir, "index.json"), data, 0644)
+}
+
+// ContractHash returns the SHA3-256 hash of the contract code encoded as hex.
+func ContractHash(code []byte) string {
+	return hex.EncodeToString(crypto.NewSHA3_256().ComputeHash(code))
+}
+
+func fetchHTTP(location string) ([]byte, error) {
+	client := http.Client{Timeout: 30 * time.Second}
+
+	resp, err := client.Get(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contract %s: %w", location, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch contract %s: %s", location, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// fetchGit clones the repository at the ref into a temporary directory and reads the contract.
+func fetchGit(location string) ([]byte, error) {
+	git, err := project.ParseGitLocation(location)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "flow-contract-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	args := []string{"clone", "--depth", "1"}
+	if git.Ref != "" {
+		args = append(args, "--branch", git.Ref)
+	}
+	args = append(args, "--", git.Repository, dir)
+
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone repository %s: %s", git.Repository, strings.TrimSpace(string(out)))
+	}
+
+	return os.ReadFile(filepath.Join(dir, filepath.FromSlash(git.Path)))
+}
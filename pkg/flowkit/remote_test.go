@@ -0,0 +1,94 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteContracts_Load(t *testing.T) {
+	code := []byte(`pub contract NonFungibleToken {}`)
+	hash := ContractHash(code)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/contracts/NonFungibleToken.cdc" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(code)
+	}))
+	defer server.Close()
+
+	location := server.URL + "/contracts/NonFungibleToken.cdc"
+
+	t.Run("Fetch and cache", func(t *testing.T) {
+		rw := afero.Afero{Fs: afero.NewMemMapFs()}
+		remote := NewRemoteContracts(rw, DefaultRemoteCacheDir)
+
+		loaded, err := remote.Load(location, "")
+		require.NoError(t, err)
+		assert.Equal(t, code, loaded)
+
+		cached, err := rw.ReadFile(DefaultRemoteCacheDir + "/" + hash + ".cdc")
+		require.NoError(t, err)
+		assert.Equal(t, code, cached)
+
+		// offline mode only uses the cache
+		remote.SetOffline(true)
+		loaded, err = remote.Load(location, "")
+		require.NoError(t, err)
+		assert.Equal(t, code, loaded)
+
+		_, err = remote.Load(server.URL+"/contracts/Other.cdc", "")
+		assert.EqualError(t, err, "contract "+server.URL+"/contracts/Other.cdc is not cached and can not be fetched in offline mode")
+	})
+
+	t.Run("Expected hash", func(t *testing.T) {
+		rw := afero.Afero{Fs: afero.NewMemMapFs()}
+		remote := NewRemoteContracts(rw, DefaultRemoteCacheDir)
+
+		_, err := remote.Load(location, "abc")
+		assert.EqualError(t, err, "contract "+location+" hash "+hash+" does not match the expected hash abc")
+
+		loaded, err := remote.Load(location, hash)
+		require.NoError(t, err)
+		assert.Equal(t, code, loaded)
+
+		// cached contract with the expected hash is not fetched again
+		fetched := requests
+		_, err = remote.Load(location, hash)
+		require.NoError(t, err)
+		assert.Equal(t, fetched, requests)
+	})
+
+	t.Run("Fetch fails", func(t *testing.T) {
+		remote := NewRemoteContracts(afero.Afero{Fs: afero.NewMemMapFs()}, DefaultRemoteCacheDir)
+
+		_, err := remote.Load(server.URL+"/missing.cdc", "")
+		assert.EqualError(t, err, "failed to fetch contract "+server.URL+"/missing.cdc: 404 Not Found")
+	})
+}
@@ -0,0 +1,161 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// DefaultScriptCacheFile is the file where script results are cached.
+const DefaultScriptCacheFile = DefaultRemoteCacheDir + "/scripts.json"
+
+// ScriptCache caches the results of scripts in a file by the key of the execution.
+//
+// Results are cached with an expiry time, or forever if they can't change, like the results
+// of scripts executed at a block height. Expired results are removed when a result is cached.
+type ScriptCache struct {
+	readerWriter ReaderWriter
+	file         string
+	now          func() time.Time
+}
+
+type scriptCacheEntry struct {
+	Value   json.RawMessage `json:"value"`
+	Expires *time.Time      `json:"expires,omitempty"`
+}
+
+// NewScriptCache returns a new script results cache stored in the file.
+func NewScriptCache(readerWriter ReaderWriter, file string) *ScriptCache {
+	return &ScriptCache{
+		readerWriter: readerWriter,
+		file:         file,
+		now:          time.Now,
+	}
+}
+
+// ScriptCacheKey returns the cache key of the script execution, the hash of the code with the imports
+// resolved, the encoded arguments, the network and the block height, which is zero for the latest block.
+func ScriptCacheKey(code []byte, args []cadence.Value, network string, height uint64) (string, error) {
+	hasher := crypto.NewSHA3_256()
+	_, _ = hasher.Write(code)
+	for _, arg := range args {
+		encoded, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode the script argument: %w", err)
+		}
+		_, _ = hasher.Write(encoded)
+	}
+	_, _ = hasher.Write([]byte(network))
+	_, _ = hasher.Write([]byte(strconv.FormatUint(height, 10)))
+
+	return fmt.Sprintf("%x", hasher.SumHash()), nil
+}
+
+// Get returns the cached result by the key, if it's cached and not expired.
+func (c *ScriptCache) Get(key string) (cadence.Value, bool) {
+	entry, ok := c.entries()[key]
+	if !ok || c.expired(entry) {
+		return nil, false
+	}
+
+	value, err := jsoncdc.Decode(nil, entry.Value)
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// Set caches the result by the key for the time to live, or forever if the time to live is zero.
+func (c *ScriptCache) Set(key string, value cadence.Value, ttl time.Duration) error {
+	encoded, err := jsoncdc.Encode(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode the script result: %w", err)
+	}
+
+	entries := c.entries()
+	for k, entry := range entries {
+		if c.expired(entry) {
+			delete(entries, k)
+		}
+	}
+
+	entry := scriptCacheEntry{Value: encoded}
+	if ttl > 0 {
+		expires := c.now().Add(ttl)
+		entry.Expires = &expires
+	}
+	entries[key] = entry
+
+	return c.write(entries)
+}
+
+// Clear removes all the cached results.
+func (c *ScriptCache) Clear() error {
+	return c.write(make(map[string]scriptCacheEntry))
+}
+
+func (c *ScriptCache) expired(entry scriptCacheEntry) bool {
+	return entry.Expires != nil && !c.now().Before(*entry.Expires)
+}
+
+// entries returns the cached results by the key, a missing or corrupted cache file is treated as empty.
+func (c *ScriptCache) entries() map[string]scriptCacheEntry {
+	entries := make(map[string]scriptCacheEntry)
+
+	data, err := c.readerWriter.ReadFile(c.file)
+	if err != nil {
+		return entries
+	}
+	_ = json.Unmarshal(data, &entries)
+
+	return entries
+}
+
+func (c *ScriptCache) write(entries map[string]scriptCacheEntry) error {
+	if mkdir, ok := c.readerWriter.(interface {
+		MkdirAll(path string, perm os.FileMode) error
+	}); ok {
+		err := mkdir.MkdirAll(path.Dir(c.file), 0755)
+		if err != nil {
+			return fmt.Errorf("failed to create script cache directory: %w", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(entries, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	err = c.readerWriter.WriteFile(c.file, data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write script cache: %w", err)
+	}
+
+	return nil
+}
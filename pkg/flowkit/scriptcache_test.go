@@ -0,0 +1,103 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptCache(t *testing.T) {
+	code := []byte(`pub fun main(a: Int): Int { return a }`)
+	args := []cadence.Value{cadence.NewInt(1)}
+
+	t.Run("Key", func(t *testing.T) {
+		key, err := ScriptCacheKey(code, args, "testnet", 0)
+		require.NoError(t, err)
+
+		same, _ := ScriptCacheKey(code, []cadence.Value{cadence.NewInt(1)}, "testnet", 0)
+		assert.Equal(t, key, same)
+
+		for _, other := range []func() (string, error){
+			func() (string, error) { return ScriptCacheKey(code, []cadence.Value{cadence.NewInt(2)}, "testnet", 0) },
+			func() (string, error) { return ScriptCacheKey(code, args, "mainnet", 0) },
+			func() (string, error) { return ScriptCacheKey(code, args, "testnet", 10) },
+			func() (string, error) { return ScriptCacheKey([]byte(`pub fun main() {}`), args, "testnet", 0) },
+		} {
+			otherKey, err := other()
+			require.NoError(t, err)
+			assert.NotEqual(t, key, otherKey)
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		rw := afero.Afero{Fs: afero.NewMemMapFs()}
+		cache := NewScriptCache(rw, DefaultScriptCacheFile)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		require.NoError(t, cache.Set("latest", cadence.String("a"), 30*time.Second))
+		require.NoError(t, cache.Set("height", cadence.String("b"), 0))
+
+		value, ok := NewScriptCache(rw, DefaultScriptCacheFile).Get("latest")
+		assert.True(t, ok)
+		assert.Equal(t, cadence.String("a"), value)
+
+		now = now.Add(time.Minute)
+		_, ok = cache.Get("latest")
+		assert.False(t, ok)
+
+		value, ok = cache.Get("height")
+		assert.True(t, ok)
+		assert.Equal(t, cadence.String("b"), value)
+
+		// expired results are removed when a result is cached
+		require.NoError(t, cache.Set("other", cadence.String("c"), 0))
+		assert.NotContains(t, cache.entries(), "latest")
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		rw := afero.Afero{Fs: afero.NewMemMapFs()}
+		cache := NewScriptCache(rw, DefaultScriptCacheFile)
+
+		require.NoError(t, cache.Set("height", cadence.String("b"), 0))
+		require.NoError(t, cache.Clear())
+
+		_, ok := cache.Get("height")
+		assert.False(t, ok)
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		rw := afero.Afero{Fs: afero.NewMemMapFs()}
+		require.NoError(t, rw.WriteFile(DefaultScriptCacheFile, []byte("{"), 0644))
+		cache := NewScriptCache(rw, DefaultScriptCacheFile)
+
+		_, ok := cache.Get("height")
+		assert.False(t, ok)
+
+		require.NoError(t, cache.Set("height", cadence.String("b"), 0))
+		_, ok = cache.Get("height")
+		assert.True(t, ok)
+	})
+}
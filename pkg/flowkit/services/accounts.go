@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/onflow/cadence"
 	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
@@ -70,8 +71,81 @@ func (a *Accounts) Get(address flow.Address) (*flow.Account, error) {
 	return account, err
 }
 
+// AccountStorage contains the storage usage of an account and the part of the balance which can be spent,
+// the rest of the balance is reserved for the storage capacity.
+type AccountStorage struct {
+	Used             uint64 // bytes used by the account storage
+	Capacity         uint64 // bytes the account can store with its balance
+	AvailableBalance uint64 // balance not reserved for the storage, in UFix64 units
+}
+
+// accountStorageScript returns the storage usage of the account.
+const accountStorageScript = `
+pub struct AccountStorage {
+	pub let used: UInt64
+	pub let capacity: UInt64
+	pub let availableBalance: UFix64
+
+	init(used: UInt64, capacity: UInt64, availableBalance: UFix64) {
+		self.used = used
+		self.capacity = capacity
+		self.availableBalance = availableBalance
+	}
+}
+
+pub fun main(address: Address): AccountStorage {
+	let account = getAccount(address)
+	return AccountStorage(
+		used: account.storageUsed,
+		capacity: account.storageCapacity,
+		availableBalance: account.availableBalance
+	)
+}
+`
+
+// Storage returns the storage usage and the available balance of the account.
+//
+// The values are not part of the account returned by the access API, so they are read by executing a script.
+func (a *Accounts) Storage(address flow.Address) (*AccountStorage, error) {
+	a.logger.StartProgress(fmt.Sprintf("Loading storage of %s...", address))
+	defer a.logger.StopProgress()
+
+	value, err := a.gateway.ExecuteScript([]byte(accountStorageScript), []cadence.Value{cadence.NewAddress(address)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage of account %s: %w", address, err)
+	}
+
+	storage, ok := value.(cadence.Struct)
+	if !ok || storage.StructType == nil {
+		return nil, fmt.Errorf("failed to parse storage of account %s", address)
+	}
+
+	fields := make(map[string]cadence.Value)
+	for i, field := range storage.StructType.Fields {
+		if i < len(storage.Fields) {
+			fields[field.Identifier] = storage.Fields[i]
+		}
+	}
+
+	used, usedOk := fields["used"].(cadence.UInt64)
+	capacity, capacityOk := fields["capacity"].(cadence.UInt64)
+	availableBalance, availableOk := fields["availableBalance"].(cadence.UFix64)
+	if !usedOk || !capacityOk || !availableOk {
+		return nil, fmt.Errorf("failed to parse storage of account %s", address)
+	}
+
+	return &AccountStorage{
+		Used:             uint64(used),
+		Capacity:         uint64(capacity),
+		AvailableBalance: uint64(availableBalance),
+	}, nil
+}
+
 // StakingInfo returns the staking and delegation information for an account.
-func (a *Accounts) StakingInfo(address flow.Address) ([]map[string]interface{}, []map[string]interface{}, error) {
+//
+// The information is read with the staking collection scripts, which include the stakes and delegations
+// of the locked tokens account, using the staking contracts of the network the address belongs to.
+func (a *Accounts) StakingInfo(address flow.Address) ([]flowkit.NodeInfo, []flowkit.DelegatorInfo, error) {
 	a.logger.StartProgress(fmt.Sprintf("Fetching info for %s...", address.String()))
 	defer a.logger.StopProgress()
 
@@ -103,51 +177,40 @@ func (a *Accounts) StakingInfo(address flow.Address) ([]map[string]interface{},
 		return nil, nil, fmt.Errorf("error getting delegation info: %s", err.Error())
 	}
 
-	// get staking infos and delegation infos
-	stakingInfos, err := flowkit.NewStakingInfoFromValue(stakingValue)
+	nodeInfos, err := flowkit.NewNodeInfosFromValue(stakingValue)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error parsing staking info: %s", err.Error())
 	}
-	delegationInfos, err := flowkit.NewStakingInfoFromValue(delegationValue)
+	delegatorInfos, err := flowkit.NewDelegatorInfosFromValue(delegationValue)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error parsing delegation info: %s", err.Error())
 	}
 
-	// get a set of node ids from all staking infos
-	nodeStakes := make(map[string]cadence.Value)
-	for _, stakingInfo := range stakingInfos {
-		nodeID, ok := stakingInfo["id"]
-		if ok {
-			nodeStakes[nodeIDToString(nodeID)] = nil
-		}
-	}
 	totalCommitmentScript := tmpl.GenerateGetTotalCommitmentBalanceScript(env)
 
-	// foreach node id, get the node total stake
-	for nodeID := range nodeStakes {
-		stake, err := a.gateway.ExecuteScript(totalCommitmentScript, []cadence.Value{cadence.String(nodeID)})
-		if err != nil {
-			return nil, nil, fmt.Errorf("error getting total stake for node: %s", err.Error())
+	// foreach node, get the node total stake including the delegators
+	nodeStakes := make(map[string]cadence.UFix64)
+	for i, node := range nodeInfos {
+		stake, ok := nodeStakes[node.ID]
+		if !ok {
+			value, err := a.gateway.ExecuteScript(totalCommitmentScript, []cadence.Value{cadence.String(node.ID)})
+			if err != nil {
+				return nil, nil, fmt.Errorf("error getting total stake for node: %s", err.Error())
+			}
+
+			stake, ok = value.(cadence.UFix64)
+			if !ok {
+				return nil, nil, fmt.Errorf("error parsing total stake for node %s", node.ID)
+			}
+			nodeStakes[node.ID] = stake
 		}
 
-		nodeStakes[nodeID] = stake
-	}
-
-	// foreach staking info, add the node total stake
-	for _, stakingInfo := range stakingInfos {
-		nodeID, ok := stakingInfo["id"]
-		if ok {
-			stakingInfo["nodeTotalStake"] = nodeStakes[nodeIDToString(nodeID)].(cadence.UFix64)
-		}
+		nodeInfos[i].TotalStake = stake
 	}
 
 	a.logger.StopProgress()
 
-	return stakingInfos, delegationInfos, nil
-}
-
-func nodeIDToString(value interface{}) string {
-	return value.(cadence.String).ToGoValue().(string)
+	return nodeInfos, delegatorInfos, nil
 }
 
 // NodeTotalStake returns the total stake including delegations of a node.
@@ -199,11 +262,19 @@ func (a *Accounts) Create(
 	}
 
 	var accKeys []*flow.AccountKey
+	totalWeight := 0
 	for i, pubKey := range pubKeys {
 		weight := flow.AccountKeyWeightThreshold
 		if len(keyWeights) > i { // if key weight is specified
 			weight = keyWeights[i]
 		}
+		if weight < 0 || weight > flow.AccountKeyWeightThreshold {
+			return nil, fmt.Errorf(
+				"invalid key weight %d, the weight must be between 0 and %d",
+				weight,
+				flow.AccountKeyWeightThreshold,
+			)
+		}
 
 		accKey := &flow.AccountKey{
 			PublicKey: pubKey,
@@ -218,6 +289,16 @@ func (a *Accounts) Create(
 		}
 
 		accKeys = append(accKeys, accKey)
+		totalWeight += weight
+	}
+
+	if totalWeight < flow.AccountKeyWeightThreshold {
+		a.logger.Info(fmt.Sprintf(
+			"%s The total weight of the account keys is %d, transactions can only be signed with keys reaching a total weight of %d",
+			output.WarningEmoji(),
+			totalWeight,
+			flow.AccountKeyWeightThreshold,
+		))
 	}
 
 	contracts := make([]templates.Contract, 0)
@@ -279,6 +360,157 @@ func (a *Accounts) Create(
 	return a.gateway.GetAccount(*newAccountAddress[0]) // we know it's the only and first event
 }
 
+// maxAccountsPerTransaction limits the accounts created by a single transaction to stay within the computation limit.
+const maxAccountsPerTransaction = 10
+
+// CreateBatch creates an account for each of the public keys, with the key as the only full weight key.
+//
+// The accounts are created by transactions creating up to 10 accounts each, signed by the signer.
+// The proposal sequence numbers are assigned to the transactions in order, so they are all sent
+// before waiting for any of them to be sealed.
+//
+// The returned accounts are in the order of the public keys and are nil for the accounts which
+// failed to be created, in which case an error is returned as well.
+func (a *Accounts) CreateBatch(
+	signer *flowkit.Account,
+	pubKeys []crypto.PublicKey,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) ([]*flow.Account, error) {
+	if a.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	block, err := a.gateway.GetLatestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	proposer, err := a.gateway.GetAccount(signer.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	keyIndex := signer.Key().Index()
+	if len(proposer.Keys) <= keyIndex {
+		return nil, fmt.Errorf("failed to retrieve proposer key at index %d", keyIndex)
+	}
+	sequenceNumber := proposer.Keys[keyIndex].SequenceNumber
+
+	a.logger.StartProgress(fmt.Sprintf("Creating %d accounts...", len(pubKeys)))
+	defer a.logger.StopProgress()
+
+	type batch struct {
+		start int
+		keys  []crypto.PublicKey
+		txID  flow.Identifier
+		err   error
+	}
+
+	batches := make([]*batch, 0)
+	for start := 0; start < len(pubKeys); start += maxAccountsPerTransaction {
+		end := start + maxAccountsPerTransaction
+		if end > len(pubKeys) {
+			end = len(pubKeys)
+		}
+		batches = append(batches, &batch{start: start, keys: pubKeys[start:end]})
+	}
+
+	// transactions are sent in the order of their sequence numbers, the transactions following
+	// a transaction which couldn't be sent are not sent as their sequence numbers would be invalid
+	var sendErr error
+	for i, b := range batches {
+		if sendErr != nil {
+			b.err = sendErr
+			continue
+		}
+
+		tx, err := flowkit.NewCreateAccountsTransaction(signer, b.keys, sigAlgo, hashAlgo)
+		if err != nil {
+			return nil, err
+		}
+
+		tx.SetBlockReference(block)
+		proposer.Keys[keyIndex].SequenceNumber = sequenceNumber + uint64(i)
+		if err = tx.SetProposer(proposer, keyIndex); err != nil {
+			return nil, err
+		}
+
+		tx, err = tx.Sign()
+		if err != nil {
+			return nil, err
+		}
+
+		sentTx, err := a.gateway.SendSignedTransaction(tx)
+		if err != nil {
+			sendErr = errors.Wrap(err, "account creation transaction failed")
+			b.err = sendErr
+			continue
+		}
+
+		a.logger.Info(fmt.Sprintf("Transaction ID: %s", sentTx.ID()))
+		b.txID = sentTx.ID()
+	}
+
+	accounts := make([]*flow.Account, len(pubKeys))
+
+	var wg sync.WaitGroup
+	for _, b := range batches {
+		if b.err != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(b *batch) {
+			defer wg.Done()
+
+			result, err := a.gateway.GetTransactionResult(b.txID, true)
+			if err != nil {
+				b.err = err
+				return
+			}
+			if result.Error != nil {
+				b.err = result.Error
+				return
+			}
+
+			events := flowkit.EventsFromTransaction(result)
+			for i, key := range b.keys {
+				address := events.GetAddressForKeyAdded(key)
+				if address == nil {
+					b.err = fmt.Errorf("address of the account with the public key %s couldn't be fetched", key)
+					return
+				}
+
+				account, err := a.gateway.GetAccount(*address)
+				if err != nil {
+					b.err = err
+					return
+				}
+				accounts[b.start+i] = account
+			}
+		}(b)
+	}
+	wg.Wait()
+
+	failed := 0
+	var batchErr error
+	for _, b := range batches {
+		if b.err != nil {
+			failed += len(b.keys)
+			if batchErr == nil {
+				batchErr = b.err
+			}
+		}
+	}
+
+	if batchErr != nil {
+		return accounts, fmt.Errorf("failed to create %d of %d accounts: %w", failed, len(pubKeys), batchErr)
+	}
+
+	return accounts, nil
+}
+
 var errUpdateNoDiff = errors.New("contract already exists and is the same as the contract provided for update")
 
 // AddContract deploys a contract code to the account provided with possible update flag.
@@ -288,32 +520,50 @@ func (a *Accounts) AddContract(
 	network string,
 	updateExisting bool,
 ) (flow.Identifier, bool, error) {
+	txID, _, updated, err := a.addContract(account, contract, network, updateExisting, false)
+	return txID, updated, err
+}
+
+// UpdateContract updates the contract deployed on the account, if force is used the update
+// compatibility check is skipped and the contract is updated even if it is unchanged.
+func (a *Accounts) UpdateContract(
+	account *flowkit.Account,
+	contract *flowkit.Script,
+	network string,
+	force bool,
+) (flow.Identifier, error) {
+	txID, _, _, err := a.addContract(account, contract, network, true, force)
+	return txID, err
+}
+
+// addContract deploys a contract code to the account, if force is used the contract
+// is updated even if the existing contract is the same as the contract provided
+// or the update is not compatible with the deployed contract.
+//
+// The result of the sealed deployment transaction is returned together with the transaction ID.
+func (a *Accounts) addContract(
+	account *flowkit.Account,
+	contract *flowkit.Script,
+	network string,
+	updateExisting bool,
+	force bool,
+) (flow.Identifier, *flow.TransactionResult, bool, error) {
 
 	program, err := project.NewProgram(contract)
 	if err != nil {
-		return flow.EmptyID, false, err
+		return flow.EmptyID, nil, false, err
 	}
 
 	if program.HasImports() {
-		contracts, err := a.state.DeploymentContractsByNetwork(network)
+		program, err = resolveImports(a.state, a.logger, program, network)
 		if err != nil {
-			return flow.EmptyID, false, err
-		}
-
-		importReplacer := project.NewImportReplacer(
-			contracts,
-			a.state.AliasesForNetwork(network),
-		)
-
-		program, err = importReplacer.Replace(program)
-		if err != nil {
-			return flow.EmptyID, false, err
+			return flow.EmptyID, nil, false, err
 		}
 	}
 
 	name, err := program.Name()
 	if err != nil {
-		return flow.EmptyID, false, err
+		return flow.EmptyID, nil, false, err
 	}
 
 	tx, err := flowkit.NewAddAccountContractTransaction(
@@ -323,7 +573,7 @@ func (a *Accounts) AddContract(
 		contract.Args,
 	)
 	if err != nil {
-		return flow.EmptyID, false, err
+		return flow.EmptyID, nil, false, err
 	}
 
 	a.logger.StartProgress(
@@ -339,34 +589,48 @@ func (a *Accounts) AddContract(
 	// check if contract exists on account
 	flowAccount, err := a.gateway.GetAccount(account.Address())
 	if err != nil {
-		return flow.EmptyID, false, err
+		return flow.EmptyID, nil, false, err
 	}
 	existingContract, exists := flowAccount.Contracts[name]
 	noDiffInContract := bytes.Equal(program.Code(), existingContract)
-	if exists && noDiffInContract {
-		return flow.EmptyID, false, errUpdateNoDiff
+	if exists && noDiffInContract && !force {
+		return flow.EmptyID, nil, false, errUpdateNoDiff
 	}
 	if exists && !updateExisting {
-		return flow.EmptyID, false, fmt.Errorf(
+		return flow.EmptyID, nil, false, fmt.Errorf(
 			fmt.Sprintf("contract %s exists in account %s", name, account.Name()),
 		)
 	}
 
 	// if we are updating contract
 	if exists && updateExisting {
+		// check the update is compatible with the deployed contract, unless forced
+		if !force {
+			incompatibilities, err := project.CheckUpdateCompatibility(existingContract, program.Code())
+			if err != nil {
+				return flow.EmptyID, nil, false, err
+			}
+			if len(incompatibilities) > 0 {
+				return flow.EmptyID, nil, false, &project.UpdateIncompatibleError{
+					Contract:          name,
+					Incompatibilities: incompatibilities,
+				}
+			}
+		}
+
 		tx, err = flowkit.NewUpdateAccountContractTransaction(
 			account,
 			name,
 			contract.Code(),
 		)
 		if err != nil {
-			return flow.EmptyID, false, err
+			return flow.EmptyID, nil, false, err
 		}
 	}
 
 	tx, err = a.prepareTransaction(tx, account)
 	if err != nil {
-		return flow.EmptyID, false, err
+		return flow.EmptyID, nil, false, err
 	}
 
 	a.logger.Info(fmt.Sprintf("Transaction ID: %s", tx.FlowTransaction().ID()))
@@ -374,27 +638,27 @@ func (a *Accounts) AddContract(
 	// send transaction with contract
 	sentTx, err := a.gateway.SendSignedTransaction(tx)
 	if err != nil {
-		return flow.EmptyID, false, fmt.Errorf("failed to send transaction to deploy a contract: %w", err)
+		return flow.EmptyID, nil, false, fmt.Errorf("failed to send transaction to deploy a contract: %w", err)
 	}
 
 	// we wait for transaction to be sealed
 	trx, err := a.gateway.GetTransactionResult(sentTx.ID(), true)
 	if err != nil {
-		return flow.EmptyID, false, err
+		return flow.EmptyID, nil, false, err
 	}
 	if trx.Error != nil {
-		return flow.EmptyID, false, trx.Error
+		return flow.EmptyID, nil, false, trx.Error
 	}
 
 	a.logger.StopProgress()
 	a.logger.Info(fmt.Sprintf(
 		"Contract '%s' %s on the account '%s'.",
 		name,
-		map[bool]string{true: "updated", false: "created"}[updateExisting],
+		map[bool]string{true: "updated", false: "created"}[exists],
 		account.Address(),
 	))
 
-	return sentTx.ID(), updateExisting, err
+	return sentTx.ID(), trx, exists, err
 }
 
 // RemoveContract removes a contract from an account and returns the updated account.
@@ -456,6 +720,106 @@ func (a *Accounts) RemoveContract(
 	return sentTx.ID(), nil
 }
 
+// AddKey adds the key to the account with a transaction signed by the account and returns the added key.
+func (a *Accounts) AddKey(account *flowkit.Account, key *flow.AccountKey) (*flow.AccountKey, error) {
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid account key: %w", err)
+	}
+
+	tx, err := flowkit.NewAddAccountKeyTransaction(account, key)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = a.sendKeyTransaction(tx, account, fmt.Sprintf("Adding key to account %s...", account.Address()))
+	if err != nil {
+		return nil, err
+	}
+
+	flowAcc, err := a.gateway.GetAccount(account.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	// the added key is the last key with the public key, as the same public key can be added multiple times
+	for i := len(flowAcc.Keys) - 1; i >= 0; i-- {
+		if flowAcc.Keys[i].PublicKey.Equals(key.PublicKey) {
+			a.logger.Info(fmt.Sprintf(
+				"Key %d added to account %s.",
+				flowAcc.Keys[i].Index,
+				account.Address(),
+			))
+			return flowAcc.Keys[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("added key couldn't be found on account %s", account.Address())
+}
+
+// RevokeKey revokes the key with the index on the account with a transaction signed by the account.
+//
+// The revoked key remains on the account but can no longer be used for signing.
+func (a *Accounts) RevokeKey(account *flowkit.Account, keyIndex int) (flow.Identifier, error) {
+	flowAcc, err := a.gateway.GetAccount(account.Address())
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	i := slices.IndexFunc(flowAcc.Keys, func(key *flow.AccountKey) bool {
+		return key.Index == keyIndex
+	})
+	if i < 0 {
+		return flow.EmptyID, fmt.Errorf("account %s doesn't have a key with index %d", account.Address(), keyIndex)
+	}
+	if flowAcc.Keys[i].Revoked {
+		return flow.EmptyID, fmt.Errorf("key %d of account %s is already revoked", keyIndex, account.Address())
+	}
+
+	tx, err := flowkit.NewRevokeAccountKeyTransaction(account, keyIndex)
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	id, err := a.sendKeyTransaction(tx, account, fmt.Sprintf("Revoking key %d of account %s...", keyIndex, account.Address()))
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	a.logger.Info(fmt.Sprintf("Key %d revoked on account %s.", keyIndex, account.Address()))
+	return id, nil
+}
+
+// sendKeyTransaction sends the transaction modifying the account keys and waits for it to be sealed.
+func (a *Accounts) sendKeyTransaction(
+	tx *flowkit.Transaction,
+	account *flowkit.Account,
+	progress string,
+) (flow.Identifier, error) {
+	tx, err := a.prepareTransaction(tx, account)
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	a.logger.Info(fmt.Sprintf("Transaction ID: %s", tx.FlowTransaction().ID().String()))
+	a.logger.StartProgress(progress)
+	defer a.logger.StopProgress()
+
+	sentTx, err := a.gateway.SendSignedTransaction(tx)
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	txr, err := a.gateway.GetTransactionResult(sentTx.ID(), true)
+	if err != nil {
+		return flow.EmptyID, err
+	}
+	if txr != nil && txr.Error != nil {
+		return flow.EmptyID, txr.Error
+	}
+
+	return sentTx.ID(), nil
+}
+
 // prepareTransaction prepares transaction for sending with data from network
 func (a *Accounts) prepareTransaction(
 	tx *flowkit.Transaction,
@@ -149,6 +149,85 @@ func TestAccounts(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Create Accounts in Batch", func(t *testing.T) {
+		_, s, gw := setup()
+
+		keys := make([]crypto.PublicKey, 0)
+		addresses := make([]flow.Address, 0)
+		for i := 0; i < 12; i++ {
+			key, _ := s.Keys.Generate("", crypto.ECDSA_P256)
+			keys = append(keys, key.PublicKey())
+			addresses = append(addresses, flow.HexToAddress(fmt.Sprintf("%x", i+1)))
+		}
+
+		sequenceNumbers := make([]uint64, 0)
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			assert.Equal(t, serviceAddress, tx.FlowTransaction().Authorizers[0])
+			assert.Equal(t, serviceAddress, tx.FlowTransaction().ProposalKey.Address)
+			sequenceNumbers = append(sequenceNumbers, tx.FlowTransaction().ProposalKey.SequenceNumber)
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		gw.GetTransactionResult.Return(tests.NewAccountsCreateResult(addresses, keys), nil)
+
+		accounts, err := s.Accounts.CreateBatch(serviceAcc, keys, crypto.ECDSA_P256, crypto.SHA3_256)
+
+		require.NoError(t, err)
+		require.Len(t, accounts, 12)
+		for _, account := range accounts {
+			assert.NotNil(t, account)
+		}
+		gw.Mock.AssertNumberOfCalls(t, tests.SendSignedTransactionFunc, 2)
+		gw.Mock.AssertNumberOfCalls(t, tests.GetTransactionResultFunc, 2)
+		gw.Mock.AssertNumberOfCalls(t, tests.GetAccountFunc, 13)
+		require.Len(t, sequenceNumbers, 2)
+		assert.Equal(t, sequenceNumbers[0]+1, sequenceNumbers[1])
+	})
+
+	t.Run("Create Accounts in Batch Partially Failed", func(t *testing.T) {
+		_, s, gw := setup()
+
+		keys := make([]crypto.PublicKey, 0)
+		addresses := make([]flow.Address, 0)
+		for i := 0; i < 12; i++ {
+			key, _ := s.Keys.Generate("", crypto.ECDSA_P256)
+			keys = append(keys, key.PublicKey())
+			addresses = append(addresses, flow.HexToAddress(fmt.Sprintf("%x", i+1)))
+		}
+
+		sent := 0
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			sent++
+			if sent > 1 {
+				gw.SendSignedTransaction.Return(nil, fmt.Errorf("send failed"))
+				return
+			}
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		gw.GetTransactionResult.Return(tests.NewAccountsCreateResult(addresses, keys), nil)
+
+		accounts, err := s.Accounts.CreateBatch(serviceAcc, keys, crypto.ECDSA_P256, crypto.SHA3_256)
+
+		assert.EqualError(t, err, "failed to create 2 of 12 accounts: account creation transaction failed: send failed")
+		require.Len(t, accounts, 12)
+		for i, account := range accounts {
+			if i < 10 {
+				assert.NotNil(t, account)
+			} else {
+				assert.Nil(t, account)
+			}
+		}
+	})
+
+	t.Run("Create Accounts in Batch Invalid Algorithm", func(t *testing.T) {
+		_, s, _ := setup()
+
+		_, err := s.Accounts.CreateBatch(serviceAcc, []crypto.PublicKey{pubKey}, crypto.ECDSA_secp256k1, crypto.SHA3_256)
+		assert.ErrorContains(t, err, "doesn't use the ECDSA_secp256k1 signature algorithm")
+	})
+
 	t.Run("Contract Add for Account", func(t *testing.T) {
 		_, s, gw := setup()
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
@@ -208,6 +287,43 @@ func TestAccounts(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Storage of an Account", func(t *testing.T) {
+		_, s, gw := setup()
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			assert.Contains(t, string(args.Get(0).([]byte)), "account.storageUsed")
+			assert.Equal(t, cadence.NewAddress(serviceAddress), args.Get(1).([]cadence.Value)[0])
+			gw.ExecuteScript.Return(cadence.NewStruct([]cadence.Value{
+				cadence.NewUInt64(1024),
+				cadence.NewUInt64(100_000),
+				cadence.UFix64(99_900_000),
+			}).WithType(&cadence.StructType{
+				QualifiedIdentifier: "AccountStorage",
+				Fields: []cadence.Field{
+					{Identifier: "used", Type: cadence.UInt64Type{}},
+					{Identifier: "capacity", Type: cadence.UInt64Type{}},
+					{Identifier: "availableBalance", Type: cadence.UFix64Type{}},
+				},
+			}), nil)
+		})
+
+		storage, err := s.Accounts.Storage(serviceAddress)
+		assert.NoError(t, err)
+		assert.Equal(t, &AccountStorage{Used: 1024, Capacity: 100_000, AvailableBalance: 99_900_000}, storage)
+		gw.Mock.AssertNumberOfCalls(t, tests.ExecuteScriptFunc, 1)
+	})
+
+	t.Run("Storage of an Account Invalid Result", func(t *testing.T) {
+		_, s, gw := setup()
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			gw.ExecuteScript.Return(cadence.NewUInt64(1), nil)
+		})
+
+		_, err := s.Accounts.Storage(serviceAddress)
+		assert.EqualError(t, err, fmt.Sprintf("failed to parse storage of account %s", serviceAddress))
+	})
+
 	t.Run("Staking Info for Account", func(t *testing.T) {
 		_, s, gw := setup()
 
@@ -230,7 +346,7 @@ func TestAccounts(t *testing.T) {
 		count := 0
 		gw.ExecuteScript.Run(func(args mock.Arguments) {
 			assert.True(t, strings.Contains(string(args.Get(0).([]byte)), "import FlowIDTableStaking from 0x9eca2b38b18b5dfe"))
-			if count < 2 {
+			if count == 0 {
 				gw.ExecuteScript.Return(cadence.NewArray(
 					[]cadence.Value{
 						cadence.Struct{
@@ -246,6 +362,23 @@ func TestAccounts(t *testing.T) {
 							},
 						},
 					}), nil)
+			} else if count == 1 {
+				// the delegator info has a numeric id
+				gw.ExecuteScript.Return(cadence.NewArray(
+					[]cadence.Value{
+						cadence.Struct{
+							StructType: &cadence.StructType{
+								Fields: []cadence.Field{
+									{
+										Identifier: "id",
+									},
+								},
+							},
+							Fields: []cadence.Value{
+								cadence.UInt32(1),
+							},
+						},
+					}), nil)
 			} else {
 				assert.True(t, strings.Contains(args.Get(1).([]cadence.Value)[0].String(), "8f4d09dae7918afbf62c48fa968a9e8b0891cee8442065fa47cc05f4bc9a8a91"))
 				gw.ExecuteScript.Return(cadence.NewUFix64("1.0"))
@@ -259,6 +392,89 @@ func TestAccounts(t *testing.T) {
 		assert.NotNil(t, val2)
 		assert.Equal(t, 3, count)
 	})
+
+	t.Run("Staking Info for Account decodes infos", func(t *testing.T) {
+		_, s, gw := setup()
+
+		nodeID := "8f4d09dae7918afbf62c48fa968a9e8b0891cee8442065fa47cc05f4bc9a8a91"
+		ufix := func(v string) cadence.UFix64 {
+			u, _ := cadence.NewUFix64(v)
+			return u
+		}
+
+		nodeInfo := cadence.NewStruct([]cadence.Value{
+			cadence.String(nodeID),
+			cadence.UInt8(4),
+			ufix("500000.0"),
+			ufix("10.5"),
+			cadence.NewArray([]cadence.Value{cadence.UInt32(1), cadence.UInt32(2)}),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "FlowIDTableStaking.NodeInfo",
+			Fields: []cadence.Field{
+				{Identifier: "id", Type: cadence.StringType{}},
+				{Identifier: "role", Type: cadence.UInt8Type{}},
+				{Identifier: "tokensStaked", Type: cadence.UFix64Type{}},
+				{Identifier: "tokensRewarded", Type: cadence.UFix64Type{}},
+				{Identifier: "delegators", Type: cadence.VariableSizedArrayType{ElementType: cadence.UInt32Type{}}},
+			},
+		})
+
+		delegatorInfo := cadence.NewStruct([]cadence.Value{
+			cadence.UInt32(1),
+			cadence.String(nodeID),
+			ufix("100.0"),
+		}).WithType(&cadence.StructType{
+			QualifiedIdentifier: "FlowIDTableStaking.DelegatorInfo",
+			Fields: []cadence.Field{
+				{Identifier: "id", Type: cadence.UInt32Type{}},
+				{Identifier: "nodeID", Type: cadence.StringType{}},
+				{Identifier: "tokensCommitted", Type: cadence.UFix64Type{}},
+			},
+		})
+
+		count := 0
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			switch count {
+			case 0:
+				gw.ExecuteScript.Return(cadence.NewArray([]cadence.Value{nodeInfo}), nil)
+			case 1:
+				gw.ExecuteScript.Return(cadence.NewArray([]cadence.Value{delegatorInfo}), nil)
+			default:
+				gw.ExecuteScript.Return(ufix("500100.0"), nil)
+			}
+			count++
+		})
+
+		nodes, delegators, err := s.Accounts.StakingInfo(flow.HexToAddress("df9c30eb2252f1fa"))
+		assert.NoError(t, err)
+		assert.Len(t, nodes, 1)
+		assert.Equal(t, nodeID, nodes[0].ID)
+		assert.Equal(t, "verification", nodes[0].RoleName())
+		assert.Equal(t, "500000.00000000", nodes[0].TokensStaked.String())
+		assert.Equal(t, "10.50000000", nodes[0].TokensRewarded.String())
+		assert.Equal(t, []uint32{1, 2}, nodes[0].Delegators)
+		assert.Equal(t, "500100.00000000", nodes[0].TotalStake.String())
+
+		assert.Len(t, delegators, 1)
+		assert.Equal(t, uint32(1), delegators[0].ID)
+		assert.Equal(t, nodeID, delegators[0].NodeID)
+		assert.Equal(t, "100.00000000", delegators[0].TokensCommitted.String())
+	})
+
+	t.Run("Staking Info for Account fails invalid info", func(t *testing.T) {
+		_, s, gw := setup()
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			gw.ExecuteScript.Return(cadence.NewArray([]cadence.Value{
+				cadence.NewStruct([]cadence.Value{cadence.UInt64(1)}).WithType(&cadence.StructType{
+					Fields: []cadence.Field{{Identifier: "id", Type: cadence.UInt64Type{}}},
+				}),
+			}), nil)
+		})
+
+		_, _, err := s.Accounts.StakingInfo(flow.HexToAddress("df9c30eb2252f1fa"))
+		assert.EqualError(t, err, "error parsing staking info: invalid node info: unexpected value 1 of field id")
+	})
 }
 
 func setupIntegration() (*flowkit.State, *Services) {
@@ -303,6 +519,11 @@ func TestAccountsCreate_Integration(t *testing.T) {
 		state, s := setupIntegration()
 		srvAcc, _ := state.EmulatorServiceAccount()
 
+		secpKey, _ := crypto.GeneratePrivateKey(
+			crypto.ECDSA_secp256k1,
+			[]byte("seedseedseedseedseedseedseedseed"),
+		)
+
 		accIn := []accountsIn{{
 			account: srvAcc,
 			sigAlgo: []crypto.SignatureAlgorithm{
@@ -350,6 +571,22 @@ func TestAccountsCreate_Integration(t *testing.T) {
 				tests.PubKeys()[0],
 			},
 			weights: []int{flow.AccountKeyWeightThreshold},
+		}, {
+			account: srvAcc,
+			args:    nil,
+			sigAlgo: []crypto.SignatureAlgorithm{
+				crypto.ECDSA_P256,
+				crypto.ECDSA_secp256k1,
+			},
+			hashAlgo: []crypto.HashAlgorithm{
+				crypto.SHA3_256,
+				crypto.SHA2_256,
+			},
+			pubKeys: []crypto.PublicKey{
+				tests.PubKeys()[0],
+				secpKey.PublicKey(),
+			},
+			weights: []int{300, 700},
 		}}
 
 		accOut := []accountsOut{{
@@ -379,6 +616,15 @@ func TestAccountsCreate_Integration(t *testing.T) {
 				tests.PubKeys()[0],
 			},
 			weights: []int{flow.AccountKeyWeightThreshold},
+		}, {
+			address: "e03daebed8ca0615",
+			code:    map[string][]byte{},
+			balance: uint64(100000),
+			pubKeys: []crypto.PublicKey{
+				tests.PubKeys()[0],
+				secpKey.PublicKey(),
+			},
+			weights: []int{300, 700},
 		}}
 
 		for i, a := range accIn {
@@ -415,6 +661,8 @@ func TestAccountsCreate_Integration(t *testing.T) {
 			"invalid account key: signing algorithm (UNKNOWN) and hashing algorithm (UNKNOWN) are not a valid pair for a Flow account key",
 			"number of keys and weights provided must match, number of provided keys: 2, number of provided key weights: 1",
 			"number of keys and weights provided must match, number of provided keys: 1, number of provided key weights: 2",
+			"invalid key weight -1, the weight must be between 0 and 1000",
+			"invalid key weight 1001, the weight must be between 0 and 1000",
 		}
 
 		accIn := []accountsIn{
@@ -465,17 +713,26 @@ func TestAccountsCreate_Integration(t *testing.T) {
 				},
 				weights: []int{1000, 1000},
 			},
-			/*{
-			 	TODO(sideninja): uncomment this test case after https://github.com/onflow/flow-go-sdk/pull/199 is released
+			{
 				account:  srvAcc,
-				sigAlgo:  crypto.ECDSA_P256,
-				hashAlgo: crypto.SHA3_256,
+				sigAlgo:  []crypto.SignatureAlgorithm{crypto.ECDSA_P256},
+				hashAlgo: []crypto.HashAlgorithm{crypto.SHA3_256},
 				args:     nil,
 				pubKeys: []crypto.PublicKey{
 					tests.PubKeys()[0],
 				},
 				weights: []int{-1},
-			}*/
+			}, {
+				account:  srvAcc,
+				sigAlgo:  []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_P256},
+				hashAlgo: []crypto.HashAlgorithm{crypto.SHA3_256, crypto.SHA3_256},
+				args:     nil,
+				pubKeys: []crypto.PublicKey{
+					tests.PubKeys()[0],
+					tests.PubKeys()[1],
+				},
+				weights: []int{500, 1001},
+			},
 		}
 
 		for i, a := range accIn {
@@ -607,6 +864,65 @@ func TestAccountsRemoveContract_Integration(t *testing.T) {
 	})
 }
 
+func TestAccountsKeys_Integration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Add and Revoke Key", func(t *testing.T) {
+		t.Parallel()
+
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		key, err := s.Accounts.AddKey(srvAcc, &flow.AccountKey{
+			PublicKey: tests.PubKeys()[1],
+			SigAlgo:   tests.SigAlgos()[1],
+			HashAlgo:  tests.HashAlgos()[1],
+			Weight:    500,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, key.Index)
+		assert.Equal(t, 500, key.Weight)
+		assert.Equal(t, tests.PubKeys()[1], key.PublicKey)
+
+		_, err = s.Accounts.RevokeKey(srvAcc, key.Index)
+		require.NoError(t, err)
+
+		acc, err := s.Accounts.Get(srvAcc.Address())
+		require.NoError(t, err)
+		require.Len(t, acc.Keys, 2)
+		assert.False(t, acc.Keys[0].Revoked)
+		assert.True(t, acc.Keys[1].Revoked)
+
+		_, err = s.Accounts.RevokeKey(srvAcc, key.Index)
+		assert.EqualError(t, err, "key 1 of account f8d6e0586b0a20c7 is already revoked")
+	})
+
+	t.Run("Revoke Key Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		_, err := s.Accounts.RevokeKey(srvAcc, 5)
+		assert.EqualError(t, err, "account f8d6e0586b0a20c7 doesn't have a key with index 5")
+	})
+
+	t.Run("Add Key Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		state, s := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		_, err := s.Accounts.AddKey(srvAcc, &flow.AccountKey{
+			PublicKey: tests.PubKeys()[1],
+			SigAlgo:   crypto.ECDSA_P256,
+			HashAlgo:  crypto.SHA3_256,
+			Weight:    1001,
+		})
+		assert.EqualError(t, err, "invalid account key: invalid key weight: 1001")
+	})
+}
+
 func TestAccountsGet_Integration(t *testing.T) {
 	t.Parallel()
 
@@ -622,6 +938,15 @@ func TestAccountsGet_Integration(t *testing.T) {
 		assert.Equal(t, acc.Address, srvAcc.Address())
 	})
 
+	t.Run("Get Account Storage", func(t *testing.T) {
+		t.Parallel()
+
+		storage, err := s.Accounts.Storage(srvAcc.Address())
+		assert.NoError(t, err)
+		assert.Greater(t, storage.Used, uint64(0))
+		assert.Greater(t, storage.Capacity, storage.Used)
+	})
+
 	t.Run("Get Account Invalid", func(t *testing.T) {
 		t.Parallel()
 
@@ -19,8 +19,10 @@
 package services
 
 import (
+	"encoding/hex"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/onflow/flow-go-sdk"
 
@@ -51,10 +53,8 @@ func NewBlocks(
 
 // GetBlock returns a block based on the provided query string.
 //
-// Query string options:
-// - "latest"                : return the latest block
-// - height (e.g. 123456789) : return block at this height
-// - ID                      : return block with this ID
+// Query string options are the block identifiers of ParseBlockIdentifier, like
+// "latest", "final", "latest-10", a block height or a block ID.
 func (e *Blocks) GetBlock(
 	query string,
 	eventType string,
@@ -63,25 +63,9 @@ func (e *Blocks) GetBlock(
 	e.logger.StartProgress("Fetching Block...")
 	defer e.logger.StopProgress()
 
-	// smart parsing of query
-	var err error
-	var block *flow.Block
-	if query == "latest" {
-		block, err = e.gateway.GetLatestBlock()
-	} else if height, ce := strconv.ParseUint(query, 10, 64); ce == nil {
-		block, err = e.gateway.GetBlockByHeight(height)
-	} else if flow.HexToID(query) != flow.EmptyID {
-		block, err = e.gateway.GetBlockByID(flow.HexToID(query))
-	} else {
-		return nil, nil, nil, fmt.Errorf("invalid query: %s, valid are: \"latest\", block height or block ID", query)
-	}
-
+	block, err := e.getBlock(query)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("error fetching block: %s", err.Error())
-	}
-
-	if block == nil {
-		return nil, nil, nil, fmt.Errorf("block not found")
+		return nil, nil, nil, err
 	}
 
 	// if we specify event get events by the type
@@ -110,6 +94,232 @@ func (e *Blocks) GetBlock(
 	return block, events, collections, err
 }
 
+// Heads of the chain the blocks of the identifiers are relative to.
+const (
+	HeadSealed = "sealed"
+	HeadFinal  = "final"
+)
+
+// BlockIdentifier identifies a block by its height, its ID, or its distance from the latest sealed or finalized block.
+type BlockIdentifier struct {
+	Height uint64
+	ID     flow.Identifier
+	// Head is the latest block the block is relative to, HeadSealed or HeadFinal,
+	// or empty if the block is identified by its height or its ID.
+	Head string
+	// Offset is the number of blocks between the block and the head.
+	Offset uint64
+}
+
+// ParseBlockIdentifier parses the identifier of a block.
+//
+// Identifier options:
+// - "latest" or "sealed"            : the latest sealed block
+// - "final"                         : the latest finalized block, which may not be sealed yet
+// - relative (e.g. "latest-10")     : the block 10 blocks before the latest sealed or finalized block
+// - height (e.g. 123456789)         : the block at this height
+// - ID                              : the block with this ID
+func ParseBlockIdentifier(identifier string) (*BlockIdentifier, error) {
+	heads := map[string]string{
+		"latest":   HeadSealed,
+		HeadSealed: HeadSealed,
+		HeadFinal:  HeadFinal,
+	}
+
+	name, offset, relative := strings.Cut(identifier, "-")
+	if head, ok := heads[name]; ok {
+		if !relative {
+			return &BlockIdentifier{Head: head}, nil
+		}
+		if blocks, err := strconv.ParseUint(offset, 10, 64); err == nil {
+			return &BlockIdentifier{Head: head, Offset: blocks}, nil
+		}
+	} else if height, err := strconv.ParseUint(identifier, 10, 64); err == nil {
+		return &BlockIdentifier{Height: height}, nil
+	} else if id, err := hex.DecodeString(identifier); err == nil && len(id) == len(flow.EmptyID) {
+		return &BlockIdentifier{ID: flow.BytesToID(id)}, nil
+	}
+
+	return nil, fmt.Errorf(
+		"invalid block identifier: %s, valid are: \"latest\", \"sealed\", \"final\", a relative height like \"latest-10\", a block height or a block ID",
+		identifier,
+	)
+}
+
+// ResolveHeight returns the height of the block of the identifier, with the same options as ParseBlockIdentifier.
+//
+// The heights relative to the latest blocks are resolved against the network, so they are resolved again on each call.
+func (e *Blocks) ResolveHeight(identifier string) (uint64, error) {
+	id, err := ParseBlockIdentifier(identifier)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case id.Head != "":
+		head, err := e.getHead(id.Head)
+		if err != nil {
+			return 0, err
+		}
+		return relativeHeight(head, id)
+	case id.ID != flow.EmptyID:
+		block, err := e.getBlock(identifier)
+		if err != nil {
+			return 0, err
+		}
+		return block.Height, nil
+	default:
+		return id.Height, nil
+	}
+}
+
+// getHead returns the latest sealed or finalized block.
+func (e *Blocks) getHead(head string) (*flow.Block, error) {
+	if head == HeadFinal {
+		return e.gateway.GetLatestFinalizedBlock()
+	}
+	return e.gateway.GetLatestBlock()
+}
+
+// relativeHeight returns the height of the block of the identifier relative to the head block.
+func relativeHeight(head *flow.Block, id *BlockIdentifier) (uint64, error) {
+	if id.Offset > head.Height {
+		return 0, fmt.Errorf("cannot get the block %d blocks before the latest %s block at height %d", id.Offset, id.Head, head.Height)
+	}
+	return head.Height - id.Offset, nil
+}
+
+// getBlock returns the block of the query, with the same options as ParseBlockIdentifier.
+func (e *Blocks) getBlock(query string) (*flow.Block, error) {
+	id, err := ParseBlockIdentifier(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var block *flow.Block
+	switch {
+	case id.Head != "":
+		block, err = e.getHead(id.Head)
+		if err == nil && id.Offset > 0 {
+			var height uint64
+			height, err = relativeHeight(block, id)
+			if err != nil {
+				return nil, err
+			}
+			block, err = e.gateway.GetBlockByHeight(height)
+		}
+	case id.ID != flow.EmptyID:
+		block, err = e.gateway.GetBlockByID(id.ID)
+	default:
+		block, err = e.gateway.GetBlockByHeight(id.Height)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error fetching block: %s", err.Error())
+	}
+
+	if block == nil {
+		return nil, fmt.Errorf("block not found")
+	}
+
+	return block, nil
+}
+
+// BlockWithCollections is a block with the collections of its collection guarantees.
+type BlockWithCollections struct {
+	*flow.Block
+	Collections []*flow.Collection
+}
+
+// TransactionCount returns the number of the transactions in the collections of the block.
+func (b *BlockWithCollections) TransactionCount() int {
+	count := 0
+	for _, collection := range b.Collections {
+		count += len(collection.TransactionIDs)
+	}
+	return count
+}
+
+// GetBlockRange returns the blocks with the heights in the range with their collections,
+// ordered by the height. The blocks are fetched concurrently by the workers.
+func (e *Blocks) GetBlockRange(startHeight uint64, endHeight uint64, workerCount int) ([]*BlockWithCollections, error) {
+	if endHeight < startHeight {
+		return nil, fmt.Errorf("cannot have end height (%d) of block range less that start height (%d)", endHeight, startHeight)
+	}
+
+	e.logger.StartProgress(fmt.Sprintf("Fetching blocks %d to %d...", startHeight, endHeight))
+	defer e.logger.StopProgress()
+
+	blocks := make([]*BlockWithCollections, endHeight-startHeight+1)
+	err := runWorkers(len(blocks), workerCount, func(i int) error {
+		height := startHeight + uint64(i)
+		block, err := e.gateway.GetBlockByHeight(height)
+		if err != nil {
+			return fmt.Errorf("error fetching block %d: %w", height, err)
+		}
+
+		collections := make([]*flow.Collection, 0, len(block.CollectionGuarantees))
+		for _, guarantee := range block.CollectionGuarantees {
+			collection, err := e.gateway.GetCollection(guarantee.CollectionID)
+			if err != nil {
+				return fmt.Errorf("error fetching collection %s of block %d: %w", guarantee.CollectionID, height, err)
+			}
+			collections = append(collections, collection)
+		}
+
+		blocks[i] = &BlockWithCollections{Block: block, Collections: collections}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// BlockTree is a block with its collections, and the transactions of the collections with their results.
+type BlockTree struct {
+	*flow.Block
+	Collections []*CollectionTree
+}
+
+// GetBlockTree returns the block of the query, with the same options as GetBlock, with its collections
+// and their transactions and results. The collections and the transactions are fetched concurrently by the workers.
+func (e *Blocks) GetBlockTree(query string, workerCount int) (*BlockTree, error) {
+	e.logger.StartProgress("Fetching Block...")
+	defer e.logger.StopProgress()
+
+	block, err := e.getBlock(query)
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]*CollectionTree, len(block.CollectionGuarantees))
+	err = runWorkers(len(collections), workerCount, func(i int) error {
+		id := block.CollectionGuarantees[i].CollectionID
+		collection, err := e.gateway.GetCollection(id)
+		if err != nil {
+			return fmt.Errorf("error fetching collection %s: %w", id, err)
+		}
+
+		collections[i] = &CollectionTree{
+			Collection:   collection,
+			Transactions: make([]*TransactionWithResult, len(collection.TransactionIDs)),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = getCollectionTransactions(e.gateway, collections, workerCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockTree{Block: block, Collections: collections}, nil
+}
+
 // GetLatestBlockHeight returns the latest block height
 func (e *Blocks) GetLatestBlockHeight() (uint64, error) {
 	block, err := e.gateway.GetLatestBlock()
@@ -19,10 +19,14 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/pkg/flowkit/tests"
 )
@@ -87,6 +91,155 @@ func TestBlocks(t *testing.T) {
 		gw.Mock.AssertNotCalled(t, tests.GetLatestBlockFunc)
 	})
 
+	t.Run("Get Block Range", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		gw.GetBlockByHeight.Run(func(args mock.Arguments) {
+			block := tests.NewBlock()
+			block.Height = args.Get(0).(uint64)
+			gw.GetBlockByHeight.Return(block, nil)
+		})
+
+		blocks, err := s.Blocks.GetBlockRange(10, 12, 1)
+
+		require.NoError(t, err)
+		require.Len(t, blocks, 3)
+		for i, block := range blocks {
+			assert.Equal(t, uint64(10+i), block.Height)
+			assert.Len(t, block.Collections, len(block.CollectionGuarantees))
+		}
+		gw.Mock.AssertNumberOfCalls(t, tests.GetBlockByHeightFunc, 3)
+	})
+
+	t.Run("Get Block Range Failed", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		gw.GetBlockByHeight.Return(nil, errors.New("not found"))
+
+		_, err := s.Blocks.GetBlockRange(10, 12, 2)
+		assert.ErrorContains(t, err, "not found")
+
+		_, err = s.Blocks.GetBlockRange(12, 10, 2)
+		assert.EqualError(t, err, "cannot have end height (10) of block range less that start height (12)")
+	})
+	t.Run("Get Block Tree", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		collection := &flow.Collection{TransactionIDs: []flow.Identifier{
+			flow.HexToID("01"),
+			flow.HexToID("02"),
+		}}
+		gw.GetCollection.Return(collection, nil)
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			result := tests.NewTransactionResult([]flow.Event{*tests.NewEvent(0, "A.foo", nil, nil)})
+			result.TransactionID = args.Get(0).(flow.Identifier)
+			gw.GetTransactionResult.Return(result, nil)
+		})
+
+		tree, err := s.Blocks.GetBlockTree("latest", 1)
+
+		require.NoError(t, err)
+		require.Len(t, tree.Collections, len(tree.CollectionGuarantees))
+		for _, c := range tree.Collections {
+			require.Len(t, c.Transactions, 2)
+			for i, tx := range c.Transactions {
+				assert.Equal(t, collection.TransactionIDs[i], tx.Result.TransactionID)
+				assert.Len(t, tx.Result.Events, 1)
+				assert.NotNil(t, tx.Transaction)
+			}
+		}
+		gw.Mock.AssertCalled(t, tests.GetLatestBlockFunc)
+		gw.Mock.AssertNumberOfCalls(t, tests.GetTransactionFunc, 2*len(tree.Collections))
+		gw.Mock.AssertNumberOfCalls(t, tests.GetTransactionResultFunc, 2*len(tree.Collections))
+	})
+
+	t.Run("Get Block Tree Failed", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		gw.GetCollection.Return(&flow.Collection{TransactionIDs: []flow.Identifier{flow.HexToID("01")}}, nil)
+		gw.GetTransaction.Return(nil, errors.New("not found"))
+
+		_, err := s.Blocks.GetBlockTree("latest", 2)
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestBlockIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Parse", func(t *testing.T) {
+		t.Parallel()
+
+		id := "a310685082f0b09f2a148b2e8905f08ea458ed873596b53b200699e8e1f6536f"
+		identifiers := map[string]BlockIdentifier{
+			"latest":    {Head: HeadSealed},
+			"sealed":    {Head: HeadSealed},
+			"final":     {Head: HeadFinal},
+			"latest-10": {Head: HeadSealed, Offset: 10},
+			"final-1":   {Head: HeadFinal, Offset: 1},
+			"1000":      {Height: 1000},
+			"0":         {Height: 0},
+			id:          {ID: flow.HexToID(id)},
+		}
+		for identifier, expected := range identifiers {
+			parsed, err := ParseBlockIdentifier(identifier)
+			require.NoError(t, err, identifier)
+			assert.Equal(t, expected, *parsed, identifier)
+		}
+
+		for _, identifier := range []string{"foo", "latest-", "latest-foo", "final+1", "10-1", ""} {
+			_, err := ParseBlockIdentifier(identifier)
+			assert.ErrorContains(t, err, fmt.Sprintf("invalid block identifier: %s, valid are:", identifier), identifier)
+		}
+	})
+
+	t.Run("Resolve Height", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		sealed := tests.NewBlock()
+		sealed.Height = 100
+		final := tests.NewBlock()
+		final.Height = 102
+		gw.GetLatestBlock.Return(sealed, nil)
+		gw.GetLatestFinalizedBlock.Return(final, nil)
+
+		heights := map[string]uint64{
+			"latest":    100,
+			"latest-10": 90,
+			"final":     102,
+			"final-2":   100,
+			"42":        42,
+		}
+		for identifier, expected := range heights {
+			height, err := s.Blocks.ResolveHeight(identifier)
+			require.NoError(t, err, identifier)
+			assert.Equal(t, expected, height, identifier)
+		}
+
+		_, err := s.Blocks.ResolveHeight("latest-101")
+		assert.EqualError(t, err, "cannot get the block 101 blocks before the latest sealed block at height 100")
+	})
+
+	t.Run("Get Relative Block", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		final := tests.NewBlock()
+		final.Height = 20
+		gw.GetLatestFinalizedBlock.Return(final, nil)
+
+		_, _, _, err := s.Blocks.GetBlock("final-5", "", false)
+
+		assert.NoError(t, err)
+		gw.Mock.AssertCalled(t, tests.GetLatestFinalizedBlockFunc)
+		gw.Mock.AssertCalled(t, tests.GetBlockByHeightFunc, uint64(15))
+		gw.Mock.AssertNotCalled(t, tests.GetLatestBlockFunc)
+	})
 }
 
 func TestBlocksGet_Integration(t *testing.T) {
@@ -124,6 +277,6 @@ func TestBlocksGet_Integration(t *testing.T) {
 		_, s := setupIntegration()
 
 		_, _, _, err := s.Blocks.GetBlock("foo", "flow.AccountCreated", true)
-		assert.Equal(t, err.Error(), "invalid query: foo, valid are: \"latest\", block height or block ID")
+		assert.Equal(t, err.Error(), "invalid block identifier: foo, valid are: \"latest\", \"sealed\", \"final\", a relative height like \"latest-10\", a block height or a block ID")
 	})
 }
@@ -19,6 +19,8 @@
 package services
 
 import (
+	"fmt"
+
 	"github.com/onflow/flow-go-sdk"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
@@ -50,3 +52,78 @@ func NewCollections(
 func (c *Collections) Get(id flow.Identifier) (*flow.Collection, error) {
 	return c.gateway.GetCollection(id)
 }
+
+// TransactionWithResult is a transaction with its result.
+type TransactionWithResult struct {
+	Transaction *flow.Transaction
+	Result      *flow.TransactionResult
+}
+
+// CollectionTree is a collection with its transactions and their results.
+type CollectionTree struct {
+	*flow.Collection
+	Transactions []*TransactionWithResult
+}
+
+// GetByBlock returns the collection with the index in the collection guarantees of the block at the height.
+func (c *Collections) GetByBlock(height uint64, index int) (*flow.Collection, error) {
+	block, err := c.gateway.GetBlockByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching block %d: %w", height, err)
+	}
+
+	if index < 0 || index >= len(block.CollectionGuarantees) {
+		return nil, fmt.Errorf("block %d has %d collections, no collection at index %d", height, len(block.CollectionGuarantees), index)
+	}
+
+	return c.gateway.GetCollection(block.CollectionGuarantees[index].CollectionID)
+}
+
+// GetTree returns the collection with its transactions and their results,
+// the transactions are fetched concurrently by the workers.
+func (c *Collections) GetTree(collection *flow.Collection, workerCount int) (*CollectionTree, error) {
+	c.logger.StartProgress("Fetching Transactions...")
+	defer c.logger.StopProgress()
+
+	tree := &CollectionTree{
+		Collection:   collection,
+		Transactions: make([]*TransactionWithResult, len(collection.TransactionIDs)),
+	}
+
+	err := getCollectionTransactions(c.gateway, []*CollectionTree{tree}, workerCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// getCollectionTransactions fetches the transactions of the collections with their results,
+// the transactions of all the collections are fetched concurrently by the same workers.
+func getCollectionTransactions(gw gateway.Gateway, collections []*CollectionTree, workerCount int) error {
+	type transactionIndex struct{ collection, transaction int }
+	indexes := make([]transactionIndex, 0)
+	for c, collection := range collections {
+		for t := range collection.TransactionIDs {
+			indexes = append(indexes, transactionIndex{c, t})
+		}
+	}
+
+	return runWorkers(len(indexes), workerCount, func(i int) error {
+		index := indexes[i]
+		collection := collections[index.collection]
+		id := collection.TransactionIDs[index.transaction]
+
+		tx, err := gw.GetTransaction(id)
+		if err != nil {
+			return fmt.Errorf("error fetching transaction %s: %w", id, err)
+		}
+		result, err := gw.GetTransactionResult(id, false)
+		if err != nil {
+			return fmt.Errorf("error fetching transaction result %s: %w", id, err)
+		}
+
+		collection.Transactions[index.transaction] = &TransactionWithResult{Transaction: tx, Result: result}
+		return nil
+	})
+}
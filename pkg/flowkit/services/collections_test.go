@@ -19,10 +19,14 @@
 package services
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
 )
 
 func TestCollections(t *testing.T) {
@@ -37,4 +41,38 @@ func TestCollections(t *testing.T) {
 		assert.NoError(t, err)
 		gw.Mock.AssertCalled(t, "GetCollection", ID)
 	})
+
+	t.Run("Get Collection By Block", func(t *testing.T) {
+		_, s, gw := setup()
+		block := tests.NewBlock()
+
+		_, err := s.Collections.GetByBlock(10, 0)
+
+		assert.NoError(t, err)
+		gw.Mock.AssertCalled(t, tests.GetBlockByHeightFunc, uint64(10))
+		gw.Mock.AssertCalled(t, tests.GetCollectionFunc, block.CollectionGuarantees[0].CollectionID)
+
+		_, err = s.Collections.GetByBlock(10, len(block.CollectionGuarantees))
+		assert.EqualError(t, err, fmt.Sprintf(
+			"block 10 has %d collections, no collection at index %d",
+			len(block.CollectionGuarantees),
+			len(block.CollectionGuarantees),
+		))
+	})
+
+	t.Run("Get Collection Tree", func(t *testing.T) {
+		_, s, gw := setup()
+		collection := &flow.Collection{TransactionIDs: []flow.Identifier{flow.HexToID("01"), flow.HexToID("02")}}
+
+		tree, err := s.Collections.GetTree(collection, 2)
+
+		require.NoError(t, err)
+		assert.Len(t, tree.Transactions, 2)
+		for _, tx := range tree.Transactions {
+			assert.NotNil(t, tx.Transaction)
+			assert.NotNil(t, tx.Result)
+		}
+		gw.Mock.AssertCalled(t, tests.GetTransactionFunc, flow.HexToID("02"))
+		gw.Mock.AssertCalled(t, tests.GetTransactionResultFunc, flow.HexToID("02"), false)
+	})
 }
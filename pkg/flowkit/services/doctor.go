@@ -0,0 +1,227 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/project"
+)
+
+// DoctorCheck is the result of checking a part of the configuration against the network.
+type DoctorCheck struct {
+	Name    string
+	Passed  bool
+	Message string
+}
+
+func passed(name string, format string, args ...interface{}) *DoctorCheck {
+	return &DoctorCheck{Name: name, Passed: true, Message: fmt.Sprintf(format, args...)}
+}
+
+func failed(name string, format string, args ...interface{}) *DoctorCheck {
+	return &DoctorCheck{Name: name, Message: fmt.Sprintf(format, args...)}
+}
+
+// Doctor checks the configuration against the state of the network.
+//
+// The network must be reachable, the accounts used on the network must exist with the configured keys,
+// and the contracts must parse and their aliases must point at accounts containing the contracts.
+// The accounts are only checked if their address is valid on the chain of the network, all the
+// accounts are checked on custom networks.
+func (p *Project) Doctor(network string) ([]*DoctorCheck, error) {
+	if p.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	networkConf, err := p.state.Networks().ByName(network)
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger.StartProgress(fmt.Sprintf("Checking configuration against network %s...", network))
+	defer p.logger.StopProgress()
+
+	name := fmt.Sprintf("network %s", network)
+	if err := p.gateway.Ping(); err != nil {
+		// the other checks can't be done without the network
+		return []*DoctorCheck{
+			failed(name, "%s is not reachable, check the network host or start the emulator: %s", networkConf.Host, err),
+		}, nil
+	}
+
+	checks := []*DoctorCheck{passed(name, "%s is reachable", networkConf.Host)}
+
+	chain, knownChain := networkConf.ChainID()
+	for _, account := range *p.state.Accounts() {
+		networkAccount, err := account.ForNetwork(network)
+		if err != nil {
+			continue // account is not defined for the network
+		}
+		address := networkAccount.Address()
+		if knownChain && !address.IsValid(chain) {
+			continue // account is used on other networks
+		}
+
+		checks = append(checks, p.checkAccount(networkAccount))
+	}
+
+	checks = append(checks, p.checkContracts(network)...)
+
+	return checks, nil
+}
+
+// checkAccount checks the account exists and the configured keys match the on-chain keys with enough weight.
+func (p *Project) checkAccount(account *flowkit.Account) *DoctorCheck {
+	name := fmt.Sprintf("account %s", account.Name())
+
+	onChainAccount, err := p.gateway.GetAccount(account.Address())
+	if err != nil {
+		return failed(name, "account %s doesn't exist on the network, check the configured address", account.Address())
+	}
+
+	weight := 0
+	for _, key := range account.Keys() {
+		if err := key.Validate(); err != nil {
+			return failed(name, "key %d is invalid: %s", key.Index(), err)
+		}
+
+		signer, err := key.Signer(context.Background())
+		if err != nil {
+			return failed(name, "key %d can't be used for signing: %s", key.Index(), err)
+		}
+
+		onChainKey := matchingKey(onChainAccount, key.Index(), signer.PublicKey())
+		if onChainKey == nil {
+			return failed(
+				name,
+				"key %d doesn't match any key of account %s, check the private key configured for the account",
+				key.Index(),
+				account.Address(),
+			)
+		}
+		if onChainKey.Index != key.Index() {
+			return failed(
+				name,
+				"key %d matches the on-chain key %d, change the configured key index to %d",
+				key.Index(),
+				onChainKey.Index,
+				onChainKey.Index,
+			)
+		}
+		if onChainKey.Revoked {
+			return failed(name, "key %d is revoked on account %s", key.Index(), account.Address())
+		}
+
+		weight += onChainKey.Weight
+	}
+
+	if weight < flow.AccountKeyWeightThreshold {
+		return failed(
+			name,
+			"the configured keys have a total weight of %d, keys with a total weight of %d are required to sign transactions",
+			weight,
+			flow.AccountKeyWeightThreshold,
+		)
+	}
+
+	return passed(name, "account %s matches the configured keys", account.Address())
+}
+
+// matchingKey returns the on-chain key with the public key, preferring the key at the index.
+func matchingKey(account *flow.Account, index int, publicKey crypto.PublicKey) *flow.AccountKey {
+	var match *flow.AccountKey
+	for _, key := range account.Keys {
+		if !key.PublicKey.Equals(publicKey) {
+			continue
+		}
+		if key.Index == index {
+			return key
+		}
+		if match == nil {
+			match = key
+		}
+	}
+
+	return match
+}
+
+// checkContracts checks the contract files exist and parse, and the aliases of the contracts on the network.
+func (p *Project) checkContracts(network string) []*DoctorCheck {
+	var checks []*DoctorCheck
+	checked := make(map[string]bool)
+
+	for _, contract := range p.state.Contracts().ByNetwork(network) {
+		name := fmt.Sprintf("contract %s", contract.Name)
+
+		if contract.IsAlias() {
+			checks = append(checks, p.checkAlias(name, contract))
+			continue
+		}
+
+		if checked[contract.Name] {
+			continue
+		}
+		checked[contract.Name] = true
+
+		var code []byte
+		var err error
+		if project.IsRemote(contract.Location) {
+			code, err = p.state.RemoteContracts().Load(contract.Location, contract.Hash)
+		} else {
+			code, err = p.state.ReadFile(contract.Location)
+		}
+		if err != nil {
+			checks = append(checks, failed(name, "contract %s can't be read, check the contract location: %s", contract.Location, err))
+			continue
+		}
+
+		if _, err := parser.ParseProgram(nil, code, parser.Config{}); err != nil {
+			checks = append(checks, failed(name, "contract %s doesn't parse, fix the contract code: %s", contract.Location, err))
+			continue
+		}
+
+		checks = append(checks, passed(name, "contract %s parses", contract.Location))
+	}
+
+	return checks
+}
+
+// checkAlias checks the alias points at an account containing the contract.
+func (p *Project) checkAlias(name string, contract config.Contract) *DoctorCheck {
+	address := flow.HexToAddress(contract.Alias)
+
+	account, err := p.gateway.GetAccount(address)
+	if err != nil {
+		return failed(name, "alias %s doesn't exist on the network, check the alias address", contract.Alias)
+	}
+
+	if _, ok := account.Contracts[contract.Name]; !ok {
+		return failed(name, "alias %s doesn't contain the contract %s, check the alias address", contract.Alias, contract.Name)
+	}
+
+	return passed(name, "alias %s contains the contract", contract.Alias)
+}
@@ -0,0 +1,128 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func TestDoctor(t *testing.T) {
+	t.Parallel()
+
+	setupDoctor := func(weight int) (*Services, *tests.TestGateway) {
+		state, s, gw := setup()
+
+		serviceAccount, _ := state.EmulatorServiceAccount()
+		pk, _ := serviceAccount.Key().PrivateKey()
+		alias := flow.HexToAddress("ee82856bf20e2aa6")
+
+		state.Contracts().AddOrUpdate("Hello", config.Contract{
+			Name:     "Hello",
+			Location: tests.ContractHelloString.Filename,
+		})
+		state.Contracts().AddOrUpdate("FungibleToken", config.Contract{
+			Name:     "FungibleToken",
+			Location: "./FungibleToken.cdc",
+			Network:  "emulator",
+			Alias:    alias.String(),
+		})
+
+		gw.Ping.Return(nil)
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			address := args.Get(0).(flow.Address)
+			switch address {
+			case serviceAccount.Address():
+				gw.GetAccount.Return(&flow.Account{
+					Address: address,
+					Keys: []*flow.AccountKey{{
+						Index:     0,
+						PublicKey: (*pk).PublicKey(),
+						Weight:    weight,
+					}},
+				}, nil)
+			case alias:
+				gw.GetAccount.Return(&flow.Account{
+					Address:   address,
+					Contracts: map[string][]byte{"FungibleToken": []byte("pub contract FungibleToken {}")},
+				}, nil)
+			default:
+				gw.GetAccount.Return(nil, fmt.Errorf("account not found"))
+			}
+		})
+
+		return s, gw
+	}
+
+	t.Run("Pass", func(t *testing.T) {
+		t.Parallel()
+		s, _ := setupDoctor(flow.AccountKeyWeightThreshold)
+
+		checks, err := s.Project.Doctor("emulator")
+		require.NoError(t, err)
+		require.Len(t, checks, 4)
+
+		for _, check := range checks {
+			assert.True(t, check.Passed, check.Message)
+		}
+		assert.Equal(t, "network emulator", checks[0].Name)
+		assert.Equal(t, "account emulator-account", checks[1].Name)
+		assert.Equal(t, "contract Hello", checks[2].Name)
+		assert.Equal(t, "contract FungibleToken", checks[3].Name)
+	})
+
+	t.Run("Fail insufficient key weight", func(t *testing.T) {
+		t.Parallel()
+		s, _ := setupDoctor(500)
+
+		checks, err := s.Project.Doctor("emulator")
+		require.NoError(t, err)
+
+		assert.False(t, checks[1].Passed)
+		assert.Equal(t, "the configured keys have a total weight of 500, keys with a total weight of 1000 are required to sign transactions", checks[1].Message)
+	})
+
+	t.Run("Fail unreachable network", func(t *testing.T) {
+		t.Parallel()
+		_, s, gw := setup()
+		gw.Ping.Return(fmt.Errorf("connection refused"))
+
+		checks, err := s.Project.Doctor("emulator")
+		require.NoError(t, err)
+		require.Len(t, checks, 1)
+		assert.False(t, checks[0].Passed)
+		assert.Equal(t, "127.0.0.1:3569 is not reachable, check the network host or start the emulator: connection refused", checks[0].Message)
+	})
+
+	t.Run("Fail missing network", func(t *testing.T) {
+		t.Parallel()
+		_, s, _ := setup()
+
+		_, err := s.Project.Doctor("foo")
+		assert.EqualError(t, err, "network named foo does not exist in configuration")
+	})
+}
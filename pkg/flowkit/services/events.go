@@ -20,8 +20,13 @@ package services
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/access/grpc"
 
@@ -30,11 +35,20 @@ import (
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 )
 
+const (
+	// defaultEventRetries is the number of times a failed query of a chunk of events is retried.
+	defaultEventRetries = 3
+	// defaultEventRetryBackoff is the delay before the first retry of a query, doubled for each following retry.
+	defaultEventRetryBackoff = 500 * time.Millisecond
+)
+
 // Events is a service that handles all event-related interactions.
 type Events struct {
-	gateway gateway.Gateway
-	state   *flowkit.State
-	logger  output.Logger
+	gateway      gateway.Gateway
+	state        *flowkit.State
+	logger       output.Logger
+	retries      int
+	retryBackoff time.Duration
 }
 
 // NewEvents returns a new events service.
@@ -44,9 +58,11 @@ func NewEvents(
 	logger output.Logger,
 ) *Events {
 	return &Events{
-		gateway: gateway,
-		state:   state,
-		logger:  logger,
+		gateway:      gateway,
+		state:        state,
+		logger:       logger,
+		retries:      defaultEventRetries,
+		retryBackoff: defaultEventRetryBackoff,
 	}
 }
 
@@ -71,29 +87,85 @@ func makeEventQueries(events []string, startHeight uint64, endHeight uint64, blo
 
 }
 
+// Get returns the events of the types in the block range, ordered by the block height.
+//
+// The range is fetched in chunks of the block count by the workers, see Stream.
 func (e *Events) Get(events []string, startHeight uint64, endHeight uint64, blockCount uint64, workerCount int) ([]flow.BlockEvents, error) {
+	var resultEvents []flow.BlockEvents
+	err := e.Stream(events, startHeight, endHeight, blockCount, workerCount, func(blockEvents []flow.BlockEvents) error {
+		resultEvents = append(resultEvents, blockEvents...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resultEvents, nil
+}
+
+// Stream fetches the events of the types in the block range and passes them to the handler.
+//
+// The range is split in chunks of the block count which are fetched concurrently by the workers,
+// a chunk failing to be fetched is retried with a backoff. The handler is called with the events
+// of each chunk in the block order, and only a limited number of chunks is fetched ahead of the
+// chunk passed to the handler, so the events of large ranges don't have to be kept in memory.
+func (e *Events) Stream(
+	events []string,
+	startHeight uint64,
+	endHeight uint64,
+	blockCount uint64,
+	workerCount int,
+	handler func([]flow.BlockEvents) error,
+) error {
 	if endHeight < startHeight {
-		return nil, fmt.Errorf("cannot have end height (%d) of block range less that start height (%d)", endHeight, startHeight)
+		return fmt.Errorf("cannot have end height (%d) of block range less that start height (%d)", endHeight, startHeight)
+	}
+	if blockCount == 0 {
+		return fmt.Errorf("the number of blocks fetched by a worker must be greater than zero")
+	}
+	if workerCount < 1 {
+		workerCount = 1
 	}
 
 	e.logger.StartProgress("Fetching events...")
 	defer e.logger.StopProgress()
 
-	queries := makeEventQueries(events, startHeight, endHeight, blockCount)
+	chunks := makeEventChunks(makeEventQueries(events, startHeight, endHeight, blockCount))
 
-	jobChan := make(chan grpc.EventRangeQuery, workerCount)
-	results := make(chan EventWorkerResult)
+	jobs := make(chan eventChunk)
+	results := make(chan eventChunkResult)
+	done := make(chan struct{})
+	defer close(done)
 
-	var wg sync.WaitGroup
+	// chunks are only dispatched within the window ahead of the next chunk passed to the handler,
+	// so the chunks fetched out of order don't accumulate
+	window := make(chan struct{}, 2*workerCount)
 
+	var wg sync.WaitGroup
 	for i := 0; i < workerCount; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			e.eventWorker(jobChan, results)
+			e.eventWorker(jobs, results, done)
 		}()
 	}
 
+	go func() {
+		defer close(jobs)
+		for _, chunk := range chunks {
+			select {
+			case window <- struct{}{}:
+			case <-done:
+				return
+			}
+			select {
+			case jobs <- chunk:
+			case <-done:
+				return
+			}
+		}
+	}()
+
 	// wait on the workers to finish and close the result channel
 	// to signal downstream that all work is done
 	go func() {
@@ -101,38 +173,201 @@ func (e *Events) Get(events []string, startHeight uint64, endHeight uint64, bloc
 		wg.Wait()
 	}()
 
-	go func() {
-		defer close(jobChan)
-		for _, query := range queries {
-			jobChan <- query
+	pending := make(map[int][]flow.BlockEvents)
+	next := 0
+	scanned := uint64(0)
+	found := 0
+	progressed := time.Now()
+	for result := range results {
+		if result.err != nil {
+			return result.err
 		}
-	}()
 
-	var resultEvents []flow.BlockEvents
-	for eventResult := range results {
-		if eventResult.Error != nil {
-			return nil, eventResult.Error
+		pending[result.chunk.index] = result.events
+		for {
+			blockEvents, ok := pending[next]
+			if !ok {
+				break
+			}
+
+			if err := handler(blockEvents); err != nil {
+				return err
+			}
+
+			chunk := chunks[next]
+			scanned += chunk.endHeight - chunk.startHeight + 1
+			for _, block := range blockEvents {
+				found += len(block.Events)
+			}
+			e.logger.Debug(fmt.Sprintf("Fetched events of blocks %d to %d", chunk.startHeight, chunk.endHeight))
+			if time.Since(progressed) >= time.Second {
+				e.logger.StartProgress(fmt.Sprintf(
+					"Fetching events... %d of %d blocks scanned, %d events found",
+					scanned, endHeight-startHeight+1, found,
+				))
+				progressed = time.Now()
+			}
+
+			delete(pending, next)
+			<-window
+			next++
 		}
+	}
 
-		resultEvents = append(resultEvents, eventResult.Events...)
+	e.logger.Debug(fmt.Sprintf("%d blocks scanned, %d events found", scanned, found))
+	return nil
+}
+
+func (e *Events) eventWorker(jobs <-chan eventChunk, results chan<- eventChunkResult, done <-chan struct{}) {
+	for chunk := range jobs {
+		result := eventChunkResult{chunk: chunk}
+		result.events, result.err = e.fetchChunk(chunk)
+
+		select {
+		case results <- result:
+		case <-done:
+			return
+		}
 	}
+}
 
-	return resultEvents, nil
+// fetchChunk fetches the events of all the queries of the chunk, retrying the queries which fail.
+func (e *Events) fetchChunk(chunk eventChunk) ([]flow.BlockEvents, error) {
+	var chunkEvents []flow.BlockEvents
+	for _, query := range chunk.queries {
+		backoff := e.retryBackoff
+		for attempt := 0; ; attempt++ {
+			blockEvents, err := e.gateway.GetEvents(query.Type, query.StartHeight, query.EndHeight)
+			if err == nil {
+				chunkEvents = append(chunkEvents, blockEvents...)
+				break
+			}
+			if attempt >= e.retries {
+				return nil, fmt.Errorf(
+					"failed to fetch events %s of blocks %d to %d: %w",
+					query.Type, query.StartHeight, query.EndHeight, err,
+				)
+			}
 
+			e.logger.Debug(fmt.Sprintf(
+				"Retrying to fetch events %s of blocks %d to %d: %s",
+				query.Type, query.StartHeight, query.EndHeight, err,
+			))
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	return mergeBlockEvents(chunkEvents), nil
 }
 
-func (e *Events) eventWorker(jobChan <-chan grpc.EventRangeQuery, results chan<- EventWorkerResult) {
-	for q := range jobChan {
-		//e.logger.Debug(fmt.Sprintf("Fetching events %v", q))
-		blockEvents, err := e.gateway.GetEvents(q.Type, q.StartHeight, q.EndHeight)
-		if err != nil {
-			results <- EventWorkerResult{nil, err}
+// mergeBlockEvents merges the events of the same block fetched for each type, ordered by the block height,
+// and orders the events of a block by the transaction index and the event index.
+func mergeBlockEvents(blockEvents []flow.BlockEvents) []flow.BlockEvents {
+	merged := make([]flow.BlockEvents, 0, len(blockEvents))
+	indexes := make(map[uint64]int)
+	for _, block := range blockEvents {
+		i, ok := indexes[block.Height]
+		if !ok {
+			indexes[block.Height] = len(merged)
+			block.Events = append([]flow.Event(nil), block.Events...)
+			merged = append(merged, block)
+			continue
+		}
+		merged[i].Events = append(merged[i].Events, block.Events...)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Height < merged[j].Height
+	})
+	for _, block := range merged {
+		events := block.Events
+		sort.SliceStable(events, func(i, j int) bool {
+			if events[i].TransactionIndex != events[j].TransactionIndex {
+				return events[i].TransactionIndex < events[j].TransactionIndex
+			}
+			return events[i].EventIndex < events[j].EventIndex
+		})
+	}
+
+	return merged
+}
+
+// ContractEventTypes returns the types of the events declared by the contract deployed on the network.
+//
+// The contract is identified by its address and name, like A.1654653399040a61.FlowToken,
+// optionally followed by .* like the wildcard of its event types.
+func (e *Events) ContractEventTypes(contract string) ([]string, error) {
+	parts := strings.Split(strings.TrimSuffix(contract, ".*"), ".")
+	if len(parts) != 3 || parts[0] != "A" {
+		return nil, fmt.Errorf("invalid contract %s, the contract must be like A.1654653399040a61.FlowToken.*", contract)
+	}
+	address := flow.HexToAddress(parts[1])
+	name := parts[2]
+
+	account, err := e.gateway.GetAccount(address)
+	if err != nil {
+		return nil, err
+	}
+
+	code, ok := account.Contracts[name]
+	if !ok {
+		return nil, fmt.Errorf("contract %s is not deployed to the account %s", name, address)
+	}
+
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the contract %s: %w", name, err)
+	}
+
+	var types []string
+	for _, declaration := range program.CompositeDeclarations() {
+		if declaration.CompositeKind != common.CompositeKindContract || declaration.Identifier.Identifier != name {
+			continue
+		}
+
+		for _, composite := range declaration.Members.Composites() {
+			if composite.CompositeKind == common.CompositeKindEvent {
+				types = append(types, fmt.Sprintf("A.%s.%s.%s", address, name, composite.Identifier.Identifier))
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		return nil, fmt.Errorf("contract %s doesn't declare any events", contract)
+	}
+
+	return types, nil
+}
+
+// eventChunk is a block range of the queries of all the event types, fetched by a worker.
+type eventChunk struct {
+	index       int
+	startHeight uint64
+	endHeight   uint64
+	queries     []grpc.EventRangeQuery
+}
+
+// makeEventChunks groups the queries of the same block range in chunks.
+func makeEventChunks(queries []grpc.EventRangeQuery) []eventChunk {
+	var chunks []eventChunk
+	for _, query := range queries {
+		last := len(chunks) - 1
+		if last < 0 || chunks[last].startHeight != query.StartHeight {
+			chunks = append(chunks, eventChunk{
+				index:       last + 1,
+				startHeight: query.StartHeight,
+				endHeight:   query.EndHeight,
+			})
+			last++
 		}
-		results <- EventWorkerResult{blockEvents, nil}
+		chunks[last].queries = append(chunks[last].queries, query)
 	}
+	return chunks
 }
 
-type EventWorkerResult struct {
-	Events []flow.BlockEvents
-	Error  error
+type eventChunkResult struct {
+	chunk  eventChunk
+	events []flow.BlockEvents
+	err    error
 }
@@ -21,12 +21,18 @@ package services
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/tests"
 )
 
@@ -70,12 +76,130 @@ func TestEvents(t *testing.T) {
 		_, s, gw := setup()
 
 		gw.GetEvents.Return([]flow.BlockEvents{}, errors.New("failed getting event"))
+		s.Events.retryBackoff = 0
 
 		_, err := s.Events.Get([]string{"flow.CreateAccount"}, 0, 1, 250, 1)
 
-		assert.EqualError(t, err, "failed getting event")
+		assert.EqualError(t, err, "failed to fetch events flow.CreateAccount of blocks 0 to 1: failed getting event")
+		gw.Mock.AssertNumberOfCalls(t, tests.GetEventsFunc, 4)
 	})
 
+	t.Run("Should retry failed chunks", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		s.Events.retryBackoff = 0
+
+		failed := false
+		gw.GetEvents.Run(func(args mock.Arguments) {
+			if !failed {
+				failed = true
+				gw.GetEvents.Return(nil, errors.New("unavailable"))
+				return
+			}
+			gw.GetEvents.Return([]flow.BlockEvents{{Height: args.Get(1).(uint64)}}, nil)
+		})
+
+		events, err := s.Events.Get([]string{"flow.CreateAccount"}, 0, 9, 5, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []flow.BlockEvents{{Height: 0}, {Height: 5}}, events)
+		gw.Mock.AssertNumberOfCalls(t, tests.GetEventsFunc, 3)
+	})
+
+	t.Run("Should stream chunks in block order", func(t *testing.T) {
+		t.Parallel()
+
+		events := NewEvents(slowChunksGateway{}, nil, output.NewStdoutLogger(output.NoneLog))
+
+		var heights []uint64
+		err := events.Stream([]string{"flow.CreateAccount"}, 0, 99, 10, 4, func(blockEvents []flow.BlockEvents) error {
+			for _, e := range blockEvents {
+				heights = append(heights, e.Height)
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Len(t, heights, 20)
+		assert.True(t, sort.SliceIsSorted(heights, func(i, j int) bool { return heights[i] < heights[j] }))
+	})
+
+	t.Run("Should merge events of the types by block", func(t *testing.T) {
+		t.Parallel()
+
+		merged := mergeBlockEvents([]flow.BlockEvents{
+			{Height: 1, Events: []flow.Event{{Type: "A", TransactionIndex: 1, EventIndex: 0}}},
+			{Height: 2, Events: []flow.Event{{Type: "A", TransactionIndex: 0, EventIndex: 1}}},
+			{Height: 1, Events: []flow.Event{{Type: "B", TransactionIndex: 0, EventIndex: 2}, {Type: "B", TransactionIndex: 1, EventIndex: 1}}},
+			{Height: 2, Events: []flow.Event{}},
+		})
+
+		assert.Equal(t, []flow.BlockEvents{
+			{Height: 1, Events: []flow.Event{
+				{Type: "B", TransactionIndex: 0, EventIndex: 2},
+				{Type: "A", TransactionIndex: 1, EventIndex: 0},
+				{Type: "B", TransactionIndex: 1, EventIndex: 1},
+			}},
+			{Height: 2, Events: []flow.Event{{Type: "A", TransactionIndex: 0, EventIndex: 1}}},
+		}, merged)
+	})
+
+	t.Run("Get Contract Event Types", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			account.Contracts = map[string][]byte{
+				"Market": []byte(`
+					pub contract Market {
+						pub event Listed(id: UInt64)
+						pub event Sold(id: UInt64, price: UFix64)
+						pub struct Listing {}
+					}
+				`),
+			}
+			gw.GetAccount.Return(account, nil)
+		})
+
+		types, err := s.Events.ContractEventTypes("A.0000000000000001.Market.*")
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"A.0000000000000001.Market.Listed",
+			"A.0000000000000001.Market.Sold",
+		}, types)
+
+		_, err = s.Events.ContractEventTypes("A.0000000000000001.Auction.*")
+		assert.EqualError(t, err, "contract Auction is not deployed to the account 0000000000000001")
+
+		_, err = s.Events.ContractEventTypes("Market")
+		assert.EqualError(t, err, "invalid contract Market, the contract must be like A.1654653399040a61.FlowToken.*")
+	})
+
+	t.Run("Test create chunks", func(t *testing.T) {
+		t.Parallel()
+
+		chunks := makeEventChunks(makeEventQueries([]string{"first", "second"}, 0, 400, 250))
+
+		require.Len(t, chunks, 2)
+		assert.Equal(t, 1, chunks[1].index)
+		assert.Equal(t, uint64(250), chunks[1].startHeight)
+		assert.Equal(t, uint64(400), chunks[1].endHeight)
+		assert.Len(t, chunks[1].queries, 2)
+	})
+
+}
+
+// slowChunksGateway returns the events of the first and last block of the range,
+// the ranges of lower blocks are the slowest so they are fetched out of order.
+type slowChunksGateway struct {
+	gateway.Gateway
+}
+
+func (slowChunksGateway) GetEvents(_ string, start uint64, end uint64) ([]flow.BlockEvents, error) {
+	time.Sleep(time.Duration(100-start) * time.Millisecond / 10)
+	return []flow.BlockEvents{{Height: start}, {Height: end}}, nil
 }
 
 func TestEvents_Integration(t *testing.T) {
@@ -139,9 +263,10 @@ func TestEvents_Integration(t *testing.T) {
 			eventNames = append(eventNames, eName)
 		}
 
+		// the events of all the types are merged by block
 		events, err := s.Events.Get(eventNames, 0, 1, 250, 5)
 		assert.NoError(t, err)
-		assert.Len(t, events, 20)
-		assert.Len(t, events[1].Events, 1)
+		assert.Len(t, events, 2)
+		assert.Len(t, events[1].Events, 10)
 	})
 }
@@ -0,0 +1,119 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/util"
+)
+
+// Faucet is a service that funds accounts on testnet using the testnet faucet.
+//
+// The testnet faucet doesn't provide an API, the funding is requested in the faucet web page
+// which is protected by a captcha, so the page is opened in the browser and the account
+// balance is polled until the funding is received.
+type Faucet struct {
+	gateway      gateway.Gateway
+	state        *flowkit.State
+	logger       output.Logger
+	openURL      func(url string) error
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+// NewFaucet returns a new faucet service.
+func NewFaucet(
+	gateway gateway.Gateway,
+	state *flowkit.State,
+	logger output.Logger,
+) *Faucet {
+	return &Faucet{
+		gateway:      gateway,
+		state:        state,
+		logger:       logger,
+		openURL:      util.OpenBrowserWindow,
+		pollInterval: 2 * time.Second,
+		timeout:      5 * time.Minute,
+	}
+}
+
+// SetURLOpener sets the function opening the faucet page, by default the page is opened in the browser.
+func (f *Faucet) SetURLOpener(openURL func(url string) error) {
+	f.openURL = openURL
+}
+
+// Fund opens the testnet faucet page funding the account, waits for the balance of the
+// account to increase and returns the funded account.
+//
+// Only testnet is supported, there is no faucet on mainnet.
+func (f *Faucet) Fund(address flow.Address, network string) (*flow.Account, error) {
+	n, err := f.state.Networks().ByName(network)
+	if err != nil {
+		return nil, err
+	}
+
+	switch chain, _ := n.ChainID(); chain {
+	case flow.Testnet:
+	case flow.Mainnet:
+		return nil, fmt.Errorf("funding accounts is not supported on mainnet, FLOW must be transferred from an existing account")
+	default:
+		return nil, fmt.Errorf("funding accounts is only supported on testnet, network %s is not testnet", network)
+	}
+
+	if !address.IsValid(flow.Testnet) {
+		return nil, fmt.Errorf("address %s is not a valid testnet address", address)
+	}
+
+	account, err := f.gateway.GetAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	balance := account.Balance
+
+	link := util.TestnetFaucetFundURL(address)
+	f.logger.Info(fmt.Sprintf("Complete the captcha in the testnet faucet to fund the account: %s", link))
+	if err := f.openURL(link); err != nil {
+		f.logger.Info(err.Error())
+	}
+
+	f.logger.StartProgress(fmt.Sprintf("Waiting for account %s to be funded...", address))
+	defer f.logger.StopProgress()
+
+	deadline := time.Now().Add(f.timeout)
+	for account.Balance <= balance {
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("account %s was not funded within %s, fund it in the testnet faucet: %s", address, f.timeout, link)
+		}
+		time.Sleep(f.pollInterval)
+
+		account, err = f.gateway.GetAccount(address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return account, nil
+}
@@ -0,0 +1,115 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func TestFaucet(t *testing.T) {
+	address := flow.HexToAddress("9a0766d93b6608b7")
+
+	// the balance of the account is increased after the polls
+	fundAfter := func(gw *tests.TestGateway, polls int) *int {
+		requests := 0
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			requests++
+			account := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			account.Balance = 100
+			if requests > polls {
+				account.Balance = 100000000100
+			}
+			gw.GetAccount.Return(account, nil)
+		})
+		return &requests
+	}
+
+	t.Run("Fund Account", func(t *testing.T) {
+		_, s, gw := setup()
+		s.Faucet.pollInterval = time.Millisecond
+		requests := fundAfter(gw, 2)
+
+		var opened string
+		s.Faucet.SetURLOpener(func(url string) error {
+			opened = url
+			return nil
+		})
+
+		account, err := s.Faucet.Fund(address, "testnet")
+		require.NoError(t, err)
+		assert.Equal(t, "https://testnet-faucet.onflow.org/fund-account?address=0x9a0766d93b6608b7", opened)
+		assert.Equal(t, uint64(100000000100), account.Balance)
+		assert.Equal(t, 3, *requests)
+	})
+
+	t.Run("Fund Account Browser Not Opened", func(t *testing.T) {
+		_, s, gw := setup()
+		s.Faucet.pollInterval = time.Millisecond
+		fundAfter(gw, 1)
+
+		s.Faucet.SetURLOpener(func(url string) error {
+			return fmt.Errorf("could not open a browser window")
+		})
+
+		account, err := s.Faucet.Fund(address, "testnet")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100000000100), account.Balance)
+	})
+
+	t.Run("Fail Not Funded", func(t *testing.T) {
+		_, s, gw := setup()
+		s.Faucet.pollInterval = time.Millisecond
+		s.Faucet.timeout = 10 * time.Millisecond
+		fundAfter(gw, 1000)
+		s.Faucet.SetURLOpener(func(url string) error { return nil })
+
+		_, err := s.Faucet.Fund(address, "testnet")
+		assert.EqualError(t, err, "account 9a0766d93b6608b7 was not funded within 10ms, fund it in the testnet faucet: https://testnet-faucet.onflow.org/fund-account?address=0x9a0766d93b6608b7")
+	})
+
+	t.Run("Fail Mainnet", func(t *testing.T) {
+		_, s, _ := setup()
+
+		_, err := s.Faucet.Fund(flow.HexToAddress("e467b9dd11fa00df"), "mainnet")
+		assert.EqualError(t, err, "funding accounts is not supported on mainnet, FLOW must be transferred from an existing account")
+	})
+
+	t.Run("Fail Emulator", func(t *testing.T) {
+		_, s, _ := setup()
+
+		_, err := s.Faucet.Fund(flow.HexToAddress("f8d6e0586b0a20c7"), "emulator")
+		assert.EqualError(t, err, "funding accounts is only supported on testnet, network emulator is not testnet")
+	})
+
+	t.Run("Fail Invalid Address", func(t *testing.T) {
+		_, s, _ := setup()
+
+		_, err := s.Faucet.Fund(flow.HexToAddress("f8d6e0586b0a20c7"), "testnet")
+		assert.EqualError(t, err, "address f8d6e0586b0a20c7 is not a valid testnet address")
+	})
+}
@@ -21,8 +21,11 @@ package services
 import (
 	"encoding/hex"
 	"fmt"
+	"runtime"
+	"strings"
 
 	goeth "github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/rlp"
 	slip10 "github.com/lmars/go-slip10"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
@@ -54,8 +57,17 @@ func NewKeys(
 	}
 }
 
+// DefaultDerivationPath is the BIP44 path of the first Flow key, used by Flow wallets and the Ledger app.
+const DefaultDerivationPath = "m/44'/539'/0'/0/0"
+
+// DerivationPath returns the BIP44 path of the Flow key with the index in the first account.
+func DerivationPath(index uint32) string {
+	return fmt.Sprintf("m/44'/539'/0'/0/%d", index)
+}
+
+// GetMnemonic generates a new 24 word BIP39 mnemonic.
 func (k *Keys) GetMnemonic() (string, error) {
-	entropy, err := bip39.NewEntropy(128)
+	entropy, err := bip39.NewEntropy(256)
 	if err != nil {
 		return "", err
 	}
@@ -66,6 +78,8 @@ func (k *Keys) GetMnemonic() (string, error) {
 	return mnemonic, nil
 }
 
+// DerivePrivateKeyFromMnemonic derives the private key on the derivation path from the BIP39 mnemonic using SLIP-0010,
+// the default derivation path is used if the path is empty.
 func (k *Keys) DerivePrivateKeyFromMnemonic(mnemonic string, sigAlgo crypto.SignatureAlgorithm, derivationPath string) (crypto.PrivateKey, error) {
 
 	if !bip39.IsMnemonicValid(mnemonic) {
@@ -84,7 +98,7 @@ func (k *Keys) derivePrivateKeyFromSeed(seed []byte, sigAlgo crypto.SignatureAlg
 	}
 
 	if derivationPath == "" {
-		derivationPath = "m/44'/539'/0'/0/0"
+		derivationPath = DefaultDerivationPath
 	}
 
 	path, err := goeth.ParseDerivationPath(derivationPath)
@@ -118,8 +132,23 @@ func (k *Keys) derivePrivateKeyFromSeed(seed []byte, sigAlgo crypto.SignatureAlg
 	return privateKey, nil
 }
 
-// Parses private key
+// privateKeyLength is the length in bytes of the private keys on the supported curves.
+const privateKeyLength = 32
+
+// ParsePrivateKey parses the hex encoded private key, optionally prefixed with 0x.
 func (k *Keys) ParsePrivateKey(inputPrivateKey string, sigAlgo crypto.SignatureAlgorithm) (crypto.PrivateKey, error) {
+	inputPrivateKey = strings.TrimPrefix(strings.TrimSpace(inputPrivateKey), "0x")
+	if len(inputPrivateKey)%2 != 0 {
+		return nil, fmt.Errorf("failed to decode private key: hex string has an odd length of %d characters", len(inputPrivateKey))
+	}
+	if len(inputPrivateKey) != privateKeyLength*2 {
+		return nil, fmt.Errorf(
+			"failed to decode private key: expected %d hex characters, got %d",
+			privateKeyLength*2,
+			len(inputPrivateKey),
+		)
+	}
+
 	privateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, inputPrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode private key: %w", err)
@@ -149,19 +178,175 @@ func (k *Keys) Generate(inputSeed string, sigAlgo crypto.SignatureAlgorithm) (cr
 	return privateKey, nil
 }
 
-// DecodeRLP decodes an RLP encoded public key
+// GeneratedKey is a private key generated in a batch with its index.
+type GeneratedKey struct {
+	Index      int
+	PrivateKey crypto.PrivateKey
+}
+
+// BatchSeed returns the seed of the key with the index in a batch generated from the seed.
+func BatchSeed(seed string, index int) string {
+	return fmt.Sprintf("%s-%d", seed, index)
+}
+
+// GenerateBatch generates the number of keys concurrently and passes them to the write function in the order of the indexes.
+//
+// Only the keys being generated are kept in memory, so large batches can be streamed to a file.
+// If the seed is provided, each key is generated from the seed and its index, so the same batch is generated every time.
+func (k *Keys) GenerateBatch(
+	count int,
+	seed string,
+	sigAlgo crypto.SignatureAlgorithm,
+	write func(key GeneratedKey) error,
+) error {
+	type generated struct {
+		key GeneratedKey
+		err error
+	}
+
+	workers := runtime.NumCPU()
+	done := make(chan struct{})
+	defer close(done)
+
+	// the channels of the keys in generation are queued in order of the indexes,
+	// which also limits the keys generated ahead of the writer to the number of workers
+	pending := make(chan chan generated, workers)
+	go func() {
+		defer close(pending)
+		for i := 0; i < count; i++ {
+			result := make(chan generated, 1)
+			select {
+			case pending <- result:
+			case <-done:
+				return
+			}
+
+			go func(index int) {
+				keySeed := ""
+				if seed != "" {
+					keySeed = BatchSeed(seed, index)
+				}
+
+				privateKey, err := k.Generate(keySeed, sigAlgo)
+				result <- generated{key: GeneratedKey{Index: index, PrivateKey: privateKey}, err: err}
+			}(i)
+		}
+	}()
+
+	k.logger.StartProgress(fmt.Sprintf("Generating %d keys...", count))
+	defer k.logger.StopProgress()
+
+	for result := range pending {
+		generated := <-result
+		if generated.err != nil {
+			return fmt.Errorf("failed to generate key %d: %w", generated.key.Index, generated.err)
+		}
+
+		err := write(generated.key)
+		if err != nil {
+			return err
+		}
+
+		written := generated.key.Index + 1
+		if written%100 == 0 && written < count {
+			k.logger.StartProgress(fmt.Sprintf("Generating %d keys, %d generated...", count, written))
+		}
+	}
+
+	return nil
+}
+
+// DecodeRLP decodes an RLP encoded account public key, the encoding used by the account creation.
+//
+// The errors identify the field of the encoding that couldn't be decoded.
 func (k *Keys) DecodeRLP(publicKey string) (*flow.AccountKey, error) {
-	publicKeyBytes, err := hex.DecodeString(publicKey)
+	publicKeyBytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(publicKey), "0x"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode public key: %w", err)
 	}
 
-	accountKey, err := flow.DecodeAccountKey(publicKeyBytes)
+	content, rest, err := rlp.SplitList(publicKeyBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode: %w", err)
+		return nil, fmt.Errorf("failed to decode: the account key must be an RLP list: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("failed to decode: %d unexpected bytes after the account key", len(rest))
+	}
+
+	var encodedPublicKey []byte
+	var sigAlgo, hashAlgo, weight uint
+	fields := []struct {
+		name  string
+		value interface{}
+	}{
+		{"public key", &encodedPublicKey},
+		{"signature algorithm", &sigAlgo},
+		{"hash algorithm", &hashAlgo},
+		{"weight", &weight},
+	}
+
+	for _, field := range fields {
+		if len(content) == 0 {
+			return nil, fmt.Errorf("failed to decode: missing %s field", field.name)
+		}
+
+		_, _, fieldRest, err := rlp.Split(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s field: %w", field.name, err)
+		}
+
+		err = rlp.DecodeBytes(content[:len(content)-len(fieldRest)], field.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s field: %w", field.name, err)
+		}
+		content = fieldRest
+	}
+
+	if len(content) > 0 {
+		return nil, fmt.Errorf("failed to decode: unexpected fields after the weight field")
 	}
 
-	return accountKey, nil
+	signatureAlgorithm := crypto.SignatureAlgorithm(sigAlgo)
+	if signatureAlgorithm != crypto.ECDSA_P256 && signatureAlgorithm != crypto.ECDSA_secp256k1 {
+		return nil, fmt.Errorf("failed to decode signature algorithm field: unsupported value %d", sigAlgo)
+	}
+
+	hashAlgorithm := crypto.HashAlgorithm(hashAlgo)
+	if hashAlgorithm != crypto.SHA2_256 && hashAlgorithm != crypto.SHA3_256 {
+		return nil, fmt.Errorf("failed to decode hash algorithm field: unsupported value %d", hashAlgo)
+	}
+
+	if weight > uint(flow.AccountKeyWeightThreshold) {
+		return nil, fmt.Errorf("failed to decode weight field: %d is above the maximum of %d", weight, flow.AccountKeyWeightThreshold)
+	}
+
+	decodedPublicKey, err := crypto.DecodePublicKey(signatureAlgorithm, encodedPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key field: %w", err)
+	}
+
+	return &flow.AccountKey{
+		PublicKey: decodedPublicKey,
+		SigAlgo:   signatureAlgorithm,
+		HashAlgo:  hashAlgorithm,
+		Weight:    int(weight),
+	}, nil
+}
+
+// GetAccountKey fetches the key with the index of the account from the network.
+func (k *Keys) GetAccountKey(address flow.Address, index int) (*flow.AccountKey, error) {
+	account, err := k.gateway.GetAccount(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account with address %s: %w", address, err)
+	}
+
+	for _, key := range account.Keys {
+		if key.Index == index {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("account 0x%s has no key with index %d", address, index)
 }
 
 // DecodePEM decodes a PEM encoded public key with specified signature algorithm.
@@ -177,3 +362,70 @@ func (k *Keys) DecodePEM(key string, sigAlgo crypto.SignatureAlgorithm) (*flow.A
 		Weight:    -1,
 	}, nil
 }
+
+// EncodePrivateKey encodes the private key to PEM or DER in the format, and validates the encoded key
+// decodes to the same key.
+func (k *Keys) EncodePrivateKey(
+	privateKey crypto.PrivateKey,
+	encoding flowkit.KeyEncoding,
+	format flowkit.PrivateKeyFormat,
+) ([]byte, error) {
+	var encoded []byte
+	var err error
+	switch encoding {
+	case flowkit.EncodingPEM:
+		encoded, err = flowkit.EncodePrivateKeyPEM(privateKey, format)
+	case flowkit.EncodingDER:
+		encoded, err = flowkit.MarshalPrivateKey(privateKey, format)
+	default:
+		return nil, fmt.Errorf("encoding %s not supported, valid encodings are pem and der", encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := k.DecodeKey(encoded, encoding)
+	if err != nil || decoded == nil || !decoded.Equals(privateKey) {
+		return nil, fmt.Errorf("failed to encode private key: the encoded key doesn't decode to the same key")
+	}
+
+	return encoded, nil
+}
+
+// EncodePublicKey encodes the public key to PEM or DER, and validates the encoded key decodes to the same key.
+func (k *Keys) EncodePublicKey(publicKey crypto.PublicKey, encoding flowkit.KeyEncoding) ([]byte, error) {
+	var encoded []byte
+	var err error
+	switch encoding {
+	case flowkit.EncodingPEM:
+		encoded, err = flowkit.EncodePublicKeyPEM(publicKey)
+	case flowkit.EncodingDER:
+		encoded, err = flowkit.MarshalPublicKey(publicKey)
+	default:
+		return nil, fmt.Errorf("encoding %s not supported, valid encodings are pem and der", encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, decoded, err := k.DecodeKey(encoded, encoding)
+	if err != nil || !decoded.Equals(publicKey) {
+		return nil, fmt.Errorf("failed to encode public key: the encoded key doesn't decode to the same key")
+	}
+
+	return encoded, nil
+}
+
+// DecodeKey decodes a private or public key from PEM or DER, the curve of the key is read from the encoding.
+//
+// The private key is nil if the encoded key is a public key.
+func (k *Keys) DecodeKey(encoded []byte, encoding flowkit.KeyEncoding) (crypto.PrivateKey, crypto.PublicKey, error) {
+	switch encoding {
+	case flowkit.EncodingPEM:
+		return flowkit.DecodeKeyPEM(encoded)
+	case flowkit.EncodingDER:
+		return flowkit.DecodeKeyDER(encoded)
+	default:
+		return nil, nil, fmt.Errorf("encoding %s not supported, valid encodings are pem and der", encoding)
+	}
+}
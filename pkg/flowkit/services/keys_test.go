@@ -20,10 +20,17 @@ package services
 
 import (
 	"encoding/hex"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
 )
 
 func TestKeys(t *testing.T) {
@@ -125,6 +132,72 @@ func TestKeys(t *testing.T) {
 		assert.Equal(t, hex.EncodeToString(key.PublicKey().Encode()), "d7482bbaff7827035d5b238df318b10604673dc613808723efbd23fbc4b9fad34a415828d924ec7b83ac0eddf22ef115b7c203ee39fb080572d7e51775ee54be")
 	})
 
+	t.Run("Generate Mnemonic", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		mnemonic, err := s.Keys.GetMnemonic()
+
+		assert.NoError(t, err)
+		assert.Len(t, strings.Fields(mnemonic), 24)
+
+		_, err = s.Keys.DerivePrivateKeyFromMnemonic(mnemonic, crypto.ECDSA_P256, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Derive Keys with mnemonic (key index)", func(t *testing.T) {
+		t.Parallel()
+		_, s, _ := setup()
+
+		mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+
+		tests := []struct {
+			sigAlgo    crypto.SignatureAlgorithm
+			index      uint32
+			privateKey string
+			publicKey  string
+		}{{
+			sigAlgo:    crypto.ECDSA_P256,
+			index:      0,
+			privateKey: "0x55a8a0c1373147d8d8e561d462a8a30b9e62cebe28ad4f0a2b34a08ffd89a871",
+			publicKey:  "b4975976d22a32630b79df969e05ff87a5180c7fc2fd832ab54a778779d9f8b3389407c8f170b562b3749b914227932fd3b9b8e0a2d78659f36273408bfead75",
+		}, {
+			sigAlgo:    crypto.ECDSA_P256,
+			index:      1,
+			privateKey: "0x642bb51cfc5e3bc52a290efa86c688050df2fe0ccc478198f2a14e25e1c5aa4e",
+			publicKey:  "72526a8502b2fb048f91c5ee2b63f616707e772800e436bacbf3230f54b27280fd271546417237e54c5057c8c4331f0f797ed8d300fd16d72cf4fc1c61102c0e",
+		}, {
+			sigAlgo:    crypto.ECDSA_secp256k1,
+			index:      0,
+			privateKey: "0x26fc9ce54aacd33490c15f388802867ebc376ed298b67b2ee95717177d5634bc",
+			publicKey:  "88ced571f6b2b3d16757086c51c888342437a0defd126bece1426a00cdeebd1b7fc07b9cf221dcf4d68044782c522fc4cb3a85b0987bdb8e5e7fd106d356892f",
+		}, {
+			sigAlgo:    crypto.ECDSA_secp256k1,
+			index:      1,
+			privateKey: "0x75a0260a2605cadac67e58e4db4f9ce1f37b61406530982c8cec49850982bd51",
+			publicKey:  "9a2e3cced3d79689d9dfcea2952b7873978a39bd5cd997f65a6cfb5e99f094c0ac3bda47da5dbdc6121c72d82f91b4ef2b1bf6a702e64464b5e0929da6bea2ae",
+		}}
+
+		for _, test := range tests {
+			key, err := s.Keys.DerivePrivateKeyFromMnemonic(mnemonic, test.sigAlgo, DerivationPath(test.index))
+			assert.NoError(t, err)
+			assert.Equal(t, test.privateKey, key.String())
+			assert.Equal(t, test.publicKey, hex.EncodeToString(key.PublicKey().Encode()))
+		}
+
+		assert.Equal(t, DefaultDerivationPath, DerivationPath(0))
+	})
+
+	t.Run("Fail Derive Keys with invalid mnemonic", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		_, err := s.Keys.DerivePrivateKeyFromMnemonic("abandon abandon abandon", crypto.ECDSA_P256, "")
+
+		assert.EqualError(t, err, "invalid mnemonic")
+	})
+
 	t.Run("Generate Keys with private key", func(t *testing.T) {
 		t.Parallel()
 
@@ -136,6 +209,24 @@ func TestKeys(t *testing.T) {
 		assert.Equal(t, key.PublicKey().String(), "0x3da1d2eb3d9f1a0f57b434dca6bac2068216ccc5c69221a70f5c060152a39296ad28ad260536977f88eea45da9064b81a18c17f5cdc30e638752767359f0b496")
 	})
 
+	t.Run("Parse Private Key Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		key, err := s.Keys.ParsePrivateKey("0xaf232020ea7a7256eebdcebd609457d0dea51436a4377d2b577a3cf1f6d45c44", crypto.ECDSA_secp256k1)
+		assert.NoError(t, err)
+		assert.Equal(t, crypto.ECDSA_secp256k1, key.Algorithm())
+
+		_, err = s.Keys.ParsePrivateKey("af232020ea7a7256eebdcebd609457d0dea51436a4377d2b577a3cf1f6d45c4", crypto.ECDSA_P256)
+		assert.EqualError(t, err, "failed to decode private key: hex string has an odd length of 63 characters")
+
+		_, err = s.Keys.ParsePrivateKey("af232020ea7a7256eebdcebd609457d0dea51436a4377d2b577a3cf1f6d45c", crypto.ECDSA_P256)
+		assert.EqualError(t, err, "failed to decode private key: expected 64 hex characters, got 62")
+
+		_, err = s.Keys.ParsePrivateKey("zz232020ea7a7256eebdcebd609457d0dea51436a4377d2b577a3cf1f6d45c44", crypto.ECDSA_P256)
+		assert.Error(t, err)
+	})
+
 	t.Run("Generate Keys Invalid", func(t *testing.T) {
 		t.Parallel()
 
@@ -160,6 +251,59 @@ func TestKeys(t *testing.T) {
 
 	})
 
+	t.Run("Generate Keys batch with seed", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		seed := "aaaaaaaaaaaaaaaaaaaaaaannndddddd_its_gone"
+
+		generateBatch := func() []GeneratedKey {
+			keys := make([]GeneratedKey, 0)
+			err := s.Keys.GenerateBatch(250, seed, crypto.ECDSA_secp256k1, func(key GeneratedKey) error {
+				keys = append(keys, key)
+				return nil
+			})
+			require.NoError(t, err)
+			return keys
+		}
+
+		first := generateBatch()
+		second := generateBatch()
+		require.Len(t, first, 250)
+
+		for i, key := range first {
+			// the keys are written in order of the indexes and are the same in every batch
+			assert.Equal(t, i, key.Index)
+			assert.True(t, key.PrivateKey.Equals(second[i].PrivateKey))
+
+			expected, err := s.Keys.Generate(BatchSeed(seed, i), crypto.ECDSA_secp256k1)
+			require.NoError(t, err)
+			assert.True(t, key.PrivateKey.Equals(expected))
+		}
+		assert.False(t, first[0].PrivateKey.Equals(first[1].PrivateKey))
+	})
+
+	t.Run("Generate Keys batch fails on write error", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		written := 0
+		err := s.Keys.GenerateBatch(100, "", crypto.ECDSA_P256, func(key GeneratedKey) error {
+			written++
+			if key.Index == 10 {
+				return fmt.Errorf("disk full")
+			}
+			return nil
+		})
+		assert.EqualError(t, err, "disk full")
+		assert.Equal(t, 11, written)
+
+		err = s.Keys.GenerateBatch(2, "im_short", crypto.ECDSA_P256, func(key GeneratedKey) error {
+			return nil
+		})
+		assert.EqualError(t, err, "failed to generate key 0: failed to generate private key: crypto: insufficient seed length 10, must be at least 32 bytes for ECDSA_P256")
+	})
+
 	t.Run("Decode RLP Key", func(t *testing.T) {
 		t.Parallel()
 
@@ -179,6 +323,46 @@ func TestKeys(t *testing.T) {
 		assert.Equal(t, err.Error(), "failed to decode public key: encoding/hex: odd length hex string")
 	})
 
+	t.Run("Decode RLP Key invalid fields", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		publicKey := "b84084d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db24"
+
+		keys := map[string]string{
+			"8203e8":                            "failed to decode: the account key must be an RLP list: rlp: expected List",
+			"f844" + publicKey + "0203":         "failed to decode: missing weight field",
+			"f847" + publicKey + "02c08203e8":   "failed to decode hash algorithm field: rlp: expected input string or byte for uint",
+			"f848" + publicKey + "0203830003e8": "failed to decode weight field: rlp: non-canonical integer (leading zero bytes) for uint",
+			"f847" + publicKey + "05038203e8":   "failed to decode signature algorithm field: unsupported value 5",
+			"f847" + publicKey + "02038207d0":   "failed to decode weight field: 2000 is above the maximum of 1000",
+			"f848" + publicKey + "02038203e801": "failed to decode: unexpected fields after the weight field",
+		}
+
+		for encoded, message := range keys {
+			_, err := s.Keys.DecodeRLP(encoded)
+			assert.EqualError(t, err, message)
+		}
+	})
+
+	t.Run("Get Account Key", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		account := tests.NewAccountWithAddress("f8d6e0586b0a20c7")
+		account.Keys[1].Index = 3
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			gw.GetAccount.Return(account, nil)
+		})
+
+		key, err := s.Keys.GetAccountKey(account.Address, 3)
+		require.NoError(t, err)
+		assert.Equal(t, account.Keys[1], key)
+
+		_, err = s.Keys.GetAccountKey(account.Address, 7)
+		assert.EqualError(t, err, "account 0xf8d6e0586b0a20c7 has no key with index 7")
+	})
+
 	t.Run("Decode PEM Key", func(t *testing.T) {
 		t.Parallel()
 
@@ -197,4 +381,46 @@ func TestKeys(t *testing.T) {
 		_, err := s.Keys.DecodePEM("nope", crypto.ECDSA_P256)
 		assert.Equal(t, err.Error(), "crypto: failed to parse PEM string, not all bytes in PEM key were decoded: 6e6f7065")
 	})
+
+	t.Run("Encode and Decode Keys", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+			privateKey, err := s.Keys.Generate("", sigAlgo)
+			require.NoError(t, err)
+
+			for _, encoding := range []flowkit.KeyEncoding{flowkit.EncodingPEM, flowkit.EncodingDER} {
+				for _, format := range []flowkit.PrivateKeyFormat{flowkit.FormatSEC1, flowkit.FormatPKCS8} {
+					encoded, err := s.Keys.EncodePrivateKey(privateKey, encoding, format)
+					require.NoError(t, err)
+
+					decoded, publicKey, err := s.Keys.DecodeKey(encoded, encoding)
+					require.NoError(t, err)
+					assert.True(t, privateKey.Equals(decoded))
+					assert.True(t, privateKey.PublicKey().Equals(publicKey))
+				}
+
+				encoded, err := s.Keys.EncodePublicKey(privateKey.PublicKey(), encoding)
+				require.NoError(t, err)
+
+				decoded, publicKey, err := s.Keys.DecodeKey(encoded, encoding)
+				require.NoError(t, err)
+				assert.Nil(t, decoded)
+				assert.Equal(t, sigAlgo, publicKey.Algorithm())
+				assert.True(t, privateKey.PublicKey().Equals(publicKey))
+			}
+		}
+	})
+
+	t.Run("Decode Key Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		_, _, err := s.Keys.DecodeKey([]byte("nope"), flowkit.EncodingPEM)
+		assert.EqualError(t, err, "no EC PRIVATE KEY, PRIVATE KEY or PUBLIC KEY PEM block found")
+
+		_, _, err = s.Keys.DecodeKey([]byte("nope"), "rlp")
+		assert.EqualError(t, err, "encoding rlp not supported, valid encodings are pem and der")
+	})
 }
@@ -19,11 +19,21 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
 
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc/codes"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
@@ -53,24 +63,38 @@ func NewProject(
 }
 
 // Init initializes a new project using the properties provided.
+//
+// The configuration is created in the format provided, the global configuration only supports the JSON format.
 func (p *Project) Init(
 	readerWriter flowkit.ReaderWriter,
 	reset bool,
 	global bool,
+	format string,
 	sigAlgo crypto.SignatureAlgorithm,
 	hashAlgo crypto.HashAlgorithm,
 	serviceKey crypto.PrivateKey,
 ) (*flowkit.State, error) {
-	path := config.DefaultPath
+	path, err := config.DefaultPathForFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := config.DefaultFormatPaths
 	if global {
+		if path != config.DefaultPath {
+			return nil, fmt.Errorf("global configuration only supports the json format")
+		}
 		path = config.GlobalPath()
+		existing = []string{path}
 	}
 
-	if flowkit.Exists(path) && !reset {
-		return nil, fmt.Errorf(
-			"configuration already exists at: %s, if you want to reset configuration use the reset flag",
-			path,
-		)
+	for _, existingPath := range existing {
+		if flowkit.Exists(existingPath) && !reset {
+			return nil, fmt.Errorf(
+				"configuration already exists at: %s, if you want to reset configuration use the reset flag",
+				existingPath,
+			)
+		}
 	}
 
 	state, err := flowkit.Init(readerWriter, sigAlgo, hashAlgo)
@@ -205,29 +229,130 @@ func (p *Project) CheckForStandardContractUsageOnMainnet() error {
 	return nil
 }
 
+// DeployOptions define the options used when deploying the project.
+type DeployOptions struct {
+	// Update existing contracts on the accounts.
+	Update bool
+	// Force the deployment even if the deployed contract code is unchanged.
+	Force bool
+	// Resume the deployment skipping contracts recorded in the journal as deployed by a previous failed run.
+	Resume bool
+	// RollbackOnFailure removes contracts added during the run if any of the contracts fail to deploy.
+	RollbackOnFailure bool
+	// Journal is the path of the deployment journal, if not set the default journal path is used.
+	Journal string
+	// MaxContractSize is the maximum size of a contract in bytes, if not set the default maximum size is used.
+	MaxContractSize int
+	// Strict fails the deployment instead of warning if any contract exceeds the maximum size.
+	Strict bool
+	// Workers is the number of contracts deployed concurrently, contracts are deployed one by one if not set.
+	Workers int
+	// Manifest is the path of the deployment manifest updated with the deployed contracts, if not set no manifest is written.
+	Manifest string
+	// SortBy defines the deployment order of contracts which don't depend on each other.
+	SortBy project.SortOrder
+	// Tags select the contracts with any of the deployment tags, all contracts are deployed if not set.
+	Tags []string
+	// Exclude the contracts with the names from the deployment.
+	Exclude []string
+	// NoDefaultAliases disables the default aliases of core contracts imported by the deployed contracts.
+	NoDefaultAliases bool
+	// OnDeploying is called before the contract deployment transaction is sent.
+	OnDeploying func(contract *project.Contract)
+	// OnDeployed is called with the result of each contract deployment, including skipped and failed contracts.
+	OnDeployed func(result ContractDeployResult)
+}
+
+// ContractDeployResult is the result of a single contract deployment.
+type ContractDeployResult struct {
+	Contract *project.Contract
+	Address  flow.Address
+	// TransactionID is the ID of the deployment transaction, empty if the contract was skipped or failed.
+	TransactionID flow.Identifier
+	// Skipped is set if the contract was not deployed because it is unchanged or deployed by a previous run.
+	Skipped bool
+	// Updated is set if the contract was already deployed and was updated.
+	Updated bool
+	// Error is the reason the contract failed to deploy.
+	Error error
+}
+
+// DefaultMaxContractSize is the maximum size in bytes of the transpiled contract code and its arguments.
+const DefaultMaxContractSize = 1_500_000
+
 // Deploy the project for the provided network.
 //
 // Retrieve all the contracts for specified network, sort them for deployment
 // deploy one by one and replace the imports in the contract source so it corresponds
 // to the account name the contract was deployed to.
-func (p *Project) Deploy(network string, update bool) ([]*project.Contract, error) {
+//
+// Contracts with code equal to the already deployed code are skipped, unless forced by options.
+func (p *Project) Deploy(network string, options DeployOptions) ([]*project.Contract, error) {
+	sorted, _, err := p.deploy(context.Background(), network, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return sorted, nil
+}
+
+// DeployProject deploys the project contracts for the network using the provided gateway.
+//
+// Contracts are deployed the same way as by the deploy command, but nothing is printed,
+// the progress can be followed with the callbacks in the options instead.
+// The result of each contract deployment is returned together with the deployment error
+// if any of the contracts failed to deploy. The deployment stops when the context is cancelled.
+func DeployProject(
+	ctx context.Context,
+	state *flowkit.State,
+	gateway gateway.Gateway,
+	network string,
+	options DeployOptions,
+) ([]ContractDeployResult, error) {
+	p := NewProject(gateway, state, output.NewStdoutLogger(output.NoneLog))
+	_, results, err := p.deploy(ctx, network, options)
+	return results, err
+}
+
+// deploy the project and return the sorted contracts together with the result of each contract deployment.
+func (p *Project) deploy(
+	ctx context.Context,
+	network string,
+	options DeployOptions,
+) ([]*project.Contract, []ContractDeployResult, error) {
 	if p.state == nil {
-		return nil, config.ErrDoesNotExist
+		return nil, nil, config.ErrDoesNotExist
 	}
 
 	contracts, err := p.state.DeploymentContractsByNetwork(network)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	deployment, err := project.NewDeployment(contracts, p.state.AliasesForNetwork(network))
+	aliases, err := p.aliases(network, contracts, !options.NoDefaultAliases)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	contracts, err = selectContracts(contracts, aliases, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deployment, err := newDeployment(contracts, aliases)
+	if err != nil {
+		return nil, nil, err
 	}
+	deployment.SetSortOrder(options.SortBy)
 
 	sorted, err := deployment.Sort()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	err = p.checkContractSizes(sorted, contracts, aliases, options)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	p.logger.Info(fmt.Sprintf(
@@ -237,76 +362,1189 @@ func (p *Project) Deploy(network string, update bool) ([]*project.Contract, erro
 	))
 	defer p.logger.StopProgress()
 
-	// todo refactor service layer so it can be shared
-	accounts := NewAccounts(p.gateway, p.state, output.NewStdoutLogger(output.NoneLog))
+	run := &deploymentRun{
+		ctx:       ctx,
+		project:   p,
+		network:   network,
+		options:   options,
+		contracts: contracts,
+		aliases:   aliases,
+		// todo refactor service layer so it can be shared
+		accounts:     NewAccounts(p.gateway, p.state, output.NewStdoutLogger(output.NoneLog)),
+		transactions: NewTransactions(p.gateway, p.state, output.NewStdoutLogger(output.NoneLog)),
+		deployed:     newDeployedContracts(p.gateway),
+		journal:      newDeploymentJournal(p.state.ReaderWriter(), options.Journal, network),
+		deployErr:    &ProjectDeploymentError{},
+	}
+	if options.Resume {
+		run.journal, err = loadDeploymentJournal(p.state.ReaderWriter(), options.Journal, network)
+		if err != nil {
+			return nil, run.results, err
+		}
+	}
+	if options.Manifest != "" {
+		run.manifest, err = LoadManifest(p.state.ReaderWriter(), options.Manifest)
+		if err != nil {
+			return nil, run.results, err
+		}
+	}
 
-	deployErr := &ProjectDeploymentError{}
-	for _, contract := range sorted {
-		targetAccount, err := p.state.Accounts().ByName(contract.AccountName)
+	if options.Workers > 1 {
+		var levels [][]*project.Contract
+		levels, err = deployment.SortLevels()
 		if err != nil {
-			return nil, fmt.Errorf("target account for deploying contract not found in configuration")
+			return nil, run.results, err
 		}
+		err = run.deployLevels(levels)
+	} else {
+		err = run.deployAll(sorted)
+	}
+	if err != nil {
+		return nil, run.results, err
+	}
 
-		// special case for emulator updates, where we remove and add a contract because it allows us to have more freedom in changes.
-		// Updating contracts is limited as described in https://developers.flow.com/cadence/language/contract-updatability
-		if update && network == config.DefaultEmulatorNetwork().Name {
-			_, _ = accounts.RemoveContract(targetAccount, contract.Name) // ignore failure as it's meant to be best-effort
+	if run.manifest != nil { // contracts deployed before a failure are also recorded
+		err = run.manifest.Save(p.state.ReaderWriter(), options.Manifest)
+		if err != nil {
+			return nil, run.results, err
 		}
+	}
 
-		txID, updated, err := accounts.AddContract(
-			targetAccount,
-			flowkit.NewScript(contract.Code(), contract.Args, contract.Location()),
-			network,
-			update,
-		)
-		if err != nil && errors.Is(err, errUpdateNoDiff) {
+	if run.failed() {
+		if options.RollbackOnFailure {
+			p.rollback(run.accounts, run.journal, run.added)
+		}
+		return nil, run.results, run.deployErr
+	}
+
+	if err := run.journal.remove(); err != nil {
+		return nil, run.results, err
+	}
+
+	p.logger.Info(fmt.Sprintf("\n%s All contracts deployed successfully", output.SuccessEmoji()))
+	return sorted, run.results, nil
+}
+
+// deploymentRun holds the state of a single project deployment.
+//
+// Contracts can be deployed concurrently so access to the shared state is synchronized.
+type deploymentRun struct {
+	ctx          context.Context
+	project      *Project
+	network      string
+	options      DeployOptions
+	contracts    []*project.Contract
+	aliases      project.Aliases
+	accounts     *Accounts
+	transactions *Transactions
+	deployed     *deployedContracts
+	journal      *deploymentJournal
+	deployErr    *ProjectDeploymentError
+	added        []*project.Contract // contracts added during this run, used for rollback
+	succeeded    []*project.Contract
+	results      []ContractDeployResult
+	manifest     Manifest // nil if the manifest is not written
+	mu           sync.Mutex
+}
+
+// deployAll deploys the contracts one by one in the provided order.
+func (r *deploymentRun) deployAll(contracts []*project.Contract) error {
+	for _, contract := range contracts {
+		err := r.deploy(contract)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deployLevels deploys the contract levels one after another, and contracts in a level concurrently.
+//
+// Contracts deployed to the same account are deployed sequentially to avoid conflicts of the proposal key
+// sequence number. If any of the contracts in a level fail to deploy the following levels are not deployed.
+func (r *deploymentRun) deployLevels(levels [][]*project.Contract) error {
+	workers := make(chan struct{}, r.options.Workers)
+
+	for i, level := range levels {
+		byAccount := make(map[string][]*project.Contract)
+		accountNames := make([]string, 0)
+		for _, contract := range level {
+			if _, ok := byAccount[contract.AccountName]; !ok {
+				accountNames = append(accountNames, contract.AccountName)
+			}
+			byAccount[contract.AccountName] = append(byAccount[contract.AccountName], contract)
+		}
+
+		var wg sync.WaitGroup
+		errs := make([]error, len(accountNames))
+		for j, name := range accountNames {
+			wg.Add(1)
+			go func(j int, contracts []*project.Contract) {
+				defer wg.Done()
+				workers <- struct{}{}
+				defer func() { <-workers }()
+
+				errs[j] = r.deployAll(contracts)
+			}(j, byAccount[name])
+		}
+		wg.Wait()
+
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+
+		if r.failed() && i < len(levels)-1 {
+			names := make([]string, len(r.succeeded))
+			for j, contract := range r.succeeded {
+				names[j] = contract.Name
+			}
+			r.project.logger.Info(fmt.Sprintf(
+				"\nStopped deployment after failure, deployed contracts: %s",
+				strings.Join(names, ", "),
+			))
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// deploy the contract, deployment failures are collected in the deployment error and
+// only the errors preventing the deployment from continuing are returned.
+func (r *deploymentRun) deploy(contract *project.Contract) error {
+	p := r.project
+
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+
+	targetAccount, err := p.state.Accounts().ByName(contract.AccountName)
+	if err != nil {
+		return fmt.Errorf("target account for deploying contract not found in configuration")
+	}
+
+	if r.journal.deployed(contract) {
+		p.logger.Info(fmt.Sprintf(
+			"%s -> 0x%s [skipping, deployed in previous run]",
+			output.Italic(contract.Name),
+			contract.AccountAddress.String(),
+		))
+		r.report(ContractDeployResult{
+			Contract: contract,
+			Address:  contract.AccountAddress,
+			Skipped:  true,
+		})
+		return nil
+	}
+
+	// imports are replaced before deploying so the default aliases of core contracts are used
+	code, err := transpile(contract, r.contracts, r.aliases)
+	if err != nil {
+		r.fail(contract, err, fmt.Sprintf("failed to resolve imports for contract %s", contract.Name))
+		return nil
+	}
+
+	if !r.options.Force {
+		unchanged, err := r.deployed.unchanged(contract, code)
+		if err != nil {
+			r.fail(contract, err, fmt.Sprintf("failed to fetch deployed contract %s", contract.Name))
+			return nil
+		}
+		if unchanged {
 			p.logger.Info(fmt.Sprintf(
-				"%s -> 0x%s [skipping, no changes found]",
+				"%s -> 0x%s [skipping, unchanged]",
 				output.Italic(contract.Name),
 				contract.AccountAddress.String(),
 			))
-			continue
-		} else if err != nil {
-			deployErr.add(contract, err, fmt.Sprintf("failed to deploy contract %s", contract.Name))
-			continue
+			return r.succeed(contract, false, flow.EmptyID, 0)
 		}
+	}
+
+	var pre, post []config.DeploymentHook
+	if deployment := p.state.Deployments().ContractByAccountAndNetwork(
+		contract.Name,
+		contract.AccountName,
+		r.network,
+	); deployment != nil {
+		pre, post = deployment.Pre, deployment.Post
+	}
+
+	if r.options.OnDeploying != nil {
+		r.options.OnDeploying(contract)
+	}
+
+	if !r.runHooks(contract, pre, "pre") {
+		return nil
+	}
+
+	// special case for emulator updates, where we remove and add a contract because it allows us to have more freedom in changes.
+	// Updating contracts is limited as described in https://developers.flow.com/cadence/language/contract-updatability
+	if r.options.Update && r.network == config.DefaultEmulatorNetwork().Name {
+		_, _ = r.accounts.RemoveContract(targetAccount, contract.Name) // ignore failure as it's meant to be best-effort
+	}
 
+	txID, result, updated, err := r.accounts.addContract(
+		targetAccount,
+		flowkit.NewScript(code, contract.Args, contract.Location()),
+		r.network,
+		r.options.Update,
+		r.options.Force,
+	)
+	if err != nil && errors.Is(err, errUpdateNoDiff) {
 		p.logger.Info(fmt.Sprintf(
-			"%s -> 0x%s (%s) %s",
-			output.Green(contract.Name),
-			contract.AccountAddress,
-			txID.String(),
-			map[bool]string{true: "[updated]", false: ""}[updated],
+			"%s -> 0x%s [skipping, no changes found]",
+			output.Italic(contract.Name),
+			contract.AccountAddress.String(),
+		))
+		return r.succeed(contract, false, flow.EmptyID, 0)
+	} else if err != nil {
+		r.fail(contract, err, fmt.Sprintf("failed to deploy contract %s", contract.Name))
+		return nil
+	}
+
+	p.logger.Info(fmt.Sprintf(
+		"%s -> 0x%s (%s) %s",
+		output.Green(contract.Name),
+		contract.AccountAddress,
+		txID.String(),
+		map[bool]string{true: "[updated]", false: ""}[updated],
+	))
+
+	err = r.succeed(contract, !updated, txID, result.BlockHeight)
+	if err != nil {
+		return err
+	}
+
+	r.runHooks(contract, post, "post")
+	return nil
+}
+
+// runHooks sends the deployment hook transactions for the contract one by one.
+//
+// If any of the hook transactions fails the contract deployment fails the same way as a failed contract
+// transaction, so the deployment is rolled back if requested. The following hooks are not sent and false is returned.
+func (r *deploymentRun) runHooks(contract *project.Contract, hooks []config.DeploymentHook, stage string) bool {
+	p := r.project
+
+	for _, hook := range hooks {
+		signer, err := p.state.Accounts().ByName(hook.Signer)
+		if err != nil {
+			r.fail(contract, err, fmt.Sprintf("signer of %s deployment hook %s for contract %s", stage, hook.Location, contract.Name))
+			return false
+		}
+
+		code, err := p.state.ReaderWriter().ReadFile(hook.Location)
+		if err != nil {
+			r.fail(contract, err, fmt.Sprintf("failed to read %s deployment hook %s for contract %s", stage, hook.Location, contract.Name))
+			return false
+		}
+
+		tx, result, err := r.transactions.Send(
+			NewSingleTransactionAccount(signer),
+			flowkit.NewScript(code, hook.Args, hook.Location),
+			flow.DefaultTransactionGasLimit,
+			r.network,
+		)
+		if err == nil && result.Error != nil {
+			err = result.Error
+		}
+		if err != nil {
+			r.fail(contract, err, fmt.Sprintf("%s deployment hook %s for contract %s failed", stage, hook.Location, contract.Name))
+			return false
+		}
+
+		p.logger.Info(fmt.Sprintf(
+			"  %s hook %s (%s) [%s]",
+			stage,
+			hook.Location,
+			tx.ID().String(),
+			result.Status.String(),
 		))
 	}
 
-	if len(deployErr.contracts) > 0 {
-		return nil, deployErr
+	return true
+}
+
+// succeed records the deployed contract in the journal and the manifest.
+//
+// If the contract was skipped the transaction ID is empty.
+func (r *deploymentRun) succeed(contract *project.Contract, added bool, txID flow.Identifier, blockHeight uint64) error {
+	r.report(ContractDeployResult{
+		Contract:      contract,
+		Address:       contract.AccountAddress,
+		TransactionID: txID,
+		Skipped:       txID == flow.EmptyID,
+		Updated:       !added && txID != flow.EmptyID,
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if added {
+		r.added = append(r.added, contract)
 	}
+	r.succeeded = append(r.succeeded, contract)
 
-	p.logger.Info(fmt.Sprintf("\n%s All contracts deployed successfully", output.SuccessEmoji()))
-	return sorted, nil
+	if r.manifest != nil {
+		code, err := transpile(contract, r.contracts, r.aliases)
+		if err != nil {
+			return err
+		}
+		deployed := ManifestContract{
+			Address:     contract.AccountAddress.String(),
+			CodeHash:    codeHash(code),
+			BlockHeight: blockHeight,
+		}
+		if txID != flow.EmptyID {
+			deployed.TransactionID = txID.String()
+		}
+		r.manifest.add(contract.Name, r.network, deployed)
+	}
+
+	return r.journal.add(contract)
 }
 
-type ProjectDeploymentError struct {
-	contracts map[string]error
+func (r *deploymentRun) fail(contract *project.Contract, err error, msg string) {
+	r.report(ContractDeployResult{
+		Contract: contract,
+		Address:  contract.AccountAddress,
+		Error:    fmt.Errorf("%s: %w", msg, err),
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deployErr.add(contract, err, msg)
 }
 
-func (d *ProjectDeploymentError) add(contract *project.Contract, err error, msg string) {
-	if d.contracts == nil {
-		d.contracts = make(map[string]error)
+// report records the result of the contract deployment and passes it to the callback.
+//
+// The callback is called outside the lock, and can be called concurrently if contracts are deployed by multiple workers.
+func (r *deploymentRun) report(result ContractDeployResult) {
+	r.mu.Lock()
+	r.results = append(r.results, result)
+	r.mu.Unlock()
+
+	if r.options.OnDeployed != nil {
+		r.options.OnDeployed(result)
 	}
-	d.contracts[contract.Name] = fmt.Errorf("%s: %w", msg, err)
 }
 
-func (d *ProjectDeploymentError) Contracts() map[string]error {
-	return d.contracts
+func (r *deploymentRun) failed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.deployErr.contracts) > 0
 }
 
-func (d *ProjectDeploymentError) Error() string {
-	err := ""
-	for c, e := range d.contracts {
-		err = fmt.Sprintf("%s %s: %s,", err, c, e.Error())
+// checkContractSizes warns about contracts exceeding the maximum contract size before any contract is deployed.
+//
+// If the strict option is set an error is returned instead of the warning.
+func (p *Project) checkContractSizes(
+	sorted []*project.Contract,
+	contracts []*project.Contract,
+	aliases project.Aliases,
+	options DeployOptions,
+) error {
+	limit := options.MaxContractSize
+	if limit == 0 {
+		limit = DefaultMaxContractSize
 	}
-	return err
+
+	for _, contract := range sorted {
+		code, err := transpile(contract, contracts, aliases)
+		if err != nil {
+			continue // unresolved imports are reported when deploying the contract
+		}
+
+		size, err := contractSize(code, contract.Args)
+		if err != nil {
+			return err
+		}
+		if size <= limit {
+			continue
+		}
+
+		msg := fmt.Sprintf(
+			"contract %s size is %d bytes which exceeds the maximum contract size of %d bytes",
+			contract.Name,
+			size,
+			limit,
+		)
+		if options.Strict {
+			return errors.New(msg)
+		}
+		p.logger.Info(fmt.Sprintf("%s %s", output.WarningEmoji(), msg))
+	}
+
+	return nil
+}
+
+// contractSize returns the size of the contract code together with the size of the encoded arguments.
+func contractSize(code []byte, args []cadence.Value) (int, error) {
+	size := len(code)
+	for _, arg := range args {
+		encoded, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return 0, err
+		}
+		size += len(encoded)
+	}
+
+	return size, nil
+}
+
+// rollback removes the contracts added during the failed deployment in the reverse order of deployment.
+//
+// Updated contracts can not be restored to the previous version, so they are left unchanged.
+func (p *Project) rollback(accounts *Accounts, journal *deploymentJournal, added []*project.Contract) {
+	p.logger.Info("\nRolling back contracts deployed during the failed deployment")
+
+	for i := len(added) - 1; i >= 0; i-- {
+		contract := added[i]
+		account, err := p.state.Accounts().ByName(contract.AccountName)
+		if err != nil {
+			p.logger.Error(fmt.Sprintf("failed to rollback contract %s: %s", contract.Name, err))
+			continue
+		}
+
+		_, err = accounts.RemoveContract(account, contract.Name)
+		if err != nil {
+			p.logger.Error(fmt.Sprintf("failed to rollback contract %s: %s", contract.Name, err))
+			continue
+		}
+
+		p.logger.Info(fmt.Sprintf(
+			"%s -> 0x%s [removed]",
+			output.Italic(contract.Name),
+			contract.AccountAddress.String(),
+		))
+		if err := journal.delete(contract); err != nil {
+			p.logger.Error(fmt.Sprintf("failed to update deployment journal: %s", err))
+		}
+	}
+}
+
+// DeploymentGraph returns the dependency graph of the contracts deployed on the provided network.
+func (p *Project) DeploymentGraph(network string) (*project.DependencyGraph, error) {
+	if p.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	contracts, err := p.state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := p.aliases(network, contracts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := newDeployment(contracts, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	return deployment.Graph()
+}
+
+// PlannedContract is a contract deployment planned by a dry-run of the deployment.
+type PlannedContract struct {
+	Contract *project.Contract
+	// Update is true if the contract already exists on the target account.
+	Update bool
+	// Imports maps import locations to the addresses substituted in the deployed code.
+	Imports map[string]string
+	// Code is the contract code with imports replaced by the addresses.
+	Code []byte
+}
+
+// Plan the deployment for the provided network without signing or sending any transactions.
+//
+// Contracts are sorted in the deployment order and imports are resolved the same way as when deploying with the options,
+// the target accounts are fetched to check whether contracts are new or updated.
+func (p *Project) Plan(network string, options DeployOptions) ([]PlannedContract, error) {
+	if p.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	contracts, err := p.state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := p.aliases(network, contracts, !options.NoDefaultAliases)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err = selectContracts(contracts, aliases, options)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := newDeployment(contracts, aliases)
+	if err != nil {
+		return nil, err
+	}
+	deployment.SetSortOrder(options.SortBy)
+
+	sorted, err := deployment.Sort()
+	if err != nil {
+		return nil, err
+	}
+
+	deployed := newDeployedContracts(p.gateway)
+	plan := make([]PlannedContract, 0, len(sorted))
+	for _, contract := range sorted {
+		program, err := project.NewProgram(
+			flowkit.NewScript(contract.Code(), contract.Args, contract.Location()),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		replacer := project.NewImportReplacer(contracts, aliases, newLoader()).ForContract(contract)
+		imports, err := replacer.Addresses(program)
+		if err != nil {
+			return nil, err
+		}
+
+		program, err = replacer.Replace(program)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err := deployed.byAddress(contract.AccountAddress)
+		if err != nil {
+			return nil, err
+		}
+		_, exists := existing[contract.Name]
+
+		plan = append(plan, PlannedContract{
+			Contract: contract,
+			Update:   exists,
+			Imports:  imports,
+			Code:     program.Code(),
+		})
+	}
+
+	return plan, nil
+}
+
+// ContractDiff contains the local contract code and the code deployed on the network.
+type ContractDiff struct {
+	Contract *project.Contract
+	// Code is the local contract code with imports replaced by the addresses.
+	Code []byte
+	// DeployedCode is the code deployed on the target account, nil if the contract is not deployed.
+	DeployedCode []byte
+}
+
+// Deployed is true if the contract is deployed on the target account.
+func (d ContractDiff) Deployed() bool {
+	return d.DeployedCode != nil
+}
+
+// Differs is true if the deployed code is different from the local contract code.
+func (d ContractDiff) Differs() bool {
+	return !d.Deployed() || codeHash(d.Code) != codeHash(d.DeployedCode)
+}
+
+// Diff compares the local code of the deployment contracts with the code deployed on the network.
+//
+// The local code is transpiled the same way as when deploying, if no names are provided all the
+// contracts in the deployments for the network are compared.
+func (p *Project) Diff(network string, names []string) ([]ContractDiff, error) {
+	if p.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	contracts, err := p.state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := p.aliases(network, contracts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := newDeployment(contracts, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted, err := deployment.Sort()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if slices.IndexFunc(sorted, func(c *project.Contract) bool { return c.Name == name }) < 0 {
+			return nil, fmt.Errorf("contract %s is not in the deployments for network %s", name, network)
+		}
+	}
+
+	deployed := newDeployedContracts(p.gateway)
+	diffs := make([]ContractDiff, 0, len(sorted))
+	for _, contract := range sorted {
+		if len(names) > 0 && !slices.Contains(names, contract.Name) {
+			continue
+		}
+
+		code, err := transpile(contract, contracts, aliases)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err := deployed.byAddress(contract.AccountAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		diffs = append(diffs, ContractDiff{
+			Contract:     contract,
+			Code:         code,
+			DeployedCode: existing[contract.Name],
+		})
+	}
+
+	return diffs, nil
+}
+
+// StaleContract is a contract deployed on a deployment target account, which is no longer part of the deployment.
+type StaleContract struct {
+	Name    string
+	Account *flowkit.Account
+}
+
+// StaleContracts returns the contracts deployed on the deployment target accounts for the network,
+// which were removed from the deployment configuration.
+//
+// Core contracts, contracts with an alias on the network and the contracts of the network
+// service account are never stale, since they are not deployed by the project.
+func (p *Project) StaleContracts(network string) ([]StaleContract, error) {
+	if p.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	contracts, err := p.state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := p.aliases(network, contracts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := newDeployment(contracts, aliases)
+	if err != nil {
+		return nil, err
+	}
+	expected := deployment.ContractNamesByAccount()
+
+	serviceAddress := flow.EmptyAddress
+	if n, err := p.state.Networks().ByName(network); err == nil {
+		if chain, ok := n.ChainID(); ok {
+			serviceAddress = flow.ServiceAddress(chain)
+		}
+	}
+
+	stale := make([]StaleContract, 0)
+	accounts := p.state.AccountsForNetwork(network)
+	for i := range accounts {
+		account := &accounts[i]
+		if account.Address() == serviceAddress {
+			p.logger.Info(fmt.Sprintf(
+				"Skipping the service account 0x%s, its contracts are never removed",
+				account.Address(),
+			))
+			continue
+		}
+
+		flowAccount, err := p.gateway.GetAccount(account.Address())
+		if err != nil {
+			return nil, err
+		}
+
+		names := maps.Keys(flowAccount.Contracts)
+		slices.Sort(names)
+		for _, name := range names {
+			if _, aliased := aliases[name]; aliased {
+				continue
+			}
+			if project.IsCoreContract(name, network, account.Address()) {
+				continue
+			}
+			if !slices.Contains(expected[account.Address()], name) {
+				stale = append(stale, StaleContract{
+					Name:    name,
+					Account: account,
+				})
+			}
+		}
+	}
+
+	return stale, nil
+}
+
+// RemoveStaleContracts removes the stale contracts from the accounts.
+func (p *Project) RemoveStaleContracts(contracts []StaleContract) error {
+	accounts := NewAccounts(p.gateway, p.state, output.NewStdoutLogger(output.NoneLog))
+
+	for _, contract := range contracts {
+		txID, err := accounts.RemoveContract(contract.Account, contract.Name)
+		if err != nil {
+			return fmt.Errorf("failed to remove contract %s: %w", contract.Name, err)
+		}
+
+		p.logger.Info(fmt.Sprintf(
+			"%s -> 0x%s (%s) [removed]",
+			output.Italic(contract.Name),
+			contract.Account.Address(),
+			txID.String(),
+		))
+	}
+
+	return nil
+}
+
+// Manifest regenerates the deployment manifest at the path from the contracts deployed on the network.
+//
+// Deployments on other networks already in the manifest are kept unchanged.
+func (p *Project) Manifest(network string, path string) (Manifest, error) {
+	if p.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	contracts, err := p.state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := LoadManifest(p.state.ReaderWriter(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed := newDeployedContracts(p.gateway)
+	for _, contract := range contracts {
+		accountContracts, err := deployed.byAddress(contract.AccountAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		code, exists := accountContracts[contract.Name]
+		if !exists {
+			continue
+		}
+
+		manifest.add(contract.Name, network, ManifestContract{
+			Address:  contract.AccountAddress.String(),
+			CodeHash: codeHash(code),
+		})
+	}
+
+	err = manifest.Save(p.state.ReaderWriter(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// aliases returns the aliases for the network merged with the default aliases of core contracts imported by the contracts.
+//
+// Aliases defined in the configuration always take precedence over the default aliases.
+func (p *Project) aliases(network string, contracts []*project.Contract, defaults bool) (project.Aliases, error) {
+	return networkAliases(p.state, p.logger, network, contracts, defaults)
+}
+
+// selectContracts returns the contracts selected by the tags and exclusions of the deployment options.
+//
+// Selecting a subset of contracts fails if a selected contract imports a contract which is not selected,
+// since the imported contract might not be deployed.
+func selectContracts(
+	contracts []*project.Contract,
+	aliases project.Aliases,
+	options DeployOptions,
+) ([]*project.Contract, error) {
+	if len(options.Tags) == 0 && len(options.Exclude) == 0 {
+		return contracts, nil
+	}
+
+	for _, name := range options.Exclude {
+		if slices.IndexFunc(contracts, func(c *project.Contract) bool { return c.Name == name }) < 0 {
+			return nil, fmt.Errorf("excluded contract %s is not in the deployments", name)
+		}
+	}
+
+	for _, tag := range options.Tags {
+		if slices.IndexFunc(contracts, func(c *project.Contract) bool { return slices.Contains(c.Tags, tag) }) < 0 {
+			return nil, fmt.Errorf("no contracts in the deployments are tagged with %s", tag)
+		}
+	}
+
+	selected := func(contract *project.Contract) bool {
+		if slices.Contains(options.Exclude, contract.Name) {
+			return false
+		}
+		if len(options.Tags) == 0 {
+			return true
+		}
+		for _, tag := range contract.Tags {
+			if slices.Contains(options.Tags, tag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	deployment, err := newDeployment(contracts, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := deployment.Dependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*project.Contract, 0, len(contracts))
+	for _, contract := range contracts {
+		if !selected(contract) {
+			continue
+		}
+
+		for _, imported := range dependencies[contract] {
+			if selected(imported) {
+				continue
+			}
+
+			suggestion := "add a tag to its deployment"
+			if slices.Contains(options.Exclude, imported.Name) {
+				suggestion = "remove it from the excluded contracts"
+			} else if len(imported.Tags) > 0 {
+				suggestion = fmt.Sprintf("include it with the tag %s", strings.Join(imported.Tags, " or "))
+			}
+
+			return nil, fmt.Errorf(
+				"contract %s imports contract %s which is not selected for the deployment, %s",
+				contract.Name,
+				imported.Name,
+				suggestion,
+			)
+		}
+
+		result = append(result, contract)
+	}
+
+	return result, nil
+}
+
+// newDeployment creates a deployment resolving contract locations relative to the working directory.
+func newDeployment(contracts []*project.Contract, aliases project.Aliases) (*project.Deployment, error) {
+	return project.NewDeployment(contracts, aliases, newLoader())
+}
+
+// newLoader returns the loader normalizing the contract locations relative to the working directory.
+func newLoader() project.Loader {
+	root, _ := os.Getwd() // if working directory is unknown locations are normalized as relative paths
+	return project.NewFilesystemLoader(root)
+}
+
+// transpile returns the contract code with imports replaced by the addresses of the
+// contracts deployed on the network or their aliases, without changing the contract.
+func transpile(
+	contract *project.Contract,
+	contracts []*project.Contract,
+	aliases project.Aliases,
+) ([]byte, error) {
+	program, err := project.NewProgram(
+		flowkit.NewScript(contract.Code(), contract.Args, contract.Location()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if program.HasImports() {
+		program, err = project.NewImportReplacer(contracts, aliases, newLoader()).ForContract(contract).Replace(program)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return program.Code(), nil
+}
+
+// codeHash returns the SHA3-256 hash of the contract code encoded as hex.
+func codeHash(code []byte) string {
+	return flowkit.ContractHash(code)
+}
+
+// deployedContracts fetches and caches contracts deployed on the accounts.
+type deployedContracts struct {
+	gateway   gateway.Gateway
+	contracts map[flow.Address]map[string][]byte
+	mu        sync.Mutex
+}
+
+func newDeployedContracts(gateway gateway.Gateway) *deployedContracts {
+	return &deployedContracts{
+		gateway:   gateway,
+		contracts: make(map[flow.Address]map[string][]byte),
+	}
+}
+
+// byAddress returns contracts deployed on the account with the address, the account is only fetched once.
+//
+// Accounts which don't exist yet, like emulator accounts created by the deployment, have no contracts deployed.
+func (d *deployedContracts) byAddress(address flow.Address) (map[string][]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if contracts, ok := d.contracts[address]; ok {
+		return contracts, nil
+	}
+
+	account, err := d.gateway.GetAccount(address)
+	if err != nil && isAccountNotFound(err) {
+		d.contracts[address] = make(map[string][]byte)
+		return d.contracts[address], nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	d.contracts[address] = account.Contracts
+	return account.Contracts, nil
+}
+
+// isAccountNotFound checks whether the error is returned for an account that doesn't exist,
+// the emulator gateway returns the message of the status error without the status.
+func isAccountNotFound(err error) bool {
+	return gateway.GRPCCode(err) == codes.NotFound || strings.Contains(err.Error(), "could not find account")
+}
+
+// unchanged checks whether the transpiled contract code is the same as the code deployed on the target account.
+func (d *deployedContracts) unchanged(contract *project.Contract, code []byte) (bool, error) {
+	contracts, err := d.byAddress(contract.AccountAddress)
+	if err != nil {
+		return false, err
+	}
+
+	existing, exists := contracts[contract.Name]
+	if !exists {
+		return false, nil
+	}
+
+	return codeHash(existing) == codeHash(code), nil
+}
+
+type ProjectDeploymentError struct {
+	contracts map[string]error
+}
+
+func (d *ProjectDeploymentError) add(contract *project.Contract, err error, msg string) {
+	if d.contracts == nil {
+		d.contracts = make(map[string]error)
+	}
+	d.contracts[contract.Name] = fmt.Errorf("%s: %w", msg, err)
+}
+
+func (d *ProjectDeploymentError) Contracts() map[string]error {
+	return d.contracts
+}
+
+func (d *ProjectDeploymentError) Error() string {
+	err := ""
+	for c, e := range d.contracts {
+		err = fmt.Sprintf("%s %s: %s,", err, c, e.Error())
+	}
+	return err
+}
+
+// DefaultJournalPath is the default path of the deployment journal stored next to the configuration.
+const DefaultJournalPath = "flow.deployment.json"
+
+// deploymentJournal records the contracts successfully deployed on the network, so a failed
+// deployment can be resumed. The journal is removed once all the contracts are deployed.
+type deploymentJournal struct {
+	readerWriter flowkit.ReaderWriter
+	path         string
+	Network      string            `json:"network"`
+	Contracts    []journalContract `json:"contracts"`
+}
+
+type journalContract struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+func newDeploymentJournal(readerWriter flowkit.ReaderWriter, path string, network string) *deploymentJournal {
+	if path == "" {
+		path = DefaultJournalPath
+	}
+
+	return &deploymentJournal{
+		readerWriter: readerWriter,
+		path:         path,
+		Network:      network,
+		Contracts:    make([]journalContract, 0),
+	}
+}
+
+// loadDeploymentJournal loads the journal of the previous deployment, if there is no journal an empty journal is returned.
+func loadDeploymentJournal(readerWriter flowkit.ReaderWriter, path string, network string) (*deploymentJournal, error) {
+	journal := newDeploymentJournal(readerWriter, path, network)
+
+	data, err := readerWriter.ReadFile(journal.path)
+	if err != nil {
+		return journal, nil // nothing to resume
+	}
+
+	err = json.Unmarshal(data, journal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deployment journal %s: %w", journal.path, err)
+	}
+
+	if journal.Network != network {
+		return nil, fmt.Errorf(
+			"deployment journal %s was created for network %s, can not resume deployment on network %s",
+			journal.path,
+			journal.Network,
+			network,
+		)
+	}
+
+	return journal, nil
+}
+
+func (j *deploymentJournal) deployed(contract *project.Contract) bool {
+	for _, c := range j.Contracts {
+		if c.Name == contract.Name && c.Address == contract.AccountAddress.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// add the deployed contract to the journal and save the journal.
+func (j *deploymentJournal) add(contract *project.Contract) error {
+	j.Contracts = append(j.Contracts, journalContract{
+		Name:    contract.Name,
+		Address: contract.AccountAddress.String(),
+	})
+	return j.save()
+}
+
+// delete the contract from the journal and save the journal.
+func (j *deploymentJournal) delete(contract *project.Contract) error {
+	contracts := make([]journalContract, 0, len(j.Contracts))
+	for _, c := range j.Contracts {
+		if c.Name != contract.Name || c.Address != contract.AccountAddress.String() {
+			contracts = append(contracts, c)
+		}
+	}
+	j.Contracts = contracts
+	return j.save()
+}
+
+func (j *deploymentJournal) save() error {
+	data, err := json.MarshalIndent(j, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	err = j.readerWriter.WriteFile(j.path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write deployment journal %s: %w", j.path, err)
+	}
+
+	return nil
+}
+
+// remove the journal after a successful deployment.
+func (j *deploymentJournal) remove() error {
+	remover, ok := j.readerWriter.(interface{ Remove(name string) error })
+	if !ok {
+		return nil
+	}
+
+	err := remover.Remove(j.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove deployment journal %s: %w", j.path, err)
+	}
+
+	return nil
+}
+
+// ManifestContract is a contract deployed on a network recorded in the deployment manifest.
+type ManifestContract struct {
+	Address       string `json:"address"`
+	CodeHash      string `json:"codeHash"`
+	BlockHeight   uint64 `json:"blockHeight,omitempty"`
+	TransactionID string `json:"transactionId,omitempty"`
+}
+
+// Manifest maps contract names to the contract deployments by the network name.
+//
+// The manifest is merged across networks, so deploying to a network only changes the deployments on that network.
+type Manifest map[string]map[string]ManifestContract
+
+// LoadManifest loads the manifest from the path, if the manifest doesn't exist an empty manifest is returned.
+func LoadManifest(readerWriter flowkit.ReaderWriter, path string) (Manifest, error) {
+	manifest := make(Manifest)
+
+	data, err := readerWriter.ReadFile(path)
+	if err != nil {
+		return manifest, nil
+	}
+
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deployment manifest %s: %w", path, err)
+	}
+
+	return manifest, nil
+}
+
+// add the contract deployment to the manifest.
+//
+// If the deployment has no transaction, because the contract was unchanged, the transaction
+// of the previous deployment with the same code is kept.
+func (m Manifest) add(name string, network string, contract ManifestContract) {
+	if _, ok := m[name]; !ok {
+		m[name] = make(map[string]ManifestContract)
+	}
+
+	previous, exists := m[name][network]
+	if contract.TransactionID == "" && exists &&
+		previous.Address == contract.Address && previous.CodeHash == contract.CodeHash {
+		contract.TransactionID = previous.TransactionID
+		contract.BlockHeight = previous.BlockHeight
+	}
+
+	m[name][network] = contract
+}
+
+// Save the manifest to the path.
+func (m Manifest) Save(readerWriter flowkit.ReaderWriter, path string) error {
+	data, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	err = readerWriter.WriteFile(path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write deployment manifest %s: %w", path, err)
+	}
+
+	return nil
 }
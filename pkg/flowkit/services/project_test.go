@@ -19,17 +19,23 @@
 package services
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
@@ -45,7 +51,7 @@ func TestProject(t *testing.T) {
 
 		st, s, _ := setup()
 		pkey := tests.PrivKeys()[0]
-		init, err := s.Project.Init(st.ReaderWriter(), false, false, crypto.ECDSA_P256, crypto.SHA3_256, pkey)
+		init, err := s.Project.Init(st.ReaderWriter(), false, false, "json", crypto.ECDSA_P256, crypto.SHA3_256, pkey)
 		assert.NoError(t, err)
 
 		sacc, err := init.EmulatorServiceAccount()
@@ -58,7 +64,7 @@ func TestProject(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, (*p).String(), pkey.String())
 
-		init, err = s.Project.Init(st.ReaderWriter(), false, false, crypto.ECDSA_P256, crypto.SHA3_256, nil)
+		init, err = s.Project.Init(st.ReaderWriter(), false, false, "json", crypto.ECDSA_P256, crypto.SHA3_256, nil)
 		assert.NoError(t, err)
 		em, err := init.EmulatorServiceAccount()
 		assert.NoError(t, err)
@@ -67,50 +73,1007 @@ func TestProject(t *testing.T) {
 		assert.NotNil(t, (*k).String())
 	})
 
+	t.Run("Init Project With Algorithms", func(t *testing.T) {
+		t.Parallel()
+
+		st, s, _ := setup()
+		pkey, err := s.Keys.Generate("", crypto.ECDSA_secp256k1)
+		require.NoError(t, err)
+
+		init, err := s.Project.Init(st.ReaderWriter(), true, false, "json", crypto.ECDSA_secp256k1, crypto.SHA2_256, pkey)
+		require.NoError(t, err)
+
+		sacc, err := init.EmulatorServiceAccount()
+		require.NoError(t, err)
+		assert.Equal(t, crypto.ECDSA_secp256k1, sacc.Key().SigAlgo())
+		assert.Equal(t, crypto.SHA2_256, sacc.Key().HashAlgo())
+
+		p, err := sacc.Key().PrivateKey()
+		require.NoError(t, err)
+		assert.Equal(t, pkey.String(), (*p).String())
+	})
+
 	t.Run("Deploy Project", func(t *testing.T) {
 		t.Parallel()
 
 		state, s, gw := setup()
 
 		c := config.Contract{
-			Name:     "Hello",
+			Name:     "Hello",
+			Location: tests.ContractHelloString.Filename,
+			Network:  "emulator",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		n := config.Network{
+			Name: "emulator",
+			Host: "127.0.0.1:3569",
+		}
+		state.Networks().AddOrUpdate(n.Name, n)
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		d := config.Deployment{
+			Network: n.Name,
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+				Args: nil,
+			}},
+		}
+		state.Deployments().AddOrUpdate(d)
+
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			assert.Equal(t, tx.FlowTransaction().Payer, acct2.Address())
+			assert.True(t, strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.add"))
+
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		contracts, err := s.Project.Deploy("emulator", DeployOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, len(contracts), 1)
+		assert.Equal(t, contracts[0].AccountAddress, acct2.Address())
+	})
+
+	t.Run("Deploy Project Unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Network:  "testnet",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+			}},
+		})
+
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			addr := args.Get(0).(flow.Address)
+			acc := tests.NewAccountWithAddress(addr.String())
+			acc.Contracts = map[string][]byte{
+				tests.ContractHelloString.Name: tests.ContractHelloString.Source,
+			}
+			gw.GetAccount.Return(acc, nil)
+		})
+
+		contracts, err := s.Project.Deploy("testnet", DeployOptions{Update: true})
+		require.NoError(t, err)
+		assert.Len(t, contracts, 1)
+		gw.Mock.AssertNotCalled(t, tests.SendSignedTransactionFunc, mock.Anything)
+
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			assert.True(t, strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.update"))
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		_, err = s.Project.Deploy("testnet", DeployOptions{Update: true, Force: true})
+		require.NoError(t, err)
+		gw.Mock.AssertNumberOfCalls(t, tests.SendSignedTransactionFunc, 1)
+	})
+
+	t.Run("Deploy Project Resume", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Network:  "testnet",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+			}},
+		})
+
+		journal := fmt.Sprintf(
+			`{"network": "testnet", "contracts": [{"name": "%s", "address": "%s"}]}`,
+			c.Name,
+			acct2.Address().String(),
+		)
+		err := state.ReaderWriter().WriteFile(DefaultJournalPath, []byte(journal), 0644)
+		require.NoError(t, err)
+
+		_, err = s.Project.Deploy("emulator", DeployOptions{Resume: true})
+		assert.EqualError(t, err, "deployment journal flow.deployment.json was created for network testnet, can not resume deployment on network emulator")
+
+		contracts, err := s.Project.Deploy("testnet", DeployOptions{Resume: true})
+		require.NoError(t, err)
+		assert.Len(t, contracts, 1)
+		gw.Mock.AssertNotCalled(t, tests.SendSignedTransactionFunc, mock.Anything)
+
+		// journal is removed after successful deployment
+		_, err = state.ReaderWriter().ReadFile(DefaultJournalPath)
+		assert.Error(t, err)
+	})
+
+	t.Run("Deploy Project Rollback", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		for _, r := range []tests.Resource{tests.ContractA, tests.ContractHelloString} {
+			state.Contracts().AddOrUpdate(r.Name, config.Contract{
+				Name:     r.Name,
+				Location: r.Filename,
+				Network:  "testnet",
+			})
+		}
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{
+				{Name: tests.ContractA.Name}, {Name: tests.ContractHelloString.Name},
+			},
+		})
+
+		deployed := make(map[string][]byte)
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			acc := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			acc.Contracts = deployed
+			gw.GetAccount.Return(acc, nil)
+		})
+
+		removed := make([]string, 0)
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			decodeName, _ := jsoncdc.Decode(nil, tx.FlowTransaction().Arguments[0])
+			name := decodeName.ToGoValue().(string)
+
+			if strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.remove") {
+				removed = append(removed, name)
+				gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+				return
+			}
+
+			if name == tests.ContractHelloString.Name {
+				gw.SendSignedTransaction.Return((*flow.Transaction)(nil), fmt.Errorf("sealing timeout"))
+				return
+			}
+
+			deployed[name] = []byte("")
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		_, err := s.Project.Deploy("testnet", DeployOptions{RollbackOnFailure: true})
+		require.Error(t, err)
+		assert.Equal(t, []string{tests.ContractA.Name}, removed)
+
+		// rolled back contracts are removed from the journal
+		data, err := state.ReaderWriter().ReadFile(DefaultJournalPath)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), tests.ContractA.Name)
+	})
+
+	t.Run("Deploy Project Update Rollback New Contract", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		for _, r := range []tests.Resource{tests.ContractA, tests.ContractHelloString} {
+			state.Contracts().AddOrUpdate(r.Name, config.Contract{
+				Name:     r.Name,
+				Location: r.Filename,
+				Network:  "testnet",
+			})
+		}
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{
+				{Name: tests.ContractA.Name}, {Name: tests.ContractHelloString.Name},
+			},
+		})
+
+		deployed := make(map[string][]byte)
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			acc := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			acc.Contracts = deployed
+			gw.GetAccount.Return(acc, nil)
+		})
+
+		removed := make([]string, 0)
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			decodeName, _ := jsoncdc.Decode(nil, tx.FlowTransaction().Arguments[0])
+			name := decodeName.ToGoValue().(string)
+
+			if strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.remove") {
+				removed = append(removed, name)
+				gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+				return
+			}
+
+			if name == tests.ContractHelloString.Name {
+				gw.SendSignedTransaction.Return((*flow.Transaction)(nil), fmt.Errorf("sealing timeout"))
+				return
+			}
+
+			deployed[name] = []byte("")
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		// contracts not deployed before are created although updating is allowed, so they are removed by the rollback
+		_, err := s.Project.Deploy("testnet", DeployOptions{Update: true, RollbackOnFailure: true})
+		require.Error(t, err)
+		assert.Equal(t, []string{tests.ContractA.Name}, removed)
+	})
+
+	t.Run("Deploy Project Hooks", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Network:  "testnet",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		hook := config.DeploymentHook{
+			Location: tests.TransactionSimple.Filename,
+			Signer:   acct2.Name(),
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+				Pre:  []config.DeploymentHook{hook},
+				Post: []config.DeploymentHook{hook},
+			}},
+		})
+
+		scripts := make([]string, 0)
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			scripts = append(scripts, string(tx.FlowTransaction().Script))
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		_, err := s.Project.Deploy("testnet", DeployOptions{})
+		require.NoError(t, err)
+		require.Len(t, scripts, 3)
+		assert.Equal(t, string(tests.TransactionSimple.Source), scripts[0])
+		assert.Contains(t, scripts[1], "signer.contracts.add")
+		assert.Equal(t, string(tests.TransactionSimple.Source), scripts[2])
+
+		// failing hook stops the deployment
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			gw.SendSignedTransaction.Return(nil, fmt.Errorf("failed"))
+		})
+
+		_, err = s.Project.Deploy("testnet", DeployOptions{Force: true})
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+		assert.EqualError(t, deployErr.Contracts()[c.Name], "pre deployment hook transactionSimple.cdc for contract Hello failed: failed")
+	})
+
+	t.Run("Deploy Project Hooks Rollback", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Network:  "testnet",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+				Post: []config.DeploymentHook{{
+					Location: tests.TransactionSimple.Filename,
+					Signer:   acct2.Name(),
+				}},
+			}},
+		})
+
+		// the contract is only on the account after it's added, so it's added rather than updated and can be removed
+		added := false
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			if added {
+				account.Contracts = map[string][]byte{c.Name: tests.ContractHelloString.Source}
+			}
+			gw.GetAccount.Return(account, nil)
+		})
+
+		removed := make([]string, 0)
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			script := string(tx.FlowTransaction().Script)
+
+			switch {
+			case strings.Contains(script, "signer.contracts.add"):
+				added = true
+				gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+			case strings.Contains(script, "signer.contracts.remove"):
+				decodeName, _ := jsoncdc.Decode(nil, tx.FlowTransaction().Arguments[0])
+				removed = append(removed, decodeName.ToGoValue().(string))
+				gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+			case script == string(tests.TransactionSimple.Source):
+				gw.SendSignedTransaction.Return(nil, fmt.Errorf("failed"))
+			default:
+				gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+			}
+		})
+
+		_, err := s.Project.Deploy("testnet", DeployOptions{
+			RollbackOnFailure: true,
+			Manifest:          "flow.manifest.json",
+		})
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+		assert.EqualError(t, deployErr.Contracts()[c.Name], "post deployment hook transactionSimple.cdc for contract Hello failed: failed")
+		assert.Equal(t, []string{c.Name}, removed)
+
+		// the manifest is written although the deployment failed
+		_, err = state.ReaderWriter().ReadFile("flow.manifest.json")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Deploy Project Contract Size", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, _ := setup()
+
+		code := []byte(fmt.Sprintf("pub contract Big {\n// %s\n}", strings.Repeat("x", 1000)))
+		err := state.ReaderWriter().WriteFile("big.cdc", code, 0644)
+		require.NoError(t, err)
+
+		c := config.Contract{
+			Name:     "Big",
+			Location: "big.cdc",
+			Network:  "emulator",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "emulator",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+			}},
+		})
+
+		_, err = s.Project.Deploy("emulator", DeployOptions{MaxContractSize: len(code) - 1, Strict: true})
+		assert.EqualError(t, err, fmt.Sprintf(
+			"contract Big size is %d bytes which exceeds the maximum contract size of %d bytes",
+			len(code),
+			len(code)-1,
+		))
+
+		contracts, err := s.Project.Deploy("emulator", DeployOptions{MaxContractSize: len(code), Strict: true})
+		assert.NoError(t, err)
+		assert.Len(t, contracts, 1)
+	})
+
+	t.Run("Deploy Project Workers", func(t *testing.T) {
+		t.Parallel()
+
+		setupWorkers := func(concurrent bool) (*flowkit.State, *Services, *tests.TestGateway) {
+			state, s, gw := setup()
+
+			for _, r := range []tests.Resource{tests.ContractA, tests.ContractB, tests.ContractAA} {
+				state.Contracts().AddOrUpdate(r.Name, config.Contract{
+					Name:     r.Name,
+					Location: r.Filename,
+					Network:  "testnet",
+				})
+			}
+
+			a := tests.Alice()
+			state.Accounts().AddOrUpdate(a)
+			d := tests.Donald()
+			state.Accounts().AddOrUpdate(d)
+
+			state.Deployments().AddOrUpdate(config.Deployment{
+				Network:   "testnet",
+				Account:   a.Name(),
+				Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name}},
+			})
+			if concurrent {
+				state.Deployments().AddOrUpdate(config.Deployment{
+					Network:   "testnet",
+					Account:   d.Name(),
+					Contracts: []config.ContractDeployment{{Name: tests.ContractAA.Name}},
+				})
+			}
+
+			return state, s, gw
+		}
+
+		_, s, gw := setupWorkers(true)
+		contracts, err := s.Project.Deploy("testnet", DeployOptions{Workers: 2})
+		require.NoError(t, err)
+		assert.Len(t, contracts, 3)
+		gw.Mock.AssertNumberOfCalls(t, tests.SendSignedTransactionFunc, 3)
+
+		// failure in a level stops deploying the following levels
+		_, s, gw = setupWorkers(false)
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+			decodeName, _ := jsoncdc.Decode(nil, tx.FlowTransaction().Arguments[0])
+			if decodeName.ToGoValue().(string) == tests.ContractA.Name {
+				gw.SendSignedTransaction.Return((*flow.Transaction)(nil), fmt.Errorf("sealing timeout"))
+				return
+			}
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		_, err = s.Project.Deploy("testnet", DeployOptions{Workers: 2})
+		require.Error(t, err)
+		gw.Mock.AssertNumberOfCalls(t, tests.SendSignedTransactionFunc, 1)
+	})
+
+	t.Run("Deploy Project API", func(t *testing.T) {
+		t.Parallel()
+
+		state, _, gw := setup()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Network:  "testnet",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+			}},
+		})
+
+		deploying := make([]string, 0)
+		deployed := make([]ContractDeployResult, 0)
+		options := DeployOptions{
+			OnDeploying: func(contract *project.Contract) {
+				deploying = append(deploying, contract.Name)
+			},
+			OnDeployed: func(result ContractDeployResult) {
+				deployed = append(deployed, result)
+			},
+		}
+
+		results, err := DeployProject(context.Background(), state, gw.Mock, "testnet", options)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, c.Name, results[0].Contract.Name)
+		assert.Equal(t, acct2.Address(), results[0].Address)
+		assert.NotEqual(t, flow.EmptyID, results[0].TransactionID)
+		assert.False(t, results[0].Skipped)
+		assert.NoError(t, results[0].Error)
+		assert.Equal(t, []string{c.Name}, deploying)
+		assert.Equal(t, results, deployed)
+
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			gw.SendSignedTransaction.Return(nil, fmt.Errorf("failed"))
+		})
+
+		results, err = DeployProject(context.Background(), state, gw.Mock, "testnet", DeployOptions{})
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+		require.Len(t, results, 1)
+		assert.ErrorContains(t, results[0].Error, "failed to deploy contract Hello")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		results, err = DeployProject(ctx, state, gw.Mock, "testnet", DeployOptions{})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Deployment Plan", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		for _, r := range []tests.Resource{tests.ContractA, tests.ContractB} {
+			state.Contracts().AddOrUpdate(r.Name, config.Contract{
+				Name:     r.Name,
+				Location: r.Filename,
+				Network:  "testnet",
+			})
+		}
+
+		a := tests.Alice()
+		state.Accounts().AddOrUpdate(a)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   "testnet",
+			Account:   a.Name(),
+			Contracts: []config.ContractDeployment{{Name: tests.ContractB.Name}, {Name: tests.ContractA.Name}},
+		})
+
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			acc := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			acc.Contracts = map[string][]byte{
+				tests.ContractA.Name: tests.ContractA.Source,
+			}
+			gw.GetAccount.Return(acc, nil)
+		})
+
+		plan, err := s.Project.Plan("testnet", DeployOptions{})
+		require.NoError(t, err)
+		require.Len(t, plan, 2)
+
+		assert.Equal(t, tests.ContractA.Name, plan[0].Contract.Name)
+		assert.True(t, plan[0].Update)
+		assert.Empty(t, plan[0].Imports)
+
+		assert.Equal(t, tests.ContractB.Name, plan[1].Contract.Name)
+		assert.False(t, plan[1].Update)
+		assert.Equal(t, map[string]string{"./contractA.cdc": a.Address().String()}, plan[1].Imports)
+		assert.Contains(t, string(plan[1].Code), fmt.Sprintf("import ContractA from 0x%s", a.Address()))
+
+		gw.Mock.AssertNotCalled(t, tests.SendSignedTransactionFunc, mock.Anything)
+	})
+
+	t.Run("Deployment Plan Account Not Found", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		state.Contracts().AddOrUpdate(tests.ContractA.Name, config.Contract{
+			Name:     tests.ContractA.Name,
+			Location: tests.ContractA.Filename,
+			Network:  "testnet",
+		})
+
+		a := tests.Alice()
+		state.Accounts().AddOrUpdate(a)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   "testnet",
+			Account:   a.Name(),
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}},
+		})
+
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			gw.GetAccount.Return(nil, status.Error(codes.NotFound, "could not find account"))
+		})
+
+		plan, err := s.Project.Plan("testnet", DeployOptions{})
+		require.NoError(t, err)
+		require.Len(t, plan, 1)
+		assert.False(t, plan[0].Update)
+
+		// other errors are returned
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			gw.GetAccount.Return(nil, status.Error(codes.Unavailable, "unavailable"))
+		})
+
+		_, err = s.Project.Plan("testnet", DeployOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Deployment Plan Tags", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		for _, r := range []tests.Resource{tests.ContractA, tests.ContractB, tests.ContractHelloString} {
+			state.Contracts().AddOrUpdate(r.Name, config.Contract{
+				Name:     r.Name,
+				Location: r.Filename,
+				Network:  "testnet",
+			})
+		}
+
+		a := tests.Alice()
+		state.Accounts().AddOrUpdate(a)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: a.Name(),
+			Contracts: []config.ContractDeployment{
+				{Name: tests.ContractA.Name, Tags: []string{"core"}},
+				{Name: tests.ContractB.Name, Tags: []string{"markets"}},
+				{Name: tests.ContractHelloString.Name},
+			},
+		})
+
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			gw.GetAccount.Return(tests.NewAccountWithAddress(args.Get(0).(flow.Address).String()), nil)
+		})
+
+		names := func(plan []PlannedContract) []string {
+			result := make([]string, len(plan))
+			for i, p := range plan {
+				result[i] = p.Contract.Name
+			}
+			return result
+		}
+
+		plan, err := s.Project.Plan("testnet", DeployOptions{Tags: []string{"core"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{tests.ContractA.Name}, names(plan))
+
+		plan, err = s.Project.Plan("testnet", DeployOptions{Tags: []string{"core", "markets"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{tests.ContractA.Name, tests.ContractB.Name}, names(plan))
+
+		plan, err = s.Project.Plan("testnet", DeployOptions{Exclude: []string{tests.ContractHelloString.Name}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{tests.ContractA.Name, tests.ContractB.Name}, names(plan))
+
+		_, err = s.Project.Plan("testnet", DeployOptions{Tags: []string{"markets"}})
+		assert.EqualError(t, err, "contract ContractB imports contract ContractA which is not selected for the deployment, include it with the tag core")
+
+		_, err = s.Project.Plan("testnet", DeployOptions{Exclude: []string{tests.ContractA.Name}})
+		assert.EqualError(t, err, "contract ContractB imports contract ContractA which is not selected for the deployment, remove it from the excluded contracts")
+
+		_, err = s.Project.Plan("testnet", DeployOptions{Tags: []string{"unknown"}})
+		assert.EqualError(t, err, "no contracts in the deployments are tagged with unknown")
+
+		_, err = s.Project.Deploy("testnet", DeployOptions{Tags: []string{"markets"}})
+		assert.Error(t, err)
+		gw.Mock.AssertNotCalled(t, tests.SendSignedTransactionFunc, mock.Anything)
+	})
+
+	t.Run("Diff Contracts", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		for _, r := range []tests.Resource{tests.ContractA, tests.ContractHelloString} {
+			state.Contracts().AddOrUpdate(r.Name, config.Contract{
+				Name:     r.Name,
+				Location: r.Filename,
+				Network:  "testnet",
+			})
+		}
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{
+				{Name: tests.ContractA.Name}, {Name: tests.ContractHelloString.Name},
+			},
+		})
+
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			acc := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			acc.Contracts = map[string][]byte{
+				tests.ContractHelloString.Name: tests.ContractHelloString.Source,
+			}
+			gw.GetAccount.Return(acc, nil)
+		})
+
+		diffs, err := s.Project.Diff("testnet", nil)
+		require.NoError(t, err)
+		require.Len(t, diffs, 2)
+
+		for _, d := range diffs {
+			if d.Contract.Name == tests.ContractHelloString.Name {
+				assert.True(t, d.Deployed())
+				assert.False(t, d.Differs())
+			} else {
+				assert.False(t, d.Deployed())
+				assert.True(t, d.Differs())
+			}
+		}
+
+		diffs, err = s.Project.Diff("testnet", []string{tests.ContractHelloString.Name})
+		require.NoError(t, err)
+		require.Len(t, diffs, 1)
+
+		_, err = s.Project.Diff("testnet", []string{"Missing"})
+		assert.EqualError(t, err, "contract Missing is not in the deployments for network testnet")
+	})
+
+	t.Run("Default Core Contract Aliases", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, _ := setup()
+
+		code := []byte(`
+			import FungibleToken from "./FungibleToken.cdc"
+			pub contract Kitty {}
+		`)
+		err := state.ReaderWriter().WriteFile("kitty.cdc", code, 0644)
+		require.NoError(t, err)
+
+		state.Contracts().AddOrUpdate("Kitty", config.Contract{
+			Name:     "Kitty",
+			Location: "kitty.cdc",
+		})
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   "testnet",
+			Account:   acct2.Name(),
+			Contracts: []config.ContractDeployment{{Name: "Kitty"}},
+		})
+
+		plan, err := s.Project.Plan("testnet", DeployOptions{})
+		require.NoError(t, err)
+		require.Len(t, plan, 1)
+		assert.Equal(t, map[string]string{"./FungibleToken.cdc": "9a0766d93b6608b7"}, plan[0].Imports)
+
+		// explicit alias takes precedence
+		state.Contracts().AddOrUpdate("FungibleToken", config.Contract{
+			Name:     "FungibleToken",
+			Location: "FungibleToken.cdc",
+			Network:  "testnet",
+			Alias:    "0000000000000005",
+		})
+
+		plan, err = s.Project.Plan("testnet", DeployOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"./FungibleToken.cdc": "0000000000000005"}, plan[0].Imports)
+
+		err = state.Contracts().Remove("FungibleToken")
+		require.NoError(t, err)
+		_, err = s.Project.Plan("testnet", DeployOptions{NoDefaultAliases: true})
+		assert.Error(t, err)
+	})
+
+	t.Run("Stale Contracts", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
 			Location: tests.ContractHelloString.Filename,
-			Network:  "emulator",
+			Network:  "testnet",
 		}
 		state.Contracts().AddOrUpdate(c.Name, c)
 
-		n := config.Network{
-			Name: "emulator",
-			Host: "127.0.0.1:3569",
-		}
-		state.Networks().AddOrUpdate(n.Name, n)
-
 		acct2 := tests.Donald()
 		state.Accounts().AddOrUpdate(acct2)
 
-		d := config.Deployment{
-			Network: n.Name,
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
 			Account: acct2.Name(),
 			Contracts: []config.ContractDeployment{{
 				Name: c.Name,
-				Args: nil,
 			}},
-		}
-		state.Deployments().AddOrUpdate(d)
+		})
+
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			acc := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			acc.Contracts = map[string][]byte{
+				tests.ContractHelloString.Name: tests.ContractHelloString.Source,
+				"Removed":                      []byte(`pub contract Removed {}`),
+			}
+			gw.GetAccount.Return(acc, nil)
+		})
+
+		stale, err := s.Project.StaleContracts("testnet")
+		require.NoError(t, err)
+		require.Len(t, stale, 1)
+		assert.Equal(t, "Removed", stale[0].Name)
+		assert.Equal(t, acct2.Address(), stale[0].Account.Address())
 
 		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
 			tx := args.Get(0).(*flowkit.Transaction)
-			assert.Equal(t, tx.FlowTransaction().Payer, acct2.Address())
-			assert.True(t, strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.add"))
-
+			assert.True(t, strings.Contains(string(tx.FlowTransaction().Script), "signer.contracts.remove"))
 			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
 		})
 
-		contracts, err := s.Project.Deploy("emulator", false)
+		err = s.Project.RemoveStaleContracts(stale)
+		require.NoError(t, err)
+		gw.Mock.AssertNumberOfCalls(t, tests.SendSignedTransactionFunc, 1)
+	})
 
-		assert.NoError(t, err)
-		assert.Equal(t, len(contracts), 1)
-		assert.Equal(t, contracts[0].AccountAddress, acct2.Address())
+	t.Run("Stale Contracts Service Account", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Network:  "emulator",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		serviceAcc, _ := state.EmulatorServiceAccount()
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "emulator",
+			Account: serviceAcc.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+			}},
+		})
+
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			acc := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			acc.Contracts = map[string][]byte{
+				tests.ContractHelloString.Name: tests.ContractHelloString.Source,
+				"FlowServiceAccount":           []byte(`pub contract FlowServiceAccount {}`),
+				"FlowToken":                    []byte(`pub contract FlowToken {}`),
+			}
+			gw.GetAccount.Return(acc, nil)
+		})
+
+		stale, err := s.Project.StaleContracts("emulator")
+		require.NoError(t, err)
+		assert.Empty(t, stale)
+		gw.Mock.AssertNotCalled(t, tests.GetAccountFunc, serviceAcc.Address())
+	})
+
+	t.Run("Stale Contracts Core and Aliased", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Network:  "testnet",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+		state.Contracts().AddOrUpdate("Aliased", config.Contract{
+			Name:     "Aliased",
+			Location: "Aliased.cdc",
+			Network:  "testnet",
+			Alias:    "0000000000000005",
+		})
+
+		acct := tests.Donald()
+		acct.SetAddress(flow.HexToAddress("9a0766d93b6608b7"))
+		state.Accounts().AddOrUpdate(acct)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+			}},
+		})
+
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			acc := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			acc.Contracts = map[string][]byte{
+				tests.ContractHelloString.Name: tests.ContractHelloString.Source,
+				"FungibleToken":                []byte(`pub contract FungibleToken {}`),
+				"Aliased":                      []byte(`pub contract Aliased {}`),
+				"Removed":                      []byte(`pub contract Removed {}`),
+			}
+			gw.GetAccount.Return(acc, nil)
+		})
+
+		stale, err := s.Project.StaleContracts("testnet")
+		require.NoError(t, err)
+		require.Len(t, stale, 1)
+		assert.Equal(t, "Removed", stale[0].Name)
+	})
+
+	t.Run("Deployment Manifest", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, gw := setup()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Network:  "testnet",
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		acct2 := tests.Donald()
+		state.Accounts().AddOrUpdate(acct2)
+
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: "testnet",
+			Account: acct2.Name(),
+			Contracts: []config.ContractDeployment{{
+				Name: c.Name,
+			}},
+		})
+
+		existing := fmt.Sprintf(
+			`{"%s": {"mainnet": {"address": "0000000000000001", "codeHash": "abc"}}}`,
+			c.Name,
+		)
+		err := state.ReaderWriter().WriteFile("deployments.json", []byte(existing), 0644)
+		require.NoError(t, err)
+
+		_, err = s.Project.Deploy("testnet", DeployOptions{Manifest: "deployments.json"})
+		require.NoError(t, err)
+
+		manifest, err := LoadManifest(state.ReaderWriter(), "deployments.json")
+		require.NoError(t, err)
+
+		deployed := manifest[c.Name]["testnet"]
+		assert.Equal(t, acct2.Address().String(), deployed.Address)
+		assert.Equal(t, codeHash(tests.ContractHelloString.Source), deployed.CodeHash)
+		assert.NotEmpty(t, deployed.TransactionID)
+		assert.Equal(t, "0000000000000001", manifest[c.Name]["mainnet"].Address)
+
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			acc := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			acc.Contracts = map[string][]byte{
+				tests.ContractHelloString.Name: tests.ContractHelloString.Source,
+			}
+			gw.GetAccount.Return(acc, nil)
+		})
+
+		// regenerating the manifest keeps the transaction of the unchanged contract
+		manifest, err = s.Project.Manifest("testnet", "deployments.json")
+		require.NoError(t, err)
+		assert.Equal(t, deployed, manifest[c.Name]["testnet"])
+		assert.Len(t, manifest[c.Name], 2)
 	})
 
 	t.Run("Deploy Project Using Aliases", func(t *testing.T) {
@@ -150,7 +1113,7 @@ func TestProject(t *testing.T) {
 			Network: emulator,
 			Account: a.Name(),
 			Contracts: []config.ContractDeployment{
-				{Name: c1.Name}, {Name: c3.Name},
+				{Name: c1.Name}, {Name: c3.Name, Args: []cadence.Value{cadence.String("foo")}},
 			},
 		}
 		state.Deployments().AddOrUpdate(d)
@@ -182,7 +1145,7 @@ func TestProject(t *testing.T) {
 			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
 		})
 
-		contracts, err := s.Project.Deploy(emulator, false)
+		contracts, err := s.Project.Deploy(emulator, DeployOptions{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, len(contracts), 2)
@@ -191,6 +1154,60 @@ func TestProject(t *testing.T) {
 		gw.Mock.AssertNumberOfCalls(t, tests.GetTransactionResultFunc, 2)
 	})
 
+	t.Run("Deploy Project Normalized Imports", func(t *testing.T) {
+		t.Parallel()
+
+		emulator := config.DefaultEmulatorNetwork().Name
+		state, s, gw := setup()
+
+		// the imported contract is configured with an absolute location, while contract B imports it relatively
+		root, err := os.Getwd()
+		require.NoError(t, err)
+		location := filepath.Join(root, tests.ContractA.Filename)
+		err = state.ReaderWriter().WriteFile(location, tests.ContractA.Source, 0644)
+		require.NoError(t, err)
+
+		state.Contracts().AddOrUpdate(tests.ContractA.Name, config.Contract{
+			Name:     tests.ContractA.Name,
+			Location: location,
+			Network:  emulator,
+		})
+		state.Contracts().AddOrUpdate(tests.ContractB.Name, config.Contract{
+			Name:     tests.ContractB.Name,
+			Location: tests.ContractB.Filename,
+			Network:  emulator,
+		})
+
+		a := tests.Alice()
+		state.Accounts().AddOrUpdate(a)
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: emulator,
+			Account: a.Name(),
+			Contracts: []config.ContractDeployment{
+				{Name: tests.ContractA.Name}, {Name: tests.ContractB.Name},
+			},
+		})
+
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction)
+
+			argName, _ := jsoncdc.Decode(nil, tx.FlowTransaction().Arguments[0])
+			argCode, _ := jsoncdc.Decode(nil, tx.FlowTransaction().Arguments[1])
+			code, _ := hex.DecodeString(argCode.ToGoValue().(string))
+
+			if argName.ToGoValue().(string) == tests.ContractB.Name {
+				assert.Contains(t, string(code), fmt.Sprintf("import ContractA from 0x%s", a.Address().Hex()))
+			}
+
+			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+		})
+
+		contracts, err := s.Project.Deploy(emulator, DeployOptions{})
+		require.NoError(t, err)
+		assert.Len(t, contracts, 2)
+		gw.Mock.AssertNumberOfCalls(t, tests.SendSignedTransactionFunc, 2)
+	})
+
 	t.Run("Deploy Project New Import Schema and Aliases", func(t *testing.T) {
 		t.Parallel()
 
@@ -228,7 +1245,7 @@ func TestProject(t *testing.T) {
 			Network: emulator,
 			Account: a.Name(),
 			Contracts: []config.ContractDeployment{
-				{Name: c1.Name}, {Name: c3.Name},
+				{Name: c1.Name}, {Name: c3.Name, Args: []cadence.Value{cadence.String("foo")}},
 			},
 		}
 		state.Deployments().AddOrUpdate(d)
@@ -260,7 +1277,7 @@ func TestProject(t *testing.T) {
 			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
 		})
 
-		contracts, err := s.Project.Deploy(emulator, false)
+		contracts, err := s.Project.Deploy(emulator, DeployOptions{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, len(contracts), 2)
@@ -311,7 +1328,7 @@ func TestProject(t *testing.T) {
 			gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
 		})
 
-		contracts, err := s.Project.Deploy("emulator", false)
+		contracts, err := s.Project.Deploy("emulator", DeployOptions{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, len(contracts), 1)
@@ -347,7 +1364,7 @@ func simpleDeploy(state *flowkit.State, s *Services, update bool) ([]*project.Co
 	}
 	state.Deployments().AddOrUpdate(d)
 
-	return s.Project.Deploy(n.Name, update)
+	return s.Project.Deploy(n.Name, DeployOptions{Update: update})
 }
 
 func TestProject_Integration(t *testing.T) {
@@ -426,7 +1443,7 @@ func TestProject_Integration(t *testing.T) {
 			replacedContracts[i] = strings.ReplaceAll(replacedContracts[i], `"./contractB.cdc"`, addr)
 		}
 
-		contracts, err := s.Project.Deploy(n.Name, false)
+		contracts, err := s.Project.Deploy(n.Name, DeployOptions{})
 		assert.NoError(t, err)
 		assert.Len(t, contracts, 2)
 
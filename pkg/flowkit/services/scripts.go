@@ -20,11 +20,16 @@ package services
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go-sdk"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
-	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/project"
@@ -52,25 +57,295 @@ func NewScripts(
 
 // Execute script code with passed arguments on the selected network.
 func (s *Scripts) Execute(script *flowkit.Script, network string) (cadence.Value, error) {
+	code, err := s.resolveCode(script, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.gateway.ExecuteScript(code, script.Args)
+}
+
+// ExecuteAtHeight executes script code with passed arguments on the selected network
+// at the block with the provided height.
+func (s *Scripts) ExecuteAtHeight(script *flowkit.Script, network string, height uint64) (cadence.Value, error) {
+	code, err := s.resolveCode(script, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.gateway.ExecuteScriptAtHeight(code, script.Args, height)
+}
+
+// ExecuteAtID executes script code with passed arguments on the selected network
+// at the block with the provided ID.
+func (s *Scripts) ExecuteAtID(script *flowkit.Script, network string, id flow.Identifier) (cadence.Value, error) {
+	code, err := s.resolveCode(script, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.gateway.ExecuteScriptAtID(code, script.Args, id)
+}
+
+// ExecuteCached executes script code with passed arguments on the selected network at the block with the height,
+// or at the latest block if the height is zero, using the result in the cache if it's cached.
+//
+// Results at a block height can't change, so they are cached forever, while results at the latest block
+// are only cached for the time to live.
+func (s *Scripts) ExecuteCached(
+	script *flowkit.Script,
+	network string,
+	height uint64,
+	cache *flowkit.ScriptCache,
+	ttl time.Duration,
+) (cadence.Value, error) {
+	code, err := s.resolveCode(script, network)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := flowkit.ScriptCacheKey(code, script.Args, network, height)
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := cache.Get(key); ok {
+		s.logger.Debug(fmt.Sprintf("script cache hit: %s", key))
+		return value, nil
+	}
+	s.logger.Debug(fmt.Sprintf("script cache miss: %s", key))
+
+	var value cadence.Value
+	if height == 0 {
+		value, err = s.gateway.ExecuteScript(code, script.Args)
+	} else {
+		value, err = s.gateway.ExecuteScriptAtHeight(code, script.Args, height)
+		ttl = 0
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if height == 0 && ttl <= 0 {
+		return value, nil // results at the latest block are never cached forever
+	}
+
+	err = cache.Set(key, value, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// BenchmarkRun is a single execution of a benchmarked script.
+type BenchmarkRun struct {
+	Index    int
+	Duration time.Duration
+	Error    error
+}
+
+// BenchmarkOptions control benchmarking a script.
+type BenchmarkOptions struct {
+	// Runs is the number of times the script is executed.
+	Runs int
+	// Parallelism is the maximum number of executions at once, the script is executed one run at a time if it's at most one.
+	Parallelism int
+	// Report is called with each run once it's done.
+	Report func(BenchmarkRun)
+}
+
+// Benchmark executes the script the number of runs on the selected network and measures the latency of each run.
+//
+// The imports of the script are resolved once before the runs, the runs that fail are reported with their error.
+func (s *Scripts) Benchmark(
+	script *flowkit.Script,
+	network string,
+	options BenchmarkOptions,
+) ([]BenchmarkRun, error) {
+	if options.Runs < 1 {
+		return nil, fmt.Errorf("the number of runs must be at least one, not %d", options.Runs)
+	}
+
+	code, err := s.resolveCode(script, network)
+	if err != nil {
+		return nil, err
+	}
+
+	parallelism := options.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	runs := make([]BenchmarkRun, options.Runs)
+	slots := make(chan struct{}, parallelism)
+	var reportLock sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := range runs {
+		slots <- struct{}{}
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			start := time.Now()
+			_, err := s.gateway.ExecuteScript(code, script.Args)
+			runs[i] = BenchmarkRun{
+				Index:    i,
+				Duration: time.Since(start),
+				Error:    err,
+			}
+
+			if options.Report != nil {
+				reportLock.Lock()
+				options.Report(runs[i])
+				reportLock.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return runs, nil
+}
+
+// MapResult is the result of the script executed for an account.
+type MapResult struct {
+	Address flow.Address
+	Value   cadence.Value
+	Error   error
+}
+
+// MapOptions control executing a script for a list of accounts.
+type MapOptions struct {
+	// Parallelism is the maximum number of executions at once, the script is executed for one account at a time if it's at most one.
+	Parallelism int
+	// MaxRequestsPerSecond is the maximum rate of the executions, zero for no limit.
+	MaxRequestsPerSecond float64
+	// MaxRetries is the number of times an execution failing because the access node is unavailable or overloaded is retried.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled for each following retry.
+	RetryBackoff time.Duration
+	// Report is called with the result for each account once it's known.
+	Report func(MapResult)
+}
+
+// Map executes the script once for each account with the address of the account as the first argument,
+// followed by the arguments of the script.
+//
+// The executions failing for an account are reported in the result of the account without stopping the others.
+func (s *Scripts) Map(
+	script *flowkit.Script,
+	addresses []flow.Address,
+	network string,
+	options MapOptions,
+) ([]MapResult, error) {
+	code, err := s.resolveCode(script, network)
+	if err != nil {
+		return nil, err
+	}
+
+	parallelism := options.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	limited := gateway.NewLimitedGateway(s.gateway, gateway.Limits{
+		MaxRetries:           options.MaxRetries,
+		RetryBackoff:         options.RetryBackoff,
+		MaxRequestsPerSecond: options.MaxRequestsPerSecond,
+	})
+
+	results := make([]MapResult, len(addresses))
+	slots := make(chan struct{}, parallelism)
+	var reportLock sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := range addresses {
+		slots <- struct{}{}
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			args := append([]cadence.Value{cadence.NewAddress(addresses[i])}, script.Args...)
+			value, err := limited.ExecuteScript(code, args)
+			results[i] = MapResult{
+				Address: addresses[i],
+				Value:   value,
+				Error:   err,
+			}
+
+			if options.Report != nil {
+				reportLock.Lock()
+				options.Report(results[i])
+				reportLock.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results, nil
+}
+
+// ImportedFiles returns the locations of the local files the script imports on the selected network,
+// including the files imported by the imported contracts.
+//
+// Files which can't be read or parsed are included without their imports.
+func (s *Scripts) ImportedFiles(script *flowkit.Script, network string) ([]string, error) {
+	if s.state == nil {
+		return nil, nil
+	}
+
 	program, err := project.NewProgram(script)
 	if err != nil {
 		return nil, err
 	}
 
-	if program.HasImports() {
-		contracts, err := s.state.DeploymentContractsByNetwork(network)
-		if err != nil {
-			return nil, err
-		}
+	contracts, err := s.state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	replacer := project.NewImportReplacer(contracts, s.state.AliasesForNetwork(network), newLoader())
 
-		importReplacer := project.NewImportReplacer(
-			contracts,
-			s.state.AliasesForNetwork(network),
-		)
+	files := make([]string, 0)
+	seen := make(map[string]bool)
+	programs := []*project.Program{program}
+	for len(programs) > 0 {
+		program, programs = programs[0], programs[1:]
+
+		for _, file := range replacer.ImportedFiles(program) {
+			if seen[file] {
+				continue
+			}
+			seen[file] = true
+			files = append(files, file)
 
-		if s.state == nil {
-			return nil, config.ErrDoesNotExist
+			code, err := s.state.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			imported, err := project.NewProgram(flowkit.NewScript(code, nil, file))
+			if err != nil {
+				continue
+			}
+			programs = append(programs, imported)
 		}
+	}
+
+	return files, nil
+}
+
+// resolveCode returns the script code with the imports resolved on the network.
+func (s *Scripts) resolveCode(script *flowkit.Script, network string) ([]byte, error) {
+	program, err := project.NewProgram(script)
+	if err != nil {
+		return nil, err
+	}
+
+	if program.HasImports() {
 		if network == "" {
 			return nil, fmt.Errorf("missing network, specify which network to use to resolve imports in script code")
 		}
@@ -78,11 +353,199 @@ func (s *Scripts) Execute(script *flowkit.Script, network string) (cadence.Value
 			return nil, fmt.Errorf("resolving imports in scripts not supported")
 		}
 
-		program, err = importReplacer.Replace(program)
+		program, err = resolveImports(s.state, s.logger, program, network)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return s.gateway.ExecuteScript(program.Code(), script.Args)
+	return program.Code(), nil
+}
+
+// StorageItem is a path of the account storage with the type of the value at the path.
+type StorageItem struct {
+	Path   cadence.Path
+	Type   string       // type of the stored value, or the borrow type of a capability link
+	Target cadence.Path // target path of a capability link, empty for stored values
+}
+
+// IsLink reports if the item is a capability link on a public or private path.
+func (s StorageItem) IsLink() bool {
+	return s.Path.Domain != common.PathDomainStorage.Identifier()
+}
+
+// storageItemsScript enumerates the storage, public and private paths of the account.
+const storageItemsScript = `
+pub struct StorageItem {
+	pub let path: Path
+	pub let type: Type
+	pub let target: Path?
+
+	init(path: Path, type: Type, target: Path?) {
+		self.path = path
+		self.type = type
+		self.target = target
+	}
+}
+
+pub fun main(address: Address): [StorageItem] {
+	let account = getAuthAccount(address)
+	let items: [StorageItem] = []
+
+	account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+		items.append(StorageItem(path: path, type: type, target: nil))
+		return true
+	})
+	account.forEachPublic(fun (path: PublicPath, type: Type): Bool {
+		items.append(StorageItem(path: path, type: type, target: account.getLinkTarget(path)))
+		return true
+	})
+	account.forEachPrivate(fun (path: PrivatePath, type: Type): Bool {
+		items.append(StorageItem(path: path, type: type, target: account.getLinkTarget(path)))
+		return true
+	})
+
+	return items
+}
+`
+
+// StorageItems returns the items stored on the storage paths of the account and the capabilities
+// linked on the public and private paths.
+func (s *Scripts) StorageItems(address flow.Address) ([]StorageItem, error) {
+	s.logger.StartProgress(fmt.Sprintf("Loading storage paths of %s...", address))
+	defer s.logger.StopProgress()
+
+	value, err := s.Execute(
+		flowkit.NewScript([]byte(storageItemsScript), []cadence.Value{cadence.NewAddress(address)}, ""),
+		"",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage paths of account %s: %w", address, err)
+	}
+
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse storage paths of account %s", address)
+	}
+
+	items := make([]StorageItem, 0, len(array.Values))
+	for _, value := range array.Values {
+		item, err := parseStorageItem(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse storage paths of account %s: %w", address, err)
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Path.String() < items[j].Path.String()
+	})
+
+	return items, nil
+}
+
+func parseStorageItem(value cadence.Value) (StorageItem, error) {
+	item, ok := value.(cadence.Struct)
+	if !ok || item.StructType == nil {
+		return StorageItem{}, fmt.Errorf("unexpected value %s", value)
+	}
+
+	fields := make(map[string]cadence.Value)
+	for i, field := range item.StructType.Fields {
+		if i < len(item.Fields) {
+			fields[field.Identifier] = item.Fields[i]
+		}
+	}
+
+	path, ok := fields["path"].(cadence.Path)
+	if !ok {
+		return StorageItem{}, fmt.Errorf("unexpected path %s", fields["path"])
+	}
+
+	typeValue, ok := fields["type"].(cadence.TypeValue)
+	if !ok {
+		return StorageItem{}, fmt.Errorf("unexpected type of path %s", path)
+	}
+
+	result := StorageItem{Path: path}
+
+	switch t := typeValue.StaticType.(type) {
+	case nil:
+		result.Type = "unknown"
+	case cadence.CapabilityType:
+		// links are enumerated with the capability type, the borrow type is more useful
+		if t.BorrowType != nil {
+			result.Type = t.BorrowType.ID()
+		} else {
+			result.Type = t.ID()
+		}
+	default:
+		result.Type = t.ID()
+	}
+
+	if target, ok := fields["target"].(cadence.Optional); ok && target.Value != nil {
+		result.Target, _ = target.Value.(cadence.Path)
+	}
+
+	return result, nil
+}
+
+// storedValueScript returns a reference to the value stored on the path, which is exported with its fields.
+const storedValueScript = `
+pub fun main(address: Address, path: StoragePath): AnyStruct? {
+	let account = getAuthAccount(address)
+	if let resource = account.borrow<&AnyResource>(from: path) {
+		return resource
+	}
+	return account.borrow<&AnyStruct>(from: path)
+}
+`
+
+// parseStoragePath parses a storage path in the /storage/<identifier> format.
+func parseStoragePath(path string) (cadence.Path, error) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) != 2 || parts[1] == "" {
+		return cadence.Path{}, fmt.Errorf("invalid path %s, the path must be in the /storage/<identifier> format", path)
+	}
+
+	if parts[0] != common.PathDomainStorage.Identifier() {
+		return cadence.Path{}, fmt.Errorf(
+			"invalid path %s, only values on storage paths can be read, use the target path of the capability link",
+			path,
+		)
+	}
+
+	return cadence.NewPath(parts[0], parts[1]), nil
+}
+
+// StoredValue returns the value stored on the storage path of the account, the path is in the /storage/<identifier> format.
+func (s *Scripts) StoredValue(address flow.Address, storagePath string) (cadence.Value, error) {
+	path, err := parseStoragePath(storagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.StartProgress(fmt.Sprintf("Loading %s of %s...", path, address))
+	defer s.logger.StopProgress()
+
+	value, err := s.Execute(
+		flowkit.NewScript(
+			[]byte(storedValueScript),
+			[]cadence.Value{cadence.NewAddress(address), path},
+			"",
+		),
+		"",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s of account %s: %w", path, address, err)
+	}
+
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+	if value == nil {
+		return nil, fmt.Errorf("no value is stored on %s of account %s", path, address)
+	}
+
+	return value, nil
 }
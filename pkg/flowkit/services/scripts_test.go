@@ -19,11 +19,18 @@
 package services
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
@@ -51,6 +58,274 @@ func TestScripts(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Execute Script Core Contract Import", func(t *testing.T) {
+		_, s, gw := setup()
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			assert.Contains(t, string(args.Get(0).([]byte)), "import FungibleToken from 0x9a0766d93b6608b7")
+			gw.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
+		})
+
+		code := []byte(`
+			import "FungibleToken"
+			pub fun main() {}
+		`)
+		_, err := s.Scripts.Execute(flowkit.NewScript(code, nil, "script.cdc"), "testnet")
+
+		assert.NoError(t, err)
+		gw.Mock.AssertNumberOfCalls(t, tests.ExecuteScriptFunc, 1)
+	})
+
+	t.Run("Execute Script At Height", func(t *testing.T) {
+		_, s, gw := setup()
+
+		gw.ExecuteScriptAtHeight.Run(func(args mock.Arguments) {
+			assert.Equal(t, "\"Foo\"", args.Get(1).([]cadence.Value)[0].String())
+			assert.Equal(t, uint64(100), args.Get(2).(uint64))
+			gw.ExecuteScriptAtHeight.Return(cadence.MustConvertValue(""), nil)
+		})
+
+		args := []cadence.Value{cadence.String("Foo")}
+		_, err := s.Scripts.ExecuteAtHeight(
+			flowkit.NewScript(tests.ScriptArgString.Source, args, ""),
+			"",
+			100,
+		)
+
+		assert.NoError(t, err)
+		gw.Mock.AssertCalled(t, tests.ExecuteScriptAtHeightFunc, mock.Anything, mock.Anything, uint64(100))
+		gw.Mock.AssertNotCalled(t, tests.ExecuteScriptFunc, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Execute Script At ID", func(t *testing.T) {
+		_, s, gw := setup()
+		id := flow.HexToID("a310685082f0b09f2a148b2e8905f08ea458ed873596b53b200699e8e1f6536f")
+
+		gw.ExecuteScriptAtID.Run(func(args mock.Arguments) {
+			assert.Equal(t, id, args.Get(2).(flow.Identifier))
+			gw.ExecuteScriptAtID.Return(cadence.MustConvertValue(""), nil)
+		})
+
+		args := []cadence.Value{cadence.String("Foo")}
+		_, err := s.Scripts.ExecuteAtID(
+			flowkit.NewScript(tests.ScriptArgString.Source, args, ""),
+			"",
+			id,
+		)
+
+		assert.NoError(t, err)
+		gw.Mock.AssertCalled(t, tests.ExecuteScriptAtIDFunc, mock.Anything, mock.Anything, id)
+		gw.Mock.AssertNotCalled(t, tests.ExecuteScriptFunc, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Execute Script Cached", func(t *testing.T) {
+		_, s, gw := setup()
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			gw.ExecuteScript.Return(cadence.String("latest"), nil)
+		})
+		gw.ExecuteScriptAtHeight.Run(func(args mock.Arguments) {
+			gw.ExecuteScriptAtHeight.Return(cadence.String("at height"), nil)
+		})
+
+		cache := flowkit.NewScriptCache(afero.Afero{Fs: afero.NewMemMapFs()}, flowkit.DefaultScriptCacheFile)
+		script := flowkit.NewScript(tests.ScriptArgString.Source, []cadence.Value{cadence.String("Foo")}, "")
+
+		for i := 0; i < 2; i++ {
+			value, err := s.Scripts.ExecuteCached(script, "", 0, cache, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, cadence.String("latest"), value)
+
+			value, err = s.Scripts.ExecuteCached(script, "", 100, cache, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, cadence.String("at height"), value)
+		}
+		gw.Mock.AssertNumberOfCalls(t, tests.ExecuteScriptFunc, 1)
+		gw.Mock.AssertNumberOfCalls(t, tests.ExecuteScriptAtHeightFunc, 1)
+
+		// other arguments are a different execution
+		other := flowkit.NewScript(tests.ScriptArgString.Source, []cadence.Value{cadence.String("Bar")}, "")
+		_, err := s.Scripts.ExecuteCached(other, "", 0, cache, time.Minute)
+		assert.NoError(t, err)
+		gw.Mock.AssertNumberOfCalls(t, tests.ExecuteScriptFunc, 2)
+
+		// results at the latest block aren't cached without a time to live
+		_, err = s.Scripts.ExecuteCached(script, "testnet", 0, cache, 0)
+		assert.NoError(t, err)
+		_, err = s.Scripts.ExecuteCached(script, "testnet", 0, cache, 0)
+		assert.NoError(t, err)
+		gw.Mock.AssertNumberOfCalls(t, tests.ExecuteScriptFunc, 4)
+	})
+
+	t.Run("Benchmark Script", func(t *testing.T) {
+		_, s, gw := setup()
+
+		calls := 0
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			calls++
+			if calls == 2 {
+				gw.ExecuteScript.Return(nil, fmt.Errorf("execution failed"))
+				return
+			}
+			gw.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
+		})
+
+		reported := 0
+		args := []cadence.Value{cadence.String("Foo")}
+		runs, err := s.Scripts.Benchmark(
+			flowkit.NewScript(tests.ScriptArgString.Source, args, ""),
+			"",
+			BenchmarkOptions{Runs: 3, Report: func(BenchmarkRun) { reported++ }},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, runs, 3)
+		assert.Equal(t, 3, reported)
+		assert.NoError(t, runs[0].Error)
+		assert.EqualError(t, runs[1].Error, "execution failed")
+		assert.NoError(t, runs[2].Error)
+		gw.Mock.AssertNumberOfCalls(t, tests.ExecuteScriptFunc, 3)
+
+		_, err = s.Scripts.Benchmark(
+			flowkit.NewScript(tests.ScriptArgString.Source, args, ""),
+			"",
+			BenchmarkOptions{Runs: 0},
+		)
+		assert.EqualError(t, err, "the number of runs must be at least one, not 0")
+	})
+
+	t.Run("Map Script", func(t *testing.T) {
+		_, s, gw := setup()
+
+		addresses := []flow.Address{
+			flow.HexToAddress("01"),
+			flow.HexToAddress("02"),
+			flow.HexToAddress("03"),
+		}
+
+		attempts := make(map[string]int)
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			scriptArgs := args.Get(1).([]cadence.Value)
+			assert.Len(t, scriptArgs, 2)
+			assert.Equal(t, "\"Foo\"", scriptArgs[1].String())
+
+			address := scriptArgs[0].String()
+			attempts[address]++
+			switch {
+			case address == "0x0000000000000002" && attempts[address] == 1:
+				gw.ExecuteScript.Return(nil, status.Error(codes.Unavailable, "unavailable"))
+			case address == "0x0000000000000003":
+				gw.ExecuteScript.Return(nil, fmt.Errorf("execution failed"))
+			default:
+				gw.ExecuteScript.Return(cadence.String(address), nil)
+			}
+		})
+
+		reported := 0
+		results, err := s.Scripts.Map(
+			flowkit.NewScript(tests.ScriptArgString.Source, []cadence.Value{cadence.String("Foo")}, ""),
+			addresses,
+			"",
+			MapOptions{
+				MaxRetries:   2,
+				RetryBackoff: time.Millisecond,
+				Report:       func(MapResult) { reported++ },
+			},
+		)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, 3, reported)
+		assert.Equal(t, cadence.String("0x0000000000000001"), results[0].Value)
+		assert.Equal(t, cadence.String("0x0000000000000002"), results[1].Value)
+		assert.NoError(t, results[1].Error)
+		assert.Equal(t, 2, attempts["0x0000000000000002"])
+		assert.EqualError(t, results[2].Error, "execution failed")
+		assert.Equal(t, 1, attempts["0x0000000000000003"])
+	})
+
+	t.Run("Storage Items", func(t *testing.T) {
+		_, s, gw := setup()
+
+		itemType := &cadence.StructType{
+			QualifiedIdentifier: "StorageItem",
+			Fields: []cadence.Field{
+				{Identifier: "path", Type: cadence.PathType{}},
+				{Identifier: "type", Type: cadence.MetaType{}},
+				{Identifier: "target", Type: cadence.OptionalType{Type: cadence.PathType{}}},
+			},
+		}
+		vaultType := &cadence.ResourceType{
+			Location:            common.AddressLocation{Address: common.Address(flow.HexToAddress("7e60df042a9c0868")), Name: "FlowToken"},
+			QualifiedIdentifier: "FlowToken.Vault",
+		}
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			assert.Equal(t, "0x01cf0e2f2f715450", args.Get(1).([]cadence.Value)[0].String())
+			gw.ExecuteScript.Return(cadence.NewArray([]cadence.Value{
+				cadence.NewStruct([]cadence.Value{
+					cadence.NewPath("storage", "flowTokenVault"),
+					cadence.TypeValue{StaticType: vaultType},
+					cadence.NewOptional(nil),
+				}).WithType(itemType),
+				cadence.NewStruct([]cadence.Value{
+					cadence.NewPath("public", "flowTokenReceiver"),
+					cadence.TypeValue{StaticType: cadence.CapabilityType{
+						BorrowType: cadence.ReferenceType{Type: vaultType},
+					}},
+					cadence.NewOptional(cadence.NewPath("storage", "flowTokenVault")),
+				}).WithType(itemType),
+			}), nil)
+		})
+
+		items, err := s.Scripts.StorageItems(flow.HexToAddress("01cf0e2f2f715450"))
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, "/public/flowTokenReceiver", items[0].Path.String())
+		assert.True(t, items[0].IsLink())
+		assert.Equal(t, "&A.7e60df042a9c0868.FlowToken.Vault", items[0].Type)
+		assert.Equal(t, "/storage/flowTokenVault", items[0].Target.String())
+		assert.Equal(t, "/storage/flowTokenVault", items[1].Path.String())
+		assert.False(t, items[1].IsLink())
+		assert.Equal(t, "A.7e60df042a9c0868.FlowToken.Vault", items[1].Type)
+	})
+
+	t.Run("Stored Value", func(t *testing.T) {
+		_, s, gw := setup()
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			assert.Equal(t, "/storage/flowTokenVault", args.Get(1).([]cadence.Value)[1].String())
+			gw.ExecuteScript.Return(cadence.NewOptional(cadence.String("foo")), nil)
+		})
+
+		value, err := s.Scripts.StoredValue(flow.HexToAddress("01cf0e2f2f715450"), "/storage/flowTokenVault")
+
+		assert.NoError(t, err)
+		assert.Equal(t, cadence.String("foo"), value)
+	})
+
+	t.Run("Stored Value Empty", func(t *testing.T) {
+		_, s, gw := setup()
+
+		gw.ExecuteScript.Run(func(args mock.Arguments) {
+			gw.ExecuteScript.Return(cadence.NewOptional(nil), nil)
+		})
+
+		_, err := s.Scripts.StoredValue(flow.HexToAddress("01cf0e2f2f715450"), "/storage/foo")
+
+		assert.EqualError(t, err, "no value is stored on /storage/foo of account 01cf0e2f2f715450")
+	})
+
+	t.Run("Stored Value Invalid Path", func(t *testing.T) {
+		_, s, _ := setup()
+
+		_, err := s.Scripts.StoredValue(flow.HexToAddress("01cf0e2f2f715450"), "/public/flowTokenReceiver")
+		assert.EqualError(t, err, "invalid path /public/flowTokenReceiver, only values on storage paths can be read, use the target path of the capability link")
+
+		_, err = s.Scripts.StoredValue(flow.HexToAddress("01cf0e2f2f715450"), "flowTokenVault")
+		assert.EqualError(t, err, "invalid path flowTokenVault, the path must be in the /storage/<identifier> format")
+	})
 }
 
 func TestScripts_Integration(t *testing.T) {
@@ -140,7 +415,7 @@ func TestScripts_Integration(t *testing.T) {
 		out := []string{
 			"missing network, specify which network to use to resolve imports in script code",
 			"resolving imports in scripts not supported",
-			"import ./contractHello.cdc could not be resolved from provided contracts",
+			"error resolving imports on network foo: import from scriptImport.cdc could not be found: ./contractHello.cdc (normalized: contractHello.cdc), make sure import path is correct, and the contract is added to deployments or has an alias",
 		}
 
 		for x, i := range in {
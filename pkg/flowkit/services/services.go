@@ -19,9 +19,17 @@
 package services
 
 import (
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
+	"github.com/onflow/flow-cli/pkg/flowkit/project"
 )
 
 // Services is a collection of services that provide domain-specific functionality
@@ -38,6 +46,8 @@ type Services struct {
 	Status       *Status
 	Snapshot     *Snapshot
 	Tests        *Tests
+	Faucet       *Faucet
+	Signatures   *Signatures
 }
 
 // NewServices returns a new services collection for a state,
@@ -59,6 +69,8 @@ func NewServices(
 		Status:       NewStatus(gateway, state, logger),
 		Snapshot:     NewSnapshot(gateway, state, logger),
 		Tests:        NewTests(state, logger),
+		Faucet:       NewFaucet(gateway, state, logger),
+		Signatures:   NewSignatures(state, logger),
 	}
 }
 
@@ -74,4 +86,72 @@ func (s *Services) SetLogger(logger output.Logger) {
 	s.Status.logger = logger
 	s.Snapshot.logger = logger
 	s.Tests.logger = logger
+	s.Faucet.logger = logger
+	s.Signatures.logger = logger
+}
+
+// resolveImports replaces the imports of the program by file location or contract name with the addresses
+// of the contracts deployed to the network, or the addresses of their aliases on the network.
+//
+// The imports are resolved the same way as the imports of the deployed contracts, including the
+// default aliases of the core contracts, so the same code can be used on all the networks.
+func resolveImports(
+	state *flowkit.State,
+	logger output.Logger,
+	program *project.Program,
+	network string,
+) (*project.Program, error) {
+	if state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	// the program is included so the default aliases of the core contracts it imports are used
+	importer := project.NewContract("", program.Location(), program.Code(), flow.EmptyAddress, "", nil)
+	aliases, err := networkAliases(state, logger, network, append(slices.Clone(contracts), importer), true)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err = project.NewImportReplacer(contracts, aliases, newLoader()).Replace(program)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving imports on network %s: %w", network, err)
+	}
+
+	return program, nil
+}
+
+// networkAliases returns the aliases of the contracts on the network.
+//
+// If defaults are used, the core contracts imported by the contracts which are not part of the contracts
+// and don't have an alias already are aliased to their addresses on the network.
+func networkAliases(
+	state *flowkit.State,
+	logger output.Logger,
+	network string,
+	contracts []*project.Contract,
+	defaults bool,
+) (project.Aliases, error) {
+	aliases := state.AliasesForNetwork(network)
+	if !defaults {
+		return aliases, nil
+	}
+
+	core, err := project.CoreContractAliases(network, contracts, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	names := maps.Keys(core)
+	slices.Sort(names)
+	for _, name := range names {
+		logger.Info(fmt.Sprintf("Using default alias 0x%s for core contract %s", core[name], name))
+		aliases[name] = core[name]
+	}
+
+	return aliases, nil
 }
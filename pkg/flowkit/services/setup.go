@@ -0,0 +1,176 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+)
+
+// SetupAccount is an account of the network created by the project setup.
+type SetupAccount struct {
+	Name string
+	// Address is the address of the created account, it's saved in the configuration state.
+	Address flow.Address
+	// PreviousAddress is the address of the account in the configuration before it was created.
+	PreviousAddress flow.Address
+}
+
+// SetupResult is the result of setting up the project on a network.
+type SetupResult struct {
+	// Accounts are the accounts which didn't exist on the network and were created.
+	Accounts []SetupAccount
+	// Contracts are the results of the contract deployments.
+	Contracts []ContractDeployResult
+}
+
+// SetupError is returned if any step of the project setup failed, it lists the failures.
+type SetupError struct {
+	Failures []string
+}
+
+func (e *SetupError) Error() string {
+	return fmt.Sprintf("project setup failed:\n%s", strings.Join(e.Failures, "\n"))
+}
+
+// Setup creates the accounts of the project deployments on the network and deploys the project contracts.
+//
+// The deployment accounts which don't exist on the network with their key are created by the emulator
+// service account, and their new addresses are set in the configuration state, which is not saved.
+// The contracts are deployed the same way as by the deploy command once all the accounts exist.
+//
+// The accounts created before a failure are returned together with the setup error.
+func (p *Project) Setup(ctx context.Context, network string, options DeployOptions) (*SetupResult, error) {
+	if p.state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	signer, err := p.state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SetupResult{}
+	setupErr := &SetupError{}
+	accounts := NewAccounts(p.gateway, p.state, output.NewStdoutLogger(output.NoneLog))
+
+	for _, account := range p.state.AccountsForNetwork(network) {
+		if account.Address() == signer.Address() {
+			continue
+		}
+
+		created, err := p.setupAccount(ctx, accounts, signer, &account)
+		if err != nil {
+			setupErr.Failures = append(setupErr.Failures, fmt.Sprintf("account %s: %s", account.Name(), err))
+			p.logger.Error(fmt.Sprintf("Failed to create account %s: %s", account.Name(), err))
+			continue
+		}
+		if created == nil {
+			p.logger.Info(fmt.Sprintf("Account %s exists at 0x%s", account.Name(), account.Address()))
+			continue
+		}
+
+		err = p.state.SetAccountAddress(account.Name(), network, created.Address)
+		if err != nil {
+			return result, err
+		}
+
+		result.Accounts = append(result.Accounts, SetupAccount{
+			Name:            account.Name(),
+			Address:         created.Address,
+			PreviousAddress: account.Address(),
+		})
+		p.logger.Info(fmt.Sprintf("%s Account %s created at 0x%s", output.SuccessEmoji(), account.Name(), created.Address))
+	}
+
+	if len(setupErr.Failures) > 0 {
+		return result, setupErr
+	}
+
+	_, result.Contracts, err = p.deploy(ctx, network, options)
+	var deployErr *ProjectDeploymentError
+	if errors.As(err, &deployErr) {
+		names := maps.Keys(deployErr.Contracts())
+		slices.Sort(names)
+		for _, name := range names {
+			setupErr.Failures = append(setupErr.Failures, fmt.Sprintf("contract %s: %s", name, deployErr.Contracts()[name]))
+		}
+		return result, setupErr
+	}
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// setupAccount creates the account if it doesn't exist on the network with its key, nil is returned if it exists.
+func (p *Project) setupAccount(
+	ctx context.Context,
+	accounts *Accounts,
+	signer *flowkit.Account,
+	account *flowkit.Account,
+) (*flow.Account, error) {
+	key := account.Key()
+	keySigner, err := key.Signer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	publicKey := keySigner.PublicKey()
+
+	if account.Address() != flow.EmptyAddress {
+		onChainAccount, err := p.gateway.GetAccount(account.Address())
+		if err == nil && hasKey(onChainAccount, publicKey) {
+			return nil, nil
+		}
+	}
+
+	p.logger.StartProgress(fmt.Sprintf("Creating account %s...", account.Name()))
+	defer p.logger.StopProgress()
+
+	return accounts.Create(
+		signer,
+		[]crypto.PublicKey{publicKey},
+		[]int{flow.AccountKeyWeightThreshold},
+		[]crypto.SignatureAlgorithm{key.SigAlgo()},
+		[]crypto.HashAlgorithm{key.HashAlgo()},
+		nil,
+	)
+}
+
+// hasKey returns true if the account has the public key, and the key is not revoked.
+func hasKey(account *flow.Account, publicKey crypto.PublicKey) bool {
+	for _, key := range account.Keys {
+		if key.PublicKey.Equals(publicKey) && !key.Revoked {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func TestProjectSetup_Integration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create Accounts And Deploy", func(t *testing.T) {
+		t.Parallel()
+
+		state, s := setupIntegration()
+		n := config.DefaultEmulatorNetwork()
+		state.Networks().AddOrUpdate(n.Name, n)
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+			Network:  n.Name,
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		donald := tests.Donald()
+		state.Accounts().AddOrUpdate(donald)
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   n.Name,
+			Account:   donald.Name(),
+			Contracts: []config.ContractDeployment{{Name: c.Name}},
+		})
+
+		result, err := s.Project.Setup(context.Background(), n.Name, DeployOptions{Update: true})
+		require.NoError(t, err)
+
+		require.Len(t, result.Accounts, 1)
+		assert.Equal(t, donald.Name(), result.Accounts[0].Name)
+		assert.Equal(t, flow.HexToAddress("0x3"), result.Accounts[0].PreviousAddress)
+
+		account, err := state.Accounts().ByName(donald.Name())
+		require.NoError(t, err)
+		assert.Equal(t, result.Accounts[0].Address, account.Address())
+
+		require.Len(t, result.Contracts, 1)
+		assert.NoError(t, result.Contracts[0].Error)
+		assert.Equal(t, account.Address(), result.Contracts[0].Address)
+
+		onChainAccount, err := s.Accounts.Get(account.Address())
+		require.NoError(t, err)
+		assert.Contains(t, onChainAccount.Contracts, c.Name)
+
+		// existing accounts and unchanged contracts are skipped
+		result, err = s.Project.Setup(context.Background(), n.Name, DeployOptions{Update: true})
+		require.NoError(t, err)
+		assert.Empty(t, result.Accounts)
+		require.Len(t, result.Contracts, 1)
+		assert.True(t, result.Contracts[0].Skipped)
+	})
+
+	t.Run("Fail Deploy", func(t *testing.T) {
+		t.Parallel()
+
+		state, s := setupIntegration()
+		n := config.DefaultEmulatorNetwork()
+		state.Networks().AddOrUpdate(n.Name, n)
+
+		c := config.Contract{
+			Name:     "Failing",
+			Location: "failing.cdc",
+			Network:  n.Name,
+		}
+		state.Contracts().AddOrUpdate(c.Name, c)
+
+		code := []byte(`pub contract Failing { init() { panic("failed") } }`)
+		err := state.ReaderWriter().WriteFile(c.Location, code, 0644)
+		require.NoError(t, err)
+
+		srvAcc, _ := state.EmulatorServiceAccount()
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   n.Name,
+			Account:   srvAcc.Name(),
+			Contracts: []config.ContractDeployment{{Name: c.Name}},
+		})
+
+		result, err := s.Project.Setup(context.Background(), n.Name, DeployOptions{Update: true})
+		var setupErr *SetupError
+		require.ErrorAs(t, err, &setupErr)
+		require.Len(t, setupErr.Failures, 1)
+		assert.Contains(t, setupErr.Failures[0], "contract "+c.Name)
+		assert.Empty(t, result.Accounts)
+		require.Len(t, result.Contracts, 1)
+		assert.Error(t, result.Contracts[0].Error)
+	})
+}
@@ -0,0 +1,132 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/output"
+)
+
+// Encodings of the messages passed to the signature commands.
+const (
+	MessageEncodingUTF8   = "utf8"
+	MessageEncodingHex    = "hex"
+	MessageEncodingBase64 = "base64"
+)
+
+// Signatures is a service that signs and verifies user messages.
+type Signatures struct {
+	state  *flowkit.State
+	logger output.Logger
+}
+
+// NewSignatures returns a new signatures service.
+func NewSignatures(
+	state *flowkit.State,
+	logger output.Logger,
+) *Signatures {
+	return &Signatures{
+		state:  state,
+		logger: logger,
+	}
+}
+
+// Signature is a user message signature created with an account key.
+type Signature struct {
+	Signature []byte
+	KeyIndex  int
+	PublicKey crypto.PublicKey
+	SigAlgo   crypto.SignatureAlgorithm
+	HashAlgo  crypto.HashAlgorithm
+}
+
+// DecodeMessage decodes the message in the encoding, UTF-8 messages are signed as they are.
+func DecodeMessage(message string, encoding string) ([]byte, error) {
+	switch encoding {
+	case MessageEncodingUTF8, "":
+		return []byte(message), nil
+	case MessageEncodingHex:
+		decoded, err := hex.DecodeString(strings.TrimPrefix(message, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex message: %w", err)
+		}
+		return decoded, nil
+	case MessageEncodingBase64:
+		decoded, err := base64.StdEncoding.DecodeString(message)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 message: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf(
+			"invalid message encoding %s, must be %s, %s or %s",
+			encoding,
+			MessageEncodingUTF8,
+			MessageEncodingHex,
+			MessageEncodingBase64,
+		)
+	}
+}
+
+// Sign signs the message with the key of the account, prefixed with the user domain tag
+// so the signature can be verified in Cadence and can't be used as a transaction signature.
+func (s *Signatures) Sign(account *flowkit.Account, message []byte) (*Signature, error) {
+	key := account.Key()
+	signer, err := key.Signer(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := flow.SignUserMessage(signer, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the message: %w", err)
+	}
+
+	return &Signature{
+		Signature: signature,
+		KeyIndex:  key.Index(),
+		PublicKey: signer.PublicKey(),
+		SigAlgo:   key.SigAlgo(),
+		HashAlgo:  key.HashAlgo(),
+	}, nil
+}
+
+// Verify checks the signature of the message prefixed with the user domain tag was created by the public key.
+func (s *Signatures) Verify(
+	message []byte,
+	signature []byte,
+	publicKey crypto.PublicKey,
+	hashAlgo crypto.HashAlgorithm,
+) (bool, error) {
+	hasher, err := crypto.NewHasher(hashAlgo)
+	if err != nil {
+		return false, err
+	}
+
+	return publicKey.Verify(signature, append(flow.UserDomainTag[:], message...), hasher)
+}
@@ -0,0 +1,112 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Sign and verify message", func(t *testing.T) {
+		t.Parallel()
+
+		state, s, _ := setup()
+		serviceAcc, err := state.EmulatorServiceAccount()
+		require.NoError(t, err)
+
+		message := []byte("The quick brown fox jumps over the lazy dog")
+		signature, err := s.Signatures.Sign(serviceAcc, message)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, signature.KeyIndex)
+		assert.Equal(t, crypto.ECDSA_P256, signature.SigAlgo)
+		assert.Equal(t, crypto.SHA3_256, signature.HashAlgo)
+
+		valid, err := s.Signatures.Verify(message, signature.Signature, signature.PublicKey, signature.HashAlgo)
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		// the signature is created over the message with the user domain tag
+		hasher, err := crypto.NewHasher(crypto.SHA3_256)
+		require.NoError(t, err)
+		valid, err = signature.PublicKey.Verify(signature.Signature, message, hasher)
+		require.NoError(t, err)
+		assert.False(t, valid)
+
+		valid, err = signature.PublicKey.Verify(signature.Signature, append(flow.UserDomainTag[:], message...), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = s.Signatures.Verify([]byte("other message"), signature.Signature, signature.PublicKey, signature.HashAlgo)
+		require.NoError(t, err)
+		assert.False(t, valid)
+
+		valid, err = s.Signatures.Verify(message, signature.Signature, signature.PublicKey, crypto.SHA2_256)
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("Verify with SHA2", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, _ := setup()
+		privateKey, err := s.Keys.Generate("", crypto.ECDSA_secp256k1)
+		require.NoError(t, err)
+
+		signer, err := crypto.NewInMemorySigner(privateKey, crypto.SHA2_256)
+		require.NoError(t, err)
+
+		message := []byte{0x00, 0xff, 0x10}
+		signature, err := flow.SignUserMessage(signer, message)
+		require.NoError(t, err)
+
+		valid, err := s.Signatures.Verify(message, signature, privateKey.PublicKey(), crypto.SHA2_256)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Decode message", func(t *testing.T) {
+		t.Parallel()
+
+		messages := map[string]string{
+			MessageEncodingUTF8:   "hello",
+			MessageEncodingHex:    "0x68656c6c6f",
+			MessageEncodingBase64: "aGVsbG8=",
+		}
+
+		for encoding, message := range messages {
+			decoded, err := DecodeMessage(message, encoding)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("hello"), decoded)
+		}
+
+		_, err := DecodeMessage("zz", MessageEncodingHex)
+		assert.EqualError(t, err, "invalid hex message: encoding/hex: invalid byte: U+007A 'z'")
+
+		_, err = DecodeMessage("hello", "utf16")
+		assert.EqualError(t, err, "invalid message encoding utf16, must be utf8, hex or base64")
+	})
+}
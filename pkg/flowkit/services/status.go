@@ -19,7 +19,12 @@
 package services
 
 import (
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 )
@@ -57,3 +62,77 @@ func (s *Status) Ping(network string) (string, error) {
 
 	return n.Host, nil
 }
+
+// NetworkStatus is the status of the access node of a network.
+type NetworkStatus struct {
+	AccessNode string
+	// Latency of the ping of the access node.
+	Latency time.Duration
+	// ChainID of the network parameters of the access node.
+	ChainID flow.ChainID
+	// ExpectedChainID is the chain of the network name, empty for custom networks.
+	ExpectedChainID flow.ChainID
+	SealedBlock     *flow.Block
+	FinalizedBlock  *flow.Block
+}
+
+// ChainMismatch returns true if the chain of the access node is not the chain of the network name,
+// for example if the testnet network is configured with a mainnet access node.
+func (n *NetworkStatus) ChainMismatch() bool {
+	return n.ExpectedChainID != "" && n.ChainID != n.ExpectedChainID
+}
+
+// Check returns the status of the access node of the network, with the latency of a ping, the chain
+// of the access node, and the latest sealed and finalized blocks.
+//
+// An error is returned with the status if the access node is unreachable.
+func (s *Status) Check(network string) (*NetworkStatus, error) {
+	n, err := s.state.Networks().ByName(network)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &NetworkStatus{
+		AccessNode:      n.Host,
+		ExpectedChainID: networkChainID(network),
+	}
+
+	start := time.Now()
+	err = s.gateway.Ping()
+	if err != nil {
+		return status, err
+	}
+	status.Latency = time.Since(start)
+
+	status.ChainID, err = s.gateway.GetChainID()
+	if err != nil {
+		return status, err
+	}
+
+	status.SealedBlock, err = s.gateway.GetLatestBlock()
+	if err != nil {
+		return status, err
+	}
+
+	status.FinalizedBlock, err = s.gateway.GetLatestFinalizedBlock()
+	if err != nil {
+		return status, err
+	}
+
+	return status, nil
+}
+
+// networkChainID returns the chain of the network with the name of a default network,
+// the chain of custom networks can't be determined.
+func networkChainID(name string) flow.ChainID {
+	switch name {
+	case config.DefaultEmulatorNetwork().Name:
+		return flow.Emulator
+	case config.DefaultTestnetNetwork().Name:
+		return flow.Testnet
+	case config.DefaultMainnetNetwork().Name:
+		return flow.Mainnet
+	}
+
+	return ""
+}
@@ -0,0 +1,78 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/tests"
+)
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Check", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		final := tests.NewBlock()
+		final.Height = 2
+		gw.GetLatestFinalizedBlock.Return(final, nil)
+
+		status, err := s.Status.Check("emulator")
+
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:3569", status.AccessNode)
+		assert.Equal(t, flow.Emulator, status.ChainID)
+		assert.False(t, status.ChainMismatch())
+		assert.Equal(t, uint64(1), status.SealedBlock.Height)
+		assert.Equal(t, uint64(2), status.FinalizedBlock.Height)
+	})
+
+	t.Run("Check Chain Mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		gw.GetChainID.Return(flow.Mainnet, nil)
+
+		status, err := s.Status.Check("emulator")
+
+		require.NoError(t, err)
+		assert.Equal(t, flow.Emulator, status.ExpectedChainID)
+		assert.True(t, status.ChainMismatch())
+	})
+
+	t.Run("Check Unreachable", func(t *testing.T) {
+		t.Parallel()
+
+		_, s, gw := setup()
+		gw.Ping.Return(fmt.Errorf("connection refused"))
+
+		status, err := s.Status.Check("emulator")
+
+		assert.EqualError(t, err, "connection refused")
+		assert.Equal(t, "127.0.0.1:3569", status.AccessNode)
+		gw.Mock.AssertNotCalled(t, tests.GetChainIDFunc)
+	})
+}
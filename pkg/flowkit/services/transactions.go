@@ -21,13 +21,23 @@ package services
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"google.golang.org/grpc/codes"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/gateway"
 	"github.com/onflow/flow-cli/pkg/flowkit/output"
 	"github.com/onflow/flow-cli/pkg/flowkit/project"
@@ -142,10 +152,16 @@ func (t *transactionAccountRoles) toAddresses() *transactionAddresses {
 }
 
 // getSigners for signing the transaction, detect if all accounts are same so only return the one account.
+//
+// The proposer and the authorizers sign the payload, and the payer signs the envelope last,
+// so the payer is only included once at the end even if it also has the other roles.
 func (t *transactionAccountRoles) getSigners() []*flowkit.Account {
 	// build only unique accounts to sign, it's important payer account is last
 	sigs := make([]*flowkit.Account, 0)
 	addLastIfUnique := func(signer *flowkit.Account) {
+		if signer.Address() == t.payer.Address() {
+			return
+		}
 		for _, sig := range sigs {
 			if sig.Address() == signer.Address() {
 				return
@@ -158,9 +174,8 @@ func (t *transactionAccountRoles) getSigners() []*flowkit.Account {
 	for _, auth := range t.authorizers {
 		addLastIfUnique(auth)
 	}
-	addLastIfUnique(t.payer)
 
-	return sigs
+	return append(sigs, t.payer)
 }
 
 // NewTransactionAddresses defines transaction roles by account addresses.
@@ -224,20 +239,10 @@ func (t *Transactions) Build(
 			return nil, fmt.Errorf("resolving imports in transactions not supported")
 		}
 
-		contracts, err := t.state.DeploymentContractsByNetwork(network)
+		program, err = resolveImports(t.state, t.logger, program, network)
 		if err != nil {
 			return nil, err
 		}
-
-		importReplacer := project.NewImportReplacer(
-			contracts,
-			t.state.AliasesForNetwork(network),
-		)
-
-		program, err = importReplacer.Replace(program)
-		if err != nil {
-			return nil, fmt.Errorf("error resolving imports: %w", err)
-		}
 	}
 
 	if err := tx.SetProposer(proposerAccount, proposerKeyIndex); err != nil {
@@ -257,9 +262,23 @@ func (t *Transactions) Build(
 }
 
 // Sign transaction payload using the signer account.
+//
+// The signer signs the envelope if it's the payer of the transaction, and the payload otherwise.
 func (t *Transactions) Sign(
 	signer *flowkit.Account,
 	payload []byte,
+) (*flowkit.Transaction, error) {
+	return t.SignWithRole(signer, payload, flowkit.SigningRoleAuto)
+}
+
+// SignWithRole signs the transaction payload using the signer account, which must have the role in the transaction.
+//
+// The payer signs the envelope and the other roles sign the payload, so the transaction
+// must be signed by the proposer and the authorizers before it's signed by the payer.
+func (t *Transactions) SignWithRole(
+	signer *flowkit.Account,
+	payload []byte,
+	role flowkit.SigningRole,
 ) (*flowkit.Transaction, error) {
 	if t.state == nil {
 		return nil, fmt.Errorf("missing configuration, initialize it: flow state init")
@@ -270,7 +289,7 @@ func (t *Transactions) Sign(
 		return nil, err
 	}
 
-	err = tx.SetSigner(signer)
+	err = tx.SetSignerWithRole(signer, role)
 	if err != nil {
 		return nil, err
 	}
@@ -279,7 +298,13 @@ func (t *Transactions) Sign(
 }
 
 // SendSigned sends the transaction that is already signed.
+//
+// The transaction is only sent if it's signed by the proposer, the authorizers and the payer.
 func (t *Transactions) SendSigned(tx *flowkit.Transaction) (*flow.Transaction, *flow.TransactionResult, error) {
+	if missing := tx.MissingSignatures(); len(missing) > 0 {
+		return nil, nil, fmt.Errorf("transaction is missing the signatures of %s", missing)
+	}
+
 	t.logger.StartProgress(fmt.Sprintf("Sending transaction with ID: %s", tx.FlowTransaction().ID()))
 	defer t.logger.StopProgress()
 
@@ -296,17 +321,138 @@ func (t *Transactions) SendSigned(tx *flowkit.Transaction) (*flow.Transaction, *
 	return sentTx, res, nil
 }
 
+// WaitOptions control waiting for the result of a sent transaction.
+type WaitOptions struct {
+	// Status the transaction must reach, the transaction is waited to be sealed if unknown.
+	Status flow.TransactionStatus
+	// Timeout of waiting for the status, there is no timeout if zero.
+	Timeout time.Duration
+	// NoWait returns right after the transaction is sent, without the transaction result.
+	NoWait bool
+}
+
+// DefaultWaitOptions waits for the transaction to be sealed without a timeout.
+var DefaultWaitOptions = WaitOptions{Status: flow.TransactionStatusSealed}
+
+// SendOptions control sending a transaction and waiting for its result.
+type SendOptions struct {
+	WaitOptions
+	// Retries is the number of times the transaction is sent again if sending it fails with a transient error.
+	Retries int
+}
+
+// statusPollInterval is how often the transaction result is fetched while waiting for the status.
+var statusPollInterval = time.Second
+
+// retryBackoff is the delay before sending a transaction again, doubled for each following retry.
+var retryBackoff = time.Second
+
 // Send a transaction code using the signer account and arguments for the specified network.
+//
+// The transaction result is returned once the transaction is sealed.
 func (t *Transactions) Send(
 	accounts *transactionAccountRoles,
 	script *flowkit.Script,
 	gasLimit uint64,
 	network string,
+) (*flow.Transaction, *flow.TransactionResult, error) {
+	return t.SendWithOptions(accounts, script, gasLimit, network, SendOptions{WaitOptions: DefaultWaitOptions})
+}
+
+// SendWithOptions sends a transaction code like Send and waits for the result as specified by the options.
+//
+// If sending the transaction fails because the access node is unavailable or too slow, the transaction is sent
+// again unless the network already knows it. If the sequence number of the proposal key doesn't match, the transaction
+// is built and signed again with the current sequence number before it's sent again.
+//
+// The sent transaction is returned along the error if waiting for the status fails, so its ID can be reported.
+func (t *Transactions) SendWithOptions(
+	accounts *transactionAccountRoles,
+	script *flowkit.Script,
+	gasLimit uint64,
+	network string,
+	options SendOptions,
 ) (*flow.Transaction, *flow.TransactionResult, error) {
 	if t.state == nil {
 		return nil, nil, fmt.Errorf("missing configuration, initialize it: flow state init")
 	}
 
+	tx, err := t.buildAndSign(accounts, script, gasLimit, network)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	backoff := retryBackoff
+	retry := func(reason string, rebuild bool) error {
+		t.logger.Info(fmt.Sprintf("Retrying transaction in %s, %s", backoff, reason))
+		time.Sleep(backoff)
+		backoff *= 2
+
+		if !rebuild {
+			return nil
+		}
+
+		rebuilt, err := t.buildAndSign(accounts, script, gasLimit, network)
+		if err != nil {
+			return err
+		}
+		tx = rebuilt
+		return nil
+	}
+
+	for attempt := 0; ; attempt++ {
+		id := tx.FlowTransaction().ID()
+		t.logger.Info(fmt.Sprintf("Transaction ID: %s", id))
+		t.logger.StartProgress("Sending transaction...")
+
+		sentTx, err := t.gateway.SendSignedTransaction(tx)
+		t.logger.StopProgress()
+		if err != nil {
+			reason, rebuild := retryReason(err)
+			if reason == "" || attempt >= options.Retries {
+				return nil, nil, err
+			}
+
+			// the transaction could reach the network even if sending it failed, so it's only sent again if it's unknown
+			if _, getErr := t.gateway.GetTransaction(id); getErr != nil {
+				if err := retry(reason, rebuild); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+
+			t.logger.Info(fmt.Sprintf("Transaction %s was received by the network, it's not sent again", id))
+			sentTx = tx.FlowTransaction()
+		}
+
+		if options.NoWait {
+			return sentTx, nil, nil
+		}
+
+		res, err := t.WaitForStatus(sentTx.ID(), options.WaitOptions)
+		if err != nil {
+			return sentTx, nil, err
+		}
+
+		// the transaction failed without any effect, so it can be built again with the current sequence number
+		if res.Error != nil && isSequenceNumberMismatch(res.Error) && attempt < options.Retries {
+			if err := retry("the sequence number of the proposal key doesn't match", true); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+
+		return sentTx, res, nil
+	}
+}
+
+// buildAndSign builds the transaction and signs it with the accounts, the payer signs last.
+func (t *Transactions) buildAndSign(
+	accounts *transactionAccountRoles,
+	script *flowkit.Script,
+	gasLimit uint64,
+	network string,
+) (*flowkit.Transaction, error) {
 	tx, err := t.Build(
 		accounts.toAddresses(),
 		accounts.proposer.Key().Index(),
@@ -315,36 +461,560 @@ func (t *Transactions) Send(
 		network,
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	for _, signer := range accounts.getSigners() {
-		err = tx.SetSigner(signer)
+	return signTransaction(tx, accounts.getSigners())
+}
+
+// signTransaction signs the transaction with the signers in order.
+func signTransaction(tx *flowkit.Transaction, signers []*flowkit.Account) (*flowkit.Transaction, error) {
+	for _, signer := range signers {
+		err := tx.SetSigner(signer)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		tx, err = tx.Sign()
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
 
-	t.logger.Info(fmt.Sprintf("Transaction ID: %s", tx.FlowTransaction().ID()))
-	t.logger.StartProgress("Sending transaction...")
+	return tx, nil
+}
 
-	sentTx, err := t.gateway.SendSignedTransaction(tx)
+// retryReason returns why sending the transaction can be retried, or an empty reason if it can't,
+// and whether the transaction must be built again with the current sequence number of the proposal key.
+func retryReason(err error) (string, bool) {
+	if isSequenceNumberMismatch(err) {
+		return "the sequence number of the proposal key doesn't match", true
+	}
+
+	if errors.Is(err, gateway.ErrTimeout) {
+		return "the request timed out", false
+	}
+
+	switch gateway.GRPCCode(err) {
+	case codes.Unavailable:
+		return "the access node is unavailable", false
+	case codes.DeadlineExceeded:
+		return "the request deadline was exceeded", false
+	}
+
+	return "", false
+}
+
+// isSequenceNumberMismatch returns true if the transaction failed because the sequence number of the proposal key
+// is not the current one, which happens when transactions proposed with the same key race.
+func isSequenceNumberMismatch(err error) bool {
+	return sequenceNumberMismatch.MatchString(err.Error())
+}
+
+// sequenceNumberMismatch matches the errors of the access nodes and the execution of transactions with an invalid sequence number.
+var sequenceNumberMismatch = regexp.MustCompile(`sequence number mismatch|has sequence number \d+, but given \d+`)
+
+// WaitForStatus polls the transaction result until the transaction reaches the status of the wait options.
+//
+// Each change of the transaction status is logged with the time of the change. An error is returned
+// if the transaction expires or doesn't reach the status before the timeout.
+func (t *Transactions) WaitForStatus(id flow.Identifier, wait WaitOptions) (*flow.TransactionResult, error) {
+	status := wait.Status
+	if status == flow.TransactionStatusUnknown {
+		status = flow.TransactionStatusSealed
+	}
+
+	var deadline <-chan time.Time
+	if wait.Timeout > 0 {
+		timer := time.NewTimer(wait.Timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	last := flow.TransactionStatusUnknown
+	for {
+		result, err := t.gateway.GetTransactionResult(id, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.Status != last {
+			last = result.Status
+			t.logger.Info(fmt.Sprintf(
+				"%s Transaction %s is %s",
+				time.Now().Format("15:04:05"),
+				id,
+				strings.ToLower(last.String()),
+			))
+		}
+
+		if last == flow.TransactionStatusExpired {
+			return nil, fmt.Errorf("transaction %s expired before it was %s", id, strings.ToLower(status.String()))
+		}
+		if last >= status {
+			return result, nil
+		}
+
+		select {
+		case <-deadline:
+			return nil, fmt.Errorf(
+				"transaction %s is not %s after %s, the last status is %s",
+				id,
+				strings.ToLower(status.String()),
+				wait.Timeout,
+				strings.ToLower(last.String()),
+			)
+		case <-time.After(statusPollInterval):
+		}
+	}
+}
+
+// BatchTransaction is a transaction of a batch sent with SendBatch.
+type BatchTransaction struct {
+	Script   *flowkit.Script
+	Signer   *flowkit.Account
+	GasLimit uint64
+	// DependsOn is the index of a previous transaction which must succeed before the transaction is sent,
+	// the transaction doesn't depend on another transaction if it's negative.
+	DependsOn int
+}
+
+// BatchResult is the outcome of a transaction of a batch.
+type BatchResult struct {
+	Index int
+	// ID of the sent transaction, empty if the transaction wasn't sent.
+	ID     flow.Identifier
+	Result *flow.TransactionResult
+	// Error of sending the transaction or waiting for its result.
+	Error error
+	// Skipped is set if the transaction wasn't sent because the transaction it depends on failed.
+	Skipped bool
+}
+
+// Failed reports if the transaction wasn't sealed without an error.
+func (r BatchResult) Failed() bool {
+	return r.Error != nil || r.Result == nil || r.Result.Error != nil
+}
+
+// BatchOptions control sending a batch of transactions.
+type BatchOptions struct {
+	// Start is the index of the first transaction sent, the transactions before it are considered already sent.
+	Start int
+	// Parallelism is the maximum number of independent transactions sent at once. If it's at most one, the
+	// transactions are sent one by one in order, and each transaction depends on the previous transaction.
+	Parallelism int
+	// Report is called with the result of each transaction once it's known.
+	Report func(BatchResult)
+}
+
+// SendBatch sends the transactions of the batch in order and waits for them to be sealed.
+//
+// A transaction is sent after the transaction it depends on is sealed, and it's skipped if that transaction failed.
+// The sequence numbers of the proposal keys are assigned by the batch, so the transactions of the same signer
+// can be sent before the previous transactions of the signer are sealed.
+func (t *Transactions) SendBatch(
+	transactions []BatchTransaction,
+	network string,
+	options BatchOptions,
+) ([]BatchResult, error) {
+	if t.state == nil {
+		return nil, fmt.Errorf("missing configuration, initialize it: flow state init")
+	}
+	if options.Start < 0 || options.Start > len(transactions) {
+		return nil, fmt.Errorf("start index %d is out of the range of the %d transactions", options.Start, len(transactions))
+	}
+
+	parallelism := options.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	dependencies := make([]int, len(transactions))
+	for i, transaction := range transactions {
+		if transaction.DependsOn >= i {
+			return nil, fmt.Errorf("transaction %d can only depend on a previous transaction, not on %d", i, transaction.DependsOn)
+		}
+
+		dependencies[i] = transaction.DependsOn
+		if parallelism == 1 && i > 0 {
+			dependencies[i] = i - 1
+		}
+	}
+
+	results := make([]BatchResult, len(transactions))
+	done := make([]chan struct{}, len(transactions))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	sequences := newSequenceNumbers()
+	slots := make(chan struct{}, parallelism)
+	var reportLock sync.Mutex
+	var wg sync.WaitGroup
+
+	// the transactions are started in order, so the transaction a transaction depends on is always started before it
+	for i := options.Start; i < len(transactions); i++ {
+		slots <- struct{}{}
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			defer close(done[i])
+
+			dependency := dependencies[i]
+			if dependency >= options.Start {
+				<-done[dependency]
+			}
+
+			if dependency >= options.Start && results[dependency].Failed() {
+				results[i] = BatchResult{
+					Index:   i,
+					Error:   fmt.Errorf("transaction %d it depends on failed", dependency),
+					Skipped: true,
+				}
+			} else {
+				results[i] = t.sendBatchTransaction(i, transactions[i], network, sequences)
+			}
+
+			if options.Report != nil {
+				reportLock.Lock()
+				options.Report(results[i])
+				reportLock.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results[options.Start:], nil
+}
+
+// sendBatchTransaction sends the transaction of a batch with the sequence number assigned by the batch.
+func (t *Transactions) sendBatchTransaction(
+	index int,
+	transaction BatchTransaction,
+	network string,
+	sequences *sequenceNumbers,
+) BatchResult {
+	result := BatchResult{Index: index}
+	signer := transaction.Signer
+	key := fmt.Sprintf("%s/%d", signer.Address(), signer.Key().Index())
+
+	// the proposal key is locked until the transaction is sent, so the transactions are sent in the sequence order
+	unlock := sequences.lock(key)
+
+	tx, err := t.Build(
+		NewSingleTransactionAccount(signer).toAddresses(),
+		signer.Key().Index(),
+		transaction.Script,
+		transaction.GasLimit,
+		network,
+	)
+	if err != nil {
+		unlock()
+		result.Error = err
+		return result
+	}
+
+	proposalKey := tx.FlowTransaction().ProposalKey
+	sequence := sequences.next(key, proposalKey.SequenceNumber)
+	tx.FlowTransaction().SetProposalKey(proposalKey.Address, proposalKey.KeyIndex, sequence)
+
+	tx, err = signTransaction(tx, []*flowkit.Account{signer})
+	if err != nil {
+		unlock()
+		result.Error = err
+		return result
+	}
+
+	_, err = t.gateway.SendSignedTransaction(tx)
+	if err != nil {
+		// the sequence number isn't used, so it's fetched again for the next transaction of the key
+		sequences.reset(key)
+		unlock()
+		result.Error = err
+		return result
+	}
+	sequences.advance(key, sequence)
+	unlock()
+
+	result.ID = tx.FlowTransaction().ID()
+	result.Result, result.Error = t.WaitForStatus(result.ID, DefaultWaitOptions)
+	if result.Result != nil && result.Result.Error != nil && isSequenceNumberMismatch(result.Result.Error) {
+		sequences.reset(key)
+	}
+
+	return result
+}
+
+// sequenceNumbers assigns the sequence numbers of the proposal keys of a batch, identified by the address and the key index.
+type sequenceNumbers struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+	// numbers are the next sequence numbers of the keys, a key without a number uses the number fetched from the network.
+	numbers map[string]uint64
+}
+
+func newSequenceNumbers() *sequenceNumbers {
+	return &sequenceNumbers{
+		locks:   make(map[string]*sync.Mutex),
+		numbers: make(map[string]uint64),
+	}
+}
+
+// lock locks the key and returns the function unlocking it.
+func (s *sequenceNumbers) lock(key string) func() {
+	s.mu.Lock()
+	lock, ok := s.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.locks[key] = lock
+	}
+	s.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// next returns the next sequence number of the key, or the fetched sequence number if the key doesn't have one.
+func (s *sequenceNumbers) next(key string, fetched uint64) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if number, ok := s.numbers[key]; ok {
+		return number
+	}
+	return fetched
+}
+
+// advance sets the next sequence number of the key after the used sequence number.
+func (s *sequenceNumbers) advance(key string, used uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.numbers[key] = used + 1
+}
+
+// reset makes the key use the sequence number fetched from the network.
+func (s *sequenceNumbers) reset(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.numbers, key)
+}
+
+// Replay sends the code and the arguments of a transaction of the network again, so for example a sealed mainnet
+// transaction can be reproduced on the emulator the service is connected to.
+//
+// The imports of the core contracts from their addresses on the network are replaced with their addresses on the
+// emulator, and the emulator service account is the proposer, the payer and all the authorizers of the transaction.
+func (t *Transactions) Replay(tx *flow.Transaction, network string) (*flow.Transaction, *flow.TransactionResult, error) {
+	if t.state == nil {
+		return nil, nil, fmt.Errorf("missing configuration, initialize it: flow state init")
+	}
+
+	serviceAccount, err := t.state.EmulatorServiceAccount()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	t.logger.StopProgress()
-	t.logger.StartProgress("Waiting for transaction to be sealed...")
+	args := make([]cadence.Value, len(tx.Arguments))
+	for i, argument := range tx.Arguments {
+		args[i], err = jsoncdc.Decode(nil, argument)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode argument %d: %w", i, err)
+		}
+	}
+
+	program, err := project.NewProgram(flowkit.NewScript(tx.Script, args, ""))
+	if err != nil {
+		return nil, nil, err
+	}
+	project.ReplaceCoreContractImports(program, network, config.DefaultEmulatorNetwork().Name)
+
+	authorizers := make([]*flowkit.Account, len(tx.Authorizers))
+	for i := range authorizers {
+		authorizers[i] = serviceAccount
+	}
+
+	roles, err := NewTransactionAccountRoles(serviceAccount, serviceAccount, authorizers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return t.SendWithOptions(
+		roles,
+		flowkit.NewScript(program.Code(), args, ""),
+		tx.GasLimit,
+		config.DefaultEmulatorNetwork().Name,
+		SendOptions{WaitOptions: DefaultWaitOptions},
+	)
+}
+
+// maxGasLimit is the maximum gas limit of a transaction, used to estimate the computation without limiting it.
+const maxGasLimit = 9999
+
+// Estimate is the computation used by a transaction executed on the emulator without committing it.
+type Estimate struct {
+	ComputationUsed uint64
+	// Error of the transaction execution, in which case the computation is only used up to the error.
+	Error error
+}
+
+// SuggestedGasLimit returns the estimated computation with a margin of 20%.
+func (e *Estimate) SuggestedGasLimit() uint64 {
+	return (e.ComputationUsed*6 + 4) / 5
+}
+
+// Estimate executes the transaction code without committing it, to estimate the computation it uses.
+//
+// The transaction is executed on the emulator gateway if it's used, otherwise on a new in-memory emulator.
+// The imports are resolved for the emulator network and the emulator service account has all the roles.
+func (t *Transactions) Estimate(script *flowkit.Script) (*Estimate, error) {
+	if t.state == nil {
+		return nil, fmt.Errorf("missing configuration, initialize it: flow state init")
+	}
+
+	serviceAccount, err := t.state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	emulatorGateway, ok := t.gateway.(*gateway.EmulatorGateway)
+	if !ok {
+		emulatorGateway = gateway.NewEmulatorGateway(serviceAccount)
+	}
+
+	required, err := flowkit.RequiredAuthorizers(script.Code())
+	if err != nil {
+		return nil, err
+	}
+	authorizers := make([]*flowkit.Account, required)
+	for i := range authorizers {
+		authorizers[i] = serviceAccount
+	}
+
+	roles, err := NewTransactionAccountRoles(serviceAccount, serviceAccount, authorizers)
+	if err != nil {
+		return nil, err
+	}
+
+	emulatorTransactions := NewTransactions(emulatorGateway, t.state, t.logger)
+	tx, err := emulatorTransactions.Build(
+		roles.toAddresses(),
+		serviceAccount.Key().Index(),
+		script,
+		maxGasLimit,
+		config.DefaultEmulatorNetwork().Name,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.SetSigner(serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err = tx.Sign()
+	if err != nil {
+		return nil, err
+	}
+
+	t.logger.StartProgress("Estimating transaction computation...")
 	defer t.logger.StopProgress()
 
-	res, err := t.gateway.GetTransactionResult(sentTx.ID(), true)
+	result, err := emulatorGateway.DryRunTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Estimate{
+		ComputationUsed: result.ComputationUsed,
+		Error:           result.Error,
+	}, nil
+}
+
+// SignatureVerification is the result of verifying a transaction signature with the key of the signing account.
+type SignatureVerification struct {
+	flow.TransactionSignature
+	// Envelope is true for envelope signatures and false for payload signatures.
+	Envelope bool
+	Valid    bool
+	// Reason why the signature is invalid.
+	Reason string
+}
+
+// VerifySignatures verifies the payload and envelope signatures of the transaction
+// with the keys of the signing accounts fetched from the network.
+func (t *Transactions) VerifySignatures(tx *flow.Transaction) ([]SignatureVerification, error) {
+	accounts := make(map[flow.Address]*flow.Account)
+	account := func(address flow.Address) (*flow.Account, error) {
+		if _, ok := accounts[address]; !ok {
+			acc, err := t.gateway.GetAccount(address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get account 0x%s: %w", address, err)
+			}
+			accounts[address] = acc
+		}
+		return accounts[address], nil
+	}
+
+	verify := func(signature flow.TransactionSignature, message []byte) (SignatureVerification, error) {
+		verification := SignatureVerification{TransactionSignature: signature}
+
+		acc, err := account(signature.Address)
+		if err != nil {
+			return verification, err
+		}
+
+		if signature.KeyIndex < 0 || signature.KeyIndex >= len(acc.Keys) {
+			verification.Reason = fmt.Sprintf("account 0x%s has no key at index %d", signature.Address, signature.KeyIndex)
+			return verification, nil
+		}
+
+		key := acc.Keys[signature.KeyIndex]
+		if key.Revoked {
+			verification.Reason = fmt.Sprintf("key at index %d is revoked", signature.KeyIndex)
+			return verification, nil
+		}
+
+		hasher, err := crypto.NewHasher(key.HashAlgo)
+		if err != nil {
+			return verification, err
+		}
+
+		valid, err := key.PublicKey.Verify(signature.Signature, append(flow.TransactionDomainTag[:], message...), hasher)
+		if err != nil {
+			return verification, err
+		}
+
+		verification.Valid = valid
+		if !valid {
+			verification.Reason = fmt.Sprintf("signature doesn't match the key at index %d", signature.KeyIndex)
+		}
+		return verification, nil
+	}
+
+	verifications := make([]SignatureVerification, 0, len(tx.PayloadSignatures)+len(tx.EnvelopeSignatures))
+	for _, signature := range tx.PayloadSignatures {
+		verification, err := verify(signature, tx.PayloadMessage())
+		if err != nil {
+			return nil, err
+		}
+		verifications = append(verifications, verification)
+	}
+
+	for _, signature := range tx.EnvelopeSignatures {
+		verification, err := verify(signature, tx.EnvelopeMessage())
+		if err != nil {
+			return nil, err
+		}
+		verification.Envelope = true
+		verifications = append(verifications, verification)
+	}
 
-	return sentTx, res, err
+	return verifications, nil
 }
 
 func (t *Transactions) GetRLP(rlpUrl string) ([]byte, error) {
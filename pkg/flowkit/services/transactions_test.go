@@ -19,9 +19,11 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
@@ -29,6 +31,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow-cli/pkg/flowkit"
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
@@ -97,6 +101,513 @@ func TestTransactions(t *testing.T) {
 
 }
 
+func TestTransactionsSendRoles(t *testing.T) {
+	t.Parallel()
+
+	const threeAuthTransaction = `transaction { prepare(a: AuthAccount, b: AuthAccount, c: AuthAccount) {} }`
+
+	alice, bob, charlie := tests.Alice(), tests.Bob(), tests.Charlie()
+
+	send := func(t *testing.T, roles *transactionAccountRoles, code []byte) *flowkit.Transaction {
+		_, s, gw := setup()
+
+		// the sequence number of each account key is different, so the proposal key can be checked
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			address := args.Get(0).(flow.Address)
+			account := tests.NewAccountWithAddress(address.String())
+			for _, key := range account.Keys {
+				key.SequenceNumber = uint64(address[flow.AddressLength-1]) * 10
+			}
+			gw.GetAccount.Return(account, nil)
+		})
+
+		var sent *flowkit.Transaction
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			sent = args.Get(0).(*flowkit.Transaction)
+			gw.SendSignedTransaction.Return(sent.FlowTransaction(), nil)
+		})
+
+		_, _, err := s.Transactions.Send(roles, flowkit.NewScript(code, nil, ""), gasLimit, "")
+		require.NoError(t, err)
+		require.NotNil(t, sent)
+		assert.Empty(t, sent.MissingSignatures())
+
+		return sent
+	}
+
+	signatureAddresses := func(signatures []flow.TransactionSignature) []flow.Address {
+		addresses := make([]flow.Address, 0, len(signatures))
+		for _, sig := range signatures {
+			addresses = append(addresses, sig.Address)
+		}
+		return addresses
+	}
+
+	t.Run("Payer is the proposer", func(t *testing.T) {
+		roles, err := NewTransactionAccountRoles(alice, alice, []*flowkit.Account{bob})
+		require.NoError(t, err)
+
+		tx := send(t, roles, tests.TransactionSingleAuth.Source).FlowTransaction()
+		assert.Equal(t, alice.Address(), tx.ProposalKey.Address)
+		assert.Equal(t, uint64(10), tx.ProposalKey.SequenceNumber)
+		assert.Equal(t, []flow.Address{bob.Address()}, signatureAddresses(tx.PayloadSignatures))
+		assert.Equal(t, []flow.Address{alice.Address()}, signatureAddresses(tx.EnvelopeSignatures))
+	})
+
+	t.Run("Payer is not the proposer", func(t *testing.T) {
+		roles, err := NewTransactionAccountRoles(alice, bob, []*flowkit.Account{alice})
+		require.NoError(t, err)
+
+		tx := send(t, roles, tests.TransactionSingleAuth.Source).FlowTransaction()
+		assert.Equal(t, alice.Address(), tx.ProposalKey.Address)
+		assert.Equal(t, uint64(10), tx.ProposalKey.SequenceNumber)
+		assert.Equal(t, []flow.Address{alice.Address()}, signatureAddresses(tx.PayloadSignatures))
+		assert.Equal(t, []flow.Address{bob.Address()}, signatureAddresses(tx.EnvelopeSignatures))
+	})
+
+	t.Run("Three authorizers", func(t *testing.T) {
+		roles, err := NewTransactionAccountRoles(bob, charlie, []*flowkit.Account{alice, bob, charlie})
+		require.NoError(t, err)
+
+		tx := send(t, roles, []byte(threeAuthTransaction)).FlowTransaction()
+		assert.Equal(t, bob.Address(), tx.ProposalKey.Address)
+		assert.Equal(t, uint64(20), tx.ProposalKey.SequenceNumber)
+		assert.Equal(t, []flow.Address{alice.Address(), bob.Address(), charlie.Address()}, tx.Authorizers)
+		assert.ElementsMatch(t, []flow.Address{alice.Address(), bob.Address()}, signatureAddresses(tx.PayloadSignatures))
+		assert.Equal(t, []flow.Address{charlie.Address()}, signatureAddresses(tx.EnvelopeSignatures))
+	})
+}
+
+func TestTransactionsWait(t *testing.T) {
+	// not parallel, as the poll interval is shared by the tests
+	defer func(interval time.Duration) { statusPollInterval = interval }(statusPollInterval)
+	statusPollInterval = time.Millisecond
+
+	alice := tests.Alice()
+	script := flowkit.NewScript(tests.TransactionSimple.Source, nil, "")
+
+	// the transaction is sealed on the fourth poll of the result
+	delayedSeal := func(gw *tests.TestGateway) {
+		statuses := []flow.TransactionStatus{
+			flow.TransactionStatusPending,
+			flow.TransactionStatusFinalized,
+			flow.TransactionStatusExecuted,
+			flow.TransactionStatusSealed,
+		}
+		polls := 0
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			assert.False(t, args.Get(1).(bool))
+			result := tests.NewTransactionResult(nil)
+			result.Status = statuses[polls]
+			if polls < len(statuses)-1 {
+				polls++
+			}
+			gw.GetTransactionResult.Return(result, nil)
+		})
+	}
+
+	t.Run("Wait for sealed", func(t *testing.T) {
+		_, s, gw := setup()
+		delayedSeal(gw)
+
+		tx, result, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", SendOptions{WaitOptions: DefaultWaitOptions})
+		require.NoError(t, err)
+		assert.NotNil(t, tx)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+		gw.Mock.AssertNumberOfCalls(t, tests.GetTransactionResultFunc, 4)
+	})
+
+	t.Run("Wait for executed", func(t *testing.T) {
+		_, s, gw := setup()
+		delayedSeal(gw)
+
+		wait := WaitOptions{Status: flow.TransactionStatusExecuted}
+		_, result, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", SendOptions{WaitOptions: wait})
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusExecuted, result.Status)
+		gw.Mock.AssertNumberOfCalls(t, tests.GetTransactionResultFunc, 3)
+	})
+
+	t.Run("No wait", func(t *testing.T) {
+		_, s, gw := setup()
+
+		tx, result, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", SendOptions{WaitOptions: WaitOptions{NoWait: true}})
+		require.NoError(t, err)
+		assert.NotNil(t, tx)
+		assert.Nil(t, result)
+		gw.Mock.AssertNotCalled(t, tests.GetTransactionResultFunc, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail timeout", func(t *testing.T) {
+		_, s, gw := setup()
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			result := tests.NewTransactionResult(nil)
+			result.Status = flow.TransactionStatusPending
+			gw.GetTransactionResult.Return(result, nil)
+		})
+
+		wait := WaitOptions{Status: flow.TransactionStatusSealed, Timeout: 20 * time.Millisecond}
+		tx, result, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", SendOptions{WaitOptions: wait})
+		require.Error(t, err)
+		require.NotNil(t, tx)
+		assert.Nil(t, result)
+		assert.Equal(t, fmt.Sprintf("transaction %s is not sealed after 20ms, the last status is pending", tx.ID()), err.Error())
+	})
+
+	t.Run("Fail expired", func(t *testing.T) {
+		_, s, gw := setup()
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			result := tests.NewTransactionResult(nil)
+			result.Status = flow.TransactionStatusExpired
+			gw.GetTransactionResult.Return(result, nil)
+		})
+
+		tx, _, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", SendOptions{WaitOptions: DefaultWaitOptions})
+		require.Error(t, err)
+		assert.Equal(t, fmt.Sprintf("transaction %s expired before it was sealed", tx.ID()), err.Error())
+	})
+}
+
+func TestTransactionsSendRetries(t *testing.T) {
+	// not parallel, as the poll interval and the retry backoff are shared by the tests
+	defer func(interval, backoff time.Duration) {
+		statusPollInterval, retryBackoff = interval, backoff
+	}(statusPollInterval, retryBackoff)
+	statusPollInterval, retryBackoff = time.Millisecond, time.Millisecond
+
+	alice := tests.Alice()
+	script := flowkit.NewScript(tests.TransactionSimple.Source, nil, "")
+	options := SendOptions{WaitOptions: DefaultWaitOptions, Retries: 2}
+	seqErr := fmt.Errorf("invalid proposal key: public key 0 on account %s has sequence number 2, but given 1", alice.Address())
+
+	// sends fail with the errors in order, and then succeed
+	failSends := func(gw *tests.TestGateway, errs ...error) *[]flow.Identifier {
+		var sent []flow.Identifier
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction).FlowTransaction()
+			sent = append(sent, tx.ID())
+			if len(sent) <= len(errs) {
+				gw.SendSignedTransaction.Return(nil, errs[len(sent)-1])
+				return
+			}
+			gw.SendSignedTransaction.Return(tx, nil)
+		})
+		return &sent
+	}
+
+	// the sequence number of the proposal key is increased by each fetch of the account
+	increaseSequence := func(gw *tests.TestGateway) {
+		sequence := uint64(0)
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(args.Get(0).(flow.Address).String())
+			for _, key := range account.Keys {
+				key.SequenceNumber = sequence
+			}
+			sequence++
+			gw.GetAccount.Return(account, nil)
+		})
+	}
+
+	t.Run("Retry unavailable", func(t *testing.T) {
+		_, s, gw := setup()
+		sent := failSends(gw, status.Error(codes.Unavailable, "unavailable"))
+		gw.GetTransaction.Return(nil, status.Error(codes.NotFound, "not found"))
+
+		_, result, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", options)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+		require.Len(t, *sent, 2)
+		assert.Equal(t, (*sent)[0], (*sent)[1])
+	})
+
+	t.Run("No resend of received transaction", func(t *testing.T) {
+		_, s, gw := setup()
+		sent := failSends(gw, status.Error(codes.DeadlineExceeded, "deadline exceeded"))
+
+		tx, result, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", options)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+		require.Len(t, *sent, 1)
+		assert.Equal(t, (*sent)[0], tx.ID())
+		gw.Mock.AssertCalled(t, tests.GetTransactionFunc, tx.ID())
+	})
+
+	t.Run("Rebuild on sequence number mismatch", func(t *testing.T) {
+		_, s, gw := setup()
+		increaseSequence(gw)
+		sent := failSends(gw, seqErr)
+		gw.GetTransaction.Return(nil, status.Error(codes.NotFound, "not found"))
+
+		tx, _, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", options)
+		require.NoError(t, err)
+		require.Len(t, *sent, 2)
+		assert.NotEqual(t, (*sent)[0], (*sent)[1])
+		assert.Equal(t, uint64(1), tx.ProposalKey.SequenceNumber)
+	})
+
+	t.Run("Rebuild on sequence number mismatch in result", func(t *testing.T) {
+		_, s, gw := setup()
+		increaseSequence(gw)
+		sent := failSends(gw)
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			result := tests.NewTransactionResult(nil)
+			if len(*sent) == 1 {
+				result.Error = seqErr
+			}
+			gw.GetTransactionResult.Return(result, nil)
+		})
+
+		tx, result, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", options)
+		require.NoError(t, err)
+		assert.NoError(t, result.Error)
+		require.Len(t, *sent, 2)
+		assert.Equal(t, (*sent)[1], tx.ID())
+	})
+
+	t.Run("Fail retries exhausted", func(t *testing.T) {
+		_, s, gw := setup()
+		unavailable := status.Error(codes.Unavailable, "unavailable")
+		sent := failSends(gw, unavailable, unavailable, unavailable)
+		gw.GetTransaction.Return(nil, status.Error(codes.NotFound, "not found"))
+
+		_, _, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", options)
+		assert.ErrorIs(t, err, unavailable)
+		assert.Len(t, *sent, 3)
+	})
+
+	t.Run("Fail not retryable", func(t *testing.T) {
+		_, s, gw := setup()
+		sent := failSends(gw, status.Error(codes.InvalidArgument, "invalid"))
+
+		_, _, err := s.Transactions.SendWithOptions(NewSingleTransactionAccount(alice), script, gasLimit, "", options)
+		assert.Error(t, err)
+		assert.Len(t, *sent, 1)
+		gw.Mock.AssertNotCalled(t, tests.GetTransactionFunc, mock.Anything)
+	})
+}
+
+func TestTransactionsSendBatch(t *testing.T) {
+	// not parallel, as the poll interval is shared by the tests
+	defer func(interval time.Duration) {
+		statusPollInterval = interval
+	}(statusPollInterval)
+	statusPollInterval = time.Millisecond
+
+	alice, bob := tests.Alice(), tests.Bob()
+	script := flowkit.NewScript(tests.TransactionSimple.Source, nil, "")
+	batch := []BatchTransaction{
+		{Script: script, Signer: alice, GasLimit: gasLimit, DependsOn: -1},
+		{Script: script, Signer: bob, GasLimit: gasLimit, DependsOn: -1},
+		{Script: script, Signer: alice, GasLimit: gasLimit, DependsOn: -1},
+	}
+
+	// the proposal keys of the sent transactions are recorded
+	recordSends := func(gw *tests.TestGateway) *[]flow.ProposalKey {
+		var sent []flow.ProposalKey
+		gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+			tx := args.Get(0).(*flowkit.Transaction).FlowTransaction()
+			sent = append(sent, tx.ProposalKey)
+			gw.SendSignedTransaction.Return(tx, nil)
+		})
+		return &sent
+	}
+
+	t.Run("Send in order", func(t *testing.T) {
+		_, s, gw := setup()
+		sent := recordSends(gw)
+
+		var reported []int
+		results, err := s.Transactions.SendBatch(batch, "", BatchOptions{
+			Report: func(result BatchResult) { reported = append(reported, result.Index) },
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		for _, result := range results {
+			assert.False(t, result.Failed())
+		}
+		assert.Equal(t, []int{0, 1, 2}, reported)
+
+		// the sequence numbers of the signers are assigned by the batch
+		require.Len(t, *sent, 3)
+		assert.Equal(t, alice.Address(), (*sent)[0].Address)
+		assert.Equal(t, uint64(42), (*sent)[0].SequenceNumber)
+		assert.Equal(t, bob.Address(), (*sent)[1].Address)
+		assert.Equal(t, uint64(42), (*sent)[1].SequenceNumber)
+		assert.Equal(t, alice.Address(), (*sent)[2].Address)
+		assert.Equal(t, uint64(43), (*sent)[2].SequenceNumber)
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		_, s, gw := setup()
+		sent := recordSends(gw)
+
+		results, err := s.Transactions.SendBatch(batch, "", BatchOptions{Start: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 2, results[0].Index)
+		assert.Len(t, *sent, 1)
+	})
+
+	t.Run("Skip after failure", func(t *testing.T) {
+		_, s, gw := setup()
+		sent := recordSends(gw)
+		gw.GetTransactionResult.Run(func(args mock.Arguments) {
+			result := tests.NewTransactionResult(nil)
+			result.Error = fmt.Errorf("panic")
+			gw.GetTransactionResult.Return(result, nil)
+		})
+
+		results, err := s.Transactions.SendBatch(batch, "", BatchOptions{})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.True(t, results[0].Failed())
+		assert.False(t, results[0].Skipped)
+		assert.True(t, results[1].Skipped)
+		assert.EqualError(t, results[1].Error, "transaction 0 it depends on failed")
+		assert.True(t, results[2].Skipped)
+		assert.Len(t, *sent, 1)
+	})
+
+	t.Run("Fail invalid dependency", func(t *testing.T) {
+		_, s, _ := setup()
+
+		_, err := s.Transactions.SendBatch([]BatchTransaction{
+			{Script: script, Signer: alice, GasLimit: gasLimit, DependsOn: 0},
+		}, "", BatchOptions{})
+		assert.EqualError(t, err, "transaction 0 can only depend on a previous transaction, not on 0")
+	})
+}
+
+func TestTransactionsReplay(t *testing.T) {
+	t.Parallel()
+
+	state, s, gw := setup()
+	serviceAccount, _ := state.EmulatorServiceAccount()
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(`
+			import FlowToken from 0x1654653399040a61
+			transaction(amount: UFix64) { prepare(signer: AuthAccount) {} }
+		`)).
+		SetGasLimit(200).
+		AddAuthorizer(flow.HexToAddress("0x01"))
+	amount, _ := cadence.NewUFix64("1.5")
+	require.NoError(t, tx.AddArgument(amount))
+
+	gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+		sent := args.Get(0).(*flowkit.Transaction).FlowTransaction()
+		assert.Contains(t, string(sent.Script), "import FlowToken from 0x0ae53cb6e3f42a79")
+		assert.Equal(t, []flow.Address{serviceAccount.Address()}, sent.Authorizers)
+		assert.Equal(t, serviceAccount.Address(), sent.Payer)
+		require.Len(t, sent.Arguments, 1)
+		sentAmount, err := sent.Argument(0)
+		require.NoError(t, err)
+		assert.Equal(t, amount, sentAmount)
+		assert.Equal(t, uint64(200), sent.GasLimit)
+		gw.SendSignedTransaction.Return(sent, nil)
+	})
+
+	_, result, err := s.Transactions.Replay(tx, "mainnet")
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	gw.Mock.AssertNumberOfCalls(t, tests.SendSignedTransactionFunc, 1)
+}
+
+func TestTransactionsVerifySignatures(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := tests.Alice(), tests.Bob()
+
+	// the on-chain accounts have the configured key at index 0 and a revoked key at index 1
+	onChainAccount := func(account *flowkit.Account) *flow.Account {
+		pk, _ := account.Key().PrivateKey()
+
+		key := &flow.AccountKey{
+			PublicKey: (*pk).PublicKey(),
+			SigAlgo:   account.Key().SigAlgo(),
+			HashAlgo:  account.Key().HashAlgo(),
+			Weight:    flow.AccountKeyWeightThreshold,
+		}
+		revoked := *key
+		revoked.Index = 1
+		revoked.Revoked = true
+
+		return &flow.Account{Address: account.Address(), Keys: []*flow.AccountKey{key, &revoked}}
+	}
+
+	sign := func(t *testing.T, tx *flow.Transaction, account *flowkit.Account, keyIndex int, envelope bool) {
+		signer, err := account.Key().Signer(context.Background())
+		require.NoError(t, err)
+
+		if envelope {
+			require.NoError(t, tx.SignEnvelope(account.Address(), keyIndex, signer))
+		} else {
+			require.NoError(t, tx.SignPayload(account.Address(), keyIndex, signer))
+		}
+	}
+
+	verify := func(t *testing.T, tx *flow.Transaction) []SignatureVerification {
+		_, s, gw := setup()
+		gw.GetAccount.Run(func(args mock.Arguments) {
+			address := args.Get(0).(flow.Address)
+			if address == alice.Address() {
+				gw.GetAccount.Return(onChainAccount(alice), nil)
+			} else {
+				gw.GetAccount.Return(onChainAccount(bob), nil)
+			}
+		})
+
+		verifications, err := s.Transactions.VerifySignatures(tx)
+		require.NoError(t, err)
+		return verifications
+	}
+
+	newTx := func() *flow.Transaction {
+		return flow.NewTransaction().
+			SetScript(tests.TransactionSingleAuth.Source).
+			SetProposalKey(alice.Address(), 0, 0).
+			SetPayer(bob.Address()).
+			AddAuthorizer(alice.Address())
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+		tx := newTx()
+		sign(t, tx, alice, 0, false)
+		sign(t, tx, bob, 0, true)
+
+		verifications := verify(t, tx)
+		require.Len(t, verifications, 2)
+		assert.Equal(t, alice.Address(), verifications[0].Address)
+		assert.False(t, verifications[0].Envelope)
+		assert.True(t, verifications[0].Valid)
+		assert.Equal(t, bob.Address(), verifications[1].Address)
+		assert.True(t, verifications[1].Envelope)
+		assert.True(t, verifications[1].Valid)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		tx := newTx()
+		sign(t, tx, alice, 1, false)
+		sign(t, tx, alice, 2, false)
+		// bob signs the payload message, not the envelope
+		sign(t, tx, bob, 0, false)
+		tx.EnvelopeSignatures = tx.PayloadSignatures[2:]
+		tx.PayloadSignatures = tx.PayloadSignatures[:2]
+
+		verifications := verify(t, tx)
+		require.Len(t, verifications, 3)
+		assert.False(t, verifications[0].Valid)
+		assert.Equal(t, "key at index 1 is revoked", verifications[0].Reason)
+		assert.False(t, verifications[1].Valid)
+		assert.Equal(t, fmt.Sprintf("account 0x%s has no key at index 2", alice.Address()), verifications[1].Reason)
+		assert.True(t, verifications[2].Envelope)
+		assert.False(t, verifications[2].Valid)
+		assert.Equal(t, "signature doesn't match the key at index 0", verifications[2].Reason)
+	})
+}
+
 func setupAccounts(state *flowkit.State, s *Services) {
 	setupAccount(state, s, tests.Alice())
 	setupAccount(state, s, tests.Bob())
@@ -171,6 +682,15 @@ func Test_TransactionRoles(t *testing.T) {
 			signerAddresses: []flow.Address{
 				a.Address(), b.Address(),
 			},
+		}, {
+			transactionAccountRoles: &transactionAccountRoles{
+				proposer:    a,
+				payer:       a,
+				authorizers: []*flowkit.Account{b},
+			},
+			signerAddresses: []flow.Address{
+				b.Address(), a.Address(),
+			},
 		}, {
 			transactionAccountRoles: &transactionAccountRoles{
 				proposer: a,
@@ -606,4 +1126,21 @@ func TestTransactions_Integration(t *testing.T) {
 		assert.Nil(t, txr.Error)
 		assert.Equal(t, txr.Status, flow.TransactionStatusSealed)
 	})
+
+	t.Run("Estimate Transaction", func(t *testing.T) {
+		t.Parallel()
+		_, s := setupIntegration()
+
+		loop := []byte(`transaction { prepare(signer: AuthAccount) { var i = 0; while i < 10 { i = i + 1 } } }`)
+		estimate, err := s.Transactions.Estimate(flowkit.NewScript(loop, nil, ""))
+		require.NoError(t, err)
+		assert.NoError(t, estimate.Error)
+		assert.Greater(t, estimate.ComputationUsed, uint64(0))
+		assert.GreaterOrEqual(t, estimate.SuggestedGasLimit(), estimate.ComputationUsed*6/5)
+
+		failing := []byte(`transaction { prepare(signer: AuthAccount) { panic("failed") } }`)
+		estimate, err = s.Transactions.Estimate(flowkit.NewScript(failing, nil, ""))
+		require.NoError(t, err)
+		assert.ErrorContains(t, estimate.Error, "failed")
+	})
 }
@@ -0,0 +1,209 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// AccountKeyFactory creates an account key of a registered key type from the configuration.
+//
+// Keys stored in files are read with the reader writer, which is nil if the key is not created by a state.
+type AccountKeyFactory func(accountKeyConf config.AccountKey, readerWriter ReaderWriter) (AccountKey, error)
+
+var (
+	accountKeyTypesMu sync.RWMutex
+	// accountKeyTypes is the registry of the key types, used to create the keys of all the accounts.
+	accountKeyTypes = map[config.KeyType]AccountKeyFactory{
+		config.KeyTypeHex: func(conf config.AccountKey, _ ReaderWriter) (AccountKey, error) {
+			return newHexAccountKey(conf)
+		},
+		config.KeyTypeBip44: func(conf config.AccountKey, _ ReaderWriter) (AccountKey, error) {
+			return newBip44AccountKey(conf)
+		},
+		config.KeyTypeGoogleKMS: func(conf config.AccountKey, _ ReaderWriter) (AccountKey, error) {
+			return newKmsAccountKey(conf)
+		},
+		config.KeyTypeAWSKMS: func(conf config.AccountKey, _ ReaderWriter) (AccountKey, error) {
+			return newKmsAccountKey(conf)
+		},
+		config.KeyTypeKMS: func(conf config.AccountKey, _ ReaderWriter) (AccountKey, error) {
+			return newKmsAccountKey(conf)
+		},
+		config.KeyTypeEncrypted: func(conf config.AccountKey, _ ReaderWriter) (AccountKey, error) {
+			return newEncryptedAccountKey(conf), nil
+		},
+		config.KeyTypeFile: func(conf config.AccountKey, readerWriter ReaderWriter) (AccountKey, error) {
+			return newFileAccountKey(conf, readerWriter), nil
+		},
+		config.KeyTypeHardware: func(conf config.AccountKey, _ ReaderWriter) (AccountKey, error) {
+			return newHardwareAccountKey(conf), nil
+		},
+		config.KeyTypeKeystore: func(conf config.AccountKey, readerWriter ReaderWriter) (AccountKey, error) {
+			return newKeystoreAccountKey(conf, readerWriter), nil
+		},
+	}
+)
+
+// RegisterAccountKeyType adds a key type used by the accounts in the configuration.
+//
+// The key types must be registered before the state is loaded, and the built-in types can't be replaced.
+func RegisterAccountKeyType(keyType config.KeyType, factory AccountKeyFactory) error {
+	if keyType == "" {
+		return fmt.Errorf("the key type can't be empty")
+	}
+
+	accountKeyTypesMu.Lock()
+	defer accountKeyTypesMu.Unlock()
+
+	if _, exists := accountKeyTypes[keyType]; exists {
+		return fmt.Errorf(`key type "%s" is already registered`, keyType)
+	}
+
+	accountKeyTypes[keyType] = factory
+	return nil
+}
+
+func accountKeyFactory(keyType config.KeyType) (AccountKeyFactory, error) {
+	accountKeyTypesMu.RLock()
+	defer accountKeyTypesMu.RUnlock()
+
+	factory, ok := accountKeyTypes[keyType]
+	if !ok {
+		return nil, fmt.Errorf(`invalid key type: "%s"`, keyType)
+	}
+
+	return factory, nil
+}
+
+// Signer signs messages with a key held by a signing backend, for example a secrets manager.
+type Signer interface {
+	// Sign signs the message, which already includes the domain tag, and returns the raw signature.
+	Sign(ctx context.Context, message []byte) ([]byte, error)
+	// PublicKey returns the public key of the signing key.
+	PublicKey() crypto.PublicKey
+	// Metadata describes the signing key, for example with the backend address and the key name,
+	// and is included in the signing errors.
+	Metadata() map[string]string
+}
+
+// SignerProvider resolves the signers of the account keys of a registered key type.
+//
+// The keys are configured with the resource ID or the location, the meaning of which is up to the provider.
+type SignerProvider interface {
+	// Validate checks the key configuration, and can check the backend is accessible.
+	Validate(key config.AccountKey) error
+	// Signer returns the signer of the key.
+	Signer(ctx context.Context, key config.AccountKey) (Signer, error)
+}
+
+// RegisterSignerProvider adds a key type signing with the provider, so accounts with keys of the type
+// can be used by all the services, like any of the built-in key types.
+func RegisterSignerProvider(keyType config.KeyType, provider SignerProvider) error {
+	return RegisterAccountKeyType(keyType, func(conf config.AccountKey, _ ReaderWriter) (AccountKey, error) {
+		return &ProviderAccountKey{
+			baseAccountKey: newBaseAccountKey(conf),
+			resourceID:     conf.ResourceID,
+			location:       conf.Location,
+			provider:       provider,
+		}, nil
+	})
+}
+
+var _ AccountKey = &ProviderAccountKey{}
+
+// ProviderAccountKey implements signing with the registered provider of the key type.
+type ProviderAccountKey struct {
+	*baseAccountKey
+	resourceID string
+	location   string
+	provider   SignerProvider
+}
+
+func (a *ProviderAccountKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:       a.keyType,
+		Index:      a.index,
+		SigAlgo:    a.sigAlgo,
+		HashAlgo:   a.hashAlgo,
+		Weight:     a.weight,
+		Revoked:    a.revoked,
+		ResourceID: a.resourceID,
+		Location:   a.location,
+	}
+}
+
+func (a *ProviderAccountKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	signer, err := a.provider.Signer(ctx, a.ToConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	return &providerSigner{ctx: ctx, keyType: a.keyType, signer: signer}, nil
+}
+
+func (a *ProviderAccountKey) Validate() error {
+	return a.provider.Validate(a.ToConfig())
+}
+
+func (a *ProviderAccountKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible")
+}
+
+var _ crypto.Signer = &providerSigner{}
+
+// providerSigner adapts the signer of a provider to the signer used by the SDK,
+// signing with the context the signer was created with.
+type providerSigner struct {
+	ctx     context.Context
+	keyType config.KeyType
+	signer  Signer
+}
+
+func (s *providerSigner) Sign(message []byte) ([]byte, error) {
+	signature, err := s.signer.Sign(s.ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with %s key %s: %w", s.keyType, formatMetadata(s.signer.Metadata()), err)
+	}
+
+	return signature, nil
+}
+
+func (s *providerSigner) PublicKey() crypto.PublicKey {
+	return s.signer.PublicKey()
+}
+
+// formatMetadata formats the metadata as a sorted list of key=value pairs.
+func formatMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0, len(metadata))
+	for key, value := range metadata {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+
+	return fmt.Sprintf("(%s)", strings.Join(pairs, ", "))
+}
@@ -0,0 +1,167 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// fakeVault is a signer provider registered outside of flowkit, like a backend signing with the keys in a Vault server.
+type fakeVault struct {
+	keys map[string]crypto.PrivateKey
+}
+
+func (v *fakeVault) Validate(key config.AccountKey) error {
+	if _, ok := v.keys[key.ResourceID]; !ok {
+		return fmt.Errorf("key %s not found", key.ResourceID)
+	}
+	return nil
+}
+
+func (v *fakeVault) Signer(_ context.Context, key config.AccountKey) (flowkit.Signer, error) {
+	if err := v.Validate(key); err != nil {
+		return nil, err
+	}
+	return &fakeVaultSigner{name: key.ResourceID, key: v.keys[key.ResourceID]}, nil
+}
+
+type fakeVaultSigner struct {
+	name string
+	key  crypto.PrivateKey
+}
+
+func (s *fakeVaultSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.key.Sign(message, crypto.NewSHA3_256())
+}
+
+func (s *fakeVaultSigner) PublicKey() crypto.PublicKey {
+	return s.key.PublicKey()
+}
+
+func (s *fakeVaultSigner) Metadata() map[string]string {
+	return map[string]string{"backend": "vault", "key": s.name}
+}
+
+func TestRegisterSignerProvider(t *testing.T) {
+	privateKey, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+	require.NoError(t, err)
+
+	vault := &fakeVault{keys: map[string]crypto.PrivateKey{"transit/keys/alice": privateKey}}
+	require.NoError(t, flowkit.RegisterSignerProvider("fake-vault", vault))
+
+	t.Run("Fail registering a type twice", func(t *testing.T) {
+		err := flowkit.RegisterSignerProvider("fake-vault", vault)
+		assert.EqualError(t, err, `key type "fake-vault" is already registered`)
+
+		err = flowkit.RegisterSignerProvider(config.KeyTypeHex, vault)
+		assert.EqualError(t, err, `key type "hex" is already registered`)
+	})
+
+	t.Run("Sign with the registered type", func(t *testing.T) {
+		af := afero.Afero{Fs: afero.NewMemMapFs()}
+		err := afero.WriteFile(af.Fs, "flow.json", []byte(`{
+			"accounts": {
+				"alice": {
+					"address": "01cf0e2f2f715450",
+					"key": {
+						"type": "fake-vault",
+						"signatureAlgorithm": "ECDSA_P256",
+						"hashAlgorithm": "SHA3_256",
+						"resourceID": "transit/keys/alice"
+					}
+				}
+			}
+		}`), 0644)
+		require.NoError(t, err)
+
+		state, err := flowkit.Load([]string{"flow.json"}, af)
+		require.NoError(t, err)
+
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, config.KeyType("fake-vault"), alice.Key().Type())
+		assert.NoError(t, alice.Key().Validate())
+		assert.Equal(t, "transit/keys/alice", alice.Key().ToConfig().ResourceID)
+
+		tx := flowkit.NewTransaction()
+		tx.FlowTransaction().SetScript([]byte(`transaction {}`))
+		tx.FlowTransaction().SetProposalKey(alice.Address(), 0, 0)
+		tx.SetPayer(alice.Address())
+		require.NoError(t, tx.SetSigner(alice))
+
+		tx, err = tx.Sign()
+		require.NoError(t, err)
+
+		signatures := tx.FlowTransaction().EnvelopeSignatures
+		require.Len(t, signatures, 1)
+
+		message := append(flow.TransactionDomainTag[:], tx.FlowTransaction().EnvelopeMessage()...)
+		valid, err := privateKey.PublicKey().Verify(signatures[0].Signature, message, crypto.NewSHA3_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Fail signing with metadata", func(t *testing.T) {
+		key, err := flowkit.NewAccountKey(config.AccountKey{
+			Type:       "fake-vault",
+			SigAlgo:    crypto.ECDSA_P256,
+			HashAlgo:   crypto.SHA3_256,
+			ResourceID: "transit/keys/alice",
+		})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		signer, err := key.Signer(ctx)
+		require.NoError(t, err)
+		cancel()
+
+		_, err = signer.Sign([]byte("message"))
+		assert.EqualError(t, err, "failed to sign with fake-vault key (backend=vault, key=transit/keys/alice): context canceled")
+	})
+
+	t.Run("Fail missing key", func(t *testing.T) {
+		key, err := flowkit.NewAccountKey(config.AccountKey{
+			Type:       "fake-vault",
+			SigAlgo:    crypto.ECDSA_P256,
+			HashAlgo:   crypto.SHA3_256,
+			ResourceID: "transit/keys/bob",
+		})
+		require.NoError(t, err)
+		assert.EqualError(t, key.Validate(), "key transit/keys/bob not found")
+	})
+
+	t.Run("Fail unregistered type", func(t *testing.T) {
+		_, err := flowkit.NewAccountKey(config.AccountKey{Type: "unknown"})
+		assert.EqualError(t, err, `invalid key type: "unknown"`)
+	})
+}
@@ -21,13 +21,14 @@ package flowkit
 import (
 	"fmt"
 	"os"
-	"path"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/pkg/errors"
 
 	"github.com/onflow/flow-cli/pkg/flowkit/config"
 	"github.com/onflow/flow-cli/pkg/flowkit/config/json"
+	"github.com/onflow/flow-cli/pkg/flowkit/config/yaml"
 	"github.com/onflow/flow-cli/pkg/flowkit/project"
 )
 
@@ -44,6 +45,7 @@ type State struct {
 	confLoader   *config.Loader
 	readerWriter ReaderWriter
 	accounts     *Accounts
+	remote       *RemoteContracts
 }
 
 // ReaderWriter retrieve current file reader writer.
@@ -51,29 +53,48 @@ func (p *State) ReaderWriter() ReaderWriter {
 	return p.readerWriter
 }
 
+// RemoteContracts returns the loader of contracts with remote locations.
+func (p *State) RemoteContracts() *RemoteContracts {
+	return p.remote
+}
+
 // ReadFile exposes an injected file loader.
 func (p *State) ReadFile(source string) ([]byte, error) {
 	return p.readerWriter.ReadFile(source)
 }
 
-// SaveDefault saves to default path.
+// SaveDefault saves to the project configuration in the format it exists in, or to the default path if it doesn't exist.
 func (p *State) SaveDefault() error {
-	return p.Save(config.DefaultPath)
+	path, err := p.confLoader.DefaultPath()
+	if err != nil {
+		path = config.DefaultPath
+	}
+
+	return p.Save(path)
 }
 
 // SaveEdited saves configuration to valid path.
+//
+// If multiple configuration files were merged the changes are saved to the files they were loaded from.
+// The configuration is validated before it is saved, so invalid changes are not persisted.
 func (p *State) SaveEdited(paths []string) error {
-	// if paths are not default only allow specifying one config
-	if !config.IsDefaultPath(paths) && len(paths) > 1 {
+	// if paths are not default only allow specifying multiple configs if they were merged when loaded
+	if !config.IsDefaultPath(paths) && len(paths) > 1 && !p.confLoader.IsLayer(paths[0]) {
 		return fmt.Errorf("specifying multiple paths is not supported when updating configuration")
 	}
+
+	p.conf.Accounts = accountsToConfig(*p.accounts, p.confLoader.AccountsFromFile())
+	if err := p.conf.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	// if default paths and local config doesn't exist don't allow updating global config
 	if config.IsDefaultPath(paths) {
-		_, err := p.confLoader.Load([]string{config.DefaultPath}) // check if default is present
+		path, err := p.confLoader.DefaultPath() // check if default is present
 		if err != nil {
 			return fmt.Errorf("default configuration not found, please initialize it first or specify another configuration file")
 		} else {
-			return p.SaveDefault()
+			return p.Save(path)
 		}
 	}
 
@@ -81,9 +102,18 @@ func (p *State) SaveEdited(paths []string) error {
 }
 
 // Save saves the project configuration to the given path.
+//
+// If the path is one of multiple merged configuration files, the configuration is saved
+// to all the merged files with each change saved to the file it was loaded from.
 func (p *State) Save(path string) error {
 	p.conf.Accounts = accountsToConfig(*p.accounts, p.confLoader.AccountsFromFile())
-	err := p.confLoader.Save(p.conf, path)
+
+	var err error
+	if p.confLoader.IsLayer(path) && len(p.confLoader.Layers()) > 1 {
+		err = p.confLoader.SaveLayers(p.conf)
+	} else {
+		err = p.confLoader.Save(p.confLoader.WithoutGlobal(p.conf), path)
+	}
 
 	// if we have defined accounts to be saved to an external file, iterate over them and save them separately
 	for name, location := range p.confLoader.AccountsFromFile() {
@@ -106,6 +136,19 @@ func (p *State) Save(path string) error {
 	return nil
 }
 
+// ConfigLayers returns the paths of the configuration files merged into the project configuration.
+func (p *State) ConfigLayers() []string {
+	return p.confLoader.Layers()
+}
+
+// ResolvedConfig returns the merged project configuration serialized in the default format.
+//
+// Interpolated values are kept as placeholders, so the resolved secrets are not exposed.
+func (p *State) ResolvedConfig() ([]byte, error) {
+	p.conf.Accounts = accountsToConfig(*p.accounts, p.confLoader.AccountsFromFile())
+	return p.confLoader.Serialize(p.conf, config.DefaultPath)
+}
+
 // Networks get network configuration.
 func (p *State) Networks() *config.Networks {
 	return &p.conf.Networks
@@ -121,11 +164,30 @@ func (p *State) Contracts() *config.Contracts {
 	return &p.conf.Contracts
 }
 
+// Transactions get template transactions configuration.
+func (p *State) Transactions() *config.Transactions {
+	return &p.conf.Transactions
+}
+
+// Scripts get saved scripts configuration.
+func (p *State) Scripts() *config.Scripts {
+	return &p.conf.Scripts
+}
+
 // Accounts get accounts.
+//
+// Accounts defined differently on specific networks return the address and key of the network set with SetNetwork.
 func (p *State) Accounts() *Accounts {
 	return p.accounts
 }
 
+// SetNetwork sets the network the accounts are used on.
+func (p *State) SetNetwork(network string) {
+	for i := range *p.accounts {
+		(*p.accounts)[i].network = network
+	}
+}
+
 // Config get underlying configuration for advanced usage.
 func (p *State) Config() *config.Config {
 	return p.conf
@@ -146,6 +208,28 @@ func (p *State) EmulatorServiceAccount() (*Account, error) {
 	return p.accounts.ByName(emulator.ServiceAccount)
 }
 
+// SetAccountAddress sets the address of the account with the name on the network.
+//
+// The address is set on the network specific definition of the account if there is one,
+// otherwise on the account, for example after the account was created again on a restarted emulator.
+func (p *State) SetAccountAddress(name string, network string, address flow.Address) error {
+	for i := range *p.accounts {
+		account := &(*p.accounts)[i]
+		if account.name != name {
+			continue
+		}
+
+		if n, ok := account.networks[network]; ok {
+			n.address = address
+		} else {
+			account.address = address
+		}
+		return nil
+	}
+
+	return fmt.Errorf("could not find account with name %s in the configuration", name)
+}
+
 // SetEmulatorKey sets the default emulator service account private key.
 func (p *State) SetEmulatorKey(privateKey crypto.PrivateKey) {
 	acc, _ := p.EmulatorServiceAccount()
@@ -172,6 +256,12 @@ func (p *State) DeploymentContractsByNetwork(network string) ([]*project.Contrac
 			return nil, err
 		}
 
+		// the account is resolved for the deployment network regardless of the network it is used on
+		account, err = account.ForNetwork(network)
+		if err != nil {
+			return nil, err
+		}
+
 		// go through each contract in this deployment
 		for _, deploymentContract := range deploy.Contracts {
 			c, err := p.conf.Contracts.ByNameAndNetwork(deploymentContract.Name, network)
@@ -179,19 +269,21 @@ func (p *State) DeploymentContractsByNetwork(network string) ([]*project.Contrac
 				return nil, err
 			}
 
-			code, err := p.readerWriter.ReadFile(c.Location)
+			code, err := p.contractCode(c)
 			if err != nil {
 				return nil, errors.Wrap(err, "deployment by network failed to read contract code")
 			}
 
 			contract := project.NewContract(
 				c.Name,
-				path.Clean(c.Location),
+				project.CleanLocation(c.Location),
 				code,
-				account.address,
+				account.Address(),
 				account.name,
 				deploymentContract.Args,
 			)
+			contract.ImportAccounts = deploymentContract.Imports
+			contract.Tags = deploymentContract.Tags
 
 			contracts = append(contracts, contract)
 		}
@@ -200,6 +292,15 @@ func (p *State) DeploymentContractsByNetwork(network string) ([]*project.Contrac
 	return contracts, nil
 }
 
+// contractCode reads the contract code from the file system or loads it from the remote location.
+func (p *State) contractCode(contract *config.Contract) ([]byte, error) {
+	if project.IsRemote(contract.Location) {
+		return p.remote.Load(contract.Location, contract.Hash)
+	}
+
+	return p.readerWriter.ReadFile(contract.Location)
+}
+
 // AccountsForNetwork returns all accounts used on a network defined by deployments.
 func (p *State) AccountsForNetwork(network string) Accounts {
 	exists := make(map[string]bool, 0)
@@ -208,7 +309,9 @@ func (p *State) AccountsForNetwork(network string) Accounts {
 	for _, account := range *p.accounts {
 		if len(p.conf.Deployments.ByAccountAndNetwork(account.name, network)) > 0 {
 			if !exists[account.name] {
-				accounts = append(accounts, account)
+				if networkAccount, err := account.ForNetwork(network); err == nil {
+					accounts = append(accounts, *networkAccount)
+				}
 			}
 		}
 	}
@@ -222,20 +325,27 @@ func (p *State) AliasesForNetwork(network string) project.Aliases {
 	// get all contracts for selected network and if any has an address as target make it an alias
 	for _, contract := range p.conf.Contracts.ByNetwork(network) {
 		if contract.IsAlias() {
-			aliases[path.Clean(contract.Location)] = contract.Alias // alias for import by file location
-			aliases[contract.Name] = contract.Alias                 // alias for import by name
+			aliases[project.CleanLocation(contract.Location)] = contract.Alias // alias for import by file location
+			aliases[contract.Name] = contract.Alias                            // alias for import by name
 		}
 	}
 
 	return aliases
 }
 
-// Load loads a project configuration and returns the resulting project.
-func Load(configFilePaths []string, readerWriter ReaderWriter) (*State, error) {
-	confLoader := config.NewLoader(readerWriter)
+// LoadOption changes how the project configuration is loaded.
+type LoadOption func(loader *config.Loader)
+
+// WithoutAddressValidation skips checking the account addresses are valid on the chains of the networks using them.
+func WithoutAddressValidation() LoadOption {
+	return func(loader *config.Loader) {
+		loader.SetSkipAddressValidation(true)
+	}
+}
 
-	// here we add all available parsers (more to add yaml etc...)
-	confLoader.AddConfigParser(json.NewParser())
+// Load loads a project configuration and returns the resulting project.
+func Load(configFilePaths []string, readerWriter ReaderWriter, options ...LoadOption) (*State, error) {
+	confLoader := newLoader(readerWriter, options...)
 	conf, err := confLoader.Load(configFilePaths)
 	if err != nil {
 		return nil, err
@@ -253,6 +363,112 @@ func Load(configFilePaths []string, readerWriter ReaderWriter) (*State, error) {
 	return proj, nil
 }
 
+// Lint checks the project configuration files and returns all the problems found in them.
+//
+// Besides the problems found by the configuration loader it reports contract files which can't be read.
+func Lint(configFilePaths []string, readerWriter ReaderWriter, options ...LoadOption) ([]config.Problem, error) {
+	confLoader := newLoader(readerWriter, options...)
+	conf, problems, err := confLoader.Lint(configFilePaths)
+	if err != nil {
+		return nil, err
+	}
+	if conf == nil { // the configuration files couldn't be parsed
+		return problems, nil
+	}
+
+	checked := make(map[string]bool)
+	for _, contract := range conf.Contracts {
+		if checked[contract.Name] || contract.Location == "" || project.IsRemote(contract.Location) {
+			continue
+		}
+		checked[contract.Name] = true
+
+		if _, err := readerWriter.ReadFile(contract.Location); err != nil {
+			problems = append(problems, config.Problem{
+				Path:    config.Pointer("contracts", contract.Name),
+				Message: fmt.Sprintf("contract file %s can not be read", contract.Location),
+			})
+		}
+	}
+
+	return problems, nil
+}
+
+// GlobalSettings returns the settings of the global user configuration.
+//
+// Empty settings are returned if the global user configuration doesn't exist.
+func GlobalSettings(readerWriter ReaderWriter) (config.Settings, error) {
+	conf, err := loadGlobalUserConfig(readerWriter)
+	if err != nil {
+		return config.Settings{}, err
+	}
+
+	return conf.Settings, nil
+}
+
+// SetGlobalSetting changes the setting in the global user configuration and saves it.
+//
+// The global user configuration is created if it doesn't exist yet.
+func SetGlobalSetting(key string, value string, readerWriter ReaderWriter) error {
+	path := config.GlobalUserPath()
+	if path == "" {
+		return fmt.Errorf("global configuration path can not be determined")
+	}
+
+	loader := newLoader(readerWriter)
+	conf, err := loader.Load([]string{path})
+	if errors.Is(err, config.ErrDoesNotExist) {
+		conf = config.Empty()
+	} else if err != nil {
+		return err
+	}
+
+	err = conf.Settings.Set(key, value)
+	if err != nil {
+		return err
+	}
+
+	return loader.Save(conf, path)
+}
+
+func loadGlobalUserConfig(readerWriter ReaderWriter) (*config.Config, error) {
+	path := config.GlobalUserPath()
+	if path == "" {
+		return config.Empty(), nil
+	}
+
+	conf, err := newLoader(readerWriter).Load([]string{path})
+	if errors.Is(err, config.ErrDoesNotExist) {
+		return config.Empty(), nil
+	}
+
+	return conf, err
+}
+
+// ConvertConfig converts the configuration file to the format of the destination file.
+func ConvertConfig(source string, destination string, readerWriter ReaderWriter) error {
+	return newLoader(readerWriter).Convert(source, destination)
+}
+
+// MigrateConfig migrates the configuration from a legacy format and returns the changes made.
+//
+// The original configuration is saved to the backup path.
+func MigrateConfig(path string, backup string, readerWriter ReaderWriter) ([]string, error) {
+	return newLoader(readerWriter).Migrate(path, backup)
+}
+
+// newLoader returns a configuration loader with parsers for all the supported formats.
+func newLoader(readerWriter ReaderWriter, options ...LoadOption) *config.Loader {
+	loader := config.NewLoader(readerWriter)
+	loader.AddConfigParser(json.NewParser())
+	loader.AddConfigParser(yaml.NewParser())
+	for _, option := range options {
+		option(loader)
+	}
+
+	return loader
+}
+
 // Exists checks if a project configuration exists.
 func Exists(path string) bool {
 	return config.Exists(path)
@@ -269,14 +485,12 @@ func Init(
 		return nil, err
 	}
 
-	loader := config.NewLoader(readerWriter)
-	loader.AddConfigParser(json.NewParser())
-
 	return &State{
-		confLoader:   loader,
+		confLoader:   newLoader(readerWriter),
 		readerWriter: readerWriter,
 		conf:         config.Default(),
 		accounts:     &Accounts{*emulatorServiceAccount},
+		remote:       NewRemoteContracts(readerWriter, DefaultRemoteCacheDir),
 	}, nil
 }
 
@@ -286,7 +500,7 @@ func newProject(
 	loader *config.Loader,
 	readerWriter ReaderWriter,
 ) (*State, error) {
-	accounts, err := accountsFromConfig(conf)
+	accounts, err := accountsFromConfig(conf, readerWriter)
 	if err != nil {
 		return nil, err
 	}
@@ -296,5 +510,6 @@ func newProject(
 		readerWriter: readerWriter,
 		confLoader:   loader,
 		accounts:     &accounts,
+		remote:       NewRemoteContracts(readerWriter, DefaultRemoteCacheDir),
 	}, nil
 }
@@ -19,6 +19,7 @@
 package flowkit
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -884,3 +885,135 @@ func Test_CustomEmulatorValuesInConfig(t *testing.T) {
 	assert.Equal(t, state.conf, &config)
 	assert.NoError(t, err)
 }
+
+func Test_NetworkAccounts(t *testing.T) {
+	b := []byte(`{
+		"networks": {
+			"emulator": "127.0.0.1:3569",
+			"testnet": "access.devnet.nodes.onflow.org:9000",
+			"mainnet": "access.mainnet.nodes.onflow.org:9000"
+		},
+		"accounts": {
+			"admin": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7",
+				"networks": {
+					"testnet": {
+						"address": "0x9a0766d93b6608b7",
+						"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+					}
+				}
+			},
+			"tester": {
+				"networks": {
+					"testnet": {
+						"address": "0x9a0766d93b6608b7",
+						"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+					}
+				}
+			}
+		},
+		"contracts": {
+			"Foo": "./Foo.cdc"
+		},
+		"deployments": {
+			"emulator": {
+				"admin": ["Foo"]
+			},
+			"testnet": {
+				"admin": ["Foo"]
+			}
+		}
+	}`)
+
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := afero.WriteFile(af.Fs, "flow.json", b, 0644)
+	require.NoError(t, err)
+	err = afero.WriteFile(af.Fs, "Foo.cdc", []byte(`pub contract Foo {}`), 0644)
+	require.NoError(t, err)
+
+	state, err := Load([]string{"flow.json"}, af)
+	require.NoError(t, err)
+
+	// accounts are resolved for the selected network
+	admin, err := state.Accounts().ByName("admin")
+	require.NoError(t, err)
+	assert.Equal(t, "f8d6e0586b0a20c7", admin.Address().String())
+
+	state.SetNetwork("testnet")
+	admin, err = state.Accounts().ByName("admin")
+	require.NoError(t, err)
+	assert.Equal(t, "9a0766d93b6608b7", admin.Address().String())
+
+	tester, err := state.Accounts().ByName("tester")
+	require.NoError(t, err)
+	assert.Equal(t, "9a0766d93b6608b7", tester.Address().String())
+
+	state.SetNetwork("mainnet")
+	_, err = state.Accounts().ByName("tester")
+	assert.EqualError(t, err, "account tester is not defined for network mainnet")
+
+	// deployments use the account defined for the deployment network
+	contracts, err := state.DeploymentContractsByNetwork("emulator")
+	require.NoError(t, err)
+	assert.Equal(t, "f8d6e0586b0a20c7", contracts[0].AccountAddress.String())
+
+	contracts, err = state.DeploymentContractsByNetwork("testnet")
+	require.NoError(t, err)
+	assert.Equal(t, "9a0766d93b6608b7", contracts[0].AccountAddress.String())
+
+	// the network definitions are saved back
+	err = state.Save("flow.json")
+	require.NoError(t, err)
+
+	saved, err := Load([]string{"flow.json"}, af)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, state.Config().Accounts, saved.Config().Accounts)
+}
+
+func Test_InitEmulatorServiceAccount(t *testing.T) {
+	algorithms := []struct {
+		sigAlgo  crypto.SignatureAlgorithm
+		hashAlgo crypto.HashAlgorithm
+	}{
+		{crypto.ECDSA_P256, crypto.SHA3_256},
+		{crypto.ECDSA_secp256k1, crypto.SHA2_256},
+	}
+
+	for _, algos := range algorithms {
+		t.Run(fmt.Sprintf("%s %s", algos.sigAlgo, algos.hashAlgo), func(t *testing.T) {
+			state, err := Init(af, algos.sigAlgo, algos.hashAlgo)
+			require.NoError(t, err)
+
+			err = state.Save("init.json")
+			require.NoError(t, err)
+
+			loaded, err := Load([]string{"init.json"}, af)
+			require.NoError(t, err)
+
+			service, err := loaded.EmulatorServiceAccount()
+			require.NoError(t, err)
+			assert.Equal(t, algos.sigAlgo, service.Key().SigAlgo())
+			assert.Equal(t, algos.hashAlgo, service.Key().HashAlgo())
+
+			signer, err := service.Key().Signer(context.Background())
+			require.NoError(t, err)
+
+			message := []byte("service account")
+			signature, err := signer.Sign(message)
+			require.NoError(t, err)
+
+			hasher, err := crypto.NewHasher(algos.hashAlgo)
+			require.NoError(t, err)
+
+			valid, err := signer.PublicKey().Verify(signature, message, hasher)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		})
+	}
+
+	t.Run("Invalid Algorithms", func(t *testing.T) {
+		_, err := Init(af, crypto.UnknownSignatureAlgorithm, crypto.SHA3_256)
+		assert.ErrorContains(t, err, "invalid emulator service key")
+	})
+}
@@ -0,0 +1,242 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+// placeholderPattern matches the placeholders of template transaction code, like `${amount}`.
+var placeholderPattern = regexp.MustCompile(`\$\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*}`)
+
+// transactionHeaderPattern matches the transaction declaration with its optional parameter list.
+var transactionHeaderPattern = regexp.MustCompile(`\btransaction\s*(?:\(([^)]*)\))?\s*\{`)
+
+// NewTemplateScript creates the script of a template transaction with the arguments parsed from the values of the parameters.
+//
+// Placeholders in the code, like `${amount}`, are turned into transaction parameters of the
+// types declared in the template. The values of the parameters are parsed as the declared
+// types, or as the types of the transaction parameters if they are not declared.
+func NewTemplateScript(template config.Transaction, code []byte, params map[string]string) (*Script, error) {
+	code, err := replacePlaceholders(template, code)
+	if err != nil {
+		return nil, err
+	}
+
+	parameters, _ := prepareParameters(template.Location, code)
+
+	names := make([]string, 0, len(parameters))
+	args := make([]string, 0, len(parameters))
+	for _, parameter := range parameters {
+		name := parameter.Identifier.Identifier
+		names = append(names, name)
+
+		value, ok := params[name]
+		if !ok {
+			return nil, fmt.Errorf("missing parameter %s of the transaction %s", name, template.Name)
+		}
+
+		parameterType := template.ParameterType(name)
+		if parameterType == "" {
+			parameterType = parameter.TypeAnnotation.Type.String()
+		}
+
+		args = append(args, fmt.Sprintf("%s:%s:%s", name, parameterType, value))
+	}
+
+	unknown := make([]string, 0)
+	for name := range params {
+		if indexOf(names, name) < 0 {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf(
+			"parameters %s don't match any of the parameters %s of the transaction %s",
+			strings.Join(unknown, ", "),
+			names,
+			template.Name,
+		)
+	}
+
+	values, err := ParseNamedArguments(template.Location, code, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the transaction %s: %w", template.Name, err)
+	}
+
+	return NewScript(code, values, template.Location), nil
+}
+
+// replacePlaceholders replaces the placeholders of the code with the transaction parameters of the same names.
+func replacePlaceholders(template config.Transaction, code []byte) ([]byte, error) {
+	matches := placeholderPattern.FindAllSubmatch(code, -1)
+	if len(matches) == 0 {
+		return code, nil
+	}
+
+	header := transactionHeaderPattern.FindSubmatchIndex(code)
+	if header == nil {
+		return nil, fmt.Errorf("transaction %s has placeholders, but no transaction declaration", template.Name)
+	}
+
+	declarations := make([]string, 0)
+	declared := make([]string, 0)
+	if header[2] >= 0 {
+		for _, declaration := range strings.Split(string(code[header[2]:header[3]]), ",") {
+			declaration = strings.TrimSpace(declaration)
+			if declaration == "" {
+				continue
+			}
+			declarations = append(declarations, declaration)
+			name, _, _ := strings.Cut(declaration, ":")
+			declared = append(declared, strings.TrimSpace(name))
+		}
+	}
+
+	for _, match := range matches {
+		name := string(match[1])
+		if indexOf(declared, name) >= 0 {
+			continue
+		}
+
+		parameterType := template.ParameterType(name)
+		if parameterType == "" {
+			return nil, fmt.Errorf("type of the placeholder %s of the transaction %s is not declared", name, template.Name)
+		}
+
+		declarations = append(declarations, fmt.Sprintf("%s: %s", name, parameterType))
+		declared = append(declared, name)
+	}
+
+	replaced := make([]byte, 0, len(code))
+	replaced = append(replaced, code[:header[0]]...)
+	replaced = append(replaced, fmt.Sprintf("transaction(%s) {", strings.Join(declarations, ", "))...)
+	replaced = append(replaced, code[header[1]:]...)
+
+	return placeholderPattern.ReplaceAll(replaced, []byte("${1}")), nil
+}
+
+// ScriptParameter is a parameter of the main function of a script.
+type ScriptParameter struct {
+	Name string
+	Type string
+}
+
+// ScriptSignature returns the parameters of the main function of the script and its signature,
+// like `main(address: Address): UFix64`.
+func ScriptSignature(location string, code []byte) ([]ScriptParameter, string, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse script %s: %w", location, err)
+	}
+
+	var main *ast.FunctionDeclaration
+	for _, declaration := range program.FunctionDeclarations() {
+		if declaration.Identifier.Identifier == "main" {
+			main = declaration
+		}
+	}
+	if main == nil {
+		return nil, "", fmt.Errorf("script %s doesn't declare a main function", location)
+	}
+
+	parameters := make([]ScriptParameter, 0)
+	declarations := make([]string, 0)
+	if main.ParameterList != nil {
+		for _, parameter := range main.ParameterList.Parameters {
+			parameterType := parameter.TypeAnnotation.Type.String()
+			parameters = append(parameters, ScriptParameter{
+				Name: parameter.Identifier.Identifier,
+				Type: parameterType,
+			})
+			declarations = append(declarations, fmt.Sprintf("%s: %s", parameter.Identifier.Identifier, parameterType))
+		}
+	}
+
+	signature := fmt.Sprintf("main(%s)", strings.Join(declarations, ", "))
+	if main.ReturnTypeAnnotation != nil && main.ReturnTypeAnnotation.String() != "" {
+		signature = fmt.Sprintf("%s: %s", signature, main.ReturnTypeAnnotation)
+	}
+
+	return parameters, signature, nil
+}
+
+// NewSavedScript creates the script of a saved script with the arguments parsed from the values of the parameters.
+//
+// The values are parsed as the types of the parameters of the main function, the types declared in
+// the configuration must be the same. Errors include the signature of the main function.
+func NewSavedScript(saved config.Script, code []byte, params map[string]string) (*Script, error) {
+	parameters, signature, err := ScriptSignature(saved.Location, code)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(parameters))
+	args := make([]string, 0, len(parameters))
+	for _, parameter := range parameters {
+		names = append(names, parameter.Name)
+
+		declaredType := saved.ParameterType(parameter.Name)
+		if declaredType != "" && declaredType != parameter.Type {
+			return nil, fmt.Errorf(
+				"parameter %s of the script %s is declared as %s, but the signature is %s",
+				parameter.Name, saved.Name, declaredType, signature,
+			)
+		}
+
+		value, ok := params[parameter.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing parameter %s of the script %s, the signature is %s", parameter.Name, saved.Name, signature)
+		}
+
+		args = append(args, fmt.Sprintf("%s:%s:%s", parameter.Name, parameter.Type, value))
+	}
+
+	unknown := make([]string, 0)
+	for name := range params {
+		if indexOf(names, name) < 0 {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf(
+			"parameters %s don't match any of the parameters of the script %s, the signature is %s",
+			strings.Join(unknown, ", "),
+			saved.Name,
+			signature,
+		)
+	}
+
+	values, err := ParseNamedArguments(saved.Location, code, args)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the script %s with the signature %s: %w", saved.Name, signature, err)
+	}
+
+	return NewScript(code, values, saved.Location), nil
+}
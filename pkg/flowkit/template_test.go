@@ -0,0 +1,156 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit"
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+func TestNewTemplateScript(t *testing.T) {
+	template := config.Transaction{
+		Name:     "transfer",
+		Location: "transfer.cdc",
+		Parameters: []config.TransactionParameter{
+			{Name: "amount", Type: "UFix64"},
+		},
+	}
+
+	t.Run("Placeholders", func(t *testing.T) {
+		code := []byte(`transaction(to: Address) { prepare(signer: AuthAccount) { log(${amount}) } }`)
+
+		script, err := flowkit.NewTemplateScript(template, code, map[string]string{"amount": "10.0", "to": "01"})
+		require.NoError(t, err)
+
+		amount, _ := cadence.NewUFix64("10.0")
+		assert.Equal(t, "transaction(to: Address, amount: UFix64) { prepare(signer: AuthAccount) { log(amount) } }", string(script.Code()))
+		assert.Equal(t, []cadence.Value{cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1}), amount}, script.Args)
+		assert.Equal(t, "transfer.cdc", script.Location())
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		code := []byte(`transaction(amount: UFix64) {}`)
+
+		script, err := flowkit.NewTemplateScript(template, code, map[string]string{"amount": "1.5"})
+		require.NoError(t, err)
+		assert.Equal(t, string(code), string(script.Code()))
+		assert.Len(t, script.Args, 1)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		tests := map[string]struct {
+			code   string
+			params map[string]string
+		}{
+			"type of the placeholder to of the transaction transfer is not declared": {
+				code: `transaction { prepare(signer: AuthAccount) { log(${to}) } }`,
+			},
+			"missing parameter amount of the transaction transfer": {
+				code: `transaction(amount: UFix64) {}`,
+			},
+			"parameters from don't match any of the parameters [amount]": {
+				code:   `transaction(amount: UFix64) {}`,
+				params: map[string]string{"amount": "1.0", "from": "01"},
+			},
+			"argument amount has the type UFix64, but the parameter type is Int": {
+				code:   `transaction(amount: Int) {}`,
+				params: map[string]string{"amount": "1"},
+			},
+		}
+
+		for message, test := range tests {
+			_, err := flowkit.NewTemplateScript(template, []byte(test.code), test.params)
+			assert.ErrorContains(t, err, message)
+		}
+	})
+}
+
+func TestNewSavedScript(t *testing.T) {
+	saved := config.Script{
+		Name:     "get-balance",
+		Location: "balance.cdc",
+		Parameters: []config.ScriptParameter{
+			{Name: "addr", Type: "Address"},
+		},
+	}
+	code := []byte(`pub fun main(addr: Address, vault: String): UFix64 { return 1.0 }`)
+
+	t.Run("Signature", func(t *testing.T) {
+		parameters, signature, err := flowkit.ScriptSignature(saved.Location, code)
+		require.NoError(t, err)
+		assert.Equal(t, "main(addr: Address, vault: String): UFix64", signature)
+		assert.Equal(t, []flowkit.ScriptParameter{
+			{Name: "addr", Type: "Address"},
+			{Name: "vault", Type: "String"},
+		}, parameters)
+
+		_, signature, err = flowkit.ScriptSignature(saved.Location, []byte(`pub fun main() {}`))
+		require.NoError(t, err)
+		assert.Equal(t, "main()", signature)
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		script, err := flowkit.NewSavedScript(saved, code, map[string]string{"addr": "0xf8d6e0586b0a20c7", "vault": "flow"})
+		require.NoError(t, err)
+		assert.Equal(t, string(code), string(script.Code()))
+		assert.Equal(t, []cadence.Value{
+			cadence.NewAddress([8]byte{0xf8, 0xd6, 0xe0, 0x58, 0x6b, 0x0a, 0x20, 0xc7}),
+			cadence.String("flow"),
+		}, script.Args)
+		assert.Equal(t, "balance.cdc", script.Location())
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		tests := map[string]struct {
+			code   string
+			params map[string]string
+		}{
+			"missing parameter vault of the script get-balance, the signature is main(addr: Address, vault: String): UFix64": {
+				code:   string(code),
+				params: map[string]string{"addr": "01"},
+			},
+			"parameters owner don't match any of the parameters of the script get-balance, the signature is main(addr: Address, vault: String): UFix64": {
+				code:   string(code),
+				params: map[string]string{"addr": "01", "vault": "flow", "owner": "02"},
+			},
+			"parameter addr of the script get-balance is declared as Address, but the signature is main(addr: String)": {
+				code:   `pub fun main(addr: String) {}`,
+				params: map[string]string{"addr": "01"},
+			},
+			"invalid parameters of the script get-balance with the signature main(addr: Address, vault: String): UFix64": {
+				code:   string(code),
+				params: map[string]string{"addr": "not an address", "vault": "flow"},
+			},
+			"script balance.cdc doesn't declare a main function": {
+				code: `pub fun run() {}`,
+			},
+		}
+
+		for message, test := range tests {
+			_, err := flowkit.NewSavedScript(saved, []byte(test.code), test.params)
+			assert.ErrorContains(t, err, message)
+		}
+	})
+}
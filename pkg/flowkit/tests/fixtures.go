@@ -22,6 +22,7 @@ import (
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/onflow/flow-go-sdk/test"
 )
 
@@ -103,3 +104,47 @@ func NewAccountCreateResult(address flow.Address) *flow.TransactionResult {
 
 	return NewTransactionResult(events)
 }
+
+// NewAccountsCreateResult returns a result of a transaction creating an account
+// for each of the addresses with the public key at the same position.
+func NewAccountsCreateResult(addresses []flow.Address, keys []crypto.PublicKey) *flow.TransactionResult {
+	events := make([]flow.Event, 0, len(addresses)*2)
+	for i, address := range addresses {
+		keyBytes := make([]cadence.Value, 0)
+		for _, b := range keys[i].Encode() {
+			keyBytes = append(keyBytes, cadence.UInt8(b))
+		}
+
+		events = append(events, flow.Event{
+			Type:       flow.EventAccountCreated,
+			EventIndex: i * 2,
+			Value: cadence.Event{
+				EventType: cadence.NewEventType(common.NewStringLocation(nil, flow.EventAccountCreated), "", []cadence.Field{{
+					Identifier: "address",
+					Type:       cadence.AddressType{},
+				}}, nil),
+				Fields: []cadence.Value{
+					cadence.NewAddress(address),
+				},
+			},
+		}, flow.Event{
+			Type:       flow.EventAccountKeyAdded,
+			EventIndex: i*2 + 1,
+			Value: cadence.Event{
+				EventType: cadence.NewEventType(common.NewStringLocation(nil, flow.EventAccountKeyAdded), "", []cadence.Field{{
+					Identifier: "address",
+					Type:       cadence.AddressType{},
+				}, {
+					Identifier: "publicKey",
+					Type:       &cadence.StructType{},
+				}}, nil),
+				Fields: []cadence.Value{
+					cadence.NewAddress(address),
+					cadence.NewStruct([]cadence.Value{cadence.NewArray(keyBytes)}),
+				},
+			},
+		})
+	}
+
+	return NewTransactionResult(events)
+}
@@ -27,32 +27,42 @@ import (
 )
 
 const (
-	GetAccountFunc            = "GetAccount"
-	SendSignedTransactionFunc = "SendSignedTransaction"
-	GetCollectionFunc         = "GetCollection"
-	GetTransactionResultFunc  = "GetTransactionResult"
-	GetEventsFunc             = "GetEvents"
-	GetLatestBlockFunc        = "GetLatestBlock"
-	GetBlockByHeightFunc      = "GetBlockByHeight"
-	GetBlockByIDFunc          = "GetBlockByID"
-	ExecuteScriptFunc         = "ExecuteScript"
-	GetTransactionFunc        = "GetTransaction"
+	GetAccountFunc              = "GetAccount"
+	SendSignedTransactionFunc   = "SendSignedTransaction"
+	GetCollectionFunc           = "GetCollection"
+	GetTransactionResultFunc    = "GetTransactionResult"
+	GetEventsFunc               = "GetEvents"
+	GetLatestBlockFunc          = "GetLatestBlock"
+	GetLatestFinalizedBlockFunc = "GetLatestFinalizedBlock"
+	GetBlockByHeightFunc        = "GetBlockByHeight"
+	GetBlockByIDFunc            = "GetBlockByID"
+	ExecuteScriptFunc           = "ExecuteScript"
+	ExecuteScriptAtHeightFunc   = "ExecuteScriptAtHeight"
+	ExecuteScriptAtIDFunc       = "ExecuteScriptAtID"
+	GetTransactionFunc          = "GetTransaction"
+	GetChainIDFunc              = "GetChainID"
+	PingFunc                    = "Ping"
 )
 
 // go:generate
 
 type TestGateway struct {
-	Mock                  *mocks.Gateway
-	SendSignedTransaction *mock.Call
-	GetAccount            *mock.Call
-	GetCollection         *mock.Call
-	GetTransactionResult  *mock.Call
-	GetEvents             *mock.Call
-	GetLatestBlock        *mock.Call
-	GetBlockByHeight      *mock.Call
-	GetBlockByID          *mock.Call
-	ExecuteScript         *mock.Call
-	GetTransaction        *mock.Call
+	Mock                    *mocks.Gateway
+	SendSignedTransaction   *mock.Call
+	GetAccount              *mock.Call
+	GetCollection           *mock.Call
+	GetTransactionResult    *mock.Call
+	GetEvents               *mock.Call
+	GetLatestBlock          *mock.Call
+	GetLatestFinalizedBlock *mock.Call
+	GetBlockByHeight        *mock.Call
+	GetBlockByID            *mock.Call
+	ExecuteScript           *mock.Call
+	ExecuteScriptAtHeight   *mock.Call
+	ExecuteScriptAtID       *mock.Call
+	GetTransaction          *mock.Call
+	GetChainID              *mock.Call
+	Ping                    *mock.Call
 }
 
 func DefaultMockGateway() *TestGateway {
@@ -91,9 +101,24 @@ func DefaultMockGateway() *TestGateway {
 			mock.Anything,
 			mock.Anything,
 		),
-		GetBlockByHeight: m.On(GetBlockByHeightFunc, mock.Anything),
-		GetBlockByID:     m.On(GetBlockByIDFunc, mock.Anything),
-		GetLatestBlock:   m.On(GetLatestBlockFunc),
+		ExecuteScriptAtHeight: m.On(
+			ExecuteScriptAtHeightFunc,
+			mock.Anything,
+			mock.Anything,
+			mock.AnythingOfType("uint64"),
+		),
+		ExecuteScriptAtID: m.On(
+			ExecuteScriptAtIDFunc,
+			mock.Anything,
+			mock.Anything,
+			mock.AnythingOfType("flow.Identifier"),
+		),
+		GetBlockByHeight:        m.On(GetBlockByHeightFunc, mock.Anything),
+		GetBlockByID:            m.On(GetBlockByIDFunc, mock.Anything),
+		GetLatestBlock:          m.On(GetLatestBlockFunc),
+		GetLatestFinalizedBlock: m.On(GetLatestFinalizedBlockFunc),
+		GetChainID:              m.On(GetChainIDFunc),
+		Ping:                    m.On(PingFunc),
 	}
 
 	// default return values
@@ -110,11 +135,22 @@ func DefaultMockGateway() *TestGateway {
 		t.ExecuteScript.Return(cadence.MustConvertValue(""), nil)
 	})
 
+	t.ExecuteScriptAtHeight.Run(func(args mock.Arguments) {
+		t.ExecuteScriptAtHeight.Return(cadence.MustConvertValue(""), nil)
+	})
+
+	t.ExecuteScriptAtID.Run(func(args mock.Arguments) {
+		t.ExecuteScriptAtID.Return(cadence.MustConvertValue(""), nil)
+	})
+
 	t.GetTransaction.Return(NewTransaction(), nil)
 	t.GetCollection.Return(NewCollection(), nil)
 	t.GetTransactionResult.Return(NewTransactionResult(nil), nil)
 	t.GetEvents.Return([]flow.BlockEvents{}, nil)
 	t.GetLatestBlock.Return(NewBlock(), nil)
+	t.GetLatestFinalizedBlock.Return(NewBlock(), nil)
+	t.GetChainID.Return(flow.Emulator, nil)
+	t.Ping.Return(nil)
 	t.GetBlockByHeight.Return(NewBlock(), nil)
 	t.GetBlockByID.Return(NewBlock(), nil)
 
@@ -39,6 +39,52 @@ func (_m *Gateway) ExecuteScript(_a0 []byte, _a1 []cadence.Value) (cadence.Value
 	return r0, r1
 }
 
+// ExecuteScriptAtHeight provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Gateway) ExecuteScriptAtHeight(_a0 []byte, _a1 []cadence.Value, _a2 uint64) (cadence.Value, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 cadence.Value
+	if rf, ok := ret.Get(0).(func([]byte, []cadence.Value, uint64) cadence.Value); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cadence.Value)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, []cadence.Value, uint64) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExecuteScriptAtID provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Gateway) ExecuteScriptAtID(_a0 []byte, _a1 []cadence.Value, _a2 flow.Identifier) (cadence.Value, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 cadence.Value
+	if rf, ok := ret.Get(0).(func([]byte, []cadence.Value, flow.Identifier) cadence.Value); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cadence.Value)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, []cadence.Value, flow.Identifier) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccount provides a mock function with given fields: _a0
 func (_m *Gateway) GetAccount(_a0 flow.Address) (*flow.Account, error) {
 	ret := _m.Called(_a0)
@@ -108,6 +154,27 @@ func (_m *Gateway) GetBlockByID(_a0 flow.Identifier) (*flow.Block, error) {
 	return r0, r1
 }
 
+// GetChainID provides a mock function with given fields:
+func (_m *Gateway) GetChainID() (flow.ChainID, error) {
+	ret := _m.Called()
+
+	var r0 flow.ChainID
+	if rf, ok := ret.Get(0).(func() flow.ChainID); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(flow.ChainID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCollection provides a mock function with given fields: _a0
 func (_m *Gateway) GetCollection(_a0 flow.Identifier) (*flow.Collection, error) {
 	ret := _m.Called(_a0)
@@ -177,6 +244,29 @@ func (_m *Gateway) GetLatestBlock() (*flow.Block, error) {
 	return r0, r1
 }
 
+// GetLatestFinalizedBlock provides a mock function with given fields:
+func (_m *Gateway) GetLatestFinalizedBlock() (*flow.Block, error) {
+	ret := _m.Called()
+
+	var r0 *flow.Block
+	if rf, ok := ret.Get(0).(func() *flow.Block); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.Block)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLatestProtocolStateSnapshot provides a mock function with given fields:
 func (_m *Gateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
 	ret := _m.Called()
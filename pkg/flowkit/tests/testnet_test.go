@@ -155,7 +155,7 @@ func Test_Testnet_ProjectDeploy(t *testing.T) {
 		},
 	})
 
-	contracts, err := srv.Project.Deploy(testnet, true)
+	contracts, err := srv.Project.Deploy(testnet, services.DeployOptions{Update: true})
 	assert.NoError(t, err)
 	assert.Len(t, contracts, 3)
 	assert.Equal(t, ContractA.Name, contracts[0].Name)
@@ -166,7 +166,7 @@ func Test_Testnet_ProjectDeploy(t *testing.T) {
 	ContractA.Source = []byte(`pub contract ContractA { init() {} }`)
 	_ = afero.WriteFile(mockFs, ContractA.Filename, ContractA.Source, 0644)
 
-	contracts, err = srv.Project.Deploy(testnet, true)
+	contracts, err = srv.Project.Deploy(testnet, services.DeployOptions{Update: true})
 	assert.NoError(t, err)
 	assert.Len(t, contracts, 3)
 	assert.Equal(t, ContractA.Name, contracts[0].Name)
@@ -28,6 +28,7 @@ import (
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/onflow/flow-go-sdk/templates"
 )
 
@@ -93,6 +94,41 @@ func NewRemoveAccountContractTransaction(signer *Account, name string) (*Transac
 	)
 }
 
+// NewAddAccountKeyTransaction creates new transaction adding the key to the account.
+func NewAddAccountKeyTransaction(signer *Account, key *flow.AccountKey) (*Transaction, error) {
+	template, err := templates.AddAccountKey(signer.Address(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionFromTemplate(template, signer)
+}
+
+// revokeAccountKeyTemplate revokes the account key, the key remains on the account but can't be used for signing.
+const revokeAccountKeyTemplate = `
+transaction(keyIndex: Int) {
+	prepare(signer: AuthAccount) {
+		if signer.keys.revoke(keyIndex: keyIndex) == nil {
+			panic("account key with the index doesn't exist")
+		}
+	}
+}
+`
+
+// NewRevokeAccountKeyTransaction creates new transaction revoking the account key with the index.
+func NewRevokeAccountKeyTransaction(signer *Account, keyIndex int) (*Transaction, error) {
+	template := flow.NewTransaction().
+		SetScript([]byte(revokeAccountKeyTemplate)).
+		AddAuthorizer(signer.Address())
+
+	err := template.AddArgument(cadence.NewInt(keyIndex))
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionFromTemplate(template, signer)
+}
+
 func addAccountContractWithArgs(
 	signer *Account,
 	contract templates.Contract,
@@ -151,6 +187,60 @@ func NewCreateAccountTransaction(
 	return newTransactionFromTemplate(template, signer)
 }
 
+// createAccountsTemplate creates an account with a full weight key for each of the public keys.
+const createAccountsTemplate = `
+transaction(publicKeys: [String]) {
+	prepare(signer: AuthAccount) {
+		for publicKey in publicKeys {
+			let account = AuthAccount(payer: signer)
+			account.keys.add(
+				publicKey: PublicKey(
+					publicKey: publicKey.decodeHex(),
+					signatureAlgorithm: SignatureAlgorithm.%s
+				),
+				hashAlgorithm: HashAlgorithm.%s,
+				weight: 1000.0
+			)
+		}
+	}
+}
+`
+
+// NewCreateAccountsTransaction creates new transaction creating an account for each of the public keys.
+//
+// All the keys must use the same signature and hash algorithm.
+func NewCreateAccountsTransaction(
+	signer *Account,
+	publicKeys []crypto.PublicKey,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (*Transaction, error) {
+	accountKey := flow.AccountKey{SigAlgo: sigAlgo, HashAlgo: hashAlgo, Weight: flow.AccountKeyWeightThreshold}
+	if err := accountKey.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid account key: %w", err)
+	}
+
+	keys := make([]cadence.Value, 0, len(publicKeys))
+	for _, key := range publicKeys {
+		if key.Algorithm() != sigAlgo {
+			return nil, fmt.Errorf("public key %s doesn't use the %s signature algorithm", key, sigAlgo)
+		}
+
+		keys = append(keys, cadence.String(hex.EncodeToString(key.Encode())))
+	}
+
+	template := flow.NewTransaction().
+		SetScript([]byte(fmt.Sprintf(createAccountsTemplate, sigAlgo, hashAlgo))).
+		AddAuthorizer(signer.Address())
+
+	err := template.AddArgument(cadence.NewArray(keys))
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionFromTemplate(template, signer)
+}
+
 func newTransactionFromTemplate(templateTx *flow.Transaction, signer *Account) (*Transaction, error) {
 	tx := &Transaction{tx: templateTx}
 
@@ -164,9 +254,22 @@ func newTransactionFromTemplate(templateTx *flow.Transaction, signer *Account) (
 	return tx, nil
 }
 
+// SigningRole is the role of an account signing a transaction, the payer signs the envelope
+// and the proposer and the authorizers sign the payload.
+type SigningRole string
+
+const (
+	// SigningRoleAuto signs the envelope if the signer is the payer, and the payload otherwise.
+	SigningRoleAuto       SigningRole = ""
+	SigningRoleProposer   SigningRole = "proposer"
+	SigningRoleAuthorizer SigningRole = "authorizer"
+	SigningRolePayer      SigningRole = "payer"
+)
+
 // Transaction builder of flow transactions.
 type Transaction struct {
 	signer   *Account
+	role     SigningRole
 	proposer *flow.Account
 	tx       *flow.Transaction
 }
@@ -212,6 +315,87 @@ func (t *Transaction) SetSigner(account *Account) error {
 	return nil
 }
 
+// SetSignerWithRole sets the signer for transaction, which must have the role in the transaction.
+//
+// The payer can only sign with the payer role, as the payer signature covers the other roles of the payer.
+func (t *Transaction) SetSignerWithRole(account *Account, role SigningRole) error {
+	err := t.SetSigner(account)
+	if err != nil {
+		return err
+	}
+
+	address := account.Address()
+	switch role {
+	case SigningRoleAuto:
+	case SigningRolePayer:
+		if address != t.tx.Payer {
+			return fmt.Errorf("account 0x%s is not the payer of the transaction, the payer is 0x%s", address, t.tx.Payer)
+		}
+	case SigningRoleProposer, SigningRoleAuthorizer:
+		if role == SigningRoleProposer && address != t.tx.ProposalKey.Address {
+			return fmt.Errorf(
+				"account 0x%s is not the proposer of the transaction, the proposer is 0x%s",
+				address,
+				t.tx.ProposalKey.Address,
+			)
+		}
+		if role == SigningRoleAuthorizer && !t.authorizersContains(address) {
+			return fmt.Errorf(
+				"account 0x%s is not an authorizer of the transaction, the authorizers are %s",
+				address,
+				t.tx.Authorizers,
+			)
+		}
+		if address == t.tx.Payer {
+			return fmt.Errorf("account 0x%s is the payer of the transaction and must sign with the payer role", address)
+		}
+	default:
+		return fmt.Errorf("invalid signing role %s, valid roles are proposer, authorizer and payer", role)
+	}
+
+	t.role = role
+	return nil
+}
+
+// MissingSignatures returns the addresses that haven't signed the transaction yet.
+//
+// The payer must sign the envelope, and the proposer and the authorizers must sign the payload
+// unless they are also the payer.
+func (t *Transaction) MissingSignatures() []flow.Address {
+	signed := func(signatures []flow.TransactionSignature, address flow.Address) bool {
+		for _, sig := range signatures {
+			if sig.Address == address {
+				return true
+			}
+		}
+		return false
+	}
+
+	missing := make([]flow.Address, 0)
+	required := append([]flow.Address{t.tx.ProposalKey.Address}, t.tx.Authorizers...)
+	for _, address := range required {
+		if address == t.tx.Payer || signed(t.tx.PayloadSignatures, address) || containsAddress(missing, address) {
+			continue
+		}
+		missing = append(missing, address)
+	}
+
+	if !signed(t.tx.EnvelopeSignatures, t.tx.Payer) {
+		missing = append(missing, t.tx.Payer)
+	}
+
+	return missing
+}
+
+func containsAddress(addresses []flow.Address, address flow.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
 // validSigner checks whether the signer is valid for transaction
 func (t *Transaction) validSigner(s flow.Address) bool {
 	return t.tx.ProposalKey.Address == s ||
@@ -221,23 +405,24 @@ func (t *Transaction) validSigner(s flow.Address) bool {
 
 // authorizersContains checks whether address is in the authorizer list
 func (t *Transaction) authorizersContains(address flow.Address) bool {
-	for _, a := range t.tx.Authorizers {
-		if address == a {
-			return true
-		}
-	}
-
-	return false
+	return containsAddress(t.tx.Authorizers, address)
 }
 
 // SetProposer sets the proposer for transaction.
+//
+// The sequence number of the proposal key is the current sequence number of the key on the account,
+// so the proposer account must be fetched from the network when the transaction is built.
 func (t *Transaction) SetProposer(proposer *flow.Account, keyIndex int) error {
-	if len(proposer.Keys) <= keyIndex {
+	if keyIndex < 0 || len(proposer.Keys) <= keyIndex {
 		return fmt.Errorf("failed to retrieve proposer key at index %d", keyIndex)
 	}
 
-	t.proposer = proposer
 	proposerKey := proposer.Keys[keyIndex]
+	if proposerKey.Revoked {
+		return fmt.Errorf("proposer key at index %d is revoked", keyIndex)
+	}
+
+	t.proposer = proposer
 
 	t.tx.SetProposalKey(
 		proposer.Address,
@@ -323,30 +508,82 @@ func (t *Transaction) AddAuthorizers(authorizers []flow.Address) (*Transaction,
 	return t, nil
 }
 
+// RequiredAuthorizers returns the number of authorizers required by the prepare block of the transaction code.
+func RequiredAuthorizers(code []byte) (int, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return 0, err
+	}
+
+	declarations := program.TransactionDeclarations()
+	if len(declarations) != 1 {
+		return 0, fmt.Errorf("can only support one transaction declaration per file, found %d", len(declarations))
+	}
+
+	if declarations[0].Prepare == nil {
+		return 0, nil
+	}
+
+	return len(declarations[0].Prepare.FunctionDeclaration.ParameterList.Parameters), nil
+}
+
 // Sign signs transaction using signer account.
+//
+// If the signer key doesn't have the full weight, the transaction is also signed with the other
+// keys of the account until the signatures reach the weight threshold.
 func (t *Transaction) Sign() (*Transaction, error) {
-	keyIndex := t.signer.Key().Index()
-	signer, err := t.signer.Key().Signer(context.Background())
-	if err != nil {
-		return nil, err
+	// the envelope signature covers the payload signatures, so they can't be added once the envelope is signed
+	if !t.shouldSignEnvelope() && len(t.tx.EnvelopeSignatures) > 0 {
+		return nil, fmt.Errorf("transaction is already signed by the payer, the payload must be signed before the envelope")
 	}
 
-	if t.shouldSignEnvelope() {
-		err = t.tx.SignEnvelope(t.signer.address, keyIndex, signer)
+	for _, key := range t.signingKeys() {
+		signer, err := key.Signer(context.Background())
 		if err != nil {
-			return nil, fmt.Errorf("failed to sign transaction: %s", err)
+			return nil, err
 		}
-	} else {
-		err = t.tx.SignPayload(t.signer.address, keyIndex, signer)
-		if err != nil {
-			return nil, fmt.Errorf("failed to sign transaction: %s", err)
+
+		if t.shouldSignEnvelope() {
+			err = t.tx.SignEnvelope(t.signer.Address(), key.Index(), signer)
+			if err != nil {
+				return nil, fmt.Errorf("failed to sign transaction: %s", err)
+			}
+		} else {
+			err = t.tx.SignPayload(t.signer.Address(), key.Index(), signer)
+			if err != nil {
+				return nil, fmt.Errorf("failed to sign transaction: %s", err)
+			}
 		}
 	}
 
 	return t, nil
 }
 
+// signingKeys returns the signer keys needed to reach the signature weight threshold, revoked keys are skipped.
+func (t *Transaction) signingKeys() []AccountKey {
+	keys := make([]AccountKey, 0)
+
+	weight := 0
+	for _, key := range t.signer.Keys() {
+		if key.Revoked() {
+			continue
+		}
+
+		keys = append(keys, key)
+		weight += key.Weight()
+		if weight >= flow.AccountKeyWeightThreshold {
+			return keys
+		}
+	}
+
+	return keys
+}
+
 // shouldSignEnvelope checks if signer should sign envelope or payload
 func (t *Transaction) shouldSignEnvelope() bool {
-	return t.signer.address == t.tx.Payer
+	if t.role != SigningRoleAuto {
+		return t.role == SigningRolePayer
+	}
+
+	return t.signer.Address() == t.tx.Payer
 }
@@ -0,0 +1,212 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package flowkit
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/pkg/flowkit/config"
+)
+
+func newWeightedKey(t *testing.T, index int, weight int, privateKey string) AccountKey {
+	pk, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, privateKey)
+	require.NoError(t, err)
+
+	key, err := NewAccountKey(config.AccountKey{
+		Type:       config.KeyTypeHex,
+		Index:      index,
+		SigAlgo:    crypto.ECDSA_P256,
+		HashAlgo:   crypto.SHA3_256,
+		Weight:     weight,
+		PrivateKey: pk,
+	})
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestTransactionSignWeightedKeys(t *testing.T) {
+	address := flow.HexToAddress("01cf0e2f2f715450")
+
+	account := NewAccount("alice").
+		SetAddress(address).
+		SetKey(newWeightedKey(t, 0, 500, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")).
+		AddKey(newWeightedKey(t, 1, 500, "1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")).
+		AddKey(newWeightedKey(t, 2, 1000, "2272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"))
+
+	sign := func(t *testing.T, signer *Account) []flow.TransactionSignature {
+		tx := NewTransaction()
+		tx.FlowTransaction().SetScript([]byte(`transaction {}`))
+		tx.FlowTransaction().SetProposalKey(address, signer.Key().Index(), 0)
+		tx.SetPayer(address)
+
+		require.NoError(t, tx.SetSigner(signer))
+		tx, err := tx.Sign()
+		require.NoError(t, err)
+
+		return tx.FlowTransaction().EnvelopeSignatures
+	}
+
+	t.Run("Aggregate partial weights", func(t *testing.T) {
+		signatures := sign(t, account)
+		require.Len(t, signatures, 2)
+		assert.Equal(t, 0, signatures[0].KeyIndex)
+		assert.Equal(t, 1, signatures[1].KeyIndex)
+	})
+
+	t.Run("Select full weight key", func(t *testing.T) {
+		signer, err := account.ForKeyIndex(2)
+		require.NoError(t, err)
+
+		signatures := sign(t, signer)
+		require.Len(t, signatures, 1)
+		assert.Equal(t, 2, signatures[0].KeyIndex)
+	})
+
+	t.Run("Select partial weight key", func(t *testing.T) {
+		signer, err := account.ForKeyIndex(1)
+		require.NoError(t, err)
+
+		signatures := sign(t, signer)
+		require.Len(t, signatures, 2)
+		assert.ElementsMatch(t, []int{1, 0}, []int{signatures[0].KeyIndex, signatures[1].KeyIndex})
+	})
+
+	t.Run("Fail missing key", func(t *testing.T) {
+		_, err := account.ForKeyIndex(3)
+		assert.EqualError(t, err, "account alice doesn't have a key with index 3")
+	})
+
+	t.Run("Skip revoked key", func(t *testing.T) {
+		account := NewAccount("bob").
+			SetAddress(address).
+			SetKey(newWeightedKey(t, 0, 500, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")).
+			AddKey(newWeightedKey(t, 1, 500, "1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")).
+			AddKey(newWeightedKey(t, 2, 500, "2272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"))
+
+		require.NoError(t, account.RevokeKey(1))
+		assert.True(t, account.Keys()[1].Revoked())
+		assert.True(t, account.Keys()[1].ToConfig().Revoked)
+
+		signatures := sign(t, account)
+		require.Len(t, signatures, 2)
+		assert.Equal(t, 0, signatures[0].KeyIndex)
+		assert.Equal(t, 2, signatures[1].KeyIndex)
+
+		assert.EqualError(t, account.RevokeKey(3), "account bob doesn't have a key with index 3")
+	})
+}
+
+func TestTransactionMultipleSigners(t *testing.T) {
+	newSigner := func(name string, address string, privateKey string) *Account {
+		return NewAccount(name).
+			SetAddress(flow.HexToAddress(address)).
+			SetKey(newWeightedKey(t, 0, 1000, privateKey))
+	}
+
+	alice := newSigner("alice", "01cf0e2f2f715450", "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+	bob := newSigner("bob", "179b6b1cb6755e31", "1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+	carol := newSigner("carol", "f3fcd2c1a78f5eee", "2272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+
+	// alice proposes, alice and carol authorize and bob pays
+	build := func(t *testing.T) []byte {
+		tx := NewTransaction()
+		tx.FlowTransaction().
+			SetScript([]byte(`transaction { prepare(a: AuthAccount, c: AuthAccount) {} }`)).
+			SetProposalKey(alice.Address(), 0, 7).
+			AddAuthorizer(alice.Address()).
+			AddAuthorizer(carol.Address())
+		tx.SetPayer(bob.Address())
+
+		return []byte(hex.EncodeToString(tx.FlowTransaction().Encode()))
+	}
+
+	sign := func(t *testing.T, payload []byte, signer *Account, role SigningRole) ([]byte, error) {
+		tx, err := NewTransactionFromPayload(payload)
+		require.NoError(t, err)
+
+		if err := tx.SetSignerWithRole(signer, role); err != nil {
+			return nil, err
+		}
+
+		tx, err = tx.Sign()
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(hex.EncodeToString(tx.FlowTransaction().Encode())), nil
+	}
+
+	t.Run("Sign with all roles", func(t *testing.T) {
+		payload := build(t)
+
+		payload, err := sign(t, payload, alice, SigningRoleAuthorizer)
+		require.NoError(t, err)
+		payload, err = sign(t, payload, carol, SigningRoleAuthorizer)
+		require.NoError(t, err)
+
+		tx, err := NewTransactionFromPayload(payload)
+		require.NoError(t, err)
+		assert.Equal(t, []flow.Address{bob.Address()}, tx.MissingSignatures())
+
+		payload, err = sign(t, payload, bob, SigningRolePayer)
+		require.NoError(t, err)
+
+		tx, err = NewTransactionFromPayload(payload)
+		require.NoError(t, err)
+		assert.Empty(t, tx.MissingSignatures())
+		assert.Len(t, tx.FlowTransaction().PayloadSignatures, 2)
+		assert.Len(t, tx.FlowTransaction().EnvelopeSignatures, 1)
+		assert.Equal(t, uint64(7), tx.FlowTransaction().ProposalKey.SequenceNumber)
+	})
+
+	t.Run("Missing signatures", func(t *testing.T) {
+		tx, err := NewTransactionFromPayload(build(t))
+		require.NoError(t, err)
+		assert.Equal(t, []flow.Address{alice.Address(), carol.Address(), bob.Address()}, tx.MissingSignatures())
+	})
+
+	t.Run("Fail signing with a role of another account", func(t *testing.T) {
+		_, err := sign(t, build(t), alice, SigningRolePayer)
+		assert.EqualError(t, err, "account 0x01cf0e2f2f715450 is not the payer of the transaction, the payer is 0x179b6b1cb6755e31")
+
+		_, err = sign(t, build(t), bob, SigningRoleAuthorizer)
+		assert.EqualError(t, err, "account 0x179b6b1cb6755e31 is not an authorizer of the transaction, the authorizers are [01cf0e2f2f715450 f3fcd2c1a78f5eee]")
+
+		_, err = sign(t, build(t), carol, SigningRoleProposer)
+		assert.EqualError(t, err, "account 0xf3fcd2c1a78f5eee is not the proposer of the transaction, the proposer is 0x01cf0e2f2f715450")
+
+		_, err = sign(t, build(t), carol, "owner")
+		assert.EqualError(t, err, "invalid signing role owner, valid roles are proposer, authorizer and payer")
+	})
+
+	t.Run("Fail signing the payload after the envelope", func(t *testing.T) {
+		payload, err := sign(t, build(t), bob, SigningRolePayer)
+		require.NoError(t, err)
+
+		_, err = sign(t, payload, carol, SigningRoleAuthorizer)
+		assert.EqualError(t, err, "transaction is already signed by the payer, the payload must be signed before the envelope")
+	})
+}
@@ -156,6 +156,11 @@ func TestnetFaucetURL(publicKey string, sigAlgo crypto.SignatureAlgorithm) strin
 	return link
 }
 
+// TestnetFaucetFundURL returns the testnet faucet page funding the account.
+func TestnetFaucetFundURL(address flow.Address) string {
+	return fmt.Sprintf("%sfund-account?address=0x%s", TestnetFaucetHost, address.Hex())
+}
+
 func MainnetFlowPortURL(publicKey string) string {
 	return fmt.Sprintf("%s%s", FlowPortUrl, strings.TrimPrefix(publicKey, "0x"))
 }
@@ -24,6 +24,9 @@ import (
 	"github.com/onflow/cadence"
 )
 
+// NewStakingInfoFromValue returns the fields of each struct in the array value.
+//
+// Deprecated: use NewNodeInfosFromValue or NewDelegatorInfosFromValue, which decode the fields to typed structs.
 func NewStakingInfoFromValue(value cadence.Value) ([]map[string]interface{}, error) {
 	stakingInfo := make([]map[string]interface{}, 0)
 	arrayValue, ok := value.(cadence.Array)
@@ -54,3 +57,267 @@ func NewStakingInfoFromValue(value cadence.Value) ([]map[string]interface{}, err
 
 	return stakingInfo, nil
 }
+
+// NodeInfo is the staking information of a node, as FlowIDTableStaking.NodeInfo.
+type NodeInfo struct {
+	ID                       string
+	Role                     uint8
+	NetworkingAddress        string
+	NetworkingKey            string
+	StakingKey               string
+	InitialWeight            uint64
+	TokensCommitted          cadence.UFix64
+	TokensStaked             cadence.UFix64
+	TokensUnstaking          cadence.UFix64
+	TokensUnstaked           cadence.UFix64
+	TokensRewarded           cadence.UFix64
+	TokensRequestedToUnstake cadence.UFix64
+	Delegators               []uint32
+	TotalStake               cadence.UFix64 // stake of the node including the delegators, not part of the node info
+}
+
+// nodeRoles are the names of the node roles, indexed by the role number.
+var nodeRoles = []string{"", "collection", "consensus", "execution", "verification", "access"}
+
+// RoleName returns the name of the node role.
+func (n NodeInfo) RoleName() string {
+	if int(n.Role) < len(nodeRoles) && n.Role != 0 {
+		return nodeRoles[n.Role]
+	}
+	return fmt.Sprintf("unknown (%d)", n.Role)
+}
+
+// DelegatorInfo is the staking information of a delegator, as FlowIDTableStaking.DelegatorInfo.
+type DelegatorInfo struct {
+	ID                       uint32
+	NodeID                   string
+	TokensCommitted          cadence.UFix64
+	TokensStaked             cadence.UFix64
+	TokensUnstaking          cadence.UFix64
+	TokensUnstaked           cadence.UFix64
+	TokensRewarded           cadence.UFix64
+	TokensRequestedToUnstake cadence.UFix64
+}
+
+// NewNodeInfosFromValue decodes the array of FlowIDTableStaking.NodeInfo structs.
+func NewNodeInfosFromValue(value cadence.Value) ([]NodeInfo, error) {
+	values, err := structArray(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node info: %w", err)
+	}
+
+	nodes := make([]NodeInfo, 0, len(values))
+	for _, v := range values {
+		d := newStructDecoder(v)
+		node := NodeInfo{
+			ID:                       d.string("id"),
+			Role:                     uint8(d.uint("role")),
+			NetworkingAddress:        d.string("networkingAddress"),
+			NetworkingKey:            d.string("networkingKey"),
+			StakingKey:               d.string("stakingKey"),
+			InitialWeight:            d.uint("initialWeight"),
+			TokensCommitted:          d.ufix64("tokensCommitted"),
+			TokensStaked:             d.ufix64("tokensStaked"),
+			TokensUnstaking:          d.ufix64("tokensUnstaking"),
+			TokensUnstaked:           d.ufix64("tokensUnstaked"),
+			TokensRewarded:           d.ufix64("tokensRewarded"),
+			TokensRequestedToUnstake: d.ufix64("tokensRequestedToUnstake"),
+		}
+		for _, delegator := range d.array("delegators") {
+			node.Delegators = append(node.Delegators, uint32(d.number("delegators", delegator)))
+		}
+		if d.err != nil {
+			return nil, fmt.Errorf("invalid node info: %w", d.err)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// NewDelegatorInfosFromValue decodes the array of FlowIDTableStaking.DelegatorInfo structs.
+func NewDelegatorInfosFromValue(value cadence.Value) ([]DelegatorInfo, error) {
+	values, err := structArray(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delegator info: %w", err)
+	}
+
+	delegators := make([]DelegatorInfo, 0, len(values))
+	for _, v := range values {
+		d := newStructDecoder(v)
+		delegator := DelegatorInfo{
+			ID:                       uint32(d.uint("id")),
+			NodeID:                   d.string("nodeID"),
+			TokensCommitted:          d.ufix64("tokensCommitted"),
+			TokensStaked:             d.ufix64("tokensStaked"),
+			TokensUnstaking:          d.ufix64("tokensUnstaking"),
+			TokensUnstaked:           d.ufix64("tokensUnstaked"),
+			TokensRewarded:           d.ufix64("tokensRewarded"),
+			TokensRequestedToUnstake: d.ufix64("tokensRequestedToUnstake"),
+		}
+		if d.err != nil {
+			return nil, fmt.Errorf("invalid delegator info: %w", d.err)
+		}
+
+		delegators = append(delegators, delegator)
+	}
+
+	return delegators, nil
+}
+
+func structArray(value cadence.Value) ([]cadence.Struct, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("value must be an array")
+	}
+
+	structs := make([]cadence.Struct, 0, len(array.Values))
+	for _, v := range array.Values {
+		s, ok := v.(cadence.Struct)
+		if !ok || s.StructType == nil {
+			return nil, fmt.Errorf("value must be an array of structs")
+		}
+		structs = append(structs, s)
+	}
+
+	return structs, nil
+}
+
+// structDecoder decodes the fields of a struct by their names, missing fields are decoded to zero values.
+//
+// The first error is kept, so the fields can be decoded without checking each of them.
+type structDecoder struct {
+	fields map[string]cadence.Value
+	err    error
+}
+
+func newStructDecoder(value cadence.Struct) *structDecoder {
+	fields := make(map[string]cadence.Value)
+	for i, field := range value.StructType.Fields {
+		if i < len(value.Fields) {
+			fields[field.Identifier] = value.Fields[i]
+		}
+	}
+
+	return &structDecoder{fields: fields}
+}
+
+func (d *structDecoder) fail(name string, value cadence.Value) {
+	if d.err == nil {
+		d.err = fmt.Errorf("unexpected value %s of field %s", value, name)
+	}
+}
+
+func (d *structDecoder) string(name string) string {
+	value, ok := d.fields[name]
+	if !ok {
+		return ""
+	}
+	s, ok := value.(cadence.String)
+	if !ok {
+		d.fail(name, value)
+	}
+	return string(s)
+}
+
+func (d *structDecoder) ufix64(name string) cadence.UFix64 {
+	value, ok := d.fields[name]
+	if !ok {
+		return 0
+	}
+	u, ok := value.(cadence.UFix64)
+	if !ok {
+		d.fail(name, value)
+	}
+	return u
+}
+
+func (d *structDecoder) uint(name string) uint64 {
+	value, ok := d.fields[name]
+	if !ok {
+		return 0
+	}
+	return d.number(name, value)
+}
+
+func (d *structDecoder) number(name string, value cadence.Value) uint64 {
+	switch v := value.(type) {
+	case cadence.UInt8:
+		return uint64(v)
+	case cadence.UInt16:
+		return uint64(v)
+	case cadence.UInt32:
+		return uint64(v)
+	case cadence.UInt64:
+		return uint64(v)
+	}
+	d.fail(name, value)
+	return 0
+}
+
+func (d *structDecoder) array(name string) []cadence.Value {
+	value, ok := d.fields[name]
+	if !ok {
+		return nil
+	}
+	a, ok := value.(cadence.Array)
+	if !ok {
+		d.fail(name, value)
+	}
+	return a.Values
+}
+
+// DecodeValue decodes the Cadence value to plain values which can be encoded to JSON,
+// composite values are decoded to maps of their fields.
+func DecodeValue(value cadence.Value) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case cadence.Optional:
+		return DecodeValue(v.Value)
+	case cadence.Bool:
+		return bool(v)
+	case cadence.String:
+		return string(v)
+	case cadence.Array:
+		values := make([]interface{}, 0, len(v.Values))
+		for _, value := range v.Values {
+			values = append(values, DecodeValue(value))
+		}
+		return values
+	case cadence.Dictionary:
+		pairs := make(map[string]interface{}, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			key := pair.Key.String()
+			if s, ok := pair.Key.(cadence.String); ok {
+				key = string(s)
+			}
+			pairs[key] = DecodeValue(pair.Value)
+		}
+		return pairs
+	case cadence.Struct:
+		return decodeFields(v.StructType, v.Fields)
+	case cadence.Resource:
+		return decodeFields(v.ResourceType, v.Fields)
+	case cadence.Event:
+		return decodeFields(v.EventType, v.Fields)
+	case cadence.Contract:
+		return decodeFields(v.ContractType, v.Fields)
+	case cadence.Enum:
+		return decodeFields(v.EnumType, v.Fields)
+	default:
+		return v.String()
+	}
+}
+
+func decodeFields(compositeType cadence.CompositeType, values []cadence.Value) map[string]interface{} {
+	fields := make(map[string]interface{}, len(values))
+	for i, field := range compositeType.CompositeFields() {
+		if i < len(values) {
+			fields[field.Identifier] = DecodeValue(values[i])
+		}
+	}
+
+	return fields
+}